@@ -24,6 +24,7 @@ func experimentalCommands() []*cobra.Command {
 		experimentalHatchery(),
 		experimentalWorkflow(),
 		experimentalWorkflowTemplate(),
+		experimentalBulk(),
 	}
 }
 
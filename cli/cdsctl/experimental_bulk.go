@@ -0,0 +1,489 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rockbears/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var experimentalBulkCmd = cli.Command{
+	Name:  "bulk",
+	Short: "CDS Experimental bulk commands on projects",
+	Long: `Apply a YAML manifest on many projects in one batch.
+
+The manifest lists the projects and the variable sets items, concurrency rules, notifications,
+run retention and repositories that must exist on each of them:
+
+    projects: [PROJ1, PROJ2]
+    variablesets:
+      - name: my-vars
+        items:
+          - {name: url, type: string, value: https://my-url}
+    concurrencies:
+      - {name: deploy, order: oldest_first, pool: 1}
+    notifications:
+      - name: my-hook
+        webhook_url: https://my-hook
+        filters:
+          runs: {event: [WorkflowRunEnded]}
+    retention:
+      default_retention: {duration_in_days: 30, count: 20}
+    repositories:
+      - {vcs: github, name: my-org/my-repo}
+
+Apply is idempotent: it creates the missing entities and updates the ones that differ from the manifest,
+entities missing from the manifest are left untouched. Secret values and notification auth headers
+can't be read back from CDS: they are always updated, and updating a notification that has no auth
+headers in the manifest removes its headers. The type of an existing variable set item is never
+changed: the item must be deleted first.`,
+}
+
+func experimentalBulk() *cobra.Command {
+	planCmd := cli.NewListCommand(bulkPlanCmd, bulkPlanFunc, nil)
+	// The plan of the other projects is displayed before exiting on error
+	planCmd.PostRun = func(*cobra.Command, []string) {
+		if bulkPlanFailed {
+			cli.OSExit(1)
+		}
+	}
+	return cli.NewCommand(experimentalBulkCmd, nil, []*cobra.Command{
+		planCmd,
+		cli.NewCommand(bulkApplyCmd, bulkApplyFunc, nil),
+	})
+}
+
+var bulkFlags = []cli.Flag{
+	{
+		Name:    "parallel",
+		Usage:   "Number of projects processed in parallel",
+		Default: "5",
+	},
+}
+
+var bulkPlanCmd = cli.Command{
+	Name:    "plan",
+	Aliases: []string{"diff"},
+	Short:   "Show the changes a manifest would apply on each project",
+	Long:    "Show the changes a manifest would apply on each project. A project whose changes can't be computed is shown with the error action, and the command exits with code 1.",
+	Example: "cdsctl experimental bulk plan manifest.yml",
+	Args: []cli.Arg{
+		{Name: "manifest"},
+	},
+	Flags: bulkFlags,
+}
+
+var bulkApplyCmd = cli.Command{
+	Name:    "apply",
+	Short:   "Apply a manifest on each project",
+	Long:    "Apply a manifest on each project and print the applied changes. The command exits with code 1 if a change can't be computed or applied.",
+	Example: "cdsctl experimental bulk apply manifest.yml --parallel 10",
+	Args: []cli.Arg{
+		{Name: "manifest"},
+	},
+	Flags: bulkFlags,
+}
+
+const (
+	bulkActionCreate   = "create"
+	bulkActionUpdate   = "update"
+	bulkActionConflict = "conflict"
+	bulkActionError    = "error"
+)
+
+// bulkPlanFailed is set when the changes of a project can't be computed by the plan command.
+var bulkPlanFailed bool
+
+type bulkManifest struct {
+	Projects      []string                  `json:"projects"`
+	VariableSets  []sdk.ProjectVariableSet  `json:"variablesets,omitempty"`
+	Concurrencies []sdk.ProjectConcurrency  `json:"concurrencies,omitempty"`
+	Notifications []sdk.ProjectNotification `json:"notifications,omitempty"`
+	Retention     *sdk.Retentions           `json:"retention,omitempty"`
+	Repositories  []bulkRepository          `json:"repositories,omitempty"`
+}
+
+type bulkRepository struct {
+	VCSServer string `json:"vcs"`
+	Name      string `json:"name"`
+}
+
+func (m *bulkManifest) check() error {
+	if len(m.Projects) == 0 {
+		return cli.NewError("no project in manifest")
+	}
+	for _, vs := range m.VariableSets {
+		if vs.Name == "" {
+			return cli.NewError("missing variable set name")
+		}
+		for _, item := range vs.Items {
+			if item.Name == "" {
+				return cli.NewError("missing item name in variable set %s", vs.Name)
+			}
+			if item.Type != sdk.ProjectVariableTypeString && item.Type != sdk.ProjectVariableTypeSecret {
+				return cli.NewError("invalid type %q for item %s of variable set %s, want %s or %s", item.Type, item.Name, vs.Name, sdk.ProjectVariableTypeString, sdk.ProjectVariableTypeSecret)
+			}
+		}
+	}
+	for i := range m.Concurrencies {
+		if err := m.Concurrencies[i].Check(); err != nil {
+			return cli.WrapError(err, "invalid concurrency %s", m.Concurrencies[i].Name)
+		}
+	}
+	for _, n := range m.Notifications {
+		if n.Name == "" || n.WebHookURL == "" {
+			return cli.NewError("notifications need a name and a webhook_url")
+		}
+	}
+	for _, r := range m.Repositories {
+		if r.VCSServer == "" || r.Name == "" {
+			return cli.NewError("repositories need a vcs and a name")
+		}
+	}
+	return nil
+}
+
+// bulkChange is a change to apply on a project.
+type bulkChange struct {
+	Project string `json:"project" cli:"project"`
+	Kind    string `json:"kind" cli:"kind"`
+	Name    string `json:"name" cli:"name"`
+	Action  string `json:"action" cli:"action"`
+	Detail  string `json:"detail,omitempty" cli:"detail"`
+	apply   func(ctx context.Context) error
+}
+
+// bulkProjectPlan contains the changes of a project, or the error that occurred while computing them.
+type bulkProjectPlan struct {
+	project string
+	changes []bulkChange
+	err     error
+}
+
+func readBulkManifest(v cli.Values) (*bulkManifest, int, error) {
+	parallel, err := strconv.Atoi(v.GetString("parallel"))
+	if err != nil || parallel <= 0 {
+		return nil, 0, cli.NewError("invalid parallel value %q", v.GetString("parallel"))
+	}
+	btes, err := os.ReadFile(v.GetString("manifest"))
+	if err != nil {
+		return nil, 0, cli.WrapError(err, "unable to read manifest %s", v.GetString("manifest"))
+	}
+	var m bulkManifest
+	if err := yaml.Unmarshal(btes, &m); err != nil {
+		return nil, 0, cli.WrapError(err, "unable to read manifest %s", v.GetString("manifest"))
+	}
+	if err := m.check(); err != nil {
+		return nil, 0, err
+	}
+	return &m, parallel, nil
+}
+
+// bulkForEachProject runs f on each project of the manifest with at most parallel projects at once.
+func bulkForEachProject(m *bulkManifest, parallel int, f func(projectKey string, index int)) {
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, p := range m.Projects {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(p string, i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			f(p, i)
+		}(p, i)
+	}
+	wg.Wait()
+}
+
+func bulkPlan(ctx context.Context, m *bulkManifest, parallel int) []bulkProjectPlan {
+	plans := make([]bulkProjectPlan, len(m.Projects))
+	bulkForEachProject(m, parallel, func(projectKey string, i int) {
+		changes, err := bulkPlanProject(ctx, m, projectKey)
+		plans[i] = bulkProjectPlan{project: projectKey, changes: changes, err: err}
+	})
+	return plans
+}
+
+func bulkPlanFunc(v cli.Values) (cli.ListResult, error) {
+	m, parallel, err := readBulkManifest(v)
+	if err != nil {
+		return nil, err
+	}
+	changes, nbErrors := bulkPlanChanges(bulkPlan(context.Background(), m, parallel))
+	bulkPlanFailed = nbErrors > 0
+	return cli.AsListResult(changes), nil
+}
+
+// bulkPlanChanges returns the changes of all the projects, a project whose changes can't be computed is given
+// as an error change.
+func bulkPlanChanges(plans []bulkProjectPlan) ([]bulkChange, int) {
+	var changes []bulkChange
+	var nbErrors int
+	for _, p := range plans {
+		if p.err != nil {
+			changes = append(changes, bulkChange{Project: p.project, Kind: "project", Name: p.project, Action: bulkActionError, Detail: fmt.Sprintf("unable to compute changes: %v", p.err)})
+			nbErrors++
+			continue
+		}
+		changes = append(changes, p.changes...)
+	}
+	return changes, nbErrors
+}
+
+func bulkApplyFunc(v cli.Values) error {
+	m, parallel, err := readBulkManifest(v)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	plans := bulkPlan(ctx, m, parallel)
+
+	// Changes of a project are applied in order: a variable set is created before its items
+	reports := make([][]string, len(plans))
+	errs := make([]int, len(plans))
+	bulkForEachProject(m, parallel, func(projectKey string, i int) {
+		if plans[i].err != nil {
+			reports[i] = append(reports[i], fmt.Sprintf("%s: unable to compute changes: %v", projectKey, plans[i].err))
+			errs[i]++
+			return
+		}
+		for _, c := range plans[i].changes {
+			line := fmt.Sprintf("%s: %s %s %s", projectKey, c.Action, c.Kind, c.Name)
+			if c.Detail != "" {
+				line += " (" + c.Detail + ")"
+			}
+			if err := c.apply(ctx); err != nil {
+				reports[i] = append(reports[i], line+": "+err.Error())
+				errs[i]++
+				continue
+			}
+			reports[i] = append(reports[i], line+": OK")
+		}
+	})
+
+	var nbChanges, nbErrors int
+	for i := range plans {
+		for _, l := range reports[i] {
+			fmt.Println(l)
+		}
+		nbChanges += len(plans[i].changes)
+		nbErrors += errs[i]
+	}
+	fmt.Printf("%d projects, %d changes, %d errors\n", len(plans), nbChanges, nbErrors)
+	if nbErrors > 0 {
+		cli.OSExit(1)
+	}
+	return nil
+}
+
+// bulkPlanProject computes the changes to apply on a project.
+func bulkPlanProject(ctx context.Context, m *bulkManifest, projectKey string) ([]bulkChange, error) {
+	var changes []bulkChange
+	newChange := func(kind, name, action, detail string, apply func(ctx context.Context) error) {
+		changes = append(changes, bulkChange{Project: projectKey, Kind: kind, Name: name, Action: action, Detail: detail, apply: apply})
+	}
+
+	// Variable sets
+	if len(m.VariableSets) > 0 {
+		existingVSs, err := client.ProjectVariableSetList(ctx, projectKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, vs := range m.VariableSets {
+			vsName := vs.Name
+			existingItems := make(map[string]sdk.ProjectVariableSetItem)
+			if bulkVariableSetExists(existingVSs, vsName) {
+				existing, err := client.ProjectVariableSetShow(ctx, projectKey, vsName)
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range existing.Items {
+					existingItems[item.Name] = item
+				}
+			} else {
+				newChange("variableset", vsName, bulkActionCreate, "", func(ctx context.Context) error {
+					return client.ProjectVariableSetCreate(ctx, projectKey, &sdk.ProjectVariableSet{Name: vsName})
+				})
+			}
+			for _, item := range vs.Items {
+				item := sdk.ProjectVariableSetItem{Name: item.Name, Type: item.Type, Value: item.Value}
+				name := vsName + "/" + item.Name
+				existing, has := existingItems[item.Name]
+				switch {
+				case !has:
+					newChange("variableset-item", name, bulkActionCreate, "", func(ctx context.Context) error {
+						return client.ProjectVariableSetItemAdd(ctx, projectKey, vsName, &item)
+					})
+				case existing.Type != item.Type:
+					// The type of an item can't be updated, the item is not deleted as its value would be lost if the
+					// creation fails
+					newChange("variableset-item", name, bulkActionConflict, fmt.Sprintf("type: %s -> %s", existing.Type, item.Type), func(ctx context.Context) error {
+						return cli.NewError("the type of an item can't be updated, delete the item %s and apply again", name)
+					})
+				case item.Type == sdk.ProjectVariableTypeSecret || existing.Value != item.Value:
+					detail := "value"
+					if item.Type == sdk.ProjectVariableTypeSecret {
+						detail = "secret value can't be compared"
+					}
+					newChange("variableset-item", name, bulkActionUpdate, detail, func(ctx context.Context) error {
+						return client.ProjectVariableSetItemUpdate(ctx, projectKey, vsName, &item)
+					})
+				}
+			}
+		}
+	}
+
+	// Concurrency rules
+	if len(m.Concurrencies) > 0 {
+		existingConcurrencies, err := client.ProjectConcurrencyList(ctx, projectKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range m.Concurrencies {
+			c := sdk.ProjectConcurrency{Name: c.Name, Description: c.Description, Order: c.Order, Pool: c.Pool, If: c.If, CancelInProgress: c.CancelInProgress}
+			var existing *sdk.ProjectConcurrency
+			for i := range existingConcurrencies {
+				if existingConcurrencies[i].Name == c.Name {
+					existing = &existingConcurrencies[i]
+					break
+				}
+			}
+			if existing == nil {
+				newChange("concurrency", c.Name, bulkActionCreate, "", func(ctx context.Context) error {
+					return client.ProjectConcurrencyCreate(ctx, projectKey, &c)
+				})
+				continue
+			}
+			var diffs []string
+			diffs = bulkDiff(diffs, "description", existing.Description, c.Description)
+			diffs = bulkDiff(diffs, "order", existing.Order, c.Order)
+			diffs = bulkDiff(diffs, "pool", existing.Pool, c.Pool)
+			diffs = bulkDiff(diffs, "if", existing.If, c.If)
+			diffs = bulkDiff(diffs, "cancel_in_progress", existing.CancelInProgress, c.CancelInProgress)
+			if len(diffs) > 0 {
+				c.ID = existing.ID
+				newChange("concurrency", c.Name, bulkActionUpdate, strings.Join(diffs, ", "), func(ctx context.Context) error {
+					return client.ProjectConcurrencyUpdate(ctx, projectKey, &c)
+				})
+			}
+		}
+	}
+
+	// Notifications
+	if len(m.Notifications) > 0 {
+		existingNotifs, err := client.ProjectNotificationList(ctx, projectKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range m.Notifications {
+			n := sdk.ProjectNotification{Name: n.Name, WebHookURL: n.WebHookURL, Filters: n.Filters, Auth: n.Auth}
+			var existing *sdk.ProjectNotification
+			for i := range existingNotifs {
+				if existingNotifs[i].Name == n.Name {
+					existing = &existingNotifs[i]
+					break
+				}
+			}
+			if existing == nil {
+				newChange("notification", n.Name, bulkActionCreate, "", func(ctx context.Context) error {
+					return client.ProjectNotificationCreate(ctx, projectKey, &n)
+				})
+				continue
+			}
+			var diffs []string
+			diffs = bulkDiff(diffs, "webhook_url", existing.WebHookURL, n.WebHookURL)
+			if len(existing.Filters) != len(n.Filters) || (len(n.Filters) > 0 && !reflect.DeepEqual(existing.Filters, n.Filters)) {
+				diffs = append(diffs, "filters")
+			}
+			if len(n.Auth.Headers) > 0 {
+				diffs = append(diffs, "auth headers can't be compared")
+			} else if len(diffs) > 0 {
+				// Auth headers are not returned by CDS, the update replaces them by the ones of the manifest
+				diffs = append(diffs, "auth headers removed")
+			}
+			if len(diffs) > 0 {
+				n.ID = existing.ID
+				newChange("notification", n.Name, bulkActionUpdate, strings.Join(diffs, ", "), func(ctx context.Context) error {
+					return client.ProjectNotificationUpdate(ctx, projectKey, &n)
+				})
+			}
+		}
+	}
+
+	// Run retention
+	if m.Retention != nil {
+		var existing sdk.Retentions
+		prr, err := client.ProjectRunRetentionGet(ctx, projectKey)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			existing = prr.Retentions
+		}
+		// Compare the JSON values, an empty list is omitted like a nil one
+		existingJSON, _ := json.Marshal(existing)
+		wantedJSON, _ := json.Marshal(m.Retention)
+		if string(existingJSON) != string(wantedJSON) {
+			retention := sdk.ProjectRunRetention{ProjectKey: projectKey, Retentions: *m.Retention}
+			newChange("retention", projectKey, bulkActionUpdate, "", func(ctx context.Context) error {
+				return client.ProjectRunRetentionImport(ctx, projectKey, retention)
+			})
+		}
+	}
+
+	// Repositories
+	existingRepos := make(map[string][]sdk.ProjectRepository)
+	for _, r := range m.Repositories {
+		repos, has := existingRepos[r.VCSServer]
+		if !has {
+			var err error
+			repos, err = client.ProjectVCSRepositoryList(ctx, projectKey, r.VCSServer)
+			if err != nil {
+				return nil, err
+			}
+			existingRepos[r.VCSServer] = repos
+		}
+		found := false
+		for _, repo := range repos {
+			if repo.Name == r.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r := r
+			newChange("repository", r.VCSServer+"/"+r.Name, bulkActionCreate, "", func(ctx context.Context) error {
+				return client.ProjectVCSRepositoryAdd(ctx, projectKey, r.VCSServer, sdk.ProjectRepository{Name: r.Name})
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func bulkVariableSetExists(vss []sdk.ProjectVariableSet, name string) bool {
+	for _, vs := range vss {
+		if vs.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// bulkDiff appends a description of the change of a field if its value changed.
+func bulkDiff(diffs []string, field string, before, after interface{}) []string {
+	if reflect.DeepEqual(before, after) {
+		return diffs
+	}
+	return append(diffs, fmt.Sprintf("%s: %v -> %v", field, before, after))
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+)
+
+func Test_bulkPlanProject(t *testing.T) {
+	notification := sdk.ProjectNotification{
+		ID:         "notif-id",
+		Name:       "my-hook",
+		WebHookURL: "https://my-hook",
+		Filters:    sdk.ProjectNotificationFilters{"runs": {Events: []string{"WorkflowRunEnded"}}},
+	}
+
+	tests := []struct {
+		name     string
+		manifest bulkManifest
+		setup    func(m *mock_cdsclient.MockInterface)
+		want     []bulkChange
+		// apply the planned changes and check the calls
+		apply   func(m *mock_cdsclient.MockInterface)
+		wantErr bool
+	}{
+		{
+			name:     "create a variable set and its items",
+			manifest: bulkManifest{VariableSets: []sdk.ProjectVariableSet{{Name: "my-vars", Items: []sdk.ProjectVariableSetItem{{Name: "url", Type: sdk.ProjectVariableTypeString, Value: "https://my-url"}}}}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectVariableSetList(gomock.Any(), "PROJ").Return(nil, nil)
+			},
+			want: []bulkChange{
+				{Project: "PROJ", Kind: "variableset", Name: "my-vars", Action: bulkActionCreate},
+				{Project: "PROJ", Kind: "variableset-item", Name: "my-vars/url", Action: bulkActionCreate},
+			},
+			apply: func(m *mock_cdsclient.MockInterface) {
+				gomock.InOrder(
+					m.EXPECT().ProjectVariableSetCreate(gomock.Any(), "PROJ", &sdk.ProjectVariableSet{Name: "my-vars"}),
+					m.EXPECT().ProjectVariableSetItemAdd(gomock.Any(), "PROJ", "my-vars", &sdk.ProjectVariableSetItem{Name: "url", Type: sdk.ProjectVariableTypeString, Value: "https://my-url"}),
+				)
+			},
+		},
+		{
+			name: "update the items of an existing variable set",
+			manifest: bulkManifest{VariableSets: []sdk.ProjectVariableSet{{Name: "my-vars", Items: []sdk.ProjectVariableSetItem{
+				{Name: "same", Type: sdk.ProjectVariableTypeString, Value: "foo"},
+				{Name: "changed", Type: sdk.ProjectVariableTypeString, Value: "new"},
+				{Name: "password", Type: sdk.ProjectVariableTypeSecret, Value: "secret"},
+				{Name: "token", Type: sdk.ProjectVariableTypeSecret, Value: "secret"},
+			}}}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectVariableSetList(gomock.Any(), "PROJ").Return([]sdk.ProjectVariableSet{{Name: "my-vars"}}, nil)
+				m.EXPECT().ProjectVariableSetShow(gomock.Any(), "PROJ", "my-vars").Return(&sdk.ProjectVariableSet{Name: "my-vars", Items: []sdk.ProjectVariableSetItem{
+					{Name: "same", Type: sdk.ProjectVariableTypeString, Value: "foo"},
+					{Name: "changed", Type: sdk.ProjectVariableTypeString, Value: "old"},
+					{Name: "password", Type: sdk.ProjectVariableTypeSecret},
+					{Name: "token", Type: sdk.ProjectVariableTypeString, Value: "secret"},
+				}}, nil)
+			},
+			want: []bulkChange{
+				{Project: "PROJ", Kind: "variableset-item", Name: "my-vars/changed", Action: bulkActionUpdate, Detail: "value"},
+				{Project: "PROJ", Kind: "variableset-item", Name: "my-vars/password", Action: bulkActionUpdate, Detail: "secret value can't be compared"},
+				{Project: "PROJ", Kind: "variableset-item", Name: "my-vars/token", Action: bulkActionConflict, Detail: "type: string -> secret"},
+			},
+			// The conflicting item is neither deleted nor updated
+			apply: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectVariableSetItemUpdate(gomock.Any(), "PROJ", "my-vars", &sdk.ProjectVariableSetItem{Name: "changed", Type: sdk.ProjectVariableTypeString, Value: "new"})
+				m.EXPECT().ProjectVariableSetItemUpdate(gomock.Any(), "PROJ", "my-vars", &sdk.ProjectVariableSetItem{Name: "password", Type: sdk.ProjectVariableTypeSecret, Value: "secret"})
+			},
+			wantErr: true,
+		},
+		{
+			name: "create and update concurrency rules",
+			manifest: bulkManifest{Concurrencies: []sdk.ProjectConcurrency{
+				{Name: "deploy", Order: sdk.ConcurrencyOrderOldestFirst, Pool: 2},
+				{Name: "build", Order: sdk.ConcurrencyOrderNewestFirst, Pool: 1},
+				{Name: "same", Order: sdk.ConcurrencyOrderOldestFirst, Pool: 1},
+			}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectConcurrencyList(gomock.Any(), "PROJ").Return([]sdk.ProjectConcurrency{
+					{ID: "deploy-id", Name: "deploy", Order: sdk.ConcurrencyOrderOldestFirst, Pool: 1},
+					{ID: "same-id", Name: "same", Order: sdk.ConcurrencyOrderOldestFirst, Pool: 1},
+				}, nil)
+			},
+			want: []bulkChange{
+				{Project: "PROJ", Kind: "concurrency", Name: "deploy", Action: bulkActionUpdate, Detail: "pool: 1 -> 2"},
+				{Project: "PROJ", Kind: "concurrency", Name: "build", Action: bulkActionCreate},
+			},
+			apply: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectConcurrencyUpdate(gomock.Any(), "PROJ", &sdk.ProjectConcurrency{ID: "deploy-id", Name: "deploy", Order: sdk.ConcurrencyOrderOldestFirst, Pool: 2})
+				m.EXPECT().ProjectConcurrencyCreate(gomock.Any(), "PROJ", &sdk.ProjectConcurrency{Name: "build", Order: sdk.ConcurrencyOrderNewestFirst, Pool: 1})
+			},
+		},
+		{
+			name:     "unchanged notification",
+			manifest: bulkManifest{Notifications: []sdk.ProjectNotification{{Name: "my-hook", WebHookURL: "https://my-hook", Filters: notification.Filters}}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectNotificationList(gomock.Any(), "PROJ").Return([]sdk.ProjectNotification{notification}, nil)
+			},
+		},
+		{
+			name:     "notification update without auth headers in the manifest",
+			manifest: bulkManifest{Notifications: []sdk.ProjectNotification{{Name: "my-hook", WebHookURL: "https://my-new-hook", Filters: notification.Filters}}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectNotificationList(gomock.Any(), "PROJ").Return([]sdk.ProjectNotification{notification}, nil)
+			},
+			want: []bulkChange{
+				{Project: "PROJ", Kind: "notification", Name: "my-hook", Action: bulkActionUpdate, Detail: "webhook_url: https://my-hook -> https://my-new-hook, auth headers removed"},
+			},
+			apply: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectNotificationUpdate(gomock.Any(), "PROJ", &sdk.ProjectNotification{ID: "notif-id", Name: "my-hook", WebHookURL: "https://my-new-hook", Filters: notification.Filters})
+			},
+		},
+		{
+			name: "notification with auth headers",
+			manifest: bulkManifest{Notifications: []sdk.ProjectNotification{
+				{Name: "my-hook", WebHookURL: "https://my-hook", Filters: notification.Filters, Auth: sdk.ProjectNotificationAuth{Headers: map[string]string{"Authorization": "Bearer foo"}}},
+				{Name: "other-hook", WebHookURL: "https://other-hook"},
+			}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectNotificationList(gomock.Any(), "PROJ").Return([]sdk.ProjectNotification{notification}, nil)
+			},
+			want: []bulkChange{
+				{Project: "PROJ", Kind: "notification", Name: "my-hook", Action: bulkActionUpdate, Detail: "auth headers can't be compared"},
+				{Project: "PROJ", Kind: "notification", Name: "other-hook", Action: bulkActionCreate},
+			},
+		},
+		{
+			name:     "retention",
+			manifest: bulkManifest{Retention: &sdk.Retentions{DefaultRetention: sdk.RetentionRule{DurationInDays: 30, Count: 20}}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectRunRetentionGet(gomock.Any(), "PROJ").Return(nil, sdk.WithStack(sdk.ErrNotFound))
+			},
+			want: []bulkChange{
+				{Project: "PROJ", Kind: "retention", Name: "PROJ", Action: bulkActionUpdate},
+			},
+			apply: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectRunRetentionImport(gomock.Any(), "PROJ", sdk.ProjectRunRetention{ProjectKey: "PROJ", Retentions: sdk.Retentions{DefaultRetention: sdk.RetentionRule{DurationInDays: 30, Count: 20}}})
+			},
+		},
+		{
+			name:     "unchanged retention",
+			manifest: bulkManifest{Retention: &sdk.Retentions{DefaultRetention: sdk.RetentionRule{DurationInDays: 30, Count: 20}}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectRunRetentionGet(gomock.Any(), "PROJ").Return(&sdk.ProjectRunRetention{Retentions: sdk.Retentions{WorkflowRetentions: []sdk.WorkflowRetentions{}, DefaultRetention: sdk.RetentionRule{DurationInDays: 30, Count: 20}}}, nil)
+			},
+		},
+		{
+			name:     "repositories",
+			manifest: bulkManifest{Repositories: []bulkRepository{{VCSServer: "github", Name: "my-org/my-repo"}, {VCSServer: "github", Name: "my-org/new-repo"}}},
+			setup: func(m *mock_cdsclient.MockInterface) {
+				// The repositories of a VCS server are listed once
+				m.EXPECT().ProjectVCSRepositoryList(gomock.Any(), "PROJ", "github").Return([]sdk.ProjectRepository{{Name: "my-org/my-repo"}}, nil)
+			},
+			want: []bulkChange{
+				{Project: "PROJ", Kind: "repository", Name: "github/my-org/new-repo", Action: bulkActionCreate},
+			},
+			apply: func(m *mock_cdsclient.MockInterface) {
+				m.EXPECT().ProjectVCSRepositoryAdd(gomock.Any(), "PROJ", "github", sdk.ProjectRepository{Name: "my-org/new-repo"})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock_cdsclient.NewMockInterface(ctrl)
+			client = mockClient
+
+			tt.setup(mockClient)
+			changes, err := bulkPlanProject(context.TODO(), &tt.manifest, "PROJ")
+			require.NoError(t, err)
+
+			got := make([]bulkChange, 0, len(changes))
+			for _, c := range changes {
+				c.apply = nil
+				got = append(got, c)
+			}
+			want := tt.want
+			if want == nil {
+				want = []bulkChange{}
+			}
+			require.Equal(t, want, got)
+
+			if tt.apply == nil {
+				return
+			}
+			tt.apply(mockClient)
+			var applyErr error
+			for _, c := range changes {
+				if err := c.apply(context.TODO()); err != nil {
+					applyErr = err
+				}
+			}
+			if tt.wantErr {
+				require.Error(t, applyErr)
+			} else {
+				require.NoError(t, applyErr)
+			}
+		})
+	}
+}
+
+func Test_bulkPlanChanges(t *testing.T) {
+	plans := []bulkProjectPlan{
+		{project: "PROJ1", changes: []bulkChange{{Project: "PROJ1", Kind: "concurrency", Name: "deploy", Action: bulkActionCreate}}},
+		{project: "PROJ2", err: sdk.WithStack(sdk.ErrForbidden)},
+		{project: "PROJ3", changes: []bulkChange{{Project: "PROJ3", Kind: "concurrency", Name: "deploy", Action: bulkActionUpdate}}},
+	}
+
+	// The changes of the other projects are kept
+	changes, nbErrors := bulkPlanChanges(plans)
+	require.Equal(t, 1, nbErrors)
+	require.Len(t, changes, 3)
+	require.Equal(t, "PROJ1", changes[0].Project)
+	require.Equal(t, bulkActionError, changes[1].Action)
+	require.Equal(t, "PROJ2", changes[1].Project)
+	require.Contains(t, changes[1].Detail, "unable to compute changes")
+	require.Equal(t, "PROJ3", changes[2].Project)
+}
@@ -47,15 +47,25 @@ var hatcheryAddCmd = cli.Command{
 	Name:    "add",
 	Aliases: []string{"create"},
 	Short:   "Create a new hatchery",
-	Example: "cdsctl hatchery add <hatchery_name>",
+	Example: "cdsctl hatchery add <hatchery_name> --project PROJ_KEY",
 	Ctx:     []cli.Arg{},
 	Args: []cli.Arg{
 		{Name: "hatcheryIdentifier"},
 	},
+	Flags: []cli.Flag{
+		{
+			Name:  "project",
+			Type:  cli.FlagSlice,
+			Usage: "restrict the hatchery to the jobs of the given projects",
+		},
+	},
 }
 
 func hatcheryAddFunc(v cli.Values) (interface{}, error) {
-	h := sdk.Hatchery{Name: v.GetString("hatcheryIdentifier")}
+	h := sdk.Hatchery{
+		Name:        v.GetString("hatcheryIdentifier"),
+		ProjectKeys: v.GetStringSlice("project"),
+	}
 	hresp, err := client.HatcheryAdd(context.Background(), &h)
 	if err != nil {
 		return nil, err
@@ -22,8 +22,14 @@ func experimentalProject() *cobra.Command {
 		projectNotification(),
 		projectVariableSet(),
 		projectConcurrency(),
+		projectEnvironment(),
+		projectFreeze(),
 		projectWebHooks(),
 		projectRetention(),
+		projectUsage(),
+		projectQuota(),
+		projectRBACAsCode(),
+		projectCache(),
 	})
 }
 
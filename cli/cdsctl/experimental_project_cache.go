@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var projectCacheCmd = cli.Command{
+	Name:  "cache",
+	Short: "Manage the worker caches of a CDS project",
+}
+
+func projectCache() *cobra.Command {
+	return cli.NewCommand(projectCacheCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectCacheListCmd, projectCacheListFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectCacheDeleteCmd, projectCacheDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectCacheListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List the worker caches of the project",
+	Example: "cdsctl X project cache list MY-PROJECT --ref refs/heads/main",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "ref", Type: cli.FlagString, Usage: "Filter on git ref"},
+	},
+	Mcp: true,
+}
+
+func projectCacheListFunc(v cli.Values) (cli.ListResult, error) {
+	var mods []cdsclient.RequestModifier
+	if v.GetString("ref") != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("ref", v.GetString("ref")))
+	}
+	caches, err := client.ProjectCacheList(context.Background(), v.GetString(_ProjectKey), mods...)
+	return cli.AsListResult(caches), err
+}
+
+var projectCacheDeleteCmd = cli.Command{
+	Name:    "delete",
+	Short:   "Delete a worker cache of the project, on all git refs unless --ref is given",
+	Example: "cdsctl X project cache delete MY-PROJECT go-mod-1234 --ref refs/heads/main",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "key"},
+	},
+	Flags: []cli.Flag{
+		{Name: "ref", Type: cli.FlagString, Usage: "Only delete the cache saved on this git ref"},
+	},
+}
+
+func projectCacheDeleteFunc(v cli.Values) error {
+	var mods []cdsclient.RequestModifier
+	if v.GetString("ref") != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("ref", v.GetString("ref")))
+	}
+	return client.ProjectCacheDelete(context.Background(), v.GetString(_ProjectKey), v.GetString("key"), mods...)
+}
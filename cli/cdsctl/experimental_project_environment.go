@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var projectEnvironmentCmd = cli.Command{
+	Name:    "environment",
+	Aliases: []string{"environments", "env"},
+	Short:   "Manage deployment environments on a CDS project",
+}
+
+func projectEnvironment() *cobra.Command {
+	return cli.NewCommand(projectEnvironmentCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectEnvironmentListCmd, projectEnvironmentListFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(projectEnvironmentShowCmd, projectEnvironmentShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectEnvironmentCreateCmd, projectEnvironmentCreateFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectEnvironmentUpdateCmd, projectEnvironmentUpdateFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectEnvironmentDeleteCmd, projectEnvironmentDeleteFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectEnvironmentHistoryCmd, projectEnvironmentHistoryFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+type projectEnvironmentDisplay struct {
+	Name           string `cli:"name"`
+	Description    string `cli:"description"`
+	ReviewerUsers  string `cli:"reviewer_users"`
+	ReviewerGroups string `cli:"reviewer_groups"`
+	AllowedRefs    string `cli:"allowed_refs"`
+	WaitTimer      int64  `cli:"wait_timer"`
+	VariableSet    string `cli:"variable_set"`
+	CurrentVersion string `cli:"current_version"`
+	DeployedBy     string `cli:"deployed_by"`
+	DeployedAt     string `cli:"deployed_at"`
+}
+
+func newProjectEnvironmentDisplay(env sdk.ProjectEnvironment) projectEnvironmentDisplay {
+	d := projectEnvironmentDisplay{
+		Name:           env.Name,
+		Description:    env.Description,
+		ReviewerUsers:  strings.Join(env.ReviewerUsers, ","),
+		ReviewerGroups: strings.Join(env.ReviewerGroups, ","),
+		AllowedRefs:    strings.Join(env.AllowedRefs, ","),
+		WaitTimer:      env.WaitTimer,
+		VariableSet:    env.VariableSet,
+	}
+	if env.CurrentDeployment != nil {
+		d.CurrentVersion = env.CurrentDeployment.Version
+		d.DeployedBy = env.CurrentDeployment.Username
+		d.DeployedAt = env.CurrentDeployment.LastModified.Format(time.RFC3339)
+	}
+	return d
+}
+
+var projectEnvironmentListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List all environments in the given project with their current deployment",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Mcp: true,
+}
+
+func projectEnvironmentListFunc(v cli.Values) (cli.ListResult, error) {
+	envs, err := client.ProjectEnvironmentList(context.Background(), v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]projectEnvironmentDisplay, 0, len(envs))
+	for _, env := range envs {
+		res = append(res, newProjectEnvironmentDisplay(env))
+	}
+	return cli.AsListResult(res), nil
+}
+
+var projectEnvironmentShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Get the given environment",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectEnvironmentShowFunc(v cli.Values) (interface{}, error) {
+	env, err := client.ProjectEnvironmentGet(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil {
+		return nil, err
+	}
+	return newProjectEnvironmentDisplay(*env), nil
+}
+
+var projectEnvironmentFlags = []cli.Flag{
+	{Name: "reviewer-users", Type: cli.FlagSlice, Usage: "Users allowed to trigger jobs deploying on the environment"},
+	{Name: "reviewer-groups", Type: cli.FlagSlice, Usage: "Groups allowed to trigger jobs deploying on the environment"},
+	{Name: "allowed-refs", Type: cli.FlagSlice, Usage: "Git ref patterns allowed to deploy on the environment (ex: refs/heads/main,refs/tags/*)"},
+	{Name: "wait-timer", Type: cli.FlagString, Usage: "Minutes to wait before starting jobs deploying on the environment"},
+	{Name: "variableset", Type: cli.FlagString, Usage: "Variable set added to jobs deploying on the environment"},
+}
+
+var projectEnvironmentCreateCmd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"create"},
+	Short:   "Create a new environment inside the given project",
+	Example: "cdsctl X project environment add MY-PROJECT production \"Production servers\" --reviewer-groups ops --allowed-refs refs/heads/master --wait-timer 10 --variableset prod",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "description"},
+	},
+	Flags: projectEnvironmentFlags,
+}
+
+func projectEnvironmentCreateFunc(v cli.Values) error {
+	env := sdk.ProjectEnvironment{
+		Name:        v.GetString("name"),
+		Description: v.GetString("description"),
+		ProjectKey:  v.GetString(_ProjectKey),
+	}
+	if err := applyProjectEnvironmentFlags(v, &env); err != nil {
+		return err
+	}
+	return client.ProjectEnvironmentCreate(context.Background(), v.GetString(_ProjectKey), &env)
+}
+
+var projectEnvironmentUpdateCmd = cli.Command{
+	Name:    "update",
+	Aliases: []string{"up"},
+	Short:   "Update the given environment inside the given project",
+	Example: "cdsctl X project environment update MY-PROJECT production --description=<new description> --wait-timer 0",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: append([]cli.Flag{{Name: "description", Type: cli.FlagString}}, projectEnvironmentFlags...),
+}
+
+func projectEnvironmentUpdateFunc(v cli.Values) error {
+	env, err := client.ProjectEnvironmentGet(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	if v.GetString("description") != "" {
+		env.Description = v.GetString("description")
+	}
+	if err := applyProjectEnvironmentFlags(v, env); err != nil {
+		return err
+	}
+	return client.ProjectEnvironmentUpdate(context.Background(), v.GetString(_ProjectKey), env)
+}
+
+func applyProjectEnvironmentFlags(v cli.Values, env *sdk.ProjectEnvironment) error {
+	if users := v.GetStringSlice("reviewer-users"); users != nil {
+		env.ReviewerUsers = users
+	}
+	if groups := v.GetStringSlice("reviewer-groups"); groups != nil {
+		env.ReviewerGroups = groups
+	}
+	if refs := v.GetStringSlice("allowed-refs"); refs != nil {
+		env.AllowedRefs = refs
+	}
+	if v.GetString("wait-timer") != "" {
+		waitTimer, err := strconv.ParseInt(v.GetString("wait-timer"), 10, 64)
+		if err != nil {
+			return err
+		}
+		env.WaitTimer = waitTimer
+	}
+	if v.GetString("variableset") != "" {
+		env.VariableSet = v.GetString("variableset")
+	}
+	return nil
+}
+
+var projectEnvironmentDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete an environment on a project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectEnvironmentDeleteFunc(v cli.Values) error {
+	return client.ProjectEnvironmentDelete(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+}
+
+var projectEnvironmentHistoryCmd = cli.Command{
+	Name:    "history",
+	Aliases: []string{"deployments"},
+	Short:   "List the deployments on the given environment, newest first",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{Name: "offset", Type: cli.FlagString, Default: "0"},
+		{Name: "limit", Type: cli.FlagString, Default: "20"},
+	},
+	Mcp: true,
+}
+
+func projectEnvironmentHistoryFunc(v cli.Values) (cli.ListResult, error) {
+	deployments, err := client.ProjectEnvironmentListDeployments(context.Background(), v.GetString(_ProjectKey), v.GetString("name"),
+		cdsclient.WithQueryParameter("offset", v.GetString("offset")),
+		cdsclient.WithQueryParameter("limit", v.GetString("limit")))
+	return cli.AsListResult(deployments), err
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectFreezeCmd = cli.Command{
+	Name:    "freeze",
+	Aliases: []string{"freezes"},
+	Short:   "Manage change freeze periods on a CDS project",
+}
+
+func projectFreeze() *cobra.Command {
+	return cli.NewCommand(projectFreezeCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectFreezeListCmd, projectFreezeListFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(projectFreezeShowCmd, projectFreezeShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectFreezeCreateCmd, projectFreezeCreateFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectFreezeUpdateCmd, projectFreezeUpdateFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectFreezeDeleteCmd, projectFreezeDeleteFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(projectFreezeOverrideCmd, projectFreezeOverrideFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectFreezeOverrideListCmd, projectFreezeOverrideListFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+type projectFreezeDisplay struct {
+	Name         string `cli:"name"`
+	Description  string `cli:"description"`
+	Environments string `cli:"environments"`
+	Workflows    string `cli:"workflows"`
+	Period       string `cli:"period"`
+	ActiveUntil  string `cli:"active_until"`
+}
+
+func newProjectFreezeDisplay(f sdk.ProjectFreeze) projectFreezeDisplay {
+	d := projectFreezeDisplay{
+		Name:         f.Name,
+		Description:  f.Description,
+		Environments: strings.Join(f.Environments, ","),
+		Workflows:    strings.Join(f.Workflows, ","),
+	}
+	if f.Cron != "" {
+		d.Period = fmt.Sprintf("%s for %dm (%s)", f.Cron, f.Duration, f.Timezone)
+	} else if f.Start != nil && f.End != nil {
+		d.Period = fmt.Sprintf("%s - %s", f.Start.Format(time.RFC3339), f.End.Format(time.RFC3339))
+	}
+	if until := f.ActiveUntil(time.Now()); until != nil {
+		d.ActiveUntil = until.Format(time.RFC3339)
+	}
+	return d
+}
+
+var projectFreezeListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List all freezes in the given project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Mcp: true,
+}
+
+func projectFreezeListFunc(v cli.Values) (cli.ListResult, error) {
+	freezes, err := client.ProjectFreezeList(context.Background(), v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]projectFreezeDisplay, 0, len(freezes))
+	for _, f := range freezes {
+		res = append(res, newProjectFreezeDisplay(f))
+	}
+	return cli.AsListResult(res), nil
+}
+
+var projectFreezeShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Get the given freeze",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectFreezeShowFunc(v cli.Values) (interface{}, error) {
+	f, err := client.ProjectFreezeGet(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil {
+		return nil, err
+	}
+	return newProjectFreezeDisplay(*f), nil
+}
+
+var projectFreezeFlags = []cli.Flag{
+	{Name: "environments", Type: cli.FlagSlice, Usage: "Environments frozen, all the jobs if empty"},
+	{Name: "workflows", Type: cli.FlagSlice, Usage: "Workflow name patterns frozen, all the workflows if empty"},
+	{Name: "start", Type: cli.FlagString, Usage: "Start of the freeze (RFC3339)"},
+	{Name: "end", Type: cli.FlagString, Usage: "End of the freeze (RFC3339)"},
+	{Name: "cron", Type: cli.FlagString, Usage: "Cron expression of the start of a recurring freeze"},
+	{Name: "duration", Type: cli.FlagString, Usage: "Duration in minutes of a recurring freeze"},
+	{Name: "timezone", Type: cli.FlagString, Usage: "Timezone of the cron expression"},
+}
+
+var projectFreezeCreateCmd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"create"},
+	Short:   "Create a new freeze inside the given project",
+	Example: `cdsctl X project freeze add MY-PROJECT christmas "No prod deployment" --environments production --start 2026-12-20T00:00:00Z --end 2027-01-04T00:00:00Z
+cdsctl X project freeze add MY-PROJECT weekend --cron "0 18 * * 5" --duration 3840 --timezone Europe/Paris`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "description"},
+	},
+	Flags: projectFreezeFlags,
+}
+
+func projectFreezeCreateFunc(v cli.Values) error {
+	f := sdk.ProjectFreeze{
+		Name:        v.GetString("name"),
+		Description: v.GetString("description"),
+		ProjectKey:  v.GetString(_ProjectKey),
+	}
+	if err := applyProjectFreezeFlags(v, &f); err != nil {
+		return err
+	}
+	warnProjectFreezeHoldsAllJobs(f)
+	return client.ProjectFreezeCreate(context.Background(), v.GetString(_ProjectKey), &f)
+}
+
+var projectFreezeUpdateCmd = cli.Command{
+	Name:    "update",
+	Aliases: []string{"up"},
+	Short:   "Update the given freeze inside the given project",
+	Example: "cdsctl X project freeze update MY-PROJECT christmas --end 2027-01-06T00:00:00Z",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: append([]cli.Flag{{Name: "description", Type: cli.FlagString}}, projectFreezeFlags...),
+}
+
+func projectFreezeUpdateFunc(v cli.Values) error {
+	f, err := client.ProjectFreezeGet(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	if v.GetString("description") != "" {
+		f.Description = v.GetString("description")
+	}
+	if err := applyProjectFreezeFlags(v, f); err != nil {
+		return err
+	}
+	warnProjectFreezeHoldsAllJobs(*f)
+	return client.ProjectFreezeUpdate(context.Background(), v.GetString(_ProjectKey), f)
+}
+
+func warnProjectFreezeHoldsAllJobs(f sdk.ProjectFreeze) {
+	if f.HoldsAllJobs() {
+		fmt.Fprintf(os.Stderr, "Warning: freeze %s has no environment nor workflow filter, it will hold all the jobs of the project\n", f.Name)
+	}
+}
+
+func applyProjectFreezeFlags(v cli.Values, f *sdk.ProjectFreeze) error {
+	if envs := v.GetStringSlice("environments"); envs != nil {
+		f.Environments = envs
+	}
+	if workflows := v.GetStringSlice("workflows"); workflows != nil {
+		f.Workflows = workflows
+	}
+	for _, flag := range []struct {
+		name string
+		t    **time.Time
+	}{{"start", &f.Start}, {"end", &f.End}} {
+		if v.GetString(flag.name) == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v.GetString(flag.name))
+		if err != nil {
+			return cli.NewError("invalid %s date: %v", flag.name, err)
+		}
+		*flag.t = &t
+	}
+	if v.GetString("cron") != "" {
+		f.Cron = v.GetString("cron")
+	}
+	if v.GetString("duration") != "" {
+		duration, err := v.GetInt64("duration")
+		if err != nil {
+			return err
+		}
+		f.Duration = duration
+	}
+	if v.GetString("timezone") != "" {
+		f.Timezone = v.GetString("timezone")
+	}
+	return nil
+}
+
+var projectFreezeDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete a freeze on a project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectFreezeDeleteFunc(v cli.Values) error {
+	return client.ProjectFreezeDelete(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+}
+
+var projectFreezeOverrideCmd = cli.Command{
+	Name:    "override",
+	Short:   "Override a freeze for a workflow run (break-glass), the override is audited",
+	Example: `cdsctl X project freeze override MY-PROJECT christmas <workflow-run-id> --reason "hotfix for incident #42"`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "workflow-run-id"},
+	},
+	Flags: []cli.Flag{
+		{Name: "reason", Type: cli.FlagString, Usage: "Why the freeze is overridden (mandatory)"},
+	},
+}
+
+func projectFreezeOverrideFunc(v cli.Values) (interface{}, error) {
+	return client.ProjectFreezeOverride(context.Background(), v.GetString(_ProjectKey), v.GetString("name"), sdk.ProjectFreezeOverrideRequest{
+		WorkflowRunID: v.GetString("workflow-run-id"),
+		Reason:        v.GetString("reason"),
+	})
+}
+
+var projectFreezeOverrideListCmd = cli.Command{
+	Name:  "overrides",
+	Short: "List the overrides of the given freeze, newest first",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Mcp: true,
+}
+
+func projectFreezeOverrideListFunc(v cli.Values) (cli.ListResult, error) {
+	overrides, err := client.ProjectFreezeListOverrides(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+	return cli.AsListResult(overrides), err
+}
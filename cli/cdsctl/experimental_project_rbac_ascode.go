@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectRBACAsCodeCmd = cli.Command{
+	Name:  "rbac",
+	Short: "Manage the permissions of a CDS project as code",
+	Long:  "The project, workflow and variable set permissions of the project are read from the .cds/rbac.yml file of the designated repository",
+}
+
+func projectRBACAsCode() *cobra.Command {
+	return cli.NewCommand(projectRBACAsCodeCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(projectRBACAsCodeShowCmd, projectRBACAsCodeShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectRBACAsCodeSetCmd, projectRBACAsCodeSetFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectRBACAsCodeDeleteCmd, projectRBACAsCodeDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectRBACAsCodeShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Show the repository that manages the permissions of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Mcp: true,
+}
+
+func projectRBACAsCodeShowFunc(v cli.Values) (interface{}, error) {
+	return client.ProjectRBACAsCodeGet(context.Background(), v.GetString(_ProjectKey))
+}
+
+var projectRBACAsCodeSetCmd = cli.Command{
+	Name:    "set",
+	Short:   "Designate the repository that manages the permissions of the project",
+	Example: "cdsctl X project rbac set MY-PROJECT my-vcs-server my/repo",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "vcs-server"},
+		{Name: "repository"},
+	},
+}
+
+func projectRBACAsCodeSetFunc(v cli.Values) error {
+	r := sdk.ProjectRBACAsCode{
+		VCSServer:  v.GetString("vcs-server"),
+		Repository: v.GetString("repository"),
+	}
+	return client.ProjectRBACAsCodeUpdate(context.Background(), v.GetString(_ProjectKey), &r)
+}
+
+var projectRBACAsCodeDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Stop managing the permissions of the project as code, existing permissions are kept",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectRBACAsCodeDeleteFunc(v cli.Values) error {
+	return client.ProjectRBACAsCodeDelete(context.Background(), v.GetString(_ProjectKey))
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var projectUsageCmd = cli.Command{
+	Name:  "usage",
+	Short: "Show compute usage of a CDS project",
+}
+
+func projectUsage() *cobra.Command {
+	return cli.NewCommand(projectUsageCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectUsageListCmd, projectUsageListFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectUsageShowCmd, projectUsageShowFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectUsageListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List worker minutes consumed by the project for each month",
+	Example: "cdsctl X project usage list MY-PROJECT --from 2025-01 --to 2025-12 --workflow my-workflow",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "from", Type: cli.FlagString, Usage: "First month (YYYY-MM)"},
+		{Name: "to", Type: cli.FlagString, Usage: "Last month (YYYY-MM)"},
+		{Name: "vcs", Type: cli.FlagString, Usage: "Filter on vcs server"},
+		{Name: "repository", Type: cli.FlagString, Usage: "Filter on repository"},
+		{Name: "workflow", Type: cli.FlagString, Usage: "Filter on workflow name"},
+	},
+	Mcp: true,
+}
+
+func projectUsageListFunc(v cli.Values) (cli.ListResult, error) {
+	var mods []cdsclient.RequestModifier
+	for _, f := range []string{"from", "to", "vcs", "repository", "workflow"} {
+		if v.GetString(f) != "" {
+			mods = append(mods, cdsclient.WithQueryParameter(f, v.GetString(f)))
+		}
+	}
+	usages, err := client.ProjectUsageList(context.Background(), v.GetString(_ProjectKey), mods...)
+	return cli.AsListResult(usages), err
+}
+
+var projectUsageShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Show worker minutes consumed by the project during a month, by workflow, worker model and hatchery",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "month"},
+	},
+	Mcp: true,
+}
+
+func projectUsageShowFunc(v cli.Values) (cli.ListResult, error) {
+	details, err := client.ProjectUsageGet(context.Background(), v.GetString(_ProjectKey), v.GetString("month"))
+	return cli.AsListResult(details), err
+}
+
+var projectQuotaCmd = cli.Command{
+	Name:  "quota",
+	Short: "Manage compute quota of a CDS project",
+}
+
+func projectQuota() *cobra.Command {
+	return cli.NewCommand(projectQuotaCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(projectQuotaShowCmd, projectQuotaShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectQuotaSetCmd, projectQuotaSetFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectQuotaDeleteCmd, projectQuotaDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectQuotaShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Show the quota of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Mcp: true,
+}
+
+func projectQuotaShowFunc(v cli.Values) (interface{}, error) {
+	return client.ProjectQuotaGet(context.Background(), v.GetString(_ProjectKey))
+}
+
+var projectQuotaSetCmd = cli.Command{
+	Name:    "set",
+	Short:   "Set the quota of the project (0 means unlimited)",
+	Example: "cdsctl X project quota set MY-PROJECT --max-concurrent-jobs 10 --max-monthly-minutes 50000",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "max-concurrent-jobs", Type: cli.FlagString},
+		{Name: "max-monthly-minutes", Type: cli.FlagString},
+	},
+}
+
+func projectQuotaSetFunc(v cli.Values) error {
+	quota, err := client.ProjectQuotaGet(context.Background(), v.GetString(_ProjectKey))
+	if err != nil {
+		return err
+	}
+	if v.GetString("max-concurrent-jobs") != "" {
+		nb, err := strconv.ParseInt(v.GetString("max-concurrent-jobs"), 10, 64)
+		if err != nil {
+			return cli.WrapError(err, "invalid value for max-concurrent-jobs")
+		}
+		quota.MaxConcurrentJobs = nb
+	}
+	if v.GetString("max-monthly-minutes") != "" {
+		nb, err := strconv.ParseInt(v.GetString("max-monthly-minutes"), 10, 64)
+		if err != nil {
+			return cli.WrapError(err, "invalid value for max-monthly-minutes")
+		}
+		quota.MaxMonthlyMinutes = nb
+	}
+	return client.ProjectQuotaUpdate(context.Background(), v.GetString(_ProjectKey), quota)
+}
+
+var projectQuotaDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Remove the quota of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectQuotaDeleteFunc(v cli.Values) error {
+	return client.ProjectQuotaDelete(context.Background(), v.GetString(_ProjectKey))
+}
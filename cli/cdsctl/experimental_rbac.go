@@ -28,6 +28,9 @@ func experimentalRbac() *cobra.Command {
 		cli.NewListCommand(rbacListCmd, rbacListFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(rbacUserCmd, rbacUserPermissionFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(rbacGroupCmd, rbacGroupPermissionFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(rbacRulesCmd, rbacRulesFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(rbacExplainCmd, rbacExplainFunc, nil, withAllCommandModifiers()...),
+		rbacGrant(),
 	})
 }
 
@@ -183,3 +186,96 @@ func rbacGroupPermissionFunc(v cli.Values) error {
 	fmt.Printf("%s", string(result))
 	return nil
 }
+
+// rbacUsername returns the user given by the flag, or the current user.
+func rbacUsername(v cli.Values) (string, error) {
+	if username := v.GetString("user"); username != "" {
+		return username, nil
+	}
+	me, err := client.UserGetMe(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return me.Username, nil
+}
+
+var rbacRulesCmd = cli.Command{
+	Name:    "rules",
+	Aliases: []string{"effective"},
+	Short:   "List the roles given to a user on all resources, with the permission that gives each of them",
+	Example: "cdsctl X rbac rules --user <username>",
+	Ctx:     []cli.Arg{},
+	Flags: []cli.Flag{
+		{Name: "user", Usage: "Username, default to the current user"},
+	},
+	Mcp: true,
+}
+
+func rbacRulesFunc(v cli.Values) (cli.ListResult, error) {
+	username, err := rbacUsername(v)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := client.RBACUserPermissionRules(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(rules), nil
+}
+
+var rbacExplainCmd = cli.Command{
+	Name:  "explain",
+	Short: "Explain why a user has, or has not, a role",
+	Long: `Run the permission check of a role for a user and explain its result.
+
+The resource checked is given by the flags: --region, --variableset, --workflow (with --vcs and --repository), --project, or none for a global role.`,
+	Example: `cdsctl X rbac explain --user foo --project MYPROJ --role manage-workflow
+cdsctl X rbac explain --user foo --project MYPROJ --vcs github --repository ovh/cds --workflow build --role trigger
+cdsctl X rbac explain --user foo --region build --role execute`,
+	Ctx: []cli.Arg{},
+	Flags: []cli.Flag{
+		{Name: "user", Usage: "Username, default to the current user"},
+		{Name: "role", Usage: "Role to check"},
+		{Name: "project", Usage: "Project key"},
+		{Name: "vcs", Usage: "VCS server of the workflow"},
+		{Name: "repository", Usage: "Repository of the workflow"},
+		{Name: "workflow", Usage: "Workflow name"},
+		{Name: "variableset", Usage: "Variable set name"},
+		{Name: "region", Usage: "Region name"},
+		{Name: "format", Usage: "Output format: yaml or json", Default: "yaml"},
+	},
+	Mcp: true,
+}
+
+func rbacExplainFunc(v cli.Values) error {
+	username, err := rbacUsername(v)
+	if err != nil {
+		return err
+	}
+	req := sdk.RBACExplainRequest{
+		Username:    username,
+		Role:        v.GetString("role"),
+		ProjectKey:  v.GetString("project"),
+		VCSServer:   v.GetString("vcs"),
+		Repository:  v.GetString("repository"),
+		Workflow:    v.GetString("workflow"),
+		VariableSet: v.GetString("variableset"),
+		Region:      v.GetString("region"),
+	}
+	if err := req.Check(); err != nil {
+		return err
+	}
+	explanation, err := client.RBACUserPermissionExplain(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	var result []byte
+	if v.GetString("format") == "json" {
+		result, _ = json.MarshalIndent(explanation, "", "  ")
+		result = append(result, '\n')
+	} else {
+		result, _ = yaml.Marshal(explanation)
+	}
+	fmt.Printf("%s", string(result))
+	return nil
+}
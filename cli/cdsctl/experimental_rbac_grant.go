@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var rbacGrantCmd = cli.Command{
+	Name:    "grant",
+	Aliases: []string{"grants"},
+	Short:   "Request, review and revoke temporary permissions",
+}
+
+func rbacGrant() *cobra.Command {
+	return cli.NewCommand(rbacGrantCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(rbacGrantRequestCmd, rbacGrantRequestFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(rbacGrantListCmd, rbacGrantListFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantShowCmd, rbacGrantShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantApproveCmd, rbacGrantApproveFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantRejectCmd, rbacGrantRejectFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantRevokeCmd, rbacGrantRevokeFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var rbacGrantRequestCmd = cli.Command{
+	Name:  "request",
+	Short: "Request a temporary role, to be approved by a user with the manage-permission role",
+	Long: `Request a temporary role for the current user.
+
+The resource is given by the flags: --region, --variableset, --workflow (with --vcs and --repository), --project, or none for a global role.`,
+	Example: `cdsctl X rbac grant request --project MYPROJ --role manage --duration 2h --justification "incident #42"`,
+	Ctx:     []cli.Arg{},
+	Flags: []cli.Flag{
+		{Name: "role", Usage: "Role requested"},
+		{Name: "project", Usage: "Project key"},
+		{Name: "vcs", Usage: "VCS server of the workflow"},
+		{Name: "repository", Usage: "Repository of the workflow"},
+		{Name: "workflow", Usage: "Workflow name"},
+		{Name: "variableset", Usage: "Variable set name"},
+		{Name: "region", Usage: "Region name"},
+		{Name: "duration", Usage: "How long the role is needed once approved (ex: 30m, 4h)", Default: "1h"},
+		{Name: "justification", Usage: "Why the role is needed (mandatory)"},
+	},
+}
+
+func rbacGrantRequestFunc(v cli.Values) (interface{}, error) {
+	duration, err := time.ParseDuration(v.GetString("duration"))
+	if err != nil {
+		return nil, cli.WrapError(err, "invalid duration %s", v.GetString("duration"))
+	}
+	me, err := client.UserGetMe(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	g := sdk.RBACGrantRequest{
+		Username:      me.Username,
+		Role:          v.GetString("role"),
+		ProjectKey:    v.GetString("project"),
+		VCSServer:     v.GetString("vcs"),
+		Repository:    v.GetString("repository"),
+		Workflow:      v.GetString("workflow"),
+		VariableSet:   v.GetString("variableset"),
+		Region:        v.GetString("region"),
+		Justification: v.GetString("justification"),
+		Duration:      int64(duration.Minutes()),
+	}
+	if err := g.Check(0); err != nil {
+		return nil, err
+	}
+	return client.RBACUserGrantRequest(context.Background(), g)
+}
+
+var rbacGrantListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List the grant requests of a user, or all of them with --all",
+	Example: `cdsctl X rbac grant list --all --status Pending`,
+	Ctx:     []cli.Arg{},
+	Flags: []cli.Flag{
+		{Name: "user", Usage: "Username, default to the current user"},
+		{Name: "all", Type: cli.FlagBool, Usage: "List the requests of all users (needs the manage-permission role)"},
+		{Name: "status", Usage: "Filter on status: Pending, Approved, Rejected, Revoked or Expired"},
+	},
+	Mcp: true,
+}
+
+func rbacGrantListFunc(v cli.Values) (cli.ListResult, error) {
+	if v.GetBool("all") {
+		grants, err := client.RBACGrantList(context.Background(), v.GetString("status"))
+		if err != nil {
+			return nil, err
+		}
+		return cli.AsListResult(grants), nil
+	}
+	username, err := rbacUsername(v)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := client.RBACUserGrantList(context.Background(), username, v.GetString("status"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(grants), nil
+}
+
+var rbacGrantShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Show a grant request",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+	Mcp: true,
+}
+
+func rbacGrantShowFunc(v cli.Values) (interface{}, error) {
+	return client.RBACGrantGet(context.Background(), v.GetString("id"))
+}
+
+var rbacGrantReviewFlags = []cli.Flag{
+	{Name: "comment", Usage: "Comment of the review"},
+}
+
+var rbacGrantApproveCmd = cli.Command{
+	Name:    "approve",
+	Short:   "Approve a pending grant request, the role is given until the end of the requested duration",
+	Example: `cdsctl X rbac grant approve <id> --comment "ok for incident #42"`,
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+	Flags: rbacGrantReviewFlags,
+}
+
+func rbacGrantApproveFunc(v cli.Values) (interface{}, error) {
+	return client.RBACGrantApprove(context.Background(), v.GetString("id"), v.GetString("comment"))
+}
+
+var rbacGrantRejectCmd = cli.Command{
+	Name:  "reject",
+	Short: "Reject a pending grant request",
+	Ctx:   []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+	Flags: rbacGrantReviewFlags,
+}
+
+func rbacGrantRejectFunc(v cli.Values) (interface{}, error) {
+	return client.RBACGrantReject(context.Background(), v.GetString("id"), v.GetString("comment"))
+}
+
+var rbacGrantRevokeCmd = cli.Command{
+	Name:  "revoke",
+	Short: "Revoke an approved grant request before its expiry",
+	Ctx:   []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+	Flags: rbacGrantReviewFlags,
+}
+
+func rbacGrantRevokeFunc(v cli.Values) (interface{}, error) {
+	return client.RBACGrantRevoke(context.Background(), v.GetString("id"), v.GetString("comment"))
+}
@@ -22,10 +22,26 @@ func experimentalWorker() *cobra.Command {
 
 var workerV2ListCmd = cli.Command{
 	Name:    "list",
-	Example: "cdsctl experimental worker list",
+	Example: "cdsctl experimental worker list --agents",
+	Flags: []cli.Flag{
+		{
+			Name:    "agents",
+			Usage:   "list the persistent worker agents with their health status",
+			Default: "false",
+			Type:    cli.FlagBool,
+		},
+	},
 }
 
 func workerV2ListFunc(v cli.Values) (cli.ListResult, error) {
+	if v.GetBool("agents") {
+		agents, err := client.V2WorkerAgentList(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return cli.AsListResult(agents), nil
+	}
+
 	workers, err := client.V2WorkerList(context.Background())
 	if err != nil {
 		return nil, err
@@ -32,10 +32,13 @@ func experimentalWorkflow() *cobra.Command {
 		cli.NewCommand(workflowRunStopCmd, workflowRunStopFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowLintCmd, workflowLintFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowRunSearchCmd, workflowRunSearchFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowRunStatsCmd, workflowRunStatsFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowAttestationCmd, workflowAttestationFunc, nil, withAllCommandModifiers()...),
 		experimentalWorkflowRunLogs(),
 		experimentalWorkflowJob(),
 		experimentalWorkflowResult(),
 		experimentalWorkflowVersion(),
+		experimentalWorkflowTest(),
 	})
 }
 
@@ -326,12 +329,32 @@ var workflowRunStatusCmd = cli.Command{
 		{Name: "proj_key"},
 		{Name: "workflow_run_id"},
 	},
+	Flags: []cli.Flag{
+		{
+			Name:  "wait",
+			Type:  cli.FlagBool,
+			Usage: "Wait for the end of the run, the exit code is 0 if the run succeeded, 1 if it failed, 2 if it was stopped and 3 if it was cancelled",
+		},
+	},
 	Mcp: true,
 }
 
 func workflowRunStatusFunc(v cli.Values) (interface{}, error) {
 	projKey := v.GetString("proj_key")
 	workflowRunID := v.GetString("workflow_run_id")
+	if v.GetBool("wait") {
+		follower, err := newWorkflowRunFollower(projKey, workflowRunID, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		status, err := follower.Follow(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		if err := workflowRunStatusError(workflowRunID, status); err != nil {
+			return nil, err
+		}
+	}
 	run, err := client.WorkflowV2RunStatus(context.Background(), projKey, workflowRunID)
 	if err != nil {
 		return nil, err
@@ -442,6 +465,16 @@ var workflowRunCmd = cli.Command{
 		{
 			Name: "inputs-file",
 		},
+		{
+			Name:  "follow",
+			Type:  cli.FlagBool,
+			Usage: "Stream the logs of the jobs and wait for the end of the run",
+		},
+		{
+			Name:  "wait",
+			Type:  cli.FlagBool,
+			Usage: "Wait for the end of the run, the exit code is 0 if the run succeeded, 1 if it failed, 2 if it was stopped and 3 if it was cancelled",
+		},
 	},
 }
 
@@ -498,11 +531,12 @@ func workflowRunFunc(v cli.Values) (interface{}, error) {
 	}
 
 	type run struct {
-		Workflow  string `json:"workflow" cli:"workflow"`
-		RunNumber int64  `json:"run_number" cli:"run_number"`
-		RunID     string `json:"run_id" cli:"run_id"`
-		Error     string `json:"error" cli:"error"`
-		UIUrl     string `json:"uri_url" cli:"ui_url"`
+		Workflow  string                  `json:"workflow" cli:"workflow"`
+		RunNumber int64                   `json:"run_number" cli:"run_number"`
+		RunID     string                  `json:"run_id" cli:"run_id"`
+		Error     string                  `json:"error" cli:"error"`
+		UIUrl     string                  `json:"uri_url" cli:"ui_url"`
+		Status    sdk.V2WorkflowRunStatus `json:"status,omitempty" cli:"status"`
 	}
 
 	retry := 0
@@ -512,15 +546,33 @@ func workflowRunFunc(v cli.Values) (interface{}, error) {
 			return nil, err
 		}
 		if event.Status == sdk.HookEventStatusDone {
-			if len(event.WorkflowHooks) == 1 {
-				return run{
-					Workflow:  wkfName,
-					RunNumber: event.WorkflowHooks[0].RunNumber,
-					RunID:     event.WorkflowHooks[0].RunID,
-					UIUrl:     fmt.Sprintf("%s/project/%s/run/%s", runResp.UIUrl, projKey, event.WorkflowHooks[0].RunID),
-				}, nil
+			if len(event.WorkflowHooks) != 1 {
+				return nil, fmt.Errorf("workflow did not start")
+			}
+			r := run{
+				Workflow:  wkfName,
+				RunNumber: event.WorkflowHooks[0].RunNumber,
+				RunID:     event.WorkflowHooks[0].RunID,
+				UIUrl:     fmt.Sprintf("%s/project/%s/run/%s", runResp.UIUrl, projKey, event.WorkflowHooks[0].RunID),
+			}
+			if !v.GetBool("follow") && !v.GetBool("wait") {
+				return r, nil
+			}
+			if v.GetBool("follow") {
+				fmt.Printf("Workflow %s #%d started: %s\n", r.Workflow, r.RunNumber, r.UIUrl)
+			}
+			follower, err := newWorkflowRunFollower(projKey, r.RunID, v.GetBool("follow"), nil)
+			if err != nil {
+				return nil, err
+			}
+			r.Status, err = follower.Follow(context.Background())
+			if err != nil {
+				return nil, err
 			}
-			return nil, fmt.Errorf("workflow did not start")
+			if err := workflowRunStatusError(r.RunID, r.Status); err != nil {
+				return nil, err
+			}
+			return r, nil
 		}
 		if event.Status == sdk.HookEventStatusError || event.Status == sdk.HookEventStatusSkipped {
 			return run{
@@ -536,9 +588,21 @@ func workflowRunFunc(v cli.Values) (interface{}, error) {
 }
 
 var workflowRestartCmd = cli.Command{
-	Name:    "restart",
-	Short:   "Restart workflow failed jobs",
-	Example: "cdsctl workflow restart <proj_key> <workflow_run_id>",
+	Name:  "restart",
+	Short: "Restart workflow failed jobs",
+	Long: `Restart workflow failed jobs.
+
+The restarted jobs can be run with overrides: --env adds env variables and --debug sets the debug log level on all of them.
+Use --overrides-file to give a YAML file with the overrides per job, including the gate inputs or the worker model:
+
+	env:
+	  MY_VAR: my-value
+	jobs:
+	  build:
+	    model: my-project/my-vcs/my-repo/my-model
+	    inputs:
+	      my-gate-input: true`,
+	Example: "cdsctl workflow restart <proj_key> <workflow_run_id> --env MY_VAR=my-value --debug",
 	Ctx:     []cli.Arg{},
 	Args: []cli.Arg{
 		{Name: "proj_key"},
@@ -551,6 +615,20 @@ var workflowRestartCmd = cli.Command{
 		{
 			Name: "inputs-file",
 		},
+		{
+			Type:  cli.FlagArray,
+			Name:  "env",
+			Usage: "Add env variables to the restarted jobs like --env KEY=VALUE --env KEY2=VALUE2",
+		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "debug",
+			Usage: "Run the restarted jobs with the debug log level",
+		},
+		{
+			Name:  "overrides-file",
+			Usage: "YAML file with the overrides of the restarted jobs",
+		},
 	},
 }
 
@@ -558,12 +636,37 @@ func workflowRestartFunc(v cli.Values) error {
 	projKey := v.GetString("proj_key")
 	workflowRunID := v.GetString("workflow_run_id")
 	if v.GetString("inputs") == "" && v.GetString("inputs-file") == "" {
-		run, err := client.WorkflowV2Restart(context.Background(), projKey, workflowRunID)
+		var payload sdk.V2WorkflowRunRestartRequest
+		if v.GetString("overrides-file") != "" {
+			bts, err := os.ReadFile(v.GetString("overrides-file"))
+			if err != nil {
+				return fmt.Errorf("unable to read file %s: %v", v.GetString("overrides-file"), err)
+			}
+			if err := yaml.Unmarshal(bts, &payload); err != nil {
+				return fmt.Errorf("unable to parse overrides: %v", err)
+			}
+		}
+		env, err := workflowRunJobEnvFlag(v)
+		if err != nil {
+			return err
+		}
+		if len(env) > 0 && payload.Env == nil {
+			payload.Env = make(map[string]string)
+		}
+		for k, value := range env {
+			payload.Env[k] = value
+		}
+		payload.Debug = payload.Debug || v.GetBool("debug")
+
+		run, err := client.WorkflowV2Restart(context.Background(), projKey, workflowRunID, payload)
 		if err != nil {
 			return err
 		}
 		fmt.Printf("Worflow %s #%d.%d restarted", run.WorkflowName, run.RunNumber, run.RunAttempt)
 	} else {
+		if v.GetString("overrides-file") != "" || len(v.GetStringArray("env")) > 0 || v.GetBool("debug") {
+			return cli.NewError("overrides can't be given with inputs, use the inputs of the jobs in the overrides file")
+		}
 		payload := sdk.V2WorkflowRunTriggerJobsRequest{}
 		if v.GetString("inputs") != "" {
 			var inputs sdk.V2WorkflowRunJobInputs
@@ -592,3 +695,19 @@ func workflowRestartFunc(v cli.Values) error {
 
 	return nil
 }
+
+// workflowRunJobEnvFlag reads the env variables given with --env KEY=VALUE.
+func workflowRunJobEnvFlag(v cli.Values) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, e := range v.GetStringArray("env") {
+		if e == "" {
+			continue
+		}
+		k, value, ok := strings.Cut(e, "=")
+		if !ok {
+			return nil, cli.NewError("invalid env variable %q, expected KEY=VALUE", e)
+		}
+		env[k] = value
+	}
+	return env, nil
+}
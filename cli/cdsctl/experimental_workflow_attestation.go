@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/gpg"
+)
+
+var workflowAttestationCmd = cli.Command{
+	Name:    "attestation",
+	Aliases: []string{"attestations"},
+	Short:   "List and verify the signed provenance and SBOM attestations of an artifact digest",
+	Long: `List the attestations of an artifact digest.
+
+With --verify, the signatures of the attestations are checked by cdsctl with the public keys of the project,
+and the command fails if no attestation can be verified. Use --public-key to check them with a key file
+instead of the keys served by the API.`,
+	Example: "cdsctl experimental workflow attestation MY-PROJECT sha256:4e07408562bedb8b60ce05c1decfe3ad16b72230967de01f640b7e4729b49fce --verify",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "digest"},
+	},
+	Flags: []cli.Flag{
+		{Name: "verify", Type: cli.FlagBool, Usage: "Check the attestation signatures and fail if no attestation can be verified for the digest"},
+		{Name: "public-key", Usage: "Path of the armored PGP or PEM RSA public key used by --verify"},
+	},
+	Mcp: true,
+}
+
+func workflowAttestationFunc(v cli.Values) (cli.ListResult, error) {
+	ctx := context.Background()
+	projectKey := v.GetString(_ProjectKey)
+	attestations, err := client.WorkflowV2AttestationList(ctx, projectKey, v.GetString("digest"))
+	if err != nil {
+		return nil, err
+	}
+	if !v.GetBool("verify") {
+		return cli.AsListResult(attestations), nil
+	}
+
+	var keys []sdk.V2AttestationPublicKey
+	if path := v.GetString("public-key"); path != "" {
+		btes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, cli.WrapError(err, "unable to read public key %s", path)
+		}
+		keys = []sdk.V2AttestationPublicKey{attestationPublicKeyFromFile(string(btes))}
+	} else {
+		keys, err = client.WorkflowV2AttestationKeys(ctx, projectKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The verification status returned by the API is replaced by the one computed locally
+	algorithm, digest := sdk.ParseDigest(v.GetString("digest"))
+	var verified bool
+	for i := range attestations {
+		a := &attestations[i]
+		statement, err := verifyAttestation(keys, *a, algorithm, digest)
+		a.Verified = err == nil
+		a.Statement = statement
+		a.Error = ""
+		if err != nil {
+			a.Error = err.Error()
+		}
+		verified = verified || a.Verified
+	}
+	if !verified {
+		return nil, fmt.Errorf("no verified attestation found for %s", v.GetString("digest"))
+	}
+	return cli.AsListResult(attestations), nil
+}
+
+// attestationPublicKeyFromFile returns a key given by the user, it is used whatever the signer and the id of the
+// signature key.
+func attestationPublicKeyFromFile(content string) sdk.V2AttestationPublicKey {
+	k := sdk.V2AttestationPublicKey{Type: sdk.AttestationKeyTypeRSA, Public: content}
+	if strings.Contains(content, "BEGIN PGP PUBLIC KEY BLOCK") {
+		k.Type = sdk.AttestationKeyTypePGP
+	}
+	return k
+}
+
+// verifyAttestation checks the DSSE envelope of the attestation with the public key of its signer, and that the signed
+// statement is about the given digest.
+func verifyAttestation(keys []sdk.V2AttestationPublicKey, a sdk.V2WorkflowRunAttestation, algorithm, digest string) (*sdk.InTotoStatement, error) {
+	envelope := a.Detail.Envelope
+	if len(envelope.Signatures) == 0 {
+		return nil, cli.NewError("attestation is not signed")
+	}
+	keyID := envelope.Signatures[0].KeyID
+	for _, k := range keys {
+		if k.KeyID != "" && (k.KeyID != keyID || k.Signer != a.Detail.Signer) {
+			continue
+		}
+		var verify func(message, sig []byte) error
+		switch k.Type {
+		case sdk.AttestationKeyTypePGP:
+			publicKey, err := gpg.NewPublicKeyFromPem(k.Public)
+			if err != nil {
+				return nil, cli.WrapError(err, "unable to read public key %s", k.KeyID)
+			}
+			verify = func(message, sig []byte) error {
+				return publicKey.VerifySignature(string(message), sig)
+			}
+		case sdk.AttestationKeyTypeRSA:
+			publicKey, err := sdk.ParseRSAAttestationPublicKey(k.Public)
+			if err != nil {
+				return nil, err
+			}
+			verify = func(message, sig []byte) error {
+				return sdk.VerifyRSAAttestation(publicKey, message, sig)
+			}
+		default:
+			return nil, cli.NewError("unsupported public key type %q", k.Type)
+		}
+		return envelope.VerifyStatement(keyID, verify, algorithm, digest)
+	}
+	return nil, cli.NewError("public key %s of the %s signer not found", keyID, a.Detail.Signer)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/gpg"
+)
+
+func Test_verifyAttestation(t *testing.T) {
+	statement, err := sdk.NewSBOMStatement([]sdk.InTotoSubject{{Name: "my-binary", Digest: map[string]string{"sha256": "abcd"}}}, []byte(`{"bomFormat":"CycloneDX"}`))
+	require.NoError(t, err)
+	payload, err := json.Marshal(statement)
+	require.NoError(t, err)
+
+	// Instance attestation key
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPublic, err := sdk.EncodeRSAAttestationPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	instanceEnvelope, err := sdk.NewDSSEEnvelope(sdk.InTotoPayloadType, payload, "instance-key", func(message []byte) ([]byte, error) {
+		sum := sha256.Sum256(message)
+		return rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sum[:])
+	})
+	require.NoError(t, err)
+	instanceAttestation := sdk.V2WorkflowRunAttestation{Detail: sdk.V2WorkflowRunResultAttestationDetail{Signer: sdk.AttestationSignerInstance, Envelope: *instanceEnvelope}}
+
+	// Project attestation key
+	pgpKey, err := keys.GeneratePGPKeyPair(sdk.AttestationProjectKeyName, "", "")
+	require.NoError(t, err)
+	pgpPrivate, err := gpg.NewPrivateKeyFromPem(pgpKey.Private, "")
+	require.NoError(t, err)
+	projectEnvelope, err := sdk.NewDSSEEnvelope(sdk.InTotoPayloadType, payload, pgpKey.KeyID, func(message []byte) ([]byte, error) {
+		return pgpPrivate.GenerateSignature(string(message))
+	})
+	require.NoError(t, err)
+	projectAttestation := sdk.V2WorkflowRunAttestation{Detail: sdk.V2WorkflowRunResultAttestationDetail{Signer: sdk.AttestationSignerProject, Envelope: *projectEnvelope}}
+
+	apiKeys := []sdk.V2AttestationPublicKey{
+		{Signer: sdk.AttestationSignerProject, KeyID: pgpKey.KeyID, Type: sdk.AttestationKeyTypePGP, Public: pgpKey.Public},
+		{Signer: sdk.AttestationSignerInstance, KeyID: "instance-key", Type: sdk.AttestationKeyTypeRSA, Public: rsaPublic},
+	}
+
+	s, err := verifyAttestation(apiKeys, instanceAttestation, "sha256", "abcd")
+	require.NoError(t, err)
+	require.Equal(t, statement.Subject, s.Subject)
+	_, err = verifyAttestation(apiKeys, projectAttestation, "sha256", "abcd")
+	require.NoError(t, err)
+
+	// The signed statement must be about the digest
+	_, err = verifyAttestation(apiKeys, instanceAttestation, "sha256", "ef01")
+	require.Error(t, err)
+
+	// A signature made by another key is refused even if the API says it is verified
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	forgedEnvelope, err := sdk.NewDSSEEnvelope(sdk.InTotoPayloadType, payload, "instance-key", func(message []byte) ([]byte, error) {
+		sum := sha256.Sum256(message)
+		return rsa.SignPKCS1v15(rand.Reader, otherKey, crypto.SHA256, sum[:])
+	})
+	require.NoError(t, err)
+	forged := sdk.V2WorkflowRunAttestation{Verified: true, Detail: sdk.V2WorkflowRunResultAttestationDetail{Signer: sdk.AttestationSignerInstance, Envelope: *forgedEnvelope}}
+	_, err = verifyAttestation(apiKeys, forged, "sha256", "abcd")
+	require.Error(t, err)
+
+	// The key must belong to the signer of the attestation
+	_, err = verifyAttestation(apiKeys[1:], projectAttestation, "sha256", "abcd")
+	require.Error(t, err)
+
+	// A key given by the user is used whatever the key id
+	_, err = verifyAttestation([]sdk.V2AttestationPublicKey{attestationPublicKeyFromFile(rsaPublic)}, instanceAttestation, "sha256", "abcd")
+	require.NoError(t, err)
+	_, err = verifyAttestation([]sdk.V2AttestationPublicKey{attestationPublicKeyFromFile(pgpKey.Public)}, projectAttestation, "sha256", "abcd")
+	require.NoError(t, err)
+	_, err = verifyAttestation([]sdk.V2AttestationPublicKey{attestationPublicKeyFromFile(rsaPublic)}, projectAttestation, "sha256", "abcd")
+	require.Error(t, err)
+}
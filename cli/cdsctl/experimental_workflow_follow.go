@@ -0,0 +1,358 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowRunFollowColors = []func(format string, a ...interface{}) string{cli.Cyan, cli.Magenta, cli.Yellow, cli.Blue, cli.Green}
+
+// workflowRunStatusExitCode maps the final status of a workflow run to the exit code of the command.
+func workflowRunStatusExitCode(status sdk.V2WorkflowRunStatus) int {
+	switch status {
+	case sdk.V2WorkflowRunStatusSuccess, sdk.V2WorkflowRunStatusSkipped:
+		return 0
+	case sdk.V2WorkflowRunStatusFail:
+		return 1
+	case sdk.V2WorkflowRunStatusStopped:
+		return 2
+	case sdk.V2WorkflowRunStatusCancelled:
+		return 3
+	}
+	return 50
+}
+
+// workflowRunStatusError returns an error with the exit code of the final status, nil if the run succeeded.
+func workflowRunStatusError(runID string, status sdk.V2WorkflowRunStatus) error {
+	code := workflowRunStatusExitCode(status)
+	if code == 0 {
+		return nil
+	}
+	return &cli.Error{
+		Code: code,
+		Err:  fmt.Errorf("workflow run %s ended with status %s", runID, status),
+	}
+}
+
+// workflowRunFollower polls a workflow run until it ends. When logs are enabled, the logs of running jobs
+// are streamed from the CDN and the logs of ended jobs are downloaded.
+type workflowRunFollower struct {
+	projKey    string
+	runID      string
+	logs       bool
+	pattern    *regexp.Regexp
+	cdnURL     string
+	goRoutines *sdk.GoRoutines
+	mutex      sync.Mutex
+	jobs       map[string]*workflowRunJobFollower
+}
+
+type workflowRunJobFollower struct {
+	runJob           sdk.V2WorkflowRunJob
+	prefix           string
+	steps            map[string]*workflowRunStepFollower
+	currentStep      string
+	cancel           context.CancelFunc
+	done             bool
+	downloadAttempts int
+}
+
+type workflowRunStepFollower struct {
+	name     string
+	link     sdk.CDNLogLink
+	nextLine int64
+}
+
+func newWorkflowRunFollower(projKey, runID string, logs bool, pattern *regexp.Regexp) (*workflowRunFollower, error) {
+	f := &workflowRunFollower{
+		projKey: projKey,
+		runID:   runID,
+		logs:    logs,
+		pattern: pattern,
+		jobs:    make(map[string]*workflowRunJobFollower),
+	}
+	if logs {
+		cdnURL, err := client.CDNURL()
+		if err != nil {
+			return nil, err
+		}
+		f.cdnURL = cdnURL
+	}
+	return f, nil
+}
+
+// Follow returns the final status of the workflow run.
+func (f *workflowRunFollower) Follow(ctx context.Context) (sdk.V2WorkflowRunStatus, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	f.goRoutines = sdk.NewGoRoutines(ctx)
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	var terminatedPolls int
+	for {
+		run, err := client.WorkflowV2RunStatus(ctx, f.projKey, f.runID)
+		if err != nil {
+			return "", err
+		}
+		if f.logs {
+			runJobs, err := client.WorkflowV2RunJobs(ctx, f.projKey, f.runID)
+			if err != nil {
+				return "", err
+			}
+			for _, rj := range runJobs {
+				if f.pattern != nil && !f.pattern.MatchString(rj.JobID) {
+					continue
+				}
+				if err := f.followJob(ctx, rj); err != nil {
+					return "", err
+				}
+			}
+		}
+		if run.Status.IsTerminated() {
+			terminatedPolls++
+			if f.jobsDone() || terminatedPolls > 5 {
+				return run.Status, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *workflowRunFollower) followJob(ctx context.Context, rj sdk.V2WorkflowRunJob) error {
+	f.mutex.Lock()
+	jf, has := f.jobs[rj.ID]
+	if !has {
+		name := rj.JobID
+		if len(rj.Matrix) > 0 {
+			name += "(" + rj.Matrix.String() + ")"
+		}
+		color := workflowRunFollowColors[len(f.jobs)%len(workflowRunFollowColors)]
+		jf = &workflowRunJobFollower{
+			prefix: color("[%s]", name),
+			steps:  make(map[string]*workflowRunStepFollower),
+		}
+		f.jobs[rj.ID] = jf
+	}
+	previousStatus := jf.runJob.Status
+	jf.runJob = rj
+	f.mutex.Unlock()
+
+	if jf.done {
+		return nil
+	}
+	if previousStatus != rj.Status {
+		f.print(jf, "%s job %s", cli.Arrow, rj.Status)
+	}
+
+	if !rj.Status.IsTerminated() {
+		if rj.Status == sdk.V2WorkflowRunJobStatusBuilding && jf.cancel == nil {
+			if err := f.refreshSteps(ctx, jf); err != nil {
+				return err
+			}
+			f.stream(ctx, jf)
+		}
+		return nil
+	}
+
+	// Stop the stream then print the lines that were not received
+	if jf.cancel != nil {
+		jf.cancel()
+	}
+	if err := f.refreshSteps(ctx, jf); err != nil {
+		return err
+	}
+	f.mutex.Lock()
+	steps := make([]*workflowRunStepFollower, 0, len(jf.steps))
+	for _, s := range jf.steps {
+		steps = append(steps, s)
+	}
+	f.mutex.Unlock()
+	complete := true
+	for _, s := range workflowRunSortSteps(rj, steps) {
+		data, err := client.WorkflowLogDownload(ctx, s.link)
+		if err != nil {
+			// The logs can be available a few seconds after the end of the job
+			if strings.Contains(err.Error(), "resource not found") {
+				complete = false
+				continue
+			}
+			return err
+		}
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		for i := s.nextLine; i < int64(len(lines)); i++ {
+			f.printLine(jf, s, i, lines[i])
+		}
+	}
+	jf.downloadAttempts++
+	jf.done = complete || jf.downloadAttempts >= 5
+	return nil
+}
+
+func (f *workflowRunFollower) jobsDone() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, jf := range f.jobs {
+		if !jf.done {
+			return false
+		}
+	}
+	return true
+}
+
+// refreshSteps loads the log links of the steps started by the job.
+func (f *workflowRunFollower) refreshSteps(ctx context.Context, jf *workflowRunJobFollower) error {
+	f.mutex.Lock()
+	rj := jf.runJob
+	f.mutex.Unlock()
+	links, err := client.WorkflowV2RunJobLogLinks(ctx, f.projKey, f.runID, rj.ID)
+	if err != nil {
+		return err
+	}
+
+	stepNames := workflowRunJobStepNames(rj)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for i, link := range links.Data {
+		if _, has := jf.steps[link.APIRef]; has || i >= len(stepNames) {
+			continue
+		}
+		jf.steps[link.APIRef] = &workflowRunStepFollower{
+			name: stepNames[i],
+			link: sdk.CDNLogLink{APIRef: link.APIRef, ItemType: link.ItemType},
+		}
+	}
+	return nil
+}
+
+// stream listens the CDN websocket for the logs of the given job until the job ends.
+func (f *workflowRunFollower) stream(ctx context.Context, jf *workflowRunJobFollower) {
+	ctx, jf.cancel = context.WithCancel(ctx)
+	runJobID := jf.runJob.ID
+
+	filter, _ := json.Marshal(sdk.CDNStreamFilter{JobRunID: runJobID})
+	chanMessageToSend := make(chan json.RawMessage, 1)
+	chanMsgReceived := make(chan json.RawMessage)
+	chanErrorReceived := make(chan error)
+
+	f.goRoutines.Exec(ctx, "workflowRunFollower.websocket."+runJobID, func(ctx context.Context) {
+		for ctx.Err() == nil {
+			// The filter is sent again on each connection
+			select {
+			case chanMessageToSend <- filter:
+			default:
+			}
+			if err := client.RequestWebsocket(ctx, f.goRoutines, fmt.Sprintf("%s/item/stream", f.cdnURL), chanMessageToSend, chanMsgReceived, chanErrorReceived); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			}
+			time.Sleep(1 * time.Second)
+		}
+	})
+
+	f.goRoutines.Exec(ctx, "workflowRunFollower.lines."+runJobID, func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-chanErrorReceived:
+				if cli.Verbose {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				}
+			case m := <-chanMsgReceived:
+				var line struct {
+					Number     int64  `json:"number"`
+					Value      string `json:"value"`
+					ApiRefHash string `json:"api_ref_hash"`
+				}
+				if err := json.Unmarshal(m, &line); err != nil {
+					continue
+				}
+				f.mutex.Lock()
+				s, has := jf.steps[line.ApiRefHash]
+				f.mutex.Unlock()
+				if !has {
+					// A new step started since the last refresh
+					if err := f.refreshSteps(ctx, jf); err != nil {
+						continue
+					}
+					f.mutex.Lock()
+					s, has = jf.steps[line.ApiRefHash]
+					f.mutex.Unlock()
+					if !has {
+						continue
+					}
+				}
+				f.printLine(jf, s, line.Number, strings.TrimSuffix(line.Value, "\n"))
+			}
+		}
+	})
+}
+
+func (f *workflowRunFollower) printLine(jf *workflowRunJobFollower, s *workflowRunStepFollower, number int64, value string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if number < s.nextLine {
+		return
+	}
+	if jf.currentStep != s.name {
+		jf.currentStep = s.name
+		fmt.Printf("%s %s step %s\n", jf.prefix, cli.Arrow, s.name)
+	}
+	fmt.Printf("%s %s\n", jf.prefix, value)
+	s.nextLine = number + 1
+}
+
+func (f *workflowRunFollower) print(jf *workflowRunJobFollower, format string, args ...interface{}) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	fmt.Printf("%s %s\n", jf.prefix, fmt.Sprintf(format, args...))
+}
+
+// workflowRunJobStepNames returns the names of the started steps in the order of their log links: the steps,
+// then the post steps in reverse order.
+func workflowRunJobStepNames(rj sdk.V2WorkflowRunJob) []string {
+	stepNames := make([]string, 0, len(rj.StepsStatus))
+	for i, s := range rj.Job.Steps {
+		stepName := sdk.GetJobStepName(s.ID, i)
+		if _, ok := rj.StepsStatus[stepName]; ok {
+			stepNames = append(stepNames, stepName)
+		}
+	}
+	for i := len(rj.Job.Steps) - 1; i >= 0; i-- {
+		stepName := sdk.GetJobStepName(rj.Job.Steps[i].ID, i)
+		if _, ok := rj.StepsStatus["Post-"+stepName]; ok {
+			stepNames = append(stepNames, "Post-"+stepName)
+		}
+	}
+	return stepNames
+}
+
+// workflowRunSortSteps returns the steps in their execution order.
+func workflowRunSortSteps(rj sdk.V2WorkflowRunJob, steps []*workflowRunStepFollower) []*workflowRunStepFollower {
+	order := make(map[string]int)
+	for i, s := range rj.Job.Steps {
+		stepName := sdk.GetJobStepName(s.ID, i)
+		order[stepName] = i
+		order["Post-"+stepName] = 2*len(rj.Job.Steps) - i
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return order[steps[i].name] < order[steps[j].name]
+	})
+	return steps
+}
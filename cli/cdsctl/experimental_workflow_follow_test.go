@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_workflowRunStatusError(t *testing.T) {
+	tests := []struct {
+		status sdk.V2WorkflowRunStatus
+		code   int
+	}{
+		{status: sdk.V2WorkflowRunStatusSuccess, code: 0},
+		{status: sdk.V2WorkflowRunStatusSkipped, code: 0},
+		{status: sdk.V2WorkflowRunStatusFail, code: 1},
+		{status: sdk.V2WorkflowRunStatusStopped, code: 2},
+		{status: sdk.V2WorkflowRunStatusCancelled, code: 3},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			require.Equal(t, tt.code, workflowRunStatusExitCode(tt.status))
+
+			err := workflowRunStatusError("run-id", tt.status)
+			if tt.code == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var cliErr *cli.Error
+			require.True(t, errors.As(err, &cliErr))
+			require.Equal(t, tt.code, cliErr.Code)
+			require.Contains(t, cliErr.Error(), "run-id")
+		})
+	}
+}
+
+func Test_workflowRunJobStepNames(t *testing.T) {
+	rj := sdk.V2WorkflowRunJob{
+		Job: sdk.V2Job{Steps: []sdk.ActionStep{{ID: "checkout"}, {}, {ID: "deploy"}, {ID: "not-started"}}},
+		StepsStatus: sdk.JobStepsStatus{
+			"checkout":      {},
+			"step-1":        {},
+			"deploy":        {},
+			"Post-checkout": {},
+			"Post-deploy":   {},
+		},
+	}
+	require.Equal(t, []string{"checkout", "step-1", "deploy", "Post-deploy", "Post-checkout"}, workflowRunJobStepNames(rj))
+	require.Empty(t, workflowRunJobStepNames(sdk.V2WorkflowRunJob{}))
+}
+
+func Test_workflowRunSortSteps(t *testing.T) {
+	rj := sdk.V2WorkflowRunJob{
+		Job: sdk.V2Job{Steps: []sdk.ActionStep{{ID: "checkout"}, {}, {ID: "deploy"}}},
+	}
+	steps := []*workflowRunStepFollower{
+		{name: "Post-checkout"},
+		{name: "deploy"},
+		{name: "Post-deploy"},
+		{name: "step-1"},
+		{name: "checkout"},
+	}
+	sorted := workflowRunSortSteps(rj, steps)
+	names := make([]string, 0, len(sorted))
+	for _, s := range sorted {
+		names = append(names, s.name)
+	}
+	require.Equal(t, []string{"checkout", "step-1", "deploy", "Post-deploy", "Post-checkout"}, names)
+}
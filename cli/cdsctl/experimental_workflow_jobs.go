@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 )
 
 var experimentalWorkflowJobCmd = cli.Command{
@@ -25,6 +27,9 @@ func experimentalWorkflowJob() *cobra.Command {
 		cli.NewCommand(workflowRunStartJobCmd, workflowRunStartJobFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowRunJobInfoCmd, workflowRunJobInfoFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowRunJobListRetriesCmd, workflowRunJobListRetriesFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowRunJobDebugCmd, workflowRunJobDebugFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowRunJobDebugSessionsCmd, workflowRunJobDebugSessionsFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowRunJobReplayCmd, workflowRunJobReplayFunc, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -32,7 +37,8 @@ var workflowRunStartJobCmd = cli.Command{
 	Name:    "run",
 	Aliases: []string{"start"},
 	Short:   "Start a job",
-	Example: "cdsctl workflow run <proj_key> <workflow_run_id> <job_identifier>",
+	Long:    "Start a job waiting on a gate or re-run a job. Use --env, --model and --debug to override the definition of the job for this run.",
+	Example: "cdsctl workflow run <proj_key> <workflow_run_id> <job_identifier> --env MY_VAR=my-value --debug",
 	Ctx:     []cli.Arg{},
 	Args: []cli.Arg{
 		{Name: "proj_key"},
@@ -44,6 +50,20 @@ var workflowRunStartJobCmd = cli.Command{
 			Name:    "data",
 			Default: "{}",
 		},
+		{
+			Type:  cli.FlagArray,
+			Name:  "env",
+			Usage: "Add env variables to the job like --env KEY=VALUE --env KEY2=VALUE2",
+		},
+		{
+			Name:  "model",
+			Usage: "Run the job on the given worker model",
+		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "debug",
+			Usage: "Run the job with the debug log level",
+		},
 	},
 }
 
@@ -56,7 +76,26 @@ func workflowRunStartJobFunc(v cli.Values) error {
 	if err := json.Unmarshal([]byte(data), &payload); err != nil {
 		return fmt.Errorf("unable to read json data")
 	}
-	run, err := client.WorkflowV2JobStart(context.Background(), projKey, workflowRunID, jobIdentifier, payload)
+	env, err := workflowRunJobEnvFlag(v)
+	if err != nil {
+		return err
+	}
+	mods := []cdsclient.RequestModifier{
+		func(req *http.Request) {
+			q := req.URL.Query()
+			for k, value := range env {
+				q.Add("env", k+"="+value)
+			}
+			req.URL.RawQuery = q.Encode()
+		},
+	}
+	if v.GetString("model") != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("model", v.GetString("model")))
+	}
+	if v.GetBool("debug") {
+		mods = append(mods, cdsclient.WithQueryParameter("debug", "true"))
+	}
+	run, err := client.WorkflowV2JobStart(context.Background(), projKey, workflowRunID, jobIdentifier, payload, mods...)
 	if err != nil {
 		return err
 	}
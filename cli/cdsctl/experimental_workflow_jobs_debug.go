@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowRunJobDebugCmd = cli.Command{
+	Name:  "debug",
+	Short: "Open a shell on the worker of a job waiting for debug sessions",
+	Long: `Open a shell on the worker of a job waiting for debug sessions. The job must set the debug option and you must have the debug role on the workflow.
+
+There is no terminal on the worker: each line typed is sent to the shell of the worker. Exit the shell or press Ctrl+C to close the session. Use --release to stop the debug of the job: the worker sends the job result and exits.`,
+	Example: "cdsctl experimental workflow jobs debug <proj_key> <workflow_run_id> <job_identifier>",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+		{Name: "job_identifier"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "release",
+			Type:  cli.FlagBool,
+			Usage: "Release the job without opening a shell",
+		},
+	},
+}
+
+func workflowRunJobDebugFunc(v cli.Values) error {
+	projKey := v.GetString("proj_key")
+	workflowRunID := v.GetString("workflow_run_id")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runJob, err := workflowRunJobDebugFind(ctx, projKey, workflowRunID, v.GetString("job_identifier"))
+	if err != nil {
+		return err
+	}
+	if runJob.Status != sdk.V2WorkflowRunJobStatusBuilding {
+		return cli.NewError("job %s is %s, it can't be debugged", runJob.JobID, runJob.Status)
+	}
+
+	goRoutines := sdk.NewGoRoutines(ctx)
+	chanMsgToSend := make(chan json.RawMessage, 10)
+	chanMsgReceived := make(chan json.RawMessage)
+	chanErrorReceived := make(chan error)
+	chanWSEnded := make(chan error, 1)
+	goRoutines.Exec(ctx, "workflowRunJobDebug.websocket", func(ctx context.Context) {
+		chanWSEnded <- client.WorkflowV2RunJobDebug(ctx, goRoutines, projKey, workflowRunID, runJob.ID, chanMsgToSend, chanMsgReceived, chanErrorReceived)
+	})
+	send := func(msg sdk.V2JobDebugMessage) {
+		bts, _ := json.Marshal(msg)
+		chanMsgToSend <- bts
+	}
+
+	if v.GetBool("release") {
+		send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageRelease})
+	} else {
+		fmt.Fprintf(os.Stderr, "Debug session opened on job %s, exit the shell to close it\n", runJob.JobID)
+		goRoutines.Exec(ctx, "workflowRunJobDebug.stdin", func(ctx context.Context) {
+			reader := bufio.NewReader(os.Stdin)
+			for {
+				line, err := reader.ReadString('\n')
+				if line != "" {
+					send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageInput, Data: line})
+				}
+				if err != nil {
+					if err != io.EOF {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					}
+					send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageEnd})
+					return
+				}
+			}
+		})
+	}
+
+	chanSignal := make(chan os.Signal, 1)
+	signal.Notify(chanSignal, os.Interrupt)
+	defer signal.Stop(chanSignal)
+
+	var timeout <-chan time.Time
+	for {
+		select {
+		case <-chanSignal:
+			send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageEnd})
+			// Let the session end before closing the websocket
+			timeout = time.After(2 * time.Second)
+		case <-timeout:
+			return nil
+		case err := <-chanWSEnded:
+			if err != nil && ctx.Err() == nil {
+				return cli.WrapError(err, "debug session closed")
+			}
+			return nil
+		case err := <-chanErrorReceived:
+			if cli.Verbose {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		case m := <-chanMsgReceived:
+			var msg sdk.V2JobDebugMessage
+			if err := json.Unmarshal(m, &msg); err != nil {
+				continue
+			}
+			switch msg.Type {
+			case sdk.V2JobDebugMessageOutput:
+				fmt.Print(msg.Data)
+			case sdk.V2JobDebugMessageEnd:
+				fmt.Fprintln(os.Stderr, "Debug session closed")
+				return nil
+			case sdk.V2JobDebugMessageRelease:
+				fmt.Fprintf(os.Stderr, "Job %s released\n", runJob.JobID)
+				return nil
+			}
+		}
+	}
+}
+
+// workflowRunJobDebugFind returns the run job matching the given identifier, the building one first.
+func workflowRunJobDebugFind(ctx context.Context, projKey, workflowRunID, jobIdentifier string) (*sdk.V2WorkflowRunJob, error) {
+	if sdk.IsValidUUID(jobIdentifier) {
+		return client.WorkflowV2RunJob(ctx, projKey, workflowRunID, jobIdentifier)
+	}
+	runJobs, err := client.WorkflowV2RunJobs(ctx, projKey, workflowRunID)
+	if err != nil {
+		return nil, err
+	}
+	var found *sdk.V2WorkflowRunJob
+	for i := range runJobs {
+		if runJobs[i].JobID != jobIdentifier {
+			continue
+		}
+		if found == nil || runJobs[i].Status == sdk.V2WorkflowRunJobStatusBuilding {
+			found = &runJobs[i]
+		}
+	}
+	if found == nil {
+		return nil, cli.NewError("not matching job found for given identifier %q", jobIdentifier)
+	}
+	return found, nil
+}
+
+var workflowRunJobDebugSessionsCmd = cli.Command{
+	Name:    "debug-sessions",
+	Short:   "List the debug sessions opened on a job",
+	Example: "cdsctl experimental workflow jobs debug-sessions <proj_key> <workflow_run_id> <job_identifier>",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+		{Name: "job_identifier"},
+	},
+}
+
+func workflowRunJobDebugSessionsFunc(v cli.Values) (cli.ListResult, error) {
+	projKey := v.GetString("proj_key")
+	workflowRunID := v.GetString("workflow_run_id")
+
+	runJob, err := workflowRunJobDebugFind(context.Background(), projKey, workflowRunID, v.GetString("job_identifier"))
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := client.WorkflowV2RunJobDebugSessions(context.Background(), projKey, workflowRunID, runJob.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(sessions), nil
+}
+
+var workflowRunJobReplayCmd = cli.Command{
+	Name:  "replay",
+	Short: "Export a job to replay it locally with the worker",
+	Long: `Export a job with its resolved contexts, its steps and its non secret variables to replay it locally with "worker replay".
+
+The secrets are not exported: the vcs token, the secret variables and the integrations. The plugin binaries used by the job must be downloaded in the plugins directory of the worker.`,
+	Example: "cdsctl experimental workflow jobs replay <proj_key> <workflow_run_id> <job_identifier> --output my-job.json",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+		{Name: "job_identifier"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:      "output",
+			ShortHand: "o",
+			Usage:     "File written with the exported job, default <job_identifier>.json",
+		},
+	},
+}
+
+func workflowRunJobReplayFunc(v cli.Values) error {
+	projKey := v.GetString("proj_key")
+	workflowRunID := v.GetString("workflow_run_id")
+
+	runJob, err := workflowRunJobDebugFind(context.Background(), projKey, workflowRunID, v.GetString("job_identifier"))
+	if err != nil {
+		return err
+	}
+	replay, err := client.WorkflowV2RunJobReplay(context.Background(), projKey, workflowRunID, runJob.ID)
+	if err != nil {
+		return err
+	}
+	bts, err := json.MarshalIndent(replay, "", "  ")
+	if err != nil {
+		return cli.WrapError(err, "unable to marshal job")
+	}
+	output := v.GetString("output")
+	if output == "" {
+		output = runJob.JobID + ".json"
+	}
+	if err := os.WriteFile(output, bts, 0600); err != nil {
+		return cli.WrapError(err, "unable to write file %s", output)
+	}
+
+	for _, s := range replay.OmittedSecrets {
+		fmt.Fprintf(os.Stderr, "Secret %s is not exported\n", s)
+	}
+	fmt.Printf("Job %s exported to %s, run it with: worker replay %s\n", runJob.JobID, output, output)
+	return nil
+}
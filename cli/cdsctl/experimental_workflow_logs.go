@@ -22,6 +22,7 @@ var experimentalWorkflowRunJobsCmd = cli.Command{
 func experimentalWorkflowRunLogs() *cobra.Command {
 	return cli.NewCommand(experimentalWorkflowRunJobsCmd, nil, []*cobra.Command{
 		cli.NewCommand(workflowRunJobLogsDownloadCmd, workflowRunJobLogsDownloadFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowRunJobLogsFollowCmd, workflowRunJobLogsFollowFunc, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -70,25 +71,11 @@ func workflowRunJobLogsDownloadFunc(v cli.Values) error {
 			return err
 		}
 
-		steps := make([]string, 0)
-
-		// Foreach step create a ref if a step status exists
-		for i, s := range rj.Job.Steps {
-			stepName := sdk.GetJobStepName(s.ID, i)
-			if _, ok := rj.StepsStatus[stepName]; ok {
-				steps = append(steps, stepName)
-			}
-		}
-
-		// Foreach step create a ref if a post step status exists
-		for i := len(rj.Job.Steps) - 1; i >= 0; i-- {
-			stepName := sdk.GetJobStepName(rj.Job.Steps[i].ID, i)
-			if _, ok := rj.StepsStatus["Post-"+stepName]; ok {
-				steps = append(steps, "Post-"+stepName)
-			}
-		}
-
+		steps := workflowRunJobStepNames(rj)
 		for idx, link := range links.Data {
+			if idx >= len(steps) {
+				break
+			}
 			fileName := getFileName(rj, steps[idx])
 			data, err := client.WorkflowLogDownload(context.Background(), sdk.CDNLogLink{APIRef: link.APIRef, ItemType: link.ItemType})
 			if err != nil {
@@ -107,6 +94,50 @@ func workflowRunJobLogsDownloadFunc(v cli.Values) error {
 	return nil
 }
 
+var workflowRunJobLogsFollowCmd = cli.Command{
+	Name:    "follow",
+	Aliases: []string{"stream", "tail"},
+	Short:   "Stream the logs of the workflow run jobs until the run ends",
+	Long:    "Stream the logs of the workflow run jobs until the run ends. The command exits with code 0 if the run succeeded, 1 if it failed, 2 if it was stopped and 3 if it was cancelled.",
+	Example: "cdsctl experimental workflow logs follow <proj_key> <workflow_run_id>",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "pattern",
+			Usage: "Filter on job name",
+		},
+	},
+}
+
+func workflowRunJobLogsFollowFunc(v cli.Values) error {
+	projKey := v.GetString("proj_key")
+	workflowRunID := v.GetString("workflow_run_id")
+
+	var reg *regexp.Regexp
+	if v.GetString("pattern") != "" {
+		var err error
+		reg, err = regexp.Compile(v.GetString("pattern"))
+		if err != nil {
+			return cli.NewError("invalid pattern %q", v.GetString("pattern"))
+		}
+	}
+
+	follower, err := newWorkflowRunFollower(projKey, workflowRunID, true, reg)
+	if err != nil {
+		return err
+	}
+	status, err := follower.Follow(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Workflow run %s ended with status %s\n", workflowRunID, status)
+	return workflowRunStatusError(workflowRunID, status)
+}
+
 func getFileName(rj sdk.V2WorkflowRunJob, name string) string {
 	return fmt.Sprintf("%s-%d-%d-%s-%s", rj.WorkflowName, rj.RunNumber, rj.RunAttempt, rj.JobID, name)
 }
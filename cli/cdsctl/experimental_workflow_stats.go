@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var workflowRunStatsCmd = cli.Command{
+	Name:    "stats",
+	Aliases: []string{"stat"},
+	Short:   "Display delivery stats (deployment frequency, lead time, change failure rate, time to restore, durations) of workflow runs",
+	Example: "cdsctl experimental workflow stats MY-PROJECT --workflow github/my/repo/build --from 2024-01-01T00:00:00Z --interval week",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "workflow", Usage: "<vcs>/<repo>/<workflow_name>"},
+		{Name: "repository", Usage: "<vcs>/<repo> of the workflow"},
+		{Name: "ref", Usage: "Filter on git ref"},
+		{Name: "from", Usage: "RFC3339 start date, default to 30 days before --to"},
+		{Name: "to", Usage: "RFC3339 end date, default to now"},
+		{Name: "interval", Usage: "Trend interval: day or week", Default: sdk.V2WorkflowRunStatsIntervalDay},
+	},
+	Mcp: true,
+}
+
+type workflowRunStatsLine struct {
+	Period              string  `cli:"period"`
+	Runs                int     `cli:"runs"`
+	Deployments         int     `cli:"deployments"`
+	DeploymentFrequency float64 `cli:"deployments_per_day"`
+	ChangeFailureRate   float64 `cli:"change_failure_rate"`
+	LeadTimeP50         float64 `cli:"lead_time_p50_s"`
+	TimeToRestoreP50    float64 `cli:"time_to_restore_p50_s"`
+	DurationP50         float64 `cli:"duration_p50_s"`
+	DurationP95         float64 `cli:"duration_p95_s"`
+	QueueTimeP50        float64 `cli:"queue_time_p50_s"`
+	QueueTimeP95        float64 `cli:"queue_time_p95_s"`
+}
+
+func newWorkflowRunStatsLine(period string, s sdk.V2WorkflowRunStatsSummary) workflowRunStatsLine {
+	return workflowRunStatsLine{
+		Period:              period,
+		Runs:                s.Runs,
+		Deployments:         s.Deployments,
+		DeploymentFrequency: s.DeploymentFrequency,
+		ChangeFailureRate:   s.ChangeFailureRate,
+		LeadTimeP50:         s.LeadTime.P50,
+		TimeToRestoreP50:    s.TimeToRestore.P50,
+		DurationP50:         s.Duration.P50,
+		DurationP95:         s.Duration.P95,
+		QueueTimeP50:        s.QueueTime.P50,
+		QueueTimeP95:        s.QueueTime.P95,
+	}
+}
+
+func workflowRunStatsFunc(v cli.Values) (cli.ListResult, error) {
+	var mods []cdsclient.RequestModifier
+	for _, k := range []string{"ref", "from", "to", "interval"} {
+		if v.GetString(k) != "" {
+			mods = append(mods, cdsclient.WithQueryParameter(k, v.GetString(k)))
+		}
+	}
+	if v.GetString("workflow") != "" {
+		mods = append(mods, cdsclient.Workflows(v.GetString("workflow")))
+	}
+	if v.GetString("repository") != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("workflow_repository", v.GetString("repository")))
+	}
+
+	stats, err := client.WorkflowV2RunStats(context.Background(), v.GetString(_ProjectKey), mods...)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []workflowRunStatsLine{newWorkflowRunStatsLine("total", stats.V2WorkflowRunStatsSummary)}
+	for _, b := range stats.Trend {
+		lines = append(lines, newWorkflowRunStatsLine(b.Start.Format("2006-01-02"), b.V2WorkflowRunStatsSummary))
+	}
+	for _, w := range stats.Workflows {
+		lines = append(lines, newWorkflowRunStatsLine(w.Workflow, w.V2WorkflowRunStatsSummary))
+	}
+	return cli.AsListResult(lines), nil
+}
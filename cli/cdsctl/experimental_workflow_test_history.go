@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var experimentalWorkflowTestCmd = cli.Command{
+	Name:    "test",
+	Short:   "CDS Experimental workflow test history commands",
+	Aliases: []string{"tests"},
+}
+
+func experimentalWorkflowTest() *cobra.Command {
+	return cli.NewCommand(experimentalWorkflowTestCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowTestFlakyCmd, workflowTestFlakyFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTestHistoryCmd, workflowTestHistoryFunc, nil, withAllCommandModifiers()...),
+		experimentalWorkflowTestQuarantine(),
+	})
+}
+
+var workflowTestFlakyCmd = cli.Command{
+	Name:    "flaky",
+	Short:   "List the flaky tests of a workflow",
+	Example: "cdsctl experimental workflow test flaky MY-PROJECT github/my/repo/build --days 30",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "workflow"},
+	},
+	Flags: []cli.Flag{
+		{Name: "ref", Usage: "Filter on git ref"},
+		{Name: "days", Usage: "Number of days of history to analyze"},
+		{Name: "all", Type: cli.FlagBool, Usage: "Display all the tests that failed, not only the flaky ones"},
+	},
+	Mcp: true,
+}
+
+func workflowTestFlakyFunc(v cli.Values) (cli.ListResult, error) {
+	var mods []cdsclient.RequestModifier
+	for _, k := range []string{"ref", "days"} {
+		if v.GetString(k) != "" {
+			mods = append(mods, cdsclient.WithQueryParameter(k, v.GetString(k)))
+		}
+	}
+	if v.GetBool("all") {
+		mods = append(mods, cdsclient.WithQueryParameter("all", "true"))
+	}
+	tests, err := client.WorkflowV2TestFlaky(context.Background(), v.GetString(_ProjectKey), v.GetString("workflow"), mods...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(tests), nil
+}
+
+var workflowTestHistoryCmd = cli.Command{
+	Name:    "history",
+	Short:   "Display the executions of a test across the runs of a workflow",
+	Example: "cdsctl experimental workflow test history MY-PROJECT github/my/repo/build my-suite TestMyFeature",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "workflow"},
+		{Name: "suite"},
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{Name: "ref", Usage: "Filter on git ref"},
+		{Name: "limit", Usage: "Maximum number of executions"},
+	},
+	Mcp: true,
+}
+
+func workflowTestHistoryFunc(v cli.Values) (cli.ListResult, error) {
+	var mods []cdsclient.RequestModifier
+	for _, k := range []string{"ref", "limit"} {
+		if v.GetString(k) != "" {
+			mods = append(mods, cdsclient.WithQueryParameter(k, v.GetString(k)))
+		}
+	}
+	history, err := client.WorkflowV2TestHistory(context.Background(), v.GetString(_ProjectKey), v.GetString("workflow"), v.GetString("suite"), v.GetString("name"), mods...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(history.History), nil
+}
+
+var experimentalWorkflowTestQuarantineCmd = cli.Command{
+	Name:  "quarantine",
+	Short: "Manage the quarantined tests, their failures are not blocking",
+}
+
+func experimentalWorkflowTestQuarantine() *cobra.Command {
+	return cli.NewCommand(experimentalWorkflowTestQuarantineCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowTestQuarantineListCmd, workflowTestQuarantineListFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowTestQuarantineAddCmd, workflowTestQuarantineAddFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(workflowTestQuarantineDeleteCmd, workflowTestQuarantineDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowTestQuarantineListCmd = cli.Command{
+	Name:    "list",
+	Short:   "List the quarantined tests of the project",
+	Example: "cdsctl experimental workflow test quarantine list MY-PROJECT --workflow github/my/repo/build",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "workflow", Usage: "<vcs>/<repo>/<workflow_name>"},
+	},
+	Mcp: true,
+}
+
+func workflowTestQuarantineListFunc(v cli.Values) (cli.ListResult, error) {
+	var mods []cdsclient.RequestModifier
+	if v.GetString("workflow") != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("workflow", v.GetString("workflow")))
+	}
+	quarantines, err := client.WorkflowV2TestQuarantineList(context.Background(), v.GetString(_ProjectKey), mods...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(quarantines), nil
+}
+
+var workflowTestQuarantineAddCmd = cli.Command{
+	Name:    "add",
+	Short:   "Quarantine a test of a workflow",
+	Example: "cdsctl experimental workflow test quarantine add MY-PROJECT github/my/repo/build TestMyFeature --suite my-suite --reason \"timeouts on slow runners\"",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "workflow"},
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{Name: "suite", Usage: "Test suite, quarantine the test in all suites if empty"},
+		{Name: "reason"},
+	},
+}
+
+func workflowTestQuarantineAddFunc(v cli.Values) error {
+	vcsName, repoName, workflowName, err := sdk.ParseV2WorkflowFullName(v.GetString("workflow"))
+	if err != nil {
+		return err
+	}
+	_, err = client.WorkflowV2TestQuarantineAdd(context.Background(), v.GetString(_ProjectKey), sdk.V2WorkflowTestQuarantine{
+		VCSServer:    vcsName,
+		Repository:   repoName,
+		WorkflowName: workflowName,
+		Suite:        v.GetString("suite"),
+		Name:         v.GetString("name"),
+		Reason:       v.GetString("reason"),
+	})
+	return err
+}
+
+var workflowTestQuarantineDeleteCmd = cli.Command{
+	Name:    "delete",
+	Short:   "Remove a test from quarantine",
+	Example: "cdsctl experimental workflow test quarantine delete MY-PROJECT <quarantine_id>",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func workflowTestQuarantineDeleteFunc(v cli.Values) error {
+	return client.WorkflowV2TestQuarantineDelete(context.Background(), v.GetString(_ProjectKey), v.GetString("id"))
+}
@@ -42,7 +42,14 @@ func mcpCommands() *cobra.Command {
 var mcpStartCmd = cli.Command{
 	Name:  "start",
 	Short: "Start mcp server",
-	Ctx:   []cli.Arg{},
+	Long: `Start a MCP server exposing CDS to AI assistants.
+
+Besides the read commands, the server exposes tools to get the logs of the failed steps of a workflow run,
+to diff the workflow definition of a run with the previous successful run, to lint a workflow, to explain
+a permission and to trigger a gated job with typed inputs. The JSON schemas of the entities are exposed as
+resources (cds://schema/workflow, ...). The tools and resources are exposed only if the consumer used by
+cdsctl has the required scopes.`,
+	Ctx: []cli.Arg{},
 	Flags: []cli.Flag{
 		{
 			Name: "mode",
@@ -86,6 +93,7 @@ func mcpStartRun(v cli.Values) error {
 		},
 	)
 	registerTools(server, v)
+	registerRichTools(server)
 
 	if v.GetString("mode") == "stdio" {
 		runStdioMode(server, v)
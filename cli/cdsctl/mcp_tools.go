@@ -0,0 +1,534 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rockbears/yaml"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+const (
+	mcpDefaultLogLines = 100
+	mcpMaxLogLines     = 2000
+)
+
+// mcpScopes checks the tools against the scopes of the consumer used by cdsctl. The API checks the scopes of each
+// call, the check only avoids to expose tools that always fail.
+type mcpScopes sdk.AuthConsumerScopeDetails
+
+// Allow returns true if the consumer has one of the given scopes. A consumer without scope has all of them, a tool
+// without scope is always allowed.
+func (s mcpScopes) Allow(scopes ...sdk.AuthConsumerScope) bool {
+	if len(s) == 0 || len(scopes) == 0 {
+		return true
+	}
+	for _, d := range s {
+		for _, scope := range scopes {
+			if d.Scope == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// registerRichTools adds the tools and the resources that are not mapped on a command.
+func registerRichTools(server *mcp.Server) {
+	mcpLog.logTrace("registerRichTools", "Registering MCP rich tools")
+	defer mcpLog.logTrace("registerRichTools", "End Registering MCP rich tools")
+
+	me, err := client.AuthMe()
+	if err != nil {
+		mcpLog.logTrace("registerRichTools", fmt.Sprintf("unable to load current consumer: %v", err))
+		return
+	}
+	scopes := mcpScopes(me.Consumer.AuthConsumerUser.ScopeDetails)
+
+	if scopes.Allow(sdk.AuthConsumerScopeRun) {
+		mcp.AddTool(server, &mcp.Tool{
+			Name:        "workflow-run-failed-steps-logs",
+			Title:       "Get the logs of the failed steps of a workflow run",
+			Description: "Returns the last lines of the logs of the failed step of each failed job of a workflow run, with the error infos of the jobs.",
+		}, mcpWorkflowRunFailedStepsLogs)
+		mcp.AddTool(server, &mcp.Tool{
+			Name:        "workflow-run-diff",
+			Title:       "Diff the workflow definition of a run with the previous successful run",
+			Description: "Compares the workflow definition used by a run with the one used by the previous successful run of the same workflow. Each change gives the path of the value in the workflow and its values before and after.",
+		}, mcpWorkflowRunDiff)
+		mcp.AddTool(server, &mcp.Tool{
+			Name:        "workflow-run-gate-trigger",
+			Title:       "Trigger a gated job of a workflow run",
+			Description: "Starts a job that waits for a gate. The inputs are checked and converted with the types of the gate inputs: boolean, number or string. The inputs that are not given take the default value of the gate.",
+		}, mcpWorkflowRunGateTrigger)
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "workflow-lint",
+		Title:       "Lint a workflow",
+		Description: "Checks the YAML content of a workflow file with the CDS linter.",
+	}, mcpWorkflowLint)
+
+	if scopes.Allow(sdk.AuthConsumerScopeUser) {
+		mcp.AddTool(server, &mcp.Tool{
+			Name:        "rbac-explain",
+			Title:       "Explain a permission",
+			Description: "Explains why a user has or does not have a role on a resource: the permissions granting the role, or the permissions of the user on the same scope. Give the project key for project roles, the project key, the vcs server, the repository and the workflow for workflow roles, the project key and the variable set for variable set roles, the region for region roles.",
+		}, mcpRBACExplain)
+	}
+
+	if scopes.Allow(sdk.AuthConsumerScopeProject) {
+		for _, entityType := range []string{sdk.EntityTypeWorkflow, sdk.EntityTypeWorkflowTemplate, sdk.EntityTypeJob, sdk.EntityTypeAction, sdk.EntityTypeWorkerModel} {
+			entityType := entityType
+			uri := "cds://schema/" + strings.ToLower(entityType)
+			server.AddResource(&mcp.Resource{
+				URI:         uri,
+				Name:        strings.ToLower(entityType) + "-schema",
+				Title:       entityType + " JSON schema",
+				Description: "JSON schema of the " + entityType + " files in the .cds directory of a repository",
+				MIMEType:    "application/schema+json",
+			}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+				mcpLog.logTrace("ReadResource", uri)
+				schema, err := client.UserGetSchemaV2(ctx, entityType)
+				if err != nil {
+					return nil, err
+				}
+				return &mcp.ReadResourceResult{
+					Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "application/schema+json", Text: string(schema)}},
+				}, nil
+			})
+		}
+	}
+}
+
+type mcpWorkflowRunInput struct {
+	ProjectKey    string `json:"project_key" jsonschema:"the project key"`
+	WorkflowRunID string `json:"workflow_run_id" jsonschema:"the workflow run identifier"`
+}
+
+type mcpFailedStepsLogsInput struct {
+	mcpWorkflowRunInput
+	Lines int `json:"lines,omitempty" jsonschema:"the number of log lines to return for each step, default 100"`
+}
+
+type mcpFailedStepsLogsOutput struct {
+	Status sdk.V2WorkflowRunStatus `json:"status"`
+	Jobs   []mcpFailedJob          `json:"jobs"`
+}
+
+type mcpFailedJob struct {
+	JobID    string                     `json:"job_id"`
+	RunJobID string                     `json:"run_job_id"`
+	Matrix   map[string]interface{}     `json:"matrix,omitempty"`
+	Status   sdk.V2WorkflowRunJobStatus `json:"status"`
+	Errors   []string                   `json:"errors,omitempty"`
+	Steps    []mcpFailedStep            `json:"steps,omitempty"`
+}
+
+type mcpFailedStep struct {
+	Name string `json:"name"`
+	// Truncated is true if the log contains more lines than the returned ones
+	Truncated bool   `json:"truncated"`
+	Logs      string `json:"logs"`
+}
+
+func mcpWorkflowRunFailedStepsLogs(ctx context.Context, req *mcp.CallToolRequest, in mcpFailedStepsLogsInput) (*mcp.CallToolResult, mcpFailedStepsLogsOutput, error) {
+	mcpLog.logTrace("CallTool workflow-run-failed-steps-logs", in)
+	var out mcpFailedStepsLogsOutput
+
+	lines := in.Lines
+	if lines <= 0 {
+		lines = mcpDefaultLogLines
+	}
+	if lines > mcpMaxLogLines {
+		lines = mcpMaxLogLines
+	}
+
+	run, err := client.WorkflowV2RunStatus(ctx, in.ProjectKey, in.WorkflowRunID)
+	if err != nil {
+		return nil, out, err
+	}
+	out.Status = run.Status
+	out.Jobs = make([]mcpFailedJob, 0)
+
+	runJobs, err := client.WorkflowV2RunJobs(ctx, in.ProjectKey, run.ID)
+	if err != nil {
+		return nil, out, err
+	}
+	for _, rj := range runJobs {
+		if rj.Status != sdk.V2WorkflowRunJobStatusFail {
+			continue
+		}
+		failedJob := mcpFailedJob{
+			JobID:    rj.JobID,
+			RunJobID: rj.ID,
+			Matrix:   rj.Matrix,
+			Status:   rj.Status,
+		}
+
+		infos, err := client.WorkflowV2RunJobInfoList(ctx, in.ProjectKey, run.ID, rj.ID)
+		if err != nil {
+			return nil, out, err
+		}
+		for _, i := range infos {
+			if i.Level == sdk.WorkflowRunInfoLevelError {
+				failedJob.Errors = append(failedJob.Errors, i.Message)
+			}
+		}
+
+		links, err := client.WorkflowV2RunJobLogLinks(ctx, in.ProjectKey, run.ID, rj.ID)
+		if err != nil {
+			return nil, out, err
+		}
+		stepNames := workflowRunJobStepNames(rj)
+		for idx, link := range links.Data {
+			if idx >= len(stepNames) || rj.StepsStatus[stepNames[idx]].Conclusion != sdk.V2WorkflowRunJobStatusFail {
+				continue
+			}
+			// Only the last lines are requested to the CDN
+			logLines, total, err := client.WorkflowLogLines(ctx, sdk.CDNLogLink{APIRef: link.APIRef, ItemType: link.ItemType}, -int64(lines), uint(lines))
+			if err != nil {
+				if strings.Contains(err.Error(), "resource not found") {
+					continue
+				}
+				return nil, out, err
+			}
+			failedJob.Steps = append(failedJob.Steps, mcpFailedStep{
+				Name:      stepNames[idx],
+				Truncated: total > int64(len(logLines)),
+				Logs:      joinLogLines(logLines),
+			})
+		}
+		out.Jobs = append(out.Jobs, failedJob)
+	}
+	return nil, out, nil
+}
+
+// joinLogLines returns the text of the log lines, each line ending with a line break.
+func joinLogLines(lines []sdk.CDNLogLine) string {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(l.Value)
+		if !strings.HasSuffix(l.Value, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+type mcpWorkflowRunDiffOutput struct {
+	WorkflowRunID     string `json:"workflow_run_id"`
+	RunNumber         int64  `json:"run_number"`
+	WorkflowSha       string `json:"workflow_sha"`
+	PreviousRunID     string `json:"previous_run_id,omitempty"`
+	PreviousRunNumber int64  `json:"previous_run_number,omitempty"`
+	PreviousSha       string `json:"previous_workflow_sha,omitempty"`
+	// Message explains why there is nothing to compare
+	Message string              `json:"message,omitempty"`
+	Changes []mcpWorkflowChange `json:"changes"`
+}
+
+type mcpWorkflowChange struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+func mcpWorkflowRunDiff(ctx context.Context, req *mcp.CallToolRequest, in mcpWorkflowRunInput) (*mcp.CallToolResult, mcpWorkflowRunDiffOutput, error) {
+	mcpLog.logTrace("CallTool workflow-run-diff", in)
+	var out mcpWorkflowRunDiffOutput
+
+	run, err := client.WorkflowV2RunStatus(ctx, in.ProjectKey, in.WorkflowRunID)
+	if err != nil {
+		return nil, out, err
+	}
+	out.WorkflowRunID = run.ID
+	out.RunNumber = run.RunNumber
+	out.WorkflowSha = run.WorkflowSha
+	out.Changes = make([]mcpWorkflowChange, 0)
+
+	previousRuns, err := client.WorkflowV2RunSearch(ctx, in.ProjectKey,
+		cdsclient.WithQueryParameter("workflow", run.VCSServer+"/"+run.Repository+"/"+run.WorkflowName),
+		cdsclient.WithQueryParameter("status", string(sdk.V2WorkflowRunStatusSuccess)),
+		cdsclient.WithQueryParameter("sort", "started:desc"),
+		cdsclient.WithQueryParameter("limit", "50"),
+	)
+	if err != nil {
+		return nil, out, err
+	}
+	var previous *sdk.V2WorkflowRun
+	for i := range previousRuns {
+		if previousRuns[i].ID != run.ID && previousRuns[i].RunNumber < run.RunNumber {
+			previous = &previousRuns[i]
+			break
+		}
+	}
+	if previous == nil {
+		out.Message = "no previous successful run found for workflow " + run.WorkflowName
+		return nil, out, nil
+	}
+	out.PreviousRunID = previous.ID
+	out.PreviousRunNumber = previous.RunNumber
+	out.PreviousSha = previous.WorkflowSha
+
+	before, err := toGenericJSON(previous.WorkflowData.Workflow)
+	if err != nil {
+		return nil, out, err
+	}
+	after, err := toGenericJSON(run.WorkflowData.Workflow)
+	if err != nil {
+		return nil, out, err
+	}
+	out.Changes = diffJSON("", before, after, out.Changes)
+	if len(out.Changes) == 0 {
+		out.Message = "the workflow definition did not change"
+	}
+	return nil, out, nil
+}
+
+func toGenericJSON(i interface{}) (interface{}, error) {
+	btes, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+	var res interface{}
+	if err := json.Unmarshal(btes, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// diffJSON appends the differences between two unmarshalled JSON values, objects are compared key by key.
+func diffJSON(path string, before, after interface{}, changes []mcpWorkflowChange) []mcpWorkflowChange {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if !beforeIsMap || !afterIsMap {
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, mcpWorkflowChange{Path: path, Before: before, After: after})
+		}
+		return changes
+	}
+
+	keys := make([]string, 0, len(beforeMap)+len(afterMap))
+	for k := range beforeMap {
+		keys = append(keys, k)
+	}
+	for k := range afterMap {
+		if _, has := beforeMap[k]; !has {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		subPath := k
+		if path != "" {
+			subPath = path + "." + k
+		}
+		changes = diffJSON(subPath, beforeMap[k], afterMap[k], changes)
+	}
+	return changes
+}
+
+type mcpWorkflowLintInput struct {
+	Content string `json:"content" jsonschema:"the YAML content of the workflow file"`
+}
+
+type mcpWorkflowLintOutput struct {
+	Valid    bool     `json:"valid"`
+	Messages []string `json:"messages"`
+}
+
+func mcpWorkflowLint(ctx context.Context, req *mcp.CallToolRequest, in mcpWorkflowLintInput) (*mcp.CallToolResult, mcpWorkflowLintOutput, error) {
+	mcpLog.logTrace("CallTool workflow-lint", in)
+	out := mcpWorkflowLintOutput{Messages: make([]string, 0)}
+
+	var wf sdk.V2Workflow
+	if err := yaml.Unmarshal([]byte(in.Content), &wf); err != nil {
+		out.Messages = append(out.Messages, fmt.Sprintf("unable to unmarshal yaml: %v", err))
+		return nil, out, nil
+	}
+	resp, err := client.EntityLint(ctx, sdk.EntityTypeWorkflow, wf)
+	if err != nil {
+		return nil, out, err
+	}
+	out.Messages = append(out.Messages, resp.Messages...)
+	out.Valid = len(out.Messages) == 0
+	return nil, out, nil
+}
+
+type mcpRBACExplainInput struct {
+	Username    string `json:"username,omitempty" jsonschema:"the username, default is the current user"`
+	Role        string `json:"role" jsonschema:"the role to explain, for example read, manage or trigger"`
+	ProjectKey  string `json:"project_key,omitempty" jsonschema:"the project key"`
+	VCSServer   string `json:"vcs_server,omitempty" jsonschema:"the vcs server of the workflow"`
+	Repository  string `json:"repository,omitempty" jsonschema:"the repository of the workflow"`
+	Workflow    string `json:"workflow,omitempty" jsonschema:"the workflow name"`
+	VariableSet string `json:"variable_set,omitempty" jsonschema:"the variable set name"`
+	Region      string `json:"region,omitempty" jsonschema:"the region name"`
+}
+
+func mcpRBACExplain(ctx context.Context, req *mcp.CallToolRequest, in mcpRBACExplainInput) (*mcp.CallToolResult, sdk.RBACExplanation, error) {
+	mcpLog.logTrace("CallTool rbac-explain", in)
+	explainReq := sdk.RBACExplainRequest{
+		Username:    in.Username,
+		Role:        in.Role,
+		ProjectKey:  in.ProjectKey,
+		VCSServer:   in.VCSServer,
+		Repository:  in.Repository,
+		Workflow:    in.Workflow,
+		VariableSet: in.VariableSet,
+		Region:      in.Region,
+	}
+	if explainReq.Username == "" {
+		me, err := client.AuthMe()
+		if err != nil {
+			return nil, sdk.RBACExplanation{}, err
+		}
+		explainReq.Username = me.User.Username
+	}
+	if err := explainReq.Check(); err != nil {
+		return nil, sdk.RBACExplanation{}, err
+	}
+	explanation, err := client.RBACUserPermissionExplain(ctx, explainReq)
+	return nil, explanation, err
+}
+
+type mcpGateTriggerInput struct {
+	mcpWorkflowRunInput
+	JobID  string                 `json:"job_id" jsonschema:"the identifier of the job in the workflow"`
+	Inputs map[string]interface{} `json:"inputs,omitempty" jsonschema:"the gate inputs"`
+}
+
+type mcpGateTriggerOutput struct {
+	WorkflowRunID string                  `json:"workflow_run_id"`
+	RunNumber     int64                   `json:"run_number"`
+	RunAttempt    int64                   `json:"run_attempt"`
+	Status        sdk.V2WorkflowRunStatus `json:"status"`
+	Gate          string                  `json:"gate"`
+	Inputs        map[string]interface{}  `json:"inputs"`
+}
+
+func mcpWorkflowRunGateTrigger(ctx context.Context, req *mcp.CallToolRequest, in mcpGateTriggerInput) (*mcp.CallToolResult, mcpGateTriggerOutput, error) {
+	mcpLog.logTrace("CallTool workflow-run-gate-trigger", in)
+	var out mcpGateTriggerOutput
+
+	run, err := client.WorkflowV2RunStatus(ctx, in.ProjectKey, in.WorkflowRunID)
+	if err != nil {
+		return nil, out, err
+	}
+	job, has := run.WorkflowData.Workflow.Jobs[in.JobID]
+	if !has {
+		return nil, out, fmt.Errorf("job %q not found in workflow %s", in.JobID, run.WorkflowName)
+	}
+	if job.Gate == "" {
+		return nil, out, fmt.Errorf("job %q has no gate", in.JobID)
+	}
+	gate := run.WorkflowData.Workflow.Gates[job.Gate]
+
+	inputs := make(map[string]interface{}, len(in.Inputs))
+	for name, value := range in.Inputs {
+		def, has := gate.Inputs[name]
+		if !has {
+			return nil, out, fmt.Errorf("input %q not found in gate %q", name, job.Gate)
+		}
+		v, err := convertGateInput(def, value)
+		if err != nil {
+			return nil, out, fmt.Errorf("invalid value for input %q: %v", name, err)
+		}
+		inputs[name] = v
+	}
+	sdk.MergeGateDefaultInputs(gate, inputs)
+
+	updatedRun, err := client.WorkflowV2JobStart(ctx, in.ProjectKey, run.ID, in.JobID, inputs)
+	if err != nil {
+		return nil, out, err
+	}
+	out = mcpGateTriggerOutput{
+		WorkflowRunID: updatedRun.ID,
+		RunNumber:     updatedRun.RunNumber,
+		RunAttempt:    updatedRun.RunAttempt,
+		Status:        updatedRun.Status,
+		Gate:          job.Gate,
+		Inputs:        inputs,
+	}
+	return nil, out, nil
+}
+
+// convertGateInput converts a value with the type of the gate input and checks it against the options of the input.
+func convertGateInput(def sdk.V2JobGateInput, value interface{}) (interface{}, error) {
+	if def.Options != nil && def.Options.Multiple {
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+		res := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			converted, err := convertGateInputValue(def, v)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, converted)
+		}
+		return res, nil
+	}
+	return convertGateInputValue(def, value)
+}
+
+func convertGateInputValue(def sdk.V2JobGateInput, value interface{}) (interface{}, error) {
+	var res interface{}
+	switch def.Type {
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			res = v
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a boolean", v)
+			}
+			res = b
+		default:
+			return nil, fmt.Errorf("%v is not a boolean", value)
+		}
+	case "number":
+		switch v := value.(type) {
+		case float64:
+			res = v
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", v)
+			}
+			res = f
+		default:
+			return nil, fmt.Errorf("%v is not a number", value)
+		}
+	default:
+		switch v := value.(type) {
+		case string:
+			res = v
+		case bool, float64:
+			res = fmt.Sprintf("%v", v)
+		default:
+			return nil, fmt.Errorf("%v is not a string", value)
+		}
+	}
+
+	if def.Options == nil || len(def.Options.Values) == 0 {
+		return res, nil
+	}
+	for _, o := range def.Options.Values {
+		if fmt.Sprintf("%v", o) == fmt.Sprintf("%v", res) {
+			return res, nil
+		}
+	}
+	return nil, fmt.Errorf("%v is not one of the allowed values %v", res, def.Options.Values)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+)
+
+func Test_diffJSON(t *testing.T) {
+	before := map[string]interface{}{
+		"name": "my-workflow",
+		"jobs": map[string]interface{}{
+			"build": map[string]interface{}{"runs-on": "model-1", "steps": []interface{}{"make"}},
+			"test":  map[string]interface{}{"runs-on": "model-1"},
+		},
+	}
+	after := map[string]interface{}{
+		"name": "my-workflow",
+		"jobs": map[string]interface{}{
+			"build":  map[string]interface{}{"runs-on": "model-2", "steps": []interface{}{"make"}},
+			"deploy": map[string]interface{}{"runs-on": "model-1"},
+		},
+	}
+
+	changes := diffJSON("", before, after, nil)
+	require.Equal(t, []mcpWorkflowChange{
+		{Path: "jobs.build.runs-on", Before: "model-1", After: "model-2"},
+		{Path: "jobs.deploy", After: map[string]interface{}{"runs-on": "model-1"}},
+		{Path: "jobs.test", Before: map[string]interface{}{"runs-on": "model-1"}},
+	}, changes)
+
+	require.Empty(t, diffJSON("", before, before, nil))
+}
+
+func Test_convertGateInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     sdk.V2JobGateInput
+		value   interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "string", def: sdk.V2JobGateInput{}, value: "foo", want: "foo"},
+		{name: "string from number", def: sdk.V2JobGateInput{}, value: float64(12), want: "12"},
+		{name: "string from object", def: sdk.V2JobGateInput{}, value: map[string]interface{}{}, wantErr: true},
+		{name: "boolean", def: sdk.V2JobGateInput{Type: "boolean"}, value: true, want: true},
+		{name: "boolean from string", def: sdk.V2JobGateInput{Type: "boolean"}, value: "false", want: false},
+		{name: "invalid boolean", def: sdk.V2JobGateInput{Type: "boolean"}, value: "yes please", wantErr: true},
+		{name: "number from string", def: sdk.V2JobGateInput{Type: "number"}, value: "1.5", want: 1.5},
+		{name: "invalid number", def: sdk.V2JobGateInput{Type: "number"}, value: true, wantErr: true},
+		{
+			name:  "allowed value",
+			def:   sdk.V2JobGateInput{Options: &sdk.V2JobGateOptions{Values: []interface{}{"dev", "prod"}}},
+			value: "prod",
+			want:  "prod",
+		},
+		{
+			name:    "not allowed value",
+			def:     sdk.V2JobGateInput{Options: &sdk.V2JobGateOptions{Values: []interface{}{"dev", "prod"}}},
+			value:   "staging",
+			wantErr: true,
+		},
+		{
+			name:  "multiple values",
+			def:   sdk.V2JobGateInput{Type: "number", Options: &sdk.V2JobGateOptions{Multiple: true, Values: []interface{}{1, 2, 3}}},
+			value: []interface{}{"1", float64(3)},
+			want:  []interface{}{float64(1), float64(3)},
+		},
+		{
+			name:  "multiple values from a single value",
+			def:   sdk.V2JobGateInput{Options: &sdk.V2JobGateOptions{Multiple: true}},
+			value: "foo",
+			want:  []interface{}{"foo"},
+		},
+		{
+			name:    "multiple values with a not allowed one",
+			def:     sdk.V2JobGateInput{Options: &sdk.V2JobGateOptions{Multiple: true, Values: []interface{}{"dev"}}},
+			value:   []interface{}{"dev", "prod"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertGateInput(tt.def, tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_joinLogLines(t *testing.T) {
+	require.Equal(t, "", joinLogLines(nil))
+	require.Equal(t, "first\nsecond\n", joinLogLines([]sdk.CDNLogLine{{Number: 0, Value: "first\n"}, {Number: 1, Value: "second"}}))
+}
+
+func Test_mcpWorkflowRunFailedStepsLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock_cdsclient.NewMockInterface(ctrl)
+	client = mockClient
+	mcpLog = &mcpLogger{}
+
+	run := sdk.V2WorkflowRun{ID: "run-id", Status: sdk.V2WorkflowRunStatusFail}
+	runJobs := []sdk.V2WorkflowRunJob{
+		{ID: "run-job-1", JobID: "build", Status: sdk.V2WorkflowRunJobStatusSuccess},
+		{
+			ID:     "run-job-2",
+			JobID:  "test",
+			Status: sdk.V2WorkflowRunJobStatusFail,
+			Job:    sdk.V2Job{Steps: []sdk.ActionStep{{ID: "checkout"}, {ID: "unit-tests"}}},
+			StepsStatus: sdk.JobStepsStatus{
+				"checkout":   {Conclusion: sdk.V2WorkflowRunJobStatusSuccess},
+				"unit-tests": {Conclusion: sdk.V2WorkflowRunJobStatusFail},
+			},
+		},
+	}
+	failedStepLink := sdk.CDNLogLink{APIRef: "ref-unit-tests", ItemType: sdk.CDNTypeItemJobStepLog}
+
+	mockClient.EXPECT().WorkflowV2RunStatus(gomock.Any(), "PROJ", "run-id").Return(&run, nil)
+	mockClient.EXPECT().WorkflowV2RunJobs(gomock.Any(), "PROJ", "run-id").Return(runJobs, nil)
+	mockClient.EXPECT().WorkflowV2RunJobInfoList(gomock.Any(), "PROJ", "run-id", "run-job-2").Return([]sdk.V2WorkflowRunJobInfo{
+		{Level: sdk.WorkflowRunInfoLevelInfo, Message: "job started"},
+		{Level: sdk.WorkflowRunInfoLevelError, Message: "tests failed"},
+	}, nil)
+	mockClient.EXPECT().WorkflowV2RunJobLogLinks(gomock.Any(), "PROJ", "run-id", "run-job-2").Return(sdk.CDNLogLinks{
+		Data: []sdk.CDNLogLink{{APIRef: "ref-checkout", ItemType: sdk.CDNTypeItemJobStepLog}, failedStepLink},
+	}, nil)
+	// Only the last lines of the failed step are requested
+	mockClient.EXPECT().WorkflowLogLines(gomock.Any(), failedStepLink, int64(-2), uint(2)).Return([]sdk.CDNLogLine{
+		{Number: 8, Value: "FAIL TestFoo\n"},
+		{Number: 9, Value: "exit status 1\n"},
+	}, int64(10), nil)
+
+	_, out, err := mcpWorkflowRunFailedStepsLogs(context.TODO(), nil, mcpFailedStepsLogsInput{
+		mcpWorkflowRunInput: mcpWorkflowRunInput{ProjectKey: "PROJ", WorkflowRunID: "run-id"},
+		Lines:               2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sdk.V2WorkflowRunStatusFail, out.Status)
+	require.Equal(t, []mcpFailedJob{{
+		JobID:    "test",
+		RunJobID: "run-job-2",
+		Status:   sdk.V2WorkflowRunJobStatusFail,
+		Errors:   []string{"tests failed"},
+		Steps: []mcpFailedStep{{
+			Name:      "unit-tests",
+			Truncated: true,
+			Logs:      "FAIL TestFoo\nexit status 1\n",
+		}},
+	}}, out.Jobs)
+}
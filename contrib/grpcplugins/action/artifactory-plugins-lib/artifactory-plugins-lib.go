@@ -42,7 +42,7 @@ func (*logger) Warn(a ...interface{})   {}
 
 var _ jfroglog.Log = new(logger)
 
-func PromoteArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, jobContext sdk.WorkflowRunJobsContext, r sdk.V2WorkflowRunResult, maturity string, props *utils.Properties) error {
+func PromoteArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, jobContext sdk.WorkflowRunJobsContext, r sdk.V2WorkflowRunResult, maturity string, props artifact_manager.Properties) error {
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Minute)
 	defer cancel()
 
@@ -52,14 +52,9 @@ func PromoteArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, jo
 
 	integration := jobContext.Integrations.ArtifactManager
 
-	rtConfig := grpcplugins.ArtifactoryConfig{
-		URL:   integration.Get(sdk.ArtifactoryConfigURL),
-		Token: integration.Get(sdk.ArtifactoryConfigToken),
-	}
-
-	artifactClient, err := artifact_manager.NewClient("artifactory", rtConfig.URL, rtConfig.Token)
+	artifactClient, err := newArtifactManagerClient(integration)
 	if err != nil {
-		return errors.Errorf("Failed to create artifactory client: %v", err)
+		return errors.Errorf("Failed to create artifact manager client: %v", err)
 	}
 
 	jfroglog.SetLogger(new(logger)) // reset the logger set by artifact_manager.NewClient
@@ -78,6 +73,15 @@ func PromoteArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, jo
 	return nil
 }
 
+// newArtifactManagerClient creates the client of the job artifact manager integration, Artifactory by default.
+func newArtifactManagerClient(integration sdk.JobIntegrationsContext) (artifact_manager.ArtifactManager, error) {
+	platform := integration.Get(sdk.ArtifactoryConfigPlatform)
+	if platform == "" {
+		platform = artifact_manager.TypeArtifactory
+	}
+	return artifact_manager.NewClient(platform, integration.Get(sdk.ArtifactoryConfigURL), integration.Get(sdk.ArtifactoryConfigToken))
+}
+
 func checkRunResultIntegrity(_ context.Context, c *actionplugin.Common, artifactClient artifact_manager.ArtifactManager, r sdk.V2WorkflowRunResult) error {
 	// Only check integrity for artifacts uploaded on Artifactory
 	if r.ArtifactManagerIntegrationName == nil {
@@ -198,7 +202,7 @@ func checkOCIIntegrity(c *actionplugin.Common, artifactClient artifact_manager.A
 	return nil
 }
 
-func promoteRunResult(ctx context.Context, c *actionplugin.Common, artifactClient artifact_manager.ArtifactManager, integration sdk.JobIntegrationsContext, r sdk.V2WorkflowRunResult, maturity string, props *utils.Properties, status string, futureReleaseName, futureReleaseVersion string) (string, error) {
+func promoteRunResult(ctx context.Context, c *actionplugin.Common, artifactClient artifact_manager.ArtifactManager, integration sdk.JobIntegrationsContext, r sdk.V2WorkflowRunResult, maturity string, props artifact_manager.Properties, status string, futureReleaseName, futureReleaseVersion string) (string, error) {
 	var (
 		promotedArtifact      string
 		skipExistingArtifacts bool
@@ -353,7 +357,7 @@ func promoteRunResult(ctx context.Context, c *actionplugin.Common, artifactClien
 	return promotedArtifact, nil
 }
 
-func ReleaseArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, results []sdk.V2WorkflowRunResult, maturity string, props *utils.Properties, releaseNotes string) error {
+func ReleaseArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, results []sdk.V2WorkflowRunResult, maturity string, props artifact_manager.Properties, releaseNotes string) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
 	defer cancel()
 
@@ -392,9 +396,9 @@ func ReleaseArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, re
 		maturity = integration.Get(sdk.ArtifactoryConfigPromotionHighMaturity)
 	}
 
-	artifactClient, err := artifact_manager.NewClient("artifactory", rtConfig.URL, rtConfig.Token)
+	artifactClient, err := newArtifactManagerClient(integration)
 	if err != nil {
-		return errors.Errorf("Failed to create artifactory client: %v", err)
+		return errors.Errorf("Failed to create artifact manager client: %v", err)
 	}
 
 	jfroglog.SetLogger(new(logger)) // reset the logger set by artifact_manager.NewClient
@@ -430,6 +434,12 @@ func ReleaseArtifactoryRunResult(ctx context.Context, c *actionplugin.Common, re
 		return err
 	}
 
+	// Release bundles, their distribution and SBOM are Artifactory features
+	if platform := integration.Get(sdk.ArtifactoryConfigPlatform); platform != "" && platform != artifact_manager.TypeArtifactory {
+		grpcplugins.Warnf(c, "Release bundles are not available on %s, artifacts have only been promoted to %s", platform, maturity)
+		return nil
+	}
+
 	distriClient, err := art.CreateDistributionClient(ctx, rtConfig.DistributionURL, rtConfig.ReleaseToken)
 	if err != nil {
 		return errors.Errorf("Failed to create distribution client: %v", err)
@@ -6,10 +6,10 @@ import (
 	"runtime/debug"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/ovh/cds/contrib/grpcplugins"
 	artifactorypluginslib "github.com/ovh/cds/contrib/grpcplugins/action/artifactory-plugins-lib"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/artifact_manager"
 	"github.com/ovh/cds/sdk/grpcplugin/actionplugin"
 	"github.com/pkg/errors"
 )
@@ -76,10 +76,10 @@ func (p *rtPromotePlugin) perform(ctx context.Context, artifacts string, maturit
 		return errors.Errorf("no artifacts match %q", artifacts)
 	}
 
-	var props *utils.Properties
+	var props artifact_manager.Properties
 	if properties != "" {
 		var err error
-		props, err = utils.ParseProperties(properties)
+		props, err = artifact_manager.ParseProperties(properties)
 		if err != nil {
 			return errors.Errorf("unable to parse given properties: %v", err)
 		}
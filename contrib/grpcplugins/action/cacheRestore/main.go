@@ -35,6 +35,7 @@ func (p *cacheRestorePlugin) Stream(q *actionplugin.ActionQuery, stream actionpl
 	cacheKey := q.GetOptions()["key"]
 	path := q.GetOptions()["path"]
 	failOnMiss := q.GetOptions()["fail-on-cache-miss"]
+	restoreKeys := grpcplugins.ParseRestoreKeys(q.GetOptions()["restore-keys"])
 
 	jobCtx, err := grpcplugins.GetJobContext(ctx, &p.Common)
 	if err != nil {
@@ -52,7 +53,7 @@ func (p *cacheRestorePlugin) Stream(q *actionplugin.ActionQuery, stream actionpl
 		return stream.Send(res)
 	}
 
-	if err := grpcplugins.PerformGetCache(ctx, &p.Common, *jobCtx, cacheKey, workDirs, path, (failOnMiss == "true")); err != nil {
+	if err := grpcplugins.PerformGetCache(ctx, &p.Common, *jobCtx, cacheKey, restoreKeys, workDirs, path, (failOnMiss == "true")); err != nil {
 		err := fmt.Errorf("unable to retrieve cache: %v", err)
 		res.Status = sdk.StatusFail
 		res.Details = err.Error()
@@ -73,6 +73,12 @@ func (actPlugin *junitPlugin) perform(ctx context.Context, workingDir, filePath
 		return errors.New(fmt.Sprintf("Unable to get job context: %v", err))
 	}
 
+	// Failures of quarantined tests are reported but do not fail the step
+	quarantines, err := grpcplugins.GetTestQuarantines(ctx, &actPlugin.Common)
+	if err != nil {
+		grpcplugins.Warnf(&actPlugin.Common, "Unable to get test quarantines, all failures are blocking: %v", err)
+	}
+
 	testFailed := 0
 	runResultRequests := make(map[string]*workerruntime.V2RunResultRequest)
 	for _, r := range fileResult.Results {
@@ -83,7 +89,7 @@ func (actPlugin *junitPlugin) perform(ctx context.Context, workingDir, filePath
 			return errors.New(fmt.Sprintf("Unable to read file %q: %v.", r.Path, err))
 		}
 
-		runResultRequest, nbFailed, err := createRunResult(&actPlugin.Common, bts, r.Path, sizes[r.Path], checksums[r.Path], permissions[r.Path])
+		runResultRequest, nbFailed, err := createRunResult(&actPlugin.Common, quarantines, bts, r.Path, sizes[r.Path], checksums[r.Path], permissions[r.Path])
 		if err != nil {
 			_ = openFiles[r.Path].Close()
 			return err
@@ -108,7 +114,7 @@ func (actPlugin *junitPlugin) perform(ctx context.Context, workingDir, filePath
 	return nil
 }
 
-func createRunResult(p *actionplugin.Common, fileContent []byte, filePath string, size int64, checksum grpcplugins.ChecksumResult, perm fs.FileMode) (*workerruntime.V2RunResultRequest, int, error) {
+func createRunResult(p *actionplugin.Common, quarantines sdk.V2WorkflowTestQuarantines, fileContent []byte, filePath string, size int64, checksum grpcplugins.ChecksumResult, perm fs.FileMode) (*workerruntime.V2RunResultRequest, int, error) {
 	runResult := workerruntime.V2RunResultRequest{
 		RunResult: &sdk.V2WorkflowRunResult{
 			IssuedAt: time.Now(),
@@ -117,14 +123,20 @@ func createRunResult(p *actionplugin.Common, fileContent []byte, filePath string
 		},
 	}
 
-	detail, nbKo, err := grpcplugins.ComputeRunResultTestsDetail(p, filePath, fileContent, size, checksum.Md5, checksum.Sha1, checksum.Sha256)
+	detail, _, err := grpcplugins.ComputeRunResultTestsDetail(p, filePath, fileContent, size, checksum.Md5, checksum.Sha1, checksum.Sha256)
 	if err != nil {
 		return nil, 0, err
 	}
 	runResult.RunResult.Detail = *detail
 
+	testDetail := detail.Data.(sdk.V2WorkflowRunResultTestDetail)
+	blocking, quarantined := quarantines.Failures(testDetail.TestsSuites)
+	for _, t := range quarantined {
+		grpcplugins.Warnf(p, "Test %s failed but is quarantined", t)
+	}
+
 	// Create run result at status "pending"
-	return &runResult, nbKo, nil
+	return &runResult, len(blocking), nil
 }
 
 func main() {
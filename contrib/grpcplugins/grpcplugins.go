@@ -105,8 +105,11 @@ func GetRunResults(workerHTTPPort int32) ([]sdk.WorkflowRunResult, error) {
 	return results, nil
 }
 
-func GetV2CacheLink(ctx context.Context, c *actionplugin.Common, cacheKey string) (*sdk.CDNItemLinks, error) {
-	path := fmt.Sprintf("/v2/cache/signature/%s/link", cacheKey)
+func GetV2CacheLink(ctx context.Context, c *actionplugin.Common, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
+	path := fmt.Sprintf("/v2/cache/signature/%s/link", url.PathEscape(cacheKey))
+	if len(restoreKeys) > 0 {
+		path += "?" + url.Values{"restoreKey": restoreKeys}.Encode()
+	}
 	req, err := c.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
@@ -408,6 +411,34 @@ func GetJobContext(ctx context.Context, c *actionplugin.Common) (*sdk.WorkflowRu
 	return &context, nil
 }
 
+// GetTestQuarantines returns the quarantined tests of the current workflow
+func GetTestQuarantines(ctx context.Context, c *actionplugin.Common) (sdk.V2WorkflowTestQuarantines, error) {
+	r, err := c.NewRequest(ctx, "GET", "/v2/test/quarantine", nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to prepare request")
+	}
+
+	resp, err := c.DoRequest(r)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get test quarantines")
+	}
+	defer resp.Body.Close()
+
+	btes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to read response")
+	}
+	if resp.StatusCode >= 400 {
+		return nil, errors.Errorf("unable to get test quarantines: HTTP %d: %s", resp.StatusCode, string(btes))
+	}
+
+	var quarantines sdk.V2WorkflowTestQuarantines
+	if err := sdk.JSONUnmarshal(btes, &quarantines); err != nil {
+		return nil, sdk.WrapError(err, "unable to read response")
+	}
+	return quarantines, nil
+}
+
 func GetWorkerConfig(ctx context.Context, c *actionplugin.Common) (*workerruntime.V2WorkerConfig, error) {
 	r, err := c.NewRequest(ctx, "GET", "/v2/workerConfig", nil)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
@@ -16,7 +17,21 @@ import (
 	"github.com/spf13/afero"
 )
 
-func PerformGetCache(ctx context.Context, c *actionplugin.Common, jobCtx sdk.WorkflowRunJobsContext, cacheKey string, workDirs *sdk.WorkerDirectories, path string, failOnMiss bool) error {
+// ParseRestoreKeys returns the restore keys given one per line.
+func ParseRestoreKeys(s string) []string {
+	var keys []string
+	for _, k := range strings.Split(s, "\n") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// PerformGetCache restores the cache with the given key. On the CDN, the restore keys are used as prefixes
+// to restore the latest matching cache when there is no cache for the key.
+// The output cache-hit is true only for an exact match, the restored key is set in the output cache-matched-key.
+func PerformGetCache(ctx context.Context, c *actionplugin.Common, jobCtx sdk.WorkflowRunJobsContext, cacheKey string, restoreKeys []string, workDirs *sdk.WorkerDirectories, path string, failOnMiss bool) error {
 	absPath := path
 	if !sdk.PathIsAbs(path) {
 		var err error
@@ -27,21 +42,35 @@ func PerformGetCache(ctx context.Context, c *actionplugin.Common, jobCtx sdk.Wor
 	}
 
 	// Check if file or directory exist
-	cacheFound := false
-	var err error
+	var matchedKey string
 	if jobCtx.Integrations != nil && jobCtx.Integrations.ArtifactManager.Name != "" {
-		cacheFound, err = performFromArtifactory(ctx, c, jobCtx, cacheKey, workDirs, absPath, failOnMiss)
+		if len(restoreKeys) > 0 {
+			Warn(c, "restore keys are not supported with an artifact manager")
+		}
+		cacheFound, err := performFromArtifactory(ctx, c, jobCtx, cacheKey, workDirs, absPath, failOnMiss)
+		if err != nil {
+			return err
+		}
+		if cacheFound {
+			matchedKey = cacheKey
+		}
 	} else {
-		cacheFound, err = performFromCDN(ctx, c, cacheKey, workDirs, absPath)
-	}
-	if err != nil {
-		return err
+		var err error
+		matchedKey, err = performFromCDN(ctx, c, cacheKey, restoreKeys, workDirs, absPath)
+		if err != nil {
+			return err
+		}
 	}
-	out := workerruntime.OutputRequest{
+	if err := CreateOutput(ctx, c, workerruntime.OutputRequest{
 		Name:  "cache-hit",
-		Value: strconv.FormatBool(cacheFound),
+		Value: strconv.FormatBool(matchedKey != "" && matchedKey == cacheKey),
+	}); err != nil {
+		return err
 	}
-	return CreateOutput(ctx, c, out)
+	return CreateOutput(ctx, c, workerruntime.OutputRequest{
+		Name:  "cache-matched-key",
+		Value: matchedKey,
+	})
 }
 
 func performFromArtifactory(ctx context.Context, c *actionplugin.Common, jobCtx sdk.WorkflowRunJobsContext, cacheKey string, workDirs *sdk.WorkerDirectories, absPath string, failOnMiss bool) (bool, error) {
@@ -92,54 +121,62 @@ func performFromArtifactory(ctx context.Context, c *actionplugin.Common, jobCtx
 	return true, nil
 }
 
-func performFromCDN(ctx context.Context, c *actionplugin.Common, cacheKey string, workDirs *sdk.WorkerDirectories, absPath string) (bool, error) {
-	items, err := GetV2CacheLink(ctx, c, cacheKey)
+func performFromCDN(ctx context.Context, c *actionplugin.Common, cacheKey string, restoreKeys []string, workDirs *sdk.WorkerDirectories, absPath string) (string, error) {
+	items, err := GetV2CacheLink(ctx, c, cacheKey, restoreKeys)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 	if len(items.Items) == 0 {
 		Warn(c, "no cache found")
-		return false, nil
+		return "", nil
 	}
 	if len(items.Items) != 1 {
-		return false, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to get one cache with key %s. Got %d", cacheKey, len(items.Items))
+		return "", sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to get one cache with key %s. Got %d", cacheKey, len(items.Items))
+	}
+
+	matchedKey := cacheKey
+	if apiRef, is := items.Items[0].GetCDNWorkerCacheApiRef(); is {
+		matchedKey = apiRef.CacheTag
+	}
+	if matchedKey != cacheKey {
+		Logf(c, "No cache found for key %s, restoring cache %s", cacheKey, matchedKey)
 	}
 
 	cdnSig, err := GetV2CacheSignature(ctx, c, cacheKey)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/item/%s/%s/download", items.CDNHttpURL, string(items.Items[0].Type), items.Items[0].APIRefHash), nil)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 	req.Header.Set("X-CDS-WORKER-SIGNATURE", cdnSig.Signature)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode > 200 {
-		return false, sdk.Errorf("unable to download cache (HTTP %d)", resp.StatusCode)
+		return "", sdk.Errorf("unable to download cache (HTTP %d)", resp.StatusCode)
 	}
 
 	if err := os.MkdirAll(absPath, os.FileMode(0744)); err != nil {
-		return false, fmt.Errorf("unable to create destination directory: %v", err)
+		return "", fmt.Errorf("unable to create destination directory: %v", err)
 	}
 
 	// Stream directly: HTTP body → gzip → tar → filesystem (no intermediate file)
 	countReader := &countingReader{r: resp.Body}
 	t0 := time.Now()
 	if err := sdk.UntarGz(afero.NewOsFs(), absPath, countReader); err != nil {
-		return false, fmt.Errorf("unable to extract cache: %v", err)
+		return "", fmt.Errorf("unable to extract cache: %v", err)
 	}
 	elapsed := time.Since(t0)
 
 	Successf(c, "Cache restored to %s (%d bytes downloaded and extracted in %.3f seconds).", absPath, countReader.n, elapsed.Seconds())
-	return true, nil
+	return matchedKey, nil
 }
 
 // countingReader wraps an io.Reader and counts bytes read.
@@ -0,0 +1,12 @@
+package grpcplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRestoreKeys(t *testing.T) {
+	require.Nil(t, ParseRestoreKeys(""))
+	require.Equal(t, []string{"go-mod-linux-", "go-mod-"}, ParseRestoreKeys("go-mod-linux-\n  go-mod-  \n\n"))
+}
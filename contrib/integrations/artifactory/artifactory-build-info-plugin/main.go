@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/contrib/grpcplugins"
@@ -61,16 +60,14 @@ func (e *artifactoryBuildInfoPlugin) Run(ctx context.Context, opts *integrationp
 	log.Factory = log.NewStdWrapper(log.StdWrapperOptions{DisableTimestamp: true, Level: log.LevelInfo})
 	log.UnregisterField(log.FieldCaller, log.FieldSourceFile, log.FieldSourceLine, log.FieldStackTrace)
 
-	artifactoryURL := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigURL)]
-	token := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigToken)]
 	tokenName := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigTokenName)]
 	lowMaturitySuffix := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigPromotionLowMaturity)]
 	artifactoryProjectKey := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigProjectKey)]
 	buildInfo := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigBuildInfoPrefix)]
 
-	artifactClient, err := artifact_manager.NewClient("artifactory", artifactoryURL, token)
+	artifactClient, err := art.NewArtifactManagerClient(opts.GetOptions())
 	if err != nil {
-		return fail("Failed to create artifactory client: %s", err)
+		return fail("Failed to create artifact manager client: %s", err)
 	}
 
 	nodeRunURL := opts.GetOptions()["cds.ui.pipeline.run"]
@@ -112,7 +109,7 @@ func (e *artifactoryBuildInfoPlugin) Run(ctx context.Context, opts *integrationp
 	if err != nil {
 		return fail("unable to prepare build info: %v", err)
 	}
-	fmt.Printf("Creating Artifactory Build %s %s on project %s...\n", buildInfoRequest.Name, buildInfoRequest.Number, artifactoryProjectKey)
+	fmt.Printf("Creating Build %s %s on project %s...\n", buildInfoRequest.Name, buildInfoRequest.Number, artifactoryProjectKey)
 
 	if err := artifactClient.DeleteBuild(artifactoryProjectKey, buildInfoRequest.Name, buildInfoRequest.Number); err != nil {
 		return fail("unable to clean existing build: %v", err)
@@ -132,15 +129,11 @@ func (e *artifactoryBuildInfoPlugin) Run(ctx context.Context, opts *integrationp
 	}
 
 	// Temporary code
-	if opts.GetOptions()["cds.proj.xray.enabled"] == "true" {
+	if scanner, ok := artifactClient.(artifact_manager.BuildScanner); ok && opts.GetOptions()["cds.proj.xray.enabled"] == "true" {
 		fmt.Printf("Triggering XRay Build %s %s scan...\n", buildInfoRequest.Name, buildInfoRequest.Number)
 
 		// Scan build info
-		scanBuildRequest := services.NewXrayScanParams()
-		scanBuildRequest.BuildName = buildInfoRequest.Name
-		scanBuildRequest.BuildNumber = buildInfoRequest.Number
-		scanBuildRequest.ProjectKey = artifactoryProjectKey
-		scanBuildResponseBtes, err := artifactClient.XrayScanBuild(scanBuildRequest)
+		scanBuildResponseBtes, err := scanner.ScanBuild(artifactoryProjectKey, buildInfoRequest.Name, buildInfoRequest.Number)
 		if err != nil {
 			fmt.Println(err.Error())
 		} else {
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/contrib/grpcplugins"
@@ -59,20 +58,17 @@ func (e *artifactoryPromotePlugin) Run(ctx context.Context, opts *integrationplu
 	log.Factory = log.NewStdWrapper(log.StdWrapperOptions{DisableTimestamp: true, Level: log.LevelInfo})
 	log.UnregisterField(log.FieldCaller, log.FieldSourceFile, log.FieldSourceLine, log.FieldStackTrace)
 
-	artifactoryURL := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigURL)]
-	token := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigToken)]
-
 	artifactList := opts.GetOptions()["artifacts"]
 	destMaturity := opts.GetOptions()["destMaturity"]
 	if destMaturity == "" {
 		destMaturity = DefaultHighMaturity
 	}
 
-	var props *utils.Properties
+	var props artifact_manager.Properties
 	var err error
 	setProperties := opts.GetOptions()["setProperties"]
 	if setProperties != "" {
-		props, err = utils.ParseProperties(setProperties)
+		props, err = artifact_manager.ParseProperties(setProperties)
 		if err != nil {
 			return fail("unable to parse given properties: %v", err)
 		}
@@ -86,9 +82,9 @@ func (e *artifactoryPromotePlugin) Run(ctx context.Context, opts *integrationplu
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Minute)
 	defer cancel()
 
-	artifactClient, err := artifact_manager.NewClient("artifactory", artifactoryURL, token)
+	artifactClient, err := art.NewArtifactManagerClient(opts.GetOptions())
 	if err != nil {
-		return fail("Failed to create artifactory client: %s", err)
+		return fail("Failed to create artifact manager client: %s", err)
 	}
 
 	artSplit := strings.Split(artifactList, ",")
@@ -62,6 +62,7 @@ func (e *artifactoryReleasePlugin) Run(ctx context.Context, opts *integrationplu
 	log.Factory = log.NewStdWrapper(log.StdWrapperOptions{DisableTimestamp: true, Level: log.LevelInfo})
 	log.UnregisterField(log.FieldCaller, log.FieldSourceFile, log.FieldSourceLine, log.FieldStackTrace)
 
+	platform := art.IntegrationPlatform(opts.GetOptions())
 	artifactoryURL := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigURL)]
 	distributionURL := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigDistributionURL)]
 	token := opts.GetOptions()[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigToken)]
@@ -80,11 +81,11 @@ func (e *artifactoryReleasePlugin) Run(ctx context.Context, opts *integrationplu
 		destMaturity = DefaultHighMaturity
 	}
 
-	var props *utils.Properties
+	var props artifact_manager.Properties
 	var err error
 	setProperties := opts.GetOptions()["setProperties"]
 	if setProperties != "" {
-		props, err = utils.ParseProperties(setProperties)
+		props, err = artifact_manager.ParseProperties(setProperties)
 		if err != nil {
 			return fail("unable to parse given properties: %v", err)
 		}
@@ -109,14 +110,9 @@ func (e *artifactoryReleasePlugin) Run(ctx context.Context, opts *integrationplu
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
 	defer cancel()
 
-	distriClient, err := art.CreateDistributionClient(ctx, distributionURL, releaseToken)
-	if err != nil {
-		return fail("unable to create distribution client: %v", err)
-	}
-
-	artifactClient, err := artifact_manager.NewClient("artifactory", artifactoryURL, token)
+	artifactClient, err := art.NewArtifactManagerClient(opts.GetOptions())
 	if err != nil {
-		return fail("Failed to create artifactory client: %s", err)
+		return fail("Failed to create artifact manager client: %s", err)
 	}
 
 	artSplit := strings.Split(artifactList, ",")
@@ -190,6 +186,19 @@ func (e *artifactoryReleasePlugin) Run(ctx context.Context, opts *integrationplu
 		return fail("There is no artifact to release.")
 	}
 
+	// Release bundles and their distribution are Artifactory features
+	if platform != artifact_manager.TypeArtifactory {
+		fmt.Printf("Release bundles are not available on %s, artifacts have only been promoted\n", platform)
+		return &integrationplugin.RunResult{
+			Status: sdk.StatusSuccess,
+		}, nil
+	}
+
+	distriClient, err := art.CreateDistributionClient(ctx, distributionURL, releaseToken)
+	if err != nil {
+		return fail("unable to create distribution client: %v", err)
+	}
+
 	// Release bundle
 	releaseName, releaseVersion, err := e.createReleaseBundle(distriClient, projectKey, workflowName, version, buildInfo, promotedArtifacts, destMaturity, releaseNote)
 	if err != nil {
@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +21,7 @@ import (
 
 	art "github.com/ovh/cds/contrib/integrations/artifactory"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/artifact_manager"
 	"github.com/ovh/cds/sdk/grpcplugin/integrationplugin"
 )
 
@@ -77,35 +82,63 @@ func (e *artifactoryUploadArtifactPlugin) Run(ctx context.Context, opts *integra
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Minute)
 	defer cancel()
 
-	artiClient, err := art.CreateArtifactoryClient(ctx, artifactoryURL, token)
-	if err != nil {
-		return fail("unable to create artifactory client: %v", err)
-	}
-	log.SetLogger(log.NewLogger(log.INFO, os.Stdout))
-
-	params := services.NewUploadParams()
-	params.Pattern = pathToUpload
-	params.Target = fmt.Sprintf("%s/%s/%s/%s/", cdsRepo, projectKey, workflowName, version)
-	params.Flat = true
-	params.BuildProps = fmt.Sprintf("build.name=%s/%s/%s;build.number=%s;build.timestamp=%d", buildInfo, projectKey, workflowName, url.QueryEscape(version), time.Now().Unix())
-
-	summary, err := artiClient.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, params)
-	if err != nil || summary.TotalFailed > 0 {
-		return fail("unable to upload file %s into artifactory[%s] %s: %v", pathToUpload, artifactoryURL, params.Target, err)
-	}
-	defer summary.Close()
+	target := fmt.Sprintf("%s/%s/%s/", projectKey, workflowName, version)
+	buildProps := fmt.Sprintf("build.name=%s/%s/%s;build.number=%s;build.timestamp=%d", buildInfo, projectKey, workflowName, url.QueryEscape(version), time.Now().Unix())
 
 	result := make(map[string]string)
-	for artDetails := new(utils.ArtifactDetails); summary.ArtifactsDetailsReader.NextRecord(artDetails) == nil; artDetails = new(utils.ArtifactDetails) {
-		art, err := artDetails.ToBuildInfoArtifact()
+	if platform := art.IntegrationPlatform(opts.GetOptions()); platform != artifact_manager.TypeArtifactory {
+		// Other artifact managers only upload the given file through the artifact manager client
+		artifactClient, err := art.NewArtifactManagerClient(opts.GetOptions())
 		if err != nil {
-			return fail("unable to get artifact build info: %v", err)
+			return fail("unable to create %s client: %v", platform, err)
 		}
-		result[sdk.ArtifactUploadPluginOutputPathMD5] = artDetails.Checksums.Md5
-		result[sdk.ArtifactUploadPluginOutputPathFilePath] = strings.TrimPrefix(art.Path, cdsRepo+"/")
-		result[sdk.ArtifactUploadPluginOutputPathFileName] = art.Name
+		filePath := target + filepath.Base(pathToUpload)
+		md5sum, err := uploadFile(ctx, artifactClient, cdsRepo, filePath, pathToUpload)
+		if err != nil {
+			return fail("unable to upload file %s into %s[%s] %s: %v", pathToUpload, platform, artifactoryURL, cdsRepo+"/"+target, err)
+		}
+		props, err := artifact_manager.ParseProperties(buildProps)
+		if err != nil {
+			return fail("unable to parse build properties: %v", err)
+		}
+		if err := artifactClient.SetProperties(cdsRepo, filePath, props); err != nil {
+			return fail("unable to set build properties on %s: %v", filePath, err)
+		}
+		result[sdk.ArtifactUploadPluginOutputPathMD5] = md5sum
+		result[sdk.ArtifactUploadPluginOutputPathFilePath] = filePath
+		result[sdk.ArtifactUploadPluginOutputPathFileName] = filepath.Base(pathToUpload)
 		result[sdk.ArtifactUploadPluginOutputPathRepoType] = "generic"
 		result[sdk.ArtifactUploadPluginOutputPathRepoName] = cdsRepo
+	} else {
+		artiClient, err := art.CreateArtifactoryClient(ctx, artifactoryURL, token)
+		if err != nil {
+			return fail("unable to create artifactory client: %v", err)
+		}
+		log.SetLogger(log.NewLogger(log.INFO, os.Stdout))
+
+		params := services.NewUploadParams()
+		params.Pattern = pathToUpload
+		params.Target = cdsRepo + "/" + target
+		params.Flat = true
+		params.BuildProps = buildProps
+
+		summary, err := artiClient.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, params)
+		if err != nil || summary.TotalFailed > 0 {
+			return fail("unable to upload file %s into artifactory[%s] %s: %v", pathToUpload, artifactoryURL, params.Target, err)
+		}
+		defer summary.Close()
+
+		for artDetails := new(utils.ArtifactDetails); summary.ArtifactsDetailsReader.NextRecord(artDetails) == nil; artDetails = new(utils.ArtifactDetails) {
+			art, err := artDetails.ToBuildInfoArtifact()
+			if err != nil {
+				return fail("unable to get artifact build info: %v", err)
+			}
+			result[sdk.ArtifactUploadPluginOutputPathMD5] = artDetails.Checksums.Md5
+			result[sdk.ArtifactUploadPluginOutputPathFilePath] = strings.TrimPrefix(art.Path, cdsRepo+"/")
+			result[sdk.ArtifactUploadPluginOutputPathFileName] = art.Name
+			result[sdk.ArtifactUploadPluginOutputPathRepoType] = "generic"
+			result[sdk.ArtifactUploadPluginOutputPathRepoName] = cdsRepo
+		}
 	}
 
 	fileMode, err := os.Stat(pathToUpload)
@@ -121,6 +154,20 @@ func (e *artifactoryUploadArtifactPlugin) Run(ctx context.Context, opts *integra
 	}, nil
 }
 
+// uploadFile streams a local file to the artifact manager and returns its md5 checksum.
+func uploadFile(ctx context.Context, artifactClient artifact_manager.ArtifactManager, repoName, filePath, localPath string) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if err := artifactClient.UploadFile(ctx, repoName, filePath, io.TeeReader(f, h)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func main() {
 	e := artifactoryUploadArtifactPlugin{}
 	if err := integrationplugin.Start(context.Background(), &e); err != nil {
@@ -9,11 +9,8 @@ import (
 	"strings"
 	"time"
 
-	buildinfo "github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
-	"github.com/jfrog/jfrog-client-go/artifactory/services"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/distribution"
 	authdistrib "github.com/jfrog/jfrog-client-go/distribution/auth"
@@ -86,6 +83,22 @@ func CreateArtifactoryClient(ctx context.Context, url, token string) (artifactor
 	return artifactory.New(serviceConfig)
 }
 
+// IntegrationPlatform returns the artifact manager platform set in the options of an integration plugin, Artifactory by default.
+func IntegrationPlatform(options map[string]string) string {
+	platform := options[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigPlatform)]
+	if platform == "" {
+		return artifact_manager.TypeArtifactory
+	}
+	return platform
+}
+
+// NewArtifactManagerClient creates the client of the artifact manager integration given in the options of an integration plugin.
+func NewArtifactManagerClient(options map[string]string) (artifact_manager.ArtifactManager, error) {
+	return artifact_manager.NewClient(IntegrationPlatform(options),
+		options[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigURL)],
+		options[fmt.Sprintf("cds.integration.artifact_manager.%s", sdk.ArtifactoryConfigToken)])
+}
+
 type FileToPromote struct {
 	RepoType string
 	RepoName string
@@ -93,7 +106,7 @@ type FileToPromote struct {
 	Path     string
 }
 
-func PromoteFile(artiClient artifact_manager.ArtifactManager, data FileToPromote, lowMaturity, highMaturity string, props *utils.Properties, skipExistingArtifacts bool) (bool, error) {
+func PromoteFile(artiClient artifact_manager.ArtifactManager, data FileToPromote, lowMaturity, highMaturity string, props artifact_manager.Properties, skipExistingArtifacts bool) (bool, error) {
 	hasBeenPromoted := false
 	// artifactory does not manage virtual cargo repositories
 	var srcRepo, targetRepo string
@@ -107,10 +120,12 @@ func PromoteFile(artiClient artifact_manager.ArtifactManager, data FileToPromote
 		targetRepo = fmt.Sprintf("%s-%s", data.RepoName, highMaturity)
 	}
 
-	params := services.NewMoveCopyParams()
-	params.Pattern = fmt.Sprintf("%s/%s", srcRepo, data.Path)
-	params.Target = fmt.Sprintf("%s/%s", targetRepo, data.Path)
-	params.Flat = true
+	params := artifact_manager.PromoteParams{
+		Type:       artifact_manager.PromoteTypeFile,
+		SourceRepo: srcRepo,
+		TargetRepo: targetRepo,
+		Path:       data.Path,
+	}
 
 	if lowMaturity == highMaturity {
 		fmt.Printf("%s has been already promoted\n", data.Name)
@@ -134,22 +149,12 @@ func PromoteFile(artiClient artifact_manager.ArtifactManager, data FileToPromote
 
 			if maturity == "release" {
 				fmt.Printf("Copying file %s from %s to %s\n", data.Name, srcRepo, targetRepo)
-				nbSuccess, nbFailed, err := artiClient.Copy(params)
-				if err != nil {
-					return hasBeenPromoted, err
-				}
-				if nbFailed > 0 || nbSuccess == 0 {
-					return hasBeenPromoted, fmt.Errorf("%s: copy failed with no reason", data.Name)
-				}
+				params.Copy = true
 			} else {
 				fmt.Printf("Promoting file %s from %s to %s\n", data.Name, srcRepo, targetRepo)
-				nbSuccess, nbFailed, err := artiClient.Move(params)
-				if err != nil {
-					return hasBeenPromoted, err
-				}
-				if nbFailed > 0 || nbSuccess == 0 {
-					return hasBeenPromoted, fmt.Errorf("%s: copy failed with no reason", data.Name)
-				}
+			}
+			if err := artiClient.Promote(params); err != nil {
+				return hasBeenPromoted, err
 			}
 			hasBeenPromoted = true
 		} else {
@@ -167,7 +172,7 @@ func PromoteFile(artiClient artifact_manager.ArtifactManager, data FileToPromote
 	return hasBeenPromoted, nil
 }
 
-func PromoteDockerImage(ctx context.Context, artiClient artifact_manager.ArtifactManager, data FileToPromote, lowMaturity, highMaturity string, props *utils.Properties, skipExistingArtifacts bool) (bool, error) {
+func PromoteDockerImage(ctx context.Context, artiClient artifact_manager.ArtifactManager, data FileToPromote, lowMaturity, highMaturity string, props artifact_manager.Properties, skipExistingArtifacts bool) (bool, error) {
 	hasBeenPromoted := false
 	sourceRepo := fmt.Sprintf("%s-%s", data.RepoName, lowMaturity)
 	targetRepo := fmt.Sprintf("%s-%s", data.RepoName, highMaturity)
@@ -179,9 +184,13 @@ func PromoteDockerImage(ctx context.Context, artiClient artifact_manager.Artifac
 	// Remove tag part
 	dockerImageSplit = dockerImageSplit[0 : len(dockerImageSplit)-1]
 	dockerImage := strings.TrimPrefix(strings.Join(dockerImageSplit, "/"), "/")
-	params := services.NewDockerPromoteParams(dockerImage, sourceRepo, targetRepo)
-	params.SourceTag = tag
-	params.TargetTag = tag
+	params := artifact_manager.PromoteParams{
+		Type:       artifact_manager.PromoteTypeDocker,
+		SourceRepo: sourceRepo,
+		TargetRepo: targetRepo,
+		Path:       dockerImage,
+		Tag:        tag,
+	}
 
 	if lowMaturity == highMaturity {
 		fmt.Printf("%s has been already promoted\n", data.Name)
@@ -208,7 +217,7 @@ func PromoteDockerImage(ctx context.Context, artiClient artifact_manager.Artifac
 				fmt.Printf("Promoting docker image %s from %s to %s\n", data.Name, params.SourceRepo, params.TargetRepo)
 				params.Copy = false
 			}
-			if err := artiClient.PromoteDocker(params); err != nil {
+			if err := artiClient.Promote(params); err != nil {
 				return hasBeenPromoted, err
 			}
 			hasBeenPromoted = true
@@ -253,7 +262,7 @@ type BuildInfoRequest struct {
 	RunResultsV2             []sdk.V2WorkflowRunResult
 }
 
-func PrepareBuildInfo(ctx context.Context, artiClient artifact_manager.ArtifactManager, r BuildInfoRequest) (*buildinfo.BuildInfo, error) {
+func PrepareBuildInfo(ctx context.Context, artiClient artifact_manager.ArtifactManager, r BuildInfoRequest) (*artifact_manager.BuildInfo, error) {
 	var buildInfoName string
 	if r.VCS != "" && r.Repository != "" {
 		buildInfoName = fmt.Sprintf("%s/%s/%s/%s/%s", r.BuildInfoPrefix, r.ProjectKey, r.VCS, r.Repository, r.WorkflowName)
@@ -266,33 +275,30 @@ func PrepareBuildInfo(ctx context.Context, artiClient artifact_manager.ArtifactM
 
 	log.Debug(ctx, "PrepareBuildInfo %q maturity:%q", buildInfoName, r.DefaultLowMaturitySuffix)
 
-	buildInfoRequest := &buildinfo.BuildInfo{
+	buildInfoRequest := &artifact_manager.BuildInfo{
 		Properties: map[string]string{},
 		Name:       buildInfoName,
-		Agent: &buildinfo.Agent{
+		Agent: artifact_manager.BuildAgent{
 			Name:    "artifactory-build-info-plugin",
 			Version: sdk.VERSION,
 		},
-		BuildAgent: &buildinfo.Agent{
+		BuildAgent: artifact_manager.BuildAgent{
 			Name:    r.AgentName,
 			Version: sdk.VERSION,
 		},
-		Principal:     fmt.Sprintf("token:%s", r.TokenName),
-		PluginVersion: sdk.VERSION,
-		Started:       time.Now().Format("2006-01-02T15:04:05.999-07:00"),
-		Number:        r.Version,
-		BuildUrl:      r.RunURL,
-		Modules:       []buildinfo.Module{},
-		VcsList:       make([]buildinfo.Vcs, 0),
+		Principal: fmt.Sprintf("token:%s", r.TokenName),
+		Started:   time.Now().Format("2006-01-02T15:04:05.999-07:00"),
+		Number:    r.Version,
+		URL:       r.RunURL,
+		Modules:   []artifact_manager.BuildModule{},
+		VCS: []artifact_manager.BuildVCS{{
+			Branch:   r.GitBranch,
+			Message:  r.GitMessage,
+			URL:      r.GitURL,
+			Revision: r.GitHash,
+		}},
 	}
 
-	buildInfoRequest.VcsList = append(buildInfoRequest.VcsList, buildinfo.Vcs{
-		Branch:   r.GitBranch,
-		Message:  r.GitMessage,
-		Url:      r.GitURL,
-		Revision: r.GitHash,
-	})
-
 	execContext := executionContext{
 		buildInfoName:            buildInfoName,
 		defaultLowMaturitySuffix: r.DefaultLowMaturitySuffix,
@@ -318,10 +324,10 @@ func PrepareBuildInfo(ctx context.Context, artiClient artifact_manager.ArtifactM
 	return buildInfoRequest, nil
 }
 
-func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.ArtifactManager, execContext executionContext, runResults []sdk.WorkflowRunResult) ([]buildinfo.Module, error) {
+func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.ArtifactManager, execContext executionContext, runResults []sdk.WorkflowRunResult) ([]artifact_manager.BuildModule, error) {
 	ctx, end := telemetry.Span(ctx, "artifactory.computeBuildInfoModules")
 	defer end()
-	modules := make([]buildinfo.Module, 0)
+	modules := make([]artifact_manager.BuildModule, 0)
 
 	for _, r := range runResults {
 		if r.Type != sdk.WorkflowRunResultTypeArtifactManager {
@@ -333,10 +339,9 @@ func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.Ar
 			return nil, err
 		}
 
-		mod := buildinfo.Module{
-			Id:           fmt.Sprintf("%s:%s", data.RepoType, data.Name),
-			Artifacts:    make([]buildinfo.Artifact, 0),
-			Dependencies: nil,
+		mod := artifact_manager.BuildModule{
+			ID:        fmt.Sprintf("%s:%s", data.RepoType, data.Name),
+			Artifacts: make([]artifact_manager.BuildArtifact, 0),
 		}
 
 		var currentMaturity string
@@ -352,7 +357,7 @@ func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.Ar
 
 		switch data.FileType {
 		case "docker":
-			mod.Type = buildinfo.Docker
+			mod.Type = artifact_manager.BuildModuleTypeDocker
 			modProps := make(map[string]string)
 			parsedUrl, err := url.Parse(artiClient.GetURL())
 			if err != nil {
@@ -365,19 +370,17 @@ func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.Ar
 			modProps["docker.image.tag"] = fmt.Sprintf("%s.%s/%s", data.RepoName, urlArtifactory, data.Name)
 			mod.Properties = modProps
 
-			query := fmt.Sprintf(`items.find({"name" : {"$match": "**"}, "repo":"%s", "path":"%s"}).include("repo","path","name","virtual_repos","actual_md5")`, data.RepoName, strings.TrimSuffix(data.Path, "/"))
+			query := artifact_manager.SearchQuery{Repository: data.RepoName, Path: strings.TrimSuffix(data.Path, "/")}
 			searchResults, err := artiClient.Search(ctx, query)
 			if err != nil {
 				return nil, err
 			}
 
 			for _, sr := range searchResults {
-				currentArtifact := buildinfo.Artifact{
+				currentArtifact := artifact_manager.BuildArtifact{
 					Name: sr.Name,
 					Type: strings.TrimPrefix(filepath.Ext(sr.Name), "."),
-					Checksum: buildinfo.Checksum{
-						Md5: sr.ActualMD5,
-					},
+					MD5:  sr.ActualMD5,
 				}
 				mod.Artifacts = append(mod.Artifacts, currentArtifact)
 
@@ -400,22 +403,20 @@ func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.Ar
 					for _, m := range multiArchManifests.Manifests {
 						rootPath := filepath.Dir(strings.TrimSuffix(data.Path, "/"))
 						subManifestPath := strings.TrimPrefix(rootPath+"/"+m.Digest, "/")
-						querySubManifest := fmt.Sprintf(`items.find({"name" : {"$match": "**"}, "repo":"%s", "path":"%s"}).include("repo","path","name","virtual_repos","actual_md5")`, data.RepoName, subManifestPath)
+						querySubManifest := artifact_manager.SearchQuery{Repository: data.RepoName, Path: subManifestPath}
 						searchResultsSubManifest, err := artiClient.Search(ctx, querySubManifest)
 						if err != nil {
 							return nil, err
 						}
 						for _, srSubManifest := range searchResultsSubManifest {
-							currentArtifact := buildinfo.Artifact{
+							currentArtifact := artifact_manager.BuildArtifact{
 								Name: srSubManifest.Name,
 								Type: strings.TrimPrefix(filepath.Ext(srSubManifest.Name), "."),
-								Checksum: buildinfo.Checksum{
-									Md5: srSubManifest.ActualMD5,
-								},
+								MD5:  srSubManifest.ActualMD5,
 							}
 							mod.Artifacts = append(mod.Artifacts, currentArtifact)
 						}
-						props := utils.NewProperties()
+						props := artifact_manager.NewProperties()
 						props.AddProperty("build.name", execContext.buildInfoName)
 						props.AddProperty("build.number", execContext.version)
 						props.AddProperty("build.timestamp", strconv.FormatInt(time.Now().Unix(), 10))
@@ -429,18 +430,16 @@ func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.Ar
 			}
 		default:
 			_, objectName := filepath.Split(data.Path)
-			currentArtifact := buildinfo.Artifact{
+			currentArtifact := artifact_manager.BuildArtifact{
 				Name: objectName,
 				Type: strings.TrimPrefix(filepath.Ext(objectName), "."),
-				Checksum: buildinfo.Checksum{
-					Md5: data.MD5,
-				},
+				MD5:  data.MD5,
 			}
 			mod.Artifacts = append(mod.Artifacts, currentArtifact)
 		}
 		modules = append(modules, mod)
 
-		props := utils.NewProperties()
+		props := artifact_manager.NewProperties()
 		props.AddProperty("build.name", execContext.buildInfoName)
 		props.AddProperty("build.number", execContext.version)
 		props.AddProperty("build.timestamp", strconv.FormatInt(time.Now().Unix(), 10))
@@ -453,10 +452,10 @@ func computeBuildInfoModules(ctx context.Context, artiClient artifact_manager.Ar
 	return modules, nil
 }
 
-func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.ArtifactManager, execContext executionContext, runResults []sdk.V2WorkflowRunResult) ([]buildinfo.Module, error) {
+func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.ArtifactManager, execContext executionContext, runResults []sdk.V2WorkflowRunResult) ([]artifact_manager.BuildModule, error) {
 	ctx, end := telemetry.Span(ctx, "artifactory.computeBuildInfoModulesV2")
 	defer end()
-	modules := make([]buildinfo.Module, 0)
+	modules := make([]artifact_manager.BuildModule, 0)
 
 	for _, r := range runResults {
 		if r.ArtifactManagerIntegrationName == nil {
@@ -473,10 +472,9 @@ func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.
 			dir           = r.ArtifactManagerMetadata.Get("dir")
 		)
 
-		mod := buildinfo.Module{
-			Id:           fmt.Sprintf("%s:%s", repoType, name),
-			Artifacts:    make([]buildinfo.Artifact, 0),
-			Dependencies: nil,
+		mod := artifact_manager.BuildModule{
+			ID:        fmt.Sprintf("%s:%s", repoType, name),
+			Artifacts: make([]artifact_manager.BuildArtifact, 0),
 		}
 
 		var currentMaturity string
@@ -493,26 +491,24 @@ func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.
 
 		switch repoType {
 		case "docker":
-			mod.Type = buildinfo.Docker
+			mod.Type = artifact_manager.BuildModuleTypeDocker
 			modProps := make(map[string]string)
 			modProps["docker.image.tag"] = name
 			mod.Properties = modProps
 
 			path = strings.TrimPrefix(strings.TrimSuffix(dir, "/"), "/")
 
-			query := fmt.Sprintf(`items.find({"name" : {"$match": "**"}, "repo":"%s", "path":"%s"}).include("repo","path","name","virtual_repos","actual_md5")`, repoName+"-"+currentMaturity, path)
+			query := artifact_manager.SearchQuery{Repository: repoName + "-" + currentMaturity, Path: path}
 			searchResults, err := artiClient.Search(ctx, query)
 			if err != nil {
 				return nil, err
 			}
 
 			for _, sr := range searchResults {
-				currentArtifact := buildinfo.Artifact{
+				currentArtifact := artifact_manager.BuildArtifact{
 					Name: sr.Name,
 					Type: strings.TrimPrefix(filepath.Ext(sr.Name), "."),
-					Checksum: buildinfo.Checksum{
-						Md5: sr.ActualMD5,
-					},
+					MD5:  sr.ActualMD5,
 				}
 				mod.Artifacts = append(mod.Artifacts, currentArtifact)
 			}
@@ -524,25 +520,23 @@ func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.
 			}
 			for _, m := range details.Manifests {
 				manifestDir := strings.TrimPrefix(filepath.Dir(m.Path), "/")
-				query := fmt.Sprintf(`items.find({"name" : {"$match": "**"}, "repo":"%s", "path":"%s"}).include("repo","path","name","virtual_repos","actual_md5")`, repoName+"-"+currentMaturity, manifestDir)
+				query := artifact_manager.SearchQuery{Repository: repoName + "-" + currentMaturity, Path: manifestDir}
 				searchResults, err := artiClient.Search(ctx, query)
 				if err != nil {
 					return nil, err
 				}
 
 				for _, sr := range searchResults {
-					currentArtifact := buildinfo.Artifact{
+					currentArtifact := artifact_manager.BuildArtifact{
 						Name: sr.Name,
 						Type: strings.TrimPrefix(filepath.Ext(sr.Name), "."),
-						Checksum: buildinfo.Checksum{
-							Md5: sr.ActualMD5,
-						},
+						MD5:  sr.ActualMD5,
 					}
 					mod.Artifacts = append(mod.Artifacts, currentArtifact)
 				}
 
 				// Add properties on multiarch layers
-				props := utils.NewProperties()
+				props := artifact_manager.NewProperties()
 				props.AddProperty("build.name", execContext.buildInfoName)
 				props.AddProperty("build.number", execContext.version)
 				props.AddProperty("build.timestamp", strconv.FormatInt(time.Now().Unix(), 10))
@@ -556,7 +550,7 @@ func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.
 			if err != nil {
 				return nil, err
 			}
-			mod.Type = buildinfo.Conan
+			mod.Type = artifact_manager.BuildModuleTypeConan
 			modProps := make(map[string]string)
 			modProps["conan.package.version"] = details.Version
 			modProps["conan.package.name"] = details.Name
@@ -565,12 +559,10 @@ func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.
 			path = strings.TrimPrefix(strings.TrimSuffix(dir, "/"), "/")
 
 			for _, f := range details.Files {
-				currentArtifact := buildinfo.Artifact{
+				currentArtifact := artifact_manager.BuildArtifact{
 					Name: f.FileName,
 					Type: strings.TrimPrefix(filepath.Ext(f.FileName), "."),
-					Checksum: buildinfo.Checksum{
-						Md5: f.MD5,
-					},
+					MD5:  f.MD5,
 				}
 				mod.Artifacts = append(mod.Artifacts, currentArtifact)
 			}
@@ -579,36 +571,32 @@ func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.
 			if err != nil {
 				return nil, err
 			}
-			mod.Type = buildinfo.Generic
+			mod.Type = artifact_manager.BuildModuleTypeGeneric
 
 			// An OCI package is a folder; "dir" is not set for OCI, so use the artifact path
 			// (the <name>/<version> folder) for the recursive build-property tagging below.
 			path = strings.TrimPrefix(path, "/")
 
 			for _, f := range details.Files {
-				currentArtifact := buildinfo.Artifact{
+				currentArtifact := artifact_manager.BuildArtifact{
 					Name: f.FileName,
 					Type: strings.TrimPrefix(filepath.Ext(f.FileName), "."),
-					Checksum: buildinfo.Checksum{
-						Md5: f.MD5,
-					},
+					MD5:  f.MD5,
 				}
 				mod.Artifacts = append(mod.Artifacts, currentArtifact)
 			}
 		default:
 			_, objectName := filepath.Split(path)
-			currentArtifact := buildinfo.Artifact{
+			currentArtifact := artifact_manager.BuildArtifact{
 				Name: objectName,
 				Type: strings.TrimPrefix(filepath.Ext(objectName), "."),
-				Checksum: buildinfo.Checksum{
-					Md5: md5,
-				},
+				MD5:  md5,
 			}
 			mod.Artifacts = append(mod.Artifacts, currentArtifact)
 		}
 		modules = append(modules, mod)
 
-		props := utils.NewProperties()
+		props := artifact_manager.NewProperties()
 		props.AddProperty("build.name", execContext.buildInfoName)
 		props.AddProperty("build.number", execContext.version)
 		props.AddProperty("build.timestamp", strconv.FormatInt(time.Now().Unix(), 10))
@@ -621,7 +609,7 @@ func computeBuildInfoModulesV2(ctx context.Context, artiClient artifact_manager.
 	return modules, nil
 }
 
-func SetPropertiesRecursive(ctx context.Context, client artifact_manager.ArtifactManager, repoType string, repoName string, maturity string, path string, props *utils.Properties) error {
+func SetPropertiesRecursive(ctx context.Context, client artifact_manager.ArtifactManager, repoType string, repoName string, maturity string, path string, props artifact_manager.Properties) error {
 	ctx, end := telemetry.Span(ctx, "artifactory.SetPropertiesRecursive")
 	defer end()
 	if props == nil {
@@ -19,7 +19,6 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
 
 	"github.com/ovh/cds/engine/api/action"
 	"github.com/ovh/cds/engine/api/audit"
@@ -46,6 +45,7 @@ import (
 	"github.com/ovh/cds/engine/api/migrate"
 	"github.com/ovh/cds/engine/api/notification"
 	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/organization"
 	"github.com/ovh/cds/engine/api/purge"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
@@ -64,6 +64,7 @@ import (
 	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/jws"
 	cdslog "github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 // Configuration is the configuration structure for CDS API
@@ -107,9 +108,13 @@ type Configuration struct {
 		DisableAddUserInDefaultGroup bool                       `toml:"disableAddUserInDefaultGroup" default:"false" comment:"If false, user are automatically added in the default group" json:"disableAddUserInDefaultGroup"`
 		RSAPrivateKey                string                     `toml:"rsaPrivateKey" default:"" comment:"The RSA Private Key used to sign and verify the JWT Tokens issued by the API \nThis is mandatory." json:"-"`
 		RSAPrivateKeys               []authentication.KeyConfig `toml:"rsaPrivateKeys" default:"" comment:"RSA Private Keys used to sign and verify the JWT Tokens issued by the API \nThis is mandatory." json:"-" mapstructure:"rsaPrivateKeys"`
+		AttestationPrivateKeys       []authentication.KeyConfig `toml:"attestationPrivateKeys" default:"" comment:"RSA Private Keys used to sign the attestations of the run results when a project does not have an attestation key. \nThey must be different from the JWT keys." json:"-" mapstructure:"attestationPrivateKeys"`
 		AllowedOrganizations         sdk.StringSlice            `toml:"allowedOrganizations" comment:"The list of allowed organizations for CDS users, let empty to authorize all organizations." json:"allowedOrganizations"`
+		PermissionGrantMaxDuration   int64                      `toml:"permissionGrantMaxDuration" default:"480" comment:"Maximum duration of a temporary permission requested by a user (in minutes)" json:"permissionGrantMaxDuration"`
+		GroupSyncInterval            int64                      `toml:"groupSyncInterval" default:"60" comment:"Interval between two synchronizations of the synced group memberships, for the drivers that can get the groups of a user outside of a signin (in minutes, 0 to disable)" json:"groupSyncInterval"`
 		LDAP                         struct {
-			SigninEnabled bool `toml:"signinEnabled" default:"false" json:"SigninEnabled"`
+			SigninEnabled bool                   `toml:"signinEnabled" default:"false" json:"SigninEnabled"`
+			GroupSync     sdk.AuthGroupSyncRules `toml:"groupSync" comment:"Rules mapping the LDAP groups (memberOf DN) of a user to CDS groups and organization, applied at signin and periodically" json:"groupSync" mapstructure:"groupSync"`
 		} `toml:"ldap" json:"ldap"`
 		Local struct {
 			SignupAllowedDomains string `toml:"signupAllowedDomains" default:"" comment:"Allow signup from selected domains only - comma separated. Example: your-domain.com,another-domain.com" commented:"true" json:"signupAllowedDomains"`
@@ -129,9 +134,14 @@ type Configuration struct {
 			Organization  string `toml:"organization" default:"default" comment:"Organization assigned to user created by gitlab authentication" json:"organization"`
 		} `toml:"gitlab" json:"gitlab" comment:"#######\n CDS <-> GitLab Auth. Documentation on https://ovh.github.io/cds/docs/integrations/gitlab/gitlab_authentication/ \n######"`
 		OIDC struct {
-			SigninEnabled bool   `toml:"signinEnabled" default:"false" json:"signinEnabled"`
-			Organization  string `toml:"organization" default:"default" comment:"Organization assigned to user created by openid authentication" json:"organization"`
+			SigninEnabled bool                   `toml:"signinEnabled" default:"false" json:"signinEnabled"`
+			Organization  string                 `toml:"organization" default:"default" comment:"Organization assigned to user created by openid authentication" json:"organization"`
+			GroupsClaim   string                 `toml:"groupsClaim" default:"groups" comment:"Claim of the ID token that contains the groups of the user" json:"groupsClaim"`
+			GroupSync     sdk.AuthGroupSyncRules `toml:"groupSync" comment:"Rules mapping the groups claim values of a user to CDS groups and organization, applied at signin" json:"groupSync" mapstructure:"groupSync"`
 		} `toml:"oidc" json:"oidc" comment:"#######\n CDS <-> Open ID Connect Auth. Documentation on https://ovh.github.io/cds/docs/integrations/openid-connect/ \n######"`
+		SCIM struct {
+			Organization string `toml:"organization" default:"default" comment:"Organization assigned to user provisioned by SCIM, when not given by the identity provider" json:"organization"`
+		} `toml:"scim" json:"scim" comment:"#######\n SCIM provisioning. Documentation on https://ovh.github.io/cds/docs/integrations/scim/ \n######"`
 	} `toml:"auth" comment:"##############################\n CDS Authentication Settings# \n#############################" json:"auth"`
 	Drivers struct {
 		LDAP struct {
@@ -258,6 +268,10 @@ type Configuration struct {
 		LibraryProjectKey                string `toml:"libraryProjectKey" comment:"Library project key" json:"libraryProjectKey" commented:"true"`
 		VersionRetentionScheduling       int64  `toml:"versionRetentionScheduling" comment:"Time in minute between 2 run of the workflow version purge" json:"versionRetentionScheduling" default:"60"`
 		VersionRetention                 int64  `toml:"versionRetention" comment:"Number of Workflow version CDS keep" json:"versionRetention" commented:"true"`
+		MaxMatrixPermutations            int64  `toml:"maxMatrixPermutations" comment:"Maximum number of jobs generated by a job matrix" json:"maxMatrixPermutations" default:"256"`
+		UsageRetention                   int64  `toml:"usageRetention" comment:"Number of months of job usage kept for the project quotas and usage reports (0 to disable the purge)" json:"usageRetention" default:"13"`
+
+		RunTracing observability.RunTracingConfiguration `toml:"runTracing" comment:"Export workflow runs as OpenTelemetry traces" json:"runTracing"`
 	} `toml:"workflowv2" comment:"######################\n 'Workflow V2' global configuration \n######################" json:"workflowv2"`
 	Entity struct {
 		RoutineDelay      int64  `toml:"routineDelay" comment:"Delay in minutes between to run of entities purge" json:"routineDelay" default:"15"`
@@ -329,37 +343,40 @@ type API struct {
 	WSV2Server          *websocketV2Server
 	WSHatcheryBroker    *websocket.Broker
 	WSHatcheryServer    *websocketHatcheryServer
+	WSJobDebugBroker    *websocket.Broker
+	WSJobDebugServer    *jobDebugServer
 	Cache               cache.Store
 	Metrics             struct {
-		WorkflowRunFailed          *stats.Int64Measure
-		WorkflowRunStarted         *stats.Int64Measure
-		Sessions                   *stats.Int64Measure
-		nbUsers                    *stats.Int64Measure
-		nbApplications             *stats.Int64Measure
-		nbProjects                 *stats.Int64Measure
-		nbGroups                   *stats.Int64Measure
-		nbPipelines                *stats.Int64Measure
-		nbWorkflows                *stats.Int64Measure
-		nbWorkflowsAsCodeV2        *stats.Int64Measure
-		nbArtifacts                *stats.Int64Measure
-		nbWorkerModels             *stats.Int64Measure
-		nbWorkflowRuns             *stats.Int64Measure
-		nbWorkflowNodeRuns         *stats.Int64Measure
-		nbMaxWorkersBuilding       *stats.Int64Measure
-		queue                      *stats.Int64Measure
-		v2Queue                    *stats.Int64Measure
-		WorkflowRunsMarkToDelete   *stats.Int64Measure
-		WorkflowRunsDeleted        *stats.Int64Measure
-		DatabaseConns              *stats.Int64Measure
-		RunResultToSynchronized    *stats.Int64Measure
-		RunResultSynchronized      *stats.Int64Measure
-		RunResultSynchronizedError *stats.Int64Measure
+		WorkflowRunFailed          *telemetry.Int64Measure
+		WorkflowRunStarted         *telemetry.Int64Measure
+		Sessions                   *telemetry.Int64Measure
+		nbUsers                    *telemetry.Int64Measure
+		nbApplications             *telemetry.Int64Measure
+		nbProjects                 *telemetry.Int64Measure
+		nbGroups                   *telemetry.Int64Measure
+		nbPipelines                *telemetry.Int64Measure
+		nbWorkflows                *telemetry.Int64Measure
+		nbWorkflowsAsCodeV2        *telemetry.Int64Measure
+		nbArtifacts                *telemetry.Int64Measure
+		nbWorkerModels             *telemetry.Int64Measure
+		nbWorkflowRuns             *telemetry.Int64Measure
+		nbWorkflowNodeRuns         *telemetry.Int64Measure
+		nbMaxWorkersBuilding       *telemetry.Int64Measure
+		queue                      *telemetry.Int64Measure
+		v2Queue                    *telemetry.Int64Measure
+		WorkflowRunsMarkToDelete   *telemetry.Int64Measure
+		WorkflowRunsDeleted        *telemetry.Int64Measure
+		DatabaseConns              *telemetry.Int64Measure
+		RunResultToSynchronized    *telemetry.Int64Measure
+		RunResultSynchronized      *telemetry.Int64Measure
+		RunResultSynchronizedError *telemetry.Int64Measure
 	}
 	workflowRunCraftChan            chan string
 	workflowRunTriggerChan          chan sdk.V2WorkflowRunEnqueue
 	AuthenticationDrivers           map[sdk.AuthConsumerType]sdk.AuthDriver
 	LinkDrivers                     map[sdk.AuthConsumerType]link.LinkDriver
 	WorkerModelDockerImageWhiteList []regexp.Regexp
+	RunTraceExporter                *observability.RunTraceExporter
 }
 
 // ApplyConfiguration apply an object of type api.Configuration after checking it
@@ -520,6 +537,9 @@ func (a *API) Serve(ctx context.Context) error {
 	if a.Config.WorkflowV2.JobSchedulingMaxErrors <= 0 {
 		a.Config.WorkflowV2.JobSchedulingMaxErrors = 5
 	}
+	if a.Config.WorkflowV2.MaxMatrixPermutations <= 0 {
+		a.Config.WorkflowV2.MaxMatrixPermutations = 256
+	}
 	if a.Config.Entity.RoutineDelay == 0 {
 		a.Config.Entity.RoutineDelay = 15
 	}
@@ -577,6 +597,9 @@ func (a *API) Serve(ctx context.Context) error {
 	if err := authentication.Init(ctx, a.ServiceName, RSAKeyConfigs); err != nil {
 		return sdk.WrapError(err, "unable to initialize the JWT Layer")
 	}
+	if err := authentication.InitAttestation(a.ServiceName, a.Config.Auth.AttestationPrivateKeys); err != nil {
+		return sdk.WrapError(err, "unable to initialize the attestation keys")
+	}
 
 	// Initialize service mesh httpclient
 	if a.Config.InternalServiceMesh.RequestSecondsTimeout == 0 {
@@ -803,6 +826,9 @@ func (a *API) Serve(ctx context.Context) error {
 	if err := a.initHatcheryWebsocket(event_v2.EventHatcheryWS); err != nil {
 		return err
 	}
+	if err := a.initJobDebugWebsocket(jobDebugPubSubKey); err != nil {
+		return err
+	}
 	if err := InitRouterMetrics(ctx, a); err != nil {
 		log.Error(ctx, "unable to init router metrics: %v", err)
 	}
@@ -880,6 +906,7 @@ func (a *API) Serve(ctx context.Context) error {
 			a.Config.Drivers.OIDC.ClientID,
 			a.Config.Drivers.OIDC.ClientSecret,
 			a.Config.Auth.OIDC.Organization,
+			a.Config.Auth.OIDC.GroupsClaim,
 		)
 		if err != nil {
 			return err
@@ -912,6 +939,14 @@ func (a *API) Serve(ctx context.Context) error {
 		a.LinkDrivers[sdk.ConsumerGithub] = d
 	}
 
+	if a.Config.WorkflowV2.RunTracing.Enabled {
+		log.Info(ctx, "Initializing workflow run traces exporter...")
+		a.RunTraceExporter, err = observability.NewRunTraceExporter(ctx, a.Config.WorkflowV2.RunTracing)
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Info(ctx, "Initializing event broker...")
 	if err := event.Initialize(ctx, a.mustDB(), a.Cache, &a.Config.EventBus); err != nil {
 		log.Error(ctx, "error while initializing event system: %s", err)
@@ -1027,6 +1062,12 @@ func (a *API) Serve(ctx context.Context) error {
 	a.GoRoutines.RunWithRestart(ctx, "api.manageWorkerModelsLifecycle", func(ctx context.Context) {
 		a.manageWorkerModelsLifecycle(ctx, 1*time.Minute)
 	})
+	a.GoRoutines.RunWithRestart(ctx, "api.revokeExpiredPermissions", func(ctx context.Context) {
+		a.revokeExpiredPermissions(ctx, 30*time.Second)
+	})
+	a.GoRoutines.RunWithRestart(ctx, "api.synchronizeUserGroups", func(ctx context.Context) {
+		a.synchronizeUserGroups(ctx)
+	})
 	if a.Config.Secrets.SnapshotRetentionDelay > 0 {
 		a.GoRoutines.RunWithRestart(ctx, "workflow.CleanSecretsSnapshot", func(ctx context.Context) {
 			a.cleanWorkflowRunSecrets(ctx)
@@ -1109,6 +1150,10 @@ func (a *API) Serve(ctx context.Context) error {
 		func(ctx context.Context) {
 			purge.PurgeWorkflowRunsV2(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper), a.Cache, a.Config.WorkflowV2.RunRetentionScheduling, a.GoRoutines)
 		})
+	a.GoRoutines.Run(ctx, "Purge-RunJobUsages",
+		func(ctx context.Context) {
+			purge.PurgeRunJobUsages(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper), a.Config.WorkflowV2.UsageRetention)
+		})
 	a.GoRoutines.Run(ctx, "Purge-OldRunsV1",
 		func(ctx context.Context) {
 			purge.MarkOldWorkflowRunsV1(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper), a.Config.Workflow.MaxRetentionDays, a.Config.Workflow.RetentionSchedulingSeconds, a.Config.Workflow.RetentionBatchSize)
@@ -1132,6 +1177,13 @@ func (a *API) Serve(ctx context.Context) error {
 		log.Warn(ctx, "Cleanup SQL connections")
 		s.Shutdown(ctx)               // nolint
 		a.DBConnectionFactory.Close() // nolint
+		if a.RunTraceExporter != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := a.RunTraceExporter.Shutdown(shutdownCtx); err != nil {
+				log.Error(ctx, "unable to shutdown workflow run traces exporter: %v", err)
+			}
+			cancel()
+		}
 		event.Publish(ctx, sdk.EventEngine{Message: "shutdown"}, nil)
 		event.Close(ctx)
 	}()
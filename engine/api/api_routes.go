@@ -470,6 +470,11 @@ func (api *API) InitRouter() {
 
 	r.Handle("/v2/rbac", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getPermissionsHandler))
 	r.Handle("/v2/rbac/import", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postImportRBACHandler))
+	r.Handle("/v2/rbac/grant", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getPermissionGrantsHandler))
+	r.Handle("/v2/rbac/grant/{grantID}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getPermissionGrantHandler))
+	r.Handle("/v2/rbac/grant/{grantID}/approve", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postPermissionGrantApproveHandler))
+	r.Handle("/v2/rbac/grant/{grantID}/reject", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postPermissionGrantRejectHandler))
+	r.Handle("/v2/rbac/grant/{grantID}/revoke", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postPermissionGrantRevokeHandler))
 	r.Handle("/v2/rbac/{rbacIdentifier}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getRBACHandler), r.DELETEv2(api.deleteRBACHandler))
 	r.Handle("/v2/rbac/access/project/session/check", ScopeNone(), r.POSTv2(api.getCheckSessionProjectAccessHandler))
 
@@ -484,9 +489,16 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectsV2Handler))
 	r.Handle("/v2/project/{projectKey}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectV2Handler), r.PUTv2(api.updateProjectV2Handler), r.DELETEv2(api.deleteProjectV2Handler))
 
+	r.Handle("/v2/project/{projectKey}/cache", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectCachesHandler), r.DELETEv2(api.deleteProjectCacheHandler))
 	r.Handle("/v2/project/{projectKey}/concurrency", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectConcurrenciesHandler), r.POSTv2(api.postProjectConcurrencyHandler))
 	r.Handle("/v2/project/{projectKey}/concurrency/{concurrencyName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectConcurrencyHandler), r.PUTv2(api.putProjectConcurrencyHandler), r.DELETEv2(api.deleteProjectConcurrencyHandler))
 	r.Handle("/v2/project/{projectKey}/concurrency/{concurrencyName}/runs", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectConcurrencyRunsHandler))
+	r.Handle("/v2/project/{projectKey}/environment", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectEnvironmentsHandler), r.POSTv2(api.postProjectEnvironmentHandler))
+	r.Handle("/v2/project/{projectKey}/environment/{environmentName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectEnvironmentHandler), r.PUTv2(api.putProjectEnvironmentHandler), r.DELETEv2(api.deleteProjectEnvironmentHandler))
+	r.Handle("/v2/project/{projectKey}/environment/{environmentName}/deployment", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectEnvironmentDeploymentsHandler))
+	r.Handle("/v2/project/{projectKey}/freeze", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectFreezesHandler), r.POSTv2(api.postProjectFreezeHandler))
+	r.Handle("/v2/project/{projectKey}/freeze/{freezeName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectFreezeHandler), r.PUTv2(api.putProjectFreezeHandler), r.DELETEv2(api.deleteProjectFreezeHandler))
+	r.Handle("/v2/project/{projectKey}/freeze/{freezeName}/override", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectFreezeOverridesHandler), r.POSTv2(api.postProjectFreezeOverrideHandler))
 	r.Handle("/v2/project/{projectKey}/hook", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectHooksHandler), r.POSTv2(api.postProjectHookHandler))
 	r.Handle("/v2/project/{projectKey}/hook/{uuid}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectHookHandler), r.DELETEv2(api.deleteProjectHookHandler))
 
@@ -503,8 +515,15 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project/{projectKey}/notification", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectNotifsHandler), r.POSTv2(api.postProjectNotificationHandler))
 	r.Handle("/v2/project/{projectKey}/notification/{notification}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectNotificationHandler), r.PUTv2(api.putProjectNotificationHandler), r.DELETEv2(api.deleteProjectNotificationHandler))
 
+	r.Handle("/v2/project/{projectKey}/quota", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectQuotaHandler), r.PUTv2(api.putProjectQuotaHandler), r.DELETEv2(api.deleteProjectQuotaHandler))
+
+	r.Handle("/v2/project/{projectKey}/rbac/ascode", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectRBACAsCodeHandler), r.PUTv2(api.putProjectRBACAsCodeHandler), r.DELETEv2(api.deleteProjectRBACAsCodeHandler))
+
 	r.Handle("/v2/project/{projectKey}/repositories_manager/{name}/repos", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getReposFromRepositoriesManagerV2Handler))
 
+	r.Handle("/v2/project/{projectKey}/usage", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectUsagesHandler))
+	r.Handle("/v2/project/{projectKey}/usage/{month}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectUsageDetailsHandler))
+
 	r.Handle("/v2/project/{projectKey}/variableset", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectVariableSetsHandler), r.POSTv2(api.postProjectVariableSetHandler))
 	r.Handle("/v2/project/{projectKey}/variableset/{variableSetName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectVariableSetHandler), r.DELETEv2(api.deleteProjectVariableSetHandler))
 	r.Handle("/v2/project/{projectKey}/variableset/{variableSetName}/item", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postProjectVariableSetItemHandler))
@@ -529,6 +548,13 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/version/{version}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowVersionHandler), r.DELETEv2(api.deleteWorkflowVersionHandler))
 	r.Handle("/v2/project/{projectKey}/run", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunsSearchV2Handler))
 	r.Handle("/v2/project/{projectKey}/run/filter", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunsFiltersV2Handler))
+	r.Handle("/v2/project/{projectKey}/attestation", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getProjectAttestationsHandler))
+	r.Handle("/v2/project/{projectKey}/attestation/key", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getProjectAttestationKeysHandler))
+	r.Handle("/v2/project/{projectKey}/run/stats", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunsStatsV2Handler))
+	r.Handle("/v2/project/{projectKey}/test/flaky", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowTestsFlakyHandler))
+	r.Handle("/v2/project/{projectKey}/test/history", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowTestHistoryHandler))
+	r.Handle("/v2/project/{projectKey}/test/quarantine", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowTestQuarantinesHandler), r.POSTv2(api.postWorkflowTestQuarantineHandler))
+	r.Handle("/v2/project/{projectKey}/test/quarantine/{quarantineID}", Scope(sdk.AuthConsumerScopeRun), r.DELETEv2(api.deleteWorkflowTestQuarantineHandler))
 	r.Handle("/v2/project/{projectKey}/run/retention", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunRetentionHandler), r.PUTv2(api.putWorkflowRunRetentionHandler))
 	r.Handle("/v2/project/{projectKey}/run/retention/dryrun", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postWorkflowRunRetentionDryRunHandler))
 	r.Handle("/v2/project/{projectKey}/run/retention/start", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postWorkflowRunRetentionStartHandler))
@@ -539,12 +565,16 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunV2Handler), r.DELETEv2(api.deleteWorkflowRunV2Handler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/restart", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postRestartWorkflowRunHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/infos", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunInfoV2Handler))
+	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/summary", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunSummariesHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postStopWorkflowRunHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobsV2Handler), r.POSTv2(api.postStartJobWorkflowRunHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/result", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunResultsV2Handler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/retry", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobRetryHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/infos", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobInfosHandler))
+	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/debug", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobDebugWebsocketHandler))
+	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/debug/session", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobDebugSessionsHandler))
+	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/replay", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobReplayHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobIdentifier}/run", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postRunJobHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobIdentifier}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postStopJobHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/logs/links", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobLogsLinksV2Handler))
@@ -558,8 +588,10 @@ func (api *API) InitRouter() {
 
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunQueueInfoHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/info", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postJobRunInfoHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/debug", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunDebugWebsocketHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/key/{keyName}", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunProjectV2KeyHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runinfo", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postRunInfoHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/summary", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postJobRunSummaryHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/step", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postJobRunStepHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/worker/take", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postV2WorkerTakeJobHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/worker/refresh", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postV2RefreshWorkerHandler))
@@ -567,19 +599,25 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/worker/signout", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postV2UnregisterWorkerHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/hatchery/take", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postHatcheryTakeJobRunHandler), r.DELETEv2(api.deleteHatcheryReleaseJobRunHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/result", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postJobResultHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/attestation/sign", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postJobRunAttestationSignHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunResultsHandler), r.POSTv2(api.postJobRunResultHandler), r.PUTv2(api.putJobRunResultHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult/synchronize", Scope(sdk.AuthConsumerScopeRunExecution), r.PUTv2(api.putJobRunResultSynchronizeHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult/{runResultID}", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunResultHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/cache/{cacheKey}/link", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getCacheLinkHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/test/quarantine", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunTestQuarantinesHandler))
 
 	r.Handle("/v2/queue/{regionName}", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobsQueuedRegionalizedHandler))
 	r.Handle("/v2/queue", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobsQueuedHandler))
 
 	r.Handle("/v2/worker", Scope(sdk.AuthConsumerScopeHatchery), r.GETv2(api.getWorkersV2Handler))
+	r.Handle("/v2/agent", Scope(sdk.AuthConsumerScopeHatchery), r.GETv2(api.getWorkerAgentsV2Handler))
 	r.Handle("/v2/worker/{workerName}", ScopeNone(), r.GETv2(api.getWorkerV2Handler))
 
 	r.Handle("/v2/user/{user}/gpgkey", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getUserGPGKeysHandler), r.POSTv2(api.postUserGPGGKeyHandler))
 	r.Handle("/v2/user/{user}/permissions", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getUserPermissionHandler))
+	r.Handle("/v2/user/{user}/permissions/rules", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getUserPermissionRulesHandler))
+	r.Handle("/v2/user/{user}/permissions/explain", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getUserPermissionExplainHandler))
+	r.Handle("/v2/user/{user}/permissions/grant", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getUserPermissionGrantsHandler), r.POSTv2(api.postUserPermissionGrantHandler))
 	r.Handle("/v2/user/{user}/gpgkey/{gpgKeyID}", Scope(sdk.AuthConsumerScopeUser), r.DELETEv2(api.deleteUserGPGKey))
 
 	r.Handle("/v2/user/gpgkey/{gpgKeyID}", ScopeNone(), r.GETv2(api.getUserGPGKeyHandler))
@@ -587,6 +625,15 @@ func (api *API) InitRouter() {
 
 	r.Handle("/v2/ws", ScopeNone(), r.GET(api.getWebsocketV2Handler))
 
+	// SCIM provisioning, authenticated by the signin token of a builtin consumer
+	scimAuth := service.OverrideAuth(api.authSCIMMiddleware)
+	r.Handle("/scim/v2/ServiceProviderConfig", Scope(sdk.AuthConsumerScopeSCIM), r.GETv2(api.getSCIMServiceProviderConfigHandler, scimAuth))
+	r.Handle("/scim/v2/ResourceTypes", Scope(sdk.AuthConsumerScopeSCIM), r.GETv2(api.getSCIMResourceTypesHandler, scimAuth))
+	r.Handle("/scim/v2/Users", Scope(sdk.AuthConsumerScopeSCIM), r.GETv2(api.getSCIMUsersHandler, scimAuth), r.POSTv2(api.postSCIMUserHandler, scimAuth))
+	r.Handle("/scim/v2/Users/{scimUserID}", Scope(sdk.AuthConsumerScopeSCIM), r.GETv2(api.getSCIMUserHandler, scimAuth), r.PUTv2(api.putSCIMUserHandler, scimAuth), r.PATCHv2(api.patchSCIMUserHandler, scimAuth), r.DELETEv2(api.deleteSCIMUserHandler, scimAuth))
+	r.Handle("/scim/v2/Groups", Scope(sdk.AuthConsumerScopeSCIM), r.GETv2(api.getSCIMGroupsHandler, scimAuth), r.POSTv2(api.postSCIMGroupHandler, scimAuth))
+	r.Handle("/scim/v2/Groups/{scimGroupID}", Scope(sdk.AuthConsumerScopeSCIM), r.GETv2(api.getSCIMGroupHandler, scimAuth), r.PUTv2(api.putSCIMGroupHandler, scimAuth), r.PATCHv2(api.patchSCIMGroupHandler, scimAuth), r.DELETEv2(api.deleteSCIMGroupHandler, scimAuth))
+
 	// Not Found handler
 	r.Mux.NotFoundHandler = http.HandlerFunc(r.NotFoundHandler)
 
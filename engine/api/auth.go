@@ -271,9 +271,16 @@ func (api *API) postAuthSigninHandler() service.Handler {
 			return sdk.NewErrorFrom(sdk.ErrUserDisabled, "user %s is disabled", u.Username)
 		}
 
+		// The organization given by the group sync rules takes precedence over the driver's one
+		if _, org := api.groupSyncRules(consumerType).Apply(userInfo.Groups); org != "" {
+			userInfo.Organization = org
+		}
 		if err := api.userSetOrganization(ctx, tx, u, userInfo.Organization); err != nil {
 			return err
 		}
+		if err := api.userSyncGroups(ctx, tx, u, consumerType, userInfo.Groups, true); err != nil {
+			return err
+		}
 
 		// If a new user has been created and a first admin has been create,
 		// let's init the builtin consumers from the magix token
@@ -2,17 +2,24 @@ package authentication
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"sort"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
 
 	"github.com/ovh/cds/engine/authentication"
+	"github.com/ovh/cds/sdk"
 )
 
 var (
-	signers []authentication.Signer
+	signers            []authentication.Signer
+	attestationSigners []authentication.Signer
 )
 
 type KeyConfig struct {
@@ -90,3 +97,83 @@ func VerifyJWS(signature string, content interface{}) error {
 	}
 	return lastError
 }
+
+// SigningKeyID returns an identifier of the public part of a signing key.
+func SigningKeyID(k *rsa.PublicKey) (string, error) {
+	btes, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	sum := sha256.Sum256(btes)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// InitAttestation sets the keys used to sign the attestations of run results. They are dedicated to attestations
+// so that a signature can't be produced or verified with the keys of the JWT layer.
+func InitAttestation(issuer string, keys []KeyConfig) error {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Timestamp < keys[j].Timestamp
+	})
+
+	attestationSigners = make([]authentication.Signer, len(keys))
+	for i := range keys {
+		s, err := authentication.NewSigner(issuer, []byte(keys[i].Key))
+		if err != nil {
+			return err
+		}
+		attestationSigners[i] = s
+	}
+	return nil
+}
+
+// GetAttestationKey returns the most recent attestation key, or nil if no attestation key is set.
+func GetAttestationKey() *rsa.PrivateKey {
+	if len(attestationSigners) == 0 {
+		return nil
+	}
+	return attestationSigners[len(attestationSigners)-1].GetSigningKey()
+}
+
+// GetAttestationPublicKeys returns the public keys of all the attestation keys, the old ones still verify the
+// attestations they signed.
+func GetAttestationPublicKeys() []*rsa.PublicKey {
+	keys := make([]*rsa.PublicKey, 0, len(attestationSigners))
+	for _, s := range attestationSigners {
+		keys = append(keys, &s.GetSigningKey().PublicKey)
+	}
+	return keys
+}
+
+// SignAttestation signs the payload with the most recent attestation key, it returns the id of the key and the signature.
+func SignAttestation(payload []byte) (string, []byte, error) {
+	k := GetAttestationKey()
+	if k == nil {
+		return "", nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "no attestation key is configured on the API")
+	}
+	keyID, err := SigningKeyID(&k.PublicKey)
+	if err != nil {
+		return "", nil, err
+	}
+	sum := sha256.Sum256(payload)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", nil, sdk.WithStack(err)
+	}
+	return keyID, sig, nil
+}
+
+// VerifyAttestation checks the signature of the payload with the attestation key matching the given id.
+func VerifyAttestation(keyID string, payload []byte, sig []byte) error {
+	for i := len(attestationSigners) - 1; i >= 0; i-- {
+		k := attestationSigners[i].GetSigningKey()
+		id, err := SigningKeyID(&k.PublicKey)
+		if err != nil {
+			return err
+		}
+		if id != keyID {
+			continue
+		}
+		return sdk.WithStack(sdk.VerifyRSAAttestation(&k.PublicKey, payload, sig))
+	}
+	return sdk.NewErrorFrom(sdk.ErrNotFound, "unknown attestation key %s", keyID)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ovh/cds/engine/api/bootstrap"
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/jws"
 )
 
 type myPayload struct {
@@ -35,3 +36,29 @@ func TestSignJWS(t *testing.T) {
 	assert.Equal(t, p.RandomID, res.RandomID)
 	assert.Equal(t, p.Nonce, res.Nonce)
 }
+
+func TestSignAttestation(t *testing.T) {
+	_, _ = test.SetupPG(t, bootstrap.InitiliazeDB)
+
+	require.NoError(t, authentication.InitAttestation("cds-api-test", nil))
+	_, _, err := authentication.SignAttestation([]byte("my payload"))
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotImplemented))
+
+	key, err := jws.NewRandomRSAKey()
+	require.NoError(t, err)
+	pem, err := jws.ExportPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, authentication.InitAttestation("cds-api-test", []authentication.KeyConfig{{Key: string(pem)}}))
+
+	keyID, sig, err := authentication.SignAttestation([]byte("my payload"))
+	require.NoError(t, err)
+
+	require.NoError(t, authentication.VerifyAttestation(keyID, []byte("my payload"), sig))
+	require.Error(t, authentication.VerifyAttestation(keyID, []byte("another payload"), sig))
+	require.True(t, sdk.ErrorIs(authentication.VerifyAttestation("unknown", []byte("my payload"), sig), sdk.ErrNotFound))
+
+	// The JWT signing key can't be used to verify an attestation
+	jwtKeyID, err := authentication.SigningKeyID(&authentication.GetSigningKey().PublicKey)
+	require.NoError(t, err)
+	require.True(t, sdk.ErrorIs(authentication.VerifyAttestation(jwtKeyID, []byte("my payload"), sig), sdk.ErrNotFound))
+}
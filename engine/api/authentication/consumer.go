@@ -333,3 +333,54 @@ func ConsumerRestoreInvalidatedGroupsForUser(ctx context.Context, db gorpmapper.
 
 	return nil
 }
+
+// ConsumerDeprovisionUser disables all the consumers of a deprovisioned user and set a warning on them.
+func ConsumerDeprovisionUser(ctx context.Context, db gorpmapper.SqlExecutorWithTx, userID string) error {
+	cs, err := LoadUserConsumersByUserID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		if cs[i].Disabled {
+			continue
+		}
+		cs[i].Disabled = true
+		cs[i].Warnings = append(cs[i].Warnings, sdk.NewConsumerWarningUserDeprovisioned())
+		if err := UpdateUserConsumer(ctx, db, &cs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConsumerRestoreDeprovisionedUser re-enables the consumers disabled by the deprovisioning of the user,
+// unless they have no group left.
+func ConsumerRestoreDeprovisionedUser(ctx context.Context, db gorpmapper.SqlExecutorWithTx, userID string) error {
+	cs, err := LoadUserConsumersByUserID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		var deprovisioned, lastGroupRemoved bool
+		filteredWarnings := make(sdk.AuthConsumerWarnings, 0, len(cs[i].Warnings))
+		for _, w := range cs[i].Warnings {
+			switch w.Type {
+			case sdk.WarningUserDeprovisioned:
+				deprovisioned = true
+				continue
+			case sdk.WarningLastGroupRemoved:
+				lastGroupRemoved = true
+			}
+			filteredWarnings = append(filteredWarnings, w)
+		}
+		if !deprovisioned {
+			continue
+		}
+		cs[i].Warnings = filteredWarnings
+		cs[i].Disabled = lastGroupRemoved
+		if err := UpdateUserConsumer(ctx, db, &cs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
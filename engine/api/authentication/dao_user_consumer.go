@@ -102,6 +102,12 @@ func LoadUserConsumersByGroupID(ctx context.Context, db gorp.SqlExecutor, groupI
 	return consumers, nil
 }
 
+// LoadEnabledUserConsumersByType returns all the enabled user consumers from database for given type.
+func LoadEnabledUserConsumersByType(ctx context.Context, db gorp.SqlExecutor, consumerType sdk.AuthConsumerType, opts ...LoadUserConsumerOptionFunc) (sdk.AuthUserConsumers, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE type = $1 AND disabled = false ORDER BY created ASC, id ASC").Args(consumerType)
+	return getUserConsumers(ctx, db, query, opts...)
+}
+
 // LoadUserConsumerByID returns an auth consumer from database.
 func LoadUserConsumerByID(ctx context.Context, db gorp.SqlExecutor, id string, opts ...LoadUserConsumerOptionFunc) (*sdk.AuthUserConsumer, error) {
 	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE id = $1").Args(id)
@@ -9,8 +9,8 @@ import (
 )
 
 // NewDriver returns a new OIDC auth driver for given config.
-func NewDriver(signupDisabled bool, cdsURL, url, clientID, clientSecret, orga string) (sdk.AuthDriver, error) {
-	driv, err := openid.NewOpenIDDriver(cdsURL, url, clientID, clientSecret)
+func NewDriver(signupDisabled bool, cdsURL, url, clientID, clientSecret, orga, groupsClaim string) (sdk.AuthDriver, error) {
+	driv, err := openid.NewOpenIDDriver(cdsURL, url, clientID, clientSecret, groupsClaim)
 	if err != nil {
 		return nil, err
 	}
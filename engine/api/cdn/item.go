@@ -16,34 +16,71 @@ const (
 	ParamRunID      = "runid"
 	ParamProjectKey = "projectkey"
 	ParamCacheTag   = "cachetag"
+	ParamRef        = "ref"
+	ParamRestoreKey = "restorekey"
 )
 
 func ListItems(ctx context.Context, db gorp.SqlExecutor, itemtype sdk.CDNItemType, params map[string]string) (sdk.CDNItemLinks, error) {
-	var result sdk.CDNItemLinks
-
 	if len(params) == 0 {
-		return result, sdk.WrapError(sdk.ErrInvalidData, "need parameters to filter items")
+		return sdk.CDNItemLinks{}, sdk.WrapError(sdk.ErrInvalidData, "need parameters to filter items")
+	}
+	values := make(url.Values, len(params))
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	return listItems(ctx, db, itemtype, values)
+}
+
+// SearchWorkerCache returns the latest worker cache matching the cache key, or else one of the restore keys, on the given ordered refs.
+func SearchWorkerCache(ctx context.Context, db gorp.SqlExecutor, projectKey string, cacheKey string, restoreKeys []string, refs []string) (sdk.CDNItemLinks, error) {
+	values := url.Values{
+		ParamProjectKey: []string{projectKey},
+		ParamCacheTag:   []string{cacheKey},
+		ParamRef:        refs,
 	}
+	if len(restoreKeys) > 0 {
+		values[ParamRestoreKey] = restoreKeys
+	}
+	return listItems(ctx, db, sdk.CDNTypeItemWorkerCacheV2, values)
+}
 
+// ListWorkerCaches returns all the v2 worker caches of a project.
+func ListWorkerCaches(ctx context.Context, db gorp.SqlExecutor, projectKey string) ([]sdk.CDNItem, error) {
+	result, err := listItems(ctx, db, sdk.CDNTypeItemWorkerCacheV2, url.Values{ParamProjectKey: []string{projectKey}})
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// DeleteItem marks the item to delete in CDN.
+func DeleteItem(ctx context.Context, db gorp.SqlExecutor, itemtype sdk.CDNItemType, apiRefHash string) error {
 	srvs, err := services.LoadAllByType(ctx, db, sdk.TypeCDN)
 	if err != nil {
-		return result, err
+		return err
 	}
 	if len(srvs) == 0 {
-		return result, sdk.WrapError(sdk.ErrNotFound, "no service found")
+		return sdk.WrapError(sdk.ErrNotFound, "no service found")
+	}
+	_, _, code, err := services.DoRequest(ctx, srvs, http.MethodDelete, fmt.Sprintf("/item/%s/%s", itemtype, apiRefHash), nil)
+	if code == http.StatusNotFound {
+		return sdk.WithStack(sdk.ErrNotFound)
 	}
+	return err
+}
 
-	path := fmt.Sprintf("/item/%s?", itemtype)
-	firstParams := true
-	for k, v := range params {
-		if firstParams {
-			path = fmt.Sprintf("%s%s=%s", path, k, url.QueryEscape(v))
-			firstParams = false
-		} else {
-			path = fmt.Sprintf("%s&%s=%s", path, k, url.QueryEscape(v))
-		}
+func listItems(ctx context.Context, db gorp.SqlExecutor, itemtype sdk.CDNItemType, values url.Values) (sdk.CDNItemLinks, error) {
+	var result sdk.CDNItemLinks
 
+	srvs, err := services.LoadAllByType(ctx, db, sdk.TypeCDN)
+	if err != nil {
+		return result, err
+	}
+	if len(srvs) == 0 {
+		return result, sdk.WrapError(sdk.ErrNotFound, "no service found")
 	}
+
+	path := fmt.Sprintf("/item/%s?%s", itemtype, values.Encode())
 	btes, _, code, err := services.DoRequest(ctx, srvs, http.MethodGet, path, nil)
 	if code == http.StatusNotFound {
 		return result, sdk.WithStack(sdk.ErrNotFound)
@@ -0,0 +1,68 @@
+package gorpmapping
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// SCIMAttribute tells how a SCIM attribute is filtered in SQL. Column is the compared expression, for a multi-valued
+// attribute Exists is an EXISTS sub query where %s is replaced by the comparison on the column.
+type SCIMAttribute struct {
+	Column string
+	Exists string
+}
+
+// SCIMFilterCondition returns the SQL condition matching the SCIM filter and its arguments, numbered from firstArg.
+// Attributes are looked up in lower case without the given schema prefix. As sdk.SCIMFilter.Match, comparisons
+// are case insensitive.
+func SCIMFilterCondition(f sdk.SCIMFilter, schema string, attributes map[string]SCIMAttribute, firstArg int) (string, []interface{}, error) {
+	if len(f) == 0 {
+		return "true", nil, nil
+	}
+	conditions := make([]string, 0, len(f))
+	args := make([]interface{}, 0, len(f))
+	for _, c := range f {
+		attr, ok := attributes[strings.ToLower(strings.TrimPrefix(c.Attribute, schema+":"))]
+		if !ok {
+			return "", nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported filter attribute %q", c.Attribute)
+		}
+
+		operator := c.Operator
+		if operator == "ne" {
+			operator = "eq"
+		}
+		var cond string
+		if operator == "pr" {
+			cond = fmt.Sprintf("COALESCE(%s, '') <> ''", attr.Column)
+		} else {
+			arg := fmt.Sprintf("$%d", firstArg+len(args))
+			value := c.Value
+			if operator != "eq" {
+				value = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+			}
+			args = append(args, value)
+			switch operator {
+			case "eq":
+				cond = fmt.Sprintf("lower(%s) = lower(%s)", attr.Column, arg)
+			case "co":
+				cond = fmt.Sprintf("lower(%s) LIKE '%%' || lower(%s) || '%%'", attr.Column, arg)
+			case "sw":
+				cond = fmt.Sprintf("lower(%s) LIKE lower(%s) || '%%'", attr.Column, arg)
+			case "ew":
+				cond = fmt.Sprintf("lower(%s) LIKE '%%' || lower(%s)", attr.Column, arg)
+			default:
+				return "", nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported filter operator %q", c.Operator)
+			}
+		}
+		if attr.Exists != "" {
+			cond = fmt.Sprintf(attr.Exists, cond)
+		}
+		if c.Operator == "ne" {
+			cond = "NOT (" + cond + ")"
+		}
+		conditions = append(conditions, "("+cond+")")
+	}
+	return strings.Join(conditions, " AND "), args, nil
+}
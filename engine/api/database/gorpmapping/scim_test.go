@@ -0,0 +1,62 @@
+package gorpmapping_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSCIMFilterCondition(t *testing.T) {
+	attributes := map[string]gorpmapping.SCIMAttribute{
+		"username": {Column: "u.username"},
+		"emails":   {Column: "c.value", Exists: "EXISTS (SELECT 1 FROM c WHERE c.user_id = u.id AND %s)"},
+	}
+
+	tests := []struct {
+		filter  string
+		cond    string
+		args    []interface{}
+		wantErr bool
+	}{
+		{
+			filter: `userName eq "John"`,
+			cond:   "(lower(u.username) = lower($3))",
+			args:   []interface{}{"John"},
+		},
+		{
+			filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName ne "john"`,
+			cond:   "(NOT (lower(u.username) = lower($3)))",
+			args:   []interface{}{"john"},
+		},
+		{
+			filter: `userName sw "jo_%" and emails co "example"`,
+			cond:   "(lower(u.username) LIKE lower($3) || '%') AND (EXISTS (SELECT 1 FROM c WHERE c.user_id = u.id AND lower(c.value) LIKE '%' || lower($4) || '%'))",
+			args:   []interface{}{`jo\_\%`, "example"},
+		},
+		{
+			filter: `emails pr`,
+			cond:   "(EXISTS (SELECT 1 FROM c WHERE c.user_id = u.id AND COALESCE(c.value, '') <> ''))",
+		},
+		{
+			filter:  `nickName eq "john"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := sdk.ParseSCIMFilter(tt.filter)
+			require.NoError(t, err)
+			cond, args, err := gorpmapping.SCIMFilterCondition(f, sdk.SCIMSchemaUser, attributes, 3)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.cond, cond)
+			require.ElementsMatch(t, tt.args, args)
+		})
+	}
+}
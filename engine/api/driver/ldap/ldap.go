@@ -15,6 +15,8 @@ import (
 
 const errUserNotFound = "ldap::user not found"
 
+var _ sdk.DriverWithUserGroups = new(ldapDriver)
+
 type ldapDriver struct {
 	conf Config
 	conn *ldap.Conn
@@ -95,10 +97,38 @@ func (l *ldapDriver) GetUserInfoFromDriver(ctx context.Context, req sdk.AuthCons
 	userInfo.ExternalID = entry[0].Attributes["uid"]
 	userInfo.Username = req.String("bind")
 	userInfo.Organization = req.String("company")
+	userInfo.Groups = entry[0].Groups
 
 	return userInfo, nil
 }
 
+// GetUserGroups returns the groups DN of the given user, searched with the manager account.
+func (l *ldapDriver) GetUserGroups(ctx context.Context, username string) ([]string, error) {
+	if l.conf.ManagerDN != "" {
+		if err := l.conn.Bind(l.conf.ManagerDN, l.conf.ManagerPassword); err != nil {
+			if !shoudRetry(ctx, err) {
+				return nil, sdk.WithStack(err)
+			}
+			// openLDAP binds the manager on the new connection
+			if err := l.openLDAP(ctx, l.conf); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	entry, err := l.search(ctx, username, "uid", "memberOf")
+	if err != nil {
+		if err.Error() == errUserNotFound {
+			return nil, sdk.NewErrorFrom(sdk.ErrUserNotFound, "LDAP user %s not found", username)
+		}
+		return nil, sdk.WrapError(err, "unable to search LDAP user %s", username)
+	}
+	if len(entry) > 1 {
+		return nil, sdk.WithStack(fmt.Errorf("LDAP Search error multiple values"))
+	}
+	return entry[0].Groups, nil
+}
+
 func (l *ldapDriver) openLDAP(ctx context.Context, conf Config) error {
 	if l.conn != nil {
 		l.conn.Close()
@@ -218,6 +248,9 @@ func (l *ldapDriver) search(ctx context.Context, term string, attributes ...stri
 
 		for _, a := range attributes {
 			entry.Attributes[a] = e.GetAttributeValue(a)
+			if a == "memberOf" {
+				entry.Groups = e.GetAttributeValues(a)
+			}
 		}
 		entries = append(entries, entry)
 	}
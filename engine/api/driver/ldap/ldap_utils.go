@@ -26,4 +26,5 @@ func shoudRetry(ctx context.Context, err error) bool {
 type Entry struct {
 	DN         string
 	Attributes map[string]string
+	Groups     []string // all the memberOf values
 }
@@ -19,12 +19,13 @@ var _ sdk.DriverWithSigninStateToken = (*openIDDriver)(nil)
 
 type openIDDriver struct {
 	cdsURL       string
+	groupsClaim  string
 	OAuth2Config oauth2.Config
 	Verifier     *oidc.IDTokenVerifier
 }
 
 // NewOpenIDDriver returns a new OIDC auth driver for given config.
-func NewOpenIDDriver(cdsURL, url, clientID, clientSecret string) (sdk.Driver, error) {
+func NewOpenIDDriver(cdsURL, url, clientID, clientSecret, groupsClaim string) (sdk.Driver, error) {
 	provider, err := oidc.NewProvider(context.Background(), url)
 	if err != nil {
 		return nil, sdk.WrapError(err, "failed to initialize OIDC driver")
@@ -46,6 +47,7 @@ func NewOpenIDDriver(cdsURL, url, clientID, clientSecret string) (sdk.Driver, er
 
 	return &openIDDriver{
 		cdsURL:       cdsURL,
+		groupsClaim:  groupsClaim,
 		OAuth2Config: oauth2Config,
 		Verifier:     verifier,
 	}, nil
@@ -123,5 +125,17 @@ func (d openIDDriver) GetUserInfoFromDriver(ctx context.Context, req sdk.AuthCon
 	if info.Email, ok = tokenClaim["email"].(string); !ok {
 		return info, sdk.WithStack(errors.New("missing user's email in OIDC token claim"))
 	}
+
+	// The groups claim can be a list of values or a single one
+	switch groups := tokenClaim[d.groupsClaim].(type) {
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				info.Groups = append(info.Groups, s)
+			}
+		}
+	case string:
+		info.Groups = []string{groups}
+	}
 	return info, nil
 }
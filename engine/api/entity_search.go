@@ -17,7 +17,7 @@ import (
 	"github.com/ovh/cds/sdk/telemetry"
 	"github.com/rockbears/log"
 	"github.com/rockbears/yaml"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type EntityFinder struct {
@@ -110,7 +110,7 @@ func (ef *EntityFinder) unsafeSearchEntityFromLibrary(ctx context.Context, db go
 }
 
 func (ef *EntityFinder) searchEntity(ctx context.Context, db gorp.SqlExecutor, store cache.Store, name string, entityType string) (*sdk.EntityWithObject, string, error) {
-	ctx, end := telemetry.Span(ctx, "EntityFinder.searchEntity", trace.StringAttribute("entity-type", entityType), trace.StringAttribute("entity-name", name))
+	ctx, end := telemetry.Span(ctx, "EntityFinder.searchEntity", attribute.String("entity-type", entityType), attribute.String("entity-name", name))
 	defer end()
 
 	var ref, branchOrTag, entityName, repoName, vcsName, projKey string
@@ -237,6 +237,20 @@ func sendVCSPullRequestComment(ctx context.Context, db *gorp.DbMap, vcsClient sd
 	_ = json.Unmarshal(btsEvent, &eventContext)
 	tmplParams["event"] = eventContext
 
+	// Test case outcomes of the run, failures of quarantined tests are listed apart
+	testCases, err := workflow_v2.LoadTestCasesByRunID(ctx, db, run.ID)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+	}
+	quarantines, err := workflow_v2.LoadTestQuarantines(ctx, db, run.ProjectKey, run.VCSServer, run.Repository, run.WorkflowName)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+	}
+	btsTests, _ := json.Marshal(sdk.NewV2WorkflowRunTestsReport(testCases, quarantines))
+	var testsContext map[string]interface{}
+	_ = json.Unmarshal(btsTests, &testsContext)
+	tmplParams["tests"] = testsContext
+
 	// Templating
 	tmpl, err := template.New("workflow_template").Funcs(interpolate.InterpolateHelperFuncs).Delims("[[", "]]").Parse(comment)
 	if err != nil {
@@ -0,0 +1,29 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+func PublishEnvironmentEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, projectKey string, env sdk.ProjectEnvironment, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(env)
+	e := sdk.EnvironmentEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: projectKey,
+		},
+		UserID:      u.ID,
+		Username:    u.Username,
+		Environment: env.Name,
+	}
+	publish(ctx, store, e)
+}
@@ -0,0 +1,30 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+// PublishFreezeEvent publishes a change on a freeze. The payload is the freeze, or the override for EventFreezeOverridden.
+func PublishFreezeEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, projectKey string, freezeName string, payload interface{}, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(payload)
+	e := sdk.FreezeEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: projectKey,
+		},
+		UserID:   u.ID,
+		Username: u.Username,
+		Freeze:   freezeName,
+	}
+	publish(ctx, store, e)
+}
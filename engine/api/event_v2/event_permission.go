@@ -24,3 +24,22 @@ func PublishPermissionEvent(ctx context.Context, store cache.Store, eventType sd
 	}
 	publish(ctx, store, e)
 }
+
+// PublishPermissionGrantEvent publishes a change on a grant request. The user is the one who made the change, it is empty when a grant expires.
+func PublishPermissionGrantEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, g sdk.RBACGrantRequest, u *sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(g)
+	e := sdk.PermissionEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		Permission: g.RBACName,
+	}
+	if u != nil {
+		e.UserID = u.ID
+		e.Username = u.Username
+	}
+	publish(ctx, store, e)
+}
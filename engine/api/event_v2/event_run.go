@@ -81,6 +81,38 @@ func PublishRunJobStepUpdate(ctx context.Context, store cache.Store, rj sdk.V2Wo
 	publishRunJobEvent(ctx, store, sdk.EventRunJobStepUpdated, rj)
 }
 
+// PublishRunJobDebugEvent audits a debug session opened by a user on a job.
+func PublishRunJobDebugEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, rj sdk.V2WorkflowRunJob, s sdk.V2WorkflowRunJobDebugSession) {
+	bts, _ := json.Marshal(s)
+	e := sdk.WorkflowRunJobEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: rj.ProjectKey,
+		},
+		VCSName:       rj.VCSServer,
+		Repository:    rj.Repository,
+		Workflow:      rj.WorkflowName,
+		WorkflowRunID: rj.WorkflowRunID,
+		RunJobID:      rj.ID,
+		RunNumber:     rj.RunNumber,
+		RunAttempt:    rj.RunAttempt,
+		Region:        rj.Region,
+		Hatchery:      rj.HatcheryName,
+		ModelType:     rj.ModelType,
+		ModelOSArch:   rj.ModelOSArch,
+		JobID:         rj.JobID,
+		Status:        rj.Status,
+		UserID:        s.UserID,
+		Username:      s.Username,
+	}
+	publish(ctx, store, e)
+}
+
 func publishRunJobEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, rj sdk.V2WorkflowRunJob) {
 	bts, _ := json.Marshal(rj)
 	e := sdk.WorkflowRunJobEvent{
@@ -448,6 +448,8 @@ func (api *API) putGroupUserHandler() service.Handler {
 		}
 
 		link.Admin = data.Admin
+		// A membership changed by hand is no longer managed by the group sync
+		link.Source = ""
 
 		if err := group.UpdateLinkGroupUser(ctx, tx, link); err != nil {
 			return err
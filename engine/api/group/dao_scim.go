@@ -0,0 +1,70 @@
+package group
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// scimManaged matches the groups that have a membership provisioned by SCIM, the other groups are not managed by
+// the identity provider.
+const scimManaged = `EXISTS (SELECT 1 FROM group_authentified_user WHERE group_authentified_user.group_id = "group".id AND group_authentified_user.source = '` + string(sdk.ConsumerSCIM) + `')`
+
+// scimAttributes returns the SCIM group attributes that can be filtered, the user of the SCIM consumer is not listed
+// in the members.
+func scimAttributes(ownerID string) map[string]gorpmapping.SCIMAttribute {
+	members := gorpmapping.SCIMAttribute{
+		Column: "group_authentified_user.authentified_user_id",
+		Exists: `EXISTS (SELECT 1 FROM group_authentified_user WHERE group_authentified_user.group_id = "group".id AND group_authentified_user.source = '` + string(sdk.ConsumerSCIM) + `' AND group_authentified_user.authentified_user_id <> ` + pq.QuoteLiteral(ownerID) + ` AND %s)`,
+	}
+	return map[string]gorpmapping.SCIMAttribute{
+		"id":            {Column: `"group".id::text`},
+		"externalid":    {Column: "''"},
+		"displayname":   {Column: `"group".name`},
+		"members":       members,
+		"members.value": members,
+	}
+}
+
+// CountBySCIMFilter returns the count of groups managed by SCIM matching the SCIM filter.
+func CountBySCIMFilter(ctx context.Context, db gorp.SqlExecutor, ownerID string, filter sdk.SCIMFilter) (int64, error) {
+	cond, args, err := gorpmapping.SCIMFilterCondition(filter, sdk.SCIMSchemaGroup, scimAttributes(ownerID), 1)
+	if err != nil {
+		return 0, err
+	}
+	count, err := db.SelectInt(`SELECT COUNT(id) FROM "group" WHERE `+scimManaged+` AND `+cond, args...)
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	return count, nil
+}
+
+// LoadAllBySCIMFilter returns a page of the groups managed by SCIM matching the SCIM filter, ordered by name.
+func LoadAllBySCIMFilter(ctx context.Context, db gorp.SqlExecutor, ownerID string, filter sdk.SCIMFilter, offset, limit int, opts ...LoadOptionFunc) (sdk.Groups, error) {
+	cond, args, err := gorpmapping.SCIMFilterCondition(filter, sdk.SCIMSchemaGroup, scimAttributes(ownerID), 3)
+	if err != nil {
+		return nil, err
+	}
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM "group"
+    WHERE ` + scimManaged + ` AND ` + cond + `
+    ORDER BY "group".name
+    OFFSET $1 LIMIT $2
+  `).Args(append([]interface{}{offset, limit}, args...)...)
+	return getAll(ctx, db, query, opts...)
+}
+
+// LoadSCIMByID retrieves a group managed by SCIM from database by id.
+func LoadSCIMByID(ctx context.Context, db gorp.SqlExecutor, id int64, opts ...LoadOptionFunc) (*sdk.Group, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM "group"
+    WHERE "group".id = $1 AND ` + scimManaged + `
+  `).Args(id)
+	return get(ctx, db, query, opts...)
+}
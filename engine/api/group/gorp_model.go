@@ -25,12 +25,15 @@ type LinkGroupUser struct {
 	GroupID            int64  `db:"group_id"`
 	AuthentifiedUserID string `db:"authentified_user_id"`
 	Admin              bool   `db:"group_admin"`
+	// Source is the auth driver that synchronized the membership, empty for a manual one
+	Source sdk.AuthConsumerType `db:"source"`
 	gorpmapper.SignedEntity
 }
 
 func (c LinkGroupUser) Canonical() gorpmapper.CanonicalForms {
-	_ = []interface{}{c.ID, c.AuthentifiedUserID, c.GroupID, c.Admin} // Checks that fields exists at compilation
+	_ = []interface{}{c.ID, c.AuthentifiedUserID, c.GroupID, c.Admin, c.Source} // Checks that fields exists at compilation
 	return []gorpmapper.CanonicalForm{
+		"{{printf .ID}}{{.AuthentifiedUserID}}{{printf .GroupID}}{{printf .Admin}}{{printf .Source}}",
 		"{{printf .ID}}{{.AuthentifiedUserID}}{{printf .GroupID}}{{printf .Admin}}",
 		"{{print .ID}}{{.AuthentifiedUserID}}{{print .GroupID}}{{print .Admin}}",
 	}
@@ -73,6 +73,7 @@ func loadMembers(ctx context.Context, db gorp.SqlExecutor, gs ...*sdk.Group) err
 						Admin:        link.Admin,
 						Organization: member.Organization,
 						Disabled:     member.Disabled,
+						Source:       link.Source,
 					})
 				}
 			}
@@ -17,8 +17,9 @@ type dbHatchery struct {
 }
 
 func (o dbHatchery) Canonical() gorpmapper.CanonicalForms {
-	_ = []interface{}{o.ID, o.Name, o.Config}
+	_ = []interface{}{o.ID, o.Name, o.Config, o.ProjectKeys}
 	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{.Name}}{{.Config}}{{.ProjectKeys}}",
 		"{{.ID}}{{.Name}}{{.Config}}",
 	}
 }
@@ -9,7 +9,7 @@ import (
 	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/engine/featureflipping"
@@ -18,30 +18,24 @@ import (
 	"github.com/ovh/cds/sdk/telemetry"
 )
 
-// Start may start a tracing span
+// Start may start a tracing span. The span is sampled if the tracing feature is enabled for the request,
+// if the parent span found in the request headers is sampled or according to the sampling probability.
 func Start(ctx context.Context, s telemetry.Service, w http.ResponseWriter, req *http.Request, opt telemetry.Options, m *gorpmapper.Mapper, db gorp.SqlExecutor, store cache.Store) (context.Context, error) {
 	exp := telemetry.TraceExporter(ctx)
 	if exp == nil {
 		return ctx, nil
 	}
 
-	tags := []trace.Attribute{}
-
-	var span *trace.Span
-	rootSpanContext, hasSpanContext := telemetry.DefaultFormat.SpanContextFromRequest(req)
+	tags := []attribute.KeyValue{}
 
 	var pkey string
 	if db != nil && store != nil {
 		pkey, _ = findPrimaryKeyFromRequest(ctx, req, db, store)
 		if pkey != "" {
-			tags = append(tags, trace.StringAttribute("project_key", pkey))
+			tags = append(tags, attribute.String("project_key", pkey))
 		}
 	}
 
-	var traceOpts = []trace.StartOption{
-		trace.WithSpanKind(trace.SpanKindServer),
-	}
-
 	mapVars := map[string]string{
 		"trace":                      opt.Name,
 		"project_key":                pkey,
@@ -51,48 +45,15 @@ func Start(ctx context.Context, s telemetry.Service, w http.ResponseWriter, req
 		telemetry.UserAgentAttribute: req.UserAgent(),
 	}
 
-	_, tracingEnabled := featureflipping.IsEnabled(ctx, m, db, sdk.FeatureTracing, mapVars)
-	var sampler trace.Sampler
-	switch {
-	case tracingEnabled:
-		sampler = trace.AlwaysSample()
-	case hasSpanContext && rootSpanContext.IsSampled():
-		sampler = trace.AlwaysSample()
-	}
-
-	if sampler != nil {
-		traceOpts = append(traceOpts, trace.WithSampler(sampler))
+	if _, tracingEnabled := featureflipping.IsEnabled(ctx, m, db, sdk.FeatureTracing, mapVars); tracingEnabled {
+		ctx = telemetry.ContextWithSampling(ctx)
 	}
 
-	if hasSpanContext {
-		ctx, span = trace.StartSpanWithRemoteParent(ctx, opt.Name, rootSpanContext, traceOpts...)
-		span.AddLink(
-			trace.Link{
-				TraceID: rootSpanContext.TraceID,
-				SpanID:  rootSpanContext.SpanID,
-				Type:    trace.LinkTypeChild,
-			},
-		)
-	} else {
-		ctx, span = trace.StartSpan(ctx, opt.Name, traceOpts...)
+	ctx, err := telemetry.NewWithRequest(ctx, s, w, req, opt, tags...)
+	if err != nil {
+		return ctx, err
 	}
-
-	span.AddAttributes(tags...)
-	span.AddAttributes(
-		trace.StringAttribute(telemetry.PathAttribute, req.URL.Path),
-		trace.StringAttribute(telemetry.HostAttribute, req.URL.Host),
-		trace.StringAttribute(telemetry.MethodAttribute, req.Method),
-		trace.StringAttribute(telemetry.UserAgentAttribute, req.UserAgent()),
-	)
-
-	log.Debug(ctx, "# %s saving main span: %+v", opt.Name, span)
-	ctx = context.WithValue(ctx, telemetry.ContextMainSpan, span)
-
-	ctx = telemetry.SpanContextToContext(ctx, span.SpanContext())
-	ctx = telemetry.ContextWithTag(ctx,
-		telemetry.TagServiceType, s.Type(),
-		telemetry.TagServiceName, s.Name(),
-	)
+	log.Debug(ctx, "# %s saving main span: %+v", opt.Name, telemetry.MainSpan(ctx).SpanContext())
 	return ctx, nil
 }
 
@@ -0,0 +1,203 @@
+package observability
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// RunTracingConfiguration is the configuration of the workflow run traces exporter
+type RunTracingConfiguration struct {
+	Enabled     bool              `toml:"enabled" default:"false" json:"enabled" comment:"Export v2 workflow runs as OpenTelemetry traces"`
+	Endpoint    string            `toml:"endpoint" default:"http://localhost:4318/v1/traces" json:"endpoint" comment:"OTLP/HTTP traces endpoint"`
+	Headers     map[string]string `toml:"headers" json:"-" comment:"Headers sent to the OTLP endpoint (ex: authentication)"`
+	ServiceName string            `toml:"serviceName" default:"cds-workflow-run" json:"serviceName" comment:"Service name of the exported traces"`
+}
+
+// RunTraceExporter exports the v2 workflow runs as OTLP traces once they are terminated
+type RunTraceExporter struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+}
+
+// NewRunTraceExporter returns an exporter sending traces to the configured OTLP endpoint
+func NewRunTraceExporter(ctx context.Context, cfg RunTracingConfiguration) (*RunTraceExporter, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(cfg.Endpoint)}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	exp, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create OTLP trace exporter")
+	}
+	return newRunTraceExporter(exp, cfg.ServiceName), nil
+}
+
+func newRunTraceExporter(exp sdktrace.SpanExporter, serviceName string) *RunTraceExporter {
+	if serviceName == "" {
+		serviceName = "cds-workflow-run"
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithIDGenerator(runIDGenerator{}),
+	)
+	return &RunTraceExporter{
+		provider: provider,
+		tracer:   provider.Tracer("github.com/ovh/cds/engine/api/observability"),
+	}
+}
+
+// Export sends the trace of a run attempt: run -> job -> queued, scheduled, building -> steps.
+// Span identifiers are derived from the run and jobs identifiers, so the steps spans are the parents
+// of the spans sent by the job with the TRACEPARENT environment variable.
+func (e *RunTraceExporter) Export(ctx context.Context, run sdk.V2WorkflowRun, runJobs []sdk.V2WorkflowRunJob, runJobInfos map[string][]sdk.V2WorkflowRunJobInfo) error {
+	traceID := trace.TraceID(sdk.V2WorkflowRunTraceID(run.ID, run.RunAttempt))
+
+	runEnd := run.LastModified
+	runCtx, runSpan := e.startSpan(ctx, traceID, sdk.V2WorkflowRunSpanID(run.ID), run.WorkflowName, run.Started,
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("cds.project_key", run.ProjectKey),
+			attribute.String("cds.vcs_server", run.VCSServer),
+			attribute.String("cds.repository", run.Repository),
+			attribute.String("cds.workflow", run.WorkflowName),
+			attribute.Int64("cds.run_number", run.RunNumber),
+			attribute.Int64("cds.run_attempt", run.RunAttempt),
+			attribute.String("cds.status", string(run.Status)),
+			attribute.String("vcs.ref", run.Contexts.Git.Ref),
+			attribute.String("vcs.sha", run.Contexts.Git.Sha),
+		))
+	setSpanStatus(runSpan, run.Status == sdk.V2WorkflowRunStatusFail, string(run.Status))
+
+	for _, rj := range runJobs {
+		jobEnd := runEnd
+		if rj.Ended != nil {
+			jobEnd = *rj.Ended
+		}
+		attrs := []attribute.KeyValue{
+			attribute.String("cds.job", rj.JobID),
+			attribute.String("cds.run_job_id", rj.ID),
+			attribute.String("cds.status", string(rj.Status)),
+			attribute.String("cds.region", rj.Region),
+			attribute.String("cds.model_type", rj.ModelType),
+			attribute.String("cds.hatchery", rj.HatcheryName),
+			attribute.String("cds.worker", rj.WorkerName),
+		}
+		for k, v := range rj.Matrix {
+			attrs = append(attrs, attribute.String("cds.matrix."+k, sdk.MatrixValueLabel(v)))
+		}
+		jobCtx, jobSpan := e.startSpan(runCtx, traceID, sdk.V2WorkflowRunJobSpanID(rj.ID), rj.JobID, rj.Queued, trace.WithAttributes(attrs...))
+		for _, info := range runJobInfos[rj.ID] {
+			jobSpan.AddEvent(info.Message, trace.WithTimestamp(info.IssuedAt), trace.WithAttributes(attribute.String("level", info.Level)))
+		}
+		setSpanStatus(jobSpan, rj.Status == sdk.V2WorkflowRunJobStatusFail, string(rj.Status))
+
+		// Job phases
+		scheduledAt, startedAt := jobEnd, jobEnd
+		if rj.Started != nil {
+			startedAt = *rj.Started
+			scheduledAt = startedAt
+		}
+		if rj.Scheduled != nil {
+			scheduledAt = *rj.Scheduled
+		}
+		e.phaseSpan(jobCtx, traceID, sdk.V2WorkflowRunSpanID(rj.ID, "queued"), "queued", rj.Queued, scheduledAt)
+		if rj.Scheduled != nil {
+			e.phaseSpan(jobCtx, traceID, sdk.V2WorkflowRunSpanID(rj.ID, "scheduled"), "scheduled", scheduledAt, startedAt)
+		}
+		if rj.Started != nil {
+			buildingCtx, buildingSpan := e.startSpan(jobCtx, traceID, sdk.V2WorkflowRunJobBuildingSpanID(rj.ID), "building", startedAt)
+			e.stepSpans(buildingCtx, traceID, rj, jobEnd)
+			buildingSpan.End(trace.WithTimestamp(jobEnd))
+		}
+		jobSpan.End(trace.WithTimestamp(jobEnd))
+	}
+	runSpan.End(trace.WithTimestamp(runEnd))
+
+	return sdk.WithStack(e.provider.ForceFlush(ctx))
+}
+
+func (e *RunTraceExporter) stepSpans(ctx context.Context, traceID trace.TraceID, rj sdk.V2WorkflowRunJob, jobEnd time.Time) {
+	stepIDs := make([]string, 0, len(rj.StepsStatus))
+	for id := range rj.StepsStatus {
+		stepIDs = append(stepIDs, id)
+	}
+	sort.Slice(stepIDs, func(i, j int) bool {
+		return rj.StepsStatus[stepIDs[i]].Started.Before(rj.StepsStatus[stepIDs[j]].Started)
+	})
+	for _, id := range stepIDs {
+		st := rj.StepsStatus[id]
+		if st.Started.IsZero() {
+			continue
+		}
+		stepEnd := st.Ended
+		if stepEnd.IsZero() {
+			stepEnd = jobEnd
+		}
+		_, span := e.startSpan(ctx, traceID, sdk.V2WorkflowRunJobStepSpanID(rj.ID, id), id, st.Started,
+			trace.WithAttributes(
+				attribute.String("cds.step", id),
+				attribute.String("cds.conclusion", string(st.Conclusion)),
+				attribute.String("cds.outcome", string(st.Outcome)),
+			))
+		setSpanStatus(span, st.Outcome == sdk.V2WorkflowRunJobStatusFail, string(st.Outcome))
+		span.End(trace.WithTimestamp(stepEnd))
+	}
+}
+
+func (e *RunTraceExporter) phaseSpan(ctx context.Context, traceID trace.TraceID, spanID [8]byte, name string, start, end time.Time) {
+	_, span := e.startSpan(ctx, traceID, spanID, name, start)
+	span.End(trace.WithTimestamp(end))
+}
+
+// startSpan starts a span with the given identifiers, they are returned by the runIDGenerator
+func (e *RunTraceExporter) startSpan(ctx context.Context, traceID trace.TraceID, spanID [8]byte, name string, start time.Time, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx = context.WithValue(ctx, spanIDsContextKey{}, spanIDs{traceID: traceID, spanID: trace.SpanID(spanID)})
+	opts = append([]trace.SpanStartOption{trace.WithTimestamp(start), trace.WithSpanKind(trace.SpanKindInternal)}, opts...)
+	return e.tracer.Start(ctx, name, opts...)
+}
+
+// Shutdown flushes and stops the exporter
+func (e *RunTraceExporter) Shutdown(ctx context.Context) error {
+	return sdk.WithStack(e.provider.Shutdown(ctx))
+}
+
+type spanIDsContextKey struct{}
+
+type spanIDs struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+// runIDGenerator returns the trace and span identifiers set in the context by startSpan
+type runIDGenerator struct{}
+
+func (runIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	ids, _ := ctx.Value(spanIDsContextKey{}).(spanIDs)
+	return ids.traceID, ids.spanID
+}
+
+func (runIDGenerator) NewSpanID(ctx context.Context, _ trace.TraceID) trace.SpanID {
+	ids, _ := ctx.Value(spanIDsContextKey{}).(spanIDs)
+	return ids.spanID
+}
+
+func setSpanStatus(span trace.Span, failed bool, description string) {
+	if failed {
+		span.SetStatus(codes.Error, description)
+		return
+	}
+	span.SetStatus(codes.Ok, "")
+}
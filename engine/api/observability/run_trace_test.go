@@ -0,0 +1,96 @@
+package observability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestRunTraceExporterExport(t *testing.T) {
+	now := time.Now()
+	scheduled := now.Add(time.Second)
+	started := now.Add(2 * time.Second)
+	ended := now.Add(10 * time.Second)
+
+	run := sdk.V2WorkflowRun{
+		ID:           "run-id",
+		WorkflowName: "my-workflow",
+		RunAttempt:   2,
+		Status:       sdk.V2WorkflowRunStatusFail,
+		Started:      now,
+		LastModified: ended,
+	}
+	runJobs := []sdk.V2WorkflowRunJob{
+		{
+			ID:            "job-1",
+			JobID:         "build",
+			WorkflowRunID: run.ID,
+			RunAttempt:    2,
+			Status:        sdk.V2WorkflowRunJobStatusFail,
+			Queued:        now,
+			Scheduled:     &scheduled,
+			Started:       &started,
+			Ended:         &ended,
+			StepsStatus: sdk.JobStepsStatus{
+				"step-0": {Outcome: sdk.V2WorkflowRunJobStatusSuccess, Conclusion: sdk.V2WorkflowRunJobStatusSuccess, Started: started, Ended: started.Add(time.Second)},
+				"step-1": {Outcome: sdk.V2WorkflowRunJobStatusFail, Conclusion: sdk.V2WorkflowRunJobStatusFail, Started: started.Add(time.Second), Ended: ended},
+			},
+		},
+		{
+			ID:            "job-2",
+			JobID:         "deploy",
+			WorkflowRunID: run.ID,
+			RunAttempt:    2,
+			Status:        sdk.V2WorkflowRunJobStatusSkipped,
+			Queued:        now,
+		},
+	}
+	infos := map[string][]sdk.V2WorkflowRunJobInfo{
+		"job-1": {{Level: sdk.WorkflowRunInfoLevelError, Message: "step-1 failed", IssuedAt: ended}},
+	}
+
+	exp := tracetest.NewInMemoryExporter()
+	e := newRunTraceExporter(exp, "")
+	require.NoError(t, e.Export(context.TODO(), run, runJobs, infos))
+	spans := exp.GetSpans()
+	require.NoError(t, e.Shutdown(context.TODO()))
+	// run + (job, queued, scheduled, building, 2 steps) + (job, queued)
+	require.Len(t, spans, 9)
+
+	byName := make(map[string]int)
+	for i, s := range spans {
+		require.Equal(t, trace.TraceID(sdk.V2WorkflowRunTraceID(run.ID, run.RunAttempt)), s.SpanContext.TraceID())
+		byName[s.Name] = i
+	}
+
+	runSpan := spans[byName["my-workflow"]]
+	require.False(t, runSpan.Parent.IsValid())
+	require.Equal(t, codes.Error, runSpan.Status.Code)
+
+	job := spans[byName["build"]]
+	require.Equal(t, runSpan.SpanContext.SpanID(), job.Parent.SpanID())
+	require.Len(t, job.Events, 1)
+	require.Equal(t, "step-1 failed", job.Events[0].Name)
+
+	building := spans[byName["building"]]
+	require.Equal(t, job.SpanContext.SpanID(), building.Parent.SpanID())
+	require.Equal(t, started, building.StartTime)
+	require.Equal(t, ended, building.EndTime)
+
+	// The steps are children of the span given to the job with TRACEPARENT
+	require.Contains(t, sdk.V2WorkflowRunJobTraceParent(runJobs[0]), building.SpanContext.SpanID().String())
+	step := spans[byName["step-1"]]
+	require.Equal(t, building.SpanContext.SpanID(), step.Parent.SpanID())
+	require.Equal(t, codes.Error, step.Status.Code)
+	require.Equal(t, codes.Ok, spans[byName["step-0"]].Status.Code)
+
+	_, has := byName["deploy"]
+	require.True(t, has)
+}
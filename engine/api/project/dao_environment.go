@@ -0,0 +1,78 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func InsertEnvironment(ctx context.Context, db gorpmapper.SqlExecutorWithTx, e *sdk.ProjectEnvironment) error {
+	e.ID = sdk.UUID()
+	e.LastModified = time.Now()
+	dbData := &dbProjectEnvironment{ProjectEnvironment: *e}
+	if err := gorpmapping.Insert(db, dbData); err != nil {
+		return err
+	}
+	*e = dbData.ProjectEnvironment
+	return nil
+}
+
+func UpdateEnvironment(ctx context.Context, db gorpmapper.SqlExecutorWithTx, e *sdk.ProjectEnvironment) error {
+	e.LastModified = time.Now()
+	dbData := &dbProjectEnvironment{ProjectEnvironment: *e}
+	if err := gorpmapping.Update(db, dbData); err != nil {
+		return err
+	}
+	*e = dbData.ProjectEnvironment
+	return nil
+}
+
+func DeleteEnvironment(db gorpmapper.SqlExecutorWithTx, projectKey string, environmentID string) error {
+	_, err := db.Exec("DELETE FROM project_environment WHERE id = $1 AND project_key = $2", environmentID, projectKey)
+	return sdk.WrapError(err, "cannot delete project_environment %s / %s", projectKey, environmentID)
+}
+
+func getEnvironment(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) (*sdk.ProjectEnvironment, error) {
+	var res dbProjectEnvironment
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &res.ProjectEnvironment, nil
+}
+
+func getEnvironments(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.ProjectEnvironment, error) {
+	var res []dbProjectEnvironment
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+
+	environments := make([]sdk.ProjectEnvironment, 0, len(res))
+	for _, r := range res {
+		environments = append(environments, r.ProjectEnvironment)
+	}
+
+	return environments, nil
+}
+
+func LoadEnvironmentByIDAndProjectKey(ctx context.Context, db gorp.SqlExecutor, projKey string, id string) (*sdk.ProjectEnvironment, error) {
+	query := gorpmapping.NewQuery(`SELECT project_environment.* FROM project_environment WHERE project_key = $1 AND id = $2`).Args(projKey, id)
+	return getEnvironment(ctx, db, query)
+}
+
+func LoadEnvironmentByNameAndProjectKey(ctx context.Context, db gorp.SqlExecutor, projKey string, name string) (*sdk.ProjectEnvironment, error) {
+	query := gorpmapping.NewQuery(`SELECT project_environment.* FROM project_environment WHERE project_key = $1 AND name = $2`).Args(projKey, name)
+	return getEnvironment(ctx, db, query)
+}
+
+func LoadEnvironmentsByProjectKey(ctx context.Context, db gorp.SqlExecutor, projKey string) ([]sdk.ProjectEnvironment, error) {
+	query := gorpmapping.NewQuery(`SELECT project_environment.* FROM project_environment WHERE project_key = $1 ORDER BY name`).Args(projKey)
+	return getEnvironments(ctx, db, query)
+}
@@ -0,0 +1,109 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func InsertFreeze(ctx context.Context, db gorpmapper.SqlExecutorWithTx, f *sdk.ProjectFreeze) error {
+	f.ID = sdk.UUID()
+	f.LastModified = time.Now()
+	dbData := &dbProjectFreeze{ProjectFreeze: *f}
+	if err := gorpmapping.Insert(db, dbData); err != nil {
+		return err
+	}
+	*f = dbData.ProjectFreeze
+	return nil
+}
+
+func UpdateFreeze(ctx context.Context, db gorpmapper.SqlExecutorWithTx, f *sdk.ProjectFreeze) error {
+	f.LastModified = time.Now()
+	dbData := &dbProjectFreeze{ProjectFreeze: *f}
+	if err := gorpmapping.Update(db, dbData); err != nil {
+		return err
+	}
+	*f = dbData.ProjectFreeze
+	return nil
+}
+
+func DeleteFreeze(db gorpmapper.SqlExecutorWithTx, projectKey string, freezeID string) error {
+	_, err := db.Exec("DELETE FROM project_freeze WHERE id = $1 AND project_key = $2", freezeID, projectKey)
+	return sdk.WrapError(err, "cannot delete project_freeze %s / %s", projectKey, freezeID)
+}
+
+func getFreeze(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) (*sdk.ProjectFreeze, error) {
+	var res dbProjectFreeze
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &res.ProjectFreeze, nil
+}
+
+func getFreezes(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.ProjectFreeze, error) {
+	var res []dbProjectFreeze
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+
+	freezes := make([]sdk.ProjectFreeze, 0, len(res))
+	for _, r := range res {
+		freezes = append(freezes, r.ProjectFreeze)
+	}
+
+	return freezes, nil
+}
+
+func LoadFreezeByNameAndProjectKey(ctx context.Context, db gorp.SqlExecutor, projKey string, name string) (*sdk.ProjectFreeze, error) {
+	query := gorpmapping.NewQuery(`SELECT project_freeze.* FROM project_freeze WHERE project_key = $1 AND name = $2`).Args(projKey, name)
+	return getFreeze(ctx, db, query)
+}
+
+func LoadFreezesByProjectKey(ctx context.Context, db gorp.SqlExecutor, projKey string) ([]sdk.ProjectFreeze, error) {
+	query := gorpmapping.NewQuery(`SELECT project_freeze.* FROM project_freeze WHERE project_key = $1 ORDER BY name`).Args(projKey)
+	return getFreezes(ctx, db, query)
+}
+
+func InsertFreezeOverride(ctx context.Context, db gorpmapper.SqlExecutorWithTx, o *sdk.ProjectFreezeOverride) error {
+	o.ID = sdk.UUID()
+	o.Created = time.Now()
+	dbData := &dbProjectFreezeOverride{ProjectFreezeOverride: *o}
+	if err := gorpmapping.Insert(db, dbData); err != nil {
+		return err
+	}
+	*o = dbData.ProjectFreezeOverride
+	return nil
+}
+
+func getFreezeOverrides(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.ProjectFreezeOverride, error) {
+	var res []dbProjectFreezeOverride
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	overrides := make([]sdk.ProjectFreezeOverride, 0, len(res))
+	for _, r := range res {
+		overrides = append(overrides, r.ProjectFreezeOverride)
+	}
+	return overrides, nil
+}
+
+// LoadFreezeOverridesByRunID returns the freezes overridden for a workflow run.
+func LoadFreezeOverridesByRunID(ctx context.Context, db gorp.SqlExecutor, projKey string, runID string) ([]sdk.ProjectFreezeOverride, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM project_freeze_override WHERE project_key = $1 AND workflow_run_id = $2`).Args(projKey, runID)
+	return getFreezeOverrides(ctx, db, query)
+}
+
+// LoadFreezeOverrides returns the audit trail of the overrides of a freeze, newest first.
+func LoadFreezeOverrides(ctx context.Context, db gorp.SqlExecutor, projKey string, freezeName string) ([]sdk.ProjectFreezeOverride, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM project_freeze_override WHERE project_key = $1 AND freeze = $2 ORDER BY created DESC`).Args(projKey, freezeName)
+	return getFreezeOverrides(ctx, db, query)
+}
@@ -0,0 +1,63 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func InsertQuota(ctx context.Context, db gorpmapper.SqlExecutorWithTx, quota *sdk.ProjectQuota) error {
+	quota.LastModified = time.Now()
+	dbData := dbProjectQuota{ProjectQuota: *quota}
+	if err := gorpmapping.Insert(db, &dbData); err != nil {
+		return err
+	}
+	*quota = dbData.ProjectQuota
+	return nil
+}
+
+func UpdateQuota(ctx context.Context, db gorpmapper.SqlExecutorWithTx, quota *sdk.ProjectQuota) error {
+	quota.LastModified = time.Now()
+	dbData := dbProjectQuota{ProjectQuota: *quota}
+	if err := gorpmapping.Update(db, &dbData); err != nil {
+		return err
+	}
+	*quota = dbData.ProjectQuota
+	return nil
+}
+
+func DeleteQuota(db gorpmapper.SqlExecutorWithTx, projectKey string) error {
+	_, err := db.Exec("DELETE FROM project_quota WHERE project_key = $1", projectKey)
+	return sdk.WrapError(err, "cannot delete project_quota %s", projectKey)
+}
+
+func LoadQuotaByProjectKey(ctx context.Context, db gorp.SqlExecutor, projKey string) (*sdk.ProjectQuota, error) {
+	query := gorpmapping.NewQuery(`SELECT project_quota.* FROM project_quota WHERE project_key = $1`).Args(projKey)
+	var res dbProjectQuota
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &res.ProjectQuota, nil
+}
+
+// LoadQuotaByProjectKeyForUpdate loads the quota of a project and locks it until the end of the transaction
+func LoadQuotaByProjectKeyForUpdate(ctx context.Context, db gorpmapper.SqlExecutorWithTx, projKey string) (*sdk.ProjectQuota, error) {
+	query := gorpmapping.NewQuery(`SELECT project_quota.* FROM project_quota WHERE project_key = $1 FOR UPDATE`).Args(projKey)
+	var res dbProjectQuota
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &res.ProjectQuota, nil
+}
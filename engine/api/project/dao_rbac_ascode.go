@@ -0,0 +1,48 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func InsertRBACAsCode(ctx context.Context, db gorpmapper.SqlExecutorWithTx, r *sdk.ProjectRBACAsCode) error {
+	r.Created = time.Now()
+	dbData := dbProjectRBACAsCode{ProjectRBACAsCode: *r}
+	if err := gorpmapping.Insert(db, &dbData); err != nil {
+		return err
+	}
+	*r = dbData.ProjectRBACAsCode
+	return nil
+}
+
+func UpdateRBACAsCode(ctx context.Context, db gorpmapper.SqlExecutorWithTx, r *sdk.ProjectRBACAsCode) error {
+	dbData := dbProjectRBACAsCode{ProjectRBACAsCode: *r}
+	if err := gorpmapping.Update(db, &dbData); err != nil {
+		return err
+	}
+	*r = dbData.ProjectRBACAsCode
+	return nil
+}
+
+func DeleteRBACAsCode(db gorpmapper.SqlExecutorWithTx, projectKey string) error {
+	_, err := db.Exec("DELETE FROM project_rbac_ascode WHERE project_key = $1", projectKey)
+	return sdk.WrapError(err, "cannot delete project_rbac_ascode %s", projectKey)
+}
+
+func LoadRBACAsCodeByProjectKey(ctx context.Context, db gorp.SqlExecutor, projKey string) (*sdk.ProjectRBACAsCode, error) {
+	query := gorpmapping.NewQuery(`SELECT project_rbac_ascode.* FROM project_rbac_ascode WHERE project_key = $1`).Args(projKey)
+	var res dbProjectRBACAsCode
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &res.ProjectRBACAsCode, nil
+}
@@ -24,10 +24,30 @@ type dbProjectRunFilter struct {
 	sdk.ProjectRunFilter
 }
 
+type dbProjectQuota struct {
+	sdk.ProjectQuota
+}
+
+type dbProjectRBACAsCode struct {
+	sdk.ProjectRBACAsCode
+}
+
 type dbProjectConcurrency struct {
 	sdk.ProjectConcurrency
 }
 
+type dbProjectEnvironment struct {
+	sdk.ProjectEnvironment
+}
+
+type dbProjectFreeze struct {
+	sdk.ProjectFreeze
+}
+
+type dbProjectFreezeOverride struct {
+	sdk.ProjectFreezeOverride
+}
+
 type dbProjectVariableSet struct {
 	gorpmapper.SignedEntity
 	sdk.ProjectVariableSet
@@ -197,9 +217,14 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbProjectVariableSetItemText{}, "project_variable_set_text", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectVariableSetItemSecret{}, "project_variable_set_secret", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectConcurrency{}, "project_concurrency", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectEnvironment{}, "project_environment", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectFreeze{}, "project_freeze", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectFreezeOverride{}, "project_freeze_override", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectWebHook{}, "project_webhook", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectRunRetention{}, "project_run_retention", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectRunFilter{}, "project_run_filter", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectQuota{}, "project_quota", false, "project_key"))
+	gorpmapping.Register(gorpmapping.New(dbProjectRBACAsCode{}, "project_rbac_ascode", false, "project_key"))
 
 }
 
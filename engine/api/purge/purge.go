@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/go-gorp/gorp"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/project"
@@ -37,7 +35,7 @@ func SetPurgeConfiguration(rule string, disableDeletionConf bool) error {
 }
 
 // MarkRunsAsDelete mark workflow run as delete
-func MarkRunsAsDelete(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMap, workflowRunsMarkToDelete *stats.Int64Measure) {
+func MarkRunsAsDelete(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMap, workflowRunsMarkToDelete *telemetry.Int64Measure) {
 	tickMark := time.NewTicker(15 * time.Minute)
 	defer tickMark.Stop()
 
@@ -60,7 +58,7 @@ func MarkRunsAsDelete(ctx context.Context, store cache.Store, DBFunc func() *gor
 }
 
 // PurgeWorkflow deletes workflow runs marked as to delete
-func WorkflowRuns(ctx context.Context, DBFunc func() *gorp.DbMap, workflowRunsMarkToDelete, workflowRunsDeleted *stats.Int64Measure) {
+func WorkflowRuns(ctx context.Context, DBFunc func() *gorp.DbMap, workflowRunsMarkToDelete, workflowRunsDeleted *telemetry.Int64Measure) {
 	tickPurge := time.NewTicker(15 * time.Minute)
 	defer tickPurge.Stop()
 
@@ -88,7 +86,7 @@ func WorkflowRuns(ctx context.Context, DBFunc func() *gorp.DbMap, workflowRunsMa
 }
 
 // Workflow deletes workflows marked as to delete
-func Workflow(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMap, workflowRunsMarkToDelete *stats.Int64Measure) {
+func Workflow(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMap, workflowRunsMarkToDelete *telemetry.Int64Measure) {
 	tickPurge := time.NewTicker(15 * time.Minute)
 	defer tickPurge.Stop()
 
@@ -109,7 +107,7 @@ func Workflow(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMap,
 }
 
 // MarkWorkflowRuns Deprecated: old method to mark runs to delete
-func MarkWorkflowRuns(ctx context.Context, db *gorp.DbMap, workflowRunsMarkToDelete *stats.Int64Measure) error {
+func MarkWorkflowRuns(ctx context.Context, db *gorp.DbMap, workflowRunsMarkToDelete *telemetry.Int64Measure) error {
 	dao := new(workflow.WorkflowDAO)
 	dao.Filters.DisableFilterDeletedWorkflow = false
 	wfs, err := dao.LoadAll(ctx, db)
@@ -144,7 +142,7 @@ func MarkWorkflowRuns(ctx context.Context, db *gorp.DbMap, workflowRunsMarkToDel
 }
 
 // workflows purges all marked workflows
-func workflows(ctx context.Context, db *gorp.DbMap, store cache.Store, workflowRunsMarkToDelete *stats.Int64Measure) error {
+func workflows(ctx context.Context, db *gorp.DbMap, store cache.Store, workflowRunsMarkToDelete *telemetry.Int64Measure) error {
 	query := "SELECT id, project_id FROM workflow WHERE to_delete = true ORDER BY id ASC"
 	var res []struct {
 		ID        int64 `db:"id"`
@@ -239,7 +237,7 @@ func workflows(ctx context.Context, db *gorp.DbMap, store cache.Store, workflowR
 }
 
 // deleteWorkflowRunsHistory is useful to delete all the workflow run marked with to delete flag in db
-func deleteWorkflowRunsHistory(ctx context.Context, db *gorp.DbMap, workflowRunsDeleted *stats.Int64Measure) error {
+func deleteWorkflowRunsHistory(ctx context.Context, db *gorp.DbMap, workflowRunsDeleted *telemetry.Int64Measure) error {
 	//Load service "CDN"
 	srvs, err := services.LoadAllByType(ctx, db, sdk.TypeCDN)
 	if err != nil {
@@ -271,7 +269,7 @@ func deleteWorkflowRunsHistory(ctx context.Context, db *gorp.DbMap, workflowRuns
 	return nil
 }
 
-func deleteRunHistory(ctx context.Context, db *gorp.DbMap, workflowRunID int64, cdnClient services.Client, workflowRunsDeleted *stats.Int64Measure) error {
+func deleteRunHistory(ctx context.Context, db *gorp.DbMap, workflowRunID int64, cdnClient services.Client, workflowRunsDeleted *telemetry.Int64Measure) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return sdk.WithStack(err)
@@ -365,7 +363,7 @@ func DeleteArtifactsFromRepositoryManager(ctx context.Context, db gorp.SqlExecut
 
 	lowMaturity := artifactManagerInteg.ProjectIntegration.Config[sdk.ArtifactoryConfigPromotionLowMaturity].Value
 
-	props := utils.NewProperties()
+	props := artifact_manager.NewProperties()
 	props.AddProperty("ovh.to_delete", "true")
 	props.AddProperty("ovh.to_delete_timestamp", strconv.FormatInt(time.Now().Unix(), 10))
 
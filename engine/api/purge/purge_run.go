@@ -10,7 +10,6 @@ import (
 	"github.com/fsamin/go-dump"
 	"github.com/go-gorp/gorp"
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
 
 	"github.com/ovh/cds/engine/api/application"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
@@ -43,7 +42,7 @@ func GetRetentionPolicyVariables() []string {
 	return []string{RunDaysBefore, RunStatus, RunHasGitBranch, RunGitBranchExist, RunChangeMerged, RunChangeAbandoned, RunChangeDayBefore, RunChangeExist}
 }
 
-func markWorkflowRunsToDelete(ctx context.Context, store cache.Store, db *gorp.DbMap, workflowRunsMarkToDelete *stats.Int64Measure) error {
+func markWorkflowRunsToDelete(ctx context.Context, store cache.Store, db *gorp.DbMap, workflowRunsMarkToDelete *telemetry.Int64Measure) error {
 	ctx, end := telemetry.Span(ctx, "purge.markWorkflowRunsToDelete")
 	defer end()
 
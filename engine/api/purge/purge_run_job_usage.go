@@ -0,0 +1,43 @@
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/sdk"
+)
+
+// PurgeRunJobUsages is a goroutine that periodically deletes the job usages older than the given retention in months.
+func PurgeRunJobUsages(ctx context.Context, DBFunc func() *gorp.DbMap, retentionMonths int64) {
+	if retentionMonths <= 0 {
+		log.Info(ctx, "purge> PurgeRunJobUsages disabled (retentionMonths=%d)", retentionMonths)
+		return
+	}
+
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "purge> PurgeRunJobUsages exiting: %v", ctx.Err())
+			}
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			before := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -int(retentionMonths), 0)
+			n, err := workflow_v2.DeleteRunJobUsagesBefore(ctx, DBFunc(), before)
+			if err != nil {
+				ctx = sdk.ContextWithStacktrace(ctx, err)
+				log.Error(ctx, "purge> PurgeRunJobUsages: %v", err)
+				continue
+			}
+			log.Info(ctx, "purge> PurgeRunJobUsages: %d usages started before %s deleted", n, before.Format(sdk.UsageMonthLayout))
+		}
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/go-gorp/gorp"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/engine/api/event_v2"
@@ -364,7 +363,7 @@ func DeleteArtifactsFromRepositoryManagerV2(ctx context.Context, db gorp.SqlExec
 
 	lowMaturity := artifactoryIntegration.Config[sdk.ArtifactoryConfigPromotionLowMaturity].Value
 
-	props := utils.NewProperties()
+	props := artifact_manager.NewProperties()
 	props.AddProperty("ovh.to_delete", "true")
 	props.AddProperty("ovh.to_delete_timestamp", strconv.FormatInt(time.Now().Unix(), 10))
 
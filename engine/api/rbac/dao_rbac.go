@@ -13,9 +13,25 @@ import (
 	"github.com/ovh/cds/sdk"
 )
 
-func LoadAll(ctx context.Context, db gorp.SqlExecutor) ([]sdk.RBAC, error) {
+// rbacNotExpired restricts a query on a part of the permissions to the permissions that are not expired. Expired permissions
+// are deleted by a periodic sweep, they must not grant a role until then.
+const rbacNotExpired = `rbac_id IN (SELECT id FROM rbac WHERE expire_at IS NULL OR expire_at > now())`
+
+func LoadAll(ctx context.Context, db gorp.SqlExecutor, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
 	query := gorpmapping.NewQuery(`SELECT * FROM rbac`)
-	return getAll(ctx, db, query)
+	return getAll(ctx, db, query, opts...)
+}
+
+// LoadExpiredRBAC returns the permissions with an expiry date before the given date.
+func LoadExpiredRBAC(ctx context.Context, db gorp.SqlExecutor, now time.Time, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac WHERE expire_at IS NOT NULL AND expire_at <= $1`).Args(now)
+	return getAll(ctx, db, query, opts...)
+}
+
+// LoadRBACByProjectKeyAsCode returns the permissions managed as code by the given project.
+func LoadRBACByProjectKeyAsCode(ctx context.Context, db gorp.SqlExecutor, projectKey string, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac WHERE ascode_project_key = $1`).Args(projectKey)
+	return getAll(ctx, db, query, opts...)
 }
 
 func LoadRBACByName(ctx context.Context, db gorp.SqlExecutor, name string, opts ...LoadOptionFunc) (*sdk.RBAC, error) {
@@ -28,6 +44,12 @@ func LoadRBACByID(ctx context.Context, db gorp.SqlExecutor, id string, opts ...L
 	return get(ctx, db, gorpmapping.NewQuery(query).Args(id), opts...)
 }
 
+// LoadAndLockRBACByID loads a permission and locks it until the end of the transaction, it returns ErrNotFound if the permission is already locked.
+func LoadAndLockRBACByID(ctx context.Context, db gorp.SqlExecutor, id string, opts ...LoadOptionFunc) (*sdk.RBAC, error) {
+	query := `SELECT * FROM rbac WHERE id = $1 FOR UPDATE SKIP LOCKED`
+	return get(ctx, db, gorpmapping.NewQuery(query).Args(id), opts...)
+}
+
 func LoadRBACByIDs(ctx context.Context, db gorp.SqlExecutor, IDs sdk.StringSlice, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
 	query := `SELECT * FROM rbac WHERE id = ANY ($1)`
 	return getAll(ctx, db, gorpmapping.NewQuery(query).Args(pq.StringArray(IDs)), opts...)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
 )
@@ -85,8 +86,39 @@ func HasGlobalRole(ctx context.Context, db gorp.SqlExecutor, role string, userID
 	return len(rgs) > 0, nil
 }
 
+// LoadUserIDsWithGlobalRole returns the ids of the users having a global role, directly or through one of their groups.
+func LoadUserIDsWithGlobalRole(ctx context.Context, db gorp.SqlExecutor, role string) ([]string, error) {
+	rgs, err := getAllRBACGlobal(ctx, db, gorpmapping.NewQuery(`SELECT * FROM rbac_global WHERE role = $1 AND `+rbacNotExpired).Args(role))
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0)
+	groupIDs := make(sdk.Int64Slice, 0)
+	for i := range rgs {
+		if err := loadRBACGlobalUsers(ctx, db, &rgs[i]); err != nil {
+			return nil, err
+		}
+		if err := loadRBACGlobalGroups(ctx, db, &rgs[i]); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, rgs[i].RBACUsersIDs...)
+		groupIDs = append(groupIDs, rgs[i].RBACGroupsIDs...)
+	}
+	groupIDs.Unique()
+	if len(groupIDs) > 0 {
+		links, err := group.LoadLinksGroupUserForGroupIDs(ctx, db, groupIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range links {
+			userIDs = append(userIDs, l.AuthentifiedUserID)
+		}
+	}
+	return sdk.Unique(userIDs), nil
+}
+
 func loadRBACGlobalByRoleAndIDs(ctx context.Context, db gorp.SqlExecutor, role string, rbacGlobalIDs []int64) ([]rbacGlobal, error) {
-	q := gorpmapping.NewQuery(`SELECT * from rbac_global WHERE role = $1 AND id = ANY($2)`).Args(role, pq.Int64Array(rbacGlobalIDs))
+	q := gorpmapping.NewQuery(`SELECT * from rbac_global WHERE role = $1 AND id = ANY($2) AND `+rbacNotExpired).Args(role, pq.Int64Array(rbacGlobalIDs))
 	return getAllRBACGlobal(ctx, db, q)
 }
 
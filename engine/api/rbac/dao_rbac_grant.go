@@ -0,0 +1,82 @@
+package rbac
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func InsertGrantRequest(ctx context.Context, db gorpmapper.SqlExecutorWithTx, g *sdk.RBACGrantRequest) error {
+	g.ID = sdk.UUID()
+	g.Created = time.Now()
+	dbData := &rbacGrantRequest{RBACGrantRequest: *g}
+	if err := gorpmapping.Insert(db, dbData); err != nil {
+		return err
+	}
+	*g = dbData.RBACGrantRequest
+	return nil
+}
+
+func UpdateGrantRequest(ctx context.Context, db gorpmapper.SqlExecutorWithTx, g *sdk.RBACGrantRequest) error {
+	dbData := &rbacGrantRequest{RBACGrantRequest: *g}
+	if err := gorpmapping.Update(db, dbData); err != nil {
+		return err
+	}
+	*g = dbData.RBACGrantRequest
+	return nil
+}
+
+func getGrantRequest(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) (*sdk.RBACGrantRequest, error) {
+	var res rbacGrantRequest
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &res.RBACGrantRequest, nil
+}
+
+func getGrantRequests(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.RBACGrantRequest, error) {
+	var res []rbacGrantRequest
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	grants := make([]sdk.RBACGrantRequest, 0, len(res))
+	for _, r := range res {
+		grants = append(grants, r.RBACGrantRequest)
+	}
+	return grants, nil
+}
+
+func LoadGrantRequestByID(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.RBACGrantRequest, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac_grant_request WHERE id = $1`).Args(id)
+	return getGrantRequest(ctx, db, query)
+}
+
+// LoadAndLockGrantRequestByID loads a grant request and locks it until the end of the transaction, it returns ErrNotFound if the request is already locked.
+func LoadAndLockGrantRequestByID(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.RBACGrantRequest, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac_grant_request WHERE id = $1 FOR UPDATE SKIP LOCKED`).Args(id)
+	return getGrantRequest(ctx, db, query)
+}
+
+// LoadGrantRequests returns the grant requests, newest first. Empty filters are ignored.
+func LoadGrantRequests(ctx context.Context, db gorp.SqlExecutor, userID string, status string) ([]sdk.RBACGrantRequest, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM rbac_grant_request
+		WHERE ($1 = '' OR user_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created DESC`).Args(userID, status)
+	return getGrantRequests(ctx, db, query)
+}
+
+// LoadExpiredGrantRequests returns the approved grant requests with an expiry date before the given date.
+func LoadExpiredGrantRequests(ctx context.Context, db gorp.SqlExecutor, now time.Time) ([]sdk.RBACGrantRequest, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac_grant_request WHERE status = $1 AND expire_at <= $2`).Args(sdk.RBACGrantRequestStatusApproved, now)
+	return getGrantRequests(ctx, db, query)
+}
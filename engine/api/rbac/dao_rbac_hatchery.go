@@ -65,7 +65,7 @@ func getRBACHatchery(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Que
 func LoadRBACByHatcheryID(ctx context.Context, db gorp.SqlExecutor, hatcheryID string) (*sdk.RBAC, error) {
 	ctx, next := telemetry.Span(ctx, "hatchery.LoadRBACByHatcheryID")
 	defer next()
-	query := gorpmapping.NewQuery(`SELECT * FROM rbac_hatchery WHERE hatchery_id = $1`).Args(hatcheryID)
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac_hatchery WHERE hatchery_id = $1 AND ` + rbacNotExpired).Args(hatcheryID)
 	rbHatchery, err := getRBACHatchery(ctx, db, query)
 	if err != nil {
 		return nil, err
@@ -76,7 +76,7 @@ func LoadRBACByHatcheryID(ctx context.Context, db gorp.SqlExecutor, hatcheryID s
 func LoadRBACHatcheryByHatcheryID(ctx context.Context, db gorp.SqlExecutor, hatcheryID string) (*sdk.RBACHatchery, error) {
   ctx, next := telemetry.Span(ctx, "hatchery.LoadRBACByHatcheryID")
   defer next()
-  query := gorpmapping.NewQuery(`SELECT * FROM rbac_hatchery WHERE hatchery_id = $1`).Args(hatcheryID)
+  query := gorpmapping.NewQuery(`SELECT * FROM rbac_hatchery WHERE hatchery_id = $1 AND ` + rbacNotExpired).Args(hatcheryID)
   rbHatchery, err := getRBACHatchery(ctx, db, query)
   if err != nil {
     return nil, err
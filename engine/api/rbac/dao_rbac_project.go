@@ -90,12 +90,12 @@ func getAllRBACProjects(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.
 }
 
 func loadRBACProjectsByRoleAndIDs(ctx context.Context, db gorp.SqlExecutor, role string, rbacProjectIDs []int64) ([]rbacProject, error) {
-	q := gorpmapping.NewQuery(`SELECT * from rbac_project WHERE role = $1 AND id = ANY($2)`).Args(role, pq.Int64Array(rbacProjectIDs))
+	q := gorpmapping.NewQuery(`SELECT * from rbac_project WHERE role = $1 AND id = ANY($2) AND `+rbacNotExpired).Args(role, pq.Int64Array(rbacProjectIDs))
 	return getAllRBACProjects(ctx, db, q)
 }
 
 func loadRBACProjectByRoleAndPublic(ctx context.Context, db gorp.SqlExecutor, role string) ([]rbacProject, error) {
-	q := gorpmapping.NewQuery(`SELECT * from rbac_project WHERE role = $1 AND all_users = true`).Args(role)
+	q := gorpmapping.NewQuery(`SELECT * from rbac_project WHERE role = $1 AND all_users = true AND ` + rbacNotExpired).Args(role)
 	return getAllRBACProjects(ctx, db, q)
 }
 
@@ -105,6 +105,6 @@ func loadRBACProjectsByIDs(ctx context.Context, db gorp.SqlExecutor, ids []int64
 }
 
 func loadRBACProjectByRoleAndVCSUserPublic(ctx context.Context, db gorp.SqlExecutor, role string) ([]rbacProject, error) {
-	q := gorpmapping.NewQuery(`SELECT * from rbac_project WHERE role = $1 AND all_vcs_users = true`).Args(role)
+	q := gorpmapping.NewQuery(`SELECT * from rbac_project WHERE role = $1 AND all_vcs_users = true AND ` + rbacNotExpired).Args(role)
 	return getAllRBACProjects(ctx, db, q)
 }
@@ -122,7 +122,7 @@ func loadVCSUserPublicProjectKeysByRole(ctx context.Context, db gorp.SqlExecutor
 func LoadAllProjectKeysAllowedForVCSUser(ctx context.Context, db gorp.SqlExecutor, role string, user sdk.RBACVCSUser) (sdk.StringSlice, error) {
 	btes, _ := json.Marshal([]sdk.RBACVCSUser{user})
 	var ids []int64
-	_, err := db.Select(&ids, "select id from rbac_project where role = $1 and vcs_users::JSONB @> $2", role, string(btes))
+	_, err := db.Select(&ids, "select id from rbac_project where role = $1 and vcs_users::JSONB @> $2 and "+rbacNotExpired, role, string(btes))
 	if err != nil {
 		return nil, err
 	}
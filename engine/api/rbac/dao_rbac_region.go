@@ -60,7 +60,7 @@ func getAllRBACRegions(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Q
 
 func LoadRegionIDsByRoleAndVCSUSer(ctx context.Context, db gorp.SqlExecutor, role string, user sdk.RBACVCSUser) ([]sdk.RBACRegion, error) {
 	btes, _ := json.Marshal([]sdk.RBACVCSUser{user})
-	q := gorpmapping.NewQuery(`SELECT * from rbac_region WHERE role = $1 AND vcs_users::JSONB @> $2`).Args(role, string(btes))
+	q := gorpmapping.NewQuery(`SELECT * from rbac_region WHERE role = $1 AND vcs_users::JSONB @> $2 AND `+rbacNotExpired).Args(role, string(btes))
 	return getAllRBACRegions(ctx, db, q)
 }
 
@@ -106,17 +106,17 @@ func LoadRegionIDsByRoleAndUserID(ctx context.Context, db gorp.SqlExecutor, role
 }
 
 func loadRBACRegionsByRoleAndIDs(ctx context.Context, db gorp.SqlExecutor, role string, rbacRegionIDs []int64) ([]sdk.RBACRegion, error) {
-	q := gorpmapping.NewQuery(`SELECT * from rbac_region WHERE role = $1 AND id = ANY($2)`).Args(role, pq.Int64Array(rbacRegionIDs))
+	q := gorpmapping.NewQuery(`SELECT * from rbac_region WHERE role = $1 AND id = ANY($2) AND `+rbacNotExpired).Args(role, pq.Int64Array(rbacRegionIDs))
 	return getAllRBACRegions(ctx, db, q)
 }
 
 func loadRBACRegionOnAllUsers(ctx context.Context, db gorp.SqlExecutor, role string) ([]sdk.RBACRegion, error) {
-	q := gorpmapping.NewQuery("SELECT * from rbac_region WHERE role = $1 AND all_users = true").Args(role)
+	q := gorpmapping.NewQuery("SELECT * from rbac_region WHERE role = $1 AND all_users = true AND " + rbacNotExpired).Args(role)
 	return getAllRBACRegions(ctx, db, q)
 }
 
 func loadRBACRegionOnAllVCSUsers(ctx context.Context, db gorp.SqlExecutor, role string) ([]sdk.RBACRegion, error) {
-	q := gorpmapping.NewQuery("SELECT * from rbac_region WHERE role = $1 AND all_vcs_users = true").Args(role)
+	q := gorpmapping.NewQuery("SELECT * from rbac_region WHERE role = $1 AND all_vcs_users = true AND " + rbacNotExpired).Args(role)
 	return getAllRBACRegions(ctx, db, q)
 }
 
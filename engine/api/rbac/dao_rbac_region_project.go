@@ -67,7 +67,7 @@ func insertRBACRegionProject(ctx context.Context, db gorpmapper.SqlExecutorWithT
 }
 
 func loadRBACRegionProjectByRegionAndAllProjects(ctx context.Context, db gorp.SqlExecutor, regionID string) (*rbacRegionProject, error) {
-	q := gorpmapping.NewQuery("SELECT * FROM rbac_region_project WHERE region_id = $1 AND all_projects=true LIMIT 1").Args(regionID)
+	q := gorpmapping.NewQuery("SELECT * FROM rbac_region_project WHERE region_id = $1 AND all_projects=true AND " + rbacNotExpired + " LIMIT 1").Args(regionID)
 	return getRBACRegionProject(ctx, db, q)
 }
 
@@ -109,6 +109,6 @@ func HasRoleOnRegionProject(ctx context.Context, db gorp.SqlExecutor, role strin
 }
 
 func loadRBACRegionProjectByIDs(ctx context.Context, db gorp.SqlExecutor, role string, regionID string, IDs []int64) ([]rbacRegionProject, error) {
-	q := gorpmapping.NewQuery("SELECT * FROM rbac_region_project WHERE role = $1 AND region_id = $2 AND ID = ANY($3)").Args(role, regionID, pq.Int64Array(IDs))
+	q := gorpmapping.NewQuery("SELECT * FROM rbac_region_project WHERE role = $1 AND region_id = $2 AND ID = ANY($3) AND "+rbacNotExpired).Args(role, regionID, pq.Int64Array(IDs))
 	return getAllRBACRegionProject(ctx, db, q)
 }
@@ -11,7 +11,6 @@ import (
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
-	"github.com/ovh/cds/sdk/glob"
 	"github.com/ovh/cds/sdk/telemetry"
 )
 
@@ -87,17 +86,7 @@ func HasRoleOnVariableSetAndUserID(ctx context.Context, db gorp.SqlExecutor, rol
 	if allVariablesetAllowed {
 		return true, nil
 	}
-	for _, item := range variableSets {
-		g := glob.New(item)
-		r, err := g.MatchString(vsName)
-		if err != nil {
-			return false, err
-		}
-		if r != nil {
-			return true, nil
-		}
-	}
-	return false, nil
+	return sdk.RBACNamesMatch(variableSets, vsName)
 }
 
 func HasRoleOnVariableSetsAndVCSUser(ctx context.Context, db gorp.SqlExecutor, role string, user sdk.RBACVCSUser, projectKey string, vsNames []string) (bool, string, error) {
@@ -201,7 +190,7 @@ func loadRBACVariableSetsByIDs(ctx context.Context, db gorp.SqlExecutor, ids []i
 }
 
 func loadRBACVariableSetsByProjectAndRole(ctx context.Context, db gorp.SqlExecutor, projectKey string, role string) ([]rbacVariableSet, error) {
-	query := gorpmapping.NewQuery(`SELECT * FROM rbac_variableset WHERE project_key = $1 AND role = $2`).Args(projectKey, role)
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac_variableset WHERE project_key = $1 AND role = $2 AND `+rbacNotExpired).Args(projectKey, role)
 	rbacVss, err := getAllRBACVariableSets(ctx, db, query)
 	if err != nil {
 		return nil, err
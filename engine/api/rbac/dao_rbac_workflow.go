@@ -12,7 +12,6 @@ import (
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
-	"github.com/ovh/cds/sdk/glob"
 	"github.com/ovh/cds/sdk/telemetry"
 )
 
@@ -89,17 +88,7 @@ func HasRoleOnWorkflowAndVCSUsername(ctx context.Context, db gorp.SqlExecutor, r
 	if allWorkflowAllowed {
 		return true, nil
 	}
-	for _, item := range workflows {
-		g := glob.New(item)
-		r, err := g.MatchString(workflowNamePerm)
-		if err != nil {
-			return false, err
-		}
-		if r != nil {
-			return true, nil
-		}
-	}
-	return false, nil
+	return sdk.RBACNamesMatch(workflows, workflowNamePerm)
 }
 
 func HasRoleOnWorkflowAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string, vcs, repo, workflowName string) (bool, error) {
@@ -115,17 +104,7 @@ func HasRoleOnWorkflowAndUserID(ctx context.Context, db gorp.SqlExecutor, role s
 	if allWorkflowAllowed {
 		return true, nil
 	}
-	for _, item := range workflows {
-		g := glob.New(item)
-		r, err := g.MatchString(workflowNamePerm)
-		if err != nil {
-			return false, err
-		}
-		if r != nil {
-			return true, nil
-		}
-	}
-	return false, nil
+	return sdk.RBACNamesMatch(workflows, workflowNamePerm)
 }
 
 func LoadAllWorkflowsAllowedForVCSUSer(ctx context.Context, db gorp.SqlExecutor, role string, projectKey string, user sdk.RBACVCSUser) (sdk.StringSlice, bool, error) {
@@ -215,7 +194,7 @@ func loadRBACWorkflowsByIDs(ctx context.Context, db gorp.SqlExecutor, ids []int6
 }
 
 func loadRBACWorkflowsByProjectAndRole(ctx context.Context, db gorp.SqlExecutor, projectKey string, role string) ([]rbacWorkflow, error) {
-	query := gorpmapping.NewQuery(`SELECT * FROM rbac_workflow WHERE project_key = $1 AND role = $2`).Args(projectKey, role)
+	query := gorpmapping.NewQuery(`SELECT * FROM rbac_workflow WHERE project_key = $1 AND role = $2 AND `+rbacNotExpired).Args(projectKey, role)
 	rbacWorkflows, err := getAllRBACWorkflows(ctx, db, query)
 	if err != nil {
 		return nil, err
@@ -12,12 +12,18 @@ type rbac struct {
 }
 
 func (r rbac) Canonical() gorpmapper.CanonicalForms {
-	_ = []interface{}{r.ID, r.Name}
+	_ = []interface{}{r.ID, r.Name, r.ProjectKeyAsCode, r.ExpireAt}
 	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{.Name}}{{.ProjectKeyAsCode}}{{if .ExpireAt}}{{printDate .ExpireAt}}{{end}}",
+		"{{.ID}}{{.Name}}{{.ProjectKeyAsCode}}",
 		"{{.ID}}{{.Name}}",
 	}
 }
 
+type rbacGrantRequest struct {
+	sdk.RBACGrantRequest
+}
+
 type rbacGlobal struct {
 	ID     int64  `db:"id"`
 	RbacID string `db:"rbac_id"`
@@ -298,6 +304,7 @@ func (rgg rbacWorkflowGroup) Canonical() gorpmapper.CanonicalForms {
 
 func init() {
 	gorpmapping.Register(gorpmapping.New(rbac{}, "rbac", false, "id"))
+	gorpmapping.Register(gorpmapping.New(rbacGrantRequest{}, "rbac_grant_request", false, "id"))
 	gorpmapping.Register(gorpmapping.New(rbacGlobal{}, "rbac_global", true, "id"))
 	gorpmapping.Register(gorpmapping.New(rbacGlobalUser{}, "rbac_global_users", true, "id"))
 	gorpmapping.Register(gorpmapping.New(rbacGlobalGroup{}, "rbac_global_groups", true, "id"))
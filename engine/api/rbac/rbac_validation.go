@@ -2,6 +2,7 @@ package rbac
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-gorp/gorp"
 
@@ -15,6 +16,9 @@ func IsValidRBAC(ctx context.Context, db gorp.SqlExecutor, rbac *sdk.RBAC) error
 	if rbac.Name == "" {
 		return sdk.WrapError(sdk.ErrInvalidData, "missing permission name")
 	}
+	if rbac.IsExpired(time.Now()) {
+		return sdk.NewErrorFrom(sdk.ErrInvalidData, "rbac %s: expiry date %s is in the past", rbac.Name, rbac.ExpireAt.Format(time.RFC3339))
+	}
 	for _, g := range rbac.Global {
 		if err := isValidRBACGlobal(rbac.Name, g); err != nil {
 			return err
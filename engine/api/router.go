@@ -18,9 +18,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -34,17 +32,17 @@ const nbPanicsBeforeFail = 50
 
 var (
 	onceMetrics              sync.Once
-	Errors                   *stats.Int64Measure
-	Hits                     *stats.Int64Measure
-	WebSocketClients         *stats.Int64Measure
-	WebSocketV2Clients       *stats.Int64Measure
-	WebSocketHatcheryClients *stats.Int64Measure
-	WebSocketEvents          *stats.Int64Measure
-	WebSocketV2Events        *stats.Int64Measure
-	ServerRequestCount       *stats.Int64Measure
-	ServerRequestBytes       *stats.Int64Measure
-	ServerResponseBytes      *stats.Int64Measure
-	ServerLatency            *stats.Float64Measure
+	Errors                   *telemetry.Int64Measure
+	Hits                     *telemetry.Int64Measure
+	WebSocketClients         *telemetry.Int64Measure
+	WebSocketV2Clients       *telemetry.Int64Measure
+	WebSocketHatcheryClients *telemetry.Int64Measure
+	WebSocketEvents          *telemetry.Int64Measure
+	WebSocketV2Events        *telemetry.Int64Measure
+	ServerRequestCount       *telemetry.Int64Measure
+	ServerRequestBytes       *telemetry.Int64Measure
+	ServerResponseBytes      *telemetry.Int64Measure
+	ServerLatency            *telemetry.Float64Measure
 )
 
 // Router is a wrapper around mux.Router
@@ -336,10 +334,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 
 		// Make the request context inherit from the context of the router
 		tags := telemetry.ContextGetTags(r.Background, telemetry.TagServiceType, telemetry.TagServiceName)
-		ctx, err = tag.New(ctx, tags...)
-		if err != nil {
-			log.Error(ctx, "telemetry.ContextGetTags> %v", err)
-		}
+		ctx = telemetry.ContextWithTag(ctx, tags...)
 		ctx = telemetry.ContextWithTag(ctx,
 			telemetry.RequestID, requestID,
 			telemetry.Handler, rc.Name,
@@ -367,7 +362,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 		ctx = context.WithValue(ctx, cdslog.IPAddress, clientIP)
 
 		var fields = mux.Vars(req)
-		traceTags := make([]trace.Attribute, 0)
+		traceTags := make([]attribute.KeyValue, 0)
 		for k, v := range fields {
 			var s = doc.CleanURLParameter(k)
 			s = strings.ReplaceAll(s, "-", "_")
@@ -377,10 +372,10 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 
 			vUnescaped, err := url.PathUnescape(v)
 			if err == nil {
-				traceTags = append(traceTags, trace.StringAttribute(s, vUnescaped))
+				traceTags = append(traceTags, attribute.String(s, vUnescaped))
 			} else {
 				log.Warn(ctx, "unable to unescape path %s: %v", v, err)
-				traceTags = append(traceTags, trace.StringAttribute(s, v))
+				traceTags = append(traceTags, attribute.String(s, v))
 			}
 
 		}
@@ -459,7 +454,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 		}
 		var end func()
 
-		telemetry.MainSpan(ctx).AddAttributes(traceTags...)
+		telemetry.MainSpan(ctx).SetAttributes(traceTags...)
 		ctx, end = telemetry.SpanFromMain(ctx, "router.handle")
 
 		if err := rc.Handler(ctx, responseWriter.WrappedResponseWriter(), req); err != nil {
@@ -555,6 +550,18 @@ func (r *Router) PUTv2(h service.HandlerFuncV2, cfg ...service.HandlerConfigPara
 	return &rc
 }
 
+func (r *Router) PATCHv2(h service.HandlerFuncV2, cfg ...service.HandlerConfigParam) *service.HandlerConfig {
+	var rc service.HandlerConfig
+	rbacCheckers, handler := h()
+	rc.Handler = handler
+	rc.RbacCheckers = rbacCheckers
+	rc.Method = "PATCH"
+	for _, c := range cfg {
+		c(&rc)
+	}
+	return &rc
+}
+
 // POST will set given handler only for POST request
 func (r *Router) POST(h service.HandlerFunc, cfg ...service.HandlerConfigParam) *service.HandlerConfig {
 	var rc service.HandlerConfig
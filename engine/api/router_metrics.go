@@ -20,9 +20,6 @@ import (
 	"context"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk/telemetry"
@@ -32,105 +29,105 @@ import (
 func InitRouterMetrics(ctx context.Context, s service.NamedService) error {
 	var err error
 	onceMetrics.Do(func() {
-		Errors = stats.Int64(
+		Errors = telemetry.Int64(
 			"cds/router_errors",
 			"number of errors",
-			stats.UnitDimensionless)
-		Hits = stats.Int64(
+			telemetry.UnitDimensionless)
+		Hits = telemetry.Int64(
 			"cds/router_hits",
 			"number of hits",
-			stats.UnitDimensionless)
-		WebSocketClients = stats.Int64(
+			telemetry.UnitDimensionless)
+		WebSocketClients = telemetry.Int64(
 			"cds/websocket_clients",
 			"number of websocket clients",
-			stats.UnitDimensionless)
-		WebSocketV2Clients = stats.Int64(
+			telemetry.UnitDimensionless)
+		WebSocketV2Clients = telemetry.Int64(
 			"cds/websocket_v2_clients",
 			"number of websocket v2 clients",
-			stats.UnitDimensionless)
-		WebSocketEvents = stats.Int64(
+			telemetry.UnitDimensionless)
+		WebSocketEvents = telemetry.Int64(
 			"cds/websocket_events",
 			"number of websocket events",
-			stats.UnitDimensionless)
-		WebSocketV2Events = stats.Int64(
+			telemetry.UnitDimensionless)
+		WebSocketV2Events = telemetry.Int64(
 			"cds/websocket_v2_events",
 			"number of websocket v2 events",
-			stats.UnitDimensionless)
-		ServerRequestCount = stats.Int64(
+			telemetry.UnitDimensionless)
+		ServerRequestCount = telemetry.Int64(
 			"cds/http/server/request_count",
 			"Number of HTTP requests started",
-			stats.UnitDimensionless)
-		ServerRequestBytes = stats.Int64(
+			telemetry.UnitDimensionless)
+		ServerRequestBytes = telemetry.Int64(
 			"cds/http/server/request_bytes",
 			"HTTP request body size if set as ContentLength (uncompressed)",
-			stats.UnitBytes)
-		ServerResponseBytes = stats.Int64(
+			telemetry.UnitBytes)
+		ServerResponseBytes = telemetry.Int64(
 			"cds/http/server/response_bytes",
 			"HTTP response body size (uncompressed)",
-			stats.UnitBytes)
-		ServerLatency = stats.Float64(
+			telemetry.UnitBytes)
+		ServerLatency = telemetry.Float64(
 			"cds/http/server/latency",
 			"End-to-end latency",
-			stats.UnitMilliseconds)
+			telemetry.UnitMilliseconds)
 
 		tagServiceType := telemetry.MustNewKey(telemetry.TagServiceType)
 		tagServiceName := telemetry.MustNewKey(telemetry.TagServiceName)
 
-		ServerRequestCountView := &view.View{
+		ServerRequestCountView := &telemetry.View{
 			Name:        "cds/http/server/request_count_by_handler",
 			Description: "Count of HTTP requests started",
 			Measure:     ServerRequestCount,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
-			Aggregation: view.Count(),
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
+			Aggregation: telemetry.Count(),
 		}
 
-		ServerRequestBytesView := &view.View{
+		ServerRequestBytesView := &telemetry.View{
 			Name:        "cds/http/server/request_bytes_by_handler",
 			Description: "Size distribution of HTTP request body",
 			Measure:     ServerRequestBytes,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
 			Aggregation: telemetry.DefaultSizeDistribution,
 		}
 
-		ServerResponseBytesView := &view.View{
+		ServerResponseBytesView := &telemetry.View{
 			Name:        "cds/http/server/response_bytes_by_handler",
 			Description: "Size distribution of HTTP response body",
 			Measure:     ServerResponseBytes,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
 			Aggregation: telemetry.DefaultSizeDistribution,
 		}
 
-		ServerLatencyView := &view.View{
+		ServerLatencyView := &telemetry.View{
 			Name:        "cds/http/server/latency_by_handler",
 			Description: "Latency distribution of HTTP requests",
 			Measure:     ServerLatency,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Handler)},
 			Aggregation: telemetry.DefaultLatencyDistribution,
 		}
 
-		ServerRequestCountByMethod := &view.View{
+		ServerRequestCountByMethod := &telemetry.View{
 			Name:        "cds/http/server/request_count_by_method_and_handler",
 			Description: "Server request count by HTTP method",
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Method), telemetry.MustNewKey(telemetry.Handler)},
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.Method), telemetry.MustNewKey(telemetry.Handler)},
 			Measure:     ServerRequestCount,
-			Aggregation: view.Count(),
+			Aggregation: telemetry.Count(),
 		}
 
-		ServerResponseCountByStatusCode := &view.View{
+		ServerResponseCountByStatusCode := &telemetry.View{
 			Name:        "cds/http/server/response_count_by_status_code_and_handler",
 			Description: "Server response count by status code",
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.StatusCode), telemetry.MustNewKey(telemetry.Handler)},
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, telemetry.MustNewKey(telemetry.StatusCode), telemetry.MustNewKey(telemetry.Handler)},
 			Measure:     ServerLatency,
-			Aggregation: view.Count(),
+			Aggregation: telemetry.Count(),
 		}
 
 		err = telemetry.RegisterView(ctx,
-			telemetry.NewViewCount("cds/http/router/router_errors", Errors, []tag.Key{tagServiceType, tagServiceName}),
-			telemetry.NewViewCount("cds/http/router/router_hits", Hits, []tag.Key{tagServiceType, tagServiceName}),
-			telemetry.NewViewLast("cds/http/router/websocket_clients", WebSocketClients, []tag.Key{tagServiceType, tagServiceName}),
-			telemetry.NewViewLast("cds/http/router/websocket_v2_clients", WebSocketV2Clients, []tag.Key{tagServiceType, tagServiceName}),
-			telemetry.NewViewCount("cds/http/router/websocket_events", WebSocketEvents, []tag.Key{tagServiceType, tagServiceName}),
-			telemetry.NewViewCount("cds/http/router/websocket_v2_events", WebSocketV2Events, []tag.Key{tagServiceType, tagServiceName}),
+			telemetry.NewViewCount("cds/http/router/router_errors", Errors, []telemetry.TagKey{tagServiceType, tagServiceName}),
+			telemetry.NewViewCount("cds/http/router/router_hits", Hits, []telemetry.TagKey{tagServiceType, tagServiceName}),
+			telemetry.NewViewLast("cds/http/router/websocket_clients", WebSocketClients, []telemetry.TagKey{tagServiceType, tagServiceName}),
+			telemetry.NewViewLast("cds/http/router/websocket_v2_clients", WebSocketV2Clients, []telemetry.TagKey{tagServiceType, tagServiceName}),
+			telemetry.NewViewCount("cds/http/router/websocket_events", WebSocketEvents, []telemetry.TagKey{tagServiceType, tagServiceName}),
+			telemetry.NewViewCount("cds/http/router/websocket_v2_events", WebSocketV2Events, []telemetry.TagKey{tagServiceType, tagServiceName}),
 			ServerRequestCountView,
 			ServerRequestBytesView,
 			ServerResponseBytesView,
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
@@ -11,6 +12,7 @@ import (
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/driver/builtin"
 	hatch "github.com/ovh/cds/engine/api/authentication/hatchery"
 	"github.com/ovh/cds/engine/api/hatchery"
 	"github.com/ovh/cds/engine/api/services"
@@ -294,6 +296,63 @@ func (api *API) handleAuthMiddlewareUserConsumer(ctx context.Context, w http.Res
 	return ctx, nil
 }
 
+// authSCIMMiddleware authenticates the identity provider calling the SCIM routes. It gives the signin token
+// of a builtin consumer with the SCIM scope as bearer token, so there is no session.
+func (api *API) authSCIMMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
+	ctx, end := telemetry.Span(ctx, "router.authSCIMMiddleware")
+	defer end()
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return ctx, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+	consumerID, _, err := builtin.CheckSigninConsumerToken(token)
+	if err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+
+	consumer, err := authentication.LoadUserConsumerByID(ctx, api.mustDB(), consumerID,
+		authentication.LoadUserConsumerOptions.WithAuthentifiedUser)
+	if err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	ctx = context.WithValue(ctx, cdslog.AuthUserID, consumer.AuthConsumerUser.AuthentifiedUserID)
+	service.SetTracker(w, cdslog.AuthUserID, consumer.AuthConsumerUser.AuthentifiedUserID)
+	ctx = context.WithValue(ctx, cdslog.AuthConsumerID, consumer.ID)
+	service.SetTracker(w, cdslog.AuthConsumerID, consumer.ID)
+	ctx = context.WithValue(ctx, cdslog.AuthUsername, consumer.AuthConsumerUser.AuthentifiedUser.Username)
+	service.SetTracker(w, cdslog.AuthUsername, consumer.AuthConsumerUser.AuthentifiedUser.Username)
+
+	if consumer.Type != sdk.ConsumerBuiltin {
+		return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is not a builtin consumer", consumer.ID)
+	}
+	if consumer.Disabled {
+		return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is disabled", consumer.ID)
+	}
+	if consumer.AuthConsumerUser.AuthentifiedUser.Disabled {
+		return ctx, sdk.WrapError(sdk.ErrUserDisabled, "user (%s) is disabled", consumer.AuthConsumerUser.AuthentifiedUserID)
+	}
+	if _, err := builtin.CheckSigninConsumerTokenIssuedAt(ctx, token, consumer); err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+
+	// The SCIM scope must be given explicitly, a consumer with all scopes can't be used
+	var hasScope bool
+	for _, s := range consumer.AuthConsumerUser.ScopeDetails {
+		if s.Scope == sdk.AuthConsumerScopeSCIM {
+			hasScope = true
+			break
+		}
+	}
+	if !hasScope {
+		return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) doesn't have the %s scope", consumer.ID, sdk.AuthConsumerScopeSCIM)
+	}
+
+	ctx = context.WithValue(ctx, contextUserConsumer, consumer)
+
+	return api.rbacMiddleware(ctx, w, req, rc)
+}
+
 func (api *API) xsrfMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
 	ctx, end := telemetry.Span(ctx, "router.xsrfMiddleware")
 	defer end()
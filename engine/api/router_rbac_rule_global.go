@@ -54,3 +54,11 @@ func (api *API) globalHatcheryManage(ctx context.Context, _ map[string]string) e
 func (api *API) globalPluginManage(ctx context.Context, _ map[string]string) error {
 	return api.hasGlobalRole(ctx, sdk.GlobalRoleManagePlugin)
 }
+
+func (api *API) globalUserManage(ctx context.Context, _ map[string]string) error {
+	return api.hasGlobalRole(ctx, sdk.GlobalRoleManageUser)
+}
+
+func (api *API) globalGroupManage(ctx context.Context, _ map[string]string) error {
+	return api.hasGlobalRole(ctx, sdk.GlobalRoleManageGroup)
+}
@@ -53,3 +53,12 @@ func (api *API) jobRunUpdate(ctx context.Context, vars map[string]string) error
 	}
 	return sdk.WithStack(sdk.ErrForbidden)
 }
+
+// jobRunDebug only the worker of the job can wait for debug sessions
+func (api *API) jobRunDebug(ctx context.Context, vars map[string]string) error {
+	work := getWorker(ctx)
+	if work == nil || work.JobRunID != vars["runJobID"] {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	return nil
+}
@@ -16,3 +16,11 @@ func (api *API) isCurrentUser(ctx context.Context, vars map[string]string) error
 	}
 	return sdk.WithStack(sdk.ErrForbidden)
 }
+
+// isCurrentUserOrPermissionManager return nil if the current user is the user of the route, or can manage permissions
+func (api *API) isCurrentUserOrPermissionManager(ctx context.Context, vars map[string]string) error {
+	if err := api.isCurrentUser(ctx, vars); err == nil {
+		return nil
+	}
+	return api.hasGlobalRole(ctx, sdk.GlobalRoleManagePermission)
+}
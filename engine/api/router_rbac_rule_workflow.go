@@ -85,3 +85,8 @@ func (api *API) hasRoleOnWorkflow(ctx context.Context, vars map[string]string, r
 func (api *API) workflowTrigger(ctx context.Context, vars map[string]string) error {
 	return api.hasRoleOnWorkflow(ctx, vars, sdk.WorkflowRoleTrigger)
 }
+
+// workflowDebug return nil if the current AuthUserConsumer have the WorkflowRoleDebug on current workflow
+func (api *API) workflowDebug(ctx context.Context, vars map[string]string) error {
+	return api.hasRoleOnWorkflow(ctx, vars, sdk.WorkflowRoleDebug)
+}
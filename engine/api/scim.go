@@ -0,0 +1,893 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// scimError writes the error returned by a SCIM handler with the SCIM error schema.
+func scimError(ctx context.Context, w http.ResponseWriter, err *error) {
+	if *err == nil {
+		return
+	}
+	httpErr := sdk.ExtractHTTPError(*err)
+	if httpErr.Status < 500 {
+		log.Info(ctx, "%s", *err)
+	} else {
+		log.ErrorWithStackTrace(sdk.ContextWithStacktrace(ctx, *err), *err)
+	}
+	e := sdk.SCIMError{
+		Schemas: []string{sdk.SCIMSchemaError},
+		Status:  strconv.Itoa(httpErr.Status),
+		Detail:  httpErr.Message,
+	}
+	if httpErr.Status == http.StatusConflict {
+		e.ScimType = sdk.SCIMErrorTypeUniqueness
+	}
+	*err = scimWriteJSON(w, e, httpErr.Status)
+}
+
+func scimWriteJSON(w http.ResponseWriter, data interface{}, status int) error {
+	w.Header().Set("Content-Type", "application/scim+json")
+	return service.WriteJSON(w, data, status)
+}
+
+// scimMaxResults is the maximum count of resources returned by a SCIM list request.
+const scimMaxResults = 1000
+
+// scimListRequest returns the filter, the offset and the limit from the filter, startIndex and count query parameters.
+func scimListRequest(req *http.Request) (sdk.SCIMFilter, int, int, error) {
+	var filter sdk.SCIMFilter
+	if f := req.FormValue("filter"); f != "" {
+		var err error
+		filter, err = sdk.ParseSCIMFilter(f)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	offset := 0
+	if s := req.FormValue("startIndex"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, 0, 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid startIndex %q", s)
+		}
+		if i > 1 {
+			offset = i - 1
+		}
+	}
+	limit := scimMaxResults
+	if s := req.FormValue("count"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, 0, 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid count %q", s)
+		}
+		if i >= 0 && i < limit {
+			limit = i
+		}
+	}
+	return filter, offset, limit, nil
+}
+
+func scimListResponse[T any](total int64, offset int, resources []T) sdk.SCIMListResponse {
+	res := sdk.SCIMListResponse{
+		Schemas:      []string{sdk.SCIMSchemaListResponse},
+		TotalResults: int(total),
+		StartIndex:   offset + 1,
+		ItemsPerPage: len(resources),
+		Resources:    make([]interface{}, len(resources)),
+	}
+	for i := range resources {
+		res.Resources[i] = resources[i]
+	}
+	return res
+}
+
+func (api *API) getSCIMServiceProviderConfigHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+			type supported struct {
+				Supported  bool `json:"supported"`
+				MaxResults int  `json:"maxResults,omitempty"`
+			}
+			return scimWriteJSON(w, map[string]interface{}{
+				"schemas":          []string{sdk.SCIMSchemaServiceProviderConfig},
+				"documentationUri": "https://ovh.github.io/cds/docs/integrations/scim/",
+				"patch":            supported{Supported: true},
+				"bulk":             supported{},
+				"filter":           supported{Supported: true, MaxResults: scimMaxResults},
+				"changePassword":   supported{},
+				"sort":             supported{},
+				"etag":             supported{},
+				"authenticationSchemes": []map[string]interface{}{{
+					"type":        "oauthbearertoken",
+					"name":        "CDS builtin consumer",
+					"description": "Signin token of a builtin consumer with the SCIM scope",
+					"primary":     true,
+				}},
+			}, http.StatusOK)
+		}
+}
+
+func (api *API) getSCIMResourceTypesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+			resourceTypes := []interface{}{
+				map[string]interface{}{
+					"schemas":  []string{sdk.SCIMSchemaResourceType},
+					"id":       sdk.SCIMResourceTypeUser,
+					"name":     sdk.SCIMResourceTypeUser,
+					"endpoint": "/Users",
+					"schema":   sdk.SCIMSchemaUser,
+					"schemaExtensions": []map[string]interface{}{
+						{"schema": sdk.SCIMSchemaEnterpriseUser, "required": false},
+					},
+				},
+				map[string]interface{}{
+					"schemas":  []string{sdk.SCIMSchemaResourceType},
+					"id":       sdk.SCIMResourceTypeGroup,
+					"name":     sdk.SCIMResourceTypeGroup,
+					"endpoint": "/Groups",
+					"schema":   sdk.SCIMSchemaGroup,
+				},
+			}
+			return scimWriteJSON(w, sdk.SCIMListResponse{
+				Schemas:      []string{sdk.SCIMSchemaListResponse},
+				TotalResults: len(resourceTypes),
+				StartIndex:   1,
+				ItemsPerPage: len(resourceTypes),
+				Resources:    resourceTypes,
+			}, http.StatusOK)
+		}
+}
+
+// scimUser returns the user as a SCIM resource, with the groups it is a member of through SCIM.
+func (api *API) scimUser(u sdk.AuthentifiedUser, groups []sdk.Group) sdk.SCIMUser {
+	active := !u.Disabled
+	created := u.Created
+	res := sdk.SCIMUser{
+		Schemas:     []string{sdk.SCIMSchemaUser},
+		ID:          u.ID,
+		ExternalID:  u.ExternalID,
+		UserName:    u.Username,
+		Name:        &sdk.SCIMUserName{Formatted: u.Fullname},
+		DisplayName: u.Fullname,
+		Active:      &active,
+		Meta: &sdk.SCIMMeta{
+			ResourceType: sdk.SCIMResourceTypeUser,
+			Created:      &created,
+			Location:     api.Config.URL.API + "/scim/v2/Users/" + u.ID,
+		},
+	}
+	for _, c := range u.Contacts {
+		if c.Type == sdk.UserContactTypeEmail {
+			res.Emails = append(res.Emails, sdk.SCIMMultiValued{Value: c.Value, Primary: c.Primary})
+		}
+	}
+	for _, g := range groups {
+		res.Groups = append(res.Groups, sdk.SCIMMultiValued{Value: strconv.FormatInt(g.ID, 10), Display: g.Name})
+	}
+	if u.Organization != "" {
+		res.Schemas = append(res.Schemas, sdk.SCIMSchemaEnterpriseUser)
+		res.Enterprise = &sdk.SCIMEnterpriseUser{Organization: u.Organization}
+	}
+	return res
+}
+
+// scimUsers returns the given users as SCIM resources.
+func (api *API) scimUsers(ctx context.Context, db gorp.SqlExecutor, us sdk.AuthentifiedUsers) ([]sdk.SCIMUser, error) {
+	ids := make([]string, len(us))
+	for i := range us {
+		ids[i] = us[i].ID
+	}
+	links, err := group.LoadLinksGroupUserForUserIDs(ctx, db, ids)
+	if err != nil {
+		return nil, err
+	}
+	gs, err := group.LoadAllByIDs(ctx, db, links.ToGroupIDs())
+	if err != nil {
+		return nil, err
+	}
+	mGroups := gs.ToMap()
+	userGroups := make(map[string][]sdk.Group)
+	for _, l := range links {
+		if l.Source != sdk.ConsumerSCIM {
+			continue
+		}
+		if g, ok := mGroups[l.GroupID]; ok {
+			userGroups[l.AuthentifiedUserID] = append(userGroups[l.AuthentifiedUserID], g)
+		}
+	}
+
+	res := make([]sdk.SCIMUser, len(us))
+	for i := range us {
+		res[i] = api.scimUser(us[i], userGroups[us[i].ID])
+	}
+	return res, nil
+}
+
+func (api *API) scimLoadUser(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.AuthentifiedUser, *sdk.SCIMUser, error) {
+	u, err := user.LoadByID(ctx, db, id, user.LoadOptions.WithContacts, user.LoadOptions.WithOrganization)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := api.scimUsers(ctx, db, sdk.AuthentifiedUsers{*u})
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, &res[0], nil
+}
+
+func (api *API) getSCIMUsersHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			filter, offset, limit, err := scimListRequest(req)
+			if err != nil {
+				return err
+			}
+			total, err := user.CountBySCIMFilter(ctx, api.mustDB(), filter)
+			if err != nil {
+				return err
+			}
+			us, err := user.LoadAllBySCIMFilter(ctx, api.mustDB(), filter, offset, limit, user.LoadOptions.WithContacts, user.LoadOptions.WithOrganization)
+			if err != nil {
+				return err
+			}
+			resources, err := api.scimUsers(ctx, api.mustDB(), us)
+			if err != nil {
+				return err
+			}
+			return scimWriteJSON(w, scimListResponse(total, offset, resources), http.StatusOK)
+		}
+}
+
+func (api *API) getSCIMUserHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			_, res, err := api.scimLoadUser(ctx, api.mustDB(), mux.Vars(req)["scimUserID"])
+			if err != nil {
+				return err
+			}
+			return scimWriteJSON(w, res, http.StatusOK)
+		}
+}
+
+func (api *API) postSCIMUserHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			var data sdk.SCIMUser
+			if err := service.UnmarshalBody(req, &data); err != nil {
+				return err
+			}
+
+			newUser := sdk.AuthentifiedUser{
+				Ring:       sdk.UserRingUser,
+				Username:   data.UserName,
+				Fullname:   data.Fullname(),
+				Disabled:   !data.IsActive(),
+				ExternalID: data.ExternalID,
+			}
+			if err := newUser.IsValid(); err != nil {
+				return err
+			}
+			email := data.PrimaryEmail()
+			if !sdk.IsValidEmail(email) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing or invalid email")
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			existingUser, err := user.LoadByUsername(ctx, tx, newUser.Username)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+				return err
+			}
+			if existingUser != nil {
+				return sdk.NewErrorFrom(sdk.ErrConflictData, "user %q already exists", newUser.Username)
+			}
+			existingEmail, err := user.LoadContactByTypeAndValue(ctx, tx, sdk.UserContactTypeEmail, email)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if existingEmail != nil {
+				return sdk.NewErrorFrom(sdk.ErrConflictData, "email %q is already used", email)
+			}
+
+			if err := user.Insert(ctx, tx, &newUser); err != nil {
+				return err
+			}
+			if err := user.InsertContact(ctx, tx, &sdk.UserContact{
+				Primary:  true,
+				Type:     sdk.UserContactTypeEmail,
+				UserID:   newUser.ID,
+				Value:    email,
+				Verified: true,
+			}); err != nil {
+				return err
+			}
+
+			org := data.Organization()
+			if org == "" {
+				org = api.Config.Auth.SCIM.Organization
+			}
+			if err := api.userSetOrganization(ctx, tx, &newUser, org); err != nil {
+				return err
+			}
+
+			if !api.Config.Auth.DisableAddUserInDefaultGroup {
+				if err := group.CheckUserInDefaultGroup(ctx, tx, newUser.ID); err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			log.Info(ctx, "postSCIMUserHandler> user %s provisioned by %s", newUser.Username, getUserConsumer(ctx).GetUsername())
+			event_v2.PublishUserEvent(ctx, api.Cache, sdk.EventUserCreated, newUser)
+
+			_, res, err := api.scimLoadUser(ctx, api.mustDB(), newUser.ID)
+			if err != nil {
+				return err
+			}
+			return scimWriteJSON(w, res, http.StatusCreated)
+		}
+}
+
+// scimUpdateUser updates the user from the given SCIM resource. A user deactivated by the identity provider
+// is disabled, as well as its consumers, and all its sessions are revoked.
+func (api *API) scimUpdateUser(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, oldUser *sdk.AuthentifiedUser, data sdk.SCIMUser) (*sdk.AuthentifiedUser, error) {
+	newUser := *oldUser
+	newUser.Username = data.UserName
+	newUser.Fullname = data.Fullname()
+	newUser.ExternalID = data.ExternalID
+	if err := newUser.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if newUser.Username != oldUser.Username {
+		existingUser, err := user.LoadByUsername(ctx, tx, newUser.Username)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+			return nil, err
+		}
+		if existingUser != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrConflictData, "user %q already exists", newUser.Username)
+		}
+	}
+
+	deprovisioned := !data.IsActive() && !oldUser.Disabled
+	reactivated := data.IsActive() && oldUser.Disabled
+	if deprovisioned {
+		if oldUser.ID == getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID {
+			return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "can't deactivate the user of the SCIM consumer")
+		}
+		if err := api.checkUserCanBeDisabled(ctx, tx, &newUser); err != nil {
+			return nil, err
+		}
+	}
+	newUser.Disabled = !data.IsActive()
+
+	if err := user.Update(ctx, tx, &newUser); err != nil {
+		if e, ok := sdk.Cause(err).(*pq.Error); ok && e.Code == gorpmapper.ViolateUniqueKeyPGCode {
+			return nil, sdk.NewErrorWithStack(e, sdk.ErrUsernamePresent)
+		}
+		return nil, err
+	}
+
+	if email := data.PrimaryEmail(); email != "" {
+		if err := api.scimSetPrimaryEmail(ctx, tx, &newUser, email); err != nil {
+			return nil, err
+		}
+	}
+
+	if org := data.Organization(); org != "" && org != oldUser.Organization {
+		if err := api.userSetOrganization(ctx, tx, &newUser, org); err != nil {
+			return nil, err
+		}
+	}
+
+	if deprovisioned {
+		if err := authentication.ConsumerDeprovisionUser(ctx, tx, newUser.ID); err != nil {
+			return nil, err
+		}
+		if err := revokeUserSessions(ctx, tx, newUser.ID); err != nil {
+			return nil, err
+		}
+		log.Info(ctx, "scimUpdateUser> user %s deprovisioned by %s", newUser.Username, getUserConsumer(ctx).GetUsername())
+	}
+	if reactivated {
+		if err := authentication.ConsumerRestoreDeprovisionedUser(ctx, tx, newUser.ID); err != nil {
+			return nil, err
+		}
+		log.Info(ctx, "scimUpdateUser> user %s reactivated by %s", newUser.Username, getUserConsumer(ctx).GetUsername())
+	}
+
+	return &newUser, nil
+}
+
+func (api *API) scimSetPrimaryEmail(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, u *sdk.AuthentifiedUser, email string) error {
+	primary := u.Contacts.Filter(sdk.UserContactTypeEmail).Primary()
+	if primary != nil && primary.Value == email {
+		return nil
+	}
+	if !sdk.IsValidEmail(email) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid email %q", email)
+	}
+	existingEmail, err := user.LoadContactByTypeAndValue(ctx, tx, sdk.UserContactTypeEmail, email)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if existingEmail != nil && existingEmail.UserID != u.ID {
+		return sdk.NewErrorFrom(sdk.ErrConflictData, "email %q is already used", email)
+	}
+
+	if primary == nil {
+		return user.InsertContact(ctx, tx, &sdk.UserContact{
+			Primary:  true,
+			Type:     sdk.UserContactTypeEmail,
+			UserID:   u.ID,
+			Value:    email,
+			Verified: true,
+		})
+	}
+	c := *primary
+	c.Value = email
+	c.Verified = true
+	return user.UpdateContact(ctx, tx, &c)
+}
+
+func (api *API) putSCIMUserHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			var data sdk.SCIMUser
+			if err := service.UnmarshalBody(req, &data); err != nil {
+				return err
+			}
+			return api.scimReplaceUser(ctx, w, mux.Vars(req)["scimUserID"], func(sdk.SCIMUser) (sdk.SCIMUser, error) {
+				return data, nil
+			})
+		}
+}
+
+func (api *API) patchSCIMUserHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			var patch sdk.SCIMPatchRequest
+			if err := service.UnmarshalBody(req, &patch); err != nil {
+				return err
+			}
+			return api.scimReplaceUser(ctx, w, mux.Vars(req)["scimUserID"], func(current sdk.SCIMUser) (sdk.SCIMUser, error) {
+				return current, patch.Apply(&current)
+			})
+		}
+}
+
+func (api *API) deleteSCIMUserHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalUserManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			// Users are never deleted, to keep the history of what they did: a deleted user is deactivated
+			if err := api.scimReplaceUser(ctx, nil, mux.Vars(req)["scimUserID"], func(current sdk.SCIMUser) (sdk.SCIMUser, error) {
+				active := false
+				current.Active = &active
+				return current, nil
+			}); err != nil {
+				return err
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+}
+
+// scimReplaceUser updates a user from the SCIM resource computed from its current value, then writes it if w is given.
+func (api *API) scimReplaceUser(ctx context.Context, w http.ResponseWriter, id string, update func(sdk.SCIMUser) (sdk.SCIMUser, error)) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	oldUser, current, err := api.scimLoadUser(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	data, err := update(*current)
+	if err != nil {
+		return err
+	}
+	newUser, err := api.scimUpdateUser(ctx, tx, oldUser, data)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	event_v2.PublishUserEvent(ctx, api.Cache, sdk.EventUserUpdated, *newUser)
+
+	if w == nil {
+		return nil
+	}
+	_, res, err := api.scimLoadUser(ctx, api.mustDB(), newUser.ID)
+	if err != nil {
+		return err
+	}
+	return scimWriteJSON(w, res, http.StatusOK)
+}
+
+// scimGroup returns the group as a SCIM resource. Only the memberships provisioned by SCIM are listed, except the one
+// of the user of the SCIM consumer that is made admin of the groups it creates.
+func (api *API) scimGroup(ctx context.Context, g sdk.Group) sdk.SCIMGroup {
+	id := strconv.FormatInt(g.ID, 10)
+	res := sdk.SCIMGroup{
+		Schemas:     []string{sdk.SCIMSchemaGroup},
+		ID:          id,
+		DisplayName: g.Name,
+		Meta: &sdk.SCIMMeta{
+			ResourceType: sdk.SCIMResourceTypeGroup,
+			Location:     api.Config.URL.API + "/scim/v2/Groups/" + id,
+		},
+	}
+	ownerID := getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID
+	for _, m := range g.Members {
+		if m.ID == ownerID || m.Source != sdk.ConsumerSCIM {
+			continue
+		}
+		res.Members = append(res.Members, sdk.SCIMMultiValued{Value: m.ID, Display: m.Username})
+	}
+	return res
+}
+
+// scimLoadGroup returns the group with the given SCIM id, only the groups managed by SCIM are found.
+func (api *API) scimLoadGroup(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.Group, error) {
+	groupID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return group.LoadSCIMByID(ctx, db, groupID, group.LoadOptions.WithMembers)
+}
+
+func (api *API) getSCIMGroupsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalGroupManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			filter, offset, limit, err := scimListRequest(req)
+			if err != nil {
+				return err
+			}
+			ownerID := getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID
+			total, err := group.CountBySCIMFilter(ctx, api.mustDB(), ownerID, filter)
+			if err != nil {
+				return err
+			}
+			gs, err := group.LoadAllBySCIMFilter(ctx, api.mustDB(), ownerID, filter, offset, limit, group.LoadOptions.WithMembers)
+			if err != nil {
+				return err
+			}
+			resources := make([]sdk.SCIMGroup, len(gs))
+			for i := range gs {
+				resources[i] = api.scimGroup(ctx, gs[i])
+			}
+			return scimWriteJSON(w, scimListResponse(total, offset, resources), http.StatusOK)
+		}
+}
+
+func (api *API) getSCIMGroupHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalGroupManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			g, err := api.scimLoadGroup(ctx, api.mustDB(), mux.Vars(req)["scimGroupID"])
+			if err != nil {
+				return err
+			}
+			return scimWriteJSON(w, api.scimGroup(ctx, *g), http.StatusOK)
+		}
+}
+
+func (api *API) postSCIMGroupHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalGroupManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			var data sdk.SCIMGroup
+			if err := service.UnmarshalBody(req, &data); err != nil {
+				return err
+			}
+			newGroup := sdk.Group{Name: data.DisplayName}
+			if err := newGroup.IsValid(); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			existingGroup, err := group.LoadByName(ctx, tx, newGroup.Name)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if existingGroup != nil {
+				return sdk.NewErrorFrom(sdk.ErrConflictData, "group %q already exists", newGroup.Name)
+			}
+
+			// The user of the SCIM consumer is the admin of the group, members are managed by the identity provider
+			owner, err := user.LoadByID(ctx, tx, getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID, user.LoadOptions.WithOrganization)
+			if err != nil {
+				return err
+			}
+			if err := group.Create(ctx, tx, &newGroup, owner); err != nil {
+				return err
+			}
+			// The membership of the owner marks the group as managed by SCIM
+			ownerLink, err := group.LoadLinkGroupUserForGroupIDAndUserID(ctx, tx, newGroup.ID, owner.ID)
+			if err != nil {
+				return err
+			}
+			ownerLink.Source = sdk.ConsumerSCIM
+			if err := group.UpdateLinkGroupUser(ctx, tx, ownerLink); err != nil {
+				return err
+			}
+			if err := api.scimSyncGroupMembers(ctx, tx, &newGroup, data.Members); err != nil {
+				return err
+			}
+
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			g, err := api.scimLoadGroup(ctx, api.mustDB(), strconv.FormatInt(newGroup.ID, 10))
+			if err != nil {
+				return err
+			}
+			return scimWriteJSON(w, api.scimGroup(ctx, *g), http.StatusCreated)
+		}
+}
+
+// scimSyncGroupMembers sets the members provisioned by SCIM of the group. The memberships added by hand or by an auth
+// driver are kept, as well as the one of the user of the SCIM consumer. Removed members have the group invalidated
+// in their consumers, as when they are removed by hand.
+func (api *API) scimSyncGroupMembers(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, g *sdk.Group, members []sdk.SCIMMultiValued) error {
+	ownerID := getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID
+
+	wanted := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		if m.Value != ownerID {
+			wanted[m.Value] = struct{}{}
+		}
+	}
+
+	links, err := group.LoadLinksGroupUserForGroupIDs(ctx, tx, []int64{g.ID})
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]group.LinkGroupUser, len(links))
+	var removedIDs []string
+	for _, l := range links {
+		existing[l.AuthentifiedUserID] = l
+		if _, ok := wanted[l.AuthentifiedUserID]; !ok && l.Source == sdk.ConsumerSCIM && l.AuthentifiedUserID != ownerID {
+			removedIDs = append(removedIDs, l.AuthentifiedUserID)
+		}
+	}
+	var addedIDs []string
+	for id := range wanted {
+		if _, ok := existing[id]; !ok {
+			addedIDs = append(addedIDs, id)
+		}
+	}
+
+	removed, err := user.LoadAllByIDs(ctx, tx, removedIDs)
+	if err != nil {
+		return err
+	}
+	for i := range removed {
+		l := existing[removed[i].ID]
+		if err := group.DeleteLinkGroupUser(tx, &l); err != nil {
+			return err
+		}
+		delete(existing, removed[i].ID)
+		if err := authentication.ConsumerInvalidateGroupForUser(ctx, tx, g, &removed[i]); err != nil {
+			return err
+		}
+	}
+
+	added, err := user.LoadAllByIDs(ctx, tx, addedIDs)
+	if err != nil {
+		return err
+	}
+	if len(added) != len(addedIDs) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown member in %v", addedIDs)
+	}
+	for i := range added {
+		l := group.LinkGroupUser{GroupID: g.ID, AuthentifiedUserID: added[i].ID, Source: sdk.ConsumerSCIM}
+		if err := group.InsertLinkGroupUser(ctx, tx, &l); err != nil {
+			return err
+		}
+		existing[added[i].ID] = l
+		if err := authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, tx, g.ID, added[i].ID); err != nil {
+			return err
+		}
+	}
+
+	// A group needs an admin, the user of the SCIM consumer becomes admin if no admin is left
+	var adminFound bool
+	for _, l := range existing {
+		if l.Admin {
+			adminFound = true
+			break
+		}
+	}
+	if !adminFound {
+		l, ok := existing[ownerID]
+		if !ok {
+			l = group.LinkGroupUser{GroupID: g.ID, AuthentifiedUserID: ownerID, Admin: true, Source: sdk.ConsumerSCIM}
+			if err := group.InsertLinkGroupUser(ctx, tx, &l); err != nil {
+				return err
+			}
+		} else {
+			l.Admin = true
+			if err := group.UpdateLinkGroupUser(ctx, tx, &l); err != nil {
+				return err
+			}
+		}
+	}
+
+	return group.EnsureOrganization(ctx, tx, g)
+}
+
+func (api *API) putSCIMGroupHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalGroupManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			var data sdk.SCIMGroup
+			if err := service.UnmarshalBody(req, &data); err != nil {
+				return err
+			}
+			return api.scimReplaceGroup(ctx, w, mux.Vars(req)["scimGroupID"], func(sdk.SCIMGroup) (sdk.SCIMGroup, error) {
+				return data, nil
+			})
+		}
+}
+
+func (api *API) patchSCIMGroupHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalGroupManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			var patch sdk.SCIMPatchRequest
+			if err := service.UnmarshalBody(req, &patch); err != nil {
+				return err
+			}
+			return api.scimReplaceGroup(ctx, w, mux.Vars(req)["scimGroupID"], func(current sdk.SCIMGroup) (sdk.SCIMGroup, error) {
+				return current, patch.Apply(&current)
+			})
+		}
+}
+
+// scimReplaceGroup renames the group and sets its members from the SCIM resource computed from its current value.
+func (api *API) scimReplaceGroup(ctx context.Context, w http.ResponseWriter, id string, update func(sdk.SCIMGroup) (sdk.SCIMGroup, error)) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	g, err := api.scimLoadGroup(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	data, err := update(api.scimGroup(ctx, *g))
+	if err != nil {
+		return err
+	}
+
+	if data.DisplayName != g.Name {
+		existingGroup, err := group.LoadByName(ctx, tx, data.DisplayName)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existingGroup != nil {
+			return sdk.NewErrorFrom(sdk.ErrConflictData, "group %q already exists", data.DisplayName)
+		}
+		g.Name = data.DisplayName
+		if err := g.IsValid(); err != nil {
+			return err
+		}
+		if err := group.Update(ctx, tx, g); err != nil {
+			return err
+		}
+	}
+	if err := api.scimSyncGroupMembers(ctx, tx, g, data.Members); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	g, err = api.scimLoadGroup(ctx, api.mustDB(), id)
+	if err != nil {
+		return err
+	}
+	return scimWriteJSON(w, api.scimGroup(ctx, *g), http.StatusOK)
+}
+
+func (api *API) deleteSCIMGroupHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalGroupManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) (err error) {
+			defer scimError(ctx, w, &err)
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			g, err := api.scimLoadGroup(ctx, tx, mux.Vars(req)["scimGroupID"])
+			if err != nil {
+				return err
+			}
+			if group.IsDefaultGroupID(g.ID) {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "can't delete the default group")
+			}
+
+			projPerms, err := project.LoadPermissions(ctx, tx, g.ID)
+			if err != nil {
+				return err
+			}
+			if err := authentication.ConsumerRemoveGroup(ctx, tx, g); err != nil {
+				return err
+			}
+			if err := group.Delete(ctx, tx, g); err != nil {
+				return err
+			}
+
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			for _, pg := range projPerms {
+				event.PublishDeleteProjectPermission(ctx, &pg.Project, sdk.GroupPermission{Group: *g}, getUserConsumer(ctx))
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+}
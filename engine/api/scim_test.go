@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/driver/builtin"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_scimUserAndGroupProvisioning(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	admin, _ := assets.InsertAdminUser(t, db)
+	localConsumer, err := authentication.LoadUserConsumerByTypeAndUserID(context.TODO(), api.mustDB(), sdk.ConsumerLocal, admin.ID, authentication.LoadUserConsumerOptions.WithAuthentifiedUser)
+	require.NoError(t, err)
+	_, jws, err := builtin.NewConsumer(context.TODO(), db, builtin.NewConsumerOptions{
+		Name:     sdk.RandomString(10),
+		GroupIDs: admin.GetGroupIDs(),
+		Scopes:   sdk.NewAuthConsumerScopeDetails(sdk.AuthConsumerScopeSCIM),
+	}, localConsumer)
+	require.NoError(t, err)
+
+	do := func(method, uri string, body interface{}) *httptest.ResponseRecorder {
+		var r io.Reader
+		if body != nil {
+			bts, _ := json.Marshal(body)
+			r = bytes.NewReader(bts)
+		}
+		req, err := http.NewRequest(method, uri, r)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+jws)
+		req.Header.Set("Content-Type", "application/scim+json")
+		w := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// Provision a new user
+	username := sdk.RandomString(10)
+	scimUser := sdk.SCIMUser{
+		Schemas:    []string{sdk.SCIMSchemaUser},
+		ExternalID: "ext-" + username,
+		UserName:   username,
+		Emails:   []sdk.SCIMMultiValued{{Value: username + "@example.com", Primary: true}},
+	}
+	uri := api.Router.GetRouteV2("POST", api.postSCIMUserHandler, nil)
+	test.NotEmpty(t, uri)
+	w := do("POST", uri, scimUser)
+	require.Equal(t, 201, w.Code, w.Body.String())
+	var created sdk.SCIMUser
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.True(t, created.IsActive())
+	require.Equal(t, "ext-"+username, created.ExternalID)
+
+	// The same username is a conflict
+	w = do("POST", uri, scimUser)
+	require.Equal(t, 409, w.Code, w.Body.String())
+	var scimErr sdk.SCIMError
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scimErr))
+	require.Equal(t, sdk.SCIMErrorTypeUniqueness, scimErr.ScimType)
+
+	// The user can be found with a filter
+	uri = api.Router.GetRouteV2("GET", api.getSCIMUsersHandler, nil)
+	w = do("GET", uri+"?filter=userName+eq+%22"+username+"%22", nil)
+	require.Equal(t, 200, w.Code, w.Body.String())
+	var list sdk.SCIMListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 1, list.TotalResults)
+	w = do("GET", uri+"?filter=externalId+eq+%22EXT-"+username+"%22", nil)
+	require.Equal(t, 200, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 1, list.TotalResults)
+
+	// Provision a group with the user as member
+	uri = api.Router.GetRouteV2("POST", api.postSCIMGroupHandler, nil)
+	w = do("POST", uri, sdk.SCIMGroup{
+		Schemas:     []string{sdk.SCIMSchemaGroup},
+		DisplayName: sdk.RandomString(10),
+		Members:     []sdk.SCIMMultiValued{{Value: created.ID}},
+	})
+	require.Equal(t, 201, w.Code, w.Body.String())
+	var g sdk.SCIMGroup
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	require.Len(t, g.Members, 1)
+	require.Equal(t, created.ID, g.Members[0].Value)
+
+	// A member added by hand is kept when the identity provider replaces the members
+	manualUser, _ := assets.InsertLambdaUser(t, db)
+	groupID, err := strconv.ParseInt(g.ID, 10, 64)
+	require.NoError(t, err)
+	require.NoError(t, group.InsertLinkGroupUser(context.TODO(), db, &group.LinkGroupUser{GroupID: groupID, AuthentifiedUserID: manualUser.ID}))
+	uri = api.Router.GetRouteV2("PUT", api.putSCIMGroupHandler, map[string]string{"scimGroupID": g.ID})
+	w = do("PUT", uri, sdk.SCIMGroup{
+		Schemas:     []string{sdk.SCIMSchemaGroup},
+		DisplayName: g.DisplayName,
+	})
+	require.Equal(t, 200, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	require.Empty(t, g.Members)
+	_, err = group.LoadLinkGroupUserForGroupIDAndUserID(context.TODO(), db, groupID, manualUser.ID)
+	require.NoError(t, err)
+	_, err = group.LoadLinkGroupUserForGroupIDAndUserID(context.TODO(), db, groupID, created.ID)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	// A group that is not managed by SCIM can't be found
+	manualGroup := assets.InsertGroup(t, db)
+	uri = api.Router.GetRouteV2("GET", api.getSCIMGroupHandler, map[string]string{"scimGroupID": strconv.FormatInt(manualGroup.ID, 10)})
+	w = do("GET", uri, nil)
+	require.Equal(t, 404, w.Code, w.Body.String())
+
+	// Deactivating the user disables it
+	uri = api.Router.GetRouteV2("PATCH", api.patchSCIMUserHandler, map[string]string{"scimUserID": created.ID})
+	w = do("PATCH", uri, sdk.SCIMPatchRequest{
+		Schemas:    []string{sdk.SCIMSchemaPatchOp},
+		Operations: []sdk.SCIMPatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage("false")}},
+	})
+	require.Equal(t, 200, w.Code, w.Body.String())
+	u, err := user.LoadByID(context.TODO(), db, created.ID)
+	require.NoError(t, err)
+	require.True(t, u.Disabled)
+
+	// Deleting the user deactivates it
+	uri = api.Router.GetRouteV2("DELETE", api.deleteSCIMUserHandler, map[string]string{"scimUserID": created.ID})
+	w = do("DELETE", uri, nil)
+	require.Equal(t, 204, w.Code, w.Body.String())
+
+	// Deleting the group removes it
+	uri = api.Router.GetRouteV2("DELETE", api.deleteSCIMGroupHandler, map[string]string{"scimGroupID": g.ID})
+	w = do("DELETE", uri, nil)
+	require.Equal(t, 204, w.Code, w.Body.String())
+	_, err = group.LoadByName(context.TODO(), db, g.DisplayName)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
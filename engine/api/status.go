@@ -10,8 +10,6 @@ import (
 	"time"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/mail"
@@ -74,11 +72,11 @@ type computeGlobalNumbers struct {
 }
 
 var (
-	tagRange       tag.Key
-	tagStatus      tag.Key
-	tagServiceName tag.Key
-	tagService     tag.Key
-	tagsService    []tag.Key
+	tagRange       telemetry.TagKey
+	tagStatus      telemetry.TagKey
+	tagServiceName telemetry.TagKey
+	tagService     telemetry.TagKey
+	tagsService    []telemetry.TagKey
 )
 
 // computeGlobalPublicStatus returns global public status
@@ -206,60 +204,60 @@ func (api *API) initMetrics(ctx context.Context) error {
 	log.Info(ctx, "Metrics initialized for %s/%s", api.Type(), api.Name())
 
 	// TODO refactor all the metrics name to replace "cds-api" by "api.Type()"
-	api.Metrics.nbUsers = stats.Int64("cds/cds-api/nb_users", "number of users", stats.UnitDimensionless)
-	api.Metrics.nbApplications = stats.Int64("cds/cds-api/nb_applications", "nb_applications", stats.UnitDimensionless)
-	api.Metrics.nbProjects = stats.Int64("cds/cds-api/nb_projects", "nb_projects", stats.UnitDimensionless)
-	api.Metrics.nbGroups = stats.Int64("cds/cds-api/nb_groups", "nb_groups", stats.UnitDimensionless)
-	api.Metrics.nbPipelines = stats.Int64("cds/cds-api/nb_pipelines", "nb_pipelines", stats.UnitDimensionless)
-	api.Metrics.nbWorkflows = stats.Int64("cds/cds-api/nb_workflows", "nb_workflows", stats.UnitDimensionless)
-	api.Metrics.nbWorkflowsAsCodeV2 = stats.Int64("cds/cds-api/nb_workflows_as_code_v2", "nb_workflows_as_code_v2", stats.UnitDimensionless)
-	api.Metrics.nbArtifacts = stats.Int64("cds/cds-api/nb_artifacts", "nb_artifacts", stats.UnitDimensionless)
-	api.Metrics.nbWorkerModels = stats.Int64("cds/cds-api/nb_worker_models", "nb_worker_models", stats.UnitDimensionless)
-	api.Metrics.nbWorkflowRuns = stats.Int64("cds/cds-api/nb_workflow_runs", "nb_workflow_runs", stats.UnitDimensionless)
-	api.Metrics.nbWorkflowNodeRuns = stats.Int64("cds/cds-api/nb_workflow_node_runs", "nb_workflow_node_runs", stats.UnitDimensionless)
-	api.Metrics.nbMaxWorkersBuilding = stats.Int64("cds/cds-api/nb_max_workers_building", "nb_max_workers_building", stats.UnitDimensionless)
-	api.Metrics.queue = stats.Int64("cds/cds-api/queue", "queue", stats.UnitDimensionless)
-	api.Metrics.v2Queue = stats.Int64("cds/cds-api/v2_queue", "v2_queue", stats.UnitDimensionless)
-	api.Metrics.WorkflowRunsMarkToDelete = stats.Int64(
+	api.Metrics.nbUsers = telemetry.Int64("cds/cds-api/nb_users", "number of users", telemetry.UnitDimensionless)
+	api.Metrics.nbApplications = telemetry.Int64("cds/cds-api/nb_applications", "nb_applications", telemetry.UnitDimensionless)
+	api.Metrics.nbProjects = telemetry.Int64("cds/cds-api/nb_projects", "nb_projects", telemetry.UnitDimensionless)
+	api.Metrics.nbGroups = telemetry.Int64("cds/cds-api/nb_groups", "nb_groups", telemetry.UnitDimensionless)
+	api.Metrics.nbPipelines = telemetry.Int64("cds/cds-api/nb_pipelines", "nb_pipelines", telemetry.UnitDimensionless)
+	api.Metrics.nbWorkflows = telemetry.Int64("cds/cds-api/nb_workflows", "nb_workflows", telemetry.UnitDimensionless)
+	api.Metrics.nbWorkflowsAsCodeV2 = telemetry.Int64("cds/cds-api/nb_workflows_as_code_v2", "nb_workflows_as_code_v2", telemetry.UnitDimensionless)
+	api.Metrics.nbArtifacts = telemetry.Int64("cds/cds-api/nb_artifacts", "nb_artifacts", telemetry.UnitDimensionless)
+	api.Metrics.nbWorkerModels = telemetry.Int64("cds/cds-api/nb_worker_models", "nb_worker_models", telemetry.UnitDimensionless)
+	api.Metrics.nbWorkflowRuns = telemetry.Int64("cds/cds-api/nb_workflow_runs", "nb_workflow_runs", telemetry.UnitDimensionless)
+	api.Metrics.nbWorkflowNodeRuns = telemetry.Int64("cds/cds-api/nb_workflow_node_runs", "nb_workflow_node_runs", telemetry.UnitDimensionless)
+	api.Metrics.nbMaxWorkersBuilding = telemetry.Int64("cds/cds-api/nb_max_workers_building", "nb_max_workers_building", telemetry.UnitDimensionless)
+	api.Metrics.queue = telemetry.Int64("cds/cds-api/queue", "queue", telemetry.UnitDimensionless)
+	api.Metrics.v2Queue = telemetry.Int64("cds/cds-api/v2_queue", "v2_queue", telemetry.UnitDimensionless)
+	api.Metrics.WorkflowRunsMarkToDelete = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/workflow_runs_mark_to_delete", api.Name()),
 		"number of workflow runs mark to delete",
-		stats.UnitDimensionless)
-	api.Metrics.WorkflowRunsDeleted = stats.Int64(
+		telemetry.UnitDimensionless)
+	api.Metrics.WorkflowRunsDeleted = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/workflow_runs_deleted", api.Name()),
 		"number of workflow runs deleted",
-		stats.UnitDimensionless)
-	api.Metrics.WorkflowRunStarted = stats.Int64(
+		telemetry.UnitDimensionless)
+	api.Metrics.WorkflowRunStarted = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/workflow_runs_started", api.Name()),
 		"number of started workflow runs",
-		stats.UnitDimensionless)
-	api.Metrics.WorkflowRunFailed = stats.Int64(
+		telemetry.UnitDimensionless)
+	api.Metrics.WorkflowRunFailed = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/workflow_runs_failed", api.Name()),
 		"number of failed workflow runs",
-		stats.UnitDimensionless)
-	api.Metrics.DatabaseConns = stats.Int64(
+		telemetry.UnitDimensionless)
+	api.Metrics.DatabaseConns = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/database_conn", api.Name()),
 		"number database connections",
-		stats.UnitDimensionless)
-	api.Metrics.RunResultSynchronized = stats.Int64(
+		telemetry.UnitDimensionless)
+	api.Metrics.RunResultSynchronized = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/run_results_synchronized", api.Name()),
 		"number of synchronized run results",
-		stats.UnitDimensionless)
-	api.Metrics.RunResultToSynchronized = stats.Int64(
+		telemetry.UnitDimensionless)
+	api.Metrics.RunResultToSynchronized = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/run_results_to_synchronized", api.Name()),
 		"number of non synchronized run results",
-		stats.UnitDimensionless)
-	api.Metrics.RunResultSynchronizedError = stats.Int64(
+		telemetry.UnitDimensionless)
+	api.Metrics.RunResultSynchronizedError = telemetry.Int64(
 		fmt.Sprintf("cds/cds-api/%s/run_results_synchronized_error", api.Name()),
 		"number of synchronized run results with error",
-		stats.UnitDimensionless)
+		telemetry.UnitDimensionless)
 
-	tagRange, _ = tag.NewKey("range")
-	tagStatus, _ = tag.NewKey("status")
+	tagRange = telemetry.MustNewKey("range")
+	tagStatus = telemetry.MustNewKey("status")
 
 	tagServiceType := telemetry.MustNewKey(telemetry.TagServiceType)
 	tagServiceName := telemetry.MustNewKey(telemetry.TagServiceName)
-	tagsRange := []tag.Key{tagRange, tagStatus}
-	tagsService = []tag.Key{tagServiceName, tagServiceType}
+	tagsRange := []telemetry.TagKey{tagRange, tagStatus}
+	tagsService = []telemetry.TagKey{tagServiceName, tagServiceType}
 
 	err := telemetry.RegisterView(ctx,
 		telemetry.NewViewLast("cds/nb_users", api.Metrics.nbUsers, nil),
@@ -293,10 +291,7 @@ func (api *API) initMetrics(ctx context.Context) error {
 
 func (api *API) computeMetrics(ctx context.Context) {
 	tags := telemetry.ContextGetTags(ctx, telemetry.TagServiceType, telemetry.TagServiceName)
-	ctx, err := tag.New(ctx, tags...)
-	if err != nil {
-		log.Error(ctx, "api.computeMetrics> unable to tag observability context: %v", err)
-	}
+	ctx = telemetry.ContextWithTag(ctx, tags...)
 
 	api.GoRoutines.RunWithRestart(ctx, "api.computeMetrics", func(ctx context.Context) {
 		tick := time.NewTicker(9 * time.Second).C
@@ -380,7 +375,7 @@ func (api *API) computeMetrics(ctx context.Context) {
 	})
 }
 
-func (api *API) countMetric(ctx context.Context, v *stats.Int64Measure, query string) {
+func (api *API) countMetric(ctx context.Context, v *telemetry.Int64Measure, query string) {
 	n, err := api.mustDB().SelectInt(query)
 	if err != nil {
 		log.Warn(ctx, "metrics>Errors while fetching count %s: %v", query, err)
@@ -388,12 +383,12 @@ func (api *API) countMetric(ctx context.Context, v *stats.Int64Measure, query st
 	telemetry.Record(ctx, v, n)
 }
 
-func (api *API) countMetricRange(ctx context.Context, status string, timerange string, v *stats.Int64Measure, query string, args ...interface{}) {
+func (api *API) countMetricRange(ctx context.Context, status string, timerange string, v *telemetry.Int64Measure, query string, args ...interface{}) {
 	n, err := api.mustDB().SelectInt(query, args...)
 	if err != nil {
 		log.Warn(ctx, "metrics>Errors while fetching count range %s: %v", query, err)
 	}
-	ctx, _ = tag.New(ctx, tag.Upsert(tagStatus, status), tag.Upsert(tagRange, timerange))
+	ctx = telemetry.ContextWithTag(ctx, tagStatus.Name(), status, tagRange.Name(), timerange)
 	telemetry.Record(ctx, v, n)
 }
 
@@ -446,7 +441,7 @@ func (api *API) processStatusMetrics(ctx context.Context) {
 			}
 		}
 
-		ctx, _ = tag.New(ctx, tag.Upsert(tagServiceName, service), tag.Upsert(tagService, line.Type))
+		ctx = telemetry.ContextWithTag(ctx, tagServiceName.Name(), service, tagService.Name(), line.Type)
 		v, err := telemetry.FindAndRegisterViewLast(item, tagsService)
 		if err != nil {
 			log.Warn(ctx, "metrics>Errors while FindAndRegisterViewLast %s: %v", item, err)
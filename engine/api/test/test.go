@@ -29,6 +29,8 @@ func SetupPGWithFactory(t *testing.T, bootstrapFunc ...test.Bootstrapf) (*test.F
 
 	err := authentication.Init(context.TODO(), "cds-api-test", []authentication.KeyConfig{{Key: string(test.SigningKey)}})
 	require.NoError(t, err, "unable to init authentication layer")
+	err = authentication.InitAttestation("cds-api-test", []authentication.KeyConfig{{Key: string(test.SigningKey)}})
+	require.NoError(t, err, "unable to init attestation keys")
 
 	return db, factory, cache
 }
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// scimAttributes are the SCIM user attributes that can be filtered.
+var scimAttributes = map[string]gorpmapping.SCIMAttribute{
+	"id":             {Column: "authentified_user.id"},
+	"externalid":     {Column: "authentified_user.external_id"},
+	"username":       {Column: "authentified_user.username"},
+	"displayname":    {Column: "authentified_user.fullname"},
+	"name.formatted": {Column: "authentified_user.fullname"},
+	"active":         {Column: "(NOT authentified_user.disabled)::text"},
+	"emails": {
+		Column: "user_contact.value",
+		Exists: "EXISTS (SELECT 1 FROM user_contact WHERE user_contact.user_id = authentified_user.id AND user_contact.type = '" + sdk.UserContactTypeEmail + "' AND %s)",
+	},
+	"groups": {
+		Column: "group_authentified_user.group_id::text",
+		Exists: "EXISTS (SELECT 1 FROM group_authentified_user WHERE group_authentified_user.authentified_user_id = authentified_user.id AND group_authentified_user.source = '" + string(sdk.ConsumerSCIM) + "' AND %s)",
+	},
+	strings.ToLower(sdk.SCIMSchemaEnterpriseUser + ":organization"): {
+		Column: "organization.name",
+		Exists: "EXISTS (SELECT 1 FROM authentified_user_organization JOIN organization ON organization.id = authentified_user_organization.organization_id WHERE authentified_user_organization.authentified_user_id = authentified_user.id AND %s)",
+	},
+}
+
+func init() {
+	scimAttributes["emails.value"] = scimAttributes["emails"]
+	scimAttributes["groups.value"] = scimAttributes["groups"]
+}
+
+// CountBySCIMFilter returns the count of users matching the SCIM filter.
+func CountBySCIMFilter(ctx context.Context, db gorp.SqlExecutor, filter sdk.SCIMFilter) (int64, error) {
+	cond, args, err := gorpmapping.SCIMFilterCondition(filter, sdk.SCIMSchemaUser, scimAttributes, 1)
+	if err != nil {
+		return 0, err
+	}
+	count, err := db.SelectInt("SELECT COUNT(id) FROM authentified_user WHERE "+cond, args...)
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	return count, nil
+}
+
+// LoadAllBySCIMFilter returns a page of the users matching the SCIM filter, ordered by creation date.
+func LoadAllBySCIMFilter(ctx context.Context, db gorp.SqlExecutor, filter sdk.SCIMFilter, offset, limit int, opts ...LoadOptionFunc) (sdk.AuthentifiedUsers, error) {
+	cond, args, err := gorpmapping.SCIMFilterCondition(filter, sdk.SCIMSchemaUser, scimAttributes, 3)
+	if err != nil {
+		return nil, err
+	}
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM authentified_user
+    WHERE ` + cond + `
+    ORDER BY created, id
+    OFFSET $1 LIMIT $2
+  `).Args(append([]interface{}{offset, limit}, args...)...)
+	return getAll(ctx, db, query, opts...)
+}
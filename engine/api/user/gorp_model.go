@@ -12,8 +12,9 @@ type authentifiedUser struct {
 }
 
 func (u authentifiedUser) Canonical() gorpmapper.CanonicalForms {
-	_ = []interface{}{u.ID, u.Username, u.Fullname, u.Ring, u.Created}
+	_ = []interface{}{u.ID, u.Username, u.Fullname, u.Ring, u.Created, u.ExternalID}
 	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{.Username}}{{.Fullname}}{{.Ring}}{{printDate .Created}}{{.ExternalID}}",
 		"{{.ID}}{{.Username}}{{.Fullname}}{{.Ring}}{{printDate .Created}}",
 	}
 }
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+// groupSyncRules returns the group sync rules configured for given auth driver.
+func (api *API) groupSyncRules(consumerType sdk.AuthConsumerType) sdk.AuthGroupSyncRules {
+	switch consumerType {
+	case sdk.ConsumerLDAP:
+		return api.Config.Auth.LDAP.GroupSync
+	case sdk.ConsumerOIDC:
+		return api.Config.Auth.OIDC.GroupSync
+	}
+	return nil
+}
+
+// userSyncGroups applies the sync rules of the given auth driver to the external groups of a user.
+// Only the memberships synced from this driver are added, updated or removed, manual ones are never changed.
+// A user found in the directory stays admin of the groups that have no other admin, while the memberships of
+// a user missing from the directory are always removed.
+func (api *API) userSyncGroups(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, u *sdk.AuthentifiedUser, source sdk.AuthConsumerType, externalGroups []string, inDirectory bool) error {
+	rules := api.groupSyncRules(source)
+	if len(rules) == 0 {
+		return nil
+	}
+	wanted, _ := rules.Apply(externalGroups)
+
+	if err := user.LoadOptions.WithOrganization(ctx, tx, u); err != nil {
+		return err
+	}
+	links, err := group.LoadLinksGroupUserForUserIDs(ctx, tx, []string{u.ID})
+	if err != nil {
+		return err
+	}
+	groups, err := group.LoadAllByIDs(ctx, tx, links.ToGroupIDs())
+	if err != nil {
+		return err
+	}
+	mGroups := make(map[int64]*sdk.Group, len(groups))
+	for i := range groups {
+		mGroups[groups[i].ID] = &groups[i]
+	}
+
+	// Update or remove the memberships previously synced from this driver
+	members := make(map[string]struct{}, len(links))
+	for i := range links {
+		g, ok := mGroups[links[i].GroupID]
+		if !ok {
+			continue
+		}
+		members[g.Name] = struct{}{}
+		if links[i].Source != source {
+			continue
+		}
+
+		admin, isWanted := wanted[g.Name]
+		if isWanted && links[i].Admin == admin {
+			continue
+		}
+		if links[i].Admin {
+			hasAdmin, err := groupHasOtherAdmin(ctx, tx, g.ID, u.ID)
+			if err != nil {
+				return err
+			}
+			if !hasAdmin && inDirectory {
+				log.Warn(ctx, "userSyncGroups> keep user %s as admin of group %s as it is the last one", u.Username, g.Name)
+				continue
+			}
+			if !hasAdmin {
+				log.Warn(ctx, "userSyncGroups> user %s missing from %s is removed from group %s, the group has no admin anymore", u.Username, source, g.Name)
+			}
+		}
+
+		if isWanted {
+			links[i].Admin = admin
+			if err := group.UpdateLinkGroupUser(ctx, tx, &links[i]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := group.DeleteLinkGroupUser(tx, &links[i]); err != nil {
+			return err
+		}
+		if err := group.EnsureOrganization(ctx, tx, g); err != nil {
+			return err
+		}
+		if err := authentication.ConsumerInvalidateGroupForUser(ctx, tx, g, u); err != nil {
+			return err
+		}
+		log.Info(ctx, "userSyncGroups> user %s removed from group %s by %s sync", u.Username, g.Name, source)
+	}
+
+	// Add the missing memberships
+	for name, admin := range wanted {
+		if _, ok := members[name]; ok {
+			continue
+		}
+		g, err := group.LoadByName(ctx, tx, name)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				log.Warn(ctx, "userSyncGroups> group %s given by %s sync rules does not exist", name, source)
+				continue
+			}
+			return err
+		}
+		if err := group.EnsureOrganization(ctx, tx, g); err != nil {
+			return err
+		}
+		if g.Organization != "" && u.Organization != g.Organization {
+			log.Warn(ctx, "userSyncGroups> user %s with organization %q can't be added in group %s of organization %q", u.Username, u.Organization, g.Name, g.Organization)
+			continue
+		}
+
+		if err := group.InsertLinkGroupUser(ctx, tx, &group.LinkGroupUser{
+			GroupID:            g.ID,
+			AuthentifiedUserID: u.ID,
+			Admin:              admin,
+			Source:             source,
+		}); err != nil {
+			return err
+		}
+		if err := group.EnsureOrganization(ctx, tx, g); err != nil {
+			return err
+		}
+		if err := authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, tx, g.ID, u.ID); err != nil {
+			return err
+		}
+		log.Info(ctx, "userSyncGroups> user %s added in group %s by %s sync", u.Username, g.Name, source)
+	}
+
+	return nil
+}
+
+func groupHasOtherAdmin(ctx context.Context, db gorpmapper.SqlExecutorWithTx, groupID int64, userID string) (bool, error) {
+	links, err := group.LoadLinksGroupUserForGroupIDs(ctx, db, []int64{groupID})
+	if err != nil {
+		return false, err
+	}
+	for i := range links {
+		if links[i].AuthentifiedUserID != userID && links[i].Admin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// synchronizeUserGroups periodically applies the group sync rules for the auth drivers
+// that can get the groups of a user outside of a signin.
+func (api *API) synchronizeUserGroups(ctx context.Context) {
+	if api.Config.Auth.GroupSyncInterval <= 0 {
+		return
+	}
+	delay := time.Duration(api.Config.Auth.GroupSyncInterval) * time.Minute
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "%v", ctx.Err())
+			}
+			return
+		case <-ticker.C:
+			// The lock is kept until it expires so only one API instance syncs on each interval
+			locked, err := api.Cache.Lock(cache.Key("api:synchronizeUserGroups"), delay-time.Second, 0, 1)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			if !locked {
+				continue
+			}
+			for consumerType, authDriver := range api.AuthenticationDrivers {
+				d, ok := authDriver.GetDriver().(sdk.DriverWithUserGroups)
+				if !ok || len(api.groupSyncRules(consumerType)) == 0 {
+					continue
+				}
+				if err := api.synchronizeUserGroupsForDriver(ctx, consumerType, d); err != nil {
+					log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to synchronize %s groups", consumerType))
+				}
+			}
+		}
+	}
+}
+
+func (api *API) synchronizeUserGroupsForDriver(ctx context.Context, consumerType sdk.AuthConsumerType, d sdk.DriverWithUserGroups) error {
+	consumers, err := authentication.LoadEnabledUserConsumersByType(ctx, api.mustDB(), consumerType)
+	if err != nil {
+		return err
+	}
+	for i := range consumers {
+		username := consumers[i].AuthConsumerUser.Data["username"]
+		groups, err := d.GetUserGroups(ctx, username)
+		inDirectory := true
+		if err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+				log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to get %s groups of user %s", consumerType, username))
+				continue
+			}
+			// A user that left the directory has no group anymore, all its synced memberships are removed
+			log.Info(ctx, "synchronizeUserGroupsForDriver> %s user %s not found", consumerType, username)
+			groups = nil
+			inDirectory = false
+		}
+		if err := api.synchronizeUserGroupsForConsumer(ctx, consumers[i].AuthConsumerUser.AuthentifiedUserID, consumerType, groups, inDirectory); err != nil {
+			log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to synchronize %s groups of user %s", consumerType, username))
+		}
+	}
+	return nil
+}
+
+func (api *API) synchronizeUserGroupsForConsumer(ctx context.Context, userID string, consumerType sdk.AuthConsumerType, groups []string, inDirectory bool) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	u, err := user.LoadByID(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	if u.Disabled {
+		return nil
+	}
+	// As on signin, the organization given by the group sync rules is set before the groups are synced
+	if _, org := api.groupSyncRules(consumerType).Apply(groups); org != "" {
+		if err := api.userSetOrganization(ctx, tx, u, org); err != nil {
+			return err
+		}
+	}
+	if err := api.userSyncGroups(ctx, tx, u, consumerType, groups, inDirectory); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_userSyncGroups(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	g1 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	g2 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	g3 := &sdk.Group{Name: sdk.RandomString(10)}
+	u, _ := assets.InsertLambdaUser(t, db, g3)
+
+	api.Config.Auth.LDAP.GroupSync = sdk.AuthGroupSyncRules{
+		{External: "cn=a", Group: g1.Name, Admin: true},
+		{External: "cn=b", Group: g2.Name},
+		{External: "cn=c", Group: g3.Name},
+	}
+
+	loadLinks := func() map[int64]group.LinkGroupUser {
+		links, err := group.LoadLinksGroupUserForUserIDs(context.TODO(), db, []string{u.ID})
+		require.NoError(t, err)
+		m := make(map[int64]group.LinkGroupUser)
+		for _, l := range links {
+			m[l.GroupID] = l
+		}
+		return m
+	}
+
+	require.NoError(t, api.userSyncGroups(context.TODO(), db, u, sdk.ConsumerLDAP, []string{"CN=a", "cn=b", "cn=c"}, true))
+	links := loadLinks()
+	require.Len(t, links, 3)
+	require.True(t, links[g1.ID].Admin)
+	require.Equal(t, sdk.ConsumerLDAP, links[g1.ID].Source)
+	require.False(t, links[g2.ID].Admin)
+	require.Equal(t, sdk.ConsumerLDAP, links[g2.ID].Source)
+	// The manual membership is kept as is
+	require.True(t, links[g3.ID].Admin)
+	require.Empty(t, links[g3.ID].Source)
+
+	// Memberships synced from another driver are not changed
+	require.NoError(t, api.userSyncGroups(context.TODO(), db, u, sdk.ConsumerOIDC, nil, true))
+	require.Len(t, loadLinks(), 3)
+
+	// Only the synced memberships are removed, g1 is kept as its last admin
+	require.NoError(t, api.userSyncGroups(context.TODO(), db, u, sdk.ConsumerLDAP, nil, true))
+	links = loadLinks()
+	require.Len(t, links, 2)
+	require.Contains(t, links, g1.ID)
+	require.Contains(t, links, g3.ID)
+
+	// A user missing from the directory is removed even if it is the last admin
+	require.NoError(t, api.userSyncGroups(context.TODO(), db, u, sdk.ConsumerLDAP, nil, false))
+	links = loadLinks()
+	require.Len(t, links, 1)
+	require.Contains(t, links, g3.ID)
+}
+
+type userGroupsDriver struct {
+	sdk.Driver
+	groups map[string][]string
+}
+
+func (d userGroupsDriver) GetUserGroups(_ context.Context, username string) ([]string, error) {
+	gs, ok := d.groups[username]
+	if !ok {
+		return nil, sdk.WithStack(sdk.ErrUserNotFound)
+	}
+	return gs, nil
+}
+
+func Test_synchronizeUserGroupsForDriver(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	g := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	u, _ := assets.InsertLambdaUser(t, db)
+	_, err := authentication.NewConsumerExternal(context.TODO(), db, u.ID, sdk.ConsumerLDAP, sdk.AuthDriverUserInfo{Username: u.Username})
+	require.NoError(t, err)
+
+	api.Config.Auth.LDAP.GroupSync = sdk.AuthGroupSyncRules{
+		{External: "cn=a", Group: g.Name, Organization: "default"},
+	}
+
+	// The user is added in the group of the rule
+	d := userGroupsDriver{groups: map[string][]string{u.Username: {"cn=a"}}}
+	require.NoError(t, api.synchronizeUserGroupsForDriver(context.TODO(), sdk.ConsumerLDAP, d))
+	l, err := group.LoadLinkGroupUserForGroupIDAndUserID(context.TODO(), db, g.ID, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.ConsumerLDAP, l.Source)
+
+	// A user that left the directory loses its synced groups
+	d.groups = nil
+	require.NoError(t, api.synchronizeUserGroupsForDriver(context.TODO(), sdk.ConsumerLDAP, d))
+	_, err = group.LoadLinkGroupUserForGroupIDAndUserID(context.TODO(), db, g.ID, u.ID)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
@@ -12,6 +12,7 @@ import (
 	hatch_auth "github.com/ovh/cds/engine/api/authentication/hatchery"
 	"github.com/ovh/cds/engine/api/event_v2"
 	"github.com/ovh/cds/engine/api/hatchery"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -166,6 +167,15 @@ func (api *API) postHatcheryHandler() ([]service.RbacChecker, service.Handler) {
 			if err := service.UnmarshalBody(req, &h); err != nil {
 				return err
 			}
+			for _, pKey := range h.ProjectKeys {
+				exist, err := project.Exist(api.mustDB(), pKey)
+				if err != nil {
+					return err
+				}
+				if !exist {
+					return sdk.NewErrorFrom(sdk.ErrNotFound, "project %s not found", pKey)
+				}
+			}
 
 			tx, err := api.mustDB().Begin()
 			if err != nil {
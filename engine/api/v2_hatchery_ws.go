@@ -65,6 +65,7 @@ func (api *API) getHatcheryWebsocketHandler() ([]service.RbacChecker, service.Ha
 					}
 					filter.OSArchSlice = osarch
 					filter.ModelType = hatch.ModelType
+					filter.ProjectKeys = hatch.ProjectKeys
 					filter.Region = reg.Name
 					break
 				}
@@ -168,7 +169,8 @@ func (a *API) websocketHatcheryOnMessage(e sdk.FullEventV2) {
 			}
 
 			c.mutex.Lock()
-			canHandleJob := c.filter.Region == currentRegion && c.filter.ModelType == currentModel && (c.filter.DeprecatedOSArch == currentModelOSArch || slices.Contains(c.filter.OSArchSlice, currentModelOSArch))
+			canHandleJob := c.filter.Region == currentRegion && c.filter.ModelType == currentModel && (c.filter.DeprecatedOSArch == currentModelOSArch || slices.Contains(c.filter.OSArchSlice, currentModelOSArch)) &&
+				(len(c.filter.ProjectKeys) == 0 || slices.Contains(c.filter.ProjectKeys, e.ProjectKey))
 			c.mutex.Unlock()
 			if !canHandleJob {
 				return
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cdn"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectCachesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			ref := QueryString(req, "ref")
+
+			caches, err := api.loadProjectCaches(ctx, pKey)
+			if err != nil {
+				return err
+			}
+			res := make([]sdk.V2WorkerCache, 0, len(caches))
+			for _, c := range caches {
+				if ref != "" && c.cache.Ref != ref {
+					continue
+				}
+				res = append(res, c.cache)
+			}
+			return service.WriteJSON(w, res, http.StatusOK)
+		}
+}
+
+func (api *API) deleteProjectCacheHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			ref := QueryString(req, "ref")
+			cacheKey := QueryString(req, "key")
+			if cacheKey == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing cache key")
+			}
+
+			caches, err := api.loadProjectCaches(ctx, pKey)
+			if err != nil {
+				return err
+			}
+			var found bool
+			for _, c := range caches {
+				if c.cache.Key != cacheKey || (ref != "" && c.cache.Ref != ref) {
+					continue
+				}
+				if err := cdn.DeleteItem(ctx, api.mustDB(), sdk.CDNTypeItemWorkerCacheV2, c.apiRefHash); err != nil {
+					return err
+				}
+				found = true
+			}
+			if !found {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "cache %q not found", cacheKey)
+			}
+			return nil
+		}
+}
+
+type projectCache struct {
+	cache      sdk.V2WorkerCache
+	apiRefHash string
+}
+
+func (api *API) loadProjectCaches(ctx context.Context, projectKey string) ([]projectCache, error) {
+	items, err := cdn.ListWorkerCaches(ctx, api.mustDB(), projectKey)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]projectCache, 0, len(items))
+	for _, it := range items {
+		c, is := sdk.NewV2WorkerCache(it)
+		if !is {
+			continue
+		}
+		res = append(res, projectCache{cache: c, apiRefHash: it.APIRefHash})
+	}
+	return res, nil
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// loadCurrentEnvironmentDeployments returns the last successful deployment of each environment, by environment name.
+func loadCurrentEnvironmentDeployments(ctx context.Context, db gorp.SqlExecutor, projKey string) (map[string]sdk.ProjectEnvironmentDeployment, error) {
+	deployments, err := workflow_v2.LoadCurrentEnvironmentDeployments(ctx, db, projKey)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]sdk.ProjectEnvironmentDeployment, len(deployments))
+	for _, d := range deployments {
+		res[d.Environment] = d
+	}
+	return res, nil
+}
+
+func (api *API) getProjectEnvironmentsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+
+			envs, err := project.LoadEnvironmentsByProjectKey(ctx, api.mustDB(), key)
+			if err != nil {
+				return err
+			}
+			deployments, err := loadCurrentEnvironmentDeployments(ctx, api.mustDB(), key)
+			if err != nil {
+				return err
+			}
+			for i := range envs {
+				if d, has := deployments[envs[i].Name]; has {
+					envs[i].CurrentDeployment = &d
+				}
+			}
+
+			return service.WriteJSON(w, envs, http.StatusOK)
+		}
+}
+
+func (api *API) postProjectEnvironmentHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var env sdk.ProjectEnvironment
+			if err := service.UnmarshalBody(r, &env); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			env.ProjectKey = key
+
+			if err := (&env).Check(); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback()
+			if err := project.InsertEnvironment(ctx, tx, &env); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishEnvironmentEvent(ctx, api.Cache, sdk.EventEnvironmentCreated, key, env, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, env, http.StatusOK)
+		}
+}
+
+func (api *API) putProjectEnvironmentHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			environmentName := vars["environmentName"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var env sdk.ProjectEnvironment
+			if err := service.UnmarshalBody(r, &env); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+
+			oldEnv, err := project.LoadEnvironmentByNameAndProjectKey(ctx, api.mustDB(), key, environmentName)
+			if err != nil {
+				return err
+			}
+			if env.Name != oldEnv.Name {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to rename an environment")
+			}
+			env.ID = oldEnv.ID
+			env.ProjectKey = key
+
+			if err := (&env).Check(); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback()
+			if err := project.UpdateEnvironment(ctx, tx, &env); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishEnvironmentEvent(ctx, api.Cache, sdk.EventEnvironmentUpdated, key, env, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, env, http.StatusOK)
+		}
+}
+
+func (api *API) getProjectEnvironmentHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			environmentName := vars["environmentName"]
+
+			env, err := project.LoadEnvironmentByNameAndProjectKey(ctx, api.mustDB(), key, environmentName)
+			if err != nil {
+				return err
+			}
+			deployments, err := loadCurrentEnvironmentDeployments(ctx, api.mustDB(), key)
+			if err != nil {
+				return err
+			}
+			if d, has := deployments[env.Name]; has {
+				env.CurrentDeployment = &d
+			}
+
+			return service.WriteJSON(w, env, http.StatusOK)
+		}
+}
+
+func (api *API) getProjectEnvironmentDeploymentsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			environmentName := vars["environmentName"]
+
+			offset := service.FormInt(r, "offset")
+			limit := service.FormInt(r, "limit")
+			if offset < 0 {
+				offset = 0
+			}
+			if limit <= 0 {
+				limit = 20
+			}
+			if limit > 100 {
+				limit = 100
+			}
+
+			env, err := project.LoadEnvironmentByNameAndProjectKey(ctx, api.mustDB(), key, environmentName)
+			if err != nil {
+				return err
+			}
+			deployments, err := workflow_v2.LoadEnvironmentDeployments(ctx, api.mustDB(), key, env.Name, int64(offset), int64(limit))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, deployments, http.StatusOK)
+		}
+}
+
+func (api *API) deleteProjectEnvironmentHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			environmentName := vars["environmentName"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			env, err := project.LoadEnvironmentByNameAndProjectKey(ctx, api.mustDB(), key, environmentName)
+			if err != nil {
+				return err
+			}
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback()
+			if err := project.DeleteEnvironment(tx, key, env.ID); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishEnvironmentEvent(ctx, api.Cache, sdk.EventEnvironmentDeleted, key, *env, *u.AuthConsumerUser.AuthentifiedUser)
+
+			return nil
+		}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_crudEnvironmentOnProjectLambdaUserOK(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	user1, pass := assets.InsertLambdaUser(t, db)
+
+	assets.InsertRBAcProject(t, db, "manage", proj.Key, *user1)
+	assets.InsertRBAcProject(t, db, "read", proj.Key, *user1)
+
+	// POST request
+	envRequest := sdk.ProjectEnvironment{
+		Name:           "production",
+		Description:    "Production servers",
+		ReviewerGroups: []string{"ops"},
+		AllowedRefs:    []string{"refs/heads/master", "refs/tags/*"},
+		WaitTimer:      10,
+	}
+	vars := map[string]string{
+		"projectKey": proj.Key,
+	}
+	uri := api.Router.GetRouteV2("POST", api.postProjectEnvironmentHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, user1, pass, "POST", uri, nil)
+	bts, _ := json.Marshal(envRequest)
+	req.Body = io.NopCloser(bytes.NewReader(bts))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envRequest))
+	require.NotEmpty(t, envRequest.ID)
+
+	// Then, Get environment
+	vars["environmentName"] = envRequest.Name
+	uriGetOne := api.Router.GetRouteV2("GET", api.getProjectEnvironmentHandler, vars)
+	test.NotEmpty(t, uriGetOne)
+	reqGetOne := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriGetOne, nil)
+	wGetOne := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wGetOne, reqGetOne)
+	require.Equal(t, 200, wGetOne.Code)
+	var env sdk.ProjectEnvironment
+	require.NoError(t, json.Unmarshal(wGetOne.Body.Bytes(), &env))
+	require.Equal(t, envRequest.Name, env.Name)
+	require.Equal(t, sdk.StringSlice{"refs/heads/master", "refs/tags/*"}, env.AllowedRefs)
+	require.Nil(t, env.CurrentDeployment)
+
+	// Then PUT
+	uriPut := api.Router.GetRouteV2("PUT", api.putProjectEnvironmentHandler, vars)
+	test.NotEmpty(t, uriPut)
+	reqPut := assets.NewAuthentifiedRequest(t, user1, pass, "PUT", uriPut, nil)
+	envRequest.WaitTimer = 0
+	bts, _ = json.Marshal(envRequest)
+	reqPut.Body = io.NopCloser(bytes.NewReader(bts))
+	reqPut.Header.Set("Content-Type", "application/json")
+	wPut := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wPut, reqPut)
+	require.Equal(t, 200, wPut.Code)
+
+	// Then, List
+	uriList := api.Router.GetRouteV2("GET", api.getProjectEnvironmentsHandler, vars)
+	test.NotEmpty(t, uriList)
+	reqList := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriList, nil)
+	wList := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wList, reqList)
+	require.Equal(t, 200, wList.Code)
+	var envs []sdk.ProjectEnvironment
+	require.NoError(t, json.Unmarshal(wList.Body.Bytes(), &envs))
+	require.Len(t, envs, 1)
+	require.Equal(t, int64(0), envs[0].WaitTimer)
+	require.Equal(t, envRequest.ID, envs[0].ID)
+
+	// Then, deployment history
+	uriHistory := api.Router.GetRouteV2("GET", api.getProjectEnvironmentDeploymentsHandler, vars)
+	test.NotEmpty(t, uriHistory)
+	reqHistory := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriHistory, nil)
+	wHistory := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wHistory, reqHistory)
+	require.Equal(t, 200, wHistory.Code)
+	var deployments []sdk.ProjectEnvironmentDeployment
+	require.NoError(t, json.Unmarshal(wHistory.Body.Bytes(), &deployments))
+	require.Len(t, deployments, 0)
+
+	// Then Delete
+	uriDelete := api.Router.GetRouteV2("DELETE", api.deleteProjectEnvironmentHandler, vars)
+	test.NotEmpty(t, uriDelete)
+	reqDelete := assets.NewAuthentifiedRequest(t, user1, pass, "DELETE", uriDelete, nil)
+	w3 := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w3, reqDelete)
+	require.Equal(t, 204, w3.Code)
+
+	reqList = assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriList, nil)
+	wList = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wList, reqList)
+	require.Equal(t, 200, wList.Code)
+	require.NoError(t, json.Unmarshal(wList.Body.Bytes(), &envs))
+	require.Len(t, envs, 0)
+}
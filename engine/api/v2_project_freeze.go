@@ -0,0 +1,284 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectFreezesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+
+			freezes, err := project.LoadFreezesByProjectKey(ctx, api.mustDB(), key)
+			if err != nil {
+				return err
+			}
+
+			return service.WriteJSON(w, freezes, http.StatusOK)
+		}
+}
+
+func (api *API) postProjectFreezeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var freeze sdk.ProjectFreeze
+			if err := service.UnmarshalBody(r, &freeze); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			freeze.ProjectKey = key
+
+			if err := (&freeze).Check(); err != nil {
+				return err
+			}
+			if freeze.HoldsAllJobs() {
+				log.Warn(ctx, "freeze %s on project %s has no environment nor workflow filter, it holds all the jobs of the project", freeze.Name, key)
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback()
+			if err := project.InsertFreeze(ctx, tx, &freeze); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishFreezeEvent(ctx, api.Cache, sdk.EventFreezeCreated, key, freeze.Name, freeze, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, freeze, http.StatusOK)
+		}
+}
+
+func (api *API) putProjectFreezeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			freezeName := vars["freezeName"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var freeze sdk.ProjectFreeze
+			if err := service.UnmarshalBody(r, &freeze); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+
+			oldFreeze, err := project.LoadFreezeByNameAndProjectKey(ctx, api.mustDB(), key, freezeName)
+			if err != nil {
+				return err
+			}
+			if freeze.Name != oldFreeze.Name {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to rename a freeze")
+			}
+			freeze.ID = oldFreeze.ID
+			freeze.ProjectKey = key
+
+			if err := (&freeze).Check(); err != nil {
+				return err
+			}
+			if freeze.HoldsAllJobs() {
+				log.Warn(ctx, "freeze %s on project %s has no environment nor workflow filter, it holds all the jobs of the project", freeze.Name, key)
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback()
+			if err := project.UpdateFreeze(ctx, tx, &freeze); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishFreezeEvent(ctx, api.Cache, sdk.EventFreezeUpdated, key, freeze.Name, freeze, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, freeze, http.StatusOK)
+		}
+}
+
+func (api *API) getProjectFreezeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			freezeName := vars["freezeName"]
+
+			freeze, err := project.LoadFreezeByNameAndProjectKey(ctx, api.mustDB(), key, freezeName)
+			if err != nil {
+				return err
+			}
+
+			return service.WriteJSON(w, freeze, http.StatusOK)
+		}
+}
+
+func (api *API) deleteProjectFreezeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			freezeName := vars["freezeName"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			freeze, err := project.LoadFreezeByNameAndProjectKey(ctx, api.mustDB(), key, freezeName)
+			if err != nil {
+				return err
+			}
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback()
+			if err := project.DeleteFreeze(tx, key, freeze.ID); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishFreezeEvent(ctx, api.Cache, sdk.EventFreezeDeleted, key, freeze.Name, *freeze, *u.AuthConsumerUser.AuthentifiedUser)
+
+			return nil
+		}
+}
+
+func (api *API) getProjectFreezeOverridesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			freezeName := vars["freezeName"]
+
+			overrides, err := project.LoadFreezeOverrides(ctx, api.mustDB(), key, freezeName)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, overrides, http.StatusOK)
+		}
+}
+
+// postProjectFreezeOverrideHandler is the break-glass override of a freeze for a workflow run.
+// The override is recorded with its reason and added to the run infos.
+func (api *API) postProjectFreezeOverrideHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			key := vars["projectKey"]
+			freezeName := vars["freezeName"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var overrideRequest sdk.ProjectFreezeOverrideRequest
+			if err := service.UnmarshalBody(r, &overrideRequest); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			if strings.TrimSpace(overrideRequest.Reason) == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a reason is mandatory to override a freeze")
+			}
+
+			freeze, err := project.LoadFreezeByNameAndProjectKey(ctx, api.mustDB(), key, freezeName)
+			if err != nil {
+				return err
+			}
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), key, overrideRequest.WorkflowRunID)
+			if err != nil {
+				return err
+			}
+			if wr.Status.IsTerminated() {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow run %s is terminated", wr.ID)
+			}
+
+			override := sdk.ProjectFreezeOverride{
+				ProjectKey:    key,
+				Freeze:        freeze.Name,
+				WorkflowRunID: wr.ID,
+				VCSServer:     wr.VCSServer,
+				Repository:    wr.Repository,
+				WorkflowName:  wr.WorkflowName,
+				RunNumber:     wr.RunNumber,
+				UserID:        u.AuthConsumerUser.AuthentifiedUser.ID,
+				Username:      u.AuthConsumerUser.AuthentifiedUser.Username,
+				Reason:        overrideRequest.Reason,
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback()
+			if err := project.InsertFreezeOverride(ctx, tx, &override); err != nil {
+				return err
+			}
+			if err := workflow_v2.InsertRunInfo(ctx, tx, &sdk.V2WorkflowRunInfo{
+				WorkflowRunID: wr.ID,
+				IssuedAt:      time.Now(),
+				Level:         sdk.WorkflowRunInfoLevelWarning,
+				Message:       fmt.Sprintf("Freeze %q overridden by %s: %s", freeze.Name, override.Username, override.Reason),
+			}); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishFreezeEvent(ctx, api.Cache, sdk.EventFreezeOverridden, key, freeze.Name, override, *u.AuthConsumerUser.AuthentifiedUser)
+
+			// Continue the workflow without waiting for the blocked runs trigger
+			runJobs, err := workflow_v2.LoadRunJobsByRunIDAndStatus(ctx, api.mustDB(), wr.ID, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, wr.RunAttempt)
+			if err != nil {
+				return err
+			}
+			heldRunJobs := make([]sdk.V2WorkflowRunJob, 0, len(runJobs))
+			for _, rj := range runJobs {
+				if rj.Concurrency == nil {
+					heldRunJobs = append(heldRunJobs, rj)
+				}
+			}
+			freezes, err := project.LoadFreezesByProjectKey(ctx, api.mustDB(), key)
+			if err != nil {
+				return err
+			}
+			if _, err := releaseFrozenRunJobs(ctx, api.mustDB(), freezes, *wr, heldRunJobs); err != nil {
+				return err
+			}
+			initiator := sdk.V2Initiator{
+				UserID:         u.AuthConsumerUser.AuthentifiedUser.ID,
+				User:           u.AuthConsumerUser.AuthentifiedUser.Initiator(),
+				IsAdminWithMFA: isAdmin(ctx),
+			}
+			api.EnqueueWorkflowRun(ctx, wr.ID, initiator, wr.WorkflowName, wr.RunNumber)
+
+			return service.WriteJSON(w, override, http.StatusOK)
+		}
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_crudFreezeOnProjectLambdaUserOK(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	user1, pass := assets.InsertLambdaUser(t, db)
+
+	assets.InsertRBAcProject(t, db, "manage", proj.Key, *user1)
+	assets.InsertRBAcProject(t, db, "read", proj.Key, *user1)
+
+	start := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	end := start.Add(24 * time.Hour)
+	freezeRequest := sdk.ProjectFreeze{
+		Name:         "christmas",
+		Environments: []string{"production"},
+		Start:        &start,
+		End:          &end,
+	}
+	vars := map[string]string{
+		"projectKey": proj.Key,
+	}
+	uri := api.Router.GetRouteV2("POST", api.postProjectFreezeHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, user1, pass, "POST", uri, nil)
+	bts, _ := json.Marshal(freezeRequest)
+	req.Body = io.NopCloser(bytes.NewReader(bts))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &freezeRequest))
+	require.NotEmpty(t, freezeRequest.ID)
+
+	// Then, Get freeze
+	vars["freezeName"] = freezeRequest.Name
+	uriGetOne := api.Router.GetRouteV2("GET", api.getProjectFreezeHandler, vars)
+	test.NotEmpty(t, uriGetOne)
+	reqGetOne := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriGetOne, nil)
+	wGetOne := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wGetOne, reqGetOne)
+	require.Equal(t, 200, wGetOne.Code)
+	var freeze sdk.ProjectFreeze
+	require.NoError(t, json.Unmarshal(wGetOne.Body.Bytes(), &freeze))
+	require.True(t, start.Equal(*freeze.Start))
+	require.NotNil(t, freeze.ActiveUntil(time.Now()))
+
+	// Then PUT
+	uriPut := api.Router.GetRouteV2("PUT", api.putProjectFreezeHandler, vars)
+	test.NotEmpty(t, uriPut)
+	reqPut := assets.NewAuthentifiedRequest(t, user1, pass, "PUT", uriPut, nil)
+	freezeRequest.Workflows = []string{"deploy-*"}
+	bts, _ = json.Marshal(freezeRequest)
+	reqPut.Body = io.NopCloser(bytes.NewReader(bts))
+	reqPut.Header.Set("Content-Type", "application/json")
+	wPut := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wPut, reqPut)
+	require.Equal(t, 200, wPut.Code)
+
+	// Then override the freeze for a run
+	wr := sdk.V2WorkflowRun{
+		ID:           sdk.UUID(),
+		ProjectKey:   proj.Key,
+		VCSServerID:  sdk.UUID(),
+		RepositoryID: sdk.UUID(),
+		WorkflowName: "deploy-api",
+		Status:       sdk.V2WorkflowRunStatusBuilding,
+		RunNumber:    1,
+	}
+	require.NoError(t, workflow_v2.InsertRun(context.TODO(), db, &wr))
+
+	uriOverride := api.Router.GetRouteV2("POST", api.postProjectFreezeOverrideHandler, vars)
+	test.NotEmpty(t, uriOverride)
+	reqOverride := assets.NewAuthentifiedRequest(t, user1, pass, "POST", uriOverride, sdk.ProjectFreezeOverrideRequest{WorkflowRunID: wr.ID})
+	wOverride := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wOverride, reqOverride)
+	require.Equal(t, 400, wOverride.Code)
+
+	reqOverride = assets.NewAuthentifiedRequest(t, user1, pass, "POST", uriOverride, sdk.ProjectFreezeOverrideRequest{WorkflowRunID: wr.ID, Reason: "hotfix"})
+	wOverride = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wOverride, reqOverride)
+	require.Equal(t, 200, wOverride.Code)
+
+	uriOverrides := api.Router.GetRouteV2("GET", api.getProjectFreezeOverridesHandler, vars)
+	test.NotEmpty(t, uriOverrides)
+	reqOverrides := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriOverrides, nil)
+	wOverrides := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wOverrides, reqOverrides)
+	require.Equal(t, 200, wOverrides.Code)
+	var overrides []sdk.ProjectFreezeOverride
+	require.NoError(t, json.Unmarshal(wOverrides.Body.Bytes(), &overrides))
+	require.Len(t, overrides, 1)
+	require.Equal(t, wr.ID, overrides[0].WorkflowRunID)
+	require.Equal(t, user1.Username, overrides[0].Username)
+	require.Equal(t, "hotfix", overrides[0].Reason)
+
+	infos, err := workflow_v2.LoadRunInfosByRunID(context.TODO(), db, wr.ID)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+
+	// Then Delete
+	uriDelete := api.Router.GetRouteV2("DELETE", api.deleteProjectFreezeHandler, vars)
+	test.NotEmpty(t, uriDelete)
+	reqDelete := assets.NewAuthentifiedRequest(t, user1, pass, "DELETE", uriDelete, nil)
+	w3 := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w3, reqDelete)
+	require.Equal(t, 204, w3.Code)
+
+	uriList := api.Router.GetRouteV2("GET", api.getProjectFreezesHandler, vars)
+	test.NotEmpty(t, uriList)
+	reqList := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriList, nil)
+	wList := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wList, reqList)
+	require.Equal(t, 200, wList.Code)
+	var freezes []sdk.ProjectFreeze
+	require.NoError(t, json.Unmarshal(wList.Body.Bytes(), &freezes))
+	require.Len(t, freezes, 0)
+}
@@ -0,0 +1,327 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+	"github.com/rockbears/yaml"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/repository"
+	"github.com/ovh/cds/engine/api/vcs"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectRBACAsCodeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			r, err := project.LoadRBACAsCodeByProjectKey(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+			if err := api.fillProjectRBACAsCodeNames(ctx, r); err != nil {
+				return err
+			}
+			return service.WriteJSON(w, r, http.StatusOK)
+		}
+}
+
+func (api *API) putProjectRBACAsCodeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var r sdk.ProjectRBACAsCode
+			if err := service.UnmarshalBody(req, &r); err != nil {
+				return err
+			}
+
+			vcsProject, err := vcs.LoadVCSByProject(ctx, api.mustDB(), pKey, r.VCSServer)
+			if err != nil {
+				return err
+			}
+			repo, err := repository.LoadRepositoryByName(ctx, api.mustDB(), vcsProject.ID, r.Repository)
+			if err != nil {
+				return err
+			}
+
+			existing, err := project.LoadRBACAsCodeByProjectKey(ctx, api.mustDB(), pKey)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			r.ProjectKey = pKey
+			r.VCSProjectID = vcsProject.ID
+			r.ProjectRepositoryID = repo.ID
+			if existing != nil {
+				r.Created = existing.Created
+				r.CreatedBy = existing.CreatedBy
+				// Changing the repository resets the applied state
+				if existing.ProjectRepositoryID == repo.ID {
+					r.LastCommit = existing.LastCommit
+					r.LastApplied = existing.LastApplied
+				} else {
+					r.LastCommit = ""
+					r.LastApplied = nil
+				}
+				if err := project.UpdateRBACAsCode(ctx, tx, &r); err != nil {
+					return err
+				}
+			} else {
+				r.CreatedBy = u.GetUsername()
+				r.LastCommit = ""
+				r.LastApplied = nil
+				if err := project.InsertRBACAsCode(ctx, tx, &r); err != nil {
+					return err
+				}
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			r.VCSServer = vcsProject.Name
+			r.Repository = repo.Name
+			return service.WriteJSON(w, r, http.StatusOK)
+		}
+}
+
+func (api *API) deleteProjectRBACAsCodeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			if _, err := project.LoadRBACAsCodeByProjectKey(ctx, api.mustDB(), pKey); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			// The permissions stay in place but they are not managed as code anymore
+			rbacs, err := rbac.LoadRBACByProjectKeyAsCode(ctx, tx, pKey, rbac.LoadOptions.All)
+			if err != nil {
+				return err
+			}
+			for i := range rbacs {
+				rbacs[i].ProjectKeyAsCode = ""
+				if err := rbac.Update(ctx, tx, &rbacs[i]); err != nil {
+					return err
+				}
+			}
+			if err := project.DeleteRBACAsCode(tx, pKey); err != nil {
+				return err
+			}
+			return sdk.WithStack(tx.Commit())
+		}
+}
+
+func (api *API) fillProjectRBACAsCodeNames(ctx context.Context, r *sdk.ProjectRBACAsCode) error {
+	vcsProject, err := vcs.LoadVCSByIDAndProjectKey(ctx, api.mustDB(), r.ProjectKey, r.VCSProjectID)
+	if err != nil {
+		return err
+	}
+	repo, err := repository.LoadRepositoryByID(ctx, api.mustDB(), r.ProjectRepositoryID)
+	if err != nil {
+		return err
+	}
+	r.VCSServer = vcsProject.Name
+	r.Repository = repo.Name
+	return nil
+}
+
+// analyzeRBACAsCode compares the permissions file of the repository designated by the project with the permissions
+// managed as code. The changes are applied only for a signed commit at the head of the default branch, made by a user
+// that can manage the project, otherwise the diff is only computed.
+func (api *API) analyzeRBACAsCode(ctx context.Context, analysis *sdk.ProjectRepositoryAnalysis, repo sdk.ProjectRepository, defaultBranch *sdk.VCSBranch, filesContent map[string][]byte) error {
+	projRBAC, err := project.LoadRBACAsCodeByProjectKey(ctx, api.mustDB(), analysis.ProjectKey)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if projRBAC.ProjectRepositoryID != repo.ID {
+		return nil
+	}
+
+	diff, wanted, err := api.computeRBACAsCodeDiff(ctx, analysis.ProjectKey, filesContent)
+	analysis.Data.RBAC = &diff
+	if err != nil {
+		diff.Error = sdk.ExtractHTTPError(err).From
+		return nil
+	}
+
+	// Changes from other branches or from pull requests are only a dry run
+	if defaultBranch == nil || analysis.Ref != defaultBranch.ID || analysis.Commit != defaultBranch.LatestCommit {
+		return nil
+	}
+	if !analysis.Data.CommitCheck {
+		diff.Error = "permissions can only be applied from a signed commit"
+		return nil
+	}
+	if !analysis.Data.Initiator.IsUser() {
+		diff.Error = "permissions can only be applied by a CDS user"
+		return nil
+	}
+	canManage := analysis.Data.Initiator.IsAdminWithMFA
+	if !canManage {
+		canManage, err = rbac.HasRoleOnProjectAndUserID(ctx, api.mustDB(), sdk.ProjectRoleManage, analysis.Data.Initiator.UserID, analysis.ProjectKey)
+		if err != nil {
+			return err
+		}
+	}
+	if !canManage {
+		diff.Error = "user " + analysis.Data.Initiator.Username() + " doesn't have the permission to manage project " + analysis.ProjectKey
+		return nil
+	}
+
+	if err := api.applyRBACAsCode(ctx, projRBAC, analysis, diff, wanted); err != nil {
+		diff.Error = sdk.ExtractHTTPError(err).From
+		return nil
+	}
+	diff.Applied = true
+	return nil
+}
+
+func (api *API) computeRBACAsCodeDiff(ctx context.Context, projectKey string, filesContent map[string][]byte) (sdk.RBACAsCodeDiff, []sdk.RBAC, error) {
+	var asCode sdk.RBACAsCode
+	for _, filePath := range sdk.RBACAsCodeFilePaths {
+		content, has := filesContent[filePath]
+		if !has {
+			continue
+		}
+		if err := yaml.Unmarshal(content, &asCode); err != nil {
+			return sdk.RBACAsCodeDiff{}, nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read %s: %v", filePath, err)
+		}
+		break
+	}
+	if err := asCode.Scope(projectKey); err != nil {
+		return sdk.RBACAsCodeDiff{}, nil, err
+	}
+
+	// Check that users and groups exist
+	rbacLoader := NewRBACLoader(api.mustDB())
+	for i := range asCode.Permissions {
+		if err := rbacLoader.FillRBACWithIDs(ctx, &asCode.Permissions[i]); err != nil {
+			return sdk.RBACAsCodeDiff{}, nil, err
+		}
+	}
+
+	current, err := rbac.LoadRBACByProjectKeyAsCode(ctx, api.mustDB(), projectKey, rbac.LoadOptions.All)
+	if err != nil {
+		return sdk.RBACAsCodeDiff{}, nil, err
+	}
+	managedIDs := make(map[string]struct{}, len(current))
+	for i := range current {
+		managedIDs[current[i].ID] = struct{}{}
+		if err := rbacLoader.FillRBACWithNames(ctx, &current[i]); err != nil {
+			return sdk.RBACAsCodeDiff{}, nil, err
+		}
+	}
+	for _, rb := range asCode.Permissions {
+		if _, has := managedIDs[rb.ID]; rb.ID != "" && !has {
+			return sdk.RBACAsCodeDiff{}, nil, sdk.NewErrorFrom(sdk.ErrForbidden, "permission %s already exists and is not managed by project %s", rb.Name, projectKey)
+		}
+	}
+	return sdk.NewRBACAsCodeDiff(current, asCode.Permissions), asCode.Permissions, nil
+}
+
+func (api *API) applyRBACAsCode(ctx context.Context, projRBAC *sdk.ProjectRBACAsCode, analysis *sdk.ProjectRepositoryAnalysis, diff sdk.RBACAsCodeDiff, wanted []sdk.RBAC) error {
+	changed := make(map[string]struct{}, len(diff.Added)+len(diff.Updated))
+	for _, name := range append(diff.Added, diff.Updated...) {
+		changed[name] = struct{}{}
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	var created, updated, removed []sdk.RBAC
+	for i := range wanted {
+		rb := wanted[i]
+		if _, has := changed[rb.Name]; !has {
+			continue
+		}
+		existing, err := rbac.LoadRBACByName(ctx, tx, rb.Name)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			if existing.ProjectKeyAsCode != projRBAC.ProjectKey {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "permission %s already exists and is not managed by project %s", rb.Name, projRBAC.ProjectKey)
+			}
+			if err := rbac.Delete(ctx, tx, *existing); err != nil {
+				return err
+			}
+		}
+		rb.ProjectKeyAsCode = projRBAC.ProjectKey
+		if err := rbac.Insert(ctx, tx, &rb); err != nil {
+			return err
+		}
+		if existing == nil {
+			created = append(created, rb)
+		} else {
+			updated = append(updated, rb)
+		}
+	}
+	for _, name := range diff.Removed {
+		existing, err := rbac.LoadRBACByName(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		if err := rbac.Delete(ctx, tx, *existing); err != nil {
+			return err
+		}
+		removed = append(removed, *existing)
+	}
+
+	now := time.Now()
+	projRBAC.LastCommit = analysis.Commit
+	projRBAC.LastApplied = &now
+	if err := project.UpdateRBACAsCode(ctx, tx, projRBAC); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	log.Info(ctx, "analyzeRBACAsCode> permissions of project %s applied from commit %s: %d added, %d updated, %d removed", projRBAC.ProjectKey, analysis.Commit, len(created), len(updated), len(removed))
+	u := sdk.AuthentifiedUser{ID: analysis.Data.Initiator.UserID, Username: analysis.Data.Initiator.Username()}
+	for _, rb := range created {
+		event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionCreated, rb, u)
+	}
+	for _, rb := range updated {
+		event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionUpdated, rb, u)
+	}
+	for _, rb := range removed {
+		event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionDeleted, rb, u)
+	}
+	return nil
+}
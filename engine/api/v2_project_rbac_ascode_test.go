@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_computeRBACAsCodeDiff(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	user1, _ := assets.InsertLambdaUser(t, db)
+	p := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+
+	managed := sdk.RBAC{
+		Name:             "managed-" + sdk.RandomString(10),
+		ProjectKeyAsCode: p.Key,
+		Projects:         []sdk.RBACProject{{Role: sdk.ProjectRoleRead, RBACProjectKeys: []string{p.Key}, RBACUsersName: []string{user1.Username}}},
+	}
+	removed := sdk.RBAC{
+		Name:             "removed-" + sdk.RandomString(10),
+		ProjectKeyAsCode: p.Key,
+		Projects:         []sdk.RBACProject{{Role: sdk.ProjectRoleRead, RBACProjectKeys: []string{p.Key}, AllUsers: true}},
+	}
+	manual := sdk.RBAC{
+		Name:     "manual-" + sdk.RandomString(10),
+		Projects: []sdk.RBACProject{{Role: sdk.ProjectRoleRead, RBACProjectKeys: []string{p.Key}, AllUsers: true}},
+	}
+	rbacLoader := NewRBACLoader(api.mustDB())
+	for _, r := range []*sdk.RBAC{&managed, &removed, &manual} {
+		require.NoError(t, rbacLoader.FillRBACWithIDs(context.TODO(), r))
+		require.NoError(t, rbac.Insert(context.TODO(), db, r))
+	}
+
+	file := fmt.Sprintf(`permissions:
+- name: %s
+  projects:
+  - role: manage
+    users: [%s]
+- name: added-%s
+  workflows:
+  - role: trigger
+    all_workflows: true
+    all_users: true`, managed.Name, user1.Username, p.Key)
+
+	diff, wanted, err := api.computeRBACAsCodeDiff(context.TODO(), p.Key, map[string][]byte{".cds/rbac.yml": []byte(file)})
+	require.NoError(t, err)
+	require.Len(t, wanted, 2)
+	require.Equal(t, []string{"added-" + p.Key}, diff.Added)
+	require.Equal(t, []string{managed.Name}, diff.Updated)
+	require.Equal(t, []string{removed.Name}, diff.Removed)
+
+	// A permission that is not managed by the project can't be overridden
+	file = fmt.Sprintf(`permissions:
+- name: %s
+  projects:
+  - role: read
+    all_users: true`, manual.Name)
+	_, _, err = api.computeRBACAsCodeDiff(context.TODO(), p.Key, map[string][]byte{".cds/rbac.yml": []byte(file)})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+
+	// A permission on another project is rejected
+	file = `permissions:
+- name: other
+  projects:
+  - role: read
+    projects: [OTHER]
+    all_users: true`
+	_, _, err = api.computeRBACAsCodeDiff(context.TODO(), p.Key, map[string][]byte{".cds/rbac.yml": []byte(file)})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+}
+
+func Test_deleteProjectRBACAsCodeHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	admin, pass := assets.InsertAdminUser(t, db)
+	user1, _ := assets.InsertLambdaUser(t, db)
+	p := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	vcsServer := assets.InsertTestVCSProject(t, db, p.ID, "github", "github")
+	repo := assets.InsertTestProjectRepository(t, db, p.Key, vcsServer.ID, sdk.RandomString(10))
+	require.NoError(t, project.InsertRBACAsCode(context.TODO(), db, &sdk.ProjectRBACAsCode{
+		ProjectKey:          p.Key,
+		VCSProjectID:        vcsServer.ID,
+		ProjectRepositoryID: repo.ID,
+		CreatedBy:           admin.Username,
+	}))
+
+	managed := sdk.RBAC{
+		Name:             "managed-" + sdk.RandomString(10),
+		ProjectKeyAsCode: p.Key,
+		Projects:         []sdk.RBACProject{{Role: sdk.ProjectRoleRead, RBACProjectKeys: []string{p.Key}, RBACUsersName: []string{user1.Username}}},
+	}
+	require.NoError(t, NewRBACLoader(api.mustDB()).FillRBACWithIDs(context.TODO(), &managed))
+	require.NoError(t, rbac.Insert(context.TODO(), db, &managed))
+
+	uri := api.Router.GetRouteV2("DELETE", api.deleteProjectRBACAsCodeHandler, map[string]string{"projectKey": p.Key})
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, admin, pass, "DELETE", uri, nil)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 204, w.Code)
+
+	_, err := project.LoadRBACAsCodeByProjectKey(context.TODO(), db, p.Key)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	// The permission is kept with a valid signature, and it is not managed as code anymore
+	rb, err := rbac.LoadRBACByName(context.TODO(), db, managed.Name, rbac.LoadOptions.All)
+	require.NoError(t, err)
+	require.Empty(t, rb.ProjectKeyAsCode)
+	require.Len(t, rb.Projects, 1)
+	require.Equal(t, []string{user1.ID}, rb.Projects[0].RBACUsersIDs)
+
+	asCode, err := rbac.LoadRBACByProjectKeyAsCode(context.TODO(), db, p.Key)
+	require.NoError(t, err)
+	require.Empty(t, asCode)
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func parseUsageMonth(value string) (time.Time, error) {
+	if !sdk.IsValidUsageMonth(value) {
+		return time.Time{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid month %q, expected format is YYYY-MM", value)
+	}
+	t, err := time.Parse(sdk.UsageMonthLayout, value)
+	if err != nil {
+		return time.Time{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid month %q: %v", value, err)
+	}
+	return t, nil
+}
+
+func (api *API) getProjectUsagesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			now := time.Now().UTC()
+			to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+			from := to.AddDate(-1, 0, 0)
+
+			if f := req.FormValue("from"); f != "" {
+				t, err := parseUsageMonth(f)
+				if err != nil {
+					return err
+				}
+				from = t
+			}
+			if t := req.FormValue("to"); t != "" {
+				month, err := parseUsageMonth(t)
+				if err != nil {
+					return err
+				}
+				to = month.AddDate(0, 1, 0)
+			}
+			if !from.Before(to) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "from must be before to")
+			}
+
+			usages, err := workflow_v2.LoadProjectUsages(ctx, api.mustDB(), pKey, from, to, req.FormValue("vcs"), req.FormValue("repository"), req.FormValue("workflow"))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, usages, http.StatusOK)
+		}
+}
+
+func (api *API) getProjectUsageDetailsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			month, err := parseUsageMonth(vars["month"])
+			if err != nil {
+				return err
+			}
+
+			details, err := workflow_v2.LoadProjectUsageDetails(ctx, api.mustDB(), pKey, month)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, details, http.StatusOK)
+		}
+}
+
+func (api *API) getProjectQuotaHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			quota, err := project.LoadQuotaByProjectKey(ctx, api.mustDB(), pKey)
+			if err != nil {
+				if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+					return err
+				}
+				quota = &sdk.ProjectQuota{ProjectKey: pKey}
+			}
+			return service.WriteJSON(w, quota, http.StatusOK)
+		}
+}
+
+func (api *API) putProjectQuotaHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isAdmin),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			var quota sdk.ProjectQuota
+			if err := service.UnmarshalBody(req, &quota); err != nil {
+				return err
+			}
+			quota.ProjectKey = pKey
+			if err := quota.Check(); err != nil {
+				return err
+			}
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			exists := true
+			if _, err := project.LoadQuotaByProjectKey(ctx, api.mustDB(), proj.Key); err != nil {
+				if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+					return err
+				}
+				exists = false
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			if exists {
+				if err := project.UpdateQuota(ctx, tx, &quota); err != nil {
+					return err
+				}
+			} else {
+				if err := project.InsertQuota(ctx, tx, &quota); err != nil {
+					return err
+				}
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			return service.WriteJSON(w, quota, http.StatusOK)
+		}
+}
+
+func (api *API) deleteProjectQuotaHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isAdmin),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			if err := project.DeleteQuota(tx, pKey); err != nil {
+				return err
+			}
+			return sdk.WithStack(tx.Commit())
+		}
+}
+
+// checkProjectMonthlyQuota returns an ErrQuotaExceeded error if the project has consumed its monthly minutes.
+// No job of the project can start until the end of the month.
+func checkProjectMonthlyQuota(ctx context.Context, db gorp.SqlExecutor, projKey string) error {
+	quota, err := project.LoadQuotaByProjectKey(ctx, db, projKey)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if quota.MaxMonthlyMinutes <= 0 {
+		return nil
+	}
+	minutes, err := workflow_v2.CountProjectMonthlyMinutes(ctx, db, projKey, time.Now())
+	if err != nil {
+		return err
+	}
+	if minutes >= float64(quota.MaxMonthlyMinutes) {
+		return sdk.NewErrorFrom(sdk.ErrQuotaExceeded, "project %s has consumed its monthly quota of %d minutes", projKey, quota.MaxMonthlyMinutes)
+	}
+	return nil
+}
+
+// checkProjectConcurrentJobsQuota returns an ErrQuotaExceeded error if the project cannot start a new job right now.
+// The quota is locked until the end of the given transaction, the job must be scheduled in the same transaction.
+func checkProjectConcurrentJobsQuota(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, projKey string) error {
+	quota, err := project.LoadQuotaByProjectKeyForUpdate(ctx, tx, projKey)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if quota.MaxConcurrentJobs <= 0 {
+		return nil
+	}
+	nb, err := workflow_v2.CountRunJobsByProjectStatusAndRegions(ctx, tx, []string{projKey},
+		[]sdk.V2WorkflowRunJobStatus{sdk.V2WorkflowRunJobStatusScheduling, sdk.V2WorkflowRunJobStatusBuilding}, nil)
+	if err != nil {
+		return err
+	}
+	if nb >= quota.MaxConcurrentJobs {
+		return sdk.NewErrorFrom(sdk.ErrQuotaExceeded, "project %s has reached its quota of %d concurrent jobs", projKey, quota.MaxConcurrentJobs)
+	}
+	return nil
+}
+
+// failRunJobOnMonthlyQuota fails a new waiting job of a run when the monthly quota of the project is exhausted
+func failRunJobOnMonthlyQuota(runJob *sdk.V2WorkflowRunJob, quotaErr error) *sdk.V2WorkflowRunJobInfo {
+	if quotaErr == nil || runJob.Status != sdk.V2WorkflowRunJobStatusWaiting {
+		return nil
+	}
+	runJob.Status = sdk.V2WorkflowRunJobStatusFail
+	return &sdk.V2WorkflowRunJobInfo{
+		WorkflowRunID:    runJob.WorkflowRunID,
+		WorkflowRunJobID: runJob.ID,
+		IssuedAt:         time.Now(),
+		Level:            sdk.WorkflowRunInfoLevelError,
+		Message:          "unable to start job: " + sdk.ExtractHTTPError(quotaErr).Message,
+	}
+}
+
+// failRunJobOnQuota stops a waiting job that cannot be started because the project quota is exhausted
+func (api *API) failRunJobOnQuota(ctx context.Context, jobRun *sdk.V2WorkflowRunJob, quotaErr error) error {
+	now := time.Now()
+	jobRun.Status = sdk.V2WorkflowRunJobStatusFail
+	jobRun.Ended = &now
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	info := sdk.V2WorkflowRunJobInfo{
+		WorkflowRunID:    jobRun.WorkflowRunID,
+		WorkflowRunJobID: jobRun.ID,
+		IssuedAt:         now,
+		Level:            sdk.WorkflowRunInfoLevelError,
+		Message:          "unable to start job: " + sdk.ExtractHTTPError(quotaErr).Message,
+	}
+	if err := workflow_v2.InsertRunJobInfo(ctx, tx, &info); err != nil {
+		return err
+	}
+	if err := workflow_v2.UpdateJobRun(ctx, tx, jobRun); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	api.manageEndConcurrency(jobRun.ProjectKey, jobRun.VCSServer, jobRun.Repository, jobRun.WorkflowName, jobRun.WorkflowRunID, jobRun.ID, jobRun.Concurrency)
+	api.EnqueueWorkflowRun(ctx, jobRun.WorkflowRunID, jobRun.Initiator, jobRun.WorkflowName, jobRun.RunNumber)
+	api.GoRoutines.Exec(ctx, "failRunJobOnQuota.event", func(ctx context.Context) {
+		run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), jobRun.WorkflowRunID)
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+			return
+		}
+		event_v2.PublishRunJobEvent(ctx, api.Cache, sdk.EventRunJobEnded, *run, *jobRun)
+	})
+	return quotaErr
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_putProjectQuotaHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	user1, pass := assets.InsertLambdaUser(t, db)
+	assets.InsertRBAcProject(t, db, "manage", proj.Key, *user1)
+	assets.InsertRBAcProject(t, db, "read", proj.Key, *user1)
+	admin, adminPass := assets.InsertAdminUser(t, db)
+
+	vars := map[string]string{"projectKey": proj.Key}
+	quota := sdk.ProjectQuota{MaxConcurrentJobs: 2, MaxMonthlyMinutes: 1000}
+
+	// A project manager cannot change its own quota
+	uri := api.Router.GetRouteV2("PUT", api.putProjectQuotaHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, user1, pass, "PUT", uri, quota)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 403, w.Code)
+
+	req = assets.NewAuthentifiedRequest(t, admin, adminPass, "PUT", uri, quota)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	uriGet := api.Router.GetRouteV2("GET", api.getProjectQuotaHandler, vars)
+	test.NotEmpty(t, uriGet)
+	reqGet := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriGet, nil)
+	wGet := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wGet, reqGet)
+	require.Equal(t, 200, wGet.Code)
+
+	var result sdk.ProjectQuota
+	require.NoError(t, json.Unmarshal(wGet.Body.Bytes(), &result))
+	require.Equal(t, int64(2), result.MaxConcurrentJobs)
+	require.Equal(t, int64(1000), result.MaxMonthlyMinutes)
+}
+
+func Test_getProjectUsagesHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	user1, pass := assets.InsertLambdaUser(t, db)
+	assets.InsertRBAcProject(t, db, "read", proj.Key, *user1)
+
+	now := time.Now()
+	started := now.Add(-30 * time.Minute)
+	for _, wf := range []string{"wf1", "wf1", "wf2"} {
+		usage := sdk.V2WorkflowRunJobUsage{
+			RunJobID:      sdk.UUID(),
+			WorkflowRunID: sdk.UUID(),
+			ProjectKey:    proj.Key,
+			WorkflowName:  wf,
+			WorkerModel:   "my-model",
+			Started:       started,
+			Ended:         &now,
+		}
+		require.NoError(t, workflow_v2.InsertRunJobUsage(context.TODO(), db, &usage))
+	}
+
+	vars := map[string]string{"projectKey": proj.Key}
+	uri := api.Router.GetRouteV2("GET", api.getProjectUsagesHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uri+"?workflow=wf1", nil)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	var usages []sdk.V2ProjectUsage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &usages))
+	require.Len(t, usages, 1)
+	require.Equal(t, int64(2), usages[0].Jobs)
+	require.InDelta(t, 60, usages[0].Minutes, 1)
+
+	vars["month"] = started.Format(sdk.UsageMonthLayout)
+	uriDetails := api.Router.GetRouteV2("GET", api.getProjectUsageDetailsHandler, vars)
+	test.NotEmpty(t, uriDetails)
+	reqDetails := assets.NewAuthentifiedRequest(t, user1, pass, "GET", uriDetails, nil)
+	wDetails := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wDetails, reqDetails)
+	require.Equal(t, 200, wDetails.Code)
+
+	var details []sdk.V2ProjectUsageDetail
+	require.NoError(t, json.Unmarshal(wDetails.Body.Bytes(), &details))
+	require.Len(t, details, 2)
+}
+
+func Test_checkProjectQuota(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+
+	// No quota
+	require.NoError(t, checkProjectMonthlyQuota(context.TODO(), db, proj.Key))
+
+	quota := sdk.ProjectQuota{ProjectKey: proj.Key, MaxMonthlyMinutes: 10, MaxConcurrentJobs: 1}
+	require.NoError(t, project.InsertQuota(context.TODO(), db, &quota))
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, checkProjectConcurrentJobsQuota(context.TODO(), tx, proj.Key))
+	require.NoError(t, tx.Rollback())
+
+	usage := sdk.V2WorkflowRunJobUsage{
+		RunJobID:      sdk.UUID(),
+		WorkflowRunID: sdk.UUID(),
+		ProjectKey:    proj.Key,
+		Started:       time.Now().Add(-20 * time.Minute),
+	}
+	if usage.Started.UTC().Month() != time.Now().UTC().Month() {
+		t.Skip("the month has just started")
+	}
+	require.NoError(t, workflow_v2.InsertRunJobUsage(context.TODO(), db, &usage))
+
+	err = checkProjectMonthlyQuota(context.TODO(), db, proj.Key)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrQuotaExceeded))
+
+	runJob := sdk.V2WorkflowRunJob{ID: sdk.UUID(), Status: sdk.V2WorkflowRunJobStatusWaiting}
+	info := failRunJobOnMonthlyQuota(&runJob, err)
+	require.NotNil(t, info)
+	require.Equal(t, sdk.V2WorkflowRunJobStatusFail, runJob.Status)
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ovh/cds/engine/api/cdn"
 	"github.com/ovh/cds/engine/api/event_v2"
@@ -152,11 +152,24 @@ func (api *API) getJobsQueuedRegionalizedHandler() ([]service.RbacChecker, servi
 			if err != nil {
 				return err
 			}
+			if hatch.ModelType == sdk.WorkerModelTypeAgent {
+				jobs, err := loadQueuedRunJobsForAgent(ctx, api.mustDB(), regionName, *hatch)
+				if err != nil {
+					return err
+				}
+				return service.WriteJSON(w, jobs, http.StatusOK)
+			}
 			jobs, err := workflow_v2.LoadQueuedRunJobByModelTypeAndRegionAndModelOSArch(ctx, api.mustDB(), regionName, hatch.ModelType, osarch)
 			if err != nil {
 				return err
 			}
-			return service.WriteJSON(w, jobs, http.StatusOK)
+			filteredJobs := make([]sdk.V2WorkflowRunJob, 0, len(jobs))
+			for _, j := range jobs {
+				if hatch.CanRunProject(j.ProjectKey) {
+					filteredJobs = append(filteredJobs, j)
+				}
+			}
+			return service.WriteJSON(w, filteredJobs, http.StatusOK)
 		}
 }
 
@@ -277,11 +290,11 @@ func (api *API) postJobResultHandler() ([]service.RbacChecker, service.Handler)
 				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %s is already in a final state %s", jobRun.JobID, jobRun.Status)
 			}
 
-			telemetry.MainSpan(ctx).AddAttributes(trace.StringAttribute(telemetry.TagJob, jobRun.JobID),
-				trace.StringAttribute(telemetry.TagWorkflow, jobRun.WorkflowName),
-				trace.StringAttribute(telemetry.TagProjectKey, jobRun.ProjectKey),
-				trace.StringAttribute(telemetry.TagWorkflowRun, jobRun.WorkflowRunID),
-				trace.StringAttribute(telemetry.TagWorkflowRunNumber, strconv.FormatInt(jobRun.RunNumber, 10)))
+			telemetry.MainSpan(ctx).SetAttributes(attribute.String(telemetry.TagJob, jobRun.JobID),
+				attribute.String(telemetry.TagWorkflow, jobRun.WorkflowName),
+				attribute.String(telemetry.TagProjectKey, jobRun.ProjectKey),
+				attribute.String(telemetry.TagWorkflowRun, jobRun.WorkflowRunID),
+				attribute.String(telemetry.TagWorkflowRunNumber, strconv.FormatInt(jobRun.RunNumber, 10)))
 
 			hatchConsumer := getHatcheryConsumer(ctx)
 			hatch, err := hatchery.LoadHatcheryByID(ctx, api.mustDB(), hatchConsumer.AuthConsumerHatchery.HatcheryID)
@@ -472,6 +485,12 @@ func (api *API) putJobRunResultHandler() ([]service.RbacChecker, service.Handler
 					return
 				}
 				event_v2.PublishRunJobRunResult(ctx, api.Cache, sdk.EventRunJobRunResultUpdated, run.Contexts.Git.Server, run.Contexts.Git.Repository, *runJob, runResult)
+
+				if runResult.Type == sdk.V2WorkflowRunResultTypeTest && runResult.Status == sdk.V2WorkflowRunResultStatusCompleted && oldRunResult.Status != sdk.V2WorkflowRunResultStatusCompleted {
+					if err := api.saveRunResultTestCases(ctx, *run, *runJob, runResult); err != nil {
+						log.ErrorWithStackTrace(ctx, err)
+					}
+				}
 			})
 
 			return service.WriteJSON(w, runResult, http.StatusCreated)
@@ -499,10 +518,18 @@ func (api *API) getCacheLinkHandler() ([]service.RbacChecker, service.Handler) {
 				return err
 			}
 
-			itemsLinks, err := cdn.ListItems(ctx, api.mustDBWithCtx(ctx), sdk.CDNTypeItemWorkerCacheV2, map[string]string{
-				cdn.ParamProjectKey: p.Key,
-				cdn.ParamCacheTag:   cacheKey,
-			})
+			restoreKeys, err := QueryStrings(req, "restoreKey")
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+
+			run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
+			if err != nil {
+				return err
+			}
+
+			// Caches are scoped to the git ref of the job, with fallback on the default branch
+			itemsLinks, err := cdn.SearchWorkerCache(ctx, api.mustDBWithCtx(ctx), p.Key, cacheKey, restoreKeys, sdk.WorkerCacheRefs(run.Contexts.Git))
 			if err != nil {
 				return err
 			}
@@ -696,10 +723,10 @@ func (api *API) postHatcheryTakeJobRunHandler() ([]service.RbacChecker, service.
 				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %s is already in a final state %s", jobRun.JobID, jobRun.Status)
 			}
 
-			telemetry.MainSpan(ctx).AddAttributes(trace.StringAttribute(telemetry.TagJob, jobRun.JobID),
-				trace.StringAttribute(telemetry.TagWorkflow, jobRun.WorkflowName),
-				trace.StringAttribute(telemetry.TagProjectKey, jobRun.ProjectKey),
-				trace.StringAttribute(telemetry.TagWorkflowRunNumber, strconv.FormatInt(jobRun.RunNumber, 10)))
+			telemetry.MainSpan(ctx).SetAttributes(attribute.String(telemetry.TagJob, jobRun.JobID),
+				attribute.String(telemetry.TagWorkflow, jobRun.WorkflowName),
+				attribute.String(telemetry.TagProjectKey, jobRun.ProjectKey),
+				attribute.String(telemetry.TagWorkflowRunNumber, strconv.FormatInt(jobRun.RunNumber, 10)))
 
 			if jobRun.Status != sdk.V2WorkflowRunJobStatusWaiting {
 				return sdk.WrapError(sdk.ErrNotFound, "job has already been taken by %s", jobRun.HatcheryName)
@@ -714,10 +741,27 @@ func (api *API) postHatcheryTakeJobRunHandler() ([]service.RbacChecker, service.
 				return sdk.WithStack(sdk.ErrForbidden)
 			}
 
-			jobRun.HatcheryName = hatch.Name
-			jobRun.Status = sdk.V2WorkflowRunJobStatusScheduling
-			now := time.Now()
-			jobRun.Scheduled = &now
+			if !hatch.CanRunProject(jobRun.ProjectKey) {
+				return sdk.WrapError(sdk.ErrForbidden, "hatchery %s is not allowed to run the jobs of project %s", hatch.Name, jobRun.ProjectKey)
+			}
+
+			// Worker agents can only take the jobs matching their labels, other hatcheries can't take agent jobs
+			if hatch.ModelType == sdk.WorkerModelTypeAgent || jobRun.ModelType == sdk.WorkerModelTypeAgent {
+				agentConfig, err := sdk.NewV2WorkerAgentConfig(hatch.Config)
+				if err != nil {
+					return err
+				}
+				if hatch.ModelType != sdk.WorkerModelTypeAgent || !agentConfig.CanRunJob(*jobRun) {
+					return sdk.WrapError(sdk.ErrForbidden, "hatchery %s cannot run job %s with labels %v", hatch.Name, jobRun.ID, jobRun.Job.RunsOn.Labels)
+				}
+			}
+
+			if err := checkProjectMonthlyQuota(ctx, api.mustDB(), jobRun.ProjectKey); err != nil {
+				if sdk.ErrorIs(err, sdk.ErrQuotaExceeded) {
+					return api.failRunJobOnQuota(ctx, jobRun, err)
+				}
+				return err
+			}
 
 			tx, err := api.mustDB().Begin()
 			if err != nil {
@@ -725,6 +769,15 @@ func (api *API) postHatcheryTakeJobRunHandler() ([]service.RbacChecker, service.
 			}
 			defer tx.Rollback()
 
+			if err := checkProjectConcurrentJobsQuota(ctx, tx, jobRun.ProjectKey); err != nil {
+				return err
+			}
+
+			jobRun.HatcheryName = hatch.Name
+			jobRun.Status = sdk.V2WorkflowRunJobStatusScheduling
+			now := time.Now()
+			jobRun.Scheduled = &now
+
 			if err := workflow_v2.UpdateJobRun(ctx, tx, jobRun); err != nil {
 				return err
 			}
@@ -793,6 +846,25 @@ func (api *API) getJobRunQueueInfoHandler() ([]service.RbacChecker, service.Hand
 		}
 }
 
+// loadQueuedRunJobsForAgent returns the waiting jobs of the region that the worker agent can run
+func loadQueuedRunJobsForAgent(ctx context.Context, db gorp.SqlExecutor, regionName string, agent sdk.Hatchery) ([]sdk.V2WorkflowRunJob, error) {
+	agentConfig, err := sdk.NewV2WorkerAgentConfig(agent.Config)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := workflow_v2.LoadQueuedRunJobByModelTypeAndRegion(ctx, db, regionName, sdk.WorkerModelTypeAgent)
+	if err != nil {
+		return nil, err
+	}
+	filteredJobs := make([]sdk.V2WorkflowRunJob, 0, len(jobs))
+	for _, j := range jobs {
+		if agent.CanRunProject(j.ProjectKey) && agentConfig.CanRunJob(j) {
+			filteredJobs = append(filteredJobs, j)
+		}
+	}
+	return filteredJobs, nil
+}
+
 func hatcheryCanGetJob(ctx context.Context, db gorp.SqlExecutor, regionName string, hatcheryID string) (bool, error) {
 	ctx, next := telemetry.Span(ctx, "hatcheryCanGetJob")
 	defer next()
@@ -151,6 +151,11 @@ func (api *API) postV2WorkerTakeJobHandler() ([]service.RbacChecker, service.Han
 			return err
 		}
 
+		usage := sdk.NewV2WorkflowRunJobUsage(*jobRun)
+		if err := workflow_v2.InsertRunJobUsage(ctx, tx, &usage); err != nil {
+			return err
+		}
+
 		info := sdk.V2WorkflowRunJobInfo{
 			Level:            sdk.WorkflowRunInfoLevelInfo,
 			IssuedAt:         now,
@@ -149,6 +149,9 @@ func (api *API) postImportRBACHandler() ([]service.RbacChecker, service.Handler)
 			if existingRule != nil && !force {
 				return sdk.NewErrorFrom(sdk.ErrForbidden, "unable to override existing permission")
 			}
+			if existingRule != nil && existingRule.ProjectKeyAsCode != "" {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "permission %s is managed as code by project %s", existingRule.Name, existingRule.ProjectKeyAsCode)
+			}
 			if existingRule != nil {
 				if err := rbac.Delete(ctx, tx, *existingRule); err != nil {
 					return err
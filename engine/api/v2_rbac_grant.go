@@ -0,0 +1,373 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getUserPermissionGrantsHandler returns the grant requests of a user
+func (api *API) getUserPermissionGrantsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isCurrentUserOrPermissionManager),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			u, err := user.LoadByUsername(ctx, api.mustDB(), vars["user"])
+			if err != nil {
+				return sdk.WrapError(err, "cannot load user %s", vars["user"])
+			}
+			grants, err := rbac.LoadGrantRequests(ctx, api.mustDB(), u.ID, FormString(req, "status"))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, grants, http.StatusOK)
+		}
+}
+
+// postUserPermissionGrantHandler requests a temporary role for the current user
+func (api *API) postUserPermissionGrantHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isCurrentUser),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			u, err := user.LoadByID(ctx, api.mustDB(), getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUser.ID, user.LoadOptions.WithOrganization)
+			if err != nil {
+				return err
+			}
+
+			var g sdk.RBACGrantRequest
+			if err := service.UnmarshalRequest(ctx, req, &g); err != nil {
+				return err
+			}
+			g.UserID = u.ID
+			g.Username = u.Username
+			g.Organization = u.Organization
+			g.Status = sdk.RBACGrantRequestStatusPending
+			g.Reviewer, g.ReviewComment, g.RBACName = "", "", ""
+			g.Reviewed, g.ExpireAt = nil, nil
+			if err := g.Check(api.Config.Auth.PermissionGrantMaxDuration); err != nil {
+				return err
+			}
+			if g.Access().Scope() == sdk.RBACScopeRegion && g.Organization == "" {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "a role on a region cannot be granted to a user without organization")
+			}
+
+			// Grant requests are reviewed by the users that can manage permissions
+			approverIDs, err := rbac.LoadUserIDsWithGlobalRole(ctx, api.mustDB(), sdk.GlobalRoleManagePermission)
+			if err != nil {
+				return err
+			}
+			approvers, err := user.LoadAllByIDs(ctx, api.mustDB(), approverIDs)
+			if err != nil {
+				return err
+			}
+			g.Approvers = make(sdk.StringSlice, 0, len(approvers))
+			for _, a := range approvers {
+				if a.ID != u.ID {
+					g.Approvers = append(g.Approvers, a.Username)
+				}
+			}
+			sort.Strings(g.Approvers)
+			if len(g.Approvers) == 0 {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "there is no user with role %s to approve the request", sdk.GlobalRoleManagePermission)
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			if err := rbac.InsertGrantRequest(ctx, tx, &g); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			event_v2.PublishPermissionGrantEvent(ctx, api.Cache, sdk.EventPermissionGrantRequested, g, u)
+			return service.WriteJSON(w, g, http.StatusCreated)
+		}
+}
+
+// getPermissionGrantsHandler returns all the grant requests, filtered by status
+func (api *API) getPermissionGrantsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalPermissionManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			grants, err := rbac.LoadGrantRequests(ctx, api.mustDB(), "", FormString(req, "status"))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, grants, http.StatusOK)
+		}
+}
+
+func (api *API) getPermissionGrantHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalPermissionManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			g, err := rbac.LoadGrantRequestByID(ctx, api.mustDB(), vars["grantID"])
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, g, http.StatusOK)
+		}
+}
+
+func (api *API) postPermissionGrantApproveHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalPermissionManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			return api.reviewPermissionGrant(ctx, w, req, true)
+		}
+}
+
+func (api *API) postPermissionGrantRejectHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalPermissionManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			return api.reviewPermissionGrant(ctx, w, req, false)
+		}
+}
+
+// reviewPermissionGrant approves or rejects a pending grant request. An approval creates a permission that expires after the requested duration.
+func (api *API) reviewPermissionGrant(ctx context.Context, w http.ResponseWriter, req *http.Request, approve bool) error {
+	vars := mux.Vars(req)
+	u := getUserConsumer(ctx)
+	if u == nil {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	reviewer := u.AuthConsumerUser.AuthentifiedUser
+
+	var review sdk.RBACGrantRequestReview
+	if err := service.UnmarshalRequest(ctx, req, &review); err != nil {
+		return err
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	g, err := rbac.LoadAndLockGrantRequestByID(ctx, tx, vars["grantID"])
+	if err != nil {
+		return err
+	}
+	if g.Status != sdk.RBACGrantRequestStatusPending {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "grant request %s is %s", g.ID, g.Status)
+	}
+	if g.UserID == reviewer.ID {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "you cannot review your own grant request")
+	}
+
+	now := time.Now()
+	g.Reviewer = reviewer.Username
+	g.ReviewComment = review.Comment
+	g.Reviewed = &now
+	g.Status = sdk.RBACGrantRequestStatusRejected
+
+	var perm sdk.RBAC
+	if approve {
+		expireAt := now.Add(time.Duration(g.Duration) * time.Minute)
+		perm = g.RBAC(expireAt)
+		if err := NewRBACLoader(tx).FillRBACWithIDs(ctx, &perm); err != nil {
+			return err
+		}
+		if err := rbac.Insert(ctx, tx, &perm); err != nil {
+			return err
+		}
+		g.Status = sdk.RBACGrantRequestStatusApproved
+		g.RBACName = perm.Name
+		g.ExpireAt = &expireAt
+	}
+
+	if err := rbac.UpdateGrantRequest(ctx, tx, g); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	if approve {
+		event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionCreated, perm, *reviewer)
+		event_v2.PublishPermissionGrantEvent(ctx, api.Cache, sdk.EventPermissionGrantApproved, *g, reviewer)
+	} else {
+		event_v2.PublishPermissionGrantEvent(ctx, api.Cache, sdk.EventPermissionGrantRejected, *g, reviewer)
+	}
+	return service.WriteJSON(w, g, http.StatusOK)
+}
+
+// postPermissionGrantRevokeHandler removes the permission of an approved grant request before its expiry
+func (api *API) postPermissionGrantRevokeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalPermissionManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var review sdk.RBACGrantRequestReview
+			if err := service.UnmarshalRequest(ctx, req, &review); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			g, err := rbac.LoadAndLockGrantRequestByID(ctx, tx, vars["grantID"])
+			if err != nil {
+				return err
+			}
+			if g.Status != sdk.RBACGrantRequestStatusApproved {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "grant request %s is %s", g.ID, g.Status)
+			}
+			perm, err := revokeGrantRequest(ctx, tx, g, sdk.RBACGrantRequestStatusRevoked)
+			if err != nil {
+				return err
+			}
+			if review.Comment != "" {
+				g.ReviewComment = review.Comment
+			}
+			g.Reviewer = u.AuthConsumerUser.AuthentifiedUser.Username
+			if err := rbac.UpdateGrantRequest(ctx, tx, g); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			if perm != nil {
+				event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionDeleted, *perm, *u.AuthConsumerUser.AuthentifiedUser)
+			}
+			event_v2.PublishPermissionGrantEvent(ctx, api.Cache, sdk.EventPermissionGrantRevoked, *g, u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, g, http.StatusOK)
+		}
+}
+
+// revokeGrantRequest deletes the permission of a grant request and sets its status. It returns the deleted permission, nil if it was already removed.
+func revokeGrantRequest(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, g *sdk.RBACGrantRequest, status string) (*sdk.RBAC, error) {
+	perm, err := rbac.LoadRBACByName(ctx, tx, g.RBACName)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	if perm != nil {
+		if err := rbac.Delete(ctx, tx, *perm); err != nil {
+			return nil, err
+		}
+	}
+	g.Status = status
+	return perm, nil
+}
+
+// revokeExpiredPermissions deletes the permissions that have expired, and closes the grant requests that gave them
+func (api *API) revokeExpiredPermissions(ctx context.Context, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "%v", ctx.Err())
+			}
+			return
+		case <-ticker.C:
+			now := time.Now()
+			grants, err := rbac.LoadExpiredGrantRequests(ctx, api.mustDB(), now)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			for _, g := range grants {
+				if err := api.expireGrantRequest(ctx, g.ID); err != nil {
+					log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to expire grant request %s", g.ID))
+				}
+			}
+
+			perms, err := rbac.LoadExpiredRBAC(ctx, api.mustDB(), now)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			for _, perm := range perms {
+				if err := api.expirePermission(ctx, perm.ID); err != nil {
+					log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to delete expired permission %s", perm.Name))
+				}
+			}
+		}
+	}
+}
+
+func (api *API) expireGrantRequest(ctx context.Context, id string) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	g, err := rbac.LoadAndLockGrantRequestByID(ctx, tx, id)
+	if err != nil {
+		// Already handled by another API instance
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if g.Status != sdk.RBACGrantRequestStatusApproved {
+		return nil
+	}
+	perm, err := revokeGrantRequest(ctx, tx, g, sdk.RBACGrantRequestStatusExpired)
+	if err != nil {
+		return err
+	}
+	if err := rbac.UpdateGrantRequest(ctx, tx, g); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	if perm != nil {
+		event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionExpired, *perm, sdk.AuthentifiedUser{})
+	}
+	event_v2.PublishPermissionGrantEvent(ctx, api.Cache, sdk.EventPermissionGrantExpired, *g, nil)
+	return nil
+}
+
+func (api *API) expirePermission(ctx context.Context, id string) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	perm, err := rbac.LoadAndLockRBACByID(ctx, tx, id)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !perm.IsExpired(time.Now()) {
+		return nil
+	}
+	if err := rbac.Delete(ctx, tx, *perm); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+	event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionExpired, *perm, sdk.AuthentifiedUser{})
+	return nil
+}
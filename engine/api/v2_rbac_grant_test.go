@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_permissionGrantRequestApproveAndExpire(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+
+	p := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	u, pass := assets.InsertLambdaUser(t, db)
+	approver, approverPass := assets.InsertLambdaUser(t, db)
+
+	rbManager := sdk.RBAC{
+		Name:   sdk.RandomString(10),
+		Global: []sdk.RBACGlobal{{Role: sdk.GlobalRoleManagePermission, RBACUsersIDs: []string{approver.ID}}},
+	}
+	require.NoError(t, rbac.Insert(context.TODO(), db, &rbManager))
+
+	post := func(uri string, user *sdk.AuthentifiedUser, password string, body interface{}) *httptest.ResponseRecorder {
+		req := assets.NewAuthentifiedRequest(t, user, password, "POST", uri, nil)
+		bts, _ := json.Marshal(body)
+		req.Body = io.NopCloser(bytes.NewReader(bts))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// Request a temporary manage role on the project
+	uri := api.Router.GetRouteV2("POST", api.postUserPermissionGrantHandler, map[string]string{"user": u.Username})
+	test.NotEmpty(t, uri)
+	w := post(uri, u, pass, sdk.RBACGrantRequest{Role: sdk.ProjectRoleManage, ProjectKey: p.Key, Justification: "incident", Duration: 60})
+	require.Equal(t, 201, w.Code, w.Body.String())
+	var g sdk.RBACGrantRequest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	require.Equal(t, sdk.RBACGrantRequestStatusPending, g.Status)
+	require.Contains(t, g.Approvers, approver.Username)
+
+	// The requester cannot approve its own request
+	vars := map[string]string{"grantID": g.ID}
+	uriApprove := api.Router.GetRouteV2("POST", api.postPermissionGrantApproveHandler, vars)
+	test.NotEmpty(t, uriApprove)
+	w = post(uriApprove, u, pass, sdk.RBACGrantRequestReview{})
+	require.Equal(t, 403, w.Code)
+
+	w = post(uriApprove, approver, approverPass, sdk.RBACGrantRequestReview{Comment: "ok"})
+	require.Equal(t, 200, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	require.Equal(t, sdk.RBACGrantRequestStatusApproved, g.Status)
+	require.Equal(t, approver.Username, g.Reviewer)
+	require.NotNil(t, g.ExpireAt)
+
+	perm, err := rbac.LoadRBACByName(context.TODO(), db, g.RBACName, rbac.LoadOptions.All)
+	require.NoError(t, err)
+	require.NotNil(t, perm.ExpireAt)
+	require.Len(t, perm.Projects, 1)
+	require.Equal(t, []string{p.Key}, perm.Projects[0].RBACProjectKeys)
+
+	// An approved request cannot be reviewed again
+	w = post(uriApprove, approver, approverPass, sdk.RBACGrantRequestReview{})
+	require.Equal(t, 400, w.Code)
+
+	hasRole, err := rbac.HasRoleOnProjectAndUserID(context.TODO(), db, sdk.ProjectRoleManage, u.ID, p.Key)
+	require.NoError(t, err)
+	require.True(t, hasRole)
+
+	// An expired permission is not granted anymore, even before it is removed
+	past := time.Now().Add(-time.Minute)
+	perm.ExpireAt = &past
+	require.NoError(t, rbac.Update(context.TODO(), db, perm))
+	hasRole, err = rbac.HasRoleOnProjectAndUserID(context.TODO(), db, sdk.ProjectRoleManage, u.ID, p.Key)
+	require.NoError(t, err)
+	require.False(t, hasRole)
+
+	// Once expired, the permission is removed
+	_, err = db.Exec("UPDATE rbac_grant_request SET expire_at = $1 WHERE id = $2", past, g.ID)
+	require.NoError(t, err)
+	require.NoError(t, api.expireGrantRequest(context.TODO(), g.ID))
+
+	expired, err := rbac.LoadGrantRequestByID(context.TODO(), db, g.ID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.RBACGrantRequestStatusExpired, expired.Status)
+	_, err = rbac.LoadRBACByName(context.TODO(), db, g.RBACName)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
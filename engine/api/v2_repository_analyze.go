@@ -21,7 +21,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rockbears/log"
 	"github.com/rockbears/yaml"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/entity"
@@ -379,7 +380,7 @@ func (api *API) repositoryAnalysisPoller(ctx context.Context, tick time.Duration
 					ctx,
 					"repositoryAnalysisPoller-"+a.ID,
 					func(ctx context.Context) {
-						ctx = telemetry.New(ctx, api, "api.repositoryAnalysisPoller", nil, trace.SpanKindUnspecified)
+						ctx = telemetry.New(ctx, api, "api.repositoryAnalysisPoller", trace.SpanKindUnspecified)
 						if err := api.analyzeRepository(ctx, a.ProjectRepositoryID, a.ID); err != nil {
 							log.ErrorWithStackTrace(ctx, err)
 						}
@@ -647,6 +648,11 @@ skipEntity:
 		return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to retrieve default branch on repository %s", repo.Name))
 	}
 
+	// Compare or apply the permissions managed as code by the project
+	if err := api.analyzeRBACAsCode(ctx, analysis, *repo, defaultBranch, filesContent); err != nil {
+		return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(err, "unable to analyze permissions"))
+	}
+
 	var currentAnalysisBranch *sdk.VCSBranch
 	var currentAnalysisTag sdk.VCSTag
 	if analysis.Ref == defaultBranch.ID {
@@ -839,11 +845,18 @@ skipEntity:
 	}
 
 	// Update analysis
+	nbFiles := len(analysis.Data.Entities)
+	if analysis.Data.RBAC != nil {
+		nbFiles++
+		if analysis.Data.RBAC.Error != "" {
+			skippedFiles = append(skippedFiles, "Permissions not applied: "+analysis.Data.RBAC.Error)
+		}
+	}
 	skippedFiles.Unique()
 	analysis.Data.Error = strings.Join(skippedFiles, "\n")
-	if len(skippedFiles) == len(analysis.Data.Entities) {
+	if len(skippedFiles) == nbFiles {
 		analysis.Status = sdk.RepositoryAnalysisStatusSkipped
-		if len(analysis.Data.Entities) == 0 {
+		if nbFiles == 0 {
 			analysis.Data.Error = "no file found"
 		}
 	} else if len(schedulers) == 0 {
@@ -1299,7 +1312,7 @@ func sendAnalysisHookCallback(ctx context.Context, db *gorp.DbMap, analysis sdk.
 }
 
 func findCommitter(ctx context.Context, cache cache.Store, db *gorp.DbMap, ref, sha, signKeyID, projKey string, vcsProjectWithSecret sdk.VCSProject, repoName string, vcsPublicKeys map[string][]GPGKey) (*sdk.V2Initiator, string, string, error) {
-	ctx, next := telemetry.Span(ctx, "findCommitter", trace.StringAttribute(telemetry.TagProjectKey, projKey), trace.StringAttribute(telemetry.TagVCSServer, vcsProjectWithSecret.Name), trace.StringAttribute(telemetry.TagRepository, repoName))
+	ctx, next := telemetry.Span(ctx, "findCommitter", attribute.String(telemetry.TagProjectKey, projKey), attribute.String(telemetry.TagVCSServer, vcsProjectWithSecret.Name), attribute.String(telemetry.TagRepository, repoName))
 	defer next()
 
 	// Search if gpg key is owned by a CDS suer
@@ -1731,7 +1744,8 @@ func Lint[T sdk.Lintable](ctx context.Context, db *gorp.DbMap, store cache.Store
 			}
 			for jobID, j := range x.Jobs {
 				// Check if worker model exists
-				if !strings.Contains(j.RunsOn.Model, "${{") && len(j.Steps) > 0 {
+				// Jobs targeting worker agents with labels don't use a worker model
+				if !strings.Contains(j.RunsOn.Model, "${{") && len(j.Steps) > 0 && len(j.RunsOn.Labels) == 0 {
 					_, msg, errSearch := ef.searchWorkerModel(ctx, db, store, j.RunsOn.Model)
 					if errSearch != nil {
 						err = append(err, errSearch)
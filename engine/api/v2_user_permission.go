@@ -3,9 +3,12 @@ package api
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/link"
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/service"
@@ -28,12 +31,162 @@ func (api *API) getUserPermissionHandler() ([]service.RbacChecker, service.Handl
 			}
 
 			rbacLoader := NewRBACLoader(api.mustDB())
+			now := time.Now()
+			activePermissions := make([]sdk.RBAC, 0, len(permissions))
 			for i := range permissions {
 				perm := &permissions[i]
+				if perm.IsExpired(now) {
+					continue
+				}
 				if err := rbacLoader.FillRBACWithNames(ctx, perm); err != nil {
 					return err
 				}
+				activePermissions = append(activePermissions, *perm)
+			}
+			return service.WriteJSON(w, sdk.RBACsToPermissionSummary(activePermissions), http.StatusOK)
+		}
+}
+
+// loadRBACSubjectRules returns the user, its groups, organization and linked VCS users, and all the permission rules that apply to it.
+func (api *API) loadRBACSubjectRules(ctx context.Context, u *sdk.AuthentifiedUser) (sdk.RBACSubject, []sdk.RBACRule, error) {
+	subject := sdk.RBACSubject{
+		Username:     u.Username,
+		Organization: u.Organization,
+	}
+	groups, err := group.LoadAllByUserID(ctx, api.mustDB(), u.ID)
+	if err != nil {
+		return subject, nil, err
+	}
+	for _, g := range groups {
+		subject.Groups = append(subject.Groups, g.Name)
+	}
+	links, err := link.LoadUserLinksByUserID(ctx, api.mustDB(), u.ID)
+	if err != nil {
+		return subject, nil, err
+	}
+	for _, l := range links {
+		subject.VCSUsers = append(subject.VCSUsers, sdk.RBACVCSUser{VCSServer: l.Type, VCSUsername: l.Username})
+	}
+
+	// Permissions given to all users are not linked to the user, so every permission is checked
+	permissions, err := rbac.LoadAll(ctx, api.mustDB(), rbac.LoadOptions.All)
+	if err != nil {
+		return subject, nil, err
+	}
+	rbacLoader := NewRBACLoader(api.mustDB())
+	now := time.Now()
+	activePermissions := make([]sdk.RBAC, 0, len(permissions))
+	for i := range permissions {
+		// Expired permissions are not granted anymore, even if they are not deleted yet
+		if permissions[i].IsExpired(now) {
+			continue
+		}
+		if err := rbacLoader.FillRBACWithNames(ctx, &permissions[i]); err != nil {
+			return subject, nil, err
+		}
+		activePermissions = append(activePermissions, permissions[i])
+	}
+	return subject, sdk.RBACSubjectRules(activePermissions, subject), nil
+}
+
+// getUserPermissionRulesHandler returns every role given to the user, with the permission that gives it
+func (api *API) getUserPermissionRulesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isCurrentUserOrPermissionManager),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			username := vars["user"]
+			u, err := user.LoadByUsername(ctx, api.mustDB(), username, user.LoadOptions.WithOrganization)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load user %s", username)
 			}
-			return service.WriteJSON(w, sdk.RBACsToPermissionSummary(permissions), http.StatusOK)
+			_, rules, err := api.loadRBACSubjectRules(ctx, u)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, rules, http.StatusOK)
+		}
+}
+
+// getUserPermissionExplainHandler runs the permission check of a role on a resource for the user, and explains its result
+func (api *API) getUserPermissionExplainHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isCurrentUserOrPermissionManager),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			explainRequest := sdk.RBACExplainRequest{
+				Username:    vars["user"],
+				Role:        FormString(req, "role"),
+				ProjectKey:  FormString(req, "project"),
+				VCSServer:   FormString(req, "vcs"),
+				Repository:  FormString(req, "repository"),
+				Workflow:    FormString(req, "workflow"),
+				VariableSet: FormString(req, "variableset"),
+				Region:      FormString(req, "region"),
+			}
+			if err := explainRequest.Check(); err != nil {
+				return err
+			}
+
+			u, err := user.LoadByUsername(ctx, api.mustDB(), explainRequest.Username, user.LoadOptions.WithOrganization)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load user %s", explainRequest.Username)
+			}
+
+			allowed, err := api.checkUserRole(ctx, u, explainRequest)
+			if err != nil {
+				return err
+			}
+
+			subject, rules, err := api.loadRBACSubjectRules(ctx, u)
+			if err != nil {
+				return err
+			}
+			explanation := sdk.RBACExplanation{
+				Request: explainRequest,
+				Allowed: allowed,
+			}
+			explanation.Explain(subject, rules)
+			if !allowed && u.Ring == sdk.UserRingAdmin {
+				explanation.Reason += ", but as an administrator the user can bypass the check with a MFA session"
+			}
+			return service.WriteJSON(w, explanation, http.StatusOK)
+		}
+}
+
+// checkUserRole runs, for the given user, the same checker as the routes protected by the requested role.
+func (api *API) checkUserRole(ctx context.Context, u *sdk.AuthentifiedUser, r sdk.RBACExplainRequest) (bool, error) {
+	consumer := &sdk.AuthUserConsumer{
+		AuthConsumer: sdk.AuthConsumer{Name: u.Username},
+		AuthConsumerUser: sdk.AuthUserConsumerData{
+			AuthentifiedUserID: u.ID,
+			AuthentifiedUser:   u,
+		},
+	}
+	// The context of the request is not used to not check the session of the current user (MFA...)
+	checkCtx := context.WithValue(context.Background(), contextUserConsumer, consumer)
+
+	var err error
+	switch r.Scope() {
+	case sdk.RBACScopeGlobal:
+		err = api.hasGlobalRole(checkCtx, r.Role)
+	case sdk.RBACScopeProject:
+		err = api.hasRoleOnProject(checkCtx, map[string]string{"projectKey": r.ProjectKey}, r.Role)
+	case sdk.RBACScopeWorkflow:
+		err = api.hasRoleOnWorkflow(checkCtx, map[string]string{
+			"projectKey":           r.ProjectKey,
+			"vcsIdentifier":        r.VCSServer,
+			"repositoryIdentifier": r.Repository,
+			"workflow":             r.Workflow,
+		}, r.Role)
+	case sdk.RBACScopeVariableSet:
+		err = api.hasRoleOnVariableSet(checkCtx, map[string]string{"projectKey": r.ProjectKey, "variableSetName": r.VariableSet}, r.Role)
+	case sdk.RBACScopeRegion:
+		err = api.hasRoleOnRegion(checkCtx, map[string]string{"regionIdentifier": r.Region}, r.Role)
+	}
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrForbidden) {
+			return false, nil
 		}
+		return false, err
+	}
+	return true, nil
 }
@@ -135,3 +135,67 @@ func Test_getUserPermissionHandler_unknownUser(t *testing.T) {
 	api.Router.Mux.ServeHTTP(w, req)
 	require.Equal(t, 404, w.Code)
 }
+
+func Test_getUserPermissionExplainHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+
+	p := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	u, pass := assets.InsertLambdaUser(t, db)
+
+	rb := sdk.RBAC{
+		Name: sdk.RandomString(10),
+		Projects: []sdk.RBACProject{
+			{
+				Role:            sdk.ProjectRoleManageWorkflow,
+				RBACProjectKeys: []string{p.Key},
+				RBACUsersIDs:    []string{u.ID},
+			},
+		},
+	}
+	require.NoError(t, rbac.Insert(context.TODO(), db, &rb))
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM rbac WHERE id = $1", rb.ID)
+	})
+
+	explain := func(role string) sdk.RBACExplanation {
+		vars := map[string]string{"user": u.Username}
+		uri := api.Router.GetRouteV2("GET", api.getUserPermissionExplainHandler, vars)
+		test.NotEmpty(t, uri)
+		req := assets.NewAuthentifiedRequest(t, u, pass, "GET", uri+"?project="+p.Key+"&role="+role, nil)
+		w := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(w, req)
+		require.Equal(t, 200, w.Code, w.Body.String())
+		var explanation sdk.RBACExplanation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &explanation))
+		return explanation
+	}
+
+	explanation := explain(sdk.ProjectRoleManageWorkflow)
+	require.True(t, explanation.Allowed)
+	require.Len(t, explanation.GrantedBy, 1)
+	require.Equal(t, rb.Name, explanation.GrantedBy[0].RBACName)
+	require.Equal(t, "user "+u.Username, explanation.GrantedBy[0].Through)
+
+	explanation = explain(sdk.ProjectRoleManage)
+	require.False(t, explanation.Allowed)
+	require.Empty(t, explanation.GrantedBy)
+	require.Len(t, explanation.OtherRules, 1)
+	require.Contains(t, explanation.Reason, "no permission gives role manage on project "+p.Key)
+
+	// Effective permissions
+	vars := map[string]string{"user": u.Username}
+	uri := api.Router.GetRouteV2("GET", api.getUserPermissionRulesHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, u, pass, "GET", uri, nil)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	var rules []sdk.RBACRule
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rules))
+	require.Len(t, rules, 1)
+	require.Equal(t, sdk.RBACScopeProject, rules[0].Scope)
+	require.Equal(t, p.Key, rules[0].Resource)
+}
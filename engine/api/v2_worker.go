@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/base64"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/hatchery"
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/region"
 	"github.com/ovh/cds/engine/api/worker_v2"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -55,3 +59,42 @@ func (api *API) getWorkerV2Handler() ([]service.RbacChecker, service.Handler) {
 			return service.WriteJSON(w, wkr, http.StatusOK)
 		}
 }
+
+func (api *API) getWorkerAgentsV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalListHatcheries),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			hatcheries, err := hatchery.LoadHatcheries(ctx, api.mustDB())
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			agents := make([]sdk.V2WorkerAgent, 0)
+			for _, h := range hatcheries {
+				if h.ModelType != sdk.WorkerModelTypeAgent {
+					continue
+				}
+				var regionName string
+				rbacHatchery, err := rbac.LoadRBACHatcheryByHatcheryID(ctx, api.mustDB(), h.ID)
+				if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+					return err
+				}
+				if rbacHatchery != nil {
+					reg, err := region.LoadRegionByID(ctx, api.mustDB(), rbacHatchery.RegionID)
+					if err != nil {
+						return err
+					}
+					regionName = reg.Name
+				}
+				wks, err := worker_v2.LoadAllWorkersByHatcheryID(ctx, api.mustDB(), h.ID)
+				if err != nil {
+					return err
+				}
+				agent, err := sdk.NewV2WorkerAgent(h, regionName, wks, now)
+				if err != nil {
+					return err
+				}
+				agents = append(agents, agent)
+			}
+			return service.WriteJSON(w, agents, http.StatusOK)
+		}
+}
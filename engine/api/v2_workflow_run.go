@@ -15,7 +15,7 @@ import (
 	"github.com/mitchellh/hashstructure"
 	"github.com/rockbears/log"
 	"github.com/rockbears/yaml"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ovh/cds/engine/api/entity"
 	"github.com/ovh/cds/engine/api/event_v2"
@@ -1212,6 +1212,14 @@ func (api *API) postRestartWorkflowRunHandler() ([]service.RbacChecker, service.
 				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to rerun a running workflow")
 			}
 
+			var restartRequest sdk.V2WorkflowRunRestartRequest
+			if err := service.UnmarshalBody(req, &restartRequest); err != nil {
+				return err
+			}
+			if err := restartRequest.Check(); err != nil {
+				return err
+			}
+
 			runJobs, err := workflow_v2.LoadRunJobsByRunID(ctx, api.mustDB(), wr.ID, wr.RunAttempt)
 			if err != nil {
 				return err
@@ -1256,6 +1264,39 @@ func (api *API) postRestartWorkflowRunHandler() ([]service.RbacChecker, service.
 				}
 			}
 
+			// A job runs again if it is restarted or if none of its run jobs is kept
+			keptJobIDs := make(map[string]struct{})
+			for _, rj := range runJobsToKeep {
+				keptJobIDs[rj.JobID] = struct{}{}
+			}
+			runsAgain := func(jobID string) bool {
+				_, restarted := runJobToRestart[jobID]
+				_, kept := keptJobIDs[jobID]
+				return restarted || !kept
+			}
+
+			// Overrides can only be given to the jobs that run again, and gate inputs to the restarted jobs
+			for jobID, jobRerun := range restartRequest.Jobs {
+				if _, has := wr.WorkflowData.Workflow.Jobs[jobID]; !has {
+					return sdk.NewErrorFrom(sdk.ErrInvalidData, "job %q not found in workflow %q", jobID, wr.WorkflowName)
+				}
+				if !runsAgain(jobID) {
+					return sdk.NewErrorFrom(sdk.ErrInvalidData, "job %q is not restarted", jobID)
+				}
+				if err := jobRerun.CheckJob(jobID, wr.WorkflowData.Workflow.Jobs[jobID]); err != nil {
+					return err
+				}
+				if len(jobRerun.Inputs) == 0 {
+					continue
+				}
+				if _, has := runJobToRestart[jobID]; !has {
+					return sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to send inputs to job %q, only restarted jobs accept inputs", jobID)
+				}
+				if err := sdk.CheckJobInputWithGate(wr.WorkflowData.Workflow, jobID, jobRerun.Inputs); err != nil {
+					return err
+				}
+			}
+
 			tx, err := api.mustDB().Begin()
 			if err != nil {
 				return sdk.WithStack(err)
@@ -1266,36 +1307,50 @@ func (api *API) postRestartWorkflowRunHandler() ([]service.RbacChecker, service.
 				return err
 			}
 
+			msg := u.GetFullname() + " restarted all failed and stopped jobs"
+			if len(restartRequest.Env) > 0 || restartRequest.Debug || len(restartRequest.Jobs) > 0 {
+				msg += " with overrides"
+			}
 			runInfo := sdk.V2WorkflowRunInfo{
 				WorkflowRunID: wr.ID,
 				IssuedAt:      time.Now(),
 				Level:         sdk.WorkflowRunInfoLevelInfo,
-				Message:       u.GetFullname() + " restarted all failed and stopped jobs",
+				Message:       msg,
 			}
 			if err := workflow_v2.InsertRunInfo(ctx, tx, &runInfo); err != nil {
 				return err
 			}
 
 			// For each job to restart that has a gate, reuse the previous GateInputs as-is
-			// (they are already complete) and just ensure manual=true.
+			// (they are already complete), apply the given inputs and just ensure manual=true.
+			// Jobs that run again with overrides also get an event.
 			updateRun := false
-			for _, rj := range runJobToRestart {
-				if rj.Job.Gate != "" {
-					updateRun = true
+			for jobID := range wr.WorkflowData.Workflow.Jobs {
+				if !runsAgain(jobID) {
+					continue
+				}
+				runJobEvent := sdk.V2WorkflowRunJobEvent{
+					UserID:     u.AuthConsumerUser.AuthentifiedUserID,
+					Username:   u.GetUsername(),
+					JobID:      jobID,
+					RunAttempt: wr.RunAttempt,
+					Overrides:  restartRequest.JobOverrides(jobID),
+				}
+				if rj, has := runJobToRestart[jobID]; has && rj.Job.Gate != "" {
 					inputs := make(map[string]interface{})
 					for k, v := range rj.GateInputs {
 						inputs[k] = v
 					}
-					inputs["manual"] = true
-					runJobEvent := sdk.V2WorkflowRunJobEvent{
-						Inputs:     inputs,
-						UserID:     u.AuthConsumerUser.AuthentifiedUserID,
-						Username:   u.GetUsername(),
-						JobID:      rj.JobID,
-						RunAttempt: wr.RunAttempt,
+					for k, v := range restartRequest.Jobs[jobID].Inputs {
+						inputs[k] = v
 					}
-					wr.RunJobEvent = append(wr.RunJobEvent, runJobEvent)
+					inputs["manual"] = true
+					runJobEvent.Inputs = inputs
+				} else if runJobEvent.Overrides == nil {
+					continue
 				}
+				updateRun = true
+				wr.RunJobEvent = append(wr.RunJobEvent, runJobEvent)
 			}
 			if updateRun {
 				if err := workflow_v2.UpdateRun(ctx, tx, wr); err != nil {
@@ -1470,8 +1525,8 @@ func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
 			}
 
 			for _, jtr := range jobToRuns {
-				if jtr.Status == sdk.V2WorkflowRunJobStatusSkipped && jtr.Job.Gate == "" {
-					return sdk.NewErrorFrom(sdk.ErrForbidden, "unable to start a skipped job without a gate")
+				if jtr.Status == sdk.V2WorkflowRunJobStatusSkipped && jtr.Job.Gate == "" && jtr.Job.Environment == "" {
+					return sdk.NewErrorFrom(sdk.ErrForbidden, "unable to start a skipped job without a gate or an environment")
 				}
 			}
 
@@ -1493,6 +1548,15 @@ func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
 			if err := service.UnmarshalBody(req, &inputs); err != nil {
 				return err
 			}
+			overrides, err := runJobOverridesFromRequest(req)
+			if err != nil {
+				return err
+			}
+			if overrides != nil {
+				if err := overrides.CheckJob(jobToRuns[0].JobID, jobToRuns[0].Job); err != nil {
+					return err
+				}
+			}
 			if inputs == nil {
 				inputs = jobToRuns[0].GateInputs
 			}
@@ -1560,6 +1624,7 @@ func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
 				Username:   u.GetUsername(),
 				JobID:      jobToRuns[0].JobID,
 				RunAttempt: wr.RunAttempt,
+				Overrides:  overrides,
 			})
 			if err := workflow_v2.UpdateRun(ctx, tx, wr); err != nil {
 				return err
@@ -1571,6 +1636,9 @@ func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
 				IssuedAt:      time.Now(),
 				Message:       fmt.Sprintf("%s has manually triggered the job %q", u.GetFullname(), jobToRuns[0].JobID),
 			}
+			if overrides != nil {
+				runMsg.Message += " with overrides"
+			}
 			if err := workflow_v2.InsertRunInfo(ctx, tx, &runMsg); err != nil {
 				return err
 			}
@@ -1588,6 +1656,31 @@ func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
 		}
 }
 
+// runJobOverridesFromRequest reads the overrides of a re-run job from the query parameters: env (KEY=VALUE, can be
+// repeated), model and debug.
+func runJobOverridesFromRequest(req *http.Request) (*sdk.V2WorkflowRunJobOverrides, error) {
+	q := req.URL.Query()
+	o := sdk.V2WorkflowRunJobOverrides{
+		Env:   make(map[string]string),
+		Model: q.Get("model"),
+		Debug: service.FormBool(req, "debug"),
+	}
+	for _, e := range q["env"] {
+		k, v, ok := strings.Cut(e, "=")
+		if !ok {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid env %q, expected KEY=VALUE", e)
+		}
+		o.Env[k] = v
+	}
+	if err := o.Check(); err != nil {
+		return nil, err
+	}
+	if o.IsEmpty() {
+		return nil, nil
+	}
+	return &o, nil
+}
+
 func (api *API) postWorkflowRunV2Handler() ([]service.RbacChecker, service.Handler) {
 	return service.RBAC(api.workflowTrigger),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
@@ -1753,6 +1846,7 @@ func (api *API) startWorkflowV2(ctx context.Context, proj sdk.Project, vcsProjec
 		CommitMessage:      runRequest.CommitMessage,
 		CommitAuthor:       runRequest.CommitAuthor,
 		CommitAuthorEmail:  runRequest.CommitAuthorEmail,
+		CommitDate:         runRequest.CommitDate,
 		SemverCurrent:      runRequest.SemverCurrent,
 		SemverNext:         runRequest.SemverNext,
 		ChangeSets:         runRequest.ChangeSets,
@@ -1817,7 +1911,7 @@ func (api *API) startWorkflowV2(ctx context.Context, proj sdk.Project, vcsProjec
 	}
 	wr.RunNumber = wrNumber
 
-	telemetry.MainSpan(ctx).AddAttributes(trace.StringAttribute(telemetry.TagWorkflowRunNumber, strconv.FormatInt(wrNumber, 10)))
+	telemetry.MainSpan(ctx).SetAttributes(attribute.String(telemetry.TagWorkflowRunNumber, strconv.FormatInt(wrNumber, 10)))
 
 	for jobID, inputs := range runRequest.JobInputs {
 		if inputs == nil {
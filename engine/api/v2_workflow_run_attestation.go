@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/gpg"
+)
+
+// signV2Attestation signs the payload with the attestation PGP key of the project, or with the instance attestation key if the project does not have one.
+func signV2Attestation(keys []sdk.ProjectKey, payloadType string, payload []byte) (*sdk.V2AttestationSignResponse, error) {
+	for _, k := range keys {
+		if k.Name != sdk.AttestationProjectKeyName || k.Type != sdk.KeyTypePGP || k.Disabled {
+			continue
+		}
+		privateKey, err := gpg.NewPrivateKeyFromPem(k.Private, "")
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read project key %s: %v", k.Name, err)
+		}
+		envelope, err := sdk.NewDSSEEnvelope(payloadType, payload, k.KeyID, func(message []byte) ([]byte, error) {
+			return privateKey.GenerateSignature(string(message))
+		})
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		return &sdk.V2AttestationSignResponse{Envelope: *envelope, Signer: sdk.AttestationSignerProject}, nil
+	}
+
+	attestationKey := authentication.GetAttestationKey()
+	if attestationKey == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "no attestation key is configured on the API and the project does not have a %q key", sdk.AttestationProjectKeyName)
+	}
+	keyID, err := authentication.SigningKeyID(&attestationKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := sdk.NewDSSEEnvelope(payloadType, payload, keyID, func(message []byte) ([]byte, error) {
+		_, sig, err := authentication.SignAttestation(message)
+		return sig, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.V2AttestationSignResponse{Envelope: *envelope, Signer: sdk.AttestationSignerInstance}, nil
+}
+
+// verifyV2Attestation checks the signature of the attestation and that the signed statement is about the given digest.
+func verifyV2Attestation(keys []sdk.ProjectKey, detail sdk.V2WorkflowRunResultAttestationDetail, algorithm, digest string) (*sdk.InTotoStatement, error) {
+	envelope := detail.Envelope
+	if len(envelope.Signatures) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "attestation is not signed")
+	}
+	keyID := envelope.Signatures[0].KeyID
+
+	var verify func(message, sig []byte) error
+	switch detail.Signer {
+	case sdk.AttestationSignerProject:
+		for _, k := range keys {
+			if k.KeyID != keyID || k.Type != sdk.KeyTypePGP {
+				continue
+			}
+			publicKey, err := gpg.NewPublicKeyFromPem(k.Public)
+			if err != nil {
+				return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read project key %s: %v", k.Name, err)
+			}
+			verify = func(message, sig []byte) error {
+				return publicKey.VerifySignature(string(message), sig)
+			}
+		}
+		if verify == nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "project key %s not found", keyID)
+		}
+	case sdk.AttestationSignerInstance:
+		verify = func(message, sig []byte) error {
+			return authentication.VerifyAttestation(keyID, message, sig)
+		}
+	default:
+		return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unknown attestation signer %q", detail.Signer)
+	}
+
+	return envelope.VerifyStatement(keyID, verify, algorithm, digest)
+}
+
+func (api *API) postJobRunAttestationSignHandler() ([]service.RbacChecker, service.Handler) {
+	return []service.RbacChecker{api.jobRunUpdate, api.isWorker},
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			runJobID := vars["runJobID"]
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), runJobID)
+			if err != nil {
+				return err
+			}
+			service.TrackActionMetadataFromFields(w, runJob)
+
+			var signRequest sdk.V2AttestationSignRequest
+			if err := service.UnmarshalBody(req, &signRequest); err != nil {
+				return err
+			}
+
+			// The statement is built from the data known by the API so that a worker can only sign statements about the results of its job
+			runResult, err := workflow_v2.LoadRunResult(ctx, api.mustDB(), runJob.WorkflowRunID, signRequest.RunResultID)
+			if err != nil {
+				return err
+			}
+			if runResult.WorkflowRunJobID != runJob.ID || !runResult.IsAttestable() {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "run result %s cannot be attested by job %s", runResult.ID, runJob.ID)
+			}
+			subjects, err := runResult.AttestationSubjects()
+			if err != nil {
+				return err
+			}
+			if len(subjects) == 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "no digest found for run result %s", runResult.Name())
+			}
+
+			var statement *sdk.InTotoStatement
+			if len(signRequest.SBOM) > 0 {
+				statement, err = sdk.NewSBOMStatement(subjects, signRequest.SBOM)
+			} else {
+				var run *sdk.V2WorkflowRun
+				run, err = workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
+				if err != nil {
+					return err
+				}
+				statement, err = sdk.NewV2WorkflowRunResultProvenance(*run, *runJob, subjects, time.Now())
+			}
+			if err != nil {
+				return err
+			}
+			payload, err := json.Marshal(statement)
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+
+			p, err := project.Load(ctx, api.mustDB(), runJob.ProjectKey, project.LoadOptions.WithClearKeys)
+			if err != nil {
+				return err
+			}
+			resp, err := signV2Attestation(p.Keys, sdk.InTotoPayloadType, payload)
+			if err != nil {
+				return err
+			}
+			resp.Statement = *statement
+			return service.WriteJSON(w, resp, http.StatusOK)
+		}
+}
+
+func (api *API) getProjectAttestationsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			rawDigest := QueryString(req, "digest")
+			if rawDigest == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing digest")
+			}
+			algorithm, digest := sdk.ParseDigest(rawDigest)
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey, project.LoadOptions.WithKeys)
+			if err != nil {
+				return err
+			}
+
+			runResults, err := workflow_v2.LoadRunResultAttestationsByDigest(ctx, api.mustDB(), proj.Key, algorithm, digest)
+			if err != nil {
+				return err
+			}
+
+			runs := make(map[string]*sdk.V2WorkflowRun)
+			res := make([]sdk.V2WorkflowRunAttestation, 0, len(runResults))
+			for i := range runResults {
+				r := &runResults[i]
+				detail, err := sdk.GetConcreteDetail[*sdk.V2WorkflowRunResultAttestationDetail](r)
+				if err != nil {
+					return err
+				}
+				run, has := runs[r.WorkflowRunID]
+				if !has {
+					run, err = workflow_v2.LoadRunByID(ctx, api.mustDB(), r.WorkflowRunID)
+					if err != nil {
+						return err
+					}
+					runs[r.WorkflowRunID] = run
+				}
+
+				a := sdk.V2WorkflowRunAttestation{
+					ProjectKey:    proj.Key,
+					WorkflowName:  run.WorkflowName,
+					WorkflowRunID: run.ID,
+					RunNumber:     run.RunNumber,
+					RunResultID:   r.ID,
+					Name:          detail.Name,
+					PredicateType: detail.PredicateType,
+					Signer:        detail.Signer,
+					Detail:        *detail,
+				}
+				if len(detail.Envelope.Signatures) > 0 {
+					a.KeyID = detail.Envelope.Signatures[0].KeyID
+				}
+				statement, err := verifyV2Attestation(proj.Keys, *detail, algorithm, digest)
+				if err != nil {
+					a.Error = sdk.ExtractHTTPError(err).Error()
+				} else {
+					a.Verified = true
+					a.Statement = statement
+				}
+				res = append(res, a)
+			}
+
+			return service.WriteJSON(w, res, http.StatusOK)
+		}
+}
+
+// getProjectAttestationKeysHandler returns the public keys that sign the attestations of the project, so that they can
+// be verified without trusting the verification done by the API.
+func (api *API) getProjectAttestationKeysHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey, project.LoadOptions.WithKeys)
+			if err != nil {
+				return err
+			}
+
+			res := make([]sdk.V2AttestationPublicKey, 0)
+			for _, k := range proj.Keys {
+				if k.Name != sdk.AttestationProjectKeyName || k.Type != sdk.KeyTypePGP {
+					continue
+				}
+				res = append(res, sdk.V2AttestationPublicKey{Signer: sdk.AttestationSignerProject, KeyID: k.KeyID, Type: sdk.AttestationKeyTypePGP, Public: k.Public})
+			}
+			for _, k := range authentication.GetAttestationPublicKeys() {
+				keyID, err := authentication.SigningKeyID(k)
+				if err != nil {
+					return err
+				}
+				public, err := sdk.EncodeRSAAttestationPublicKey(k)
+				if err != nil {
+					return err
+				}
+				res = append(res, sdk.V2AttestationPublicKey{Signer: sdk.AttestationSignerInstance, KeyID: keyID, Type: sdk.AttestationKeyTypeRSA, Public: public})
+			}
+			return service.WriteJSON(w, res, http.StatusOK)
+		}
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/pelletier/go-toml"
 	"github.com/rockbears/log"
 	"github.com/rockbears/yaml"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/event_v2"
@@ -130,10 +130,10 @@ func (api *API) craftWorkflowRunV2(ctx context.Context, id string) error {
 		return sdk.WrapError(err, "unable to load workflow run %s", id)
 	}
 
-	telemetry.Current(ctx).AddAttributes(
-		trace.StringAttribute(telemetry.TagProjectKey, run.ProjectKey),
-		trace.StringAttribute(telemetry.TagWorkflow, run.WorkflowName),
-		trace.StringAttribute(telemetry.TagWorkflowRunNumber, strconv.FormatInt(run.RunNumber, 10)))
+	telemetry.Current(ctx).SetAttributes(
+		attribute.String(telemetry.TagProjectKey, run.ProjectKey),
+		attribute.String(telemetry.TagWorkflow, run.WorkflowName),
+		attribute.String(telemetry.TagWorkflowRunNumber, strconv.FormatInt(run.RunNumber, 10)))
 	ctx = context.WithValue(ctx, cdslog.Project, run.ProjectKey)
 	ctx = context.WithValue(ctx, cdslog.Workflow, run.WorkflowName)
 
@@ -601,7 +601,7 @@ func retrieveAndUpdateAllJobDependencies(ctx context.Context, db *gorp.DbMap, st
 
 	// Check worker model
 	if !strings.Contains(j.RunsOn.Model, "${{") && j.From == "" && !strings.Contains(j.Region, "${{") {
-		completeName, msg, err := wref.checkWorkerModel(ctx, db, store, jobID, j.RunsOn.Model, j.Region, defaultRegion)
+		completeName, msg, err := wref.checkWorkerModel(ctx, db, store, jobID, j.RunsOn.Model, j.RunsOn.Labels, j.Region, defaultRegion)
 		if err != nil {
 			log.ErrorWithStackTrace(ctx, err)
 			return &sdk.V2WorkflowRunInfo{
@@ -1000,6 +1000,7 @@ func buildRunContext(ctx context.Context, db *gorp.DbMap, store cache.Store, wr
 		CommitMessage:          wr.RunEvent.CommitMessage,
 		Author:                 wr.RunEvent.CommitAuthor,
 		AuthorEmail:            wr.RunEvent.CommitAuthorEmail,
+		CommitDate:             wr.RunEvent.CommitDate,
 		SemverCurrent:          semverCurrent,
 		SemverNext:             semverNext,
 		ChangeSets:             wr.RunEvent.ChangeSets,
@@ -1042,11 +1043,17 @@ func buildRunContext(ctx context.Context, db *gorp.DbMap, store cache.Store, wr
 		gitContext.RepositoryURL = vcsRepo.HTTPCloneURL
 	}
 
-	if gitContext.Ref == "" {
-		defaultBranch, err := vcsClient.Branch(ctx, gitContext.Repository, sdk.VCSBranchFilters{Default: true})
-		if err != nil {
+	defaultBranch, err := vcsClient.Branch(ctx, gitContext.Repository, sdk.VCSBranchFilters{Default: true})
+	if err != nil {
+		if gitContext.Ref == "" {
 			return nil, err
 		}
+		log.Warn(ctx, "unable to get default branch of %s: %v", gitContext.Repository, err)
+	} else {
+		gitContext.DefaultBranch = defaultBranch.ID
+	}
+
+	if gitContext.Ref == "" {
 		gitContext.Ref = defaultBranch.ID
 		gitContext.RefName = defaultBranch.DisplayID
 		gitContext.RefType = sdk.GitRefTypeBranch
@@ -1287,8 +1294,8 @@ func getCDSversion(ctx context.Context, db gorp.SqlExecutor, vcsClient sdk.VCSAu
 	return semverVersion, mustSaveVersion, nil
 }
 
-func (wref *WorkflowRunEntityFinder) checkWorkerModel(ctx context.Context, db *gorp.DbMap, store cache.Store, jobName, workerModel, reg, defaultRegion string) (string, *sdk.V2WorkflowRunInfo, error) {
-	ctx, next := telemetry.Span(ctx, "wref.checkWorkerModel", trace.StringAttribute(telemetry.TagWorkerModel, workerModel))
+func (wref *WorkflowRunEntityFinder) checkWorkerModel(ctx context.Context, db *gorp.DbMap, store cache.Store, jobName, workerModel string, labels []string, reg, defaultRegion string) (string, *sdk.V2WorkflowRunInfo, error) {
+	ctx, next := telemetry.Span(ctx, "wref.checkWorkerModel", attribute.String(telemetry.TagWorkerModel, workerModel))
 	defer next()
 
 	hatcheries, err := hatchery.LoadHatcheries(ctx, db)
@@ -1315,6 +1322,18 @@ func (wref *WorkflowRunEntityFinder) checkWorkerModel(ctx context.Context, db *g
 	modelCompleteName := ""
 	modelType := ""
 
+	if len(labels) > 0 {
+		if workerModel != "" {
+			return "", &sdk.V2WorkflowRunInfo{
+				WorkflowRunID: wref.run.ID,
+				Level:         sdk.WorkflowRunInfoLevelError,
+				IssuedAt:      time.Now(),
+				Message:       fmt.Sprintf("wrong configuration on job %q. runs-on labels cannot be used with a worker model", jobName),
+			}, nil
+		}
+		return wref.checkWorkerAgent(ctx, db, hatcheries, jobName, labels, *currentRegion)
+	}
+
 	if workerModel != "" {
 		wm, msg, err := wref.ef.searchWorkerModel(ctx, db, store, workerModel)
 		if err != nil {
@@ -1370,6 +1389,45 @@ func (wref *WorkflowRunEntityFinder) checkWorkerModel(ctx context.Context, db *g
 	}, nil
 }
 
+// checkWorkerAgent checks that a worker agent allowed on the region owns all the labels requested by the job
+func (wref *WorkflowRunEntityFinder) checkWorkerAgent(ctx context.Context, db *gorp.DbMap, hatcheries []sdk.Hatchery, jobName string, labels []string, currentRegion sdk.Region) (string, *sdk.V2WorkflowRunInfo, error) {
+	rj := sdk.V2WorkflowRunJob{
+		ProjectKey: wref.run.ProjectKey,
+		ModelType:  sdk.WorkerModelTypeAgent,
+		Job:        sdk.V2Job{RunsOn: sdk.V2JobRunsOn{Labels: labels}},
+	}
+	for _, h := range hatcheries {
+		if h.ModelType != sdk.WorkerModelTypeAgent {
+			continue
+		}
+		agentConfig, err := sdk.NewV2WorkerAgentConfig(h.Config)
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+			continue
+		}
+		if !agentConfig.CanRunJob(rj) {
+			continue
+		}
+		rbacHatchery, err := rbac.LoadRBACHatcheryByHatcheryID(ctx, db, h.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return "", nil, err
+		}
+		if err != nil && sdk.ErrorIs(err, sdk.ErrNotFound) {
+			continue
+		}
+		if rbacHatchery.RegionID == currentRegion.ID {
+			return "", nil, nil
+		}
+	}
+
+	return "", &sdk.V2WorkflowRunInfo{
+		WorkflowRunID: wref.run.ID,
+		Level:         sdk.WorkflowRunInfoLevelError,
+		IssuedAt:      time.Now(),
+		Message:       fmt.Sprintf("wrong configuration on job %q. No worker agent can run it with labels [%s]", jobName, strings.Join(labels, ", ")),
+	}, nil
+}
+
 func (wref *WorkflowRunEntityFinder) checkIntegrations(ctx context.Context, db *gorp.DbMap, jobs map[string]sdk.V2Job) (map[string]sdk.ProjectIntegration, []sdk.V2WorkflowRunInfo, error) {
 	availableIntegrations, err := integration.LoadIntegrationsByProjectID(ctx, db, wref.project.ID)
 	if err != nil {
@@ -1443,7 +1501,7 @@ func (wref *WorkflowRunEntityFinder) checkIntegrations(ctx context.Context, db *
 }
 
 func (wref *WorkflowRunEntityFinder) checkWorkflowTemplate(ctx context.Context, db *gorp.DbMap, store cache.Store, templateName string) (sdk.EntityWithObject, *sdk.V2WorkflowRunInfo, error) {
-	ctx, next := telemetry.Span(ctx, "wref.checkWorkflowTemplate", trace.StringAttribute(telemetry.TagWorkflowTemplate, templateName))
+	ctx, next := telemetry.Span(ctx, "wref.checkWorkflowTemplate", attribute.String(telemetry.TagWorkflowTemplate, templateName))
 	defer next()
 
 	e, msg, err := wref.ef.searchWorkflowTemplate(ctx, db, store, templateName)
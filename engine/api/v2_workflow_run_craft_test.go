@@ -61,6 +61,15 @@ func TestCraftWorkflowRunNoHatchery(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
@@ -150,6 +159,15 @@ func TestCraftWorkflowRunDepsNotFound(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
@@ -287,6 +305,15 @@ func TestCraftWorkflowRunDepsSameRepo(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
@@ -556,6 +583,15 @@ func TestCraftWorkflowRunDepsDifferentRepo(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	// Create hatchery
 	hatch := sdk.Hatchery{Name: sdk.RandomString(10), ModelType: ""}
@@ -1012,6 +1048,15 @@ func TestCraftWorkflowRunCustomVersion_GitOnBranch(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	// Create hatchery
 	hatch := sdk.Hatchery{Name: sdk.RandomString(10), ModelType: ""}
@@ -1142,6 +1187,15 @@ func TestCraftWorkflowRunCustomVersion_GitOnTag(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	// Create hatchery
 	hatch := sdk.Hatchery{Name: sdk.RandomString(10), ModelType: ""}
@@ -2068,6 +2122,15 @@ spec: |-
 				return nil, 200, nil
 			},
 		).Times(2)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
@@ -2204,6 +2267,15 @@ func TestComputeConcurrency(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
@@ -2342,6 +2414,15 @@ func TestConcurrencyLockWorkflow(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 
@@ -2532,6 +2613,15 @@ func TestConcurrencyCancelWorkflow(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 
@@ -2727,6 +2817,15 @@ func TestConcurrencyLockWorkflowWithCondition(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 
@@ -2936,6 +3035,15 @@ func TestCraftWorkflowRun_Fallback(t *testing.T) {
 				return nil, 200, nil
 			},
 		).Times(1)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wk := sdk.V2Workflow{
@@ -3199,6 +3307,15 @@ spec: |-
 				return nil, 200, nil
 			},
 		).Times(2)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
@@ -3343,6 +3460,15 @@ spec: |-
 				return nil, 200, nil
 			},
 		).Times(2)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
@@ -3619,6 +3745,15 @@ spec: |-
 				return nil, 200, nil
 			},
 		).Times(2)
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/my/repo/branches/?branch=&default=true", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+				b := &sdk.VCSBranch{Default: true, DisplayID: "master", ID: "refs/heads/master"}
+				*(out.(*sdk.VCSBranch)) = *b
+				return nil, 200, nil
+			},
+		).AnyTimes()
 
 	wkName := sdk.RandomString(10)
 	wr := sdk.V2WorkflowRun{
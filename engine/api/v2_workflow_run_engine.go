@@ -8,14 +8,14 @@ import (
 	"path"
 	"path/filepath"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-gorp/gorp"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	art "github.com/ovh/cds/contrib/integrations/artifactory"
 	"github.com/ovh/cds/engine/api/authentication"
@@ -46,6 +46,7 @@ type prepareJobData struct {
 	defaultRegion   string
 	regionPermCache map[string]*sdk.V2WorkflowRunJobInfo
 	allVariableSets []sdk.ProjectVariableSet
+	monthlyQuotaErr error
 }
 
 func (api *API) TriggerBlockedWorkflowRuns(ctx context.Context) {
@@ -69,6 +70,10 @@ func (api *API) TriggerBlockedWorkflowRuns(ctx context.Context) {
 					log.ErrorWithStackTrace(ctx, err)
 				}
 			}
+			// Runs with jobs held by a freeze are not in the ended runs, they are checked apart
+			if err := api.triggerFrozenWorkflowRuns(ctx); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
 
 		}
 	}
@@ -149,10 +154,10 @@ func (api *API) workflowRunV2Trigger(ctx context.Context, wrEnqueue sdk.V2Workfl
 		return sdk.WrapError(err, "unable to load workflow run %s", wrEnqueue.RunID)
 	}
 
-	telemetry.Current(ctx).AddAttributes(
-		trace.StringAttribute(telemetry.TagProjectKey, run.ProjectKey),
-		trace.StringAttribute(telemetry.TagWorkflow, run.WorkflowName),
-		trace.StringAttribute(telemetry.TagWorkflowRunNumber, strconv.FormatInt(run.RunNumber, 10)))
+	telemetry.Current(ctx).SetAttributes(
+		attribute.String(telemetry.TagProjectKey, run.ProjectKey),
+		attribute.String(telemetry.TagWorkflow, run.WorkflowName),
+		attribute.String(telemetry.TagWorkflowRunNumber, strconv.FormatInt(run.RunNumber, 10)))
 	ctx = context.WithValue(ctx, cdslog.Project, run.ProjectKey)
 	ctx = context.WithValue(ctx, cdslog.Workflow, run.WorkflowName)
 
@@ -422,7 +427,7 @@ func (api *API) workflowRunV2Trigger(ctx context.Context, wrEnqueue sdk.V2Workfl
 	wref.ef.vcsServerCache[vcsServer.Name] = *vcsServer
 
 	// Prepare run jobs to be enqueued
-	runJobs, runObjectToCancel, runJobsInfos, errorMsg, runUpdated, err := prepareRunJobs(ctx, api.mustDB(), api.Cache, proj, wref, run, allRunJobs, variableSetCtx, wrEnqueue, jobsToQueue, runJobsContexts, concurrenciesDef, api.Config.Workflow.JobDefaultRegion)
+	runJobs, runObjectToCancel, runJobsInfos, errorMsg, runUpdated, err := prepareRunJobs(ctx, api.mustDB(), api.Cache, proj, wref, run, allRunJobs, variableSetCtx, wrEnqueue, jobsToQueue, runJobsContexts, concurrenciesDef, api.Config.Workflow.JobDefaultRegion, api.Config.WorkflowV2.MaxMatrixPermutations)
 	if err != nil {
 		return err
 	}
@@ -587,6 +592,15 @@ func (api *API) endWorkflowV2Trigger(ctx context.Context, run *sdk.V2WorkflowRun
 			}
 		})
 
+		if api.RunTraceExporter != nil {
+			runEnded := *run
+			api.GoRoutines.Exec(ctx, "api.exportRunTrace-"+run.ID, func(ctx context.Context) {
+				if err := api.exportRunTrace(ctx, runEnded); err != nil {
+					log.ErrorWithStackTrace(ctx, err)
+				}
+			})
+		}
+
 		// Send event
 		event_v2.PublishRunEvent(ctx, api.Cache, sdk.EventRunEnded, *run, allrunJobsMap, runResults, &wrEnqueue.Initiator)
 
@@ -995,7 +1009,7 @@ func (api *API) synchronizeRunResults(ctx context.Context, db gorp.SqlExecutor,
 		}
 
 		// Push git properties as artifact properties
-		props := utils.NewProperties()
+		props := artifact_manager.NewProperties()
 		signedProps := make(ArtifactSignature)
 
 		props.AddProperty("cds.project", run.ProjectKey)
@@ -1328,7 +1342,13 @@ func computeRunJobsInterpolation(ctx context.Context, db *gorp.DbMap, store cach
 		return jobInfoMsg, false
 	}
 
-	if strings.Contains(rj.Job.RunsOn.Model, "${{") || regionInterpolated {
+	// A worker model given when the job was re-run has not been checked yet
+	modelOverridden := false
+	if je := run.GetRunJobEvent(rj.JobID); je != nil && je.Overrides != nil && je.Overrides.Model != "" {
+		modelOverridden = true
+	}
+
+	if strings.Contains(rj.Job.RunsOn.Model, "${{") || regionInterpolated || modelOverridden {
 		model, err := ap.InterpolateToString(ctx, rj.Job.RunsOn.Model)
 		if err != nil {
 			rj.Status = sdk.V2WorkflowRunJobStatusFail
@@ -1340,7 +1360,7 @@ func computeRunJobsInterpolation(ctx context.Context, db *gorp.DbMap, store cach
 				Message:          fmt.Sprintf("Job %s: unable to interpolate %s into a string: %v", rj.JobID, rj.Job.RunsOn.Model, err),
 			}, false
 		}
-		completeName, msg, err := wref.checkWorkerModel(ctx, db, store, rj.JobID, model, rj.Region, "")
+		completeName, msg, err := wref.checkWorkerModel(ctx, db, store, rj.JobID, model, rj.Job.RunsOn.Labels, rj.Region, "")
 		if err != nil {
 			rj.Status = sdk.V2WorkflowRunJobStatusFail
 			return &sdk.V2WorkflowRunJobInfo{
@@ -1361,7 +1381,9 @@ func computeRunJobsInterpolation(ctx context.Context, db *gorp.DbMap, store cach
 				Message:          msg.Message,
 			}, false
 		}
-		if strings.HasPrefix(model, ".cds/worker-models/") {
+		if len(rj.Job.RunsOn.Labels) > 0 {
+			rj.ModelType = sdk.WorkerModelTypeAgent
+		} else if strings.HasPrefix(model, ".cds/worker-models/") {
 			rj.ModelType = wref.ef.localWorkerModelCache[model].Model.Type
 			rj.ModelOSArch = wref.ef.localWorkerModelCache[model].Model.OSArch
 		} else {
@@ -1454,7 +1476,7 @@ func computeRunJobsInterpolation(ctx context.Context, db *gorp.DbMap, store cach
 	return nil, runUpdated
 }
 
-func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Project, wref *WorkflowRunEntityFinder, run *sdk.V2WorkflowRun, existingRunJobs []sdk.V2WorkflowRunJob, runVarsetCtx map[string]interface{}, wrEnqueue sdk.V2WorkflowRunEnqueue, jobsToQueue map[string]JobToTrigger, runJobsContexts sdk.JobsResultContext, concurrenciesDef map[string]sdk.V2RunConcurrency, defaultRegion string) ([]sdk.V2WorkflowRunJob, map[string]workflow_v2.ConcurrencyObject, map[string]sdk.V2WorkflowRunJobInfo, []sdk.V2WorkflowRunInfo, bool, error) {
+func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Project, wref *WorkflowRunEntityFinder, run *sdk.V2WorkflowRun, existingRunJobs []sdk.V2WorkflowRunJob, runVarsetCtx map[string]interface{}, wrEnqueue sdk.V2WorkflowRunEnqueue, jobsToQueue map[string]JobToTrigger, runJobsContexts sdk.JobsResultContext, concurrenciesDef map[string]sdk.V2RunConcurrency, defaultRegion string, maxMatrixPermutations int64) ([]sdk.V2WorkflowRunJob, map[string]workflow_v2.ConcurrencyObject, map[string]sdk.V2WorkflowRunJobInfo, []sdk.V2WorkflowRunInfo, bool, error) {
 	runJobs := make([]sdk.V2WorkflowRunJob, 0)
 	runJobsInfo := make(map[string]sdk.V2WorkflowRunJobInfo)
 	hasToUpdateRun := false
@@ -1469,10 +1491,44 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 		return nil, nil, nil, nil, false, err
 	}
 
+	// New jobs are not queued if the project has consumed its monthly quota
+	monthlyQuotaErr := checkProjectMonthlyQuota(ctx, db, proj.Key)
+	if monthlyQuotaErr != nil && !sdk.ErrorIs(monthlyQuotaErr, sdk.ErrQuotaExceeded) {
+		return nil, nil, nil, nil, false, monthlyQuotaErr
+	}
+
 	// Browse job to queue and compute data ( matrix / region / model etc..... )
 	for jobID, jobToTrigger := range jobsToQueue {
 		jobDef := jobToTrigger.Job
 
+		// A job held by a freeze is only recorded as blocked, it is created again when the freeze ends
+		if jobToTrigger.Status == sdk.V2WorkflowRunJobStatusBlocked {
+			runJobs = append(runJobs, sdk.V2WorkflowRunJob{
+				ID:                 sdk.UUID(),
+				WorkflowRunID:      run.ID,
+				Status:             sdk.V2WorkflowRunJobStatusBlocked,
+				JobID:              jobID,
+				Job:                jobDef,
+				DeprecatedUserID:   wrEnqueue.Initiator.UserID,
+				DeprecatedUsername: wrEnqueue.Initiator.Username(),
+				DeprecatedAdminMFA: wrEnqueue.Initiator.IsAdminWithMFA,
+				ProjectKey:         run.ProjectKey,
+				VCSServer:          run.VCSServer,
+				Repository:         run.Repository,
+				Region:             jobDef.Region,
+				WorkflowName:       run.WorkflowName,
+				RunNumber:          run.RunNumber,
+				RunAttempt:         run.RunAttempt,
+				Initiator:          wrEnqueue.Initiator,
+			})
+			continue
+		}
+
+		// Apply the overrides given when the job was re-run
+		if je := run.GetRunJobEvent(jobID); je != nil && je.Overrides != nil && !jobToTrigger.Status.IsTerminated() {
+			jobDef = je.Overrides.Apply(jobDef)
+		}
+
 		// Build env context: workflow-level env merged with job-level env (job takes priority)
 		envCtx := make(map[string]string)
 		for k, v := range run.Contexts.Env {
@@ -1540,7 +1596,7 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 		}
 
 		// Compute job matrix strategy
-		matrixPermutation, msInfo := generateMatrixPermutation(ctx, runJobContext, run, jobDef, jobToTrigger.Status)
+		matrixPermutation, msInfo := generateMatrixPermutation(ctx, runJobContext, run, jobID, jobDef, jobToTrigger.Status, maxMatrixPermutations)
 		if msInfo != nil {
 			return nil, nil, nil, []sdk.V2WorkflowRunInfo{*msInfo}, false, err
 		}
@@ -1569,7 +1625,7 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 				runJob.Status = sdk.V2WorkflowRunJobStatusSuccess
 			}
 			// If the current job was a matrix, skip it
-			if jobDef.Strategy != nil && len(jobDef.Strategy.Matrix) > 0 {
+			if jobDef.Strategy.HasMatrix() {
 				runJob.Status = sdk.V2WorkflowRunJobStatusSkipped
 				runJobsInfo[runJob.ID] = sdk.V2WorkflowRunJobInfo{
 					WorkflowRunID:    runJob.WorkflowRunID,
@@ -1599,6 +1655,8 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 					if jobDef.RunsOn.Model != "" {
 						runJob.ModelType = run.WorkflowData.WorkerModels[jobDef.RunsOn.Model].Type
 						runJob.ModelOSArch = run.WorkflowData.WorkerModels[jobDef.RunsOn.Model].OSArch
+					} else if len(jobDef.RunsOn.Labels) > 0 {
+						runJob.ModelType = sdk.WorkerModelTypeAgent
 					}
 					// Only interpolate job data if job is not skipped to avoid missing variables exported by parent jobs
 					for _, jobEvent := range run.RunJobEvent {
@@ -1640,6 +1698,9 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 					if runJobInfo != nil {
 						runJobsInfo[runJob.ID] = *runJobInfo
 					}
+					if runJobInfo := failRunJobOnMonthlyQuota(&runJob, monthlyQuotaErr); runJobInfo != nil {
+						runJobsInfo[runJob.ID] = *runJobInfo
+					}
 					runJobs = append(runJobs, runJob)
 				}
 			} else {
@@ -1668,6 +1729,7 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 				defaultRegion:   defaultRegion,
 				regionPermCache: regionPermCache,
 				allVariableSets: allVariableSets,
+				monthlyQuotaErr: monthlyQuotaErr,
 			}
 
 			if jobDef.From == "" {
@@ -1976,7 +2038,7 @@ loop:
 	return nil, nil
 }
 
-func createTemplatedMatrixedJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, wref *WorkflowRunEntityFinder, matrixPermutation []map[string]string, run *sdk.V2WorkflowRun, data prepareJobData) []sdk.V2WorkflowRunInfo {
+func createTemplatedMatrixedJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, wref *WorkflowRunEntityFinder, matrixPermutation []sdk.JobMatrix, run *sdk.V2WorkflowRun, data prepareJobData) []sdk.V2WorkflowRunInfo {
 	newJobs := make(map[string]sdk.V2Job)
 	newStages := make(map[string]sdk.WorkflowStage)
 	newGates := make(map[string]sdk.V2JobGate)
@@ -1984,7 +2046,7 @@ func createTemplatedMatrixedJobs(ctx context.Context, db *gorp.DbMap, store cach
 	newConcurrencies := make(map[string]sdk.WorkflowConcurrency)
 	var entityTemplateWithObj *sdk.EntityWithObject
 	for _, m := range matrixPermutation {
-		data.runJobContext.Matrix = make(map[string]string)
+		data.runJobContext.Matrix = make(sdk.JobMatrix)
 		for k, v := range m {
 			data.runJobContext.Matrix[k] = v
 		}
@@ -1996,7 +2058,7 @@ func createTemplatedMatrixedJobs(ctx context.Context, db *gorp.DbMap, store cach
 				WorkflowRunID: run.ID,
 				Level:         sdk.WorkflowRunInfoLevelError,
 				IssuedAt:      time.Now(),
-				Message:       fmt.Sprintf("Job %s: unable to build context to compute job with permutation %v: %v", data.jobID, m, err),
+				Message:       fmt.Sprintf("Job %s: unable to build context to compute job with permutation %s: %v", data.jobID, m.String(), err),
 			}}
 		}
 
@@ -2125,7 +2187,7 @@ func createTemplatedMatrixedJobs(ctx context.Context, db *gorp.DbMap, store cach
 	return msgsLint
 }
 
-func createMatrixedRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, wref *WorkflowRunEntityFinder, matrixPermutation []map[string]string, runJobsInfo map[string]sdk.V2WorkflowRunJobInfo, run *sdk.V2WorkflowRun, data prepareJobData, concurrenciesDef map[string]sdk.V2RunConcurrency, concurrencyUnlockedCount map[string]int64, runObjToCancelled map[string]workflow_v2.ConcurrencyObject) ([]sdk.V2WorkflowRunJob, bool, error) {
+func createMatrixedRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, wref *WorkflowRunEntityFinder, matrixPermutation []sdk.JobMatrix, runJobsInfo map[string]sdk.V2WorkflowRunJobInfo, run *sdk.V2WorkflowRun, data prepareJobData, concurrenciesDef map[string]sdk.V2RunConcurrency, concurrencyUnlockedCount map[string]int64, runObjToCancelled map[string]workflow_v2.ConcurrencyObject) ([]sdk.V2WorkflowRunJob, bool, error) {
 	runJobs := make([]sdk.V2WorkflowRunJob, 0)
 	hasToUpdateRun := false
 
@@ -2161,6 +2223,8 @@ func createMatrixedRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Stor
 		if permJobDef.RunsOn.Model != "" {
 			runJob.ModelType = run.WorkflowData.WorkerModels[permJobDef.RunsOn.Model].Type
 			runJob.ModelOSArch = run.WorkflowData.WorkerModels[permJobDef.RunsOn.Model].OSArch
+		} else if len(permJobDef.RunsOn.Labels) > 0 {
+			runJob.ModelType = sdk.WorkerModelTypeAgent
 		}
 		if !data.jobToTrigger.Status.IsTerminated() {
 			for _, jobEvent := range run.RunJobEvent {
@@ -2200,6 +2264,9 @@ func createMatrixedRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Stor
 			if runJobInfo != nil {
 				runJobsInfo[runJob.ID] = *runJobInfo
 			}
+			if runJobInfo := failRunJobOnMonthlyQuota(&runJob, data.monthlyQuotaErr); runJobInfo != nil {
+				runJobsInfo[runJob.ID] = *runJobInfo
+			}
 		}
 
 		runJobs = append(runJobs, runJob)
@@ -2207,7 +2274,7 @@ func createMatrixedRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Stor
 	return runJobs, hasToUpdateRun, nil
 }
 
-func searchPermutationToTrigger(_ context.Context, permutations []map[string]string, runJobs []sdk.V2WorkflowRunJob, jobID string) []map[string]string {
+func searchPermutationToTrigger(_ context.Context, permutations []sdk.JobMatrix, runJobs []sdk.V2WorkflowRunJob, jobID string) []sdk.JobMatrix {
 	runJobsForJobID := make([]sdk.V2WorkflowRunJob, 0)
 
 	for _, rj := range runJobs {
@@ -2220,7 +2287,7 @@ func searchPermutationToTrigger(_ context.Context, permutations []map[string]str
 		return permutations
 	}
 
-	permutationToTrigger := make([]map[string]string, 0)
+	permutationToTrigger := make([]sdk.JobMatrix, 0)
 	// Browse all permutation
 	for _, perm := range permutations {
 		runJobFound := false
@@ -2229,7 +2296,7 @@ func searchPermutationToTrigger(_ context.Context, permutations []map[string]str
 		for _, rj := range runJobsForJobID {
 			for k, v := range perm {
 				// If not the same permutation, check next run job
-				if rj.Matrix[k] != v {
+				if !sdk.MatrixValueEqual(rj.Matrix[k], v) {
 					continue runJobLoop
 				}
 			}
@@ -2243,46 +2310,52 @@ func searchPermutationToTrigger(_ context.Context, permutations []map[string]str
 	return permutationToTrigger
 }
 
-func generateMatrixPermutation(ctx context.Context, rootJobContext sdk.WorkflowRunJobsContext, run *sdk.V2WorkflowRun, jobDef sdk.V2Job, status sdk.V2WorkflowRunJobStatus) ([]map[string]string, *sdk.V2WorkflowRunInfo) {
+func generateMatrixPermutation(ctx context.Context, rootJobContext sdk.WorkflowRunJobsContext, run *sdk.V2WorkflowRun, jobID string, jobDef sdk.V2Job, status sdk.V2WorkflowRunJobStatus, maxPermutations int64) ([]sdk.JobMatrix, *sdk.V2WorkflowRunInfo) {
 	if status.IsTerminated() {
-		return make([]map[string]string, 0), nil
+		return make([]sdk.JobMatrix, 0), nil
 	}
+	newMatrixError := func(format string, args ...interface{}) *sdk.V2WorkflowRunInfo {
+		return &sdk.V2WorkflowRunInfo{
+			WorkflowRunID: run.ID,
+			IssuedAt:      time.Now(),
+			Level:         sdk.WorkflowRunInfoLevelError,
+			Message:       fmt.Sprintf("Job %s: ", jobID) + fmt.Sprintf(format, args...),
+		}
+	}
+
 	keys := make([]string, 0)
-	interpolatedMatrix := make(map[string][]string)
-	if jobDef.Strategy != nil && len(jobDef.Strategy.Matrix) > 0 {
+	interpolatedMatrix := make(map[string][]interface{})
+	if jobDef.Strategy.HasMatrix() {
 		bts, _ := json.Marshal(rootJobContext)
 		var mapContexts map[string]interface{}
 		_ = json.Unmarshal(bts, &mapContexts) // error cannot happen here
 
 		ap := sdk.NewActionParser(mapContexts, sdk.DefaultFuncs)
 
-		for k, v := range jobDef.Strategy.Matrix {
+		matrix := jobDef.Strategy.Matrix
+		if jobDef.Strategy.MatrixExpression != "" {
+			interpolatedValue, err := ap.Interpolate(ctx, jobDef.Strategy.MatrixExpression)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				return nil, newMatrixError("unable to interpolate matrix %s: %v", jobDef.Strategy.MatrixExpression, err)
+			}
+			m, ok := interpolatedValue.(map[string]interface{})
+			if !ok {
+				return nil, newMatrixError("matrix expression %s must return an object, got %T", jobDef.Strategy.MatrixExpression, interpolatedValue)
+			}
+			matrix = m
+		}
+
+		for k, v := range matrix {
 			keys = append(keys, k)
 
-			matrixValues := make([]string, 0)
+			matrixValues := make([]interface{}, 0)
 			if slice, ok := v.([]interface{}); ok {
 				for _, sliceValue := range slice {
-					valueString, ok := sliceValue.(string)
-					if !ok {
-						msg := &sdk.V2WorkflowRunInfo{
-							WorkflowRunID: run.ID,
-							IssuedAt:      time.Now(),
-							Level:         sdk.WorkflowRunInfoLevelError,
-							Message:       fmt.Sprintf("matrix value %v is not a string", sliceValue),
-						}
-						return nil, msg
-					}
-
-					interpolatedValue, err := ap.InterpolateToString(ctx, valueString)
+					interpolatedValue, err := interpolateMatrixValue(ctx, ap, sliceValue)
 					if err != nil {
 						log.ErrorWithStackTrace(ctx, err)
-						msg := &sdk.V2WorkflowRunInfo{
-							WorkflowRunID: run.ID,
-							IssuedAt:      time.Now(),
-							Level:         sdk.WorkflowRunInfoLevelError,
-							Message:       fmt.Sprintf("unable to interpolate matrix value %s: %v", valueString, err),
-						}
-						return nil, msg
+						return nil, newMatrixError("unable to interpolate matrix value %v: %v", sliceValue, err)
 					}
 					matrixValues = append(matrixValues, interpolatedValue)
 				}
@@ -2290,57 +2363,85 @@ func generateMatrixPermutation(ctx context.Context, rootJobContext sdk.WorkflowR
 				interpolatedValue, err := ap.Interpolate(ctx, valueString)
 				if err != nil {
 					log.ErrorWithStackTrace(ctx, err)
-					msg := &sdk.V2WorkflowRunInfo{
-						WorkflowRunID: run.ID,
-						IssuedAt:      time.Now(),
-						Level:         sdk.WorkflowRunInfoLevelError,
-						Message:       fmt.Sprintf("unable to interpolate %s: %v", valueString, err),
-					}
-					return nil, msg
+					return nil, newMatrixError("unable to interpolate %s: %v", valueString, err)
 				}
-				interpoaltedSlice, ok := interpolatedValue.([]interface{})
+				interpolatedSlice, ok := interpolatedValue.([]interface{})
 				if !ok {
-					msg := &sdk.V2WorkflowRunInfo{
-						WorkflowRunID: run.ID,
-						IssuedAt:      time.Now(),
-						Level:         sdk.WorkflowRunInfoLevelError,
-						Message:       fmt.Sprintf("interpolated matrix is not a string slice, got %T", interpolatedValue),
-					}
-					return nil, msg
-				}
-				stringsValue := make([]string, 0, len(interpoaltedSlice))
-				for _, vle := range interpoaltedSlice {
-					stringsValue = append(stringsValue, fmt.Sprintf("%v", vle))
+					return nil, newMatrixError("interpolated matrix key %s is not a list, got %T", k, interpolatedValue)
 				}
-				matrixValues = stringsValue
+				matrixValues = interpolatedSlice
 			} else {
-				msg := &sdk.V2WorkflowRunInfo{
-					WorkflowRunID: run.ID,
-					IssuedAt:      time.Now(),
-					Level:         sdk.WorkflowRunInfoLevelError,
-					Message:       fmt.Sprintf("unable to use matrix key %s of type %T", k, v),
-				}
-				return nil, msg
+				return nil, newMatrixError("unable to use matrix key %s of type %T", k, v)
 			}
 			interpolatedMatrix[k] = matrixValues
+		}
+	}
+
+	alls := make([]sdk.JobMatrix, 0)
+	if len(interpolatedMatrix) == 0 {
+		return alls, nil
+	}
 
+	nbPermutations := int64(1)
+	for _, values := range interpolatedMatrix {
+		nbPermutations *= int64(len(values))
+		if maxPermutations > 0 && nbPermutations > maxPermutations {
+			return nil, newMatrixError("matrix generates more than %d permutations", maxPermutations)
 		}
 	}
 
-	alls := make([]map[string]string, 0)
-	if jobDef.Strategy != nil && len(interpolatedMatrix) > 0 {
-		generateMatrix(interpolatedMatrix, keys, 0, make(map[string]string), &alls)
-		for k := range interpolatedMatrix {
-			jobDef.Strategy.Matrix[k] = interpolatedMatrix[k]
+	// Keep interpolated values on the job definition to know the number of permutations
+	sort.Strings(keys)
+	generateMatrix(interpolatedMatrix, keys, 0, make(sdk.JobMatrix), &alls)
+	jobDef.Strategy.MatrixExpression = ""
+	if jobDef.Strategy.Matrix == nil {
+		jobDef.Strategy.Matrix = make(map[string]interface{})
+	}
+	for k := range jobDef.Strategy.Matrix {
+		if _, has := interpolatedMatrix[k]; !has {
+			delete(jobDef.Strategy.Matrix, k)
 		}
 	}
+	for k := range interpolatedMatrix {
+		jobDef.Strategy.Matrix[k] = interpolatedMatrix[k]
+	}
 
 	return alls, nil
 }
 
-func generateMatrix(matrix map[string][]string, keys []string, keyIndex int, current map[string]string, alls *[]map[string]string) {
+// interpolateMatrixValue interpolates the strings of a matrix value, keeping the structure of objects and lists.
+func interpolateMatrixValue(ctx context.Context, ap *sdk.ActionParser, v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case string:
+		return ap.InterpolateToString(ctx, x)
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(x))
+		for k, item := range x {
+			interpolated, err := interpolateMatrixValue(ctx, ap, item)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = interpolated
+		}
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, 0, len(x))
+		for _, item := range x {
+			interpolated, err := interpolateMatrixValue(ctx, ap, item)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, interpolated)
+		}
+		return res, nil
+	default:
+		return v, nil
+	}
+}
+
+func generateMatrix(matrix map[string][]interface{}, keys []string, keyIndex int, current sdk.JobMatrix, alls *[]sdk.JobMatrix) {
 	if len(current) == len(keys) {
-		combinationCopy := make(map[string]string)
+		combinationCopy := make(sdk.JobMatrix)
 		for k, v := range current {
 			combinationCopy[k] = v
 		}
@@ -2380,7 +2481,7 @@ func retrieveJobToQueue(ctx context.Context, db *gorp.DbMap, wrEnqueue sdk.V2Wor
 			jobsToCheck[jobID] = jobDef
 		} else {
 			// If job with matrix, check if we have to rerun a permmutation
-			if runJobMapItem.Job.Strategy != nil && len(runJobMapItem.Job.Strategy.Matrix) > 0 {
+			if runJobMapItem.Job.Strategy.HasMatrix() {
 
 				// If runjob has a status && a template, ignore it. A matrix job can be run if template has been resolved
 				if runJobMapItem.Job.From != "" {
@@ -2427,6 +2528,11 @@ func retrieveJobToQueue(ctx context.Context, db *gorp.DbMap, wrEnqueue sdk.V2Wor
 		stages.ComputeStatus()
 	}
 
+	freezes, err := project.LoadFreezesByProjectKey(ctx, db, run.ProjectKey)
+	if err != nil {
+		return nil, runInfos, err
+	}
+
 	for jobID, jobDef := range jobsToCheck {
 
 		// Skip the job in stage that cannot be run
@@ -2442,6 +2548,24 @@ func retrieveJobToQueue(ctx context.Context, db *gorp.DbMap, wrEnqueue sdk.V2Wor
 		// Build job context
 		jobContext := buildContextForJob(ctx, run.WorkflowData.Workflow, runJobsContexts, run.Contexts, stages, jobID)
 
+		// Add environment variable set before checking rights on variable sets
+		var env *sdk.ProjectEnvironment
+		if jobDef.Environment != "" {
+			var err error
+			env, err = loadJobEnvironment(ctx, db, run.ProjectKey, jobDef.Environment)
+			if err == nil {
+				err = applyJobEnvironment(*env, &jobDef)
+			}
+			if err != nil {
+				runInfos = append(runInfos, sdk.V2WorkflowRunInfo{
+					WorkflowRunID: run.ID,
+					Level:         sdk.WorkflowRunInfoLevelError,
+					Message:       fmt.Sprintf("Job %q: %v", jobID, sdk.ExtractHTTPError(err).Error()),
+				})
+				return nil, runInfos, err
+			}
+		}
+
 		canBeQueued, infos, err := checkJob(ctx, db, wrEnqueue, *run, jobID, &jobDef, jobContext)
 		runInfos = append(runInfos, infos...)
 		if err != nil {
@@ -2459,6 +2583,36 @@ func retrieveJobToQueue(ctx context.Context, db *gorp.DbMap, wrEnqueue sdk.V2Wor
 			continue
 		}
 
+		// Hold the job during the project freezes
+		freezeEnded, info, err := checkJobFreeze(ctx, db, freezes, *run, jobID, jobDef)
+		if err != nil {
+			return nil, runInfos, err
+		}
+		if info != nil {
+			runInfos = append(runInfos, *info)
+		}
+		if !freezeEnded {
+			jobToQueue[jobID] = JobToTrigger{
+				Status: sdk.V2WorkflowRunJobStatusBlocked,
+				Job:    jobDef,
+			}
+			continue
+		}
+
+		// Keep the job out of the queue while the environment wait timer is running
+		if env != nil {
+			timerEnded, info, err := checkEnvironmentWaitTimer(ctx, db, *env, *run, runJobs, jobID, jobDef)
+			if err != nil {
+				return nil, runInfos, err
+			}
+			if info != nil {
+				runInfos = append(runInfos, *info)
+			}
+			if !timerEnded {
+				continue
+			}
+		}
+
 		jobToQueue[jobID] = JobToTrigger{
 			Status: sdk.V2WorkflowRunJobStatusWaiting,
 			Job:    jobDef,
@@ -2469,7 +2623,7 @@ func retrieveJobToQueue(ctx context.Context, db *gorp.DbMap, wrEnqueue sdk.V2Wor
 }
 
 func checkJob(ctx context.Context, db gorp.SqlExecutor, wrEnqueue sdk.V2WorkflowRunEnqueue, run sdk.V2WorkflowRun, jobID string, jobDef *sdk.V2Job, currentJobContext sdk.WorkflowRunJobsContext) (bool, []sdk.V2WorkflowRunInfo, error) {
-	ctx, next := telemetry.Span(ctx, "checkJob", trace.StringAttribute(telemetry.TagJob, jobID))
+	ctx, next := telemetry.Span(ctx, "checkJob", attribute.String(telemetry.TagJob, jobID))
 	defer next()
 
 	runInfos := make([]sdk.V2WorkflowRunInfo, 0)
@@ -2672,29 +2826,22 @@ func checkCanRunJob(ctx context.Context, db gorp.SqlExecutor, run sdk.V2Workflow
 	ctx, next := telemetry.Span(ctx, "checkJobCondition")
 	defer next()
 
+	// Check job environment protection rules
+	if jobDef.Environment != "" {
+		envChecked, err := checkJobEnvironment(ctx, db, run, jobDef, initiator)
+		if err != nil || !envChecked {
+			return false, err
+		}
+	}
+
 	// Check job Gate
 	if jobDef.Gate != "" {
 		gate := run.WorkflowData.Workflow.Gates[jobDef.Gate]
 
 		// Check reviewers
-		reviewersChecked := len(gate.Reviewers.Users) == 0 && len(gate.Reviewers.Groups) == 0
-		if len(gate.Reviewers.Users) > 0 {
-			reviewersChecked = sdk.IsInArray(initiator.Username(), gate.Reviewers.Users)
-		}
-		if !reviewersChecked {
-			for _, g := range gate.Reviewers.Groups {
-				grp, err := group.LoadByName(ctx, db, g, group.LoadOptions.WithMembers)
-				if err != nil {
-					return false, err
-				}
-				reviewersChecked = sdk.IsInArray(initiator.UserID, grp.Members.UserIDs())
-				if reviewersChecked {
-					break
-				}
-			}
-		}
-		if !reviewersChecked && !initiator.IsAdminWithMFA {
-			return false, nil
+		reviewersChecked, err := checkReviewers(ctx, db, gate.Reviewers, initiator)
+		if err != nil || !reviewersChecked {
+			return false, err
 		}
 
 		// Create empty input context to be able to interpolate gate condition.
@@ -2783,6 +2930,27 @@ func checkCanRunJob(ctx context.Context, db gorp.SqlExecutor, run sdk.V2Workflow
 	return jobIfResult, nil
 }
 
+// checkReviewers returns true if there is no reviewer or if the initiator is one of them.
+func checkReviewers(ctx context.Context, db gorp.SqlExecutor, reviewers sdk.V2JobGateReviewers, initiator sdk.V2Initiator) (bool, error) {
+	reviewersChecked := len(reviewers.Users) == 0 && len(reviewers.Groups) == 0
+	if len(reviewers.Users) > 0 {
+		reviewersChecked = sdk.IsInArray(initiator.Username(), reviewers.Users)
+	}
+	if !reviewersChecked {
+		for _, g := range reviewers.Groups {
+			grp, err := group.LoadByName(ctx, db, g, group.LoadOptions.WithMembers)
+			if err != nil {
+				return false, err
+			}
+			reviewersChecked = sdk.IsInArray(initiator.UserID, grp.Members.UserIDs())
+			if reviewersChecked {
+				break
+			}
+		}
+	}
+	return reviewersChecked || initiator.IsAdminWithMFA, nil
+}
+
 func checkCondition(ctx context.Context, condition string, currentJobContext sdk.WorkflowRunJobsContext) (bool, error) {
 	ctx, next := telemetry.Span(ctx, "checkCondition")
 	defer next()
@@ -2973,3 +3141,20 @@ func (api *API) workflowRunV2TriggerUnlocking(ctx context.Context, run *sdk.V2Wo
 	api.EnqueueWorkflowRun(ctx, wrEnqueue.RunID, wrEnqueue.Initiator, run.WorkflowName, run.RunNumber)
 	return nil
 }
+
+// exportRunTrace sends the trace of the current attempt of a terminated run
+func (api *API) exportRunTrace(ctx context.Context, run sdk.V2WorkflowRun) error {
+	runJobs, err := workflow_v2.LoadRunJobsByRunID(ctx, api.mustDBWithCtx(ctx), run.ID, run.RunAttempt)
+	if err != nil {
+		return err
+	}
+	runJobInfos := make(map[string][]sdk.V2WorkflowRunJobInfo, len(runJobs))
+	for _, rj := range runJobs {
+		infos, err := workflow_v2.LoadRunJobInfosByRunJobID(ctx, api.mustDBWithCtx(ctx), rj.ID)
+		if err != nil {
+			return err
+		}
+		runJobInfos[rj.ID] = infos
+	}
+	return api.RunTraceExporter.Export(ctx, run, runJobs, runJobInfos)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-gorp/gorp"
@@ -493,6 +494,13 @@ func mergeConcurrencyRules(rulesInDB []workflow_v2.ConcurrencyRule, refRule sdk.
 }
 
 func retrieveConcurrencyDefinition(ctx context.Context, db gorp.SqlExecutor, run sdk.V2WorkflowRun, concurrencyName string) (*sdk.V2RunConcurrency, error) {
+	// Implicit concurrency rule of an environment
+	if sdk.IsEnvironmentConcurrency(concurrencyName) {
+		env := sdk.ProjectEnvironment{Name: strings.TrimPrefix(concurrencyName, sdk.EnvironmentConcurrencyPrefix)}
+		concurrency := env.Concurrency()
+		return &concurrency, nil
+	}
+
 	// Search concurrency rule on workflow
 	scope := sdk.V2RunConcurrencyScopeWorkflow
 	var jobConcurrencyDef *sdk.WorkflowConcurrency
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/sdk"
+)
+
+func loadJobEnvironment(ctx context.Context, db gorp.SqlExecutor, projKey string, name string) (*sdk.ProjectEnvironment, error) {
+	env, err := project.LoadEnvironmentByNameAndProjectKey(ctx, db, projKey, name)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "environment %q not found on project %s", name, projKey)
+		}
+		return nil, err
+	}
+	return env, nil
+}
+
+// checkJobEnvironment checks the protection rules of the job environment: the git ref of the run must be allowed
+// and only reviewers can trigger the job.
+func checkJobEnvironment(ctx context.Context, db gorp.SqlExecutor, run sdk.V2WorkflowRun, jobDef sdk.V2Job, initiator sdk.V2Initiator) (bool, error) {
+	env, err := loadJobEnvironment(ctx, db, run.ProjectKey, jobDef.Environment)
+	if err != nil {
+		return false, err
+	}
+	allowed, err := env.IsRefAllowed(run.Contexts.Git.Ref)
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		return false, sdk.NewErrorFrom(sdk.ErrForbidden, "git ref %s is not allowed to deploy on environment %s", run.Contexts.Git.Ref, env.Name)
+	}
+	return checkReviewers(ctx, db, env.Reviewers(), initiator)
+}
+
+// applyJobEnvironment adds the environment variable set and the environment concurrency rule to the job.
+// A job deploying on an environment can't have its own concurrency rule, only one job at a time can deploy on it.
+func applyJobEnvironment(env sdk.ProjectEnvironment, jobDef *sdk.V2Job) error {
+	concurrencyName := env.Concurrency().Name
+	if jobDef.Concurrency != "" && jobDef.Concurrency != concurrencyName {
+		return sdk.NewErrorFrom(sdk.ErrInvalidData, "concurrency %s is not allowed on a job deploying on environment %s", jobDef.Concurrency, env.Name)
+	}
+	if env.VariableSet != "" && !sdk.IsInArray(env.VariableSet, jobDef.VariableSets) {
+		varsets := make([]string, 0, len(jobDef.VariableSets)+1)
+		varsets = append(varsets, jobDef.VariableSets...)
+		jobDef.VariableSets = append(varsets, env.VariableSet)
+	}
+	jobDef.Concurrency = concurrencyName
+	return nil
+}
+
+// checkEnvironmentWaitTimer returns false while the environment wait timer is running.
+// The timer starts when the last job needed by the job ends, or when the run starts.
+func checkEnvironmentWaitTimer(ctx context.Context, db gorp.SqlExecutor, env sdk.ProjectEnvironment, run sdk.V2WorkflowRun, runJobs []sdk.V2WorkflowRunJob, jobID string, jobDef sdk.V2Job) (bool, *sdk.V2WorkflowRunInfo, error) {
+	if env.WaitTimer <= 0 {
+		return true, nil, nil
+	}
+	readyAt := run.Started
+	for _, rj := range runJobs {
+		if sdk.IsInArray(rj.JobID, jobDef.Needs) && rj.Ended != nil && rj.Ended.After(readyAt) {
+			readyAt = *rj.Ended
+		}
+	}
+	waitUntil := readyAt.Add(time.Duration(env.WaitTimer) * time.Minute)
+	if !time.Now().Before(waitUntil) {
+		return true, nil, nil
+	}
+
+	// The run is triggered again every minute while waiting, only add the message once
+	msg := fmt.Sprintf("Job %q: waiting for environment %q until %s", jobID, env.Name, waitUntil.Format(time.RFC3339))
+	info, err := newRunInfoOnce(ctx, db, run, sdk.WorkflowRunInfoLevelInfo, msg)
+	return false, info, err
+}
+
+// newRunInfoOnce returns a run info with the given message, or nil if the run already has it.
+func newRunInfoOnce(ctx context.Context, db gorp.SqlExecutor, run sdk.V2WorkflowRun, level string, msg string) (*sdk.V2WorkflowRunInfo, error) {
+	infos, err := workflow_v2.LoadRunInfosByRunID(ctx, db, run.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range infos {
+		if i.Message == msg {
+			return nil, nil
+		}
+	}
+	return &sdk.V2WorkflowRunInfo{
+		WorkflowRunID: run.ID,
+		IssuedAt:      time.Now(),
+		Level:         level,
+		Message:       msg,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/sdk"
+)
+
+// activeJobFreeze returns the active freeze of the project that holds the job and its end, or nil if the job
+// is not frozen or if the freeze has been overridden for the run.
+func activeJobFreeze(ctx context.Context, db gorp.SqlExecutor, freezes []sdk.ProjectFreeze, run sdk.V2WorkflowRun, jobDef sdk.V2Job) (*sdk.ProjectFreeze, *time.Time, error) {
+	now := time.Now()
+	var overrides []sdk.ProjectFreezeOverride
+	for i := range freezes {
+		f := &freezes[i]
+		until := f.ActiveUntil(now)
+		if until == nil {
+			continue
+		}
+		match, err := f.Matches(run.WorkflowName, jobDef.Environment)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !match {
+			continue
+		}
+		if overrides == nil {
+			overrides, err = project.LoadFreezeOverridesByRunID(ctx, db, run.ProjectKey, run.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		overridden := false
+		for _, o := range overrides {
+			if o.Freeze == f.Name {
+				overridden = true
+				break
+			}
+		}
+		if overridden {
+			continue
+		}
+		return f, until, nil
+	}
+	return nil, nil, nil
+}
+
+// checkJobFreeze returns false while an active freeze of the project holds the job, unless the freeze
+// has been overridden for the run.
+func checkJobFreeze(ctx context.Context, db gorp.SqlExecutor, freezes []sdk.ProjectFreeze, run sdk.V2WorkflowRun, jobID string, jobDef sdk.V2Job) (bool, *sdk.V2WorkflowRunInfo, error) {
+	f, until, err := activeJobFreeze(ctx, db, freezes, run, jobDef)
+	if err != nil {
+		return false, nil, err
+	}
+	if f == nil {
+		return true, nil, nil
+	}
+	// Held jobs are released by the blocked runs trigger, only add the message once
+	msg := fmt.Sprintf("Job %q: held by freeze %q until %s", jobID, f.Name, until.Format(time.RFC3339))
+	info, err := newRunInfoOnce(ctx, db, run, sdk.WorkflowRunInfoLevelWarning, msg)
+	return false, info, err
+}
+
+// releaseFrozenRunJobs deletes the blocked run jobs held by a freeze that is no more active for the run,
+// they will be created again when the run is enqueued. It returns true if a run job has been released.
+func releaseFrozenRunJobs(ctx context.Context, db *gorp.DbMap, freezes []sdk.ProjectFreeze, run sdk.V2WorkflowRun, heldRunJobs []sdk.V2WorkflowRunJob) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	released := false
+	for _, rj := range heldRunJobs {
+		f, _, err := activeJobFreeze(ctx, tx, freezes, run, rj.Job)
+		if err != nil {
+			return false, err
+		}
+		if f != nil {
+			continue
+		}
+		if err := workflow_v2.DeleteRunJob(tx, rj.ID); err != nil {
+			return false, err
+		}
+		released = true
+	}
+	if !released {
+		return false, nil
+	}
+	return true, sdk.WithStack(tx.Commit())
+}
+
+// triggerFrozenWorkflowRuns enqueues the runs that have jobs held by a freeze that has ended.
+func (api *API) triggerFrozenWorkflowRuns(ctx context.Context) error {
+	heldRunJobs, err := workflow_v2.LoadRunJobsHeldByFreeze(ctx, api.mustDB())
+	if err != nil {
+		return err
+	}
+	runJobsByRun := make(map[string][]sdk.V2WorkflowRunJob)
+	for _, rj := range heldRunJobs {
+		runJobsByRun[rj.WorkflowRunID] = append(runJobsByRun[rj.WorkflowRunID], rj)
+	}
+
+	freezesByProject := make(map[string][]sdk.ProjectFreeze)
+	for runID, runJobs := range runJobsByRun {
+		run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runID)
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+			continue
+		}
+		freezes, has := freezesByProject[run.ProjectKey]
+		if !has {
+			freezes, err = project.LoadFreezesByProjectKey(ctx, api.mustDB(), run.ProjectKey)
+			if err != nil {
+				return err
+			}
+			freezesByProject[run.ProjectKey] = freezes
+		}
+		released, err := releaseFrozenRunJobs(ctx, api.mustDB(), freezes, *run, runJobs)
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+			continue
+		}
+		if released {
+			api.EnqueueWorkflowRun(ctx, run.ID, runJobs[0].Initiator, run.WorkflowName, run.RunNumber)
+		}
+	}
+	return nil
+}
@@ -319,12 +319,12 @@ func TestBuildCurrentJobContextWithStages(t *testing.T) {
 }
 
 func TestGenerateMatrix(t *testing.T) {
-	matrix := map[string][]string{
+	matrix := map[string][]interface{}{
 		"foo": {"foo1", "foo2"},
 		"bar": {"bar1", "bar2"},
 	}
-	all := make([]map[string]string, 0)
-	generateMatrix(matrix, []string{"foo", "bar"}, 0, map[string]string{}, &all)
+	all := make([]sdk.JobMatrix, 0)
+	generateMatrix(matrix, []string{"foo", "bar"}, 0, sdk.JobMatrix{}, &all)
 
 	require.Equal(t, 4, len(all))
 	foo1bar1 := false
@@ -351,6 +351,58 @@ func TestGenerateMatrix(t *testing.T) {
 	require.True(t, foo2bar2)
 }
 
+func TestGenerateMatrixPermutation(t *testing.T) {
+	ctx := context.TODO()
+	run := &sdk.V2WorkflowRun{ID: sdk.UUID()}
+	jobsContext := sdk.WorkflowRunJobsContext{
+		Jobs: sdk.JobsResultContext{
+			"detect": sdk.JobResultContext{
+				Result:  sdk.V2WorkflowRunJobStatusSuccess,
+				Outputs: sdk.JobResultOutput{"matrix": `{"service":[{"name":"api","path":"./api"},{"name":"ui","path":"./ui"}],"os":["linux"]}`},
+			},
+		},
+	}
+
+	// Structured values are kept as is
+	jobDef := sdk.V2Job{Strategy: &sdk.V2JobStrategy{Matrix: map[string]interface{}{
+		"service": []interface{}{
+			map[string]interface{}{"name": "api", "port": float64(8080)},
+			map[string]interface{}{"name": "ui", "port": float64(80)},
+		},
+		"os": []interface{}{"linux", "windows"},
+	}}}
+	perms, info := generateMatrixPermutation(ctx, jobsContext, run, "build", jobDef, sdk.V2WorkflowRunJobStatusBuilding, 10)
+	require.Nil(t, info)
+	require.Len(t, perms, 4)
+	require.Equal(t, map[string]interface{}{"name": "api", "port": float64(8080)}, perms[0]["service"])
+	require.Equal(t, "os=linux, service=api", perms[0].String())
+
+	// The whole matrix is given by an expression
+	jobDef = sdk.V2Job{Strategy: &sdk.V2JobStrategy{MatrixExpression: "${{ fromJSON(jobs.detect.outputs.matrix) }}"}}
+	perms, info = generateMatrixPermutation(ctx, jobsContext, run, "build", jobDef, sdk.V2WorkflowRunJobStatusBuilding, 10)
+	require.Nil(t, info)
+	require.Len(t, perms, 2)
+	require.Equal(t, "./ui", perms[1]["service"].(map[string]interface{})["path"])
+	require.Empty(t, jobDef.Strategy.MatrixExpression)
+	require.Len(t, jobDef.Strategy.Matrix["service"], 2)
+
+	// Too many permutations
+	jobDef = sdk.V2Job{Strategy: &sdk.V2JobStrategy{Matrix: map[string]interface{}{
+		"foo": []interface{}{"1", "2", "3"},
+		"bar": []interface{}{"1", "2", "3"},
+	}}}
+	_, info = generateMatrixPermutation(ctx, jobsContext, run, "build", jobDef, sdk.V2WorkflowRunJobStatusBuilding, 8)
+	require.NotNil(t, info)
+	require.Equal(t, sdk.WorkflowRunInfoLevelError, info.Level)
+	require.Contains(t, info.Message, "Job build: matrix generates more than 8 permutations")
+
+	// The expression must return an object
+	jobDef = sdk.V2Job{Strategy: &sdk.V2JobStrategy{MatrixExpression: "${{ jobs.detect.outputs.matrix }}"}}
+	_, info = generateMatrixPermutation(ctx, jobsContext, run, "build", jobDef, sdk.V2WorkflowRunJobStatusBuilding, 10)
+	require.NotNil(t, info)
+	require.Contains(t, info.Message, "must return an object")
+}
+
 func TestWorkflowTrigger1Job(t *testing.T) {
 	api, db, _ := newTestAPI(t)
 
@@ -750,6 +802,114 @@ func TestWorkflowTriggerWithConditionKOSyntax(t *testing.T) {
 	require.Equal(t, sdk.V2WorkflowRunStatusFail, runDB.Status)
 }
 
+func TestWorkflowTriggerJobWithEnvironmentAndConcurrency(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+	_, err = db.Exec("DELETE FROM region")
+	require.NoError(t, err)
+
+	admin, _ := assets.InsertAdminUser(t, db)
+
+	org, err := organization.LoadOrganizationByName(context.TODO(), db, "default")
+	require.NoError(t, err)
+
+	reg := sdk.Region{
+		Name: "build",
+	}
+	require.NoError(t, region.Insert(context.TODO(), db, &reg))
+	api.Config.Workflow.JobDefaultRegion = reg.Name
+
+	rb := sdk.RBAC{
+		Name: sdk.RandomString(10),
+		Regions: []sdk.RBACRegion{
+			{
+				RegionID:            reg.ID,
+				AllUsers:            true,
+				RBACOrganizationIDs: []string{org.ID},
+				Role:                sdk.RegionRoleExecute,
+			},
+		},
+	}
+	require.NoError(t, rbac.Insert(context.TODO(), db, &rb))
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	vcsServer := assets.InsertTestVCSProject(t, db, proj.ID, "github", "github")
+	repo := assets.InsertTestProjectRepository(t, db, proj.Key, vcsServer.ID, sdk.RandomString(10))
+
+	env := sdk.ProjectEnvironment{
+		ProjectKey: proj.Key,
+		Name:       "production",
+	}
+	require.NoError(t, project.InsertEnvironment(context.TODO(), db, &env))
+
+	wr := sdk.V2WorkflowRun{
+		ProjectKey:   proj.Key,
+		VCSServerID:  vcsServer.ID,
+		VCSServer:    vcsServer.Name,
+		RepositoryID: repo.ID,
+		Repository:   repo.Name,
+		WorkflowName: sdk.RandomString(10),
+		WorkflowSha:  "123",
+		WorkflowRef:  "master",
+		RunAttempt:   1,
+		RunNumber:    1,
+		Started:      time.Now(),
+		LastModified: time.Now(),
+		Status:       sdk.V2WorkflowRunStatusBuilding,
+		RunEvent:     sdk.V2WorkflowRunEvent{},
+		WorkflowData: sdk.V2WorkflowRunData{Workflow: sdk.V2Workflow{
+			Concurrencies: []sdk.WorkflowConcurrency{
+				{
+					Name:  "deploy",
+					Order: sdk.ConcurrencyOrderOldestFirst,
+					Pool:  2,
+				},
+			},
+			Jobs: map[string]sdk.V2Job{
+				"deploy": {
+					Environment: "production",
+					Concurrency: "deploy",
+					Steps: []sdk.ActionStep{
+						{
+							ID: "1",
+						},
+					},
+				},
+			},
+		}},
+		Initiator: &sdk.V2Initiator{
+			UserID: admin.ID,
+			User:   admin.Initiator(),
+		},
+	}
+	require.NoError(t, workflow_v2.InsertRun(context.Background(), db, &wr))
+
+	require.NoError(t, api.workflowRunV2Trigger(context.Background(), sdk.V2WorkflowRunEnqueue{
+		RunID: wr.ID,
+		Initiator: sdk.V2Initiator{
+			UserID:         admin.ID,
+			User:           admin.Initiator(),
+			IsAdminWithMFA: true,
+		},
+	}))
+
+	// The job concurrency can't replace the environment one
+	runInfos, err := workflow_v2.LoadRunInfosByRunID(context.TODO(), db, wr.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(runInfos))
+	require.Contains(t, runInfos[0].Message, "concurrency deploy is not allowed on a job deploying on environment production")
+
+	runjobs, err := workflow_v2.LoadRunJobsByRunID(context.TODO(), db, wr.ID, wr.RunAttempt)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(runjobs))
+
+	runDB, err := workflow_v2.LoadRunByID(context.TODO(), db, wr.ID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.V2WorkflowRunStatusFail, runDB.Status)
+}
+
 func TestTriggerBlockedWorkflowRuns(t *testing.T) {
 	ctx := context.TODO()
 	api, db, _ := newTestAPI(t)
@@ -1205,7 +1365,7 @@ func TestWorkflowMatrixNeeds(t *testing.T) {
 		ProjectKey:    wr.ProjectKey,
 		RunNumber:     wr.RunNumber,
 		RunAttempt:    wr.RunAttempt,
-		Matrix: map[string]string{
+		Matrix: sdk.JobMatrix{
 			"foo": "foo1",
 		},
 		Initiator: *wr.Initiator,
@@ -1220,7 +1380,7 @@ func TestWorkflowMatrixNeeds(t *testing.T) {
 		ProjectKey:    wr.ProjectKey,
 		RunNumber:     wr.RunNumber,
 		RunAttempt:    wr.RunAttempt,
-		Matrix: map[string]string{
+		Matrix: sdk.JobMatrix{
 			"foo": "foo2",
 		},
 		Initiator: *wr.Initiator,
@@ -1353,7 +1513,7 @@ func TestWorkflowStageMatrixNeeds(t *testing.T) {
 		ProjectKey:    wr.ProjectKey,
 		RunNumber:     wr.RunNumber,
 		RunAttempt:    wr.RunAttempt,
-		Matrix: map[string]string{
+		Matrix: sdk.JobMatrix{
 			"foo": "foo1",
 		},
 		Initiator: *wr.Initiator,
@@ -1368,7 +1528,7 @@ func TestWorkflowStageMatrixNeeds(t *testing.T) {
 		ProjectKey:    wr.ProjectKey,
 		RunNumber:     wr.RunNumber,
 		RunAttempt:    wr.RunAttempt,
-		Matrix: map[string]string{
+		Matrix: sdk.JobMatrix{
 			"foo": "foo2",
 		},
 		Initiator: *wr.Initiator,
@@ -0,0 +1,338 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/engine/websocket"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	jobDebugPubSubKey = "api:job:debug"
+	jobDebugCacheKey  = "api:job:debug:worker"
+)
+
+// jobDebugServer keeps the websockets of the workers waiting for debug sessions and of the users debugging a job
+// on the current API instance. Messages are relayed between API instances through the cache.
+type jobDebugServer struct {
+	mutex    sync.RWMutex
+	workers  map[string]websocket.Client
+	sessions map[string]*jobDebugSession
+}
+
+type jobDebugSession struct {
+	mutex   sync.Mutex
+	client  websocket.Client
+	conn    *gorillawebsocket.Conn
+	session sdk.V2WorkflowRunJobDebugSession
+}
+
+func (api *API) initJobDebugWebsocket(pubSubKey string) error {
+	log.Info(api.Router.Background, "Initializing job debug WS server")
+	api.WSJobDebugServer = &jobDebugServer{
+		workers:  make(map[string]websocket.Client),
+		sessions: make(map[string]*jobDebugSession),
+	}
+	pubSub, err := api.Cache.Subscribe(pubSubKey)
+	if err != nil {
+		return sdk.WrapError(err, "unable to subscribe to %s", pubSubKey)
+	}
+	api.WSJobDebugBroker = websocket.NewBroker()
+	api.WSJobDebugBroker.OnMessage(func(m []byte) {
+		var msg sdk.V2JobDebugMessage
+		if err := sdk.JSONUnmarshal(m, &msg); err != nil {
+			err = sdk.WrapError(err, "cannot parse job debug message")
+			ctx := sdk.ContextWithStacktrace(context.TODO(), err)
+			log.Warn(ctx, err.Error())
+			return
+		}
+		api.WSJobDebugServer.dispatch(api.Router.Background, msg)
+	})
+	api.WSJobDebugBroker.Init(api.Router.Background, api.GoRoutines, pubSub)
+	return nil
+}
+
+// dispatch sends the message to the worker or to the user connected on the current API instance.
+func (s *jobDebugServer) dispatch(ctx context.Context, msg sdk.V2JobDebugMessage) {
+	if msg.ToWorker {
+		s.mutex.RLock()
+		c, has := s.workers[msg.RunJobID]
+		s.mutex.RUnlock()
+		if !has {
+			return
+		}
+		if err := c.Send(msg); err != nil {
+			log.Debug(ctx, "jobDebugServer.dispatch> unable to send message to worker of job %s: %v", msg.RunJobID, err)
+		}
+		return
+	}
+
+	s.mutex.RLock()
+	sessions := make([]*jobDebugSession, 0, 1)
+	for id, session := range s.sessions {
+		// A release ends all the sessions of the job
+		if id == msg.SessionID || (msg.Type == sdk.V2JobDebugMessageRelease && session.session.WorkflowRunJobID == msg.RunJobID) {
+			sessions = append(sessions, session)
+		}
+	}
+	s.mutex.RUnlock()
+	for _, session := range sessions {
+		if msg.Type == sdk.V2JobDebugMessageOutput {
+			session.mutex.Lock()
+			session.session.AppendOutput(msg.Data)
+			session.mutex.Unlock()
+		}
+		if err := session.client.Send(msg); err != nil {
+			log.Debug(ctx, "jobDebugServer.dispatch> unable to send message to session %s: %v", session.session.ID, err)
+		}
+		if msg.Type == sdk.V2JobDebugMessageEnd || msg.Type == sdk.V2JobDebugMessageRelease {
+			_ = session.conn.Close()
+		}
+	}
+}
+
+func (api *API) publishJobDebugMessage(ctx context.Context, msg sdk.V2JobDebugMessage) {
+	bts, _ := json.Marshal(msg)
+	if err := api.Cache.Publish(ctx, jobDebugPubSubKey, string(bts)); err != nil {
+		log.Error(ctx, "unable to publish job debug message: %v", err)
+	}
+}
+
+// getJobRunDebugWebsocketHandler is opened by a worker waiting for debug sessions at the end of a job.
+func (api *API) getJobRunDebugWebsocketHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.jobRunDebug),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			jobRunID := vars["runJobID"]
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), jobRunID)
+			if err != nil {
+				return err
+			}
+			if runJob.Status != sdk.V2WorkflowRunJobStatusBuilding {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "job %s is not building", runJob.JobID)
+			}
+			if runJob.Job.Debug == "" {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "debug is not enabled on job %s", runJob.JobID)
+			}
+
+			// The API bounds the debug time whatever the worker does, a reconnection doesn't extend it
+			debugKey := cache.Key(jobDebugCacheKey, runJob.ID)
+			var debug sdk.V2WorkflowRunJobDebug
+			has, err := api.Cache.Get(debugKey, &debug)
+			if err != nil {
+				return err
+			}
+			if !has {
+				debug = sdk.V2WorkflowRunJobDebug{
+					RunJobID: runJob.ID,
+					Worker:   getWorker(ctx).Name,
+					Until:    time.Now().Add(runJob.Job.GetDebugTimeout()),
+				}
+				if err := api.Cache.SetWithDuration(debugKey, debug, time.Until(debug.Until)); err != nil {
+					return err
+				}
+			}
+			ctx, cancel := context.WithDeadline(ctx, debug.Until)
+			defer cancel()
+
+			c, err := websocket.Upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				service.WriteError(ctx, w, r, sdk.NewErrorWithStack(err, sdk.ErrWebsocketUpgrade))
+				return nil
+			}
+			defer c.Close() // nolint
+
+			wsClient := websocket.NewClient(c)
+			wsClient.OnMessage(func(m []byte) {
+				var msg sdk.V2JobDebugMessage
+				if err := sdk.JSONUnmarshal(m, &msg); err != nil {
+					log.Warn(ctx, "getJobRunDebugWebsocketHandler> unable to read message from worker: %v", err)
+					return
+				}
+				msg.RunJobID = runJob.ID
+				msg.ToWorker = false
+				api.publishJobDebugMessage(ctx, msg)
+			})
+
+			api.WSJobDebugServer.mutex.Lock()
+			api.WSJobDebugServer.workers[runJob.ID] = wsClient
+			api.WSJobDebugServer.mutex.Unlock()
+			defer func() {
+				api.WSJobDebugServer.mutex.Lock()
+				if api.WSJobDebugServer.workers[runJob.ID] == wsClient {
+					delete(api.WSJobDebugServer.workers, runJob.ID)
+				}
+				api.WSJobDebugServer.mutex.Unlock()
+			}()
+
+			api.GoRoutines.Exec(ctx, "getJobRunDebugWebsocketHandler-"+runJob.ID, func(ctx context.Context) {
+				<-ctx.Done()
+				_ = c.Close()
+			})
+			log.Info(ctx, "getJobRunDebugWebsocketHandler> worker %s waits for debug sessions on job %s until %v", debug.Worker, runJob.ID, debug.Until)
+			return wsClient.Listen(ctx, api.GoRoutines)
+		}
+}
+
+// getWorkflowRunJobDebugWebsocketHandler opens an interactive debug session on a job whose worker waits for it.
+func (api *API) getWorkflowRunJobDebugWebsocketHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.workflowDebug),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			pKey := vars["projectKey"]
+			workflowRunID := vars["workflowRunID"]
+			jobRunID := vars["jobRunID"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), pKey, workflowRunID)
+			if err != nil {
+				return err
+			}
+			runJob, err := workflow_v2.LoadRunJobByRunIDAndID(ctx, api.mustDB(), wr.ID, jobRunID)
+			if err != nil {
+				return err
+			}
+			var debug sdk.V2WorkflowRunJobDebug
+			has, err := api.Cache.Get(cache.Key(jobDebugCacheKey, runJob.ID), &debug)
+			if err != nil {
+				return err
+			}
+			if !has || runJob.Status != sdk.V2WorkflowRunJobStatusBuilding {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "job %s is not waiting for debug sessions", runJob.JobID)
+			}
+
+			session := &jobDebugSession{
+				session: sdk.V2WorkflowRunJobDebugSession{
+					WorkflowRunID:    wr.ID,
+					WorkflowRunJobID: runJob.ID,
+					ProjectKey:       wr.ProjectKey,
+					UserID:           u.AuthConsumerUser.AuthentifiedUser.ID,
+					Username:         u.AuthConsumerUser.AuthentifiedUser.Username,
+					Started:          time.Now(),
+				},
+			}
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := workflow_v2.InsertRunJobDebugSession(ctx, tx, &session.session); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishRunJobDebugEvent(ctx, api.Cache, sdk.EventRunJobDebugStarted, *runJob, session.session)
+			log.Info(ctx, "getWorkflowRunJobDebugWebsocketHandler> user %s opened debug session %s on job %s", session.session.Username, session.session.ID, runJob.ID)
+
+			defer func() {
+				api.publishJobDebugMessage(ctx, sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageEnd, RunJobID: runJob.ID, SessionID: session.session.ID, ToWorker: true})
+				if err := api.endJobDebugSession(context.Background(), *runJob, session); err != nil {
+					log.ErrorWithStackTrace(ctx, err)
+				}
+			}()
+
+			c, err := websocket.Upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				service.WriteError(ctx, w, r, sdk.NewErrorWithStack(err, sdk.ErrWebsocketUpgrade))
+				return nil
+			}
+			defer c.Close() // nolint
+			session.conn = c
+			session.client = websocket.NewClient(c)
+			session.client.OnMessage(func(m []byte) {
+				var msg sdk.V2JobDebugMessage
+				if err := sdk.JSONUnmarshal(m, &msg); err != nil {
+					log.Warn(ctx, "getWorkflowRunJobDebugWebsocketHandler> unable to read message from user: %v", err)
+					return
+				}
+				switch msg.Type {
+				case sdk.V2JobDebugMessageInput:
+					session.mutex.Lock()
+					session.session.AppendInput(msg.Data)
+					session.mutex.Unlock()
+				case sdk.V2JobDebugMessageEnd, sdk.V2JobDebugMessageRelease:
+				default:
+					return
+				}
+				msg.RunJobID = runJob.ID
+				msg.SessionID = session.session.ID
+				msg.ToWorker = true
+				api.publishJobDebugMessage(ctx, msg)
+			})
+
+			api.WSJobDebugServer.mutex.Lock()
+			api.WSJobDebugServer.sessions[session.session.ID] = session
+			api.WSJobDebugServer.mutex.Unlock()
+			defer func() {
+				api.WSJobDebugServer.mutex.Lock()
+				delete(api.WSJobDebugServer.sessions, session.session.ID)
+				api.WSJobDebugServer.mutex.Unlock()
+			}()
+
+			api.publishJobDebugMessage(ctx, sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageStart, RunJobID: runJob.ID, SessionID: session.session.ID, ToWorker: true})
+			return session.client.Listen(ctx, api.GoRoutines)
+		}
+}
+
+func (api *API) endJobDebugSession(ctx context.Context, runJob sdk.V2WorkflowRunJob, session *jobDebugSession) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	now := time.Now()
+	session.session.Ended = &now
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := workflow_v2.UpdateRunJobDebugSession(ctx, tx, &session.session); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+	event_v2.PublishRunJobDebugEvent(ctx, api.Cache, sdk.EventRunJobDebugEnded, runJob, session.session)
+	return nil
+}
+
+func (api *API) getWorkflowRunJobDebugSessionsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			vars := mux.Vars(r)
+			pKey := vars["projectKey"]
+			workflowRunID := vars["workflowRunID"]
+			jobRunID := vars["jobRunID"]
+
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), pKey, workflowRunID)
+			if err != nil {
+				return err
+			}
+			runJob, err := workflow_v2.LoadRunJobByRunIDAndID(ctx, api.mustDB(), wr.ID, jobRunID)
+			if err != nil {
+				return err
+			}
+			sessions, err := workflow_v2.LoadRunJobDebugSessionsByRunJobID(ctx, api.mustDB(), runJob.ID)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, sessions, http.StatusOK)
+		}
+}
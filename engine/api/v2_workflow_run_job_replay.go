@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/plugin"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunJobReplayHandler exports a run job with its resolved contexts and its actions so the worker can replay
+// it offline. Secrets are not exported: the vcs token, the secret variables and the integrations.
+func (api *API) getWorkflowRunJobReplayHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.workflowDebug),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			workflowRunID := vars["workflowRunID"]
+			jobRunID := vars["jobRunID"]
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), proj.Key, workflowRunID)
+			if err != nil {
+				return err
+			}
+
+			runJob, err := workflow_v2.LoadRunJobByRunIDAndID(ctx, api.mustDB(), wr.ID, jobRunID)
+			if err != nil {
+				return err
+			}
+
+			contexts, omittedSecrets, err := computeRunJobReplayContext(ctx, api.mustDB(), *wr, *runJob)
+			if err != nil {
+				return err
+			}
+
+			plugins, err := plugin.LoadAll(ctx, api.mustDB())
+			if err != nil {
+				return err
+			}
+
+			replay := sdk.V2WorkflowRunJobReplay{
+				RunJob:         *runJob,
+				AsCodeActions:  wr.WorkflowData.Actions,
+				Contexts:       *contexts,
+				Plugins:        plugins,
+				OmittedSecrets: omittedSecrets,
+				Created:        time.Now(),
+			}
+			return service.WriteJSON(w, replay, http.StatusOK)
+		}
+}
+
+// computeRunJobReplayContext computes the context of a run job like computeRunJobContext without loading any secret.
+// It returns the names of the secrets that are not exported.
+func computeRunJobReplayContext(ctx context.Context, db gorp.SqlExecutor, run sdk.V2WorkflowRun, runJob sdk.V2WorkflowRunJob) (*sdk.WorkflowRunJobsContext, []string, error) {
+	contexts := &sdk.WorkflowRunJobsContext{}
+	contexts.CDS = run.Contexts.CDS
+	contexts.CDS.Job = runJob.JobID
+	contexts.CDS.Stage = runJob.Job.Stage
+	contexts.Git = run.Contexts.Git
+	contexts.Git.Token = ""
+	contexts.Gate = runJob.GateInputs
+	contexts.Matrix = runJob.Matrix
+
+	omittedSecrets := make([]string, 0)
+	if run.Contexts.Git.Server != "" {
+		omittedSecrets = append(omittedSecrets, "git.token")
+	}
+
+	vss := make([]sdk.ProjectVariableSet, 0, len(runJob.Job.VariableSets))
+	for _, vsName := range runJob.Job.VariableSets {
+		vs, err := project.LoadVariableSetByName(ctx, db, run.ProjectKey, vsName)
+		if err != nil {
+			return nil, nil, err
+		}
+		items, err := project.LoadVariableSetAllItem(ctx, db, vs.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		vs.Items = make([]sdk.ProjectVariableSetItem, 0, len(items))
+		for _, item := range items {
+			if item.Type == sdk.ProjectVariableTypeSecret {
+				omittedSecrets = append(omittedSecrets, "vars."+vs.Name+"."+item.Name)
+				continue
+			}
+			vs.Items = append(vs.Items, item)
+		}
+		vss = append(vss, *vs)
+	}
+	varCtx, _, err := buildVarsContext(ctx, vss)
+	if err != nil {
+		return nil, nil, err
+	}
+	contexts.Vars = varCtx
+
+	contexts.Env = make(map[string]string)
+	for k, v := range run.Contexts.Env {
+		contexts.Env[k] = v
+	}
+	for k, v := range runJob.Job.Env {
+		contexts.Env[k] = v
+	}
+
+	runJobs, err := workflow_v2.LoadRunJobsByRunIDAndStatus(ctx, db, run.ID, []string{sdk.StatusFail, sdk.StatusSkipped, sdk.StatusSuccess, sdk.StatusStopped}, runJob.RunAttempt)
+	if err != nil {
+		return nil, nil, err
+	}
+	runJobIDs := make([]string, 0, len(runJobs))
+	for _, rj := range runJobs {
+		runJobIDs = append(runJobIDs, rj.ID)
+	}
+	runResults, err := workflow_v2.LoadRunResultsByRunIDAttempt(ctx, db, run.ID, runJobIDs, runJob.RunAttempt)
+	if err != nil {
+		return nil, nil, err
+	}
+	contexts.Jobs, _ = computeExistingRunJobContexts(ctx, runJobs, runResults)
+
+	contexts.Needs = sdk.NeedsContext{}
+	for _, n := range runJob.Job.Needs {
+		if j, has := contexts.Jobs[n]; has {
+			needContext := sdk.NeedContext{
+				Result:  j.Result,
+				Outputs: j.Outputs,
+			}
+			if j.Result == sdk.V2WorkflowRunJobStatusFail && run.WorkflowData.Workflow.Jobs[n].ContinueOnError {
+				needContext.Result = sdk.V2WorkflowRunJobStatusSuccess
+			}
+			contexts.Needs[n] = needContext
+		}
+	}
+
+	// Integrations contain credentials, the job is replayed without them
+	for _, i := range runJob.Job.Integrations {
+		omittedSecrets = append(omittedSecrets, "integrations."+i)
+	}
+	for _, i := range run.WorkflowData.Workflow.Integrations {
+		omittedSecrets = append(omittedSecrets, "integrations."+i)
+	}
+
+	return contexts, omittedSecrets, nil
+}
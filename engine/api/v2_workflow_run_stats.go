@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	workflowRunStatsDefaultWindow = 30 * 24 * time.Hour
+	workflowRunStatsMaxWindow     = 366 * 24 * time.Hour
+)
+
+// parseWorkflowRunStatsQuery extracts the stats window from the query, remaining values are run search filters.
+func parseWorkflowRunStatsQuery(query url.Values, now time.Time) (time.Time, time.Time, string, error) {
+	to := now
+	if v := query.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return to, to, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given value for to param: %q", v)
+		}
+		to = t
+	}
+	from := to.Add(-workflowRunStatsDefaultWindow)
+	if v := query.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return from, to, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given value for from param: %q", v)
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		return from, to, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "from must be before to")
+	}
+	if to.Sub(from) > workflowRunStatsMaxWindow {
+		return from, to, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "stats window cannot exceed 366 days")
+	}
+
+	interval := query.Get("interval")
+	switch interval {
+	case "":
+		interval = sdk.V2WorkflowRunStatsIntervalDay
+	case sdk.V2WorkflowRunStatsIntervalDay, sdk.V2WorkflowRunStatsIntervalWeek:
+	default:
+		return from, to, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given value for interval param: %q", interval)
+	}
+
+	query.Del("from")
+	query.Del("to")
+	query.Del("interval")
+	return from, to, interval, nil
+}
+
+func (api *API) getWorkflowRunsStatsV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			query := req.URL.Query()
+			from, to, interval, err := parseWorkflowRunStatsQuery(query, time.Now())
+			if err != nil {
+				return err
+			}
+			filters, _, _, _ := parseWorkflowRunsSearchV2Query(query)
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			rows, err := workflow_v2.LoadRunsStats(ctx, api.mustDB(), proj.Key, filters, from, to, interval)
+			if err != nil {
+				return sdk.WrapError(err, "unable to load runs stats")
+			}
+
+			return service.WriteJSON(w, sdk.NewV2WorkflowRunStats(rows, from, to, interval), http.StatusOK)
+		}
+}
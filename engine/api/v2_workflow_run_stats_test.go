@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_parseWorkflowRunStatsQuery(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	query := url.Values{"workflow": {"github/my/repo/build"}, "interval": {"week"}}
+	from, to, interval, err := parseWorkflowRunStatsQuery(query, now)
+	require.NoError(t, err)
+	require.Equal(t, now, to)
+	require.Equal(t, now.Add(-30*24*time.Hour), from)
+	require.Equal(t, sdk.V2WorkflowRunStatsIntervalWeek, interval)
+	require.Equal(t, url.Values{"workflow": {"github/my/repo/build"}}, query)
+
+	from, to, interval, err = parseWorkflowRunStatsQuery(url.Values{"from": {"2024-01-01T00:00:00Z"}, "to": {"2024-02-01T00:00:00Z"}}, now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), to)
+	require.Equal(t, sdk.V2WorkflowRunStatsIntervalDay, interval)
+
+	_, _, _, err = parseWorkflowRunStatsQuery(url.Values{"from": {"2022-01-01T00:00:00Z"}}, now)
+	require.Error(t, err)
+	_, _, _, err = parseWorkflowRunStatsQuery(url.Values{"interval": {"month"}}, now)
+	require.Error(t, err)
+	_, _, _, err = parseWorkflowRunStatsQuery(url.Values{"to": {"yesterday"}}, now)
+	require.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+var insightKeyPattern = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// runJobInsightKey returns the key of the insight report of a job, the same for all the runs of the job on a commit.
+func runJobInsightKey(runJob sdk.V2WorkflowRunJob) string {
+	key := insightKeyPattern.ReplaceAllString(fmt.Sprintf("cds-%s-%s", runJob.WorkflowName, runJob.JobID), "-")
+	if len(key) > 50 {
+		key = key[:50]
+	}
+	return key
+}
+
+func (api *API) postJobRunSummaryHandler() ([]service.RbacChecker, service.Handler) {
+	return []service.RbacChecker{api.jobRunUpdate, api.isWorker},
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			runJobID := vars["runJobID"]
+
+			var summaryRequest sdk.V2WorkflowRunJobSummaryRequest
+			if err := service.UnmarshalBody(req, &summaryRequest); err != nil {
+				return err
+			}
+			if err := summaryRequest.Check(); err != nil {
+				return err
+			}
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), runJobID)
+			if err != nil {
+				return err
+			}
+			service.TrackActionMetadataFromFields(w, runJob)
+			if runJob.Status.IsTerminated() {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %s is already terminated", runJob.JobID)
+			}
+
+			summary := sdk.V2WorkflowRunJobSummary{
+				WorkflowRunID:    runJob.WorkflowRunID,
+				WorkflowRunJobID: runJob.ID,
+				JobID:            runJob.JobID,
+				StepName:         summaryRequest.StepName,
+				RunAttempt:       runJob.RunAttempt,
+				Summary:          summaryRequest.Summary,
+				Annotations:      summaryRequest.Annotations,
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := workflow_v2.InsertRunJobSummary(ctx, tx, &summary); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			if len(summary.Annotations) > 0 {
+				api.GoRoutines.Exec(context.Background(), "sendRunJobInsightReport-"+runJob.ID, func(ctx context.Context) {
+					if err := sendRunJobInsightReport(ctx, api.mustDB(), api.Cache, *runJob); err != nil {
+						log.ErrorWithStackTrace(ctx, err)
+						if err := insertRunJobInfoWarning(ctx, api.mustDB(), *runJob, fmt.Sprintf("unable to send annotations to the repository: %v", err)); err != nil {
+							log.ErrorWithStackTrace(ctx, err)
+						}
+					}
+				})
+			}
+
+			return service.WriteJSON(w, summary, http.StatusOK)
+		}
+}
+
+func insertRunJobInfoWarning(ctx context.Context, db *gorp.DbMap, runJob sdk.V2WorkflowRunJob, msg string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := workflow_v2.InsertRunJobInfo(ctx, tx, &sdk.V2WorkflowRunJobInfo{
+		WorkflowRunID:    runJob.WorkflowRunID,
+		WorkflowRunJobID: runJob.ID,
+		Level:            sdk.WorkflowRunInfoLevelWarning,
+		Message:          msg,
+	}); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+// sendRunJobInsightReport sends all the annotations of the job to the commit the run is built from.
+// Each call sends the whole report again, so it always contains every annotation of the job.
+func sendRunJobInsightReport(ctx context.Context, db *gorp.DbMap, store cache.Store, runJob sdk.V2WorkflowRunJob) error {
+	wr, err := workflow_v2.LoadRunByID(ctx, db, runJob.WorkflowRunID)
+	if err != nil {
+		return err
+	}
+	git := wr.Contexts.Git
+	if git.Server == "" || git.Repository == "" || git.Sha == "" {
+		return nil
+	}
+
+	summaries, err := workflow_v2.LoadRunJobSummariesByRunJobID(ctx, db, runJob.ID)
+	if err != nil {
+		return err
+	}
+
+	insight := sdk.VCSInsight{
+		Title:       fmt.Sprintf("%s - %s", runJob.WorkflowName, runJob.JobID),
+		Result:      sdk.VCSInsightResultPass,
+		Datas:       make([]sdk.VCSInsightData, 0, 2),
+		Annotations: make([]sdk.VCSInsightAnnotation, 0),
+	}
+	for _, s := range summaries {
+		for _, a := range s.Annotations {
+			if a.Level == sdk.V2WorkflowRunJobAnnotationLevelFailure {
+				insight.Result = sdk.VCSInsightResultFail
+			}
+			msg := a.Message
+			if a.Title != "" {
+				msg = a.Title + ": " + msg
+			}
+			insight.Annotations = append(insight.Annotations, sdk.VCSInsightAnnotation{
+				Path:     a.File,
+				Line:     a.Line,
+				Severity: a.InsightSeverity(),
+				Message:  msg,
+			})
+		}
+	}
+	insight.Detail = fmt.Sprintf("%d annotation(s) reported by job %s", len(insight.Annotations), runJob.JobID)
+	insight.Datas = append(insight.Datas, sdk.VCSInsightData{
+		Title: "Run",
+		Type:  "TEXT",
+		Text:  strconv.FormatInt(wr.RunNumber, 10),
+	})
+	if wr.Contexts.CDS.RunURL != "" {
+		insight.Datas = append(insight.Datas, sdk.VCSInsightData{
+			Title: "Link",
+			Type:  "LINK",
+			Text:  "See in CDS",
+			Href:  wr.Contexts.CDS.RunURL,
+		})
+	}
+
+	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, wr.ProjectKey, git.Server)
+	if err != nil {
+		return err
+	}
+	return vcsClient.CreateInsightReport(ctx, git.Repository, git.Sha, runJobInsightKey(runJob), insight)
+}
+
+func (api *API) getWorkflowRunSummariesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			workflowRunID := vars["workflowRunID"]
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), proj.Key, workflowRunID)
+			if err != nil {
+				return err
+			}
+
+			attempt := wr.RunAttempt
+			if attemptS := FormString(req, "attempt"); attemptS != "" {
+				attempt, err = strconv.ParseInt(attemptS, 10, 64)
+				if err != nil {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid attempt %q", attemptS)
+				}
+			}
+
+			summaries, err := workflow_v2.LoadRunJobSummariesByRunID(ctx, api.mustDB(), wr.ID, attempt)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, summaries, http.StatusOK)
+		}
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rockbears/yaml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/hatchery"
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/region"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestPostJobRunSummaryHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+	ctx := context.TODO()
+
+	db.Exec("DELETE FROM rbac")
+	db.Exec("DELETE FROM region")
+	db.Exec("DELETE FROM v2_workflow_run_job")
+	db.Exec("DELETE FROM hatchery")
+
+	admin, pwd := assets.InsertAdminUser(t, db)
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	vcsServer := assets.InsertTestVCSProject(t, db, proj.ID, "github", "github")
+	repo := assets.InsertTestProjectRepository(t, db, proj.Key, vcsServer.ID, "myrepo")
+
+	wr := sdk.V2WorkflowRun{
+		Status:           sdk.V2WorkflowRunStatusBuilding,
+		ProjectKey:       proj.Key,
+		DeprecatedUserID: admin.ID,
+		WorkflowName:     sdk.RandomString(10),
+		RepositoryID:     repo.ID,
+		VCSServerID:      vcsServer.ID,
+		VCSServer:        vcsServer.Name,
+		Repository:       repo.Name,
+		RunAttempt:       1,
+	}
+	require.NoError(t, workflow_v2.InsertRun(ctx, db, &wr))
+
+	jobRun := sdk.V2WorkflowRunJob{
+		ProjectKey:    proj.Key,
+		Status:        sdk.V2WorkflowRunJobStatusBuilding,
+		JobID:         "job1",
+		ModelType:     "docker",
+		ModelOSArch:   "linux/amd64",
+		Region:        "default",
+		WorkflowRunID: wr.ID,
+		RunAttempt:    1,
+		Initiator: sdk.V2Initiator{
+			UserID: admin.ID,
+			User:   admin.Initiator(),
+		},
+	}
+	require.NoError(t, workflow_v2.InsertRunJob(ctx, db, &jobRun))
+
+	hatch := sdk.Hatchery{
+		ModelType: "docker",
+		Name:      sdk.RandomString(10),
+	}
+	require.NoError(t, hatchery.Insert(ctx, db, &hatch))
+
+	reg := sdk.Region{Name: "default"}
+	require.NoError(t, region.Insert(ctx, db, &reg))
+
+	rbacYaml := `name: perm-default
+hatcheries:
+- role: %s
+  region: default
+  hatchery: %s
+`
+	rbacYaml = fmt.Sprintf(rbacYaml, sdk.HatcheryRoleSpawn, hatch.Name)
+	var r sdk.RBAC
+	require.NoError(t, yaml.Unmarshal([]byte(rbacYaml), &r))
+	r.Hatcheries[0].RegionID = reg.ID
+	r.Hatcheries[0].HatcheryID = hatch.ID
+	require.NoError(t, rbac.Insert(context.TODO(), db, &r))
+
+	consumer, err := authentication.NewConsumerHatchery(ctx, db, hatch)
+	require.NoError(t, err)
+
+	_, jwtWorker := assets.InsertWorker(t, ctx, db, consumer, hatch, "worker"+sdk.RandomString(10), jobRun)
+
+	vars := map[string]string{
+		"runJobID":   jobRun.ID,
+		"regionName": "default",
+	}
+	uri := api.Router.GetRouteV2("POST", api.postJobRunSummaryHandler, vars)
+	test.NotEmpty(t, uri)
+
+	// Invalid annotation level
+	req := assets.NewJWTAuthentifiedRequest(t, jwtWorker, "POST", uri, sdk.V2WorkflowRunJobSummaryRequest{
+		StepName:    "step-0",
+		Annotations: sdk.V2WorkflowRunJobAnnotations{{File: "main.go", Level: "fatal", Message: "boom"}},
+	})
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 400, w.Code)
+
+	req = assets.NewJWTAuthentifiedRequest(t, jwtWorker, "POST", uri, sdk.V2WorkflowRunJobSummaryRequest{
+		StepName:    "step-0",
+		Summary:     "## Coverage\n\n87%",
+		Annotations: sdk.V2WorkflowRunJobAnnotations{{File: "./main.go", Line: 12, Level: sdk.V2WorkflowRunJobAnnotationLevelWarning, Message: "unused variable"}},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	uriGet := api.Router.GetRouteV2("GET", api.getWorkflowRunSummariesHandler, map[string]string{
+		"projectKey":    proj.Key,
+		"workflowRunID": wr.ID,
+	})
+	test.NotEmpty(t, uriGet)
+	reqGet := assets.NewAuthentifiedRequest(t, admin, pwd, "GET", uriGet, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, reqGet)
+	require.Equal(t, 200, w.Code)
+
+	var summaries []sdk.V2WorkflowRunJobSummary
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summaries))
+	require.Len(t, summaries, 1)
+	require.Equal(t, "job1", summaries[0].JobID)
+	require.Equal(t, "step-0", summaries[0].StepName)
+	require.Equal(t, "## Coverage\n\n87%", summaries[0].Summary)
+	require.Len(t, summaries[0].Annotations, 1)
+	require.Equal(t, "main.go", summaries[0].Annotations[0].File)
+
+	// Nothing for another attempt
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, assets.NewAuthentifiedRequest(t, admin, pwd, "GET", uriGet+"?attempt=2", nil))
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summaries))
+	require.Len(t, summaries, 0)
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	workflowTestFlakyDefaultDays    = 14
+	workflowTestFlakyMaxDays        = 90
+	workflowTestHistoryDefaultLimit = 50
+	workflowTestHistoryMaxLimit     = 500
+)
+
+// saveRunResultTestCases stores the test case outcomes of a completed junit run result.
+func (api *API) saveRunResultTestCases(ctx context.Context, run sdk.V2WorkflowRun, runJob sdk.V2WorkflowRunJob, runResult sdk.V2WorkflowRunResult) error {
+	d, err := runResult.GetDetail()
+	if err != nil {
+		return err
+	}
+	detail, ok := d.(*sdk.V2WorkflowRunResultTestDetail)
+	if !ok {
+		return sdk.NewErrorFrom(sdk.ErrInvalidData, "unexpected detail type %T for run result %s", d, runResult.ID)
+	}
+	cases := sdk.NewV2WorkflowTestCases(run, runJob, detail.TestsSuites)
+	if len(cases) == 0 {
+		return nil
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := workflow_v2.InsertTestCases(ctx, tx, cases); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+func (api *API) getWorkflowTestsFlakyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			vcsName, repoName, workflowName, err := sdk.ParseV2WorkflowFullName(QueryString(req, "workflow"))
+			if err != nil {
+				return err
+			}
+			days := service.FormInt(req, "days")
+			if days <= 0 {
+				days = workflowTestFlakyDefaultDays
+			}
+			if days > workflowTestFlakyMaxDays {
+				days = workflowTestFlakyMaxDays
+			}
+			all := service.FormBool(req, "all")
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			cases, err := workflow_v2.LoadFailingTestCases(ctx, api.mustDB(), proj.Key, vcsName, repoName, workflowName, QueryString(req, "ref"), time.Now().AddDate(0, 0, -days))
+			if err != nil {
+				return err
+			}
+			quarantines, err := workflow_v2.LoadTestQuarantines(ctx, api.mustDB(), proj.Key, vcsName, repoName, workflowName)
+			if err != nil {
+				return err
+			}
+
+			histories := sdk.ComputeV2WorkflowTestHistories(cases, quarantines)
+			res := make([]sdk.V2WorkflowTestHistory, 0, len(histories))
+			for _, h := range histories {
+				if !all && !h.Flaky {
+					continue
+				}
+				h.History = nil
+				res = append(res, h)
+			}
+			return service.WriteJSON(w, res, http.StatusOK)
+		}
+}
+
+func (api *API) getWorkflowTestHistoryHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			vcsName, repoName, workflowName, err := sdk.ParseV2WorkflowFullName(QueryString(req, "workflow"))
+			if err != nil {
+				return err
+			}
+			name := QueryString(req, "name")
+			if name == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing test name")
+			}
+			limit := service.FormInt(req, "limit")
+			if limit <= 0 {
+				limit = workflowTestHistoryDefaultLimit
+			}
+			if limit > workflowTestHistoryMaxLimit {
+				limit = workflowTestHistoryMaxLimit
+			}
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			cases, err := workflow_v2.LoadTestCaseHistory(ctx, api.mustDB(), proj.Key, vcsName, repoName, workflowName, QueryString(req, "suite"), name, QueryString(req, "ref"), limit)
+			if err != nil {
+				return err
+			}
+			if len(cases) == 0 {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "no execution found for test %s", name)
+			}
+			quarantines, err := workflow_v2.LoadTestQuarantines(ctx, api.mustDB(), proj.Key, vcsName, repoName, workflowName)
+			if err != nil {
+				return err
+			}
+
+			return service.WriteJSON(w, sdk.ComputeV2WorkflowTestHistories(cases, quarantines)[0], http.StatusOK)
+		}
+}
+
+func (api *API) getWorkflowTestQuarantinesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			var vcsName, repoName, workflowName string
+			if wf := QueryString(req, "workflow"); wf != "" {
+				var err error
+				vcsName, repoName, workflowName, err = sdk.ParseV2WorkflowFullName(wf)
+				if err != nil {
+					return err
+				}
+			}
+
+			quarantines, err := workflow_v2.LoadTestQuarantines(ctx, api.mustDB(), pKey, vcsName, repoName, workflowName)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, quarantines, http.StatusOK)
+		}
+}
+
+func (api *API) postWorkflowTestQuarantineHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var quarantine sdk.V2WorkflowTestQuarantine
+			if err := service.UnmarshalBody(req, &quarantine); err != nil {
+				return err
+			}
+			if err := quarantine.Check(); err != nil {
+				return err
+			}
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+			quarantine.ProjectKey = proj.Key
+			quarantine.Author = u.GetUsername()
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := workflow_v2.InsertTestQuarantine(ctx, tx, &quarantine); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			return service.WriteJSON(w, quarantine, http.StatusOK)
+		}
+}
+
+func (api *API) deleteWorkflowTestQuarantineHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			quarantine, err := workflow_v2.LoadTestQuarantineByID(ctx, api.mustDB(), pKey, vars["quarantineID"])
+			if err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := workflow_v2.DeleteTestQuarantine(tx, *quarantine); err != nil {
+				return err
+			}
+			return sdk.WithStack(tx.Commit())
+		}
+}
+
+// getJobRunTestQuarantinesHandler returns the quarantined tests of the job's workflow, used by the junit action
+// to make their failures non-blocking.
+func (api *API) getJobRunTestQuarantinesHandler() ([]service.RbacChecker, service.Handler) {
+	return []service.RbacChecker{api.jobRunUpdate, api.isWorker},
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), vars["runJobID"])
+			if err != nil {
+				return err
+			}
+
+			service.TrackActionMetadataFromFields(w, runJob)
+
+			run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
+			if err != nil {
+				return err
+			}
+
+			quarantines, err := workflow_v2.LoadTestQuarantines(ctx, api.mustDB(), run.ProjectKey, run.VCSServer, run.Repository, run.WorkflowName)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, quarantines, http.StatusOK)
+		}
+}
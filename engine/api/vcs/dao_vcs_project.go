@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
@@ -108,14 +108,14 @@ func LoadAllVCSByProject(ctx context.Context, db gorp.SqlExecutor, projectKey st
 }
 
 func LoadVCSByProject(ctx context.Context, db gorp.SqlExecutor, projectKey string, vcsName string, opts ...gorpmapping.GetOptionFunc) (*sdk.VCSProject, error) {
-	ctx, next := telemetry.Span(ctx, "vcs.LoadVCSByProject", trace.StringAttribute(telemetry.TagProjectKey, projectKey), trace.StringAttribute(telemetry.TagVCSServer, vcsName))
+	ctx, next := telemetry.Span(ctx, "vcs.LoadVCSByProject", attribute.String(telemetry.TagProjectKey, projectKey), attribute.String(telemetry.TagVCSServer, vcsName))
 	defer next()
 	query := gorpmapping.NewQuery(`SELECT vcs_project.* FROM vcs_project JOIN project ON project.id = vcs_project.project_id WHERE project.projectkey = $1 AND vcs_project.name = $2`).Args(projectKey, vcsName)
 	return getVCSProject(ctx, db, query, opts...)
 }
 
 func LoadVCSByIDAndProjectKey(ctx context.Context, db gorp.SqlExecutor, projectKey string, vcsID string, opts ...gorpmapping.GetOptionFunc) (*sdk.VCSProject, error) {
-	ctx, next := telemetry.Span(ctx, "vcs.LoadVCSByIDAndProjectKey", trace.StringAttribute(telemetry.TagProjectKey, projectKey), trace.StringAttribute(telemetry.TagVCSServerID, vcsID))
+	ctx, next := telemetry.Span(ctx, "vcs.LoadVCSByIDAndProjectKey", attribute.String(telemetry.TagProjectKey, projectKey), attribute.String(telemetry.TagVCSServerID, vcsID))
 	defer next()
 	query := gorpmapping.NewQuery(`SELECT vcs_project.* FROM vcs_project JOIN project ON project.id = vcs_project.project_id WHERE project.projectkey = $1 AND vcs_project.id = $2`).Args(projectKey, vcsID)
 	return getVCSProject(ctx, db, query, opts...)
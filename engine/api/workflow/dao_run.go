@@ -13,7 +13,6 @@ import (
 	"github.com/go-gorp/gorp"
 	"github.com/lib/pq"
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
@@ -964,7 +963,7 @@ func CountNotPendingWorkflowRunsByWorkflowID(db gorp.SqlExecutor, workflowID int
 	return n, sdk.WithStack(err)
 }
 
-func CountWorkflowRunsMarkToDelete(ctx context.Context, db gorp.SqlExecutor, workflowRunsMarkToDelete *stats.Int64Measure) int64 {
+func CountWorkflowRunsMarkToDelete(ctx context.Context, db gorp.SqlExecutor, workflowRunsMarkToDelete *telemetry.Int64Measure) int64 {
 	n, err := db.SelectInt("select count(1) from workflow_run where to_delete = true")
 	if err != nil {
 		log.Error(ctx, "countWorkflowRunsMarkToDelete> %v", err)
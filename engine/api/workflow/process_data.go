@@ -41,7 +41,7 @@ func processWorkflowDataRun(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 	// Push data in header to allow tracing
 	if telemetry.Current(ctx).SpanContext().IsSampled() {
 		wr.Header.Set(telemetry.SampledHeader, "1")
-		wr.Header.Set(telemetry.TraceIDHeader, fmt.Sprintf("%v", telemetry.Current(ctx).SpanContext().TraceID))
+		wr.Header.Set(telemetry.TraceIDHeader, fmt.Sprintf("%v", telemetry.Current(ctx).SpanContext().TraceID()))
 	}
 	//////
 
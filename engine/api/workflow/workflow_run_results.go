@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/go-gorp/gorp"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/lib/pq"
 	"github.com/rockbears/log"
 
@@ -799,7 +798,7 @@ func SyncRunResultArtifactManagerByRunID(ctx context.Context, db gorpmapper.SqlE
 		}
 
 		// Push git properties as artifact properties
-		props := utils.NewProperties()
+		props := artifact_manager.NewProperties()
 		signedProps := make(ArtifactSignature)
 
 		props.AddProperty("cds.project", wr.Workflow.ProjectKey)
@@ -7,7 +7,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/authentication/builtin"
 	"github.com/ovh/cds/engine/api/event"
@@ -248,7 +247,7 @@ func Test_Purge_DeleteArtifactsFromRepositoryManager(t *testing.T) {
 
 	mockArtifactory := mock_artifact_manager.NewMockArtifactManager(ctrl)
 
-	mockArtifactory.EXPECT().GetRepository("repository").Return(&services.RepositoryDetails{
+	mockArtifactory.EXPECT().GetRepository("repository").Return(&artifact_manager.RepositoryDetails{
 		PackageType: "docker",
 	}, nil)
 
@@ -7,7 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/event"
@@ -41,7 +41,7 @@ func (api *API) WorkflowRunCraft(ctx context.Context, tick time.Duration) {
 					ctx,
 					"workflowRunCraft-"+strconv.FormatInt(id, 10),
 					func(ctx context.Context) {
-						ctx = telemetry.New(ctx, api, "api.workflowRunCraft", nil, trace.SpanKindUnspecified)
+						ctx = telemetry.New(ctx, api, "api.workflowRunCraft", trace.SpanKindUnspecified)
 						if err := api.workflowRunCraft(ctx, id); err != nil {
 							log.Error(ctx, "WorkflowRunCraft> error on workflow run %d: %v", id, err)
 						}
@@ -17,7 +17,7 @@ import (
 	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/telemetry"
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (api *API) WorkflowTemplateBulk(ctx context.Context, tick time.Duration, chanOperation chan WorkflowTemplateBulkOperation) {
@@ -39,7 +39,7 @@ func (api *API) WorkflowTemplateBulk(ctx context.Context, tick time.Duration, ch
 			for _, b := range bs {
 				api.GoRoutines.Exec(api.Router.Background, "workflowTemplateBulk-"+strconv.FormatInt(b.ID, 10),
 					func(ctx context.Context) {
-						ctx = telemetry.New(ctx, api, "api.workflowTemplateBulk", nil, trace.SpanKindUnspecified)
+						ctx = telemetry.New(ctx, api, "api.workflowTemplateBulk", trace.SpanKindUnspecified)
 						if err := api.workflowTemplateBulk(ctx, b.ID, chanOperation); err != nil {
 							log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "error on workflow template bulk %d", b.ID))
 						}
@@ -0,0 +1,66 @@
+package workflow_v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+// upsertEnvironmentDeployment keeps the deployment of a run job on its environment in sync with the run job status.
+// Version and git information are taken from the run contexts when the deployment is created.
+func upsertEnvironmentDeployment(ctx context.Context, db gorpmapper.SqlExecutorWithTx, wrj sdk.V2WorkflowRunJob) error {
+	if wrj.Job.Environment == "" {
+		return nil
+	}
+	// Skipped jobs did not deploy anything, unless they were already recorded
+	if wrj.Status == sdk.V2WorkflowRunJobStatusSkipped {
+		_, err := db.Exec("UPDATE v2_environment_deployment SET status = $2, last_modified = $3 WHERE workflow_run_job_id = $1", wrj.ID, wrj.Status, time.Now())
+		return sdk.WrapError(err, "unable to update deployment of run job %s", wrj.ID)
+	}
+	_, err := db.Exec(`
+		INSERT INTO v2_environment_deployment (id, project_key, environment, workflow_run_id, workflow_run_job_id, vcs_server, repository, workflow_name,
+			run_number, run_attempt, job_id, status, username, version, git_ref, git_sha, created, last_modified)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+			COALESCE(contexts->'cds'->>'version', ''), COALESCE(contexts->'git'->>'ref', ''), COALESCE(contexts->'git'->>'sha', ''), $14, $14
+		FROM v2_workflow_run WHERE id = $4
+		ON CONFLICT (workflow_run_job_id) DO UPDATE SET status = $12, last_modified = $14`,
+		sdk.UUID(), wrj.ProjectKey, wrj.Job.Environment, wrj.WorkflowRunID, wrj.ID, wrj.VCSServer, wrj.Repository, wrj.WorkflowName,
+		wrj.RunNumber, wrj.RunAttempt, wrj.JobID, wrj.Status, wrj.Initiator.Username(), time.Now())
+	return sdk.WrapError(err, "unable to save deployment of run job %s", wrj.ID)
+}
+
+func getEnvironmentDeployments(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.ProjectEnvironmentDeployment, error) {
+	var res []dbEnvironmentDeployment
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	deployments := make([]sdk.ProjectEnvironmentDeployment, 0, len(res))
+	for _, r := range res {
+		deployments = append(deployments, r.ProjectEnvironmentDeployment)
+	}
+	return deployments, nil
+}
+
+// LoadEnvironmentDeployments returns the deployment history of an environment, newest first.
+func LoadEnvironmentDeployments(ctx context.Context, db gorp.SqlExecutor, projKey, environment string, offset, limit int64) ([]sdk.ProjectEnvironmentDeployment, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_environment_deployment
+		WHERE project_key = $1 AND environment = $2
+		ORDER BY created DESC
+		OFFSET $3 LIMIT $4`).Args(projKey, environment, offset, limit)
+	return getEnvironmentDeployments(ctx, db, query)
+}
+
+// LoadCurrentEnvironmentDeployments returns the last successful deployment of each environment of the project.
+func LoadCurrentEnvironmentDeployments(ctx context.Context, db gorp.SqlExecutor, projKey string) ([]sdk.ProjectEnvironmentDeployment, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT DISTINCT ON (environment) * FROM v2_environment_deployment
+		WHERE project_key = $1 AND status = $2
+		ORDER BY environment, last_modified DESC`).Args(projKey, sdk.V2WorkflowRunJobStatusSuccess)
+	return getEnvironmentDeployments(ctx, db, query)
+}
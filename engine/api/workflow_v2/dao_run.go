@@ -733,3 +733,145 @@ func DeleteRunByID(db gorp.SqlExecutor, id string) error {
 	_, err := db.Exec("DELETE FROM v2_workflow_run WHERE id = $1", id)
 	return sdk.WrapError(err, "unable to delete workflow run v2 with id %s", id)
 }
+
+// LoadRunsStats aggregates the terminated runs of a project started in the given window. It returns a row
+// for all the runs, a row per trend bucket (day or week) and a row per workflow.
+// Lead time is measured from the commit date of the git context to the end of the first successful run
+// of the commit, time to restore from the end of the first failed run of a ref to the end of its next successful run.
+func LoadRunsStats(ctx context.Context, db gorp.SqlExecutor, projKey string, filters SearchRunsFilters, from, to time.Time, interval string) ([]sdk.V2WorkflowRunStatsRow, error) {
+	_, next := telemetry.Span(ctx, "LoadRunsStats")
+	defer next()
+
+	query := `
+	WITH runs AS (
+		SELECT
+			v2_workflow_run.id,
+			vcs_server || '/' || repository || '/' || workflow_name AS "workflow",
+			COALESCE(contexts -> 'git' ->> 'ref', '') AS "ref",
+			COALESCE(contexts -> 'git' ->> 'sha', '') AS "sha",
+			NULLIF(contexts -> 'git' ->> 'commit_date', '')::timestamptz AS "commit_date",
+			status,
+			started,
+			last_modified,
+			date_trunc(:interval, started AT TIME ZONE 'UTC') AS "bucket"
+		FROM v2_workflow_run` + runAnnotationsJoinByProject + `
+		WHERE
+			project_key = :projKey
+			AND status = ANY(:terminatedStatus)
+			AND started >= :from AND started < :to
+			AND ` + runQueryFilters + `
+	),
+	ranked_runs AS (
+		SELECT
+			runs.*,
+			ROW_NUMBER() OVER (PARTITION BY workflow, sha, status ORDER BY started) AS "status_rank",
+			-- Runs of a ref between two successful runs share the same streak
+			COALESCE(SUM(CASE WHEN status = :success THEN 1 ELSE 0 END) OVER (
+				PARTITION BY workflow, ref ORDER BY started ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+			), 0) AS "streak"
+		FROM runs
+	),
+	measured_runs AS (
+		SELECT
+			bucket,
+			workflow,
+			status,
+			EXTRACT(EPOCH FROM last_modified - started)::float8 AS "duration",
+			CASE WHEN status = :success AND status_rank = 1
+				THEN EXTRACT(EPOCH FROM last_modified - commit_date)::float8
+			END AS "lead_time",
+			CASE WHEN status = :success
+				THEN EXTRACT(EPOCH FROM last_modified - MIN(CASE WHEN status = :fail THEN last_modified END) OVER (PARTITION BY workflow, ref, streak))::float8
+			END AS "time_to_restore"
+		FROM ranked_runs
+	),
+	run_stats AS (
+		SELECT
+			GROUPING(bucket, workflow) AS "grouping",
+			bucket,
+			workflow,
+			COUNT(*) AS "runs",
+			COUNT(*) FILTER (WHERE status = :success) AS "deployments",
+			COUNT(*) FILTER (WHERE status = :fail) AS "failures",
+			COUNT(lead_time) AS "lead_time_count",
+			COALESCE(percentile_disc(0.5) WITHIN GROUP (ORDER BY lead_time), 0) AS "lead_time_p50",
+			COALESCE(percentile_disc(0.95) WITHIN GROUP (ORDER BY lead_time), 0) AS "lead_time_p95",
+			COUNT(time_to_restore) AS "time_to_restore_count",
+			COALESCE(percentile_disc(0.5) WITHIN GROUP (ORDER BY time_to_restore), 0) AS "time_to_restore_p50",
+			COALESCE(percentile_disc(0.95) WITHIN GROUP (ORDER BY time_to_restore), 0) AS "time_to_restore_p95",
+			COUNT(duration) AS "duration_count",
+			COALESCE(percentile_disc(0.5) WITHIN GROUP (ORDER BY duration), 0) AS "duration_p50",
+			COALESCE(percentile_disc(0.95) WITHIN GROUP (ORDER BY duration), 0) AS "duration_p95"
+		FROM measured_runs
+		GROUP BY GROUPING SETS ((), (bucket), (workflow))
+	),
+	queue_stats AS (
+		SELECT
+			GROUPING(runs.bucket, runs.workflow) AS "grouping",
+			runs.bucket,
+			runs.workflow,
+			COUNT(*) AS "queue_time_count",
+			percentile_disc(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM job.started - job.queued)::float8) AS "queue_time_p50",
+			percentile_disc(0.95) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM job.started - job.queued)::float8) AS "queue_time_p95"
+		FROM runs
+		JOIN v2_workflow_run_job job ON job.workflow_run_id = runs.id AND job.started IS NOT NULL
+		GROUP BY GROUPING SETS ((), (runs.bucket), (runs.workflow))
+	)
+	SELECT
+		run_stats.bucket,
+		COALESCE(run_stats.workflow, '') AS "workflow",
+		run_stats.runs,
+		run_stats.deployments,
+		run_stats.failures,
+		run_stats.lead_time_count,
+		run_stats.lead_time_p50,
+		run_stats.lead_time_p95,
+		run_stats.time_to_restore_count,
+		run_stats.time_to_restore_p50,
+		run_stats.time_to_restore_p95,
+		run_stats.duration_count,
+		run_stats.duration_p50,
+		run_stats.duration_p95,
+		COALESCE(queue_stats.queue_time_count, 0) AS "queue_time_count",
+		COALESCE(queue_stats.queue_time_p50, 0) AS "queue_time_p50",
+		COALESCE(queue_stats.queue_time_p95, 0) AS "queue_time_p95"
+	FROM run_stats
+	LEFT JOIN queue_stats ON
+		queue_stats.grouping = run_stats.grouping
+		AND queue_stats.bucket IS NOT DISTINCT FROM run_stats.bucket
+		AND queue_stats.workflow IS NOT DISTINCT FROM run_stats.workflow
+	WHERE run_stats.runs > 0`
+
+	params := map[string]interface{}{
+		"projKey":  projKey,
+		"interval": interval,
+		"success":  string(sdk.V2WorkflowRunStatusSuccess),
+		"fail":     string(sdk.V2WorkflowRunStatusFail),
+		"terminatedStatus": pq.StringArray{
+			string(sdk.V2WorkflowRunStatusSuccess),
+			string(sdk.V2WorkflowRunStatusFail),
+			string(sdk.V2WorkflowRunStatusStopped),
+			string(sdk.V2WorkflowRunStatusCancelled),
+		},
+		"from":                  from,
+		"to":                    to,
+		"workflows":             pq.StringArray(filters.Workflows),
+		"actors":                pq.StringArray(filters.Actors),
+		"status":                pq.StringArray(filters.Status),
+		"refs":                  pq.StringArray(filters.Refs),
+		"workflow_refs":         pq.StringArray(filters.WorkflowRefs),
+		"repositories":          pq.StringArray(filters.Repositories),
+		"workflow_repositories": pq.StringArray(filters.WorkflowRepositories),
+		"authors":               pq.StringArray(filters.Authors),
+		"commits":               pq.StringArray(filters.Commits),
+		"templates":             pq.StringArray(filters.Templates),
+		"annotations":           pq.StringArray(filters.Annotations),
+		"query":                 filters.queryPatterns(),
+	}
+
+	var rows []sdk.V2WorkflowRunStatsRow
+	if _, err := db.Select(&rows, query, params); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return rows, nil
+}
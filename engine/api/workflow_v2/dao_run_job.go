@@ -7,7 +7,7 @@ import (
 	"github.com/go-gorp/gorp"
 	"github.com/lib/pq"
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/user"
@@ -77,7 +77,7 @@ func getRunJob(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query
 }
 
 func InsertRunJob(ctx context.Context, db gorpmapper.SqlExecutorWithTx, wrj *sdk.V2WorkflowRunJob) error {
-	ctx, next := telemetry.Span(ctx, "workflow_v2.InsertRunJob", trace.StringAttribute(telemetry.TagJob, wrj.JobID))
+	ctx, next := telemetry.Span(ctx, "workflow_v2.InsertRunJob", attribute.String(telemetry.TagJob, wrj.JobID))
 	defer next()
 	if wrj.ID == "" {
 		wrj.ID = sdk.UUID()
@@ -100,7 +100,7 @@ func InsertRunJob(ctx context.Context, db gorpmapper.SqlExecutorWithTx, wrj *sdk
 		return err
 	}
 	*wrj = dbWkfRunJob.V2WorkflowRunJob
-	return nil
+	return upsertEnvironmentDeployment(ctx, db, *wrj)
 }
 
 func UpdateJobRun(ctx context.Context, db gorpmapper.SqlExecutorWithTx, wrj *sdk.V2WorkflowRunJob) error {
@@ -114,7 +114,12 @@ func UpdateJobRun(ctx context.Context, db gorpmapper.SqlExecutorWithTx, wrj *sdk
 		return err
 	}
 	*wrj = dbWkfRunJob.V2WorkflowRunJob
-	return nil
+	if wrj.Status.IsTerminated() && wrj.Ended != nil {
+		if err := EndRunJobUsage(ctx, db, wrj.ID, *wrj.Ended); err != nil {
+			return err
+		}
+	}
+	return upsertEnvironmentDeployment(ctx, db, *wrj)
 }
 
 func LoadRunJobsByRunID(ctx context.Context, db gorp.SqlExecutor, runID string, runAttempt int64) ([]sdk.V2WorkflowRunJob, error) {
@@ -200,6 +205,29 @@ func LoadQueuedRunJobByModelTypeAndRegionAndModelOSArch(ctx context.Context, db
 	return getAllRunJobs(ctx, db, query)
 }
 
+func LoadQueuedRunJobByModelTypeAndRegion(ctx context.Context, db gorp.SqlExecutor, regionName string, modelType string) ([]sdk.V2WorkflowRunJob, error) {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.LoadQueuedRunJobByModelTypeAndRegion")
+	defer next()
+	query := gorpmapping.NewQuery("SELECT * from v2_workflow_run_job WHERE status = $1 AND model_type = $2 and region = $3 ORDER BY queued").
+		Args(sdk.StatusWaiting, modelType, regionName)
+	return getAllRunJobs(ctx, db, query)
+}
+
+// LoadRunJobsHeldByFreeze returns the blocked run jobs that do not wait for a concurrency, they are held by a project freeze.
+func LoadRunJobsHeldByFreeze(ctx context.Context, db gorp.SqlExecutor) ([]sdk.V2WorkflowRunJob, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_job
+		WHERE status = $1 AND (concurrency IS NULL OR concurrency = 'null'::jsonb)
+		ORDER BY queued`).Args(sdk.V2WorkflowRunJobStatusBlocked)
+	return getAllRunJobs(ctx, db, query)
+}
+
+// DeleteRunJob removes a run job that has never been queued.
+func DeleteRunJob(db gorp.SqlExecutor, id string) error {
+	_, err := db.Exec("DELETE FROM v2_workflow_run_job WHERE id = $1", id)
+	return sdk.WrapError(err, "unable to delete run job %s", id)
+}
+
 func LoadRunJobsByRunIDAndStatus(ctx context.Context, db gorp.SqlExecutor, runID string, status []string, runAttempt int64) ([]sdk.V2WorkflowRunJob, error) {
 	ctx, next := telemetry.Span(ctx, "workflow_v2.LoadRunJobsByRunIDAndStatus")
 	defer next()
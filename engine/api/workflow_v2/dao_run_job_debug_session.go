@@ -0,0 +1,52 @@
+package workflow_v2
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func InsertRunJobDebugSession(ctx context.Context, db gorpmapper.SqlExecutorWithTx, s *sdk.V2WorkflowRunJobDebugSession) error {
+	s.ID = sdk.UUID()
+	dbSession := &dbWorkflowRunJobDebugSession{V2WorkflowRunJobDebugSession: *s}
+	if err := gorpmapping.InsertAndSign(ctx, db, dbSession); err != nil {
+		return err
+	}
+	*s = dbSession.V2WorkflowRunJobDebugSession
+	return nil
+}
+
+func UpdateRunJobDebugSession(ctx context.Context, db gorpmapper.SqlExecutorWithTx, s *sdk.V2WorkflowRunJobDebugSession) error {
+	dbSession := &dbWorkflowRunJobDebugSession{V2WorkflowRunJobDebugSession: *s}
+	if err := gorpmapping.UpdateAndSign(ctx, db, dbSession); err != nil {
+		return err
+	}
+	*s = dbSession.V2WorkflowRunJobDebugSession
+	return nil
+}
+
+func LoadRunJobDebugSessionsByRunJobID(ctx context.Context, db gorp.SqlExecutor, runJobID string) ([]sdk.V2WorkflowRunJobDebugSession, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM v2_workflow_run_job_debug_session WHERE workflow_run_job_id = $1 ORDER BY started").Args(runJobID)
+	var dbSessions []dbWorkflowRunJobDebugSession
+	if err := gorpmapping.GetAll(ctx, db, query, &dbSessions); err != nil {
+		return nil, err
+	}
+	sessions := make([]sdk.V2WorkflowRunJobDebugSession, 0, len(dbSessions))
+	for _, s := range dbSessions {
+		isValid, err := gorpmapping.CheckSignature(s, s.Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isValid {
+			log.Error(ctx, "debug session %s on run job %s: data corrupted", s.ID, s.WorkflowRunJobID)
+			continue
+		}
+		sessions = append(sessions, s.V2WorkflowRunJobDebugSession)
+	}
+	return sessions, nil
+}
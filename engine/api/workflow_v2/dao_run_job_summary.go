@@ -0,0 +1,52 @@
+package workflow_v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func getAllRunJobSummaries(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.V2WorkflowRunJobSummary, error) {
+	var dbSummaries []dbWorkflowRunJobSummary
+	if err := gorpmapping.GetAll(ctx, db, query, &dbSummaries); err != nil {
+		return nil, err
+	}
+	summaries := make([]sdk.V2WorkflowRunJobSummary, 0, len(dbSummaries))
+	for _, s := range dbSummaries {
+		summaries = append(summaries, s.V2WorkflowRunJobSummary)
+	}
+	return summaries, nil
+}
+
+func InsertRunJobSummary(ctx context.Context, db gorpmapper.SqlExecutorWithTx, summary *sdk.V2WorkflowRunJobSummary) error {
+	summary.ID = sdk.UUID()
+	summary.Created = time.Now()
+	dbSummary := &dbWorkflowRunJobSummary{V2WorkflowRunJobSummary: *summary}
+	if err := gorpmapping.Insert(db, dbSummary); err != nil {
+		return err
+	}
+	*summary = dbSummary.V2WorkflowRunJobSummary
+	return nil
+}
+
+// LoadRunJobSummariesByRunID returns the summaries of all the jobs of a run attempt, in the order steps sent them.
+func LoadRunJobSummariesByRunID(ctx context.Context, db gorp.SqlExecutor, runID string, runAttempt int64) ([]sdk.V2WorkflowRunJobSummary, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_job_summary
+		WHERE workflow_run_id = $1 AND run_attempt = $2
+		ORDER BY created`).Args(runID, runAttempt)
+	return getAllRunJobSummaries(ctx, db, query)
+}
+
+func LoadRunJobSummariesByRunJobID(ctx context.Context, db gorp.SqlExecutor, runJobID string) ([]sdk.V2WorkflowRunJobSummary, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_job_summary
+		WHERE workflow_run_job_id = $1
+		ORDER BY created`).Args(runJobID)
+	return getAllRunJobSummaries(ctx, db, query)
+}
@@ -0,0 +1,115 @@
+package workflow_v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+// InsertRunJobUsage records the start of a run job
+func InsertRunJobUsage(ctx context.Context, db gorpmapper.SqlExecutorWithTx, usage *sdk.V2WorkflowRunJobUsage) error {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.InsertRunJobUsage")
+	defer next()
+	if usage.Started.IsZero() {
+		usage.Started = time.Now()
+	}
+	dbUsage := &dbWorkflowRunJobUsage{V2WorkflowRunJobUsage: *usage}
+	if err := gorpmapping.Insert(db, dbUsage); err != nil {
+		return err
+	}
+	*usage = dbUsage.V2WorkflowRunJobUsage
+	return nil
+}
+
+// EndRunJobUsage records the end of a run job. Nothing is done if the job has never been started
+func EndRunJobUsage(ctx context.Context, db gorpmapper.SqlExecutorWithTx, runJobID string, ended time.Time) error {
+	_, err := db.Exec("UPDATE v2_workflow_run_job_usage SET ended = $2 WHERE run_job_id = $1 AND ended IS NULL", runJobID, ended)
+	return sdk.WrapError(err, "unable to end usage of run job %s", runJobID)
+}
+
+func LoadRunJobUsageByRunJobID(ctx context.Context, db gorp.SqlExecutor, runJobID string) (*sdk.V2WorkflowRunJobUsage, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM v2_workflow_run_job_usage WHERE run_job_id = $1").Args(runJobID)
+	var dbUsage dbWorkflowRunJobUsage
+	found, err := gorpmapping.Get(ctx, db, query, &dbUsage)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &dbUsage.V2WorkflowRunJobUsage, nil
+}
+
+// LoadProjectUsages returns the usage of a project aggregated by month, optionally filtered on a workflow
+func LoadProjectUsages(ctx context.Context, db gorp.SqlExecutor, projKey string, from, to time.Time, vcsName, repoName, workflowName string) ([]sdk.V2ProjectUsage, error) {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.LoadProjectUsages")
+	defer next()
+	query := `
+		SELECT to_char(started, 'YYYY-MM') AS month,
+			count(*) AS jobs,
+			COALESCE(sum(extract(epoch FROM (COALESCE(ended, now()) - started))), 0) / 60 AS minutes
+		FROM v2_workflow_run_job_usage
+		WHERE project_key = $1
+			AND started >= $2 AND started < $3
+			AND ($4 = '' OR vcs_server = $4)
+			AND ($5 = '' OR repository = $5)
+			AND ($6 = '' OR workflow_name = $6)
+		GROUP BY month
+		ORDER BY month DESC`
+	var usages []sdk.V2ProjectUsage
+	if _, err := db.Select(&usages, query, projKey, from, to, vcsName, repoName, workflowName); err != nil {
+		return nil, sdk.WrapError(err, "unable to load usage of project %s", projKey)
+	}
+	return usages, nil
+}
+
+// LoadProjectUsageDetails returns the usage of a project for a month by workflow, worker model and hatchery
+func LoadProjectUsageDetails(ctx context.Context, db gorp.SqlExecutor, projKey string, month time.Time) ([]sdk.V2ProjectUsageDetail, error) {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.LoadProjectUsageDetails")
+	defer next()
+	query := `
+		SELECT to_char(started, 'YYYY-MM') AS month,
+			vcs_server, repository, workflow_name, worker_model, flavor, region, hatchery_name,
+			count(*) AS jobs,
+			COALESCE(sum(extract(epoch FROM (COALESCE(ended, now()) - started))), 0) / 60 AS minutes
+		FROM v2_workflow_run_job_usage
+		WHERE project_key = $1
+			AND started >= $2 AND started < $3
+		GROUP BY month, vcs_server, repository, workflow_name, worker_model, flavor, region, hatchery_name
+		ORDER BY minutes DESC`
+	var details []sdk.V2ProjectUsageDetail
+	if _, err := db.Select(&details, query, projKey, month, month.AddDate(0, 1, 0)); err != nil {
+		return nil, sdk.WrapError(err, "unable to load usage details of project %s", projKey)
+	}
+	return details, nil
+}
+
+// CountProjectMonthlyMinutes returns the number of worker minutes consumed by a project since the beginning of the month
+func CountProjectMonthlyMinutes(ctx context.Context, db gorp.SqlExecutor, projKey string, now time.Time) (float64, error) {
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	minutes, err := db.SelectFloat(`
+		SELECT COALESCE(sum(extract(epoch FROM (COALESCE(ended, now()) - started))), 0) / 60
+		FROM v2_workflow_run_job_usage
+		WHERE project_key = $1 AND started >= $2`, projKey, monthStart)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to count monthly minutes of project %s", projKey)
+	}
+	return minutes, nil
+}
+
+// DeleteRunJobUsagesBefore deletes the usage of the jobs started before the given date
+func DeleteRunJobUsagesBefore(ctx context.Context, db gorp.SqlExecutor, before time.Time) (int64, error) {
+	res, err := db.Exec("DELETE FROM v2_workflow_run_job_usage WHERE started < $1", before)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to delete run job usages")
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -2,6 +2,7 @@ package workflow_v2
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/go-gorp/gorp"
 	"github.com/lib/pq"
@@ -119,3 +120,23 @@ func LoadRunResultsByRunJobID(ctx context.Context, db gorp.SqlExecutor, runJobID
 	`).Args(runJobID)
 	return getAllRunResults(ctx, db, query)
 }
+
+// LoadRunResultAttestationsByDigest returns the attestations of a project for the given subject digest.
+func LoadRunResultAttestationsByDigest(ctx context.Context, db gorp.SqlExecutor, projectKey string, algorithm, digest string) ([]sdk.V2WorkflowRunResult, error) {
+	ctx, next := telemetry.Span(ctx, "LoadRunResultAttestationsByDigest")
+	defer next()
+	subjects, err := json.Marshal([]map[string]interface{}{{"digest": map[string]string{algorithm: digest}}})
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	query := gorpmapping.NewQuery(`
+    SELECT v2_workflow_run_result.*
+    FROM v2_workflow_run_result
+    JOIN v2_workflow_run ON v2_workflow_run.id = v2_workflow_run_result.workflow_run_id
+    WHERE v2_workflow_run.project_key = $1
+    AND v2_workflow_run_result.type = $2
+    AND (v2_workflow_run_result.artifact_manager_detail -> 'data' -> 'subjects') @> $3::jsonb
+    ORDER BY v2_workflow_run_result.issued_at DESC
+	`).Args(projectKey, sdk.V2WorkflowRunResultTypeAttestation, string(subjects))
+	return getAllRunResults(ctx, db, query)
+}
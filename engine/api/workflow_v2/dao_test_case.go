@@ -0,0 +1,143 @@
+package workflow_v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+func getTestCases(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.V2WorkflowTestCase, error) {
+	var dbCases []dbWorkflowTestCase
+	if err := gorpmapping.GetAll(ctx, db, query, &dbCases); err != nil {
+		return nil, err
+	}
+	cases := make([]sdk.V2WorkflowTestCase, 0, len(dbCases))
+	for _, c := range dbCases {
+		cases = append(cases, c.V2WorkflowTestCase)
+	}
+	return cases, nil
+}
+
+// InsertTestCases stores the test case outcomes of a run job
+func InsertTestCases(ctx context.Context, db gorpmapper.SqlExecutorWithTx, cases []sdk.V2WorkflowTestCase) error {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.InsertTestCases")
+	defer next()
+	now := time.Now()
+	for i := range cases {
+		cases[i].Created = now
+		dbCase := &dbWorkflowTestCase{V2WorkflowTestCase: cases[i]}
+		if err := gorpmapping.Insert(db, dbCase); err != nil {
+			return err
+		}
+		cases[i] = dbCase.V2WorkflowTestCase
+	}
+	return nil
+}
+
+func LoadTestCasesByRunID(ctx context.Context, db gorp.SqlExecutor, runID string) ([]sdk.V2WorkflowTestCase, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_test_case
+		WHERE workflow_run_id = $1
+		ORDER BY suite, name`).Args(runID)
+	return getTestCases(ctx, db, query)
+}
+
+// LoadTestCaseHistory returns the last executions of a test case of a workflow, optionally filtered on a git ref
+func LoadTestCaseHistory(ctx context.Context, db gorp.SqlExecutor, projKey, vcsServer, repository, workflowName, suite, name, ref string, limit int) ([]sdk.V2WorkflowTestCase, error) {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.LoadTestCaseHistory")
+	defer next()
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM (
+			SELECT * FROM v2_workflow_test_case
+			WHERE project_key = $1 AND vcs_server = $2 AND repository = $3 AND workflow_name = $4
+				AND suite = $5 AND name = $6
+				AND ($7 = '' OR ref = $7)
+			ORDER BY created DESC
+			LIMIT $8
+		) history
+		ORDER BY created`).Args(projKey, vcsServer, repository, workflowName, suite, name, ref, limit)
+	return getTestCases(ctx, db, query)
+}
+
+// LoadFailingTestCases returns all the executions since the given date of the test cases of a workflow
+// that failed at least once in this period.
+func LoadFailingTestCases(ctx context.Context, db gorp.SqlExecutor, projKey, vcsServer, repository, workflowName, ref string, since time.Time) ([]sdk.V2WorkflowTestCase, error) {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.LoadFailingTestCases")
+	defer next()
+	query := gorpmapping.NewQuery(`
+		WITH failing AS (
+			SELECT DISTINCT suite, name
+			FROM v2_workflow_test_case
+			WHERE project_key = $1 AND vcs_server = $2 AND repository = $3 AND workflow_name = $4
+				AND ($5 = '' OR ref = $5)
+				AND created >= $6
+				AND status = $7
+		)
+		SELECT v2_workflow_test_case.*
+		FROM v2_workflow_test_case
+		JOIN failing ON failing.suite = v2_workflow_test_case.suite AND failing.name = v2_workflow_test_case.name
+		WHERE project_key = $1 AND vcs_server = $2 AND repository = $3 AND workflow_name = $4
+			AND ($5 = '' OR ref = $5)
+			AND created >= $6
+		ORDER BY created`).Args(projKey, vcsServer, repository, workflowName, ref, since, sdk.V2WorkflowTestCaseStatusFailed)
+	return getTestCases(ctx, db, query)
+}
+
+func getTestQuarantines(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) (sdk.V2WorkflowTestQuarantines, error) {
+	var dbQuarantines []dbWorkflowTestQuarantine
+	if err := gorpmapping.GetAll(ctx, db, query, &dbQuarantines); err != nil {
+		return nil, err
+	}
+	quarantines := make(sdk.V2WorkflowTestQuarantines, 0, len(dbQuarantines))
+	for _, q := range dbQuarantines {
+		quarantines = append(quarantines, q.V2WorkflowTestQuarantine)
+	}
+	return quarantines, nil
+}
+
+func InsertTestQuarantine(ctx context.Context, db gorpmapper.SqlExecutorWithTx, q *sdk.V2WorkflowTestQuarantine) error {
+	q.ID = sdk.UUID()
+	q.Created = time.Now()
+	dbQuarantine := &dbWorkflowTestQuarantine{V2WorkflowTestQuarantine: *q}
+	if err := gorpmapping.Insert(db, dbQuarantine); err != nil {
+		return err
+	}
+	*q = dbQuarantine.V2WorkflowTestQuarantine
+	return nil
+}
+
+func DeleteTestQuarantine(db gorpmapper.SqlExecutorWithTx, q sdk.V2WorkflowTestQuarantine) error {
+	dbQuarantine := &dbWorkflowTestQuarantine{V2WorkflowTestQuarantine: q}
+	return gorpmapping.Delete(db, dbQuarantine)
+}
+
+// LoadTestQuarantines returns the quarantined tests of a project, filtered on a workflow if given
+func LoadTestQuarantines(ctx context.Context, db gorp.SqlExecutor, projKey, vcsServer, repository, workflowName string) (sdk.V2WorkflowTestQuarantines, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_test_quarantine
+		WHERE project_key = $1
+			AND ($2 = '' OR vcs_server = $2)
+			AND ($3 = '' OR repository = $3)
+			AND ($4 = '' OR workflow_name = $4)
+		ORDER BY vcs_server, repository, workflow_name, suite, name`).Args(projKey, vcsServer, repository, workflowName)
+	return getTestQuarantines(ctx, db, query)
+}
+
+func LoadTestQuarantineByID(ctx context.Context, db gorp.SqlExecutor, projKey, id string) (*sdk.V2WorkflowTestQuarantine, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM v2_workflow_test_quarantine WHERE project_key = $1 AND id = $2`).Args(projKey, id)
+	var dbQuarantine dbWorkflowTestQuarantine
+	found, err := gorpmapping.Get(ctx, db, query, &dbQuarantine)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &dbQuarantine.V2WorkflowTestQuarantine, nil
+}
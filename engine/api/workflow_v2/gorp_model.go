@@ -54,10 +54,42 @@ type dbWorkflowRunJobInfo struct {
 	sdk.V2WorkflowRunJobInfo
 }
 
+type dbWorkflowRunJobDebugSession struct {
+	sdk.V2WorkflowRunJobDebugSession
+	gorpmapper.SignedEntity
+}
+
+func (s dbWorkflowRunJobDebugSession) Canonical() gorpmapper.CanonicalForms {
+	var _ = []interface{}{s.ID, s.WorkflowRunID, s.WorkflowRunJobID, s.ProjectKey, s.UserID, s.Username, s.Started, s.Ended, s.Input, s.Output}
+	return gorpmapper.CanonicalForms{
+		"{{.ID}}{{.WorkflowRunID}}{{.WorkflowRunJobID}}{{.ProjectKey}}{{.UserID}}{{.Username}}{{printDate .Started}}{{if .Ended}}{{printDate .Ended}}{{end}}{{md5sum .Input}}{{md5sum .Output}}",
+	}
+}
+
+type dbWorkflowRunJobSummary struct {
+	sdk.V2WorkflowRunJobSummary
+}
+
 type dbV2WorkflowRunResult struct {
 	sdk.V2WorkflowRunResult
 }
 
+type dbEnvironmentDeployment struct {
+	sdk.ProjectEnvironmentDeployment
+}
+
+type dbWorkflowTestCase struct {
+	sdk.V2WorkflowTestCase
+}
+
+type dbWorkflowTestQuarantine struct {
+	sdk.V2WorkflowTestQuarantine
+}
+
+type dbWorkflowRunJobUsage struct {
+	sdk.V2WorkflowRunJobUsage
+}
+
 type dbV2WorkflowVersion struct {
 	sdk.V2WorkflowVersion
 }
@@ -67,7 +99,13 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbWorkflowRunJob{}, "v2_workflow_run_job", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbWorkflowRunInfo{}, "v2_workflow_run_info", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbWorkflowRunJobInfo{}, "v2_workflow_run_job_info", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbWorkflowRunJobSummary{}, "v2_workflow_run_job_summary", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbWorkflowRunJobDebugSession{}, "v2_workflow_run_job_debug_session", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbWorkflowHook{}, "v2_workflow_hook", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbV2WorkflowRunResult{}, "v2_workflow_run_result", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbV2WorkflowVersion{}, "v2_workflow_version", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbWorkflowRunJobUsage{}, "v2_workflow_run_job_usage", false, "run_job_id"))
+	gorpmapping.Register(gorpmapping.New(dbWorkflowTestCase{}, "v2_workflow_test_case", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbWorkflowTestQuarantine{}, "v2_workflow_test_quarantine", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbEnvironmentDeployment{}, "v2_environment_deployment", false, "id"))
 }
@@ -175,6 +175,12 @@ func (s *Service) Start(ctx context.Context) error {
 		s.workerCacheExpiredPurge(ctx)
 	})
 
+	if s.Cfg.WorkerCacheQuota.MaxSizePerProject > 0 || len(s.Cfg.WorkerCacheQuota.Projects) > 0 {
+		s.GoRoutines.Run(ctx, "service.cdn-worker-cache-quota", func(ctx context.Context) {
+			s.workerCacheQuotaEviction(ctx)
+		})
+	}
+
 	return nil
 }
 
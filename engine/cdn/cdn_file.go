@@ -227,7 +227,7 @@ func (s *Service) storeFile(ctx context.Context, sig cdn.Signature, reader io.Re
 		}
 		defer tx.Rollback() //nolint
 
-		if err := s.cleanPreviousCachedData(ctx, tx, sig, apiRef.ToFilename()); err != nil {
+		if err := s.cleanPreviousCachedData(ctx, tx, itemType, sig); err != nil {
 			return nil, err
 		}
 
@@ -239,9 +239,9 @@ func (s *Service) storeFile(ctx context.Context, sig cdn.Signature, reader io.Re
 	return it, nil
 }
 
-// Mark to delete all items for given cache tag except the most recent one.
-func (s *Service) cleanPreviousCachedData(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, sig cdn.Signature, cacheTag string) error {
-	items, err := item.LoadWorkerCacheItemsByProjectAndCacheTag(ctx, s.Mapper, tx, sig.ProjectKey, cacheTag)
+// Mark to delete all items for given cache tag and git ref except the most recent one.
+func (s *Service) cleanPreviousCachedData(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, itemType sdk.CDNItemType, sig cdn.Signature) error {
+	items, err := item.LoadWorkerCacheItemsByProjectAndCacheTag(ctx, s.Mapper, tx, itemType, sig.ProjectKey, sig.Worker.CacheRef, sig.Worker.CacheTag)
 	if err != nil {
 		return err
 	}
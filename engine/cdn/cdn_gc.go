@@ -299,3 +299,87 @@ func (s *Service) purgeExpiredWorkerCacheItems(ctx context.Context, batchSize in
 
 	return sdk.WithStack(tx.Commit())
 }
+
+// workerCacheQuotaEviction periodically evicts the least recently used v2 worker caches of the projects over their quota.
+func (s *Service) workerCacheQuotaEviction(ctx context.Context) {
+	frequency := s.Cfg.WorkerCacheQuota.FrequencySeconds
+	if frequency <= 0 {
+		frequency = 900
+	}
+
+	tick := time.NewTicker(time.Duration(frequency) * time.Second)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "cdn:worker-cache-quota: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			if err := s.evictWorkerCacheOverQuota(ctx); err != nil {
+				ctx = sdk.ContextWithStacktrace(ctx, err)
+				log.Error(ctx, "cdn:worker-cache-quota: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Service) workerCacheMaxSize(projectKey string) int64 {
+	if max, has := s.Cfg.WorkerCacheQuota.Projects[projectKey]; has {
+		return max
+	}
+	return s.Cfg.WorkerCacheQuota.MaxSizePerProject
+}
+
+func (s *Service) evictWorkerCacheOverQuota(ctx context.Context) error {
+	sizes, err := item.ComputeWorkerCacheSizeByProject(s.mustDBWithCtx(ctx), sdk.CDNTypeItemWorkerCacheV2)
+	if err != nil {
+		return err
+	}
+	for _, p := range sizes {
+		maxSize := s.workerCacheMaxSize(p.ProjectKey)
+		if maxSize <= 0 || p.Size <= maxSize {
+			continue
+		}
+		items, err := item.LoadWorkerCacheItemsSizeByProjectLRU(s.mustDBWithCtx(ctx), sdk.CDNTypeItemWorkerCacheV2, p.ProjectKey)
+		if err != nil {
+			return err
+		}
+		ids := workerCacheItemsToEvict(items, p.Size, maxSize)
+		if len(ids) == 0 {
+			continue
+		}
+
+		log.Info(ctx, "cdn:worker-cache-quota: project %s uses %d bytes over %d, evicting %d caches", p.ProjectKey, p.Size, maxSize, len(ids))
+
+		tx, err := s.mustDBWithCtx(ctx).Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		if err := item.MarkItemsAsToDelete(tx, ids); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			_ = tx.Rollback()
+			return sdk.WithStack(err)
+		}
+		telemetry.Record(ctx, s.Metrics.WorkerCacheEvicted, int64(len(ids)))
+	}
+	return nil
+}
+
+// workerCacheItemsToEvict returns the ids of the first items, least recently used first, to remove to go under the max size.
+func workerCacheItemsToEvict(items []item.WorkerCacheItemSize, size int64, maxSize int64) []string {
+	var ids []string
+	for _, it := range items {
+		if size <= maxSize {
+			break
+		}
+		ids = append(ids, it.ID)
+		size -= it.Size
+	}
+	return ids
+}
@@ -436,3 +436,15 @@ func TestPurgeItem(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(items))
 }
+
+func TestWorkerCacheItemsToEvict(t *testing.T) {
+	items := []item.WorkerCacheItemSize{
+		{ID: "oldest", Size: 10},
+		{ID: "old", Size: 20},
+		{ID: "recent", Size: 30},
+	}
+	require.Empty(t, workerCacheItemsToEvict(items, 60, 60))
+	require.Equal(t, []string{"oldest"}, workerCacheItemsToEvict(items, 60, 55))
+	require.Equal(t, []string{"oldest", "old"}, workerCacheItemsToEvict(items, 60, 30))
+	require.Equal(t, []string{"oldest", "old", "recent"}, workerCacheItemsToEvict(items, 60, 10))
+}
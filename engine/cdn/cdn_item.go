@@ -115,6 +115,13 @@ func (s *Service) downloadFile(ctx context.Context, t sdk.CDNItemType, apiRefHas
 	if err := unit.Read(*iu, rc, w); err != nil {
 		return sdk.WithStack(err)
 	}
+
+	// Keep track of cache usage for the LRU eviction
+	if t == sdk.CDNTypeItemWorkerCacheV2 {
+		if err := item.UpdateLastAccess(s.mustDBWithCtx(ctx), iu.ItemID); err != nil {
+			log.Warn(ctx, "unable to update last access of item %s: %v", iu.ItemID, err)
+		}
+	}
 	return nil
 }
 
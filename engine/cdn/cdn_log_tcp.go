@@ -187,6 +187,10 @@ func (s *Service) handleWorkerLog(ctx context.Context, unsafeSign cdn.Signature,
 			return sdk.WithStack(sdk.ErrForbidden)
 		}
 		jobID = unsafeSign.RunJobID
+		// Services started by the worker itself when the hatchery cannot start them
+		if signature.HatcheryService != nil {
+			jobID = fmt.Sprintf("%s-%s", signature.RunJobID, signature.HatcheryService.ServiceName)
+		}
 	}
 
 	terminatedI := msg.Extra["_"+cdslog.ExtraFieldTerminated]
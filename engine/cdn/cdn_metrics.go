@@ -6,8 +6,6 @@ import (
 	"time"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/engine/cdn/item"
 	"github.com/ovh/cds/engine/cdn/storage"
@@ -25,44 +23,50 @@ func (s *Service) initMetrics(ctx context.Context) error {
 
 	s.RegisterCommonMetricsView(ctx)
 
-	s.Metrics.tcpServerErrorsCount = stats.Int64("cdn/tcp/errors", "tcp server number of errors", stats.UnitDimensionless)
-	tcpServerErrorsCountView := telemetry.NewViewCount(s.Metrics.tcpServerErrorsCount.Name(), s.Metrics.tcpServerErrorsCount, []tag.Key{tagServiceName, tagServiceType})
+	s.Metrics.tcpServerErrorsCount = telemetry.Int64("cdn/tcp/errors", "tcp server number of errors", telemetry.UnitDimensionless)
+	tcpServerErrorsCountView := telemetry.NewViewCount(s.Metrics.tcpServerErrorsCount.Name(), s.Metrics.tcpServerErrorsCount, []telemetry.TagKey{tagServiceName, tagServiceType})
 
-	s.Metrics.tcpServerHitsCount = stats.Int64("cdn/tcp/hits", "tcp server number of hits", stats.UnitDimensionless)
-	tcpServerHitsCountView := telemetry.NewViewCount(s.Metrics.tcpServerHitsCount.Name(), s.Metrics.tcpServerHitsCount, []tag.Key{tagServiceName, tagServiceType})
+	s.Metrics.tcpServerHitsCount = telemetry.Int64("cdn/tcp/hits", "tcp server number of hits", telemetry.UnitDimensionless)
+	tcpServerHitsCountView := telemetry.NewViewCount(s.Metrics.tcpServerHitsCount.Name(), s.Metrics.tcpServerHitsCount, []telemetry.TagKey{tagServiceName, tagServiceType})
 
-	s.Metrics.tcpServerStepLogCount = stats.Int64("cdn/tcp/step_log_count", "number of worker log received", stats.UnitDimensionless)
-	tcpServerStepLogCountView := telemetry.NewViewCount(s.Metrics.tcpServerStepLogCount.Name(), s.Metrics.tcpServerStepLogCount, []tag.Key{tagServiceName, tagServiceType})
+	s.Metrics.tcpServerStepLogCount = telemetry.Int64("cdn/tcp/step_log_count", "number of worker log received", telemetry.UnitDimensionless)
+	tcpServerStepLogCountView := telemetry.NewViewCount(s.Metrics.tcpServerStepLogCount.Name(), s.Metrics.tcpServerStepLogCount, []telemetry.TagKey{tagServiceName, tagServiceType})
 
-	s.Metrics.tcpServerServiceLogCount = stats.Int64("cdn/tcp/service_log_count", "number of service log received", stats.UnitDimensionless)
-	tcpServerServiceLogCountView := telemetry.NewViewCount(s.Metrics.tcpServerServiceLogCount.Name(), s.Metrics.tcpServerServiceLogCount, []tag.Key{tagServiceName, tagServiceType})
+	s.Metrics.tcpServerServiceLogCount = telemetry.Int64("cdn/tcp/service_log_count", "number of service log received", telemetry.UnitDimensionless)
+	tcpServerServiceLogCountView := telemetry.NewViewCount(s.Metrics.tcpServerServiceLogCount.Name(), s.Metrics.tcpServerServiceLogCount, []telemetry.TagKey{tagServiceName, tagServiceType})
 
-	s.Metrics.itemCompletedByGCCount = stats.Int64("cdn/items/completed_by_gc", "number of items completed by GC", stats.UnitDimensionless)
-	itemCompletedByGCCountView := telemetry.NewViewCount(s.Metrics.itemCompletedByGCCount.Name(), s.Metrics.itemCompletedByGCCount, []tag.Key{tagServiceName, tagServiceType})
+	s.Metrics.itemCompletedByGCCount = telemetry.Int64("cdn/items/completed_by_gc", "number of items completed by GC", telemetry.UnitDimensionless)
+	itemCompletedByGCCountView := telemetry.NewViewCount(s.Metrics.itemCompletedByGCCount.Name(), s.Metrics.itemCompletedByGCCount, []telemetry.TagKey{tagServiceName, tagServiceType})
 
-	s.Metrics.itemInDatabaseCount = stats.Int64("cdn/items/count", "number of items in database by type and status", stats.UnitDimensionless)
-	itemInDatabaseCountView := telemetry.NewViewLast(s.Metrics.itemInDatabaseCount.Name(), s.Metrics.itemInDatabaseCount, []tag.Key{tagItemType, tagStatus})
+	s.Metrics.itemInDatabaseCount = telemetry.Int64("cdn/items/count", "number of items in database by type and status", telemetry.UnitDimensionless)
+	itemInDatabaseCountView := telemetry.NewViewLast(s.Metrics.itemInDatabaseCount.Name(), s.Metrics.itemInDatabaseCount, []telemetry.TagKey{tagItemType, tagStatus})
 
-	s.Metrics.itemPerStorageUnitCount = stats.Int64("cdn/items/count_per_storage", "number of items per storage and type", stats.UnitDimensionless)
-	itemPerStorageUnitCountView := telemetry.NewViewLast(s.Metrics.itemPerStorageUnitCount.Name(), s.Metrics.itemPerStorageUnitCount, []tag.Key{tagStorage, tagItemType})
+	s.Metrics.itemPerStorageUnitCount = telemetry.Int64("cdn/items/count_per_storage", "number of items per storage and type", telemetry.UnitDimensionless)
+	itemPerStorageUnitCountView := telemetry.NewViewLast(s.Metrics.itemPerStorageUnitCount.Name(), s.Metrics.itemPerStorageUnitCount, []telemetry.TagKey{tagStorage, tagItemType})
 
-	s.Metrics.ItemSize = stats.Int64("cdn/items/size", "size items by type (in bytes) by percentil", stats.UnitBytes)
-	itemSizeView := telemetry.NewViewLast(s.Metrics.ItemSize.Name(), s.Metrics.ItemSize, []tag.Key{tagItemType, tagPercentil})
+	s.Metrics.ItemSize = telemetry.Int64("cdn/items/size", "size items by type (in bytes) by percentil", telemetry.UnitBytes)
+	itemSizeView := telemetry.NewViewLast(s.Metrics.ItemSize.Name(), s.Metrics.ItemSize, []telemetry.TagKey{tagItemType, tagPercentil})
 
-	s.Metrics.ItemToSyncCount = stats.Int64("cdn/items/sync_lag", "number of items to sync per storage and type", stats.UnitDimensionless)
-	itemToSyncCountView := telemetry.NewViewLast(s.Metrics.ItemToSyncCount.Name(), s.Metrics.ItemToSyncCount, []tag.Key{tagStorage, tagItemType})
+	s.Metrics.ItemToSyncCount = telemetry.Int64("cdn/items/sync_lag", "number of items to sync per storage and type", telemetry.UnitDimensionless)
+	itemToSyncCountView := telemetry.NewViewLast(s.Metrics.ItemToSyncCount.Name(), s.Metrics.ItemToSyncCount, []telemetry.TagKey{tagStorage, tagItemType})
 
-	s.Metrics.WSClients = stats.Int64("cdn/websocket_clients", "number of  websocket clients", stats.UnitDimensionless)
-	metricsWSClients := telemetry.NewViewLast(s.Metrics.WSClients.Name(), s.Metrics.WSClients, []tag.Key{tagServiceName, tagItemType})
+	s.Metrics.WSClients = telemetry.Int64("cdn/websocket_clients", "number of  websocket clients", telemetry.UnitDimensionless)
+	metricsWSClients := telemetry.NewViewLast(s.Metrics.WSClients.Name(), s.Metrics.WSClients, []telemetry.TagKey{tagServiceName, tagItemType})
 
-	s.Metrics.WSEvents = stats.Int64("cdn/websocket_events", "number of websocket events", stats.UnitDimensionless)
-	metricsWSEvents := telemetry.NewViewCount(s.Metrics.WSEvents.Name(), s.Metrics.WSEvents, []tag.Key{tagServiceName, tagItemType})
+	s.Metrics.WSEvents = telemetry.Int64("cdn/websocket_events", "number of websocket events", telemetry.UnitDimensionless)
+	metricsWSEvents := telemetry.NewViewCount(s.Metrics.WSEvents.Name(), s.Metrics.WSEvents, []telemetry.TagKey{tagServiceName, tagItemType})
 
-	s.Metrics.ItemToDelete = stats.Int64("cdn/items/to_delete", "number of items to delete per type", stats.UnitDimensionless)
-	itemToDeleteView := telemetry.NewViewLast(s.Metrics.ItemToDelete.Name(), s.Metrics.ItemToDelete, []tag.Key{tagItemType})
+	s.Metrics.ItemToDelete = telemetry.Int64("cdn/items/to_delete", "number of items to delete per type", telemetry.UnitDimensionless)
+	itemToDeleteView := telemetry.NewViewLast(s.Metrics.ItemToDelete.Name(), s.Metrics.ItemToDelete, []telemetry.TagKey{tagItemType})
 
-	s.Metrics.ItemUnitToDelete = stats.Int64("cdn/item_units/to_delete", "number of item units to delete per storage and type", stats.UnitDimensionless)
-	itemUnitToDeleteView := telemetry.NewViewLast(s.Metrics.ItemUnitToDelete.Name(), s.Metrics.ItemUnitToDelete, []tag.Key{tagStorage, tagItemType})
+	s.Metrics.ItemUnitToDelete = telemetry.Int64("cdn/item_units/to_delete", "number of item units to delete per storage and type", telemetry.UnitDimensionless)
+	itemUnitToDeleteView := telemetry.NewViewLast(s.Metrics.ItemUnitToDelete.Name(), s.Metrics.ItemUnitToDelete, []telemetry.TagKey{tagStorage, tagItemType})
+
+	s.Metrics.WorkerCacheRequests = telemetry.Int64("cdn/worker_cache/requests", "number of worker cache restore requests by status (hit, partial_hit, miss)", telemetry.UnitDimensionless)
+	workerCacheRequestsView := telemetry.NewViewCount(s.Metrics.WorkerCacheRequests.Name(), s.Metrics.WorkerCacheRequests, []telemetry.TagKey{tagServiceName, tagServiceType, tagStatus})
+
+	s.Metrics.WorkerCacheEvicted = telemetry.Int64("cdn/worker_cache/evicted", "number of worker cache items evicted by the project quotas", telemetry.UnitDimensionless)
+	workerCacheEvictedView := telemetry.NewViewCount(s.Metrics.WorkerCacheEvicted.Name(), s.Metrics.WorkerCacheEvicted, []telemetry.TagKey{tagServiceName, tagServiceType})
 
 	if s.DBConnectionFactory != nil {
 		s.GoRoutines.RunWithRestart(ctx, "cds-compute-metrics", func(ctx context.Context) {
@@ -84,6 +88,8 @@ func (s *Service) initMetrics(ctx context.Context) error {
 		itemToSyncCountView,
 		itemToDeleteView,
 		itemUnitToDeleteView,
+		workerCacheRequestsView,
+		workerCacheEvictedView,
 	)
 }
 
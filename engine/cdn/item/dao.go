@@ -166,18 +166,117 @@ func LoadWorkerCacheItemByProjectAndCacheTag(ctx context.Context, m *gorpmapper.
 	return getItem(ctx, m, db, query)
 }
 
-func LoadWorkerCacheItemsByProjectAndCacheTag(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, projKey string, cacheTag string) ([]sdk.CDNItem, error) {
+// LoadWorkerCacheItemsByProjectAndCacheTag returns all the items of given type for a cache tag saved on the given git ref.
+func LoadWorkerCacheItemsByProjectAndCacheTag(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, cacheType sdk.CDNItemType, projKey string, ref string, cacheTag string) ([]sdk.CDNItem, error) {
 	query := gorpmapper.NewQuery(`
 		SELECT *
 		FROM item
 		WHERE type = $1
 		AND (api_ref->>'project_key')::text = $2
 		AND (api_ref->>'cache_tag')::text = $3
+		AND COALESCE(api_ref->>'ref', '') = $4
 		AND to_delete = false
-  `).Args(sdk.CDNTypeItemWorkerCache, projKey, cacheTag)
+  `).Args(cacheType, projKey, cacheTag, ref)
 	return getItems(ctx, m, db, query)
 }
 
+// LoadWorkerCacheItemByProjectRefAndCacheTag returns the latest cache saved on the git ref with the exact cache tag.
+func LoadWorkerCacheItemByProjectRefAndCacheTag(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, cacheType sdk.CDNItemType, projKey string, ref string, cacheTag string) (*sdk.CDNItem, error) {
+	query := gorpmapper.NewQuery(`
+		SELECT *
+		FROM item
+		WHERE type = $1
+		AND (api_ref->>'project_key')::text = $2
+		AND (api_ref->>'cache_tag')::text = $3
+		AND COALESCE(api_ref->>'ref', '') = $4
+		AND status = $5
+		AND to_delete = false
+		ORDER BY created DESC
+		LIMIT 1
+	`).Args(cacheType, projKey, cacheTag, ref, sdk.CDNStatusItemCompleted)
+	return getItem(ctx, m, db, query)
+}
+
+// LoadWorkerCacheItemByProjectRefAndCacheTagPrefix returns the latest cache saved on the git ref with a cache tag starting with the given prefix.
+func LoadWorkerCacheItemByProjectRefAndCacheTagPrefix(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, cacheType sdk.CDNItemType, projKey string, ref string, prefix string) (*sdk.CDNItem, error) {
+	query := gorpmapper.NewQuery(`
+		SELECT *
+		FROM item
+		WHERE type = $1
+		AND (api_ref->>'project_key')::text = $2
+		AND left(api_ref->>'cache_tag', length($3)) = $3
+		AND COALESCE(api_ref->>'ref', '') = $4
+		AND status = $5
+		AND to_delete = false
+		ORDER BY created DESC
+		LIMIT 1
+	`).Args(cacheType, projKey, prefix, ref, sdk.CDNStatusItemCompleted)
+	return getItem(ctx, m, db, query)
+}
+
+// LoadWorkerCacheItemsByProject returns all the caches of a project, latest first.
+func LoadWorkerCacheItemsByProject(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, cacheType sdk.CDNItemType, projKey string) ([]sdk.CDNItem, error) {
+	query := gorpmapper.NewQuery(`
+		SELECT *
+		FROM item
+		WHERE type = $1
+		AND (api_ref->>'project_key')::text = $2
+		AND status = $3
+		AND to_delete = false
+		ORDER BY created DESC
+	`).Args(cacheType, projKey, sdk.CDNStatusItemCompleted)
+	return getItems(ctx, m, db, query)
+}
+
+// UpdateLastAccess sets the last access date of an item, used to evict the least recently used caches.
+func UpdateLastAccess(db gorp.SqlExecutor, id string) error {
+	_, err := db.Exec("UPDATE item SET last_access = $2 WHERE id = $1", id, time.Now())
+	return sdk.WithStack(err)
+}
+
+type WorkerCacheProjectSize struct {
+	ProjectKey string `db:"project_key"`
+	Size       int64  `db:"size"`
+}
+
+// ComputeWorkerCacheSizeByProject returns the size of the caches of each project.
+func ComputeWorkerCacheSizeByProject(db gorp.SqlExecutor, cacheType sdk.CDNItemType) ([]WorkerCacheProjectSize, error) {
+	query := `
+		SELECT api_ref->>'project_key' AS project_key, SUM(size) AS size
+		FROM item
+		WHERE type = $1
+		AND to_delete = false
+		GROUP BY api_ref->>'project_key'
+	`
+	var res []WorkerCacheProjectSize
+	if _, err := db.Select(&res, query, cacheType); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
+type WorkerCacheItemSize struct {
+	ID   string `db:"id"`
+	Size int64  `db:"size"`
+}
+
+// LoadWorkerCacheItemsSizeByProjectLRU returns the caches of a project, least recently used first.
+func LoadWorkerCacheItemsSizeByProjectLRU(db gorp.SqlExecutor, cacheType sdk.CDNItemType, projKey string) ([]WorkerCacheItemSize, error) {
+	query := `
+		SELECT id, size
+		FROM item
+		WHERE type = $1
+		AND (api_ref->>'project_key')::text = $2
+		AND to_delete = false
+		ORDER BY COALESCE(last_access, created) ASC
+	`
+	var res []WorkerCacheItemSize
+	if _, err := db.Select(&res, query, cacheType, projKey); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
 // LoadByJobRunID load an item by his job id and type
 // DEPRECATED
 func LoadByJobRunID(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, jobRunId int64, itemTypes []string, opts ...gorpmapper.GetAllOptionFunc) ([]sdk.CDNItem, error) {
@@ -14,6 +14,7 @@ import (
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 func (s *Service) bulkDeleteItemsHandler() service.Handler {
@@ -270,12 +271,65 @@ func (s *Service) getWorkerCache(ctx context.Context, r *http.Request, w http.Re
 	projectKey := r.FormValue("projectkey")
 	cachetag := r.FormValue("cachetag")
 
-	if projectKey == "" || cachetag == "" {
+	if projectKey == "" {
 		return sdk.WrapError(sdk.ErrWrongRequest, "invalid data to get worker cache")
 	}
+
+	// List all the caches of the project
+	if cachetag == "" {
+		if cacheType != string(sdk.CDNTypeItemWorkerCacheV2) {
+			return sdk.WrapError(sdk.ErrWrongRequest, "invalid data to get worker cache")
+		}
+		items, err := item.LoadWorkerCacheItemsByProject(ctx, s.Mapper, s.mustDBWithCtx(ctx), sdk.CDNTypeItemWorkerCacheV2, projectKey)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, items, http.StatusOK)
+	}
+
+	// Search the cache on the given ordered refs
+	if refs, has := r.Form["ref"]; has {
+		it, err := s.searchWorkerCache(ctx, sdk.CDNItemType(cacheType), projectKey, refs, cachetag, r.Form["restorekey"])
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, []sdk.CDNItem{*it}, http.StatusOK)
+	}
+
 	item, err := item.LoadWorkerCacheItemByProjectAndCacheTag(ctx, s.Mapper, s.mustDBWithCtx(ctx), cacheType, projectKey, cachetag)
 	if err != nil {
 		return err
 	}
 	return service.WriteJSON(w, []sdk.CDNItem{*item}, http.StatusOK)
 }
+
+// searchWorkerCache looks for the cache in each ref: first with the exact cache tag, then with the restore keys
+// used as prefixes in the given order. The latest matching cache is returned.
+func (s *Service) searchWorkerCache(ctx context.Context, cacheType sdk.CDNItemType, projectKey string, refs []string, cacheTag string, restoreKeys []string) (*sdk.CDNItem, error) {
+	db := s.mustDBWithCtx(ctx)
+	for _, ref := range refs {
+		it, err := item.LoadWorkerCacheItemByProjectRefAndCacheTag(ctx, s.Mapper, db, cacheType, projectKey, ref, cacheTag)
+		if err == nil {
+			telemetry.Record(telemetry.ContextWithTag(ctx, telemetry.TagStatus, "hit"), s.Metrics.WorkerCacheRequests, 1)
+			return it, nil
+		}
+		if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, err
+		}
+		for _, prefix := range restoreKeys {
+			if prefix == "" {
+				continue
+			}
+			it, err := item.LoadWorkerCacheItemByProjectRefAndCacheTagPrefix(ctx, s.Mapper, db, cacheType, projectKey, ref, prefix)
+			if err == nil {
+				telemetry.Record(telemetry.ContextWithTag(ctx, telemetry.TagStatus, "partial_hit"), s.Metrics.WorkerCacheRequests, 1)
+				return it, nil
+			}
+			if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return nil, err
+			}
+		}
+	}
+	telemetry.Record(telemetry.ContextWithTag(ctx, telemetry.TagStatus, "miss"), s.Metrics.WorkerCacheRequests, 1)
+	return nil, sdk.WithStack(sdk.ErrNotFound)
+}
@@ -5,7 +5,6 @@ import (
 	"sync"
 	"time"
 
-	"go.opencensus.io/stats"
 	"golang.org/x/time/rate"
 
 	"github.com/ovh/cds/engine/api"
@@ -19,6 +18,7 @@ import (
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdn"
 	"github.com/ovh/cds/sdk/log/hook/graylog"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 type handledMessage struct {
@@ -42,19 +42,21 @@ type Service struct {
 	WSEventsMutex       sync.Mutex
 	WSEvents            map[string]sdk.CDNWSEvent
 	Metrics             struct {
-		tcpServerErrorsCount     *stats.Int64Measure
-		tcpServerHitsCount       *stats.Int64Measure
-		tcpServerStepLogCount    *stats.Int64Measure
-		tcpServerServiceLogCount *stats.Int64Measure
-		itemCompletedByGCCount   *stats.Int64Measure
-		itemInDatabaseCount      *stats.Int64Measure
-		itemPerStorageUnitCount  *stats.Int64Measure
-		ItemSize                 *stats.Int64Measure
-		ItemToSyncCount          *stats.Int64Measure
-		WSClients                *stats.Int64Measure
-		WSEvents                 *stats.Int64Measure
-		ItemToDelete             *stats.Int64Measure
-		ItemUnitToDelete         *stats.Int64Measure
+		tcpServerErrorsCount     *telemetry.Int64Measure
+		tcpServerHitsCount       *telemetry.Int64Measure
+		tcpServerStepLogCount    *telemetry.Int64Measure
+		tcpServerServiceLogCount *telemetry.Int64Measure
+		itemCompletedByGCCount   *telemetry.Int64Measure
+		itemInDatabaseCount      *telemetry.Int64Measure
+		itemPerStorageUnitCount  *telemetry.Int64Measure
+		ItemSize                 *telemetry.Int64Measure
+		ItemToSyncCount          *telemetry.Int64Measure
+		WSClients                *telemetry.Int64Measure
+		WSEvents                 *telemetry.Int64Measure
+		ItemToDelete             *telemetry.Int64Measure
+		ItemUnitToDelete         *telemetry.Int64Measure
+		WorkerCacheRequests      *telemetry.Int64Measure
+		WorkerCacheEvicted       *telemetry.Int64Measure
 	}
 	storageUnitLags          sync.Map
 	storageUnitPreviousLags  sync.Map
@@ -94,6 +96,11 @@ type Configuration struct {
 		BatchSize        int `toml:"batchSize" default:"1000" json:"batchSize" comment:"Number of expired worker cache items to mark for deletion per batch"`
 		GracePeriodDays  int `toml:"gracePeriodDays" default:"730" json:"gracePeriodDays" comment:"Only delete items expired for longer than this number of days (default: 730 ~ 2 years)"`
 	} `toml:"workerCachePurge" comment:"######################\n Worker cache purge settings \n######################" json:"workerCachePurge"`
+	WorkerCacheQuota struct {
+		FrequencySeconds  int              `toml:"frequencySeconds" default:"900" json:"frequencySeconds" comment:"Frequency in seconds between each check of the worker cache quotas"`
+		MaxSizePerProject int64            `toml:"maxSizePerProject" default:"0" json:"maxSizePerProject" comment:"Maximum size in bytes of the v2 worker caches of a project, the least recently used caches are evicted above it (0: no limit)"`
+		Projects          map[string]int64 `toml:"projects" json:"projects,omitempty" comment:"Maximum size in bytes of the v2 worker caches for specific projects, by project key"`
+	} `toml:"workerCacheQuota" comment:"######################\n Worker cache quota settings \n######################" json:"workerCacheQuota"`
 }
 
 type rateLimiter struct {
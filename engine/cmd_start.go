@@ -300,6 +300,12 @@ See $ engine config command for more details.
 				if err := start(serviceCtx, srv.service, srv.arg, srv.cfg); err != nil {
 					log.Error(ctx, "%s> service has been stopped: %+v", srv.arg, err)
 				}
+				// Flush the spans and metrics not exported yet
+				shutdownCtx, cancelShutdown := context.WithTimeout(context.WithoutCancel(serviceCtx), 10*time.Second)
+				if err := telemetry.Shutdown(shutdownCtx); err != nil {
+					log.Error(ctx, "%s> unable to shutdown telemetry: %v", srv.arg, err)
+				}
+				cancelShutdown()
 				wg.Done()
 			}(s)
 
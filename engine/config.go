@@ -332,6 +332,21 @@ func configSetStartupData(conf *Configuration) (string, error) {
 			},
 		}
 
+		attestationKey, err := jws.NewRandomRSAKey()
+		if err != nil {
+			return "", err
+		}
+		attestationKeyPEM, err := jws.ExportPrivateKey(attestationKey)
+		if err != nil {
+			return "", err
+		}
+		conf.API.Auth.AttestationPrivateKeys = []authentication.KeyConfig{
+			{
+				Timestamp: time.Now().Unix(),
+				Key:       string(attestationKeyPEM),
+			},
+		}
+
 		key, _ := keyloader.GenerateKey("hmac", gorpmapper.KeySignIdentifier, false, time.Now())
 		conf.API.Database.SignatureKey = &database.RollingKeyConfig{Cipher: "hmac"}
 		conf.API.Database.SignatureKey.Keys = append(conf.API.Database.SignatureKey.Keys, database.KeyConfig{
@@ -115,6 +115,10 @@ func (h *HatcheryLocal) CheckConfiguration(cfg interface{}) error {
 		return fmt.Errorf("Invalid basedir directory")
 	}
 
+	if err := hconfig.JobServices.Check(); err != nil {
+		return fmt.Errorf("Invalid hatchery local configuration: %v", err)
+	}
+
 	if ok, err := sdk.DirectoryExists(hconfig.Basedir); !ok {
 		return fmt.Errorf("Basedir doesn't exist")
 	} else if err != nil {
@@ -13,7 +13,8 @@ import (
 // HatcheryConfiguration is the configuration for local hatchery
 type HatcheryConfiguration struct {
 	service.HatcheryCommonConfiguration `mapstructure:"commonConfiguration" toml:"commonConfiguration" json:"commonConfiguration"`
-	Basedir                             string `mapstructure:"basedir" toml:"basedir" default:"/var/lib/cds-engine" comment:"BaseDir for worker workspace" json:"basedir"`
+	Basedir                             string                                   `mapstructure:"basedir" toml:"basedir" default:"/var/lib/cds-engine" comment:"BaseDir for worker workspace" json:"basedir"`
+	JobServices                         service.HatcheryJobServicesConfiguration `mapstructure:"jobServices" toml:"jobServices" comment:"Job services started by the worker" json:"jobServices"`
 }
 
 // HatcheryLocal implements HatcheryMode interface for local usage
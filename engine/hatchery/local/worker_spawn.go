@@ -49,6 +49,7 @@ func (h *HatcheryLocal) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	workerBinary := path.Join(h.BasedirDedicated, h.getWorkerBinaryName())
 	workerConfig := h.GenerateWorkerConfig(ctx, h, spawnArgs)
 	workerConfig.Basedir = basedir
+	workerConfig.JobServices = h.Config.JobServices.WorkerConfig()
 
 	// Prefix the command with the directory where the worker binary has been downloaded
 	log.Info(ctx, "Command exec: %v", workerBinary)
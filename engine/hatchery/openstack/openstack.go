@@ -123,6 +123,10 @@ func (h *HatcheryOpenstack) CheckConfiguration(cfg interface{}) error {
 		return fmt.Errorf("Invalid hatchery openstack configuration: %v", err)
 	}
 
+	if err := hconfig.JobServices.Check(); err != nil {
+		return fmt.Errorf("Invalid hatchery openstack configuration: %v", err)
+	}
+
 	if hconfig.Tenant == "" && hconfig.Domain == "" {
 		return fmt.Errorf("One of Openstack-tenant (auth v2) or Openstack-domain (auth v3) is mandatory")
 	}
@@ -95,6 +95,7 @@ func (h *HatcheryOpenstack) SpawnWorker(ctx context.Context, spawnArgs hatchery.
 		}
 	}
 	workerConfig := h.GenerateWorkerConfig(ctx, h, spawnArgs)
+	workerConfig.JobServices = h.Config.JobServices.WorkerConfig()
 	openstackImage := spawnArgs.Model.GetOpenstackImage()
 	if basedir := h.GetImageWorkerBasedir(ctx, openstackImage); basedir != "" {
 		log.Info(ctx, "SpawnWorker> overriding worker basedir for image %q: %q -> %q", openstackImage, workerConfig.Basedir, basedir)
@@ -84,6 +84,9 @@ type HatcheryConfiguration struct {
 	InjectSSHPublicKeys []string `mapstructure:"injectSSHPublicKeys" toml:"injectSSHPublicKeys" default:"" commented:"true" comment:"List of SSH public keys to inject into spawned workers" json:"injectSSHPublicKeys"`
 
 	RequiredBinariesRequirement []string `mapstructure:"requiredBinariesRequirement" toml:"requiredBinariesRequirement" default:"" commented:"true" comment:"If a job don't have any model requirement, check if there is at least required binaries" json:"requiredBinariesRequirement"`
+
+	// JobServices lets the worker start the services declared on v2 jobs with a runtime installed on the image
+	JobServices service.HatcheryJobServicesConfiguration `mapstructure:"jobServices" toml:"jobServices" comment:"Job services started by the worker" json:"jobServices"`
 }
 
 // HatcheryOpenstack spawns instances of worker model with type 'ISO'
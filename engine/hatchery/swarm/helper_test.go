@@ -74,3 +74,17 @@ func InitTestHatcherySwarm(t *testing.T) *HatcherySwarm {
 	gock.InterceptClient(h.Client.HTTPClient())
 	return h
 }
+
+func InitTestHatcherySwarmPodman(t *testing.T, engine string) *HatcherySwarm {
+	h := InitTestHatcherySwarm(t)
+	dc := h.dockerClients["default"]
+	dc.engine = engine
+	if engine == EnginePodmanLibpod {
+		httpClient := cdsclient.NewHTTPClient(1*time.Minute, false)
+		libpod, err := newLibpodClient("https://lolcat.local", httpClient)
+		require.NoError(t, err)
+		gock.InterceptClient(httpClient)
+		dc.libpod = libpod
+	}
+	return h
+}
@@ -8,9 +8,6 @@ import (
 	"sync"
 	"time"
 
-	"go.opencensus.io/stats"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/api/types"
@@ -30,12 +27,12 @@ const (
 )
 
 func (h *HatcherySwarm) InitWorkersMetrics(ctx context.Context) error {
-	h.workerMetrics.CPU = stats.Float64("cds/hatchery/worker_cpu", "number of cpu for a worker resource", stats.UnitDimensionless)
-	h.workerMetrics.CPURequest = stats.Float64("cds/hatchery/worker_cpu_request", "number of cpu requested for a worker resource", stats.UnitDimensionless)
-	h.workerMetrics.Memory = stats.Int64("cds/hatchery/worker_memory", "number of memory for a worker resource", stats.UnitDimensionless)
-	h.workerMetrics.MemoryRequest = stats.Int64("cds/hatchery/worker_memory_request", "number of memory requested for a worker resource", stats.UnitDimensionless)
+	h.workerMetrics.CPU = telemetry.Float64("cds/hatchery/worker_cpu", "number of cpu for a worker resource", telemetry.UnitDimensionless)
+	h.workerMetrics.CPURequest = telemetry.Float64("cds/hatchery/worker_cpu_request", "number of cpu requested for a worker resource", telemetry.UnitDimensionless)
+	h.workerMetrics.Memory = telemetry.Int64("cds/hatchery/worker_memory", "number of memory for a worker resource", telemetry.UnitDimensionless)
+	h.workerMetrics.MemoryRequest = telemetry.Int64("cds/hatchery/worker_memory_request", "number of memory requested for a worker resource", telemetry.UnitDimensionless)
 
-	tags := []tag.Key{
+	tags := []telemetry.TagKey{
 		telemetry.MustNewKey(telemetry.TagServiceName),
 		telemetry.MustNewKey(telemetry.TagServiceType),
 		telemetry.MustNewKey(TagNodeName),
@@ -61,8 +58,8 @@ func (h *HatcherySwarm) StartWorkerMetricsRoutine(ctx context.Context, delay int
 		case <-ticker.C:
 			h.GoRoutines.Exec(ctx, "compute-worker-metrics", func(ctx context.Context) {
 				// Re-register view to drop ended workers metrics
-				view.Unregister(h.workerMetrics.CPUView, h.workerMetrics.CPURequestView, h.workerMetrics.MemoryView, h.workerMetrics.MemoryRequestView)
-				view.Register(h.workerMetrics.CPUView, h.workerMetrics.CPURequestView, h.workerMetrics.MemoryView, h.workerMetrics.MemoryRequestView)
+				telemetry.Unregister(h.workerMetrics.CPUView, h.workerMetrics.CPURequestView, h.workerMetrics.MemoryView, h.workerMetrics.MemoryRequestView)
+				telemetry.Register(h.workerMetrics.CPUView, h.workerMetrics.CPURequestView, h.workerMetrics.MemoryView, h.workerMetrics.MemoryRequestView)
 
 				ms, err := h.WorkersMetrics(ctx)
 				if err != nil {
@@ -76,7 +73,7 @@ func (h *HatcherySwarm) StartWorkerMetricsRoutine(ctx context.Context, delay int
 					ctx = telemetry.ContextWithTag(ctx, TagJobID, m.JobID)
 					ctx = telemetry.ContextWithTag(ctx, TagResourceName, m.Name)
 					ctx = telemetry.ContextWithTag(ctx, TagWorkerName, m.WorkerName)
-					stats.Record(ctx,
+					telemetry.RecordMeasurements(ctx,
 						h.workerMetrics.CPU.M(m.CPU),
 						h.workerMetrics.CPURequest.M(m.CPURequest),
 						h.workerMetrics.Memory.M(m.Memory),
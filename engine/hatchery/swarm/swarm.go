@@ -183,11 +183,20 @@ func (h *HatcherySwarm) InitHatchery(ctx context.Context) error {
 			cancel()
 			log.Info(ctx, "hatchery> swarm> connected to %s (%s)", hostName, cfg.Host)
 
-			h.dockerClients[hostName] = &dockerClient{
+			dc := &dockerClient{
 				Client:        *d,
 				MaxContainers: cfg.MaxContainers,
 				name:          hostName,
+				engine:        cfg.Engine,
 			}
+			if cfg.Engine == EnginePodmanLibpod {
+				dc.libpod, errc = newLibpodClient(cfg.Host, httpClient)
+				if errc != nil {
+					log.Error(ctx, "hatchery> swarm> unable to create libpod client for host %s (%s): %v", hostName, cfg.Host, errc)
+					continue
+				}
+			}
+			h.dockerClients[hostName] = dc
 		}
 		if len(h.dockerClients) == 0 {
 			log.Error(ctx, "hatchery> swarm> no docker host available. Please check errors")
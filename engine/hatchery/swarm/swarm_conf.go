@@ -130,5 +130,11 @@ func (h *HatcherySwarm) CheckConfiguration(cfg interface{}) error {
 		return fmt.Errorf("worker-memory must be > 1")
 	}
 
+	for name, engine := range hconfig.DockerEngines {
+		if !IsValidEngine(engine.Engine) {
+			return fmt.Errorf("invalid engine %q for docker engine %s", engine.Engine, name)
+		}
+	}
+
 	return nil
 }
@@ -22,7 +22,13 @@ func (h *HatcherySwarm) createNetwork(ctx context.Context, dockerClient *dockerC
 	ctx, end := telemetry.Span(ctx, "swarm.createNetwork", telemetry.Tag("network", name))
 	defer end()
 	log.Debug(ctx, "hatchery> swarm> createNetwork> Create network %s", name)
-	_, err := dockerClient.NetworkCreate(ctx, name, types.NetworkCreate{
+	labels := map[string]string{
+		"worker_net": name,
+	}
+	if dockerClient.libpod != nil {
+		return dockerClient.libpod.createNetwork(ctx, name, labels, h.Config.NetworkEnableIPv6)
+	}
+	opts := types.NetworkCreate{
 		Driver:         "bridge",
 		Internal:       false,
 		CheckDuplicate: true,
@@ -30,10 +36,13 @@ func (h *HatcherySwarm) createNetwork(ctx context.Context, dockerClient *dockerC
 		IPAM: &network.IPAM{
 			Driver: "default",
 		},
-		Labels: map[string]string{
-			"worker_net": name,
-		},
-	})
+		Labels: labels,
+	}
+	// Let Podman choose its IPAM driver, rootless networks are managed by netavark
+	if dockerClient.isPodman() {
+		opts.IPAM = nil
+	}
+	_, err := dockerClient.NetworkCreate(ctx, name, opts)
 	return err
 }
 
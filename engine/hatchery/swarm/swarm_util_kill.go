@@ -9,6 +9,7 @@ import (
 
 	types "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	docker "github.com/moby/moby/client"
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/sdk"
@@ -27,7 +28,7 @@ func (h *HatcherySwarm) killAndRemove(ctx context.Context, dockerClient *dockerC
 	ct, err := dockerClient.ContainerInspect(ctxList, ID)
 	if err != nil {
 		//If there is an error, we try to remove the container
-		if strings.Contains(err.Error(), "No such container") {
+		if isContainerNotFound(err) {
 			log.Debug(ctx, "hatchery> swarm> killAndRemove> cannot InspectContainer: %v on %s", err, dockerClient.name)
 			return nil
 		}
@@ -50,7 +51,7 @@ func (h *HatcherySwarm) killAndRemove(ctx context.Context, dockerClient *dockerC
 				Error: sdk.NewErrorFrom(err, "an error occurred when registering the model with container name: %s", ct.Name).Error(),
 			}
 
-			logsReader, errL := dockerClient.ContainerLogs(ctx, ct.ID, logsOpts)
+			logsReader, errL := dockerClient.containerLogs(ctx, ct.ID, logsOpts)
 			if errL != nil {
 				log.Error(ctx, "hatchery> swarm> killAndRemove> cannot get logs from docker for containers service %s %v : %v", ct.ID, ct.Name, errL)
 				spawnErr.Logs = []byte(fmt.Sprintf("unable to get container logs: %v", errL))
@@ -81,7 +82,7 @@ func (h *HatcherySwarm) killAndRemove(ctx context.Context, dockerClient *dockerC
 				Timestamps: true,
 				Since:      "10s",
 			}
-			logsReader, errL := dockerClient.ContainerLogs(ctx, ct.ID, logsOpts)
+			logsReader, errL := dockerClient.containerLogs(ctx, ct.ID, logsOpts)
 
 			errmsg := fmt.Sprintf("an error occurred when running the model with container name: %s", ct.Name)
 
@@ -127,14 +128,14 @@ func (h *HatcherySwarm) killAndRemove(ctx context.Context, dockerClient *dockerC
 		network, err := dockerClient.NetworkInspect(ctxList, cnetwork.NetworkID, types.NetworkInspectOptions{})
 		if err != nil {
 			cancelList()
-			if !strings.Contains(err.Error(), "No such network") {
+			if !docker.IsErrNotFound(err) && !strings.Contains(err.Error(), "No such network") {
 				return sdk.WrapError(err, "unable to get network for %s on %s", sdk.StringFirstN(ID, 7), dockerClient.name)
 			}
 			continue
 		}
 
 		//If it's the default docker bridge... skip
-		if network.Driver != bridge || network.Name == docker0 || network.Name == bridge {
+		if network.Driver != bridge || dockerClient.isDefaultNetwork(network.Name) {
 			cancelList()
 			continue
 		}
@@ -188,17 +189,28 @@ func (h *HatcherySwarm) killAndRemoveContainer(ctx context.Context, dockerClient
 	log.Debug(ctx, "hatchery> swarm> killAndRemove> remove container %s on %s", ID, dockerClient.name)
 	ctxDocker, cancelList := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancelList()
-	if err := dockerClient.ContainerKill(ctxDocker, ID, "SIGKILL"); err != nil {
-		if !strings.Contains(err.Error(), "is not running") && !strings.Contains(err.Error(), "No such container") {
+	var err error
+	if dockerClient.libpod != nil {
+		err = dockerClient.libpod.killContainer(ctxDocker, ID, "SIGKILL")
+	} else {
+		err = dockerClient.ContainerKill(ctxDocker, ID, "SIGKILL")
+	}
+	if err != nil {
+		if !isContainerNotRunning(err) && !isContainerNotFound(err) {
 			return sdk.WrapError(err, "err on kill container %v from %s", err, dockerClient.name)
 		}
 	}
 
 	ctxDockerRemove, cancelList := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancelList()
-	if err := dockerClient.ContainerRemove(ctxDockerRemove, ID, container.RemoveOptions{RemoveVolumes: true, Force: true}); err != nil {
+	if dockerClient.libpod != nil {
+		err = dockerClient.libpod.removeContainer(ctxDockerRemove, ID)
+	} else {
+		err = dockerClient.ContainerRemove(ctxDockerRemove, ID, container.RemoveOptions{RemoveVolumes: true, Force: true})
+	}
+	if err != nil {
 		// container could be already removed by a previous call to docker
-		if !strings.Contains(err.Error(), "No such container") && !strings.Contains(err.Error(), "is already in progress") {
+		if !isContainerNotFound(err) && !strings.Contains(err.Error(), "is already in progress") {
 			log.Error(ctx, "Unable to remove container %s from %s: %v", ID, dockerClient.name, err)
 		}
 	}
@@ -226,7 +238,7 @@ func (h *HatcherySwarm) killAwolNetworks(ctx context.Context) error {
 				continue
 			}
 			cancelNet()
-			if n.Driver != bridge || n.Name == docker0 || n.Name == bridge {
+			if n.Driver != bridge || dockerClient.isDefaultNetwork(n.Name) {
 				continue
 			}
 
@@ -0,0 +1,263 @@
+package swarm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/registry"
+	timetypes "github.com/docker/docker/api/types/time"
+	docker "github.com/moby/moby/client"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	// EngineDocker uses the Docker engine API
+	EngineDocker = "docker"
+	// EnginePodman uses the Docker-compatible API exposed by Podman
+	EnginePodman = "podman"
+	// EnginePodmanLibpod uses the libpod API exposed by Podman for image pulls, networks, logs and container removal
+	EnginePodmanLibpod = "podman-libpod"
+
+	podmanDefaultNetwork = "podman"
+	libpodAPIVersion     = "v4.0.0"
+)
+
+// IsValidEngine checks the engine value of a docker engine configuration
+func IsValidEngine(engine string) bool {
+	switch engine {
+	case "", EngineDocker, EnginePodman, EnginePodmanLibpod:
+		return true
+	}
+	return false
+}
+
+func (d *dockerClient) isPodman() bool {
+	return d.engine == EnginePodman || d.engine == EnginePodmanLibpod
+}
+
+// isDefaultNetwork returns true for the networks created by the engine itself
+func (d *dockerClient) isDefaultNetwork(name string) bool {
+	if name == docker0 || name == bridge {
+		return true
+	}
+	return d.isPodman() && name == podmanDefaultNetwork
+}
+
+func (d *dockerClient) containerLogs(ctx context.Context, containerID string, opts container.LogsOptions) (io.ReadCloser, error) {
+	if d.libpod != nil {
+		return d.libpod.containerLogs(ctx, containerID, opts)
+	}
+	return d.ContainerLogs(ctx, containerID, opts)
+}
+
+// isContainerNotFound handles both Docker and Podman error messages
+func isContainerNotFound(err error) bool {
+	if docker.IsErrNotFound(err) {
+		return true
+	}
+	if e, ok := sdk.Cause(err).(libpodError); ok && e.StatusCode == http.StatusNotFound {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "No such container") || strings.Contains(msg, "no container with name or ID")
+}
+
+// isContainerNotRunning handles both Docker and Podman error messages
+func isContainerNotRunning(err error) bool {
+	if e, ok := sdk.Cause(err).(libpodError); ok && e.StatusCode == http.StatusConflict {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "is not running") || strings.Contains(msg, "can only kill running containers")
+}
+
+type libpodError struct {
+	StatusCode int
+	Message    string `json:"message"`
+	Cause      string `json:"cause"`
+}
+
+func (e libpodError) Error() string {
+	return fmt.Sprintf("libpod error %d: %s", e.StatusCode, e.Message)
+}
+
+// libpodClient calls the libpod API of a Podman service. The same service also exposes
+// the Docker-compatible API used for all the other calls.
+type libpodClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newLibpodClient(host string, httpClient *http.Client) (*libpodClient, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, sdk.WrapError(err, "invalid podman host %q", host)
+	}
+	c := &libpodClient{httpClient: httpClient}
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		c.baseURL = "http://d"
+		c.httpClient = &http.Client{
+			Timeout: httpClient.Timeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socket)
+				},
+			},
+		}
+	case "tcp":
+		scheme := "http"
+		if t, ok := httpClient.Transport.(*http.Transport); ok && t.TLSClientConfig != nil {
+			scheme = "https"
+		}
+		c.baseURL = scheme + "://" + u.Host
+	case "http", "https":
+		c.baseURL = u.Scheme + "://" + u.Host
+	default:
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported podman host scheme %q", u.Scheme)
+	}
+	return c, nil
+}
+
+func (c *libpodClient) do(ctx context.Context, method, path string, query url.Values, headers map[string]string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		btes, err := json.Marshal(body)
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		reader = bytes.NewReader(btes)
+	}
+	u := c.baseURL + "/" + libpodAPIVersion + "/libpod" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close() // nolint
+		e := libpodError{StatusCode: resp.StatusCode}
+		btes, _ := io.ReadAll(resp.Body)
+		if err := json.Unmarshal(btes, &e); err != nil || e.Message == "" {
+			e.Message = string(btes)
+		}
+		return nil, sdk.WithStack(e)
+	}
+	return resp, nil
+}
+
+func (c *libpodClient) pullImage(ctx context.Context, img string, authConfig *registry.AuthConfig) error {
+	headers := map[string]string{}
+	if authConfig != nil {
+		btes, err := json.Marshal(authConfig)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		headers["X-Registry-Auth"] = base64.URLEncoding.EncodeToString(btes)
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/images/pull", url.Values{"reference": {img}, "policy": {"always"}}, headers, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint
+
+	// The pull report is a stream of json objects, errors are reported in the stream with a 200 status code
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var report struct {
+			Stream string `json:"stream"`
+			Error  string `json:"error"`
+			ID     string `json:"id"`
+		}
+		if err := dec.Decode(&report); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return sdk.WrapError(err, "unable to read pull report of image %s", img)
+		}
+		if report.Error != "" {
+			return sdk.WithStack(fmt.Errorf("unable to pull image %s: %s", img, report.Error))
+		}
+	}
+}
+
+func (c *libpodClient) createNetwork(ctx context.Context, name string, labels map[string]string, enableIPv6 bool) error {
+	body := map[string]interface{}{
+		"name":         name,
+		"driver":       "bridge",
+		"labels":       labels,
+		"ipv6_enabled": enableIPv6,
+		// DNS is needed to resolve job services aliases on rootless networks
+		"dns_enabled": true,
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/networks/create", nil, nil, body)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c *libpodClient) killContainer(ctx context.Context, containerID, signal string) error {
+	resp, err := c.do(ctx, http.MethodPost, "/containers/"+containerID+"/kill", url.Values{"signal": {signal}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c *libpodClient) removeContainer(ctx context.Context, containerID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/containers/"+containerID, url.Values{"force": {"true"}, "v": {"true"}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c *libpodClient) containerLogs(ctx context.Context, containerID string, opts container.LogsOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	if opts.ShowStdout {
+		query.Set("stdout", "true")
+	}
+	if opts.ShowStderr {
+		query.Set("stderr", "true")
+	}
+	if opts.Timestamps {
+		query.Set("timestamps", "true")
+	}
+	if opts.Since != "" {
+		ts, err := timetypes.GetTimestamp(opts.Since, time.Now())
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		query.Set("since", ts)
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/containers/"+containerID+"/logs", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,174 @@
+package swarm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_newLibpodClient(t *testing.T) {
+	c, err := newLibpodClient("unix:///run/user/1000/podman/podman.sock", &http.Client{})
+	require.NoError(t, err)
+	require.Equal(t, "http://d", c.baseURL)
+
+	c, err = newLibpodClient("tcp://podman.local:8888", &http.Client{Transport: &http.Transport{}})
+	require.NoError(t, err)
+	require.Equal(t, "http://podman.local:8888", c.baseURL)
+
+	_, err = newLibpodClient("ftp://podman.local", &http.Client{})
+	require.Error(t, err)
+}
+
+func Test_pullImageLibpod(t *testing.T) {
+	defer gock.Off()
+
+	h := InitTestHatcherySwarmPodman(t, EnginePodmanLibpod)
+	h.Config.RegistryCredentials = []RegistryCredential{
+		{
+			Domain:   "my-registry.lolcat.local",
+			Username: "my-user",
+			Password: "my-pass",
+		},
+	}
+
+	gock.New("https://lolcat.local").Post("/v4.0.0/libpod/images/pull").
+		MatchParam("reference", "my-registry.lolcat.local/my-image:my-tag").
+		AddMatcher(func(r *http.Request, _ *gock.Request) (bool, error) {
+			buf, err := base64.URLEncoding.DecodeString(r.Header.Get("X-Registry-Auth"))
+			if err != nil {
+				return false, err
+			}
+			var auth registry.AuthConfig
+			if err := json.Unmarshal(buf, &auth); err != nil {
+				return false, err
+			}
+			return auth.Username == "my-user" && auth.Password == "my-pass" && auth.ServerAddress == "my-registry.lolcat.local", nil
+		}).
+		Reply(http.StatusOK).
+		BodyString(`{"stream":"Trying to pull my-registry.lolcat.local/my-image:my-tag...\n"}` + "\n" + `{"images":["123456"],"id":"123456"}`)
+
+	require.NoError(t, h.pullImage(h.dockerClients["default"], "my-registry.lolcat.local/my-image:my-tag", time.Minute, sdk.WorkerStarterWorkerModel{ModelV1: &sdk.Model{}}))
+
+	// Errors are sent in the stream with a 200 status code
+	gock.New("https://lolcat.local").Post("/v4.0.0/libpod/images/pull").
+		MatchParam("reference", "my-image-unknown:my-tag").
+		Reply(http.StatusOK).
+		BodyString(`{"stream":"Trying to pull docker.io/library/my-image-unknown:my-tag...\n"}` + "\n" + `{"error":"manifest unknown"}`)
+
+	err := h.pullImage(h.dockerClients["default"], "my-image-unknown:my-tag", time.Minute, sdk.WorkerStarterWorkerModel{ModelV1: &sdk.Model{}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "manifest unknown")
+
+	require.True(t, gock.IsDone())
+}
+
+func Test_createNetworkPodman(t *testing.T) {
+	defer gock.Off()
+
+	// Docker-compatible API: IPAM driver is left to podman
+	h := InitTestHatcherySwarmPodman(t, EnginePodman)
+	gock.New("https://lolcat.local").Post("/v6.66/networks/create").
+		AddMatcher(func(r *http.Request, _ *gock.Request) (bool, error) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				return false, err
+			}
+			return body["Name"] == "my-network" && body["IPAM"] == nil, nil
+		}).
+		Reply(http.StatusCreated).JSON(map[string]string{"Id": "my-network-id"})
+	require.NoError(t, h.createNetwork(context.TODO(), h.dockerClients["default"], "my-network"))
+
+	// libpod API
+	h = InitTestHatcherySwarmPodman(t, EnginePodmanLibpod)
+	gock.New("https://lolcat.local").Post("/v4.0.0/libpod/networks/create").
+		AddMatcher(func(r *http.Request, _ *gock.Request) (bool, error) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				return false, err
+			}
+			labels, _ := body["labels"].(map[string]interface{})
+			return body["name"] == "my-network" && body["dns_enabled"] == true && labels["worker_net"] == "my-network", nil
+		}).
+		Reply(http.StatusOK).JSON(map[string]string{"name": "my-network"})
+	require.NoError(t, h.createNetwork(context.TODO(), h.dockerClients["default"], "my-network"))
+
+	require.True(t, gock.IsDone())
+}
+
+func Test_killAndRemoveContainerLibpod(t *testing.T) {
+	defer gock.Off()
+
+	h := InitTestHatcherySwarmPodman(t, EnginePodmanLibpod)
+
+	// Container already stopped
+	gock.New("https://lolcat.local").Post("/v4.0.0/libpod/containers/my-container/kill").
+		MatchParam("signal", "SIGKILL").
+		Reply(http.StatusConflict).JSON(map[string]interface{}{"cause": "container state improper", "message": "can only kill running containers", "response": 409})
+	gock.New("https://lolcat.local").Delete("/v4.0.0/libpod/containers/my-container").
+		MatchParam("force", "true").
+		Reply(http.StatusOK).JSON([]interface{}{})
+	require.NoError(t, h.killAndRemoveContainer(context.TODO(), h.dockerClients["default"], "my-container"))
+
+	// Unknown container
+	gock.New("https://lolcat.local").Post("/v4.0.0/libpod/containers/unknown/kill").
+		Reply(http.StatusNotFound).JSON(map[string]interface{}{"cause": "no such container", "message": "no container with name or ID \"unknown\" found: no such container", "response": 404})
+	gock.New("https://lolcat.local").Delete("/v4.0.0/libpod/containers/unknown").
+		Reply(http.StatusNotFound).JSON(map[string]interface{}{"cause": "no such container", "message": "no container with name or ID \"unknown\" found: no such container", "response": 404})
+	require.NoError(t, h.killAndRemoveContainer(context.TODO(), h.dockerClients["default"], "unknown"))
+
+	// Unexpected error
+	gock.New("https://lolcat.local").Post("/v4.0.0/libpod/containers/my-container/kill").
+		Reply(http.StatusInternalServerError).JSON(map[string]interface{}{"cause": "boom", "message": "boom", "response": 500})
+	require.Error(t, h.killAndRemoveContainer(context.TODO(), h.dockerClients["default"], "my-container"))
+
+	require.True(t, gock.IsDone())
+}
+
+func Test_containerLogsLibpod(t *testing.T) {
+	defer gock.Off()
+
+	h := InitTestHatcherySwarmPodman(t, EnginePodmanLibpod)
+
+	gock.New("https://lolcat.local").Get("/v4.0.0/libpod/containers/my-container/logs").
+		MatchParam("stdout", "true").
+		MatchParam("stderr", "true").
+		AddMatcher(func(r *http.Request, _ *gock.Request) (bool, error) {
+			return r.URL.Query().Get("since") != "", nil
+		}).
+		Reply(http.StatusOK).BodyString("my logs")
+
+	reader, err := h.dockerClients["default"].containerLogs(context.TODO(), "my-container", container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Since:      "10s",
+	})
+	require.NoError(t, err)
+	btes, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, "my logs", string(btes))
+
+	require.True(t, gock.IsDone())
+}
+
+func Test_isDefaultNetwork(t *testing.T) {
+	dockerEngine := &dockerClient{engine: EngineDocker}
+	require.True(t, dockerEngine.isDefaultNetwork("bridge"))
+	require.True(t, dockerEngine.isDefaultNetwork("docker0"))
+	require.False(t, dockerEngine.isDefaultNetwork("podman"))
+
+	podmanEngine := &dockerClient{engine: EnginePodman}
+	require.True(t, podmanEngine.isDefaultNetwork("podman"))
+	require.False(t, podmanEngine.isDefaultNetwork("my-network"))
+}
@@ -96,6 +96,16 @@ func (h *HatcherySwarm) pullImage(dockerClient *dockerClient, img string, timeou
 		log.Debug(context.TODO(), "pulling image %q on %q with login on %q", img, dockerClient.name, authConfig.ServerAddress)
 	}
 
+	if dockerClient.libpod != nil {
+		if err := dockerClient.libpod.pullImage(ctx, img, authConfig); err != nil {
+			ctx = sdk.ContextWithStacktrace(ctx, err)
+			log.Warn(ctx, "unable to pull image %s on %s: %s", img, dockerClient.name, err)
+			return err
+		}
+		log.Info(ctx, "hatchery> swarm> pullImage> pulling image %s on %s - %.3f seconds elapsed", img, dockerClient.name, time.Since(t0).Seconds())
+		return nil
+	}
+
 	res, err := dockerClient.ImageCreate(ctx, img, opts)
 	if err != nil {
 		ctx = sdk.ContextWithStacktrace(ctx, err)
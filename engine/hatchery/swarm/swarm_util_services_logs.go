@@ -66,7 +66,7 @@ func (h *HatcherySwarm) getServicesLogs() error {
 				ShowStdout: true,
 				Since:      "10s",
 			}
-			logsReader, err := dockerClient.ContainerLogs(ctxLogs, cnt.ID, logsOpts)
+			logsReader, err := dockerClient.containerLogs(ctxLogs, cnt.ID, logsOpts)
 			if err != nil {
 				err = sdk.WrapError(err, "cannot get logs from docker for containers service %s %v", cnt.ID, cnt.Names)
 				ctx := sdk.ContextWithStacktrace(ctx, err)
@@ -2,11 +2,10 @@ package swarm
 
 import (
 	docker "github.com/moby/moby/client"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/stats/view"
 
 	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
 	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 const (
@@ -55,14 +54,14 @@ type HatcherySwarm struct {
 	Config        HatcheryConfiguration
 	dockerClients map[string]*dockerClient
 	workerMetrics struct {
-		CPU               *stats.Float64Measure
-		CPURequest        *stats.Float64Measure
-		Memory            *stats.Int64Measure
-		MemoryRequest     *stats.Int64Measure
-		CPUView           *view.View
-		CPURequestView    *view.View
-		MemoryView        *view.View
-		MemoryRequestView *view.View
+		CPU               *telemetry.Float64Measure
+		CPURequest        *telemetry.Float64Measure
+		Memory            *telemetry.Int64Measure
+		MemoryRequest     *telemetry.Int64Measure
+		CPUView           *telemetry.View
+		CPURequestView    *telemetry.View
+		MemoryView        *telemetry.View
+		MemoryRequestView *telemetry.View
 	}
 }
 
@@ -70,6 +69,8 @@ type dockerClient struct {
 	docker.Client
 	MaxContainers int
 	name          string
+	engine        string
+	libpod        *libpodClient
 }
 
 // DockerEngineConfiguration is a configuration to be able to connect to a docker engine
@@ -82,6 +83,7 @@ type DockerEngineConfiguration struct {
 	TLSKEYPEM             string `mapstructure:"TLSKEYPEM" toml:"TLSKEYPEM" comment:"content of your key.pem" json:"-"`
 	APIVersion            string `mapstructure:"APIVersion" toml:"APIVersion" default:"1.41" comment:"DOCKER_API_VERSION" json:"APIVersion"` // DOCKER_API_VERSION
 	MaxContainers         int    `mapstructure:"maxContainers" toml:"maxContainers" default:"10" commented:"false" comment:"Max Containers on Host managed by this Hatchery" json:"maxContainers"`
+	Engine                string `mapstructure:"engine" toml:"engine" default:"docker" commented:"true" comment:"Container engine: docker, podman (Docker-compatible API) or podman-libpod (libpod API). Example for rootless podman: host=\"unix:///run/user/1000/podman/podman.sock\"" json:"engine,omitempty"`
 }
 
 type RegistryCredential struct {
@@ -128,6 +128,10 @@ func (h *HatcheryVSphere) CheckConfiguration(cfg interface{}) error {
 		return sdk.WithStack(fmt.Errorf("invalid hatchery vsphere configuration: %v", err))
 	}
 
+	if err := hconfig.JobServices.Check(); err != nil {
+		return sdk.WithStack(fmt.Errorf("invalid hatchery vsphere configuration: %v", err))
+	}
+
 	if hconfig.VSphereUser == "" {
 		return sdk.WithStack(fmt.Errorf("vsphere-user is mandatory"))
 	}
@@ -8,57 +8,54 @@ import (
 	"github.com/rockbears/log"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/sdk/telemetry"
 )
 
 type vsphereMetrics struct {
 	// Level 1: Per-worker
-	WorkerVCPUs    *stats.Int64Measure
-	WorkerMemoryMB *stats.Int64Measure
-	WorkerDiskGB   *stats.Int64Measure
+	WorkerVCPUs    *telemetry.Int64Measure
+	WorkerMemoryMB *telemetry.Int64Measure
+	WorkerDiskGB   *telemetry.Int64Measure
 
 	// Level 2: Hatchery-level aggregate
-	AllocatedVCPUs     *stats.Int64Measure
-	AllocatedMemoryMB  *stats.Int64Measure
-	AllocatedDiskGB    *stats.Int64Measure
-	VMCount            *stats.Int64Measure
-	WorkerVMCount      *stats.Int64Measure
-	ProvisionedVMCount *stats.Int64Measure
+	AllocatedVCPUs     *telemetry.Int64Measure
+	AllocatedMemoryMB  *telemetry.Int64Measure
+	AllocatedDiskGB    *telemetry.Int64Measure
+	VMCount            *telemetry.Int64Measure
+	WorkerVMCount      *telemetry.Int64Measure
+	ProvisionedVMCount *telemetry.Int64Measure
 	// Provisioned VMs broken down by state (sum of ready+starting+dying equals
 	// ProvisionedVMCount; in-flight clones are not VMs yet so they are tracked apart).
-	ProvisionReadyCount    *stats.Int64Measure
-	ProvisionStartingCount *stats.Int64Measure
-	ProvisionDyingCount    *stats.Int64Measure
-	ProvisionInflightCount *stats.Int64Measure
-	TemplateVCPUs          *stats.Int64Measure
-	TemplateMemoryMB       *stats.Int64Measure
-	TemplateCount          *stats.Int64Measure
+	ProvisionReadyCount    *telemetry.Int64Measure
+	ProvisionStartingCount *telemetry.Int64Measure
+	ProvisionDyingCount    *telemetry.Int64Measure
+	ProvisionInflightCount *telemetry.Int64Measure
+	TemplateVCPUs          *telemetry.Int64Measure
+	TemplateMemoryMB       *telemetry.Int64Measure
+	TemplateCount          *telemetry.Int64Measure
 
 	// Level 3: Global pool (all VMs in datacenter)
-	PoolTotalVCPUs    *stats.Int64Measure
-	PoolTotalMemoryMB *stats.Int64Measure
-	PoolTotalVMCount  *stats.Int64Measure
+	PoolTotalVCPUs    *telemetry.Int64Measure
+	PoolTotalMemoryMB *telemetry.Int64Measure
+	PoolTotalVMCount  *telemetry.Int64Measure
 
 	// Level 3: Resource Pool runtime
-	ResourcePoolCPUMax        *stats.Int64Measure
-	ResourcePoolCPUUsage      *stats.Int64Measure
-	ResourcePoolCPUUnreserved *stats.Int64Measure
-	ResourcePoolMemMax        *stats.Int64Measure
-	ResourcePoolMemUsage      *stats.Int64Measure
-	ResourcePoolMemUnreserved *stats.Int64Measure
+	ResourcePoolCPUMax        *telemetry.Int64Measure
+	ResourcePoolCPUUsage      *telemetry.Int64Measure
+	ResourcePoolCPUUnreserved *telemetry.Int64Measure
+	ResourcePoolMemMax        *telemetry.Int64Measure
+	ResourcePoolMemUsage      *telemetry.Int64Measure
+	ResourcePoolMemUnreserved *telemetry.Int64Measure
 
 	// IP address tracking
-	IPActiveCount   *stats.Int64Measure
-	IPReservedCount *stats.Int64Measure
-	IPTotalCount    *stats.Int64Measure
+	IPActiveCount   *telemetry.Int64Measure
+	IPReservedCount *telemetry.Int64Measure
+	IPTotalCount    *telemetry.Int64Measure
 
 	// Views (kept for re-registration of per-worker views)
-	workerViews    []*view.View
-	aggregateViews []*view.View
+	workerViews    []*telemetry.View
+	aggregateViews []*telemetry.View
 }
 
 func (h *HatcheryVSphere) initVSphereMetrics(ctx context.Context) error {
@@ -68,118 +65,118 @@ func (h *HatcheryVSphere) initVSphereMetrics(ctx context.Context) error {
 
 func (h *HatcheryVSphere) initVSphereMetricsMeasures() {
 	// Level 1: Per-worker
-	h.metrics.WorkerVCPUs = stats.Int64(
+	h.metrics.WorkerVCPUs = telemetry.Int64(
 		"cds/hatchery/vsphere/worker_vcpus",
-		"vCPUs for a worker VM", stats.UnitDimensionless)
-	h.metrics.WorkerMemoryMB = stats.Int64(
+		"vCPUs for a worker VM", telemetry.UnitDimensionless)
+	h.metrics.WorkerMemoryMB = telemetry.Int64(
 		"cds/hatchery/vsphere/worker_memory_mb",
-		"memory (MB) for a worker VM", stats.UnitDimensionless)
-	h.metrics.WorkerDiskGB = stats.Int64(
+		"memory (MB) for a worker VM", telemetry.UnitDimensionless)
+	h.metrics.WorkerDiskGB = telemetry.Int64(
 		"cds/hatchery/vsphere/worker_disk_gb",
-		"disk capacity (GB) for a worker VM", stats.UnitDimensionless)
+		"disk capacity (GB) for a worker VM", telemetry.UnitDimensionless)
 
 	// Level 2: Hatchery-level aggregate
-	h.metrics.AllocatedVCPUs = stats.Int64(
+	h.metrics.AllocatedVCPUs = telemetry.Int64(
 		"cds/hatchery/vsphere/allocated_vcpus",
-		"total vCPUs allocated by this hatchery's VMs", stats.UnitDimensionless)
-	h.metrics.AllocatedMemoryMB = stats.Int64(
+		"total vCPUs allocated by this hatchery's VMs", telemetry.UnitDimensionless)
+	h.metrics.AllocatedMemoryMB = telemetry.Int64(
 		"cds/hatchery/vsphere/allocated_memory_mb",
-		"total memory (MB) allocated by this hatchery's VMs", stats.UnitDimensionless)
-	h.metrics.AllocatedDiskGB = stats.Int64(
+		"total memory (MB) allocated by this hatchery's VMs", telemetry.UnitDimensionless)
+	h.metrics.AllocatedDiskGB = telemetry.Int64(
 		"cds/hatchery/vsphere/allocated_disk_gb",
-		"total disk capacity (GB) allocated by this hatchery's VMs", stats.UnitDimensionless)
-	h.metrics.VMCount = stats.Int64(
+		"total disk capacity (GB) allocated by this hatchery's VMs", telemetry.UnitDimensionless)
+	h.metrics.VMCount = telemetry.Int64(
 		"cds/hatchery/vsphere/vm_count",
-		"total VMs managed by this hatchery (workers + provisions)", stats.UnitDimensionless)
-	h.metrics.WorkerVMCount = stats.Int64(
+		"total VMs managed by this hatchery (workers + provisions)", telemetry.UnitDimensionless)
+	h.metrics.WorkerVMCount = telemetry.Int64(
 		"cds/hatchery/vsphere/worker_vm_count",
-		"VMs running as workers (claimed, no longer in the provision pool)", stats.UnitDimensionless)
-	h.metrics.ProvisionedVMCount = stats.Int64(
+		"VMs running as workers (claimed, no longer in the provision pool)", telemetry.UnitDimensionless)
+	h.metrics.ProvisionedVMCount = telemetry.Int64(
 		"cds/hatchery/vsphere/provisioned_vm_count",
-		"pre-provisioned VMs (ready + starting + dying)", stats.UnitDimensionless)
-	h.metrics.ProvisionReadyCount = stats.Int64(
+		"pre-provisioned VMs (ready + starting + dying)", telemetry.UnitDimensionless)
+	h.metrics.ProvisionReadyCount = telemetry.Int64(
 		"cds/hatchery/vsphere/provision_ready_count",
-		"provisioned VMs powered off and ready to be claimed", stats.UnitDimensionless)
-	h.metrics.ProvisionStartingCount = stats.Int64(
+		"provisioned VMs powered off and ready to be claimed", telemetry.UnitDimensionless)
+	h.metrics.ProvisionStartingCount = telemetry.Int64(
 		"cds/hatchery/vsphere/provision_starting_count",
-		"provisioned VMs powered on, still being created/finished", stats.UnitDimensionless)
-	h.metrics.ProvisionDyingCount = stats.Int64(
+		"provisioned VMs powered on, still being created/finished", telemetry.UnitDimensionless)
+	h.metrics.ProvisionDyingCount = telemetry.Int64(
 		"cds/hatchery/vsphere/provision_dying_count",
-		"provisioned VMs marked for deletion, not yet reaped", stats.UnitDimensionless)
-	h.metrics.ProvisionInflightCount = stats.Int64(
+		"provisioned VMs marked for deletion, not yet reaped", telemetry.UnitDimensionless)
+	h.metrics.ProvisionInflightCount = telemetry.Int64(
 		"cds/hatchery/vsphere/provision_inflight_count",
-		"in-flight provision clones not yet visible in the inventory", stats.UnitDimensionless)
-	h.metrics.TemplateVCPUs = stats.Int64(
+		"in-flight provision clones not yet visible in the inventory", telemetry.UnitDimensionless)
+	h.metrics.TemplateVCPUs = telemetry.Int64(
 		"cds/hatchery/vsphere/template_vcpus",
-		"total vCPUs defined by template VMs", stats.UnitDimensionless)
-	h.metrics.TemplateMemoryMB = stats.Int64(
+		"total vCPUs defined by template VMs", telemetry.UnitDimensionless)
+	h.metrics.TemplateMemoryMB = telemetry.Int64(
 		"cds/hatchery/vsphere/template_memory_mb",
-		"total memory (MB) defined by template VMs", stats.UnitDimensionless)
-	h.metrics.TemplateCount = stats.Int64(
+		"total memory (MB) defined by template VMs", telemetry.UnitDimensionless)
+	h.metrics.TemplateCount = telemetry.Int64(
 		"cds/hatchery/vsphere/template_count",
-		"number of template VMs", stats.UnitDimensionless)
+		"number of template VMs", telemetry.UnitDimensionless)
 
 	// Level 3: Global pool
-	h.metrics.PoolTotalVCPUs = stats.Int64(
+	h.metrics.PoolTotalVCPUs = telemetry.Int64(
 		"cds/hatchery/vsphere/pool_total_vcpus",
-		"total vCPUs across all VMs in datacenter", stats.UnitDimensionless)
-	h.metrics.PoolTotalMemoryMB = stats.Int64(
+		"total vCPUs across all VMs in datacenter", telemetry.UnitDimensionless)
+	h.metrics.PoolTotalMemoryMB = telemetry.Int64(
 		"cds/hatchery/vsphere/pool_total_memory_mb",
-		"total memory (MB) across all VMs in datacenter", stats.UnitDimensionless)
-	h.metrics.PoolTotalVMCount = stats.Int64(
+		"total memory (MB) across all VMs in datacenter", telemetry.UnitDimensionless)
+	h.metrics.PoolTotalVMCount = telemetry.Int64(
 		"cds/hatchery/vsphere/pool_total_vm_count",
-		"total VMs in datacenter", stats.UnitDimensionless)
+		"total VMs in datacenter", telemetry.UnitDimensionless)
 
 	// Level 3: Resource Pool runtime
-	h.metrics.ResourcePoolCPUMax = stats.Int64(
+	h.metrics.ResourcePoolCPUMax = telemetry.Int64(
 		"cds/hatchery/vsphere/resource_pool_cpu_max_mhz",
-		"Resource Pool max CPU in MHz", stats.UnitDimensionless)
-	h.metrics.ResourcePoolCPUUsage = stats.Int64(
+		"Resource Pool max CPU in MHz", telemetry.UnitDimensionless)
+	h.metrics.ResourcePoolCPUUsage = telemetry.Int64(
 		"cds/hatchery/vsphere/resource_pool_cpu_usage_mhz",
-		"Resource Pool CPU usage in MHz", stats.UnitDimensionless)
-	h.metrics.ResourcePoolCPUUnreserved = stats.Int64(
+		"Resource Pool CPU usage in MHz", telemetry.UnitDimensionless)
+	h.metrics.ResourcePoolCPUUnreserved = telemetry.Int64(
 		"cds/hatchery/vsphere/resource_pool_cpu_unreserved_mhz",
-		"Resource Pool CPU unreserved for VMs in MHz", stats.UnitDimensionless)
-	h.metrics.ResourcePoolMemMax = stats.Int64(
+		"Resource Pool CPU unreserved for VMs in MHz", telemetry.UnitDimensionless)
+	h.metrics.ResourcePoolMemMax = telemetry.Int64(
 		"cds/hatchery/vsphere/resource_pool_memory_max_bytes",
-		"Resource Pool max memory in bytes", stats.UnitDimensionless)
-	h.metrics.ResourcePoolMemUsage = stats.Int64(
+		"Resource Pool max memory in bytes", telemetry.UnitDimensionless)
+	h.metrics.ResourcePoolMemUsage = telemetry.Int64(
 		"cds/hatchery/vsphere/resource_pool_memory_usage_bytes",
-		"Resource Pool memory usage in bytes", stats.UnitDimensionless)
-	h.metrics.ResourcePoolMemUnreserved = stats.Int64(
+		"Resource Pool memory usage in bytes", telemetry.UnitDimensionless)
+	h.metrics.ResourcePoolMemUnreserved = telemetry.Int64(
 		"cds/hatchery/vsphere/resource_pool_memory_unreserved_bytes",
-		"Resource Pool memory unreserved for VMs in bytes", stats.UnitDimensionless)
+		"Resource Pool memory unreserved for VMs in bytes", telemetry.UnitDimensionless)
 
 	// IP address tracking
-	h.metrics.IPActiveCount = stats.Int64(
+	h.metrics.IPActiveCount = telemetry.Int64(
 		"cds/hatchery/vsphere/ip_active_count",
-		"Number of IP addresses active on running VMs", stats.UnitDimensionless)
-	h.metrics.IPReservedCount = stats.Int64(
+		"Number of IP addresses active on running VMs", telemetry.UnitDimensionless)
+	h.metrics.IPReservedCount = telemetry.Int64(
 		"cds/hatchery/vsphere/ip_reserved_count",
-		"Number of IP addresses held by VMs (any power state)", stats.UnitDimensionless)
-	h.metrics.IPTotalCount = stats.Int64(
+		"Number of IP addresses held by VMs (any power state)", telemetry.UnitDimensionless)
+	h.metrics.IPTotalCount = telemetry.Int64(
 		"cds/hatchery/vsphere/ip_total_count",
-		"Total number of IP addresses in configured range", stats.UnitDimensionless)
+		"Total number of IP addresses in configured range", telemetry.UnitDimensionless)
 
 	// Build views
-	baseTags := []tag.Key{
+	baseTags := []telemetry.TagKey{
 		telemetry.MustNewKey(telemetry.TagServiceName),
 		telemetry.MustNewKey(telemetry.TagServiceType),
 	}
-	workerTags := []tag.Key{
+	workerTags := []telemetry.TagKey{
 		telemetry.MustNewKey(telemetry.TagServiceName),
 		telemetry.MustNewKey(telemetry.TagServiceType),
 		telemetry.MustNewKey("worker_name"),
 		telemetry.MustNewKey("worker_model"),
 	}
 
-	h.metrics.workerViews = []*view.View{
+	h.metrics.workerViews = []*telemetry.View{
 		telemetry.NewViewLast("cds/hatchery/vsphere/worker_vcpus", h.metrics.WorkerVCPUs, workerTags),
 		telemetry.NewViewLast("cds/hatchery/vsphere/worker_memory_mb", h.metrics.WorkerMemoryMB, workerTags),
 		telemetry.NewViewLast("cds/hatchery/vsphere/worker_disk_gb", h.metrics.WorkerDiskGB, workerTags),
 	}
 
-	h.metrics.aggregateViews = []*view.View{
+	h.metrics.aggregateViews = []*telemetry.View{
 		// Level 2: Hatchery aggregate
 		telemetry.NewViewLast("cds/hatchery/vsphere/allocated_vcpus", h.metrics.AllocatedVCPUs, baseTags),
 		telemetry.NewViewLast("cds/hatchery/vsphere/allocated_memory_mb", h.metrics.AllocatedMemoryMB, baseTags),
@@ -212,8 +209,8 @@ func (h *HatcheryVSphere) initVSphereMetricsMeasures() {
 	}
 }
 
-func (h *HatcheryVSphere) allViews() []*view.View {
-	var views []*view.View
+func (h *HatcheryVSphere) allViews() []*telemetry.View {
+	var views []*telemetry.View
 	views = append(views, h.metrics.workerViews...)
 	views = append(views, h.metrics.aggregateViews...)
 	return views
@@ -255,8 +252,8 @@ func (h *HatcheryVSphere) collectVSphereMetrics(ctx context.Context) {
 	var poolCPUs, poolMemMB, poolVMCount int64
 
 	// Re-register per-worker views to drop stale workers
-	view.Unregister(h.metrics.workerViews...)
-	if err := view.Register(h.metrics.workerViews...); err != nil {
+	telemetry.Unregister(h.metrics.workerViews...)
+	if err := telemetry.Register(h.metrics.workerViews...); err != nil {
 		log.Warn(ctx, "collectVSphereMetrics> unable to re-register worker views: %v", err)
 	}
 
@@ -311,7 +308,7 @@ func (h *HatcheryVSphere) collectVSphereMetrics(ctx context.Context) {
 		// Level 1: Per-worker metrics
 		wCtx := telemetry.ContextWithTag(ctx, "worker_name", s.Name)
 		wCtx = telemetry.ContextWithTag(wCtx, "worker_model", annot.WorkerModelPath)
-		stats.Record(wCtx,
+		telemetry.RecordMeasurements(wCtx,
 			h.metrics.WorkerVCPUs.M(cpus),
 			h.metrics.WorkerMemoryMB.M(memMB),
 			h.metrics.WorkerDiskGB.M(diskGB),
@@ -330,7 +327,7 @@ func (h *HatcheryVSphere) collectVSphereMetrics(ctx context.Context) {
 	h.cacheProvisioning.mu.Unlock()
 
 	// Level 2: Hatchery aggregate
-	stats.Record(ctx,
+	telemetry.RecordMeasurements(ctx,
 		h.metrics.AllocatedVCPUs.M(hatcheryCPUs),
 		h.metrics.AllocatedMemoryMB.M(hatcheryMemMB),
 		h.metrics.AllocatedDiskGB.M(hatcheryDiskGB),
@@ -347,7 +344,7 @@ func (h *HatcheryVSphere) collectVSphereMetrics(ctx context.Context) {
 	)
 
 	// Level 3: Global pool (all VMs in datacenter)
-	stats.Record(ctx,
+	telemetry.RecordMeasurements(ctx,
 		h.metrics.PoolTotalVCPUs.M(poolCPUs),
 		h.metrics.PoolTotalMemoryMB.M(poolMemMB),
 		h.metrics.PoolTotalVMCount.M(poolVMCount),
@@ -381,7 +378,7 @@ func (h *HatcheryVSphere) collectVSphereMetrics(ctx context.Context) {
 				ipActive++
 			}
 		}
-		stats.Record(ctx,
+		telemetry.RecordMeasurements(ctx,
 			h.metrics.IPActiveCount.M(ipActive),
 			h.metrics.IPReservedCount.M(ipReserved),
 			h.metrics.IPTotalCount.M(int64(len(h.availableIPAddresses))),
@@ -401,7 +398,7 @@ func (h *HatcheryVSphere) collectVSphereMetrics(ctx context.Context) {
 		return
 	}
 
-	stats.Record(ctx,
+	telemetry.RecordMeasurements(ctx,
 		h.metrics.ResourcePoolCPUMax.M(poolMo.Runtime.Cpu.MaxUsage),
 		h.metrics.ResourcePoolCPUUsage.M(poolMo.Runtime.Cpu.OverallUsage),
 		h.metrics.ResourcePoolCPUUnreserved.M(poolMo.Runtime.Cpu.UnreservedForVm),
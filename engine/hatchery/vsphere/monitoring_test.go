@@ -11,8 +11,9 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
-	"go.opencensus.io/stats/view"
 	"go.uber.org/mock/gomock"
+
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 func TestCollectVSphereMetrics(t *testing.T) {
@@ -30,9 +31,9 @@ func TestCollectVSphereMetrics(t *testing.T) {
 	// Init metrics measures and views
 	h.initVSphereMetricsMeasures()
 	// Register views directly (no telemetry exporter needed in tests)
-	require.NoError(t, view.Register(h.allViews()...))
+	require.NoError(t, telemetry.Register(h.allViews()...))
 	t.Cleanup(func() {
-		view.Unregister(h.allViews()...)
+		telemetry.Unregister(h.allViews()...)
 	})
 
 	workerAnnot, _ := json.Marshal(annotation{
@@ -197,7 +198,7 @@ func TestCollectVSphereMetrics(t *testing.T) {
 
 func assertLastMetricValue(t *testing.T, metricName string, expected int64) {
 	t.Helper()
-	rows, err := view.RetrieveData(metricName)
+	rows, err := telemetry.RetrieveData(metricName)
 	if err != nil {
 		t.Errorf("failed to retrieve metric %q: %v", metricName, err)
 		return
@@ -206,10 +207,9 @@ func assertLastMetricValue(t *testing.T, metricName string, expected int64) {
 		t.Errorf("no data for metric %q", metricName)
 		return
 	}
-	lastValue, ok := rows[len(rows)-1].Data.(*view.LastValueData)
-	if !ok {
+	if v := telemetry.Find(metricName); v == nil || v.Aggregation.Type != telemetry.AggTypeLastValue {
 		t.Errorf("metric %q is not a LastValue gauge", metricName)
 		return
 	}
-	assert.Equal(t, float64(expected), lastValue.Value, "metric %s", metricName)
+	assert.Equal(t, float64(expected), rows[len(rows)-1].Last, "metric %s", metricName)
 }
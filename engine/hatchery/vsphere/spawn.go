@@ -277,6 +277,7 @@ func (h *HatcheryVSphere) checkVirtualMachineIsReady(ctx context.Context, model
 // one (setupGuestInfoBootstrap), so both hand the guest the exact same command.
 func (h *HatcheryVSphere) buildWorkerBootstrap(ctx context.Context, spawnArgs hatchery.SpawnArguments) (string, workerruntime.WorkerConfig, error) {
 	workerConfig := h.GenerateWorkerConfig(ctx, h, spawnArgs)
+	workerConfig.JobServices = h.Config.JobServices.WorkerConfig()
 
 	udata := spawnArgs.Model.GetPreCmd() + "\n" + spawnArgs.Model.GetCmd()
 
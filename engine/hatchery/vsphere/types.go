@@ -47,6 +47,8 @@ type HatcheryConfiguration struct {
 	// default: no inbound access at all, access being debug-only.
 	SSHAllowedCIDRs     []string `mapstructure:"sshAllowedCIDRs" toml:"sshAllowedCIDRs" default:"" commented:"true" comment:"Optional, guestinfo models only. CIDRs allowed to reach spawned workers over SSH, applied as a packet-filter allowlist in the guest. Empty means no inbound access." json:"sshAllowedCIDRs,omitempty"`
 	InjectSSHPublicKeys []string `mapstructure:"injectSSHPublicKeys" toml:"injectSSHPublicKeys" default:"" commented:"true" comment:"Optional, guestinfo models only. List of SSH public keys to inject into spawned workers. Each key must carry a from= option restricting the source addresses." json:"injectSSHPublicKeys,omitempty"`
+	// JobServices lets the worker start the services declared on v2 jobs with a runtime installed on the VM
+	JobServices service.HatcheryJobServicesConfiguration `mapstructure:"jobServices" toml:"jobServices" comment:"Optional. Job services started by the worker" json:"jobServices"`
 }
 
 // NetworkConfig defines a network with an IP range, gateway and subnet mask.
@@ -5,12 +5,11 @@ import (
 
 	"time"
 
-	"go.opencensus.io/trace"
-
 	"github.com/ovh/cds/sdk"
 	cdslog "github.com/ovh/cds/sdk/log"
 	"github.com/ovh/cds/sdk/telemetry"
 	"github.com/rockbears/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Get from queue task execution
@@ -49,7 +48,7 @@ func (s *Service) dequeueRepositoryEvent(ctx context.Context) {
 			continue
 		}
 		log.Info(ctx, "dequeueRepositoryEvent> work on event: %s", eventKey)
-		ctx := telemetry.New(ctx, s, "hooks.dequeueRepositoryEvent", nil, trace.SpanKindUnspecified)
+		ctx := telemetry.New(ctx, s, "hooks.dequeueRepositoryEvent", trace.SpanKindUnspecified)
 		if err := s.manageRepositoryEvent(ctx, eventKey); err != nil {
 			log.ErrorWithStackTrace(ctx, err)
 			continue
@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/telemetry"
@@ -40,7 +40,7 @@ func (s *Service) dequeueRepositoryEventCallback(ctx context.Context) {
 			continue
 		}
 		log.Info(ctx, "dequeueRepositoryEventCallback> work on event: %s", callback.HookEventUUID)
-		ctx := telemetry.New(ctx, s, "hooks.dequeueRepositoryEventCallback", nil, trace.SpanKindUnspecified)
+		ctx := telemetry.New(ctx, s, "hooks.dequeueRepositoryEventCallback", trace.SpanKindUnspecified)
 		telemetry.Current(ctx,
 			telemetry.Tag(telemetry.TagVCSServer, callback.VCSServerName),
 			telemetry.Tag(telemetry.TagRepository, callback.RepositoryName),
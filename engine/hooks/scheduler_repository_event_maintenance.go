@@ -4,10 +4,10 @@ import (
 	"context"
 	"time"
 
-	"go.opencensus.io/trace"
+	"github.com/rockbears/log"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/sdk/telemetry"
-	"github.com/rockbears/log"
 )
 
 // dequeueMaintenanceRepositoryEvent consumes events from the maintenance queue.
@@ -35,7 +35,7 @@ func (s *Service) dequeueMaintenanceRepositoryEvent(ctx context.Context) {
 			continue
 		}
 		log.Info(ctx, "dequeueMaintenanceRepositoryEvent> work on event: %s", eventKey)
-		ctx := telemetry.New(ctx, s, "hooks.dequeueMaintenanceRepositoryEvent", nil, trace.SpanKindUnspecified)
+		ctx := telemetry.New(ctx, s, "hooks.dequeueMaintenanceRepositoryEvent", trace.SpanKindUnspecified)
 		if err := s.manageRepositoryEvent(ctx, eventKey); err != nil {
 			log.ErrorWithStackTrace(ctx, err)
 			continue
@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/telemetry"
@@ -35,7 +35,7 @@ func (s *Service) manageOldRepositoryEvent(ctx context.Context) {
 				continue
 			}
 			for _, k := range repositoryEventKeys {
-				ctx := telemetry.New(ctx, s, "hooks.manageOldRepositoryEvent", nil, trace.SpanKindUnspecified)
+				ctx := telemetry.New(ctx, s, "hooks.manageOldRepositoryEvent", trace.SpanKindUnspecified)
 				if err := s.checkInProgressEvent(ctx, k); err != nil {
 					log.ErrorWithStackTrace(ctx, err)
 					continue
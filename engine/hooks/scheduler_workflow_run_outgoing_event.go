@@ -7,12 +7,11 @@ import (
 
 	"time"
 
-	"go.opencensus.io/trace"
-
 	"github.com/ovh/cds/sdk"
 	cdslog "github.com/ovh/cds/sdk/log"
 	"github.com/ovh/cds/sdk/telemetry"
 	"github.com/rockbears/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (s *Service) dequeueWorkflowRunOutgoingEvent(ctx context.Context) {
@@ -50,7 +49,7 @@ func (s *Service) dequeueWorkflowRunOutgoingEvent(ctx context.Context) {
 			continue
 		}
 		log.Info(ctx, "dequeueRepositoryOutgoingEvent> work on event: %s", eventKey)
-		ctx := telemetry.New(ctx, s, "hooks.dequeueRepositoryOutgoingEvent", nil, trace.SpanKindUnspecified)
+		ctx := telemetry.New(ctx, s, "hooks.dequeueRepositoryOutgoingEvent", trace.SpanKindUnspecified)
 		if err := s.manageWorkflowRunOutgoingEvent(ctx, eventKey); err != nil {
 			log.ErrorWithStackTrace(ctx, err)
 			continue
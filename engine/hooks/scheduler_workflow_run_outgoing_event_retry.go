@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/sdk"
 	cdslog "github.com/ovh/cds/sdk/log"
@@ -36,7 +36,7 @@ func (s *Service) manageOldWorkflowRunOutgoingEvent(ctx context.Context) {
 				continue
 			}
 			for _, k := range routgoingEventKeys {
-				ctx := telemetry.New(ctx, s, "hooks.manageOldWorkflowRunOutgoingEvent", nil, trace.SpanKindUnspecified)
+				ctx := telemetry.New(ctx, s, "hooks.manageOldWorkflowRunOutgoingEvent", trace.SpanKindUnspecified)
 				if err := s.checkInProgressOutgoingEvent(ctx, k); err != nil {
 					log.ErrorWithStackTrace(ctx, err)
 					continue
@@ -157,6 +157,9 @@ func (s *Service) manageRepositoryOperationCallback(ctx context.Context, ope sdk
 	if hre.ExtractData.CommitAuthorEmail == "" {
 		hre.ExtractData.CommitAuthorEmail = ope.Setup.Checkout.Result.AuthorEmail
 	}
+	if hre.ExtractData.CommitDate == "" {
+		hre.ExtractData.CommitDate = ope.Setup.Checkout.Result.CommitDate
+	}
 
 	// Update repository hook status
 	for i := range hre.WorkflowHooks {
@@ -172,6 +172,7 @@ func (s *Service) triggerWorkflows(ctx context.Context, hre *sdk.HookRepositoryE
 					CommitMessage:      hre.ExtractData.CommitMessage,
 					CommitAuthor:       hre.ExtractData.CommitAuthor,
 					CommitAuthorEmail:  hre.ExtractData.CommitAuthorEmail,
+					CommitDate:         hre.ExtractData.CommitDate,
 					Payload:            event,
 					EventName:          hre.EventName,
 					HookType:           wh.Type,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/fsamin/go-repo"
 	"github.com/pkg/errors"
@@ -77,6 +78,9 @@ func (s *Service) processCheckout(ctx context.Context, op *sdk.Operation) error
 		op.Setup.Checkout.Result.CommitMessage = currentCommit.Subject
 		op.Setup.Checkout.Result.Author = currentCommit.Author
 		op.Setup.Checkout.Result.AuthorEmail = currentCommit.AuthorEmail
+		if !currentCommit.Date.IsZero() {
+			op.Setup.Checkout.Result.CommitDate = currentCommit.Date.UTC().Format(time.RFC3339)
+		}
 	}
 
 	if op.Setup.Checkout.ProcessSemver {
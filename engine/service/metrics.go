@@ -12,10 +12,6 @@ import (
 	"sync"
 	"time"
 
-	"go.opencensus.io/stats"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
-
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/telemetry"
 	"github.com/rockbears/log"
@@ -99,64 +95,64 @@ var onceMetrics sync.Once
 
 func (c *Common) RegisterCommonMetricsView(ctx context.Context) {
 	onceMetrics.Do(func() {
-		allocStats := stats.Int64(
+		allocStats := telemetry.Int64(
 			"cds/alloc",
 			"Alloc is bytes of allocated heap objects",
-			stats.UnitBytes,
+			telemetry.UnitBytes,
 		)
 
-		totalAllocStats := stats.Int64(
+		totalAllocStats := telemetry.Int64(
 			fmt.Sprintf("cds/total_alloc"),
 			"Total Alloc is cumulative bytes allocated for heap objects",
-			stats.UnitBytes,
+			telemetry.UnitBytes,
 		)
 
-		sysStats := stats.Int64(
+		sysStats := telemetry.Int64(
 			fmt.Sprintf("cds/sys"),
 			"Sys is the total bytes of memory obtained from the OS",
-			stats.UnitBytes,
+			telemetry.UnitBytes,
 		)
 
-		gcStats := stats.Int64(
+		gcStats := telemetry.Int64(
 			fmt.Sprintf("cds/num_gc"),
 			"NumGC is the number of completed GC cycles",
-			stats.UnitDimensionless,
+			telemetry.UnitDimensionless,
 		)
 
 		tagServiceType := telemetry.MustNewKey(telemetry.TagServiceType)
 		tagServiceName := telemetry.MustNewKey(telemetry.TagServiceName)
 		tagHostname := telemetry.MustNewKey(telemetry.TagHostname)
 
-		allocView := view.View{
+		allocView := telemetry.View{
 			Name:        "cds/mem/alloc",
 			Description: allocStats.Description(),
 			Measure:     allocStats,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, tagHostname},
-			Aggregation: view.LastValue(),
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, tagHostname},
+			Aggregation: telemetry.LastValue(),
 		}
 
-		totalAllocView := view.View{
+		totalAllocView := telemetry.View{
 			Name:        "cds/mem/total_alloc",
 			Description: totalAllocStats.Description(),
 			Measure:     totalAllocStats,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, tagHostname},
-			Aggregation: view.LastValue(),
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, tagHostname},
+			Aggregation: telemetry.LastValue(),
 		}
 
-		sysView := view.View{
+		sysView := telemetry.View{
 			Name:        "cds/mem/sys",
 			Description: sysStats.Description(),
 			Measure:     sysStats,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, tagHostname},
-			Aggregation: view.LastValue(),
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, tagHostname},
+			Aggregation: telemetry.LastValue(),
 		}
 
-		gcView := view.View{
+		gcView := telemetry.View{
 			Name:        "cds/mem/gc",
 			Description: gcStats.Description(),
 			Measure:     gcStats,
-			TagKeys:     []tag.Key{tagServiceType, tagServiceName, tagHostname},
-			Aggregation: view.LastValue(),
+			TagKeys:     []telemetry.TagKey{tagServiceType, tagServiceName, tagHostname},
+			Aggregation: telemetry.LastValue(),
 		}
 
 		if err := telemetry.RegisterView(ctx, &allocView, &totalAllocView, &sysView, &gcView); err != nil {
@@ -183,10 +179,10 @@ func (c *Common) RegisterCommonMetricsView(ctx context.Context) {
 				case <-tick.C:
 					var m runtime.MemStats
 					runtime.ReadMemStats(&m)
-					stats.Record(ctx, allocStats.M(int64(m.Alloc)))
-					stats.Record(ctx, totalAllocStats.M(int64(m.TotalAlloc)))
-					stats.Record(ctx, sysStats.M(int64(m.Sys)))
-					stats.Record(ctx, gcStats.M(int64(m.NumGC)))
+					telemetry.Record(ctx, allocStats, int64(m.Alloc))
+					telemetry.Record(ctx, totalAllocStats, int64(m.TotalAlloc))
+					telemetry.Record(ctx, sysStats, int64(m.Sys))
+					telemetry.Record(ctx, gcStats, int64(m.NumGC))
 
 					if maxMemory > 0 && m.Alloc >= maxMemory {
 						onceMaxMemorySignal.Do(func() {
@@ -236,10 +232,19 @@ func GetMetricHandler(prefix string) func() Handler {
 			log.Debug(ctx, "GetMetricHandler> path: %s - tags: %v", view, tags)
 
 			if view == "" {
-				return writeJSON(w, exporter, http.StatusOK)
+				views, err := exporter.Views(ctx)
+				if err != nil {
+					return err
+				}
+				return writeJSON(w, struct {
+					Views []telemetry.HTTPExporterView
+				}{Views: views}, http.StatusOK)
 			}
 
-			metricsView := exporter.GetView(view, tags)
+			metricsView, err := exporter.GetView(ctx, view, tags)
+			if err != nil {
+				return err
+			}
 			if metricsView == nil {
 				return sdk.WithStack(sdk.ErrNotFound)
 			}
@@ -28,7 +28,7 @@ func DefaultHeaders() map[string]string {
 	now := time.Now()
 	return map[string]string{
 		"Access-Control-Allow-Origin":              "*",
-		"Access-Control-Allow-Methods":             "GET,OPTIONS,PUT,POST,DELETE",
+		"Access-Control-Allow-Methods":             "GET,OPTIONS,PUT,PATCH,POST,DELETE",
 		"Access-Control-Allow-Headers":             "Accept, Origin, Referer, User-Agent, Content-Type, Authorization, Session-Token, Last-Event-Id, If-Modified-Since, Content-Disposition, " + strings.Join(headers, ", "),
 		"Access-Control-Expose-Headers":            "Accept, Origin, Referer, User-Agent, Content-Type, Authorization, Session-Token, Last-Event-Id, ETag, Content-Disposition, " + strings.Join(headers, ", "),
 		cdsclient.ResponseAPINanosecondsTimeHeader: fmt.Sprintf("%d", now.UnixNano()),
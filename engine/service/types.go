@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/square/go-jose.v2"
 
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 )
@@ -128,6 +129,37 @@ func (hcc HatcheryCommonConfiguration) Check() error {
 	return nil
 }
 
+// HatcheryJobServicesConfiguration is used by hatcheries that cannot start job services next to the worker.
+// In that case the worker starts the services declared on the job itself.
+type HatcheryJobServicesConfiguration struct {
+	Runtime  string            `mapstructure:"runtime" toml:"runtime" default:"" commented:"true" comment:"Runtime used by the worker to start job services: docker, podman or binary.\nThe runtime has to be installed on the worker model. Let empty to disable job services on this hatchery." json:"runtime,omitempty"`
+	Binaries map[string]string `mapstructure:"binaries" toml:"binaries" commented:"true" comment:"Command started by the worker for each service image with the binary runtime.\nExample: \"postgres:14\" = \"/usr/lib/postgresql/14/bin/postgres -D /tmp/pgdata\"" json:"binaries,omitempty"`
+}
+
+func (c HatcheryJobServicesConfiguration) Check() error {
+	if c.Runtime == "" {
+		return nil
+	}
+	if !workerruntime.IsValidJobServicesRuntime(c.Runtime) {
+		return fmt.Errorf("invalid job services runtime %q", c.Runtime)
+	}
+	if c.Runtime == workerruntime.JobServicesRuntimeBinary && len(c.Binaries) == 0 {
+		return fmt.Errorf("job services binaries are mandatory with the binary runtime")
+	}
+	return nil
+}
+
+// WorkerConfig returns the job services configuration given to the worker, nil if job services are disabled
+func (c HatcheryJobServicesConfiguration) WorkerConfig() *workerruntime.JobServicesConfig {
+	if c.Runtime == "" {
+		return nil
+	}
+	return &workerruntime.JobServicesConfig{
+		Runtime:  c.Runtime,
+		Binaries: c.Binaries,
+	}
+}
+
 // Common is the struct representing a CDS µService
 type Common struct {
 	Client                cdsclient.Interface
@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE v2_workflow_run_job_usage (
+    "run_job_id"      uuid PRIMARY KEY,
+    "workflow_run_id" uuid NOT NULL,
+    "project_key"     VARCHAR(255) NOT NULL,
+    "vcs_server"      VARCHAR(256) NOT NULL DEFAULT '',
+    "repository"      VARCHAR(256) NOT NULL DEFAULT '',
+    "workflow_name"   VARCHAR(256) NOT NULL DEFAULT '',
+    "job_id"          VARCHAR(256) NOT NULL DEFAULT '',
+    "region"          VARCHAR(256) NOT NULL DEFAULT '',
+    "hatchery_name"   VARCHAR(256) NOT NULL DEFAULT '',
+    "worker_model"    TEXT NOT NULL DEFAULT '',
+    "model_type"      VARCHAR(50) NOT NULL DEFAULT '',
+    "flavor"          VARCHAR(256) NOT NULL DEFAULT '',
+    "started"         TIMESTAMP WITH TIME ZONE NOT NULL,
+    "ended"           TIMESTAMP WITH TIME ZONE
+);
+SELECT create_index('v2_workflow_run_job_usage', 'IDX_V2_WORKFLOW_RUN_JOB_USAGE_PROJECT_STARTED', 'project_key,started');
+
+CREATE TABLE project_quota (
+    "project_key"         VARCHAR(255) PRIMARY KEY,
+    "max_concurrent_jobs" BIGINT NOT NULL DEFAULT 0,
+    "max_monthly_minutes" BIGINT NOT NULL DEFAULT 0,
+    "last_modified"       TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_QUOTA_PROJECT', 'project_quota', 'project', 'project_key', 'projectkey');
+
+-- +migrate Down
+DROP TABLE v2_workflow_run_job_usage;
+DROP TABLE project_quota;
@@ -0,0 +1,43 @@
+-- +migrate Up
+CREATE TABLE v2_workflow_test_case (
+    "id"              BIGSERIAL PRIMARY KEY,
+    "project_key"     VARCHAR(255) NOT NULL,
+    "vcs_server"      VARCHAR(256) NOT NULL,
+    "repository"      VARCHAR(256) NOT NULL,
+    "workflow_name"   VARCHAR(256) NOT NULL,
+    "ref"             VARCHAR(256) NOT NULL DEFAULT '',
+    "sha"             VARCHAR(256) NOT NULL DEFAULT '',
+    "workflow_run_id" uuid NOT NULL,
+    "run_number"      BIGINT NOT NULL DEFAULT 0,
+    "run_attempt"     BIGINT NOT NULL DEFAULT 0,
+    "run_job_id"      uuid NOT NULL,
+    "job_id"          VARCHAR(256) NOT NULL DEFAULT '',
+    "suite"           TEXT NOT NULL DEFAULT '',
+    "name"            TEXT NOT NULL,
+    "status"          VARCHAR(50) NOT NULL,
+    "duration"        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "message"         TEXT NOT NULL DEFAULT '',
+    "created"         TIMESTAMP WITH TIME ZONE NOT NULL
+);
+SELECT create_foreign_key_idx_cascade('FK_V2_WORKFLOW_TEST_CASE_RUN', 'v2_workflow_test_case', 'v2_workflow_run', 'workflow_run_id', 'id');
+SELECT create_index('v2_workflow_test_case', 'IDX_V2_WORKFLOW_TEST_CASE_WORKFLOW', 'project_key,vcs_server,repository,workflow_name,created');
+SELECT create_index('v2_workflow_test_case', 'IDX_V2_WORKFLOW_TEST_CASE_TEST', 'project_key,vcs_server,repository,workflow_name,suite,name');
+
+CREATE TABLE v2_workflow_test_quarantine (
+    "id"            uuid PRIMARY KEY,
+    "project_key"   VARCHAR(255) NOT NULL,
+    "vcs_server"    VARCHAR(256) NOT NULL,
+    "repository"    VARCHAR(256) NOT NULL,
+    "workflow_name" VARCHAR(256) NOT NULL,
+    "suite"         TEXT NOT NULL DEFAULT '',
+    "name"          TEXT NOT NULL,
+    "reason"        TEXT NOT NULL DEFAULT '',
+    "author"        VARCHAR(256) NOT NULL DEFAULT '',
+    "created"       TIMESTAMP WITH TIME ZONE NOT NULL
+);
+SELECT create_foreign_key_idx_cascade('FK_V2_WORKFLOW_TEST_QUARANTINE_PROJECT', 'v2_workflow_test_quarantine', 'project', 'project_key', 'projectkey');
+SELECT create_unique_index('v2_workflow_test_quarantine', 'IDX_V2_WORKFLOW_TEST_QUARANTINE_UNIQ', 'project_key,vcs_server,repository,workflow_name,suite,name');
+
+-- +migrate Down
+DROP TABLE v2_workflow_test_case;
+DROP TABLE v2_workflow_test_quarantine;
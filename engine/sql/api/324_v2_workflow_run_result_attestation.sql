@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX idx_v2_workflow_run_result_attestation_subjects ON v2_workflow_run_result USING GIN ((artifact_manager_detail -> 'data' -> 'subjects')) WHERE type = 'attestation';
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_v2_workflow_run_result_attestation_subjects;
@@ -0,0 +1,45 @@
+-- +migrate Up
+CREATE TABLE project_environment
+(
+    "id"              uuid PRIMARY KEY,
+    "project_key"     VARCHAR(255) NOT NULL,
+    "name"            VARCHAR(255) NOT NULL,
+    "description"     TEXT NOT NULL DEFAULT '',
+    "reviewer_users"  JSONB,
+    "reviewer_groups" JSONB,
+    "allowed_refs"    JSONB,
+    "wait_timer"      BIGINT NOT NULL DEFAULT 0,
+    "variable_set"    VARCHAR(255) NOT NULL DEFAULT '',
+    "last_modified"   TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_project_environment_project', 'project_environment', 'project', 'project_key', 'projectkey');
+SELECT create_unique_index('project_environment', 'idx_unq_project_environment', 'project_key,name');
+
+CREATE TABLE v2_environment_deployment
+(
+    "id"                  uuid PRIMARY KEY,
+    "project_key"         VARCHAR(255) NOT NULL,
+    "environment"         VARCHAR(255) NOT NULL,
+    "workflow_run_id"     uuid NOT NULL,
+    "workflow_run_job_id" uuid NOT NULL,
+    "vcs_server"          VARCHAR(255) NOT NULL,
+    "repository"          VARCHAR(255) NOT NULL,
+    "workflow_name"       VARCHAR(255) NOT NULL,
+    "run_number"          BIGINT NOT NULL,
+    "run_attempt"         BIGINT NOT NULL,
+    "job_id"              VARCHAR(255) NOT NULL,
+    "status"              VARCHAR(100) NOT NULL,
+    "version"             VARCHAR(255) NOT NULL DEFAULT '',
+    "git_ref"             TEXT NOT NULL DEFAULT '',
+    "git_sha"             VARCHAR(255) NOT NULL DEFAULT '',
+    "username"            VARCHAR(255) NOT NULL DEFAULT '',
+    "created"             TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    "last_modified"       TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_v2_environment_deployment_project', 'v2_environment_deployment', 'project', 'project_key', 'projectkey');
+SELECT create_unique_index('v2_environment_deployment', 'idx_unq_v2_environment_deployment_run_job', 'workflow_run_job_id');
+SELECT create_index('v2_environment_deployment', 'idx_v2_environment_deployment_environment', 'project_key,environment,created');
+
+-- +migrate Down
+DROP TABLE v2_environment_deployment;
+DROP TABLE project_environment;
@@ -0,0 +1,41 @@
+-- +migrate Up
+CREATE TABLE project_freeze
+(
+    "id"            uuid PRIMARY KEY,
+    "project_key"   VARCHAR(255) NOT NULL,
+    "name"          VARCHAR(255) NOT NULL,
+    "description"   TEXT NOT NULL DEFAULT '',
+    "environments"  JSONB,
+    "workflows"     JSONB,
+    "start_date"    TIMESTAMP WITH TIME ZONE,
+    "end_date"      TIMESTAMP WITH TIME ZONE,
+    "cron"          VARCHAR(255) NOT NULL DEFAULT '',
+    "duration"      BIGINT NOT NULL DEFAULT 0,
+    "timezone"      VARCHAR(255) NOT NULL DEFAULT '',
+    "last_modified" TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_project_freeze_project', 'project_freeze', 'project', 'project_key', 'projectkey');
+SELECT create_unique_index('project_freeze', 'idx_unq_project_freeze', 'project_key,name');
+
+CREATE TABLE project_freeze_override
+(
+    "id"              uuid PRIMARY KEY,
+    "project_key"     VARCHAR(255) NOT NULL,
+    "freeze"          VARCHAR(255) NOT NULL,
+    "workflow_run_id" uuid NOT NULL,
+    "vcs_server"      VARCHAR(255) NOT NULL,
+    "repository"      VARCHAR(255) NOT NULL,
+    "workflow_name"   VARCHAR(255) NOT NULL,
+    "run_number"      BIGINT NOT NULL,
+    "user_id"         VARCHAR(255) NOT NULL,
+    "username"        VARCHAR(255) NOT NULL,
+    "reason"          TEXT NOT NULL,
+    "created"         TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_project_freeze_override_project', 'project_freeze_override', 'project', 'project_key', 'projectkey');
+SELECT create_unique_index('project_freeze_override', 'idx_unq_project_freeze_override_run', 'workflow_run_id,freeze');
+SELECT create_index('project_freeze_override', 'idx_project_freeze_override_freeze', 'project_key,freeze,created');
+
+-- +migrate Down
+DROP TABLE project_freeze_override;
+DROP TABLE project_freeze;
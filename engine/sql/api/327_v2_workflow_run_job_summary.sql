@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE v2_workflow_run_job_summary
+(
+    "id"                  uuid PRIMARY KEY,
+    "workflow_run_id"     uuid NOT NULL,
+    "workflow_run_job_id" uuid NOT NULL,
+    "job_id"              VARCHAR(255) NOT NULL,
+    "step_name"           VARCHAR(255) NOT NULL,
+    "run_attempt"         BIGINT NOT NULL DEFAULT 0,
+    "summary"             TEXT NOT NULL DEFAULT '',
+    "annotations"         JSONB,
+    "created"             TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_run_job_summary_run', 'v2_workflow_run_job_summary', 'v2_workflow_run', 'workflow_run_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_run_job_summary_job', 'v2_workflow_run_job_summary', 'v2_workflow_run_job', 'workflow_run_job_id', 'id');
+
+-- +migrate Down
+DROP TABLE v2_workflow_run_job_summary;
@@ -0,0 +1,34 @@
+-- +migrate Up
+ALTER TABLE rbac ADD COLUMN "expire_at" TIMESTAMP WITH TIME ZONE;
+SELECT create_index('rbac', 'idx_rbac_expire_at', 'expire_at');
+
+CREATE TABLE rbac_grant_request
+(
+    "id"             uuid PRIMARY KEY,
+    "user_id"        VARCHAR(36) NOT NULL,
+    "username"       VARCHAR(255) NOT NULL,
+    "organization"   VARCHAR(255) NOT NULL DEFAULT '',
+    "role"           VARCHAR(255) NOT NULL,
+    "project_key"    VARCHAR(255) NOT NULL DEFAULT '',
+    "vcs_server"     VARCHAR(255) NOT NULL DEFAULT '',
+    "repository"     VARCHAR(255) NOT NULL DEFAULT '',
+    "workflow"       VARCHAR(255) NOT NULL DEFAULT '',
+    "variableset"    VARCHAR(255) NOT NULL DEFAULT '',
+    "region"         VARCHAR(255) NOT NULL DEFAULT '',
+    "justification"  TEXT NOT NULL,
+    "duration"       BIGINT NOT NULL,
+    "status"         VARCHAR(50) NOT NULL,
+    "approvers"      JSONB,
+    "reviewer"       VARCHAR(255) NOT NULL DEFAULT '',
+    "review_comment" TEXT NOT NULL DEFAULT '',
+    "rbac_name"      VARCHAR(255) NOT NULL DEFAULT '',
+    "created"        TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    "reviewed"       TIMESTAMP WITH TIME ZONE,
+    "expire_at"      TIMESTAMP WITH TIME ZONE
+);
+SELECT create_foreign_key_idx_cascade('FK_rbac_grant_request_user', 'rbac_grant_request', 'authentified_user', 'user_id', 'id');
+SELECT create_index('rbac_grant_request', 'idx_rbac_grant_request_status', 'status,expire_at');
+
+-- +migrate Down
+DROP TABLE rbac_grant_request;
+ALTER TABLE rbac DROP COLUMN "expire_at";
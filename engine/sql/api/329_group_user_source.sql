@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "group_authentified_user" ADD COLUMN IF NOT EXISTS "source" VARCHAR(50) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "group_authentified_user" DROP COLUMN IF EXISTS "source";
@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE rbac ADD COLUMN "ascode_project_key" VARCHAR(255) NOT NULL DEFAULT '';
+SELECT create_index('rbac', 'idx_rbac_ascode_project_key', 'ascode_project_key');
+
+CREATE TABLE project_rbac_ascode (
+    "project_key"           VARCHAR(255) PRIMARY KEY,
+    "vcs_project_id"        uuid NOT NULL,
+    "project_repository_id" uuid NOT NULL,
+    "created"               TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    "created_by"            VARCHAR(255) NOT NULL DEFAULT '',
+    "last_commit"           VARCHAR(255) NOT NULL DEFAULT '',
+    "last_applied"          TIMESTAMP WITH TIME ZONE
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_RBAC_ASCODE_PROJECT', 'project_rbac_ascode', 'project', 'project_key', 'projectkey');
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_RBAC_ASCODE_REPOSITORY', 'project_rbac_ascode', 'project_repository', 'project_repository_id', 'id');
+
+-- +migrate Down
+DROP TABLE project_rbac_ascode;
+ALTER TABLE rbac DROP COLUMN "ascode_project_key";
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE v2_workflow_run_job_debug_session (
+  "id"                  uuid PRIMARY KEY,
+  "workflow_run_id"     uuid NOT NULL,
+  "workflow_run_job_id" uuid NOT NULL,
+  "project_key"         VARCHAR(255) NOT NULL,
+  "user_id"             VARCHAR(36) NOT NULL,
+  "username"            VARCHAR(255) NOT NULL,
+  "started"             TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  "ended"               TIMESTAMP WITH TIME ZONE,
+  "input"               TEXT
+);
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_run_job_debug_session_run', 'v2_workflow_run_job_debug_session', 'v2_workflow_run', 'workflow_run_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_run_job_debug_session_job', 'v2_workflow_run_job_debug_session', 'v2_workflow_run_job', 'workflow_run_job_id', 'id');
+
+-- +migrate Down
+DROP TABLE v2_workflow_run_job_debug_session;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE hatchery ADD COLUMN "project_keys" JSONB NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+ALTER TABLE hatchery DROP COLUMN "project_keys";
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "authentified_user" ADD COLUMN IF NOT EXISTS "external_id" VARCHAR(256) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "authentified_user" DROP COLUMN IF EXISTS "external_id";
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE v2_workflow_run_job_debug_session ADD COLUMN IF NOT EXISTS "output" TEXT;
+ALTER TABLE v2_workflow_run_job_debug_session ADD COLUMN IF NOT EXISTS "sig" BYTEA;
+ALTER TABLE v2_workflow_run_job_debug_session ADD COLUMN IF NOT EXISTS "signer" TEXT;
+
+-- +migrate Down
+ALTER TABLE v2_workflow_run_job_debug_session DROP COLUMN IF EXISTS "output";
+ALTER TABLE v2_workflow_run_job_debug_session DROP COLUMN IF EXISTS "sig";
+ALTER TABLE v2_workflow_run_job_debug_session DROP COLUMN IF EXISTS "signer";
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE item ADD COLUMN IF NOT EXISTS last_access TIMESTAMP WITH TIME ZONE;
+
+-- +migrate Down
+ALTER TABLE item DROP COLUMN IF EXISTS last_access;
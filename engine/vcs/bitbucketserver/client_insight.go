@@ -19,6 +19,7 @@ func (b *bitbucketClient) CreateInsightReport(ctx context.Context, repo string,
 		Detail:   vcsReport.Detail,
 		Data:     make([]InsightReportData, 0, len(vcsReport.Datas)),
 		Reporter: "CDS",
+		Result:   vcsReport.Result,
 	}
 	for _, d := range vcsReport.Datas {
 		data := InsightReportData{
@@ -43,5 +44,30 @@ func (b *bitbucketClient) CreateInsightReport(ctx context.Context, repo string,
 	}
 
 	path := fmt.Sprintf("/projects/%s/repos/%s/commits/%s/reports/%s", project, slug, sha, insightKey)
-	return b.do(ctx, "PUT", "insights", path, nil, values, nil, Options{})
+	if err := b.do(ctx, "PUT", "insights", path, nil, values, nil, Options{}); err != nil {
+		return err
+	}
+	if len(vcsReport.Annotations) == 0 {
+		return nil
+	}
+
+	// Annotations are added to the existing ones, remove them first as the report always contains all of them
+	annotationsPath := path + "/annotations"
+	if err := b.do(ctx, "DELETE", "insights", annotationsPath, nil, nil, nil, Options{}); err != nil {
+		return err
+	}
+	annotations := InsightAnnotations{Annotations: make([]InsightAnnotation, 0, len(vcsReport.Annotations))}
+	for _, a := range vcsReport.Annotations {
+		annotations.Annotations = append(annotations.Annotations, InsightAnnotation{
+			Path:     a.Path,
+			Line:     a.Line,
+			Message:  a.Message,
+			Severity: a.Severity,
+		})
+	}
+	values, err = json.Marshal(annotations)
+	if err != nil {
+		return err
+	}
+	return b.do(ctx, "POST", "insights", annotationsPath, nil, values, nil, Options{})
 }
@@ -207,6 +207,17 @@ type InsightReportData struct {
 	Value interface{} `json:"value"`
 }
 
+type InsightAnnotations struct {
+	Annotations []InsightAnnotation `json:"annotations"`
+}
+
+type InsightAnnotation struct {
+	Path     string `json:"path,omitempty"`
+	Line     int64  `json:"line,omitempty"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"` // One of: LOW, MEDIUM, HIGH
+}
+
 type InsightReportDataLink struct {
 	Text string `json:"linktext"`
 	Href string `json:"href"`
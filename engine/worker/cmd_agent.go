@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/rockbears/log"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+const (
+	flagAgentLabels             = "labels"
+	flagAgentCapabilities       = "capabilities"
+	flagAgentJobServicesRuntime = "job-services-runtime"
+)
+
+func cmdAgent() *cobra.Command {
+	var cmdAgent = &cobra.Command{
+		Use:   "agent",
+		Short: "Run a persistent worker agent",
+		Long: `worker agent registers this host as a persistent worker on a CDS region.
+
+The agent signs in once with a hatchery token, advertises its labels and capabilities
+then runs the matching v2 jobs one at a time. A job targets the agent with the runs-on labels:
+
+	runs-on:
+	  labels: [macos, gpu]
+
+The agent can be restricted to the jobs of some projects when its hatchery is created:
+
+	cdsctl experimental hatchery add lab-gpu-1 --project PROJ_KEY
+Each job runs in a dedicated workspace, removed at the end of the job.`,
+		Example: "worker agent --api https://cds.domain --token xxx --name lab-gpu-1 --labels gpu,linux --basedir /var/lib/cds-agent",
+		Run:     agentCmd(),
+	}
+
+	flags := cmdAgent.Flags()
+	flags.String(flagAPI, "", "URL of CDS API")
+	flags.Bool(flagInsecure, false, `(SSL) This option explicitly allows curl to perform "insecure" SSL connections and transfers.`)
+	flags.String(flagToken, "", "Hatchery token of the agent, the agent runs the jobs of the region allowed for this hatchery")
+	flags.String(flagName, "", "Name of the agent, must be the name of the hatchery")
+	flags.String(flagBaseDir, "", "This directory (default TMPDIR os environment var) will contains the job workspaces")
+	flags.String(flagLogLevel, "notice", "Log Level: debug, info, notice, warning, error")
+	flags.String(flagAgentLabels, "", "Comma separated list of labels advertised by the agent")
+	flags.String(flagAgentCapabilities, "", "Comma separated list of capabilities advertised by the agent")
+	flags.String(flagAgentJobServicesRuntime, "", "Runtime used to start the job services: docker or podman")
+	return cmdAgent
+}
+
+func agentCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-c
+			cancel()
+		}()
+
+		agent, err := agentFromFlags(cmd)
+		if err != nil {
+			sdk.Exit("%v\n", err)
+		}
+		cdslog.Initialize(ctx, &cdslog.Conf{Level: FlagString(cmd, flagLogLevel)})
+
+		if err := agent.Signin(ctx); err != nil {
+			sdk.Exit("%v\n", err)
+		}
+		if err := agent.Serve(ctx); err != nil && ctx.Err() == nil {
+			log.Error(ctx, "agent %s stopped: %v", agent.Name, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func agentFromFlags(cmd *cobra.Command) (*internal.Agent, error) {
+	agent := &internal.Agent{
+		Name:                FlagString(cmd, flagName),
+		Token:               FlagString(cmd, flagToken),
+		APIEndpoint:         FlagString(cmd, flagAPI),
+		APIEndpointInsecure: FlagBool(cmd, flagInsecure),
+		Basedir:             FlagString(cmd, flagBaseDir),
+		Log:                 cdslog.Conf{Level: FlagString(cmd, flagLogLevel)},
+		Config: sdk.V2WorkerAgentConfig{
+			Labels:       splitFlagList(FlagString(cmd, flagAgentLabels)),
+			Capabilities: splitFlagList(FlagString(cmd, flagAgentCapabilities)),
+		},
+	}
+	if agent.APIEndpoint == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "--api not provided, aborting.")
+	}
+	if agent.Token == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "--token not provided, aborting.")
+	}
+	if agent.Name == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "--name not provided, aborting.")
+	}
+	if len(agent.Config.Labels) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "--labels not provided, aborting.")
+	}
+	if runtime := FlagString(cmd, flagAgentJobServicesRuntime); runtime != "" {
+		if runtime != workerruntime.JobServicesRuntimeDocker && runtime != workerruntime.JobServicesRuntimePodman {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid --%s %q", flagAgentJobServicesRuntime, runtime)
+		}
+		agent.JobServices = &workerruntime.JobServicesConfig{Runtime: runtime}
+	}
+	if agent.Basedir == "" {
+		agent.Basedir = os.TempDir()
+	}
+	basedir, err := filepath.Abs(agent.Basedir)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	agent.Basedir = basedir
+
+	agent.WorkerBinary, err = os.Executable()
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to find the worker binary")
+	}
+	return agent, nil
+}
+
+func splitFlagList(s string) []string {
+	var res []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
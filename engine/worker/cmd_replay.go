@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+const flagReplayPluginsDir = "plugins-dir"
+
+func cmdReplay() *cobra.Command {
+	var cmdReplay = &cobra.Command{
+		Use:   "replay",
+		Short: "Replay a v2 job on this host",
+		Long: `worker replay runs a job exported with "cdsctl experimental workflow jobs replay" on this host.
+
+The job runs with the contexts of its original run but without the secrets: the vcs token,
+the secret variables and the integrations are not exported. Nothing is sent to CDS,
+the step logs are printed on the standard output. The plugin binaries are not downloaded:
+the binaries used by the job must be in the directory given with --plugins-dir.`,
+		Example: "worker replay my-job.json --basedir /tmp/replay",
+		Args:    cobra.ExactArgs(1),
+		Run:     replayCmd(),
+	}
+
+	flags := cmdReplay.Flags()
+	flags.String(flagBaseDir, "", "This directory (default TMPDIR os environment var) will contains the job workspace")
+	flags.String(flagLogLevel, "notice", "Log Level: debug, info, notice, warning, error")
+	flags.String(flagReplayPluginsDir, ".", "This directory contains the plugin binaries used by the job")
+	return cmdReplay
+}
+
+func replayCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-c
+			cancel()
+		}()
+
+		btes, err := os.ReadFile(args[0])
+		if err != nil {
+			sdk.Exit("unable to read file %s: %v\n", args[0], err)
+		}
+		var replay sdk.V2WorkflowRunJobReplay
+		if err := json.Unmarshal(btes, &replay); err != nil {
+			sdk.Exit("unable to read job from file %s: %v\n", args[0], err)
+		}
+
+		cfg := &workerruntime.WorkerConfig{
+			Name:    "replay-" + replay.RunJob.JobID,
+			Basedir: FlagString(cmd, flagBaseDir),
+			Log:     cdslog.Conf{Level: FlagString(cmd, flagLogLevel)},
+		}
+		cdslog.Initialize(ctx, &cfg.Log)
+		if cfg.Basedir == "" {
+			cfg.Basedir = os.TempDir()
+		}
+		cfg.Basedir, err = filepath.Abs(cfg.Basedir)
+		if err != nil {
+			sdk.Exit("%v\n", err)
+		}
+		fs := afero.NewOsFs()
+		if err := fs.MkdirAll(cfg.Basedir, os.FileMode(0755)); err != nil {
+			sdk.Exit("unable to setup worker basedir %q: %v\n", cfg.Basedir, err)
+		}
+
+		for _, s := range replay.OmittedSecrets {
+			fmt.Fprintf(os.Stderr, "Secret %s is not available\n", s)
+		}
+
+		w := new(internal.CurrentWorker)
+		if err := w.Init(cfg, afero.NewBasePathFs(fs, cfg.Basedir)); err != nil {
+			sdk.Exit("%v\n", err)
+		}
+		res, err := internal.V2ReplayJob(ctx, w, replay, FlagString(cmd, flagReplayPluginsDir), os.Stdout)
+		if err != nil {
+			sdk.Exit("%v\n", err)
+		}
+		if res.Status != sdk.V2WorkflowRunJobStatusSuccess {
+			sdk.Exit("Job %s: %s %s\n", replay.RunJob.JobID, res.Status, res.Error)
+		}
+		fmt.Printf("Job %s: %s\n", replay.RunJob.JobID, res.Status)
+	}
+}
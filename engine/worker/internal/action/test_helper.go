@@ -35,10 +35,18 @@ func (*TestWorker) V2GetProjectKey(ctx context.Context, keyName string, clear bo
 	panic("unimplemented")
 }
 
+func (*TestWorker) V2GetTestQuarantines(ctx context.Context) (sdk.V2WorkflowTestQuarantines, error) {
+	panic("unimplemented")
+}
+
+func (*TestWorker) V2AddStepSummary(ctx context.Context, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	panic("unimplemented")
+}
+
 func (*TestWorker) V2GetCacheSignature(ctx context.Context, cacheKey string) (*workerruntime.CDNSignature, error) {
 	panic("unimplemented")
 }
-func (*TestWorker) V2GetCacheLink(ctx context.Context, cacheKey string) (*sdk.CDNItemLinks, error) {
+func (*TestWorker) V2GetCacheLink(ctx context.Context, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
 	panic("unimplemented")
 }
 
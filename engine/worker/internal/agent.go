@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/jws"
+	cdslog "github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/namesgenerator"
+)
+
+// Agent is a persistent worker registered on a region with a hatchery token.
+// It runs the v2 jobs matching its labels one at a time, each one in a dedicated workspace.
+type Agent struct {
+	Name                string
+	Token               string
+	APIEndpoint         string
+	APIEndpointInsecure bool
+	Basedir             string
+	PollingDelay        time.Duration
+	HeartbeatDelay      time.Duration
+	Config              sdk.V2WorkerAgentConfig
+	Log                 cdslog.Conf
+	JobServices         *workerruntime.JobServicesConfig
+	WorkerBinary        string
+
+	Client     cdsclient.HatcheryServiceClient
+	Region     string
+	privateKey *rsa.PrivateKey
+	cdnConfig  sdk.CDNConfig
+	goRoutines *sdk.GoRoutines
+	// RunWorker starts the worker process for a job and waits for its end
+	RunWorker func(ctx context.Context, cfg workerruntime.WorkerConfig) error
+}
+
+// Signin registers the agent on the API, its labels and capabilities are stored in the hatchery configuration
+func (a *Agent) Signin(ctx context.Context) error {
+	var err error
+	a.privateKey, err = jws.NewRandomRSAKey()
+	if err != nil {
+		return err
+	}
+	pubKey, err := jws.ExportPublicKey(a.privateKey)
+	if err != nil {
+		return err
+	}
+	cfg, err := a.Config.ServiceConfig()
+	if err != nil {
+		return err
+	}
+	req := &sdk.AuthConsumerHatcherySigninRequest{
+		Token:        a.Token,
+		Name:         a.Name,
+		HatcheryType: sdk.WorkerModelTypeAgent,
+		Config:       cfg,
+		PublicKey:    pubKey,
+		Version:      sdk.VERSION,
+	}
+	a.Client, _, _, a.Region, err = cdsclient.NewHatcheryServiceClient(ctx, cdsclient.ServiceConfig{
+		Host:                  a.APIEndpoint,
+		TokenV2:               a.Token,
+		InsecureSkipVerifyTLS: a.APIEndpointInsecure,
+	}, req)
+	if err != nil {
+		return sdk.WrapError(err, "unable to signin agent %s", a.Name)
+	}
+	a.cdnConfig, err = a.Client.V2ConfigCDN()
+	if err != nil {
+		return sdk.WrapError(err, "unable to get CDN configuration")
+	}
+	log.Info(ctx, "agent %s registered on region %s with labels %v", a.Name, a.Region, a.Config.Labels)
+	return nil
+}
+
+// Serve sends heartbeats and runs the matching jobs until the context is canceled
+func (a *Agent) Serve(ctx context.Context) error {
+	if a.RunWorker == nil {
+		a.RunWorker = a.runWorkerProcess
+	}
+	if a.PollingDelay == 0 {
+		a.PollingDelay = 10 * time.Second
+	}
+	if a.HeartbeatDelay == 0 {
+		a.HeartbeatDelay = 30 * time.Second
+	}
+	a.goRoutines = sdk.NewGoRoutines(ctx)
+
+	a.goRoutines.Run(ctx, "agent-heartbeat", func(ctx context.Context) {
+		a.heartbeat(ctx)
+	})
+
+	var pendingJobs sdk.HatcheryPendingWorkerCreation
+	pendingJobs.Init()
+	jobs := make(chan string, 1)
+	errs := make(chan error, 1)
+	a.goRoutines.Run(ctx, "agent-queue-polling", func(ctx context.Context) {
+		if err := a.Client.V2QueuePolling(ctx, a.Region, nil, a.goRoutines, &sdk.HatcheryMetrics{}, &pendingJobs, jobs, errs, a.PollingDelay); err != nil {
+			log.Error(ctx, "agent> queue polling: %v", err)
+		}
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errs:
+			log.Error(ctx, "agent> %v", err)
+		case jobID, ok := <-jobs:
+			if !ok {
+				return ctx.Err()
+			}
+			if err := a.ProcessJob(ctx, jobID); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
+			pendingJobs.RemoveJobFromPendingWorkerCreation(jobID)
+		}
+	}
+}
+
+func (a *Agent) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(a.HeartbeatDelay)
+	defer ticker.Stop()
+	for {
+		mon := sdk.MonitoringStatus{
+			Now: time.Now(),
+			Lines: []sdk.MonitoringStatusLine{
+				{Component: "Version", Value: sdk.VERSION, Status: sdk.MonitoringStatusOK},
+				{Component: "Labels", Value: strings.Join(a.Config.Labels, ","), Status: sdk.MonitoringStatusOK},
+			},
+		}
+		if err := a.Client.Heartbeat(ctx, &mon); err != nil {
+			log.Warn(ctx, "agent> unable to send heartbeat: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessJob takes the job if it matches the agent labels, then runs a worker in a dedicated workspace removed at the end
+func (a *Agent) ProcessJob(ctx context.Context, jobID string) error {
+	info, err := a.Client.V2QueueGetJobRun(ctx, a.Region, jobID)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if info.RunJob.Status != sdk.V2WorkflowRunJobStatusWaiting || !a.Config.CanRunJob(info.RunJob) {
+		return nil
+	}
+
+	runJob, err := a.Client.V2HatcheryTakeJob(ctx, a.Region, jobID)
+	if err != nil {
+		log.Info(ctx, "agent> unable to take job %s: %v", jobID, err)
+		return nil
+	}
+
+	workerName := namesgenerator.GenerateWorkerName(a.Name)
+	ctx = context.WithValue(ctx, cdslog.AuthWorkerName, workerName)
+	log.Info(ctx, "agent> starting worker %s for job %s (%s/%s #%d)", workerName, runJob.JobID, runJob.ProjectKey, runJob.WorkflowName, runJob.RunNumber)
+
+	releaseJob := func(msg string) {
+		info := sdk.V2SendJobRunInfo{
+			Time:    time.Now(),
+			Level:   sdk.WorkflowRunInfoLevelError,
+			Message: msg,
+		}
+		if err := a.Client.V2QueuePushJobInfo(ctx, a.Region, jobID, info); err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+		}
+		if err := a.Client.V2HatcheryReleaseJob(ctx, a.Region, jobID); err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+		}
+	}
+
+	jwt, err := hatchery.NewWorkerTokenV2(a.Name, a.privateKey, time.Now().Add(1*time.Hour), hatchery.SpawnArguments{
+		WorkerName:   workerName,
+		JobID:        jobID,
+		HatcheryName: a.Name,
+	})
+	if err != nil {
+		releaseJob("unable to create a token for the worker. Please contact an administrator")
+		return err
+	}
+
+	workspace := filepath.Join(a.Basedir, workerName)
+	if err := os.MkdirAll(workspace, os.FileMode(0755)); err != nil {
+		releaseJob(fmt.Sprintf("agent %s is unable to create the job workspace", a.Name))
+		return sdk.WithStack(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(workspace); err != nil {
+			log.Error(ctx, "agent> unable to clean workspace %s: %v", workspace, err)
+		}
+	}()
+
+	cfg := a.workerConfig(workerName, jwt, jobID, workspace)
+	if err := a.RunWorker(ctx, cfg); err != nil {
+		// Release the job only if the worker didn't start it
+		if current, errG := a.Client.V2QueueGetJobRun(ctx, a.Region, jobID); errG == nil && current.RunJob.Status == sdk.V2WorkflowRunJobStatusScheduling {
+			releaseJob(fmt.Sprintf("agent %s is unable to start worker %s: %v", a.Name, workerName, err))
+		}
+		return sdk.WrapError(err, "worker %s exited with error", workerName)
+	}
+	log.Info(ctx, "agent> worker %s ended for job %s", workerName, runJob.JobID)
+	return nil
+}
+
+func (a *Agent) workerConfig(workerName, jwt, jobID, workspace string) workerruntime.WorkerConfig {
+	logConf := a.Log
+	logConf.GraylogFieldCDSServiceType = "worker"
+	logConf.GraylogFieldCDSServiceName = workerName
+	return workerruntime.WorkerConfig{
+		Name:                     workerName,
+		Basedir:                  workspace,
+		Log:                      logConf,
+		HatcheryName:             a.Name,
+		APIEndpoint:              a.APIEndpoint,
+		APIEndpointInsecure:      a.APIEndpointInsecure,
+		APIToken:                 jwt,
+		CDNEndpoint:              a.cdnConfig.HTTPURL,
+		GelfServiceAddr:          a.cdnConfig.TCPURL,
+		GelfServiceAddrEnableTLS: a.cdnConfig.TCPURLEnableTLS,
+		RunJobID:                 jobID,
+		Region:                   a.Region,
+		JobServices:              a.JobServices,
+	}
+}
+
+func (a *Agent) runWorkerProcess(ctx context.Context, cfg workerruntime.WorkerConfig) error {
+	cmd := exec.CommandContext(ctx, a.WorkerBinary, "--config", cfg.EncodeBase64())
+	cmd.Dir = cfg.Basedir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Do not leak the agent configuration to the jobs
+	for _, e := range os.Environ() {
+		if !strings.HasPrefix(e, "CDS") && !strings.HasPrefix(e, "HATCHERY") {
+			cmd.Env = append(cmd.Env, e)
+		}
+	}
+	return sdk.WithStack(cmd.Run())
+}
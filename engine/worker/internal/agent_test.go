@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+	"github.com/ovh/cds/sdk/jws"
+)
+
+func newTestAgent(t *testing.T) (*Agent, *mock_cdsclient.MockHatcheryServiceClient) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+	m := mock_cdsclient.NewMockHatcheryServiceClient(ctrl)
+
+	key, err := jws.NewRandomRSAKey()
+	require.NoError(t, err)
+
+	return &Agent{
+		Name:       "lab-gpu-1",
+		Basedir:    t.TempDir(),
+		Config:     sdk.V2WorkerAgentConfig{Labels: []string{"linux", "gpu"}},
+		Client:     m,
+		Region:     "build",
+		privateKey: key,
+	}, m
+}
+
+func TestAgentProcessJob(t *testing.T) {
+	a, m := newTestAgent(t)
+	jobID := sdk.UUID()
+	rj := sdk.V2WorkflowRunJob{
+		ID:         jobID,
+		JobID:      "build",
+		ProjectKey: "PROJ",
+		Status:     sdk.V2WorkflowRunJobStatusWaiting,
+		ModelType:  sdk.WorkerModelTypeAgent,
+		Job:        sdk.V2Job{RunsOn: sdk.V2JobRunsOn{Labels: []string{"gpu"}}},
+	}
+
+	m.EXPECT().V2QueueGetJobRun(gomock.Any(), "build", jobID).Return(&sdk.V2QueueJobInfo{RunJob: rj}, nil)
+	m.EXPECT().V2HatcheryTakeJob(gomock.Any(), "build", jobID).Return(&rj, nil)
+
+	var workspace string
+	a.RunWorker = func(ctx context.Context, cfg workerruntime.WorkerConfig) error {
+		workspace = cfg.Basedir
+		require.Equal(t, jobID, cfg.RunJobID)
+		require.Equal(t, "build", cfg.Region)
+		require.Equal(t, "lab-gpu-1", cfg.HatcheryName)
+		require.NotEmpty(t, cfg.APIToken)
+		require.Equal(t, a.Basedir, filepath.Dir(cfg.Basedir))
+		// The job writes in its workspace
+		return os.WriteFile(filepath.Join(cfg.Basedir, "file"), []byte("content"), os.FileMode(0644))
+	}
+
+	require.NoError(t, a.ProcessJob(context.TODO(), jobID))
+
+	// The workspace is removed at the end of the job
+	require.NotEmpty(t, workspace)
+	_, err := os.Stat(workspace)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestAgentProcessJobWithUnknownLabel(t *testing.T) {
+	a, m := newTestAgent(t)
+	jobID := sdk.UUID()
+	rj := sdk.V2WorkflowRunJob{
+		ID:        jobID,
+		Status:    sdk.V2WorkflowRunJobStatusWaiting,
+		ModelType: sdk.WorkerModelTypeAgent,
+		Job:       sdk.V2Job{RunsOn: sdk.V2JobRunsOn{Labels: []string{"macos"}}},
+	}
+
+	m.EXPECT().V2QueueGetJobRun(gomock.Any(), "build", jobID).Return(&sdk.V2QueueJobInfo{RunJob: rj}, nil)
+	a.RunWorker = func(ctx context.Context, cfg workerruntime.WorkerConfig) error {
+		t.Fatal("worker must not be started")
+		return nil
+	}
+
+	require.NoError(t, a.ProcessJob(context.TODO(), jobID))
+}
+
+func TestAgentProcessJobReleaseOnWorkerError(t *testing.T) {
+	a, m := newTestAgent(t)
+	jobID := sdk.UUID()
+	rj := sdk.V2WorkflowRunJob{
+		ID:        jobID,
+		Status:    sdk.V2WorkflowRunJobStatusWaiting,
+		ModelType: sdk.WorkerModelTypeAgent,
+		Job:       sdk.V2Job{RunsOn: sdk.V2JobRunsOn{Labels: []string{"linux"}}},
+	}
+	scheduled := rj
+	scheduled.Status = sdk.V2WorkflowRunJobStatusScheduling
+
+	gomock.InOrder(
+		m.EXPECT().V2QueueGetJobRun(gomock.Any(), "build", jobID).Return(&sdk.V2QueueJobInfo{RunJob: rj}, nil),
+		m.EXPECT().V2HatcheryTakeJob(gomock.Any(), "build", jobID).Return(&scheduled, nil),
+		m.EXPECT().V2QueueGetJobRun(gomock.Any(), "build", jobID).Return(&sdk.V2QueueJobInfo{RunJob: scheduled}, nil),
+		m.EXPECT().V2QueuePushJobInfo(gomock.Any(), "build", jobID, gomock.Any()).Return(nil),
+		m.EXPECT().V2HatcheryReleaseJob(gomock.Any(), "build", jobID).Return(nil),
+	)
+	a.RunWorker = func(ctx context.Context, cfg workerruntime.WorkerConfig) error {
+		return fmt.Errorf("exec: worker not found")
+	}
+
+	require.Error(t, a.ProcessJob(context.TODO(), jobID))
+}
@@ -71,6 +71,8 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	r.HandleFunc("/v2/context", LogMiddleware(workerruntime.V2_contextHandler(c, w)))
 	r.HandleFunc("/v2/result", LogMiddleware(workerruntime.V2_runResultHandler(c, w)))
 	r.HandleFunc("/v2/result/synchronize", LogMiddleware(workerruntime.V2_runResultsSynchronizeHandler(c, w)))
+	r.HandleFunc("/v2/summary", LogMiddleware(workerruntime.V2_summaryHandler(c, w)))
+	r.HandleFunc("/v2/test/quarantine", LogMiddleware(workerruntime.V2_testQuarantineHandler(c, w)))
 
 	srv := &http.Server{
 		Handler: r,
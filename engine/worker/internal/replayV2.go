@@ -0,0 +1,297 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/jws"
+	cdslog "github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/log/hook/graylog"
+)
+
+// V2ReplayJob runs a job exported by the API on the local host. The worker is not registered: nothing is sent to
+// CDS, the step logs are printed on the given output and the features needing the API are not available. The plugin
+// binaries are read from pluginsDir.
+func V2ReplayJob(ctx context.Context, w *CurrentWorker, replay sdk.V2WorkflowRunJobReplay, pluginsDir string, out io.Writer) (sdk.V2WorkflowRunJobResult, error) {
+	var res sdk.V2WorkflowRunJobResult
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = context.WithValue(ctx, cdslog.Workflow, replay.RunJob.WorkflowName)
+	ctx = context.WithValue(ctx, cdslog.Project, replay.RunJob.ProjectKey)
+
+	w.client = nil
+	w.clientV2 = &replayClient{plugins: replay.Plugins, pluginsDir: pluginsDir}
+	if err := w.Serve(ctx); err != nil {
+		return res, err
+	}
+
+	runJob := replay.RunJob
+	// Nobody can open a debug session on a replayed job
+	runJob.Job.Debug = ""
+	w.currentJobV2.context = ctx
+	w.currentJobV2.runJob = &runJob
+	w.currentJobV2.integrations = make(map[string]sdk.ProjectIntegration)
+	w.actions = replay.AsCodeActions
+	w.currentJobV2.runJobContext = replay.Contexts
+	w.actionPlugin = make(map[string]*sdk.GRPCPlugin)
+	w.checkedPluginBinaries = make(map[string]*sdk.GRPCPluginBinary)
+
+	var err error
+	w.blur, err = sdk.NewBlur(nil)
+	if err != nil {
+		return res, err
+	}
+
+	// The logs are not sent to CDN, any key can sign them
+	secretKey := make([]byte, 32)
+	if _, err := rand.Read(secretKey); err != nil {
+		return res, sdk.WithStack(err)
+	}
+	w.signer, err = jws.NewHMacSigner(secretKey)
+	if err != nil {
+		return res, sdk.WithStack(err)
+	}
+
+	// Step logs are sent by a gelf hook, read them on a local listener to print them
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return res, sdk.WithStack(err)
+	}
+	defer listener.Close() // nolint
+	logsDone := make(chan struct{})
+	go func() {
+		defer close(logsDone)
+		printReplayLogs(listener, out)
+	}()
+
+	l, h, err := cdslog.New(ctx, &graylog.Config{
+		Addr:     listener.Addr().String(),
+		Protocol: "tcp",
+		ThrottlePolicy: &graylog.ThrottlePolicyConfig{
+			Amount: 100,
+			Period: 10 * time.Millisecond,
+			Policy: graylog.NewDefaultThrottlePolicy(),
+		},
+	})
+	if err != nil {
+		return res, sdk.WithStack(err)
+	}
+	// The hook prints the step logs, not the logger
+	l.SetOutput(io.Discard)
+	w.SetGelfLogger(h, l)
+	w.setJobLogLevel()
+
+	log.Info(ctx, "V2ReplayJob> replaying job %s of workflow %s", runJob.JobID, runJob.WorkflowName)
+	res = w.V2ProcessJob()
+	res.Time = time.Now()
+
+	h.Flush()
+	h.Stop()
+	// Let the listener print the last logs
+	select {
+	case <-logsDone:
+	case <-time.After(time.Second):
+	}
+	return res, nil
+}
+
+func printReplayLogs(listener net.Listener, out io.Writer) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close() // nolint
+	reader := bufio.NewReader(conn)
+	for {
+		bts, err := reader.ReadBytes(0)
+		if len(bts) > 1 {
+			var m graylog.Message
+			if err := json.Unmarshal(bts[:len(bts)-1], &m); err == nil {
+				msg := m.Full
+				if msg == "" {
+					msg = m.Short
+				}
+				fmt.Fprintln(out, strings.TrimSuffix(msg, "\n"))
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// replayClient replaces the API client of a replayed job.
+type replayClient struct {
+	plugins    []sdk.GRPCPlugin
+	pluginsDir string
+}
+
+func errReplay(feature string) error {
+	return sdk.NewErrorFrom(sdk.ErrNotImplemented, "%s is not available when replaying a job", feature)
+}
+
+func (c *replayClient) V2WorkerRegister(ctx context.Context, authToken string, form sdk.WorkerRegistrationForm, region, runJobID string) (*sdk.V2Worker, error) {
+	return nil, errReplay("worker registration")
+}
+
+func (c *replayClient) V2WorkerUnregister(ctx context.Context, region, runJobID string) error {
+	return nil
+}
+
+func (c *replayClient) V2WorkerRefresh(ctx context.Context, region, runJobID string) error {
+	return nil
+}
+
+func (c *replayClient) V2WorkerProjectGetKey(ctx context.Context, region, runJobID, keyName string, clear bool) (*sdk.ProjectKey, error) {
+	return nil, errReplay("project keys")
+}
+
+func (c *replayClient) V2QueueGetJobRun(ctx context.Context, regionName string, id string) (*sdk.V2QueueJobInfo, error) {
+	return nil, errReplay("job retrieval")
+}
+
+func (c *replayClient) V2QueuePolling(ctx context.Context, region string, osarch []string, goRoutines *sdk.GoRoutines, hatcheryMetrics *sdk.HatcheryMetrics, pendingWorkerCreation *sdk.HatcheryPendingWorkerCreation, jobs chan<- string, errs chan<- error, delay time.Duration, ms ...cdsclient.RequestModifier) error {
+	return errReplay("queue polling")
+}
+
+func (c *replayClient) V2QueueJobResult(ctx context.Context, region string, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
+	return nil
+}
+
+func (c *replayClient) V2QueueJobRunResultGet(ctx context.Context, regionName string, jobRunID string, runResultID string) (*sdk.V2WorkflowRunResult, error) {
+	return nil, errReplay("run results")
+}
+
+func (c *replayClient) V2QueueJobRunResultsGet(ctx context.Context, regionName string, jobRunID string) ([]sdk.V2WorkflowRunResult, error) {
+	return nil, nil
+}
+
+func (c *replayClient) V2QueueJobRunResultsSynchronize(ctx context.Context, regionName string, jobRunID string) error {
+	return errReplay("run results")
+}
+
+func (c *replayClient) V2QueueJobRunResultCreate(ctx context.Context, regionName string, jobRunID string, result *sdk.V2WorkflowRunResult) error {
+	return errReplay("run results")
+}
+
+func (c *replayClient) V2QueueJobRunResultUpdate(ctx context.Context, regionName string, jobRunID string, result *sdk.V2WorkflowRunResult) error {
+	return errReplay("run results")
+}
+
+func (c *replayClient) V2QueueJobRunAttestationSign(ctx context.Context, regionName string, jobRunID string, req sdk.V2AttestationSignRequest) (*sdk.V2AttestationSignResponse, error) {
+	return nil, errReplay("attestations")
+}
+
+func (c *replayClient) V2QueuePushRunInfo(ctx context.Context, regionName string, jobRunID string, msg sdk.V2WorkflowRunInfo) error {
+	log.Info(ctx, "%s", msg.Message)
+	return nil
+}
+
+func (c *replayClient) V2QueuePushJobInfo(ctx context.Context, regionName string, jobRunID string, msg sdk.V2SendJobRunInfo) error {
+	log.Info(ctx, "%s", msg.Message)
+	return nil
+}
+
+func (c *replayClient) V2QueueJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, regionName string, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	return errReplay("debug sessions")
+}
+
+func (c *replayClient) V2QueuePushJobSummary(ctx context.Context, regionName string, jobRunID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	return nil
+}
+
+func (c *replayClient) V2QueueWorkerTakeJob(ctx context.Context, region, runJobID string) (*sdk.V2TakeJobResponse, error) {
+	return nil, errReplay("job take")
+}
+
+func (c *replayClient) V2QueueJobStepUpdate(ctx context.Context, regionName string, id string, stepsStatus sdk.JobStepsStatus) error {
+	return nil
+}
+
+func (c *replayClient) V2QueueGetCacheLinks(ctx context.Context, regionName string, id string, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
+	return nil, errReplay("cache")
+}
+
+func (c *replayClient) V2QueueGetTestQuarantines(ctx context.Context, regionName string, id string) (sdk.V2WorkflowTestQuarantines, error) {
+	return nil, nil
+}
+
+func (c *replayClient) PluginsList() ([]sdk.GRPCPlugin, error) {
+	return c.plugins, nil
+}
+
+func (c *replayClient) PluginsGet(name string) (*sdk.GRPCPlugin, error) {
+	for i := range c.plugins {
+		if c.plugins[i].Name == name {
+			return &c.plugins[i], nil
+		}
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "plugin %s not found", name)
+}
+
+func (c *replayClient) PluginAdd(*sdk.GRPCPlugin) error {
+	return errReplay("plugins")
+}
+
+func (c *replayClient) PluginUpdate(*sdk.GRPCPlugin) error {
+	return errReplay("plugins")
+}
+
+func (c *replayClient) PluginDelete(string) error {
+	return errReplay("plugins")
+}
+
+func (c *replayClient) PluginAddBinary(*sdk.GRPCPlugin, *sdk.GRPCPluginBinary) error {
+	return errReplay("plugins")
+}
+
+func (c *replayClient) PluginDeleteBinary(name, os, arch string) error {
+	return errReplay("plugins")
+}
+
+// PluginGetBinary copies the binary from the plugins directory, the worker checks its checksum.
+func (c *replayClient) PluginGetBinary(name, goos, goarch string, w io.Writer) error {
+	b, err := c.PluginGetBinaryInfos(name, goos, goarch)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(filepath.Join(c.pluginsDir, b.Name))
+	if err != nil {
+		// Don't let the worker retry the download
+		return sdk.NewErrorFrom(sdk.ErrPluginInvalid, "binary %s of plugin %s not found in %s", b.Name, name, c.pluginsDir)
+	}
+	defer f.Close() // nolint
+	_, err = io.Copy(w, f)
+	return sdk.WithStack(err)
+}
+
+func (c *replayClient) PluginGetBinaryInfos(name, os, arch string) (*sdk.GRPCPluginBinary, error) {
+	p, err := c.PluginsGet(name)
+	if err != nil {
+		return nil, err
+	}
+	if b := p.GetBinary(os, arch); b != nil {
+		return b, nil
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find plugin %s for %s/%s", name, os, arch)
+}
+
+func (c *replayClient) ProjectV2IntegrationWorkerHookGet(projectKey string, integrationName string) (*sdk.WorkerHookProjectIntegrationModel, error) {
+	return nil, sdk.WithStack(sdk.ErrNotFound)
+}
+
+var _ cdsclient.V2WorkerInterface = new(replayClient)
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/worker/internal/plugin/mock"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestV2ReplayJob(t *testing.T) {
+	replay := sdk.V2WorkflowRunJobReplay{
+		RunJob: sdk.V2WorkflowRunJob{
+			ID:           sdk.UUID(),
+			JobID:        "build",
+			ProjectKey:   "PROJ",
+			WorkflowName: "my-workflow",
+			Region:       "build",
+			Status:       sdk.V2WorkflowRunJobStatusBuilding,
+			Job: sdk.V2Job{
+				Region: "build",
+				Debug:  sdk.V2JobDebugAlways,
+				Steps: []sdk.ActionStep{
+					{ID: "step-0", Run: "echo hello"},
+					{ID: "step-1", Run: "exit 1"},
+				},
+			},
+		},
+	}
+
+	basedir := t.TempDir()
+	w := new(CurrentWorker)
+	require.NoError(t, w.Init(&workerruntime.WorkerConfig{Name: "replay-build", Basedir: basedir}, afero.NewBasePathFs(afero.NewOsFs(), basedir)))
+	// The second step fails
+	w.pluginFactory = &mock.MockFactory{Result: []string{sdk.StatusSuccess, sdk.StatusFail}}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	var out bytes.Buffer
+	res, err := V2ReplayJob(ctx, w, replay, t.TempDir(), &out)
+	require.NoError(t, err)
+	require.Equal(t, sdk.V2WorkflowRunJobStatusFail, res.Status)
+	require.Equal(t, sdk.V2WorkflowRunJobStatusSuccess, w.currentJobV2.runJob.StepsStatus["step-0"].Conclusion)
+	require.Equal(t, sdk.V2WorkflowRunJobStatusFail, w.currentJobV2.runJob.StepsStatus["step-1"].Conclusion)
+	// The debug is disabled on a replayed job
+	require.Empty(t, w.currentJobV2.runJob.Job.Debug)
+}
+
+func Test_printReplayLogs(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close() // nolint
+
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		printReplayLogs(listener, &out)
+		close(done)
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte(`{"short_message":"short","full_message":"hello\n"}` + "\x00" + `{"short_message":"only short"}` + "\x00" + `not json` + "\x00"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	<-done
+
+	require.Equal(t, "hello\nonly short\n", out.String())
+}
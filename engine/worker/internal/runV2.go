@@ -18,6 +18,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/rockbears/log"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 
 	"github.com/ovh/cds/engine/worker/internal/plugin"
@@ -62,6 +63,14 @@ func (w *CurrentWorker) V2ProcessJob() (res sdk.V2WorkflowRunJobResult) {
 	ctx = workerruntime.SetWorkingDirectory(ctx, wdFile)
 	log.Debug(ctx, "Setup workspace - %s", wdFile.Name())
 
+	// Start job services if the hatchery cannot start them
+	services, err := w.startJobServices(ctx)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return w.failJob(ctx, fmt.Sprintf("Error: unable to start services: %v", err))
+	}
+	defer w.stopJobServices(ctx, services)
+
 	// Manage services readiness
 	if result := w.runJobServicesReadiness(ctx); result.Status != sdk.V2WorkflowRunJobStatusSuccess {
 		return w.failJob(ctx, fmt.Sprintf("Error: readiness service command failed: %v", result.Error))
@@ -75,10 +84,12 @@ func (w *CurrentWorker) V2ProcessJob() (res sdk.V2WorkflowRunJobResult) {
 	ctx = workerruntime.SetKeysDirectory(ctx, kdFile)
 	log.Debug(ctx, "Setup key directory - %s", kdFile.Name())
 
-	tdFile, _, err := w.setupTmpDirectory(ctx, w.currentJobV2.runJob.JobID)
+	tdFile, tdAbs, err := w.setupTmpDirectory(ctx, w.currentJobV2.runJob.JobID)
 	if err != nil {
 		return w.failJob(ctx, fmt.Sprintf("Error: unable to setup tmp directory: %v", err))
 	}
+	w.currentJobV2.tmpDirectory = tdFile.Name()
+	w.currentJobV2.tmpDirectoryAbs = tdAbs
 	ctx = workerruntime.SetTmpDirectory(ctx, tdFile)
 	log.Debug(ctx, "Setup tmp directory - %s", tdFile.Name())
 
@@ -102,6 +113,7 @@ func (w *CurrentWorker) V2ProcessJob() (res sdk.V2WorkflowRunJobResult) {
 		return w.failJob(ctx, fmt.Sprintf("Error: unable to setup hooks: %v", err))
 	}
 	res = w.runJobAsCode(ctx)
+	w.waitForDebugSessions(ctx, res)
 
 	// Delete hooks directory
 	if err := teardownDirectory(w.basedir, hdFile.Name()); err != nil {
@@ -301,7 +313,12 @@ func (w *CurrentWorker) runJobAsCode(ctx context.Context) sdk.V2WorkflowRunJobRe
 			return w.failJob(ctx, err.Error())
 		}
 
+		if err := w.prepareStepSummaryFiles(jobStepIndex); err != nil {
+			return w.failJob(ctx, fmt.Sprintf("unable to create step summary files: %v", err))
+		}
+
 		stepRes, pa := w.runActionStep(ctx, step, w.currentJobV2.currentStepNameForLog, *currentStepContext)
+		w.sendStepSummary(ctx, jobStepIndex, w.currentJobV2.currentStepNameForLog)
 
 		// If job is already failed, display error in job logs
 		if jobResult.Status == sdk.V2WorkflowRunJobStatusFail && stepRes.Status == sdk.V2WorkflowRunJobStatusFail {
@@ -951,6 +968,20 @@ func (w *CurrentWorker) GetEnvVariable(ctx context.Context, contexts sdk.Workflo
 		}
 	}
 
+	// Files where the step can write its summary and its annotations
+	if w.currentJobV2.tmpDirectoryAbs != "" {
+		summaryFile, annotationsFile := stepSummaryFileNames(w.currentJobV2.currentStepIndexForLog)
+		newEnvVar[StepSummaryFile] = filepath.Join(w.currentJobV2.tmpDirectoryAbs, summaryFile)
+		newEnvVar[StepAnnotationsFile] = filepath.Join(w.currentJobV2.tmpDirectoryAbs, annotationsFile)
+	}
+
+	// Let the job tools attach their spans to the run trace
+	if w.currentJobV2.runJob != nil {
+		if _, has := newEnvVar["TRACEPARENT"]; !has {
+			newEnvVar["TRACEPARENT"] = sdk.V2WorkflowRunJobTraceParent(*w.currentJobV2.runJob)
+		}
+	}
+
 	pathList := sdk.StringSlice{}
 	// Retrieve path step contexts (path that comes from parent)
 	pathList = append(pathList, contexts.ParentPaths...)
@@ -1218,3 +1249,14 @@ func (w *CurrentWorker) setupHooksV2(ctx context.Context, currentJob CurrentJobV
 	}
 	return nil
 }
+
+// setJobLogLevel switches the worker to the debug log level when the job was re-run in debug.
+func (w *CurrentWorker) setJobLogLevel() {
+	if w.currentJobV2.runJobContext.Env[sdk.V2JobDebugLogEnv] != "true" {
+		return
+	}
+	logrus.SetLevel(logrus.DebugLevel)
+	if w.gelfLogger != nil {
+		w.gelfLogger.logger.SetLevel(logrus.DebugLevel)
+	}
+}
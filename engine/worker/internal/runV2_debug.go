@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// debugShell is the shell started on the worker for a debug session. There is no terminal: the input and the
+// output of the shell are relayed as is.
+type debugShell struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+type jobDebugger struct {
+	w          *CurrentWorker
+	mutex      sync.Mutex
+	shells     map[string]*debugShell
+	chanToSend chan json.RawMessage
+}
+
+// waitForDebugSessions keeps the worker alive at the end of the job when debug is enabled on it. Users having the
+// debug role on the workflow can then open shells on the worker until the timeout, until a user releases the
+// job or until the job is stopped.
+func (w *CurrentWorker) waitForDebugSessions(ctx context.Context, res sdk.V2WorkflowRunJobResult) {
+	runJob := w.currentJobV2.runJob
+	if !runJob.Job.DebugEnabled(res.Status) {
+		return
+	}
+	timeout := runJob.Job.GetDebugTimeout()
+
+	info := sdk.V2SendJobRunInfo{
+		Level:   sdk.WorkflowRunInfoLevelInfo,
+		Message: fmt.Sprintf("Job status is %s, worker %s waits for debug sessions until %s", res.Status, w.Name(), time.Now().Add(timeout).Format(time.RFC3339)),
+		Time:    time.Now(),
+	}
+	if err := w.ClientV2().V2QueuePushJobInfo(ctx, runJob.Region, runJob.ID, info); err != nil {
+		log.Error(ctx, "waitForDebugSessions> Unable to send spawn info: %v", err)
+	}
+	log.Info(ctx, "waitForDebugSessions> waiting for debug sessions for %v", timeout)
+
+	wsCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	goRoutines := sdk.NewGoRoutines(wsCtx)
+	d := &jobDebugger{
+		w:          w,
+		shells:     make(map[string]*debugShell),
+		chanToSend: make(chan json.RawMessage, 100),
+	}
+	defer d.closeShells()
+
+	chanMsgReceived := make(chan json.RawMessage)
+	chanErrorReceived := make(chan error)
+	goRoutines.Exec(wsCtx, "waitForDebugSessions.websocket", func(ctx context.Context) {
+		for ctx.Err() == nil {
+			if err := w.ClientV2().V2QueueJobDebug(ctx, goRoutines, runJob.Region, runJob.ID, d.chanToSend, chanMsgReceived, chanErrorReceived); err != nil && ctx.Err() == nil {
+				log.Warn(ctx, "waitForDebugSessions> websocket error: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			log.Info(ctx, "waitForDebugSessions> debug timeout reached")
+			d.release(ctx)
+			return
+		case err := <-chanErrorReceived:
+			log.Debug(ctx, "waitForDebugSessions> websocket error: %v", err)
+		case m := <-chanMsgReceived:
+			var msg sdk.V2JobDebugMessage
+			if err := json.Unmarshal(m, &msg); err != nil {
+				log.Warn(ctx, "waitForDebugSessions> unable to read message: %v", err)
+				continue
+			}
+			switch msg.Type {
+			case sdk.V2JobDebugMessageStart:
+				if err := d.startShell(wsCtx, msg.SessionID); err != nil {
+					log.Error(ctx, "waitForDebugSessions> unable to start shell for session %s: %v", msg.SessionID, err)
+					d.send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageOutput, SessionID: msg.SessionID, Data: fmt.Sprintf("unable to start shell: %v\n", err)})
+					d.send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageEnd, SessionID: msg.SessionID})
+				}
+			case sdk.V2JobDebugMessageInput:
+				d.mutex.Lock()
+				s, has := d.shells[msg.SessionID]
+				d.mutex.Unlock()
+				if has {
+					if _, err := io.WriteString(s.stdin, msg.Data); err != nil {
+						log.Warn(ctx, "waitForDebugSessions> unable to write to shell of session %s: %v", msg.SessionID, err)
+					}
+				}
+			case sdk.V2JobDebugMessageEnd:
+				d.closeShell(msg.SessionID)
+			case sdk.V2JobDebugMessageRelease:
+				log.Info(ctx, "waitForDebugSessions> job released by session %s", msg.SessionID)
+				d.release(ctx)
+				return
+			}
+		}
+	}
+}
+
+func (d *jobDebugger) send(msg sdk.V2JobDebugMessage) {
+	bts, _ := json.Marshal(msg)
+	select {
+	case d.chanToSend <- bts:
+	case <-time.After(5 * time.Second):
+		// The websocket is disconnected and the buffer is full
+	}
+}
+
+// release closes the sessions of the users then lets the API send the release to them before closing the websocket.
+func (d *jobDebugger) release(ctx context.Context) {
+	d.closeShells()
+	d.send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageRelease})
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+	}
+}
+
+func (d *jobDebugger) startShell(ctx context.Context, sessionID string) error {
+	shell, args := "/bin/sh", []string{"-i"}
+	if runtime.GOOS == "windows" {
+		shell, args = "PowerShell", []string{"-NoLogo", "-NoExit", "-Command", "-"}
+	}
+	cmd := exec.CommandContext(ctx, shell, args...)
+	cmd.Dir = d.w.workingDirAbs
+	cmd.Env = d.w.Environ()
+	for k, v := range d.w.currentJobV2.runJobContext.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		return sdk.WithStack(err)
+	}
+	log.Info(ctx, "jobDebugger> shell started for session %s", sessionID)
+
+	d.mutex.Lock()
+	d.shells[sessionID] = &debugShell{cmd: cmd, stdin: stdin}
+	d.mutex.Unlock()
+
+	outputDone := make(chan struct{})
+	go func() {
+		defer close(outputDone)
+		relayDebugOutput(reader, debugOutputIdleDelay, func(data string) {
+			d.send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageOutput, SessionID: sessionID, Data: d.w.blur.String(data)})
+		})
+	}()
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Info(ctx, "jobDebugger> shell of session %s exited: %v", sessionID, err)
+		}
+		writer.Close() // nolint
+		<-outputDone
+		d.mutex.Lock()
+		delete(d.shells, sessionID)
+		d.mutex.Unlock()
+		d.send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageEnd, SessionID: sessionID})
+	}()
+	return nil
+}
+
+// debugOutputIdleDelay is the delay after which a partial line of the output, like a prompt, is relayed.
+const debugOutputIdleDelay = 100 * time.Millisecond
+
+// debugOutputMaxLine is the size after which a line of the output is relayed without waiting for its end.
+const debugOutputMaxLine = 64 * 1024
+
+// relayDebugOutput relays the output of a shell by whole lines, so that secrets are blurred whatever the reads
+// that split them. A partial line is relayed when the shell writes nothing for the idle delay, or when it exceeds
+// debugOutputMaxLine.
+func relayDebugOutput(r io.Reader, idle time.Duration, relay func(string)) {
+	chunks := make(chan []byte)
+	go func() {
+		defer close(chunks)
+		for {
+			buf := make([]byte, 4096)
+			n, err := r.Read(buf)
+			if n > 0 {
+				chunks <- buf[:n]
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var pending []byte
+	flush := func(n int) {
+		if n == 0 {
+			return
+		}
+		relay(string(pending[:n]))
+		pending = append([]byte(nil), pending[n:]...)
+	}
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				flush(len(pending))
+				return
+			}
+			pending = append(pending, chunk...)
+			if len(pending) > debugOutputMaxLine {
+				flush(len(pending))
+			} else {
+				flush(bytes.LastIndexByte(pending, '\n') + 1)
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(idle)
+		case <-timer.C:
+			flush(len(pending))
+		}
+	}
+}
+
+func (d *jobDebugger) closeShell(sessionID string) {
+	d.mutex.Lock()
+	s, has := d.shells[sessionID]
+	d.mutex.Unlock()
+	if !has {
+		return
+	}
+	s.stdin.Close() // nolint
+	if s.cmd.Process != nil {
+		_ = s.cmd.Process.Kill()
+	}
+}
+
+func (d *jobDebugger) closeShells() {
+	d.mutex.Lock()
+	sessionIDs := make([]string, 0, len(d.shells))
+	for id := range d.shells {
+		sessionIDs = append(sessionIDs, id)
+	}
+	d.mutex.Unlock()
+	for _, id := range sessionIDs {
+		d.closeShell(id)
+	}
+}
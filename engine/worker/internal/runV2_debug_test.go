@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_jobDebuggerShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no /bin/sh on windows")
+	}
+	blur, err := sdk.NewBlur([]string{"my-secret-value"})
+	require.NoError(t, err)
+	w := &CurrentWorker{cfg: &workerruntime.WorkerConfig{}, blur: blur, workingDirAbs: os.TempDir()}
+	w.currentJobV2.runJobContext.Env = map[string]string{"MY_SECRET": "my-secret-value"}
+	d := &jobDebugger{
+		w:          w,
+		shells:     make(map[string]*debugShell),
+		chanToSend: make(chan json.RawMessage, 100),
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, d.startShell(ctx, "session"))
+	_, err = d.shells["session"].stdin.Write([]byte("echo value=$MY_SECRET\nexit\n"))
+	require.NoError(t, err)
+
+	var output strings.Builder
+	for {
+		select {
+		case <-ctx.Done():
+			t.Fatal("shell did not exit")
+		case m := <-d.chanToSend:
+			var msg sdk.V2JobDebugMessage
+			require.NoError(t, json.Unmarshal(m, &msg))
+			require.Equal(t, "session", msg.SessionID)
+			if msg.Type == sdk.V2JobDebugMessageOutput {
+				output.WriteString(msg.Data)
+				continue
+			}
+			require.Equal(t, sdk.V2JobDebugMessageEnd, msg.Type)
+			require.Contains(t, output.String(), "value=**********")
+			require.NotContains(t, output.String(), "my-secret-value")
+			require.Empty(t, d.shells)
+			return
+		}
+	}
+}
+
+func Test_relayDebugOutput(t *testing.T) {
+	blur, err := sdk.NewBlur([]string{"my-secret-value"})
+	require.NoError(t, err)
+
+	reader, writer := io.Pipe()
+	go func() {
+		// The secret is split across two reads, the prompt has no end of line
+		_, _ = writer.Write([]byte("value=my-sec"))
+		time.Sleep(10 * time.Millisecond)
+		_, _ = writer.Write([]byte("ret-value\n$ "))
+		time.Sleep(200 * time.Millisecond)
+		writer.Close() // nolint
+	}()
+
+	var relayed []string
+	relayDebugOutput(reader, 100*time.Millisecond, func(data string) {
+		relayed = append(relayed, blur.String(data))
+	})
+	require.Equal(t, []string{"value=**********\n", "$ "}, relayed)
+}
@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdn"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/jws"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+// jobService is a service declared on the job and started by the worker because the hatchery cannot start it
+type jobService struct {
+	name          string
+	containerName string // empty for the binary runtime
+	cmd           *exec.Cmd
+	exited        chan struct{}
+	logsDone      chan struct{}
+	logLine       int64
+}
+
+// startJobServices starts the job services when the hatchery delegates them to the worker.
+// Services are reachable on localhost, like with the kubernetes hatchery.
+func (w *CurrentWorker) startJobServices(ctx context.Context) ([]*jobService, error) {
+	if w.cfg == nil || w.cfg.JobServices == nil || len(w.currentJobV2.runJob.Job.Services) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(w.currentJobV2.runJob.Job.Services))
+	for name := range w.currentJobV2.runJob.Job.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	services := make([]*jobService, 0, len(names))
+	for _, name := range names {
+		s, err := w.startJobService(ctx, name, w.currentJobV2.runJob.Job.Services[name])
+		if err != nil {
+			w.stopJobServices(ctx, services)
+			return nil, err
+		}
+		services = append(services, s)
+	}
+	return services, nil
+}
+
+func (w *CurrentWorker) startJobService(ctx context.Context, name string, service sdk.V2JobService) (*jobService, error) {
+	s := &jobService{
+		name:     name,
+		exited:   make(chan struct{}),
+		logsDone: make(chan struct{}),
+	}
+	if w.cfg.JobServices.Runtime != workerruntime.JobServicesRuntimeBinary {
+		s.containerName = name + "-" + w.Name()
+	}
+
+	command, args, err := jobServiceCommand(*w.cfg.JobServices, s.containerName, service)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cmd = exec.Command(command, args...)
+	s.cmd.Dir = w.workingDirAbs
+	s.cmd.Env = os.Environ()
+	if s.containerName == "" {
+		for k, v := range service.Env {
+			s.cmd.Env = append(s.cmd.Env, k+"="+v)
+		}
+	}
+
+	// Stdout and stderr share the same writer, so the lines are read in order
+	reader, writer := io.Pipe()
+	s.cmd.Stdout = writer
+	s.cmd.Stderr = writer
+
+	// The arguments are not logged, they contain the environment variables of the service
+	log.Info(ctx, "starting service %s with image %s", name, service.Image)
+	if err := s.cmd.Start(); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to start service %s: %v", name, err)
+	}
+
+	go func() {
+		defer close(s.logsDone)
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			w.sendServiceLog(ctx, s, scanner.Text(), false)
+		}
+	}()
+	go func() {
+		defer close(s.exited)
+		err := s.cmd.Wait()
+		if err != nil {
+			log.Info(ctx, "service %s exited: %v", name, err)
+		}
+		writer.Close() // nolint
+	}()
+
+	info := sdk.V2SendJobRunInfo{
+		Level:   sdk.WorkflowRunInfoLevelInfo,
+		Message: fmt.Sprintf("service %s started by worker %s", name, w.Name()),
+		Time:    time.Now(),
+	}
+	if err := w.ClientV2().V2QueuePushJobInfo(ctx, w.currentJobV2.runJob.Region, w.currentJobV2.runJob.ID, info); err != nil {
+		log.Error(ctx, "startJobService> Unable to send spawn info: %v", err)
+	}
+	return s, nil
+}
+
+func (w *CurrentWorker) stopJobServices(ctx context.Context, services []*jobService) {
+	for _, s := range services {
+		if s.containerName != "" {
+			out, err := exec.Command(w.cfg.JobServices.Runtime, "rm", "-f", s.containerName).CombinedOutput()
+			if err != nil {
+				log.Error(ctx, "unable to remove service container %s: %v: %s", s.containerName, err, string(out))
+			}
+		}
+		select {
+		case <-s.exited:
+		default:
+			if err := s.cmd.Process.Kill(); err != nil {
+				log.Debug(ctx, "unable to kill service %s: %v", s.name, err)
+			}
+		}
+		select {
+		case <-s.logsDone:
+		case <-time.After(10 * time.Second):
+			log.Warn(ctx, "timeout while reading logs of service %s", s.name)
+		}
+		w.sendServiceLog(ctx, s, "", true)
+	}
+	if len(services) > 0 {
+		w.gelfLogger.hook.Flush()
+	}
+}
+
+// jobServiceCommand returns the command that runs the service in foreground
+func jobServiceCommand(cfg workerruntime.JobServicesConfig, containerName string, service sdk.V2JobService) (string, []string, error) {
+	serviceArgs := hatchery.ParseArgs(service.Env["CDS_SERVICE_ARGS"])
+
+	switch cfg.Runtime {
+	case workerruntime.JobServicesRuntimeDocker, workerruntime.JobServicesRuntimePodman:
+		args := []string{"run", "--rm", "--name", containerName, "--network", "host"}
+		if sm, ok := service.Env["CDS_SERVICE_MEMORY"]; ok {
+			args = append(args, "--memory", sm+"m")
+		}
+		keys := make([]string, 0, len(service.Env))
+		for k := range service.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			args = append(args, "-e", k+"="+service.Env[k])
+		}
+		args = append(args, service.Image)
+		args = append(args, serviceArgs...)
+		return cfg.Runtime, args, nil
+	case workerruntime.JobServicesRuntimeBinary:
+		command, ok := cfg.Binaries[service.Image]
+		if !ok {
+			// Fallback on the image name without tag
+			imageName := service.Image
+			if i := strings.LastIndex(imageName, ":"); i > strings.LastIndex(imageName, "/") {
+				imageName = imageName[:i]
+			}
+			command, ok = cfg.Binaries[imageName]
+		}
+		if !ok {
+			return "", nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no binary configured on this hatchery for service image %s", service.Image)
+		}
+		cmdArgs := hatchery.ParseArgs(command)
+		if len(cmdArgs) == 0 {
+			return "", nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid command configured for service image %s", service.Image)
+		}
+		return cmdArgs[0], append(cmdArgs[1:], serviceArgs...), nil
+	}
+	return "", nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid job services runtime %q", cfg.Runtime)
+}
+
+// sendServiceLog sends a service log line to the CDN, signed by the worker
+func (w *CurrentWorker) sendServiceLog(ctx context.Context, s *jobService, value string, terminated bool) {
+	sig := cdn.Signature{
+		Worker: &cdn.SignatureWorker{
+			WorkerID:   w.id,
+			WorkerName: w.Name(),
+		},
+		HatcheryService: &cdn.SignatureHatcheryService{
+			HatcheryName: w.cfg.HatcheryName,
+			ServiceName:  s.name,
+		},
+		ProjectKey:    w.currentJobV2.runJob.ProjectKey,
+		RunJobID:      w.currentJobV2.runJob.ID,
+		Timestamp:     time.Now().UnixNano(),
+		WorkflowName:  w.currentJobV2.runJob.WorkflowName,
+		WorkflowRunID: w.currentJobV2.runJob.WorkflowRunID,
+		RunNumber:     w.currentJobV2.runJob.RunNumber,
+		RunAttempt:    w.currentJobV2.runJob.RunAttempt,
+		JobName:       w.currentJobV2.runJob.JobID,
+		Region:        w.currentJobV2.runJob.Region,
+	}
+	signature, err := jws.Sign(w.signer, sig)
+	if err != nil {
+		log.Error(ctx, "unable to sign log of service %s: %v", s.name, err)
+		return
+	}
+	if w.blur != nil {
+		value = w.blur.String(value)
+	}
+	w.gelfLogger.logger.
+		WithField(cdslog.ExtraFieldSignature, signature).
+		WithField(cdslog.ExtraFieldLine, s.logLine).
+		WithField(cdslog.ExtraFieldTerminated, terminated).
+		Log(logrus.InfoLevel, sdk.RemoveNotPrintableChar(value))
+	s.logLine++
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_jobServiceCommandContainer(t *testing.T) {
+	service := sdk.V2JobService{
+		Image: "postgres:14",
+		Env: map[string]string{
+			"POSTGRES_PASSWORD":  "pg",
+			"CDS_SERVICE_MEMORY": "512",
+			"CDS_SERVICE_ARGS":   "-c 'max_connections=200'",
+		},
+	}
+
+	cmd, args, err := jobServiceCommand(workerruntime.JobServicesConfig{Runtime: workerruntime.JobServicesRuntimePodman}, "pg-my-worker", service)
+	require.NoError(t, err)
+	require.Equal(t, "podman", cmd)
+	require.Equal(t, []string{
+		"run", "--rm", "--name", "pg-my-worker", "--network", "host", "--memory", "512m",
+		"-e", "CDS_SERVICE_ARGS=-c 'max_connections=200'",
+		"-e", "CDS_SERVICE_MEMORY=512",
+		"-e", "POSTGRES_PASSWORD=pg",
+		"postgres:14", "-c", "max_connections=200",
+	}, args)
+}
+
+func Test_jobServiceCommandBinary(t *testing.T) {
+	cfg := workerruntime.JobServicesConfig{
+		Runtime: workerruntime.JobServicesRuntimeBinary,
+		Binaries: map[string]string{
+			"redis":       "/usr/bin/redis-server --port 6379",
+			"postgres:14": "/usr/lib/postgresql/14/bin/postgres -D '/tmp/pg data'",
+		},
+	}
+
+	cmd, args, err := jobServiceCommand(cfg, "", sdk.V2JobService{Image: "postgres:14"})
+	require.NoError(t, err)
+	require.Equal(t, "/usr/lib/postgresql/14/bin/postgres", cmd)
+	require.Equal(t, []string{"-D", "/tmp/pg data"}, args)
+
+	// Fallback on the image name without tag
+	cmd, args, err = jobServiceCommand(cfg, "", sdk.V2JobService{Image: "redis:7", Env: map[string]string{"CDS_SERVICE_ARGS": "--save ''"}})
+	require.NoError(t, err)
+	require.Equal(t, "/usr/bin/redis-server", cmd)
+	require.Equal(t, []string{"--port", "6379", "--save", ""}, args)
+
+	_, _, err = jobServiceCommand(cfg, "", sdk.V2JobService{Image: "my-registry.local:5000/mysql"})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
+
+func TestCurrentWorker_startJobServicesDisabled(t *testing.T) {
+	var w = new(CurrentWorker)
+	w.cfg = &workerruntime.WorkerConfig{}
+	w.currentJobV2.runJob = &sdk.V2WorkflowRunJob{
+		Job: sdk.V2Job{
+			Services: map[string]sdk.V2JobService{
+				"srv": {Image: "the-image"},
+			},
+		},
+	}
+	// Services are started by the hatchery
+	services, err := w.startJobServices(context.TODO())
+	require.NoError(t, err)
+	require.Empty(t, services)
+}
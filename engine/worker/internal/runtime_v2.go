@@ -37,30 +37,10 @@ func (wk *CurrentWorker) V2AddRunResult(ctx context.Context, req workerruntime.V
 		RunResult: runResult,
 	}
 
-	if integ == nil {
+	// Attestations are always stored in CDN
+	if integ == nil || runResult.Type == sdk.V2WorkflowRunResultTypeAttestation {
 		// Generate a worker signature
-		sig := cdn.Signature{
-			JobName:       wk.currentJobV2.runJob.Job.Name,
-			RunJobID:      wk.currentJobV2.runJob.ID,
-			ProjectKey:    wk.currentJobV2.runJob.ProjectKey,
-			WorkflowName:  wk.currentJobV2.runJob.WorkflowName,
-			WorkflowRunID: wk.currentJobV2.runJob.WorkflowRunID,
-			RunNumber:     wk.currentJobV2.runJob.RunNumber,
-			RunAttempt:    wk.currentJobV2.runJob.RunAttempt,
-			Region:        wk.currentJobV2.runJob.Region,
-
-			Timestamp: time.Now().UnixNano(),
-
-			Worker: &cdn.SignatureWorker{
-				WorkerID:      wk.id,
-				WorkerName:    wk.Name(),
-				RunResultName: runResult.Name(),
-				RunResultType: runResult.Typ(),
-				RunResultID:   runResult.ID,
-			},
-		}
-
-		signature, err := jws.Sign(wk.signer, sig)
+		signature, err := jws.Sign(wk.signer, wk.v2RunResultCDNSignature(runResult))
 		if err != nil {
 			return nil, sdk.NewError(sdk.ErrUnknownError, err)
 		}
@@ -79,6 +59,10 @@ func (wk *CurrentWorker) V2AddRunResult(ctx context.Context, req workerruntime.V
 		return nil, err
 	}
 
+	if req.SBOMPath != "" {
+		wk.setRunResultSBOMPath(response.RunResult.ID, req.SBOMPath)
+	}
+
 	if response.RunResult.Status == sdk.V2WorkflowRunResultStatusCompleted {
 		wk.clientV2.V2QueuePushJobInfo(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, sdk.V2SendJobRunInfo{
 			Level:   sdk.WorkflowRunInfoLevelInfo,
@@ -90,11 +74,36 @@ func (wk *CurrentWorker) V2AddRunResult(ctx context.Context, req workerruntime.V
 			Level:   sdk.WorkflowRunInfoLevelInfo,
 			Message: fmt.Sprintf("Job %q issued a new result %q", wk.currentJobV2.runJob.JobID, response.RunResult.Name()),
 		})
+
+		wk.attestRunResult(ctx, response.RunResult)
 	}
 
 	return &response, nil
 }
 
+func (wk *CurrentWorker) v2RunResultCDNSignature(runResult *sdk.V2WorkflowRunResult) cdn.Signature {
+	return cdn.Signature{
+		JobName:       wk.currentJobV2.runJob.Job.Name,
+		RunJobID:      wk.currentJobV2.runJob.ID,
+		ProjectKey:    wk.currentJobV2.runJob.ProjectKey,
+		WorkflowName:  wk.currentJobV2.runJob.WorkflowName,
+		WorkflowRunID: wk.currentJobV2.runJob.WorkflowRunID,
+		RunNumber:     wk.currentJobV2.runJob.RunNumber,
+		RunAttempt:    wk.currentJobV2.runJob.RunAttempt,
+		Region:        wk.currentJobV2.runJob.Region,
+
+		Timestamp: time.Now().UnixNano(),
+
+		Worker: &cdn.SignatureWorker{
+			WorkerID:      wk.id,
+			WorkerName:    wk.Name(),
+			RunResultName: runResult.Name(),
+			RunResultType: runResult.Typ(),
+			RunResultID:   runResult.ID,
+		},
+	}
+}
+
 func (wk *CurrentWorker) addRunResultToCurrentJobContext(_ context.Context, newRunResult *sdk.V2WorkflowRunResult) error {
 	jobContext, has := wk.currentJobV2.runJobContext.Jobs[wk.currentJobV2.runJob.JobID]
 	if !has {
@@ -138,11 +147,15 @@ func (wk *CurrentWorker) V2GetProjectKey(ctx context.Context, keyName string, cl
 	return k, err
 }
 
-func (wk *CurrentWorker) V2GetCacheLink(ctx context.Context, cacheKey string) (*sdk.CDNItemLinks, error) {
-	links, err := wk.clientV2.V2QueueGetCacheLinks(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, cacheKey)
+func (wk *CurrentWorker) V2GetCacheLink(ctx context.Context, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
+	links, err := wk.clientV2.V2QueueGetCacheLinks(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, cacheKey, restoreKeys)
 	return links, err
 }
 
+func (wk *CurrentWorker) V2GetTestQuarantines(ctx context.Context) (sdk.V2WorkflowTestQuarantines, error) {
+	return wk.clientV2.V2QueueGetTestQuarantines(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID)
+}
+
 func (wk *CurrentWorker) V2GetCacheSignature(ctx context.Context, cacheKey string) (*workerruntime.CDNSignature, error) {
 	return wk.WorkerCacheSignatureV2(cacheKey)
 }
@@ -202,6 +215,13 @@ func (wk *CurrentWorker) V2UpdateRunResult(ctx context.Context, req workerruntim
 		})
 	}
 
+	if req.SBOMPath != "" {
+		wk.setRunResultSBOMPath(runResult.ID, req.SBOMPath)
+	}
+	if runResult.Status == sdk.V2WorkflowRunResultStatusCompleted && (runResult.DataSync == nil || runResult.DataSync.LatestPromotionOrRelease() == nil) {
+		wk.attestRunResult(ctx, runResult)
+	}
+
 	return &workerruntime.V2UpdateResultResponse{RunResult: runResult}, nil
 }
 
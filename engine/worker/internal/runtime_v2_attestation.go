@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rockbears/log"
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func (wk *CurrentWorker) setRunResultSBOMPath(runResultID, sbomPath string) {
+	if wk.currentJobV2.sbomPaths == nil {
+		wk.currentJobV2.sbomPaths = make(map[string]string)
+	}
+	wk.currentJobV2.sbomPaths[runResultID] = sbomPath
+}
+
+// attestRunResult adds the signed provenance of a completed run result, and its SBOM if a step gave one.
+// Attestation failures are reported as job warnings and do not fail the job.
+func (wk *CurrentWorker) attestRunResult(ctx context.Context, runResult *sdk.V2WorkflowRunResult) {
+	if !runResult.IsAttestable() {
+		return
+	}
+	if _, has := wk.currentJobV2.attestedRunResults[runResult.ID]; has {
+		return
+	}
+	if wk.currentJobV2.attestedRunResults == nil {
+		wk.currentJobV2.attestedRunResults = make(map[string]struct{})
+	}
+	wk.currentJobV2.attestedRunResults[runResult.ID] = struct{}{}
+
+	if err := wk.attestRunResultWithError(ctx, runResult); err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		wk.clientV2.V2QueuePushJobInfo(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, sdk.V2SendJobRunInfo{
+			Level:   sdk.WorkflowRunInfoLevelWarning,
+			Message: fmt.Sprintf("unable to attest result %q: %v", runResult.Name(), sdk.ExtractHTTPError(err).Error()),
+			Time:    time.Now(),
+		})
+	}
+}
+
+func (wk *CurrentWorker) attestRunResultWithError(ctx context.Context, runResult *sdk.V2WorkflowRunResult) error {
+	subjects, err := runResult.AttestationSubjects()
+	if err != nil {
+		return err
+	}
+	if len(subjects) == 0 {
+		log.Info(ctx, "no digest found for run result %s, skipping attestation", runResult.Name())
+		return nil
+	}
+
+	if err := wk.addAttestation(ctx, runResult, "provenance", nil); err != nil {
+		return err
+	}
+
+	sbomPath, has := wk.currentJobV2.sbomPaths[runResult.ID]
+	if !has {
+		return nil
+	}
+	if !filepath.IsAbs(sbomPath) {
+		sbomPath = filepath.Join(wk.currentJobV2.runJobContext.CDS.Workspace, sbomPath)
+	}
+	sbom, err := os.ReadFile(sbomPath)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read SBOM %s: %v", sbomPath, err)
+	}
+	if !json.Valid(sbom) {
+		return sdk.NewErrorFrom(sdk.ErrInvalidData, "invalid SBOM %s, a SPDX or CycloneDX JSON document is expected", sbomPath)
+	}
+	return wk.addAttestation(ctx, runResult, "sbom", sbom)
+}
+
+// addAttestation asks the API to build and sign the statement about the subject, the provenance of the subject if no SBOM is given.
+// The envelope is stored in CDN as a run result linked to the subject.
+func (wk *CurrentWorker) addAttestation(ctx context.Context, subject *sdk.V2WorkflowRunResult, kind string, sbom []byte) error {
+	signed, err := wk.clientV2.V2QueueJobRunAttestationSign(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, sdk.V2AttestationSignRequest{
+		RunResultID: subject.ID,
+		SBOM:        sbom,
+	})
+	if err != nil {
+		return err
+	}
+	subjectDetail, err := subject.GetDetail()
+	if err != nil {
+		return err
+	}
+
+	runResult := &sdk.V2WorkflowRunResult{
+		IssuedAt: time.Now(),
+		Type:     sdk.V2WorkflowRunResultTypeAttestation,
+		Status:   sdk.V2WorkflowRunResultStatusPending,
+		Detail: sdk.V2WorkflowRunResultDetail{
+			Data: sdk.V2WorkflowRunResultAttestationDetail{
+				Name:               fmt.Sprintf("%s.%s.intoto.json", subjectDetail.GetName(), kind),
+				PredicateType:      signed.Statement.PredicateType,
+				SubjectRunResultID: subject.ID,
+				Subjects:           signed.Statement.Subject,
+				Signer:             signed.Signer,
+				Envelope:           signed.Envelope,
+			},
+		},
+	}
+	resp, err := wk.V2AddRunResult(ctx, workerruntime.V2RunResultRequest{RunResult: runResult})
+	if err != nil {
+		return err
+	}
+
+	btes, err := json.Marshal(signed.Envelope)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, runResult.ID, btes, 0644); err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := wk.client.CDNItemUpload(ctx, wk.cfg.CDNEndpoint, resp.CDNSignature, fs, runResult.ID); err != nil {
+		return err
+	}
+
+	apiRefHash, err := sdk.NewCDNRunResultApiRefV2(wk.v2RunResultCDNSignature(runResult)).ToHash()
+	if err != nil {
+		return err
+	}
+	runResult.ArtifactManagerMetadata = &sdk.V2WorkflowRunResultArtifactManagerMetadata{
+		"cdn_type":          string(sdk.CDNTypeItemRunResultV2),
+		"cdn_api_ref_hash":  apiRefHash,
+		"cdn_download_path": fmt.Sprintf("/item/%s/%s/download", sdk.CDNTypeItemRunResultV2, apiRefHash),
+	}
+	runResult.Status = sdk.V2WorkflowRunResultStatusCompleted
+	_, err = wk.V2UpdateRunResult(ctx, workerruntime.V2RunResultRequest{RunResult: runResult})
+	return err
+}
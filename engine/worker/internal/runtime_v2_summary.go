@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func stepSummaryFileNames(stepIndex int) (string, string) {
+	return fmt.Sprintf("step-%d-summary.md", stepIndex), fmt.Sprintf("step-%d-annotations.jsonl", stepIndex)
+}
+
+// prepareStepSummaryFiles creates the empty files where the step can write its summary and its annotations.
+func (wk *CurrentWorker) prepareStepSummaryFiles(stepIndex int) error {
+	if wk.currentJobV2.tmpDirectory == "" {
+		return nil
+	}
+	summaryFile, annotationsFile := stepSummaryFileNames(stepIndex)
+	for _, f := range []string{summaryFile, annotationsFile} {
+		if err := afero.WriteFile(wk.basedir, filepath.Join(wk.currentJobV2.tmpDirectory, f), nil, os.FileMode(0600)); err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (wk *CurrentWorker) V2AddStepSummary(ctx context.Context, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	for i := range req.Annotations {
+		if err := req.Annotations[i].Check(); err != nil {
+			return err
+		}
+	}
+
+	wk.stepSummaryMutex.Lock()
+	defer wk.stepSummaryMutex.Unlock()
+	if req.Summary != "" {
+		if wk.currentJobV2.stepSummary.Summary != "" && !strings.HasSuffix(wk.currentJobV2.stepSummary.Summary, "\n") {
+			wk.currentJobV2.stepSummary.Summary += "\n"
+		}
+		wk.currentJobV2.stepSummary.Summary += req.Summary
+	}
+	wk.currentJobV2.stepSummary.Annotations = append(wk.currentJobV2.stepSummary.Annotations, req.Annotations...)
+	return nil
+}
+
+// sendStepSummary sends to the API what the step wrote in its summary files and gave through the worker commands.
+// The step result does not depend on it: failures are reported as job warnings.
+func (wk *CurrentWorker) sendStepSummary(ctx context.Context, stepIndex int, stepName string) {
+	wk.stepSummaryMutex.Lock()
+	req := wk.currentJobV2.stepSummary
+	wk.currentJobV2.stepSummary = sdk.V2WorkflowRunJobSummaryRequest{}
+	wk.stepSummaryMutex.Unlock()
+	req.StepName = stepName
+
+	if wk.currentJobV2.tmpDirectory != "" {
+		summaryFile, annotationsFile := stepSummaryFileNames(stepIndex)
+		summary, err := afero.ReadFile(wk.basedir, filepath.Join(wk.currentJobV2.tmpDirectory, summaryFile))
+		if err != nil && !os.IsNotExist(err) {
+			wk.sendStepSummaryWarning(ctx, stepName, fmt.Sprintf("unable to read %s: %v", StepSummaryFile, err))
+		}
+		if len(summary) > 0 {
+			req.Summary = string(summary) + req.Summary
+		}
+
+		content, err := afero.ReadFile(wk.basedir, filepath.Join(wk.currentJobV2.tmpDirectory, annotationsFile))
+		if err != nil && !os.IsNotExist(err) {
+			wk.sendStepSummaryWarning(ctx, stepName, fmt.Sprintf("unable to read %s: %v", StepAnnotationsFile, err))
+		}
+		annotations, err := sdk.ParseV2WorkflowRunJobAnnotations(content)
+		if err != nil {
+			wk.sendStepSummaryWarning(ctx, stepName, fmt.Sprintf("unable to read %s: %v", StepAnnotationsFile, err))
+		}
+		req.Annotations = append(annotations, req.Annotations...)
+	}
+
+	if strings.TrimSpace(req.Summary) == "" && len(req.Annotations) == 0 {
+		return
+	}
+
+	// The summary is displayed in the UI, secrets must not leak there
+	req.Summary = wk.blur.String(req.Summary)
+	for i := range req.Annotations {
+		req.Annotations[i].Title = wk.blur.String(req.Annotations[i].Title)
+		req.Annotations[i].Message = wk.blur.String(req.Annotations[i].Message)
+	}
+	if err := req.Check(); err != nil {
+		wk.sendStepSummaryWarning(ctx, stepName, err.Error())
+		return
+	}
+	if err := wk.clientV2.V2QueuePushJobSummary(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, req); err != nil {
+		wk.sendStepSummaryWarning(ctx, stepName, fmt.Sprintf("unable to send summary: %v", err))
+	}
+}
+
+func (wk *CurrentWorker) sendStepSummaryWarning(ctx context.Context, stepName string, msg string) {
+	msg = fmt.Sprintf("Step %s: %s", stepName, msg)
+	wk.SendLog(ctx, workerruntime.LevelWarn, msg)
+	if err := wk.clientV2.V2QueuePushJobInfo(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, sdk.V2SendJobRunInfo{
+		Level:   sdk.WorkflowRunInfoLevelWarning,
+		Message: msg,
+		Time:    time.Now(),
+	}); err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+	}
+}
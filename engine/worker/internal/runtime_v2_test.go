@@ -10,6 +10,7 @@ import (
 	"github.com/ovh/cds/sdk/jws"
 	cdslog "github.com/ovh/cds/sdk/log"
 	"github.com/ovh/cds/sdk/log/hook/graylog"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -92,3 +93,96 @@ func TestGetRunResult(t *testing.T) {
 
 	require.Equal(t, 4, len(result.RunResults))
 }
+
+func TestSendStepSummary(t *testing.T) {
+	var w = new(CurrentWorker)
+	ctx := context.TODO()
+
+	w.basedir = afero.NewMemMapFs()
+	w.currentJobV2.runJob = &sdk.V2WorkflowRunJob{
+		ID:     sdk.UUID(),
+		Status: sdk.V2WorkflowRunJobStatusBuilding,
+		JobID:  "myjob",
+		Region: "build",
+	}
+	w.currentJobV2.tmpDirectory = "tmp"
+	require.NoError(t, w.basedir.MkdirAll("tmp", 0700))
+	var err error
+	w.blur, err = sdk.NewBlur(nil)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_cdsclient.NewMockV2WorkerInterface(ctrl)
+	w.clientV2 = mockClient
+	t.Cleanup(func() {
+		w.clientV2 = nil
+		ctrl.Finish()
+	})
+
+	require.NoError(t, w.prepareStepSummaryFiles(0))
+	summaryFile, annotationsFile := stepSummaryFileNames(0)
+	require.NoError(t, afero.WriteFile(w.basedir, "tmp/"+summaryFile, []byte("# Report\n"), 0600))
+	require.NoError(t, afero.WriteFile(w.basedir, "tmp/"+annotationsFile, []byte(`{"file": "main.go", "line": 3, "level": "failure", "message": "nil pointer"}`), 0600))
+	require.NoError(t, w.V2AddStepSummary(ctx, sdk.V2WorkflowRunJobSummaryRequest{
+		Summary:     "All good",
+		Annotations: sdk.V2WorkflowRunJobAnnotations{{File: "go.mod", Message: "outdated"}},
+	}))
+
+	mockClient.EXPECT().V2QueuePushJobSummary(gomock.Any(), "build", w.currentJobV2.runJob.ID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, region, runJobID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+			require.Equal(t, "step-0", req.StepName)
+			require.Equal(t, "# Report\nAll good", req.Summary)
+			require.Len(t, req.Annotations, 2)
+			require.Equal(t, "main.go", req.Annotations[0].File)
+			require.Equal(t, sdk.V2WorkflowRunJobAnnotationLevelNotice, req.Annotations[1].Level)
+			return nil
+		},
+	)
+	w.sendStepSummary(ctx, 0, "step-0")
+
+	// Nothing left to send for the next step
+	require.NoError(t, w.prepareStepSummaryFiles(1))
+	w.sendStepSummary(ctx, 1, "step-1")
+}
+
+func TestSendStepSummaryBlurSecrets(t *testing.T) {
+	var w = new(CurrentWorker)
+	ctx := context.TODO()
+
+	w.basedir = afero.NewMemMapFs()
+	w.currentJobV2.runJob = &sdk.V2WorkflowRunJob{
+		ID:     sdk.UUID(),
+		Status: sdk.V2WorkflowRunJobStatusBuilding,
+		JobID:  "myjob",
+		Region: "build",
+	}
+	w.currentJobV2.tmpDirectory = "tmp"
+	require.NoError(t, w.basedir.MkdirAll("tmp", 0700))
+	var err error
+	w.blur, err = sdk.NewBlur([]string{"my-secret-value"})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_cdsclient.NewMockV2WorkerInterface(ctrl)
+	w.clientV2 = mockClient
+	t.Cleanup(func() {
+		w.clientV2 = nil
+		ctrl.Finish()
+	})
+
+	require.NoError(t, w.prepareStepSummaryFiles(0))
+	summaryFile, annotationsFile := stepSummaryFileNames(0)
+	require.NoError(t, afero.WriteFile(w.basedir, "tmp/"+summaryFile, []byte("token: my-secret-value\n"), 0600))
+	require.NoError(t, afero.WriteFile(w.basedir, "tmp/"+annotationsFile, []byte(`{"file": "main.go", "title": "my-secret-value", "message": "invalid token my-secret-value"}`), 0600))
+
+	mockClient.EXPECT().V2QueuePushJobSummary(gomock.Any(), "build", w.currentJobV2.runJob.ID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, region, runJobID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+			require.Equal(t, "token: "+sdk.PasswordPlaceholder+"\n", req.Summary)
+			require.Len(t, req.Annotations, 1)
+			require.Equal(t, sdk.PasswordPlaceholder, req.Annotations[0].Title)
+			require.Equal(t, "invalid token "+sdk.PasswordPlaceholder, req.Annotations[0].Message)
+			return nil
+		},
+	)
+	w.sendStepSummary(ctx, 0, "step-0")
+}
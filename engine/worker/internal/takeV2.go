@@ -87,6 +87,7 @@ func (w *CurrentWorker) V2Take(ctx context.Context, region, jobRunID string) err
 		return sdk.WithStack(err)
 	}
 	w.SetGelfLogger(h, l)
+	w.setJobLogLevel()
 
 	// This goroutine try to get the job every 5 seconds, if it fails, it cancel the build.
 	tick := time.NewTicker(5 * time.Second)
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -32,6 +33,10 @@ const (
 	// CDS API URL
 	CDSApiUrl = "CDS_API_URL"
 	CDSCDNUrl = "CDS_CDN_URL"
+
+	// Files where a step writes its Markdown summary and its annotations
+	StepSummaryFile     = "CDS_STEP_SUMMARY"
+	StepAnnotationsFile = "CDS_STEP_ANNOTATIONS"
 )
 
 type logger struct {
@@ -50,6 +55,11 @@ type CurrentJobV2 struct {
 	sensitiveDatas         []string
 	runningStepStatus      sdk.JobStepsStatus
 	subStepName            string
+	sbomPaths              map[string]string   // SBOM given by the steps, by run result ID
+	attestedRunResults     map[string]struct{} // run results that already have a provenance
+	tmpDirectory           string              // job tmp directory, in the worker base directory
+	tmpDirectoryAbs        string
+	stepSummary            sdk.V2WorkflowRunJobSummaryRequest // summary and annotations given by the worker commands during the current step
 }
 
 type CurrentWorker struct {
@@ -70,6 +80,7 @@ type CurrentWorker struct {
 	actions               map[string]sdk.V2Action
 	pluginFactory         plugin.Factory
 	currentJobV2          CurrentJobV2
+	stepSummaryMutex      sync.Mutex
 	currentJob            struct {
 		wJob             *sdk.WorkflowNodeJobRun
 		newVariables     []sdk.Variable
@@ -204,6 +215,7 @@ func (wk *CurrentWorker) WorkerCacheSignatureV2(cacheKey string) (*workerruntime
 			WorkerID:   wk.id,
 			WorkerName: wk.Name(),
 			CacheTag:   cacheKey,
+			CacheRef:   wk.currentJobV2.runJobContext.Git.Ref,
 		},
 	}
 	signature, err := jws.Sign(wk.signer, sig)
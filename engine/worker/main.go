@@ -27,9 +27,13 @@ func main() {
 		} else {
 			cmd.AddCommand(CmdResult())
 			cmd.AddCommand(CmdOutput())
+			cmd.AddCommand(CmdSummary())
+			cmd.AddCommand(CmdAnnotation())
 		}
 	} else {
 		cmd.AddCommand(cmdRegister())
+		cmd.AddCommand(cmdAgent())
+		cmd.AddCommand(cmdReplay())
 	}
 	// last command: doc, this command is hidden
 	cmd.AddCommand(cmdDoc(cmd))
@@ -40,7 +40,7 @@ func V2_cacheLinkHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
 		}
 		switch r.Method {
 		case http.MethodGet:
-			cdnLinks, err := wk.V2GetCacheLink(r.Context(), cacheKey)
+			cdnLinks, err := wk.V2GetCacheLink(r.Context(), cacheKey, r.URL.Query()["restoreKey"])
 			if err != nil && !strings.Contains(err.Error(), "resource not found") {
 				writeError(w, r, err)
 				return
@@ -130,6 +130,49 @@ func V2_projectKeyHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
 	}
 }
 
+func V2_testQuarantineHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			quarantines, err := wk.V2GetTestQuarantines(r.Context())
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			writeJSON(w, quarantines, http.StatusOK)
+		default:
+			writeError(w, r, sdk.ErrMethodNotAllowed)
+			return
+		}
+	}
+}
+
+func V2_summaryHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, r, sdk.ErrMethodNotAllowed)
+			return
+		}
+		btes, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+
+		var req sdk.V2WorkflowRunJobSummaryRequest
+		if err := sdk.JSONUnmarshal(btes, &req); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+
+		if err := wk.V2AddStepSummary(r.Context(), req); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, nil, http.StatusNoContent)
+	}
+}
+
 func V2_contextHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -436,19 +436,33 @@ func (mr *MockRuntimeMockRecorder) V2AddRunResult(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2AddRunResult", reflect.TypeOf((*MockRuntime)(nil).V2AddRunResult), ctx, req)
 }
 
+// V2AddStepSummary mocks base method.
+func (m *MockRuntime) V2AddStepSummary(ctx context.Context, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2AddStepSummary", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2AddStepSummary indicates an expected call of V2AddStepSummary.
+func (mr *MockRuntimeMockRecorder) V2AddStepSummary(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2AddStepSummary", reflect.TypeOf((*MockRuntime)(nil).V2AddStepSummary), ctx, req)
+}
+
 // V2GetCacheLink mocks base method.
-func (m *MockRuntime) V2GetCacheLink(ctx context.Context, cacheKey string) (*sdk.CDNItemLinks, error) {
+func (m *MockRuntime) V2GetCacheLink(ctx context.Context, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "V2GetCacheLink", ctx, cacheKey)
+	ret := m.ctrl.Call(m, "V2GetCacheLink", ctx, cacheKey, restoreKeys)
 	ret0, _ := ret[0].(*sdk.CDNItemLinks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // V2GetCacheLink indicates an expected call of V2GetCacheLink.
-func (mr *MockRuntimeMockRecorder) V2GetCacheLink(ctx, cacheKey any) *gomock.Call {
+func (mr *MockRuntimeMockRecorder) V2GetCacheLink(ctx, cacheKey, restoreKeys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2GetCacheLink", reflect.TypeOf((*MockRuntime)(nil).V2GetCacheLink), ctx, cacheKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2GetCacheLink", reflect.TypeOf((*MockRuntime)(nil).V2GetCacheLink), ctx, cacheKey, restoreKeys)
 }
 
 // V2GetCacheSignature mocks base method.
@@ -524,6 +538,21 @@ func (mr *MockRuntimeMockRecorder) V2GetRunResult(ctx, filter any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2GetRunResult", reflect.TypeOf((*MockRuntime)(nil).V2GetRunResult), ctx, filter)
 }
 
+// V2GetTestQuarantines mocks base method.
+func (m *MockRuntime) V2GetTestQuarantines(ctx context.Context) (sdk.V2WorkflowTestQuarantines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2GetTestQuarantines", ctx)
+	ret0, _ := ret[0].(sdk.V2WorkflowTestQuarantines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2GetTestQuarantines indicates an expected call of V2GetTestQuarantines.
+func (mr *MockRuntimeMockRecorder) V2GetTestQuarantines(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2GetTestQuarantines", reflect.TypeOf((*MockRuntime)(nil).V2GetTestQuarantines), ctx)
+}
+
 // V2RunResultsSynchronize mocks base method.
 func (m *MockRuntime) V2RunResultsSynchronize(ctx context.Context) error {
 	m.ctrl.T.Helper()
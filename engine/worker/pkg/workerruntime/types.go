@@ -19,6 +19,8 @@ import (
 type V2RunResultRequest struct {
 	RunResult   *sdk.V2WorkflowRunResult
 	CDNItemLink sdk.CDNItemLink // TODO
+	// SBOMPath is the path of a SPDX or CycloneDX JSON document describing the run result, it is attested with its provenance
+	SBOMPath string
 }
 
 type V2AddResultResponse struct {
@@ -45,21 +47,45 @@ type V2WorkerConfig struct {
 }
 
 type WorkerConfig struct {
-	Name                     string            `json:"name"`
-	Basedir                  string            `json:"basedir"`
-	Log                      cdslog.Conf       `json:"log"`
-	HatcheryName             string            `json:"hatchery_name"`
-	APIEndpoint              string            `json:"api_endpoint"`
-	APIEndpointInsecure      bool              `json:"api_endpoint_insecure,omitempty"`
-	APIToken                 string            `json:"api_token"`
-	CDNEndpoint              string            `json:"cdn_endpoint"`
-	GelfServiceAddr          string            `json:"gelf_service_addr"`
-	GelfServiceAddrEnableTLS bool              `json:"gelf_service_addr_enable_tls,omitempty"`
-	Model                    string            `json:"model"`
-	BookedJobID              int64             `json:"booked_job_id,omitempty"`
-	RunJobID                 string            `json:"run_job_id,omitempty"`
-	Region                   string            `json:"region,omitempty"`
-	InjectEnvVars            map[string]string `json:"inject_env_vars,omitempty"`
+	Name                     string             `json:"name"`
+	Basedir                  string             `json:"basedir"`
+	Log                      cdslog.Conf        `json:"log"`
+	HatcheryName             string             `json:"hatchery_name"`
+	APIEndpoint              string             `json:"api_endpoint"`
+	APIEndpointInsecure      bool               `json:"api_endpoint_insecure,omitempty"`
+	APIToken                 string             `json:"api_token"`
+	CDNEndpoint              string             `json:"cdn_endpoint"`
+	GelfServiceAddr          string             `json:"gelf_service_addr"`
+	GelfServiceAddrEnableTLS bool               `json:"gelf_service_addr_enable_tls,omitempty"`
+	Model                    string             `json:"model"`
+	BookedJobID              int64              `json:"booked_job_id,omitempty"`
+	RunJobID                 string             `json:"run_job_id,omitempty"`
+	Region                   string             `json:"region,omitempty"`
+	InjectEnvVars            map[string]string  `json:"inject_env_vars,omitempty"`
+	JobServices              *JobServicesConfig `json:"job_services,omitempty"`
+}
+
+const (
+	JobServicesRuntimeDocker = "docker"
+	JobServicesRuntimePodman = "podman"
+	JobServicesRuntimeBinary = "binary"
+)
+
+// JobServicesConfig is set by hatcheries that cannot start job services next to the worker.
+// The worker starts the services itself with the given runtime.
+type JobServicesConfig struct {
+	Runtime string `json:"runtime"`
+	// Binaries contains the command to start for each service image, used with the binary runtime
+	Binaries map[string]string `json:"binaries,omitempty"`
+}
+
+// IsValidJobServicesRuntime checks the runtime value of a job services configuration
+func IsValidJobServicesRuntime(runtime string) bool {
+	switch runtime {
+	case JobServicesRuntimeDocker, JobServicesRuntimePodman, JobServicesRuntimeBinary:
+		return true
+	}
+	return false
 }
 
 func (cfg WorkerConfig) EncodeBase64() string {
@@ -173,8 +199,10 @@ type Runtime interface {
 	V2GetJobRun(ctx context.Context) *sdk.V2WorkflowRunJob
 	V2GetJobContext(ctx context.Context) *sdk.WorkflowRunJobsContext
 	V2GetCacheSignature(ctx context.Context, cacheKey string) (*CDNSignature, error)
-	V2GetCacheLink(ctx context.Context, cacheKey string) (*sdk.CDNItemLinks, error)
+	V2GetCacheLink(ctx context.Context, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error)
 	V2GetProjectKey(ctx context.Context, keyName string, clear bool) (*sdk.ProjectKey, error)
+	V2GetTestQuarantines(ctx context.Context) (sdk.V2WorkflowTestQuarantines, error)
+	V2AddStepSummary(ctx context.Context, req sdk.V2WorkflowRunJobSummaryRequest) error
 }
 
 func JobID(ctx context.Context) (int64, error) {
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func CmdSummary() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "summary",
+		Short: "worker summary [<markdown>]",
+		Long: `Inside a job, append Markdown content to the summary of the current step. The content is read from stdin if it is not given as argument.
+
+The summary can also be written in the file given by the CDS_STEP_SUMMARY environment variable.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var content string
+			switch len(args) {
+			case 0:
+				stdin, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					sdk.Exit("Error reading stdin: %v", err)
+				}
+				content = string(stdin)
+			case 1:
+				content = args[0]
+			default:
+				sdk.Exit("wrong number of arguments. Need 0 or 1, Got [%d]", len(args))
+			}
+
+			return postStepSummary(sdk.V2WorkflowRunJobSummaryRequest{Summary: content})
+		},
+	}
+	return c
+}
+
+func CmdAnnotation() *cobra.Command {
+	var annotation sdk.V2WorkflowRunJobAnnotation
+	c := &cobra.Command{
+		Use:   "annotation",
+		Short: "worker annotation <message> --file <file> [--line <line>] [--level notice|warning|failure] [--title <title>]",
+		Long: `Inside a job, attach a message to a line of a file of the repository. Annotations are displayed in the run and sent to the repository.
+
+Annotations can also be written in the file given by the CDS_STEP_ANNOTATIONS environment variable, one JSON object per line:
+{"file": "src/main.go", "line": 12, "level": "warning", "message": "unused variable"}`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				sdk.Exit("wrong number of arguments. Need 1, Got [%d]", len(args))
+			}
+			annotation.Message = args[0]
+			if err := annotation.Check(); err != nil {
+				sdk.Exit(err.Error())
+			}
+			return postStepSummary(sdk.V2WorkflowRunJobSummaryRequest{Annotations: sdk.V2WorkflowRunJobAnnotations{annotation}})
+		},
+	}
+	c.Flags().StringVar(&annotation.File, "file", "", "file of the repository the annotation is about")
+	c.Flags().Int64Var(&annotation.Line, "line", 0, "line of the file")
+	c.Flags().StringVar(&annotation.Level, "level", sdk.V2WorkflowRunJobAnnotationLevelNotice, "notice, warning or failure")
+	c.Flags().StringVar(&annotation.Title, "title", "", "title of the annotation")
+	return c
+}
+
+func postStepSummary(summaryRequest sdk.V2WorkflowRunJobSummaryRequest) error {
+	req := MustNewWorkerHTTPRequest(http.MethodPost, "/v2/summary", summaryRequest)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := DoHTTPRequest(ctx, req, nil); err != nil {
+		sdk.Exit(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package artifact_manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+
+	"github.com/ovh/cds/sdk"
+	arti "github.com/ovh/cds/sdk/artifact_manager/artifactory"
+)
+
+// artifactoryClient implements ArtifactManager on top of the JFrog client.
+type artifactoryClient struct {
+	client *arti.Client
+}
+
+var _ ArtifactManager = new(artifactoryClient)
+
+func (c *artifactoryClient) GetURL() string {
+	return c.client.GetURL()
+}
+
+func (c *artifactoryClient) GetFile(ctx context.Context, fileDownloadURI string) ([]byte, error) {
+	return c.client.GetFile(ctx, fileDownloadURI)
+}
+
+func (c *artifactoryClient) GetFileInfo(repoName string, filePath string) (sdk.FileInfo, error) {
+	return c.client.GetFileInfo(repoName, filePath)
+}
+
+func (c *artifactoryClient) CheckArtifactExists(repoName string, artiName string) (bool, error) {
+	return c.client.CheckArtifactExists(repoName, artiName)
+}
+
+func (c *artifactoryClient) UploadFile(_ context.Context, repoName string, filePath string, content io.Reader) error {
+	btes, err := io.ReadAll(content)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return c.client.UploadFile(repoName, filePath, btes)
+}
+
+func (c *artifactoryClient) GetRepository(repoName string) (*RepositoryDetails, error) {
+	repo, err := c.client.GetRepository(repoName)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryDetails{
+		Key:         repo.Key,
+		Rclass:      repo.Rclass,
+		PackageType: repo.PackageType,
+		Description: repo.Description,
+		URL:         repo.Url,
+	}, nil
+}
+
+func (c *artifactoryClient) GetRepositoryMaturity(repoName string) (string, error) {
+	return c.client.GetRepositoryMaturity(repoName)
+}
+
+func (c *artifactoryClient) GetProperties(repoName string, filePath string) (map[string][]string, error) {
+	return c.client.GetProperties(repoName, filePath)
+}
+
+func (c *artifactoryClient) SetProperties(repoName string, filePath string, values Properties) error {
+	if len(values) == 0 {
+		return nil
+	}
+	props := utils.NewProperties()
+	for k, vs := range values {
+		for _, v := range vs {
+			props.AddProperty(k, v)
+		}
+	}
+	return c.client.SetProperties(repoName, filePath, props)
+}
+
+func (c *artifactoryClient) Promote(params PromoteParams) error {
+	switch params.Type {
+	case PromoteTypeDocker:
+		p := services.NewDockerPromoteParams(params.Path, params.SourceRepo, params.TargetRepo)
+		p.SourceTag = params.Tag
+		p.TargetTag = params.Tag
+		p.Copy = params.Copy
+		return c.client.PromoteDocker(p)
+	case PromoteTypeFile, "":
+		p := services.NewMoveCopyParams()
+		p.Pattern = fmt.Sprintf("%s/%s", params.SourceRepo, params.Path)
+		p.Target = fmt.Sprintf("%s/%s", params.TargetRepo, params.Path)
+		p.Flat = true
+		var nbSuccess, nbFailed int
+		var err error
+		if params.Copy {
+			nbSuccess, nbFailed, err = c.client.Copy(p)
+		} else {
+			nbSuccess, nbFailed, err = c.client.Move(p)
+		}
+		if err != nil {
+			return err
+		}
+		if nbFailed > 0 || nbSuccess == 0 {
+			return fmt.Errorf("%s: copy failed with no reason", params.Path)
+		}
+		return nil
+	}
+	return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported promotion type %q", params.Type)
+}
+
+func (c *artifactoryClient) DeleteBuild(project string, buildName string, buildVersion string) error {
+	return c.client.DeleteBuild(project, buildName, buildVersion)
+}
+
+func (c *artifactoryClient) PublishBuildInfo(project string, request *BuildInfo) error {
+	return c.client.PublishBuildInfo(project, toArtifactoryBuildInfo(request))
+}
+
+func (c *artifactoryClient) ScanBuild(project string, buildName string, buildVersion string) ([]byte, error) {
+	params := services.NewXrayScanParams()
+	params.BuildName = buildName
+	params.BuildNumber = buildVersion
+	params.ProjectKey = project
+	return c.client.XrayScanBuild(params)
+}
+
+func (c *artifactoryClient) Search(ctx context.Context, query SearchQuery) (sdk.ArtifactResults, error) {
+	aql := fmt.Sprintf(`items.find({"name" : {"$match": "**"}, "repo":"%s", "path":"%s"}).include("repo","path","name","virtual_repos","actual_md5")`, query.Repository, strings.Trim(query.Path, "/"))
+	return c.client.Search(ctx, aql)
+}
+
+func toArtifactoryBuildInfo(b *BuildInfo) *buildinfo.BuildInfo {
+	res := &buildinfo.BuildInfo{
+		Name:          b.Name,
+		Number:        b.Number,
+		Started:       b.Started,
+		BuildUrl:      b.URL,
+		Principal:     b.Principal,
+		PluginVersion: b.Agent.Version,
+		Agent:         &buildinfo.Agent{Name: b.Agent.Name, Version: b.Agent.Version},
+		BuildAgent:    &buildinfo.Agent{Name: b.BuildAgent.Name, Version: b.BuildAgent.Version},
+		Properties:    buildinfo.Env{},
+		Modules:       make([]buildinfo.Module, 0, len(b.Modules)),
+		VcsList:       make([]buildinfo.Vcs, 0, len(b.VCS)),
+	}
+	for k, v := range b.Properties {
+		res.Properties[k] = v
+	}
+	for _, v := range b.VCS {
+		res.VcsList = append(res.VcsList, buildinfo.Vcs{Url: v.URL, Revision: v.Revision, Branch: v.Branch, Message: v.Message})
+	}
+	for _, m := range b.Modules {
+		mod := buildinfo.Module{
+			Id:        m.ID,
+			Type:      buildinfo.ModuleType(m.Type),
+			Artifacts: make([]buildinfo.Artifact, 0, len(m.Artifacts)),
+		}
+		if len(m.Properties) > 0 {
+			mod.Properties = m.Properties
+		}
+		for _, a := range m.Artifacts {
+			mod.Artifacts = append(mod.Artifacts, buildinfo.Artifact{
+				Name:     a.Name,
+				Type:     a.Type,
+				Path:     a.Path,
+				Checksum: buildinfo.Checksum{Md5: a.MD5, Sha1: a.SHA1, Sha256: a.SHA256},
+			})
+		}
+		res.Modules = append(res.Modules, mod)
+	}
+	return res
+}
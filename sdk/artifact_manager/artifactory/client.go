@@ -78,6 +78,19 @@ func (c *Client) GetFileInfo(repoName string, filePath string) (sdk.FileInfo, er
 	return fi, nil
 }
 
+func (c *Client) UploadFile(repoName string, filePath string, content []byte) error {
+	uploadURL := fmt.Sprintf("%s%s/%s", c.Asm.GetConfig().GetServiceDetails().GetUrl(), repoName, strings.TrimPrefix(filePath, "/"))
+	httpDetails := c.Asm.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	re, body, err := c.Asm.Client().SendPut(uploadURL, content, &httpDetails)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to call artifactory: %v", err)
+	}
+	if re.StatusCode >= 400 {
+		return sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to call artifactory [HTTP: %d] %s %s", re.StatusCode, uploadURL, string(body))
+	}
+	return nil
+}
+
 func (c *Client) SetProperties(repoName string, filePath string, props *utils.Properties) error {
 	if props == nil {
 		return nil
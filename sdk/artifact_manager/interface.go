@@ -2,33 +2,34 @@ package artifact_manager
 
 import (
 	"context"
-
-	buildinfo "github.com/jfrog/build-info-go/entities"
-	"github.com/jfrog/jfrog-client-go/artifactory/services"
-	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
-	arti "github.com/ovh/cds/sdk/artifact_manager/artifactory"
+	"io"
 
 	"github.com/ovh/cds/sdk"
+	arti "github.com/ovh/cds/sdk/artifact_manager/artifactory"
+	"github.com/ovh/cds/sdk/artifact_manager/nexus"
 )
 
+// ArtifactManager is the vendor neutral client of an artifact manager (Artifactory, Nexus...).
 // mockgen -source=interface.go -package mock_artifact_manager -destination=mock_artifact_manager/interface_mock.go ArtifactManager
 type ArtifactManager interface {
+	GetURL() string
 	GetFile(ctx context.Context, fileDownloadURI string) ([]byte, error)
 	GetFileInfo(repoName string, filePath string) (sdk.FileInfo, error)
-	GetRepository(repoName string) (*services.RepositoryDetails, error)
-	GetFolderInfo(repoName string, folderPath string) (*utils.FolderInfo, error)
-	GetProperties(repoName string, filePath string) (map[string][]string, error)
-	SetProperties(repoName string, filePath string, values *utils.Properties) error
-	DeleteBuild(project string, buildName string, buildVersion string) error
-	PublishBuildInfo(project string, request *buildinfo.BuildInfo) error
-	XrayScanBuild(params services.XrayScanParams) ([]byte, error)
-	GetURL() string
 	CheckArtifactExists(repoName string, artiName string) (bool, error)
-	PromoteDocker(params services.DockerPromoteParams) error
-	Copy(params services.MoveCopyParams) (successCount, failedCount int, err error)
-	Move(params services.MoveCopyParams) (successCount, failedCount int, err error)
+	UploadFile(ctx context.Context, repoName string, filePath string, content io.Reader) error
+	GetRepository(repoName string) (*RepositoryDetails, error)
 	GetRepositoryMaturity(repoName string) (string, error)
-	Search(ctx context.Context, query string) (sdk.ArtifactResults, error)
+	GetProperties(repoName string, filePath string) (map[string][]string, error)
+	SetProperties(repoName string, filePath string, values Properties) error
+	Promote(params PromoteParams) error
+	DeleteBuild(project string, buildName string, buildVersion string) error
+	PublishBuildInfo(project string, request *BuildInfo) error
+	Search(ctx context.Context, query SearchQuery) (sdk.ArtifactResults, error)
+}
+
+// BuildScanner is implemented by the artifact managers that can scan a published build (Artifactory with Xray).
+type BuildScanner interface {
+	ScanBuild(project string, buildName string, buildVersion string) ([]byte, error)
 }
 
 type ClientFactoryFunc func(string, string, string) (ArtifactManager, error)
@@ -41,12 +42,14 @@ func NewClient(managerType, url, token string) (ArtifactManager, error) {
 
 func newClient(managerType, url, token string) (ArtifactManager, error) {
 	switch managerType {
-	case "artifactory":
+	case TypeArtifactory:
 		asm, err := sdk.NewArtifactoryClient(url, token)
 		if err != nil {
 			return nil, err
 		}
-		return &arti.Client{Asm: asm}, nil
+		return &artifactoryClient{client: &arti.Client{Asm: asm}}, nil
+	case TypeNexus:
+		return &nexusClient{client: nexus.NewClient(url, token), buildRepository: DefaultNexusBuildRepository}, nil
 	}
 	return nil, sdk.Errorf("artifact Manager %s not implemented", managerType)
 }
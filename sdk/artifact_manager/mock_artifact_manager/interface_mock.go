@@ -11,12 +11,11 @@ package mock_artifact_manager
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
-	entities "github.com/jfrog/build-info-go/entities"
-	services "github.com/jfrog/jfrog-client-go/artifactory/services"
-	utils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	sdk "github.com/ovh/cds/sdk"
+	artifact_manager "github.com/ovh/cds/sdk/artifact_manager"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -59,22 +58,6 @@ func (mr *MockArtifactManagerMockRecorder) CheckArtifactExists(repoName, artiNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckArtifactExists", reflect.TypeOf((*MockArtifactManager)(nil).CheckArtifactExists), repoName, artiName)
 }
 
-// Copy mocks base method.
-func (m *MockArtifactManager) Copy(params services.MoveCopyParams) (int, int, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Copy", params)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
-}
-
-// Copy indicates an expected call of Copy.
-func (mr *MockArtifactManagerMockRecorder) Copy(params any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockArtifactManager)(nil).Copy), params)
-}
-
 // DeleteBuild mocks base method.
 func (m *MockArtifactManager) DeleteBuild(project, buildName, buildVersion string) error {
 	m.ctrl.T.Helper()
@@ -119,21 +102,6 @@ func (mr *MockArtifactManagerMockRecorder) GetFileInfo(repoName, filePath any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockArtifactManager)(nil).GetFileInfo), repoName, filePath)
 }
 
-// GetFolderInfo mocks base method.
-func (m *MockArtifactManager) GetFolderInfo(repoName, folderPath string) (*utils.FolderInfo, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFolderInfo", repoName, folderPath)
-	ret0, _ := ret[0].(*utils.FolderInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetFolderInfo indicates an expected call of GetFolderInfo.
-func (mr *MockArtifactManagerMockRecorder) GetFolderInfo(repoName, folderPath any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolderInfo", reflect.TypeOf((*MockArtifactManager)(nil).GetFolderInfo), repoName, folderPath)
-}
-
 // GetProperties mocks base method.
 func (m *MockArtifactManager) GetProperties(repoName, filePath string) (map[string][]string, error) {
 	m.ctrl.T.Helper()
@@ -150,10 +118,10 @@ func (mr *MockArtifactManagerMockRecorder) GetProperties(repoName, filePath any)
 }
 
 // GetRepository mocks base method.
-func (m *MockArtifactManager) GetRepository(repoName string) (*services.RepositoryDetails, error) {
+func (m *MockArtifactManager) GetRepository(repoName string) (*artifact_manager.RepositoryDetails, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRepository", repoName)
-	ret0, _ := ret[0].(*services.RepositoryDetails)
+	ret0, _ := ret[0].(*artifact_manager.RepositoryDetails)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -193,38 +161,22 @@ func (mr *MockArtifactManagerMockRecorder) GetURL() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*MockArtifactManager)(nil).GetURL))
 }
 
-// Move mocks base method.
-func (m *MockArtifactManager) Move(params services.MoveCopyParams) (int, int, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Move", params)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
-}
-
-// Move indicates an expected call of Move.
-func (mr *MockArtifactManagerMockRecorder) Move(params any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockArtifactManager)(nil).Move), params)
-}
-
-// PromoteDocker mocks base method.
-func (m *MockArtifactManager) PromoteDocker(params services.DockerPromoteParams) error {
+// Promote mocks base method.
+func (m *MockArtifactManager) Promote(params artifact_manager.PromoteParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PromoteDocker", params)
+	ret := m.ctrl.Call(m, "Promote", params)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PromoteDocker indicates an expected call of PromoteDocker.
-func (mr *MockArtifactManagerMockRecorder) PromoteDocker(params any) *gomock.Call {
+// Promote indicates an expected call of Promote.
+func (mr *MockArtifactManagerMockRecorder) Promote(params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteDocker", reflect.TypeOf((*MockArtifactManager)(nil).PromoteDocker), params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promote", reflect.TypeOf((*MockArtifactManager)(nil).Promote), params)
 }
 
 // PublishBuildInfo mocks base method.
-func (m *MockArtifactManager) PublishBuildInfo(project string, request *entities.BuildInfo) error {
+func (m *MockArtifactManager) PublishBuildInfo(project string, request *artifact_manager.BuildInfo) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishBuildInfo", project, request)
 	ret0, _ := ret[0].(error)
@@ -238,7 +190,7 @@ func (mr *MockArtifactManagerMockRecorder) PublishBuildInfo(project, request any
 }
 
 // Search mocks base method.
-func (m *MockArtifactManager) Search(ctx context.Context, query string) (sdk.ArtifactResults, error) {
+func (m *MockArtifactManager) Search(ctx context.Context, query artifact_manager.SearchQuery) (sdk.ArtifactResults, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Search", ctx, query)
 	ret0, _ := ret[0].(sdk.ArtifactResults)
@@ -253,7 +205,7 @@ func (mr *MockArtifactManagerMockRecorder) Search(ctx, query any) *gomock.Call {
 }
 
 // SetProperties mocks base method.
-func (m *MockArtifactManager) SetProperties(repoName, filePath string, values *utils.Properties) error {
+func (m *MockArtifactManager) SetProperties(repoName, filePath string, values artifact_manager.Properties) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetProperties", repoName, filePath, values)
 	ret0, _ := ret[0].(error)
@@ -266,17 +218,55 @@ func (mr *MockArtifactManagerMockRecorder) SetProperties(repoName, filePath, val
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProperties", reflect.TypeOf((*MockArtifactManager)(nil).SetProperties), repoName, filePath, values)
 }
 
-// XrayScanBuild mocks base method.
-func (m *MockArtifactManager) XrayScanBuild(params services.XrayScanParams) ([]byte, error) {
+// UploadFile mocks base method.
+func (m *MockArtifactManager) UploadFile(ctx context.Context, repoName, filePath string, content io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", ctx, repoName, filePath, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockArtifactManagerMockRecorder) UploadFile(ctx, repoName, filePath, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockArtifactManager)(nil).UploadFile), ctx, repoName, filePath, content)
+}
+
+// MockBuildScanner is a mock of BuildScanner interface.
+type MockBuildScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockBuildScannerMockRecorder
+	isgomock struct{}
+}
+
+// MockBuildScannerMockRecorder is the mock recorder for MockBuildScanner.
+type MockBuildScannerMockRecorder struct {
+	mock *MockBuildScanner
+}
+
+// NewMockBuildScanner creates a new mock instance.
+func NewMockBuildScanner(ctrl *gomock.Controller) *MockBuildScanner {
+	mock := &MockBuildScanner{ctrl: ctrl}
+	mock.recorder = &MockBuildScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBuildScanner) EXPECT() *MockBuildScannerMockRecorder {
+	return m.recorder
+}
+
+// ScanBuild mocks base method.
+func (m *MockBuildScanner) ScanBuild(project, buildName, buildVersion string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "XrayScanBuild", params)
+	ret := m.ctrl.Call(m, "ScanBuild", project, buildName, buildVersion)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// XrayScanBuild indicates an expected call of XrayScanBuild.
-func (mr *MockArtifactManagerMockRecorder) XrayScanBuild(params any) *gomock.Call {
+// ScanBuild indicates an expected call of ScanBuild.
+func (mr *MockBuildScannerMockRecorder) ScanBuild(project, buildName, buildVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XrayScanBuild", reflect.TypeOf((*MockArtifactManager)(nil).XrayScanBuild), params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanBuild", reflect.TypeOf((*MockBuildScanner)(nil).ScanBuild), project, buildName, buildVersion)
 }
@@ -0,0 +1,252 @@
+package artifact_manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/artifact_manager/nexus"
+)
+
+const (
+	// DefaultNexusBuildRepository is the raw hosted repository where build infos are stored on Nexus.
+	DefaultNexusBuildRepository = "cds-build-info"
+
+	// Nexus has no item properties, they are stored in a JSON file next to the item.
+	nexusPropertiesSuffix = ".cds-properties.json"
+)
+
+// nexusClient implements ArtifactManager on Sonatype Nexus Repository 3.
+// Repository maturity is the suffix of the repository name (ie. "my-repo-release" is a "release" repository),
+// docker images can only be moved between repositories with the Nexus Pro staging API.
+type nexusClient struct {
+	client          *nexus.Client
+	buildRepository string
+
+	mutex        sync.Mutex
+	repositories map[string]*nexus.Repository
+}
+
+var _ ArtifactManager = new(nexusClient)
+
+func (c *nexusClient) GetURL() string {
+	return c.client.URL() + "repository/"
+}
+
+func (c *nexusClient) GetFile(ctx context.Context, fileDownloadURI string) ([]byte, error) {
+	return c.client.Get(ctx, fileDownloadURI)
+}
+
+func (c *nexusClient) GetFileInfo(repoName string, filePath string) (sdk.FileInfo, error) {
+	a, err := c.client.GetAsset(context.Background(), repoName, filePath)
+	if err != nil {
+		return sdk.FileInfo{}, err
+	}
+	return sdk.FileInfo{
+		Checksums: &sdk.FileInfoChecksum{
+			Md5:    a.Checksum.MD5,
+			Sha1:   a.Checksum.SHA1,
+			Sha256: a.Checksum.SHA256,
+		},
+		Created:      a.BlobCreated,
+		CreatedBy:    a.Uploader,
+		DownloadURI:  a.DownloadURL,
+		LastModified: a.LastModified,
+		LastUpdated:  a.LastModified,
+		MimeType:     a.ContentType,
+		Path:         "/" + strings.TrimPrefix(a.Path, "/"),
+		Repo:         a.Repository,
+		SizeString:   strconv.FormatInt(a.FileSize, 10),
+		Size:         a.FileSize,
+		URI:          a.DownloadURL,
+	}, nil
+}
+
+func (c *nexusClient) CheckArtifactExists(repoName string, artiName string) (bool, error) {
+	return c.client.Exists(context.Background(), repoName, artiName)
+}
+
+func (c *nexusClient) UploadFile(ctx context.Context, repoName string, filePath string, content io.Reader) error {
+	return c.client.Upload(ctx, repoName, filePath, content)
+}
+
+func (c *nexusClient) getRepository(repoName string) (*nexus.Repository, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if r, has := c.repositories[repoName]; has {
+		return r, nil
+	}
+	r, err := c.client.GetRepository(context.Background(), repoName)
+	if err != nil {
+		return nil, err
+	}
+	if c.repositories == nil {
+		c.repositories = make(map[string]*nexus.Repository)
+	}
+	c.repositories[repoName] = r
+	return r, nil
+}
+
+func (c *nexusClient) GetRepository(repoName string) (*RepositoryDetails, error) {
+	r, err := c.getRepository(repoName)
+	if err != nil {
+		return nil, err
+	}
+	res := RepositoryDetails{
+		Key:         r.Name,
+		PackageType: r.Format,
+		URL:         r.URL,
+	}
+	switch r.Type {
+	case "hosted":
+		res.Rclass = RepositoryClassLocal
+	case "proxy":
+		res.Rclass = RepositoryClassRemote
+	case "group":
+		res.Rclass = RepositoryClassVirtual
+	}
+	if r.Format == "maven2" {
+		res.PackageType = "maven"
+	}
+	return &res, nil
+}
+
+func (c *nexusClient) GetRepositoryMaturity(repoName string) (string, error) {
+	if _, err := c.getRepository(repoName); err != nil {
+		return "", err
+	}
+	idx := strings.LastIndex(repoName, "-")
+	if idx < 0 {
+		return "", nil
+	}
+	return repoName[idx+1:], nil
+}
+
+func (c *nexusClient) GetProperties(repoName string, filePath string) (map[string][]string, error) {
+	btes, err := c.client.Download(context.Background(), repoName, filePath+nexusPropertiesSuffix)
+	if sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	props := make(map[string][]string)
+	if err := sdk.JSONUnmarshal(btes, &props); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to read properties of %s/%s: %v", repoName, filePath, err)
+	}
+	return props, nil
+}
+
+// SetProperties merges the given values in the properties file of the item. Docker repositories do not
+// accept such files, properties are ignored for them.
+func (c *nexusClient) SetProperties(repoName string, filePath string, values Properties) error {
+	if len(values) == 0 {
+		return nil
+	}
+	r, err := c.getRepository(repoName)
+	if err != nil {
+		return err
+	}
+	if r.Format == "docker" {
+		return nil
+	}
+	props, err := c.GetProperties(repoName, filePath)
+	if err != nil {
+		return err
+	}
+	for k, vs := range values {
+		props[k] = vs
+	}
+	btes, err := json.Marshal(props)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return c.client.Upload(context.Background(), repoName, filePath+nexusPropertiesSuffix, bytes.NewReader(btes))
+}
+
+func (c *nexusClient) Promote(params PromoteParams) error {
+	ctx := context.Background()
+	switch params.Type {
+	case PromoteTypeDocker:
+		if params.Copy {
+			return sdk.NewErrorFrom(sdk.ErrNotImplemented, "docker images cannot be copied between Nexus repositories")
+		}
+		return c.client.StagingMove(ctx, params.SourceRepo, params.TargetRepo, params.Path, params.Tag)
+	case PromoteTypeFile, "":
+		for _, p := range []string{params.Path, params.Path + nexusPropertiesSuffix} {
+			err := c.client.Copy(ctx, params.SourceRepo, params.TargetRepo, p)
+			if sdk.ErrorIs(err, sdk.ErrNotFound) && p != params.Path {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if params.Copy {
+				continue
+			}
+			if err := c.client.Delete(ctx, params.SourceRepo, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported promotion type %q", params.Type)
+}
+
+func (c *nexusClient) buildInfoPath(project, buildName, buildVersion string) string {
+	return path.Join(project, buildName, buildVersion, "build-info.json")
+}
+
+func (c *nexusClient) DeleteBuild(project string, buildName string, buildVersion string) error {
+	err := c.client.Delete(context.Background(), c.buildRepository, c.buildInfoPath(project, buildName, buildVersion))
+	if sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c *nexusClient) PublishBuildInfo(project string, request *BuildInfo) error {
+	btes, err := json.Marshal(request)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return c.client.Upload(context.Background(), c.buildRepository, c.buildInfoPath(project, request.Name, request.Number), bytes.NewReader(btes))
+}
+
+func (c *nexusClient) Search(ctx context.Context, query SearchQuery) (sdk.ArtifactResults, error) {
+	dir := strings.Trim(query.Path, "/")
+	// Files of raw repositories are grouped by their directory
+	q := url.Values{}
+	q.Set("repository", query.Repository)
+	q.Set("group", "/"+dir)
+	var res sdk.ArtifactResults
+	if err := c.client.SearchAssets(ctx, q, func(a nexus.Asset) bool {
+		p := strings.TrimPrefix(a.Path, "/")
+		if strings.HasSuffix(p, nexusPropertiesSuffix) {
+			return true
+		}
+		d, name := path.Split(p)
+		if strings.TrimSuffix(d, "/") != dir {
+			return true
+		}
+		res = append(res, sdk.ArtifactResult{
+			Repo:      a.Repository,
+			Path:      dir,
+			Name:      name,
+			Created:   a.BlobCreated,
+			Size:      a.FileSize,
+			ActualMD5: a.Checksum.MD5,
+		})
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
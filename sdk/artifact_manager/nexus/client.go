@@ -0,0 +1,239 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Client is a minimal Sonatype Nexus Repository 3 REST client.
+// A token with the "<username>:<password>" form, like Nexus user tokens, is sent with basic authentication,
+// any other value as a bearer token.
+type Client struct {
+	url        string
+	token      string
+	HTTPClient *http.Client
+}
+
+func NewClient(nexusURL, token string) *Client {
+	return &Client{
+		url:        strings.TrimSuffix(nexusURL, "/") + "/",
+		token:      token,
+		HTTPClient: &http.Client{Timeout: 120 * time.Second},
+	}
+}
+
+type Repository struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+}
+
+type Asset struct {
+	ID           string        `json:"id"`
+	Repository   string        `json:"repository"`
+	Format       string        `json:"format"`
+	Path         string        `json:"path"`
+	DownloadURL  string        `json:"downloadUrl"`
+	ContentType  string        `json:"contentType"`
+	LastModified time.Time     `json:"lastModified"`
+	BlobCreated  time.Time     `json:"blobCreated"`
+	Uploader     string        `json:"uploader"`
+	FileSize     int64         `json:"fileSize"`
+	Checksum     AssetChecksum `json:"checksum"`
+}
+
+type AssetChecksum struct {
+	MD5    string `json:"md5"`
+	SHA1   string `json:"sha1"`
+	SHA256 string `json:"sha256"`
+}
+
+type assetsPage struct {
+	Items             []Asset `json:"items"`
+	ContinuationToken *string `json:"continuationToken"`
+}
+
+func (c *Client) URL() string {
+	return c.url
+}
+
+// RepositoryURL returns the URL of a path in a repository.
+func (c *Client) RepositoryURL(repoName, path string) string {
+	return c.url + "repository/" + repoName + "/" + strings.TrimPrefix(path, "/")
+}
+
+// send calls Nexus and returns the response with its body to read and close when the call succeeded.
+func (c *Client) send(ctx context.Context, method, uri string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if size >= 0 && body != nil {
+		req.ContentLength = size
+	}
+	if user, password, ok := strings.Cut(c.token, ":"); ok {
+		req.SetBasicAuth(user, password)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to call nexus: %v", err)
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	btes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to call nexus [HTTP: %d] %s %s", resp.StatusCode, uri, string(btes))
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to call nexus [HTTP: %d] %s %s", resp.StatusCode, uri, string(btes))
+}
+
+func (c *Client) do(ctx context.Context, method, uri string, body io.Reader) ([]byte, error) {
+	resp, err := c.send(ctx, method, uri, body, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	btes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return btes, nil
+}
+
+// Get downloads the content of an URL.
+func (c *Client) Get(ctx context.Context, uri string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, uri, nil)
+}
+
+// Download returns the content of a path in a repository.
+func (c *Client) Download(ctx context.Context, repoName, path string) ([]byte, error) {
+	return c.Get(ctx, c.RepositoryURL(repoName, path))
+}
+
+// Copy streams a file from a repository to another without keeping its content in memory.
+func (c *Client) Copy(ctx context.Context, sourceRepo, targetRepo, path string) error {
+	resp, err := c.send(ctx, http.MethodGet, c.RepositoryURL(sourceRepo, path), nil, -1)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	uploadResp, err := c.send(ctx, http.MethodPut, c.RepositoryURL(targetRepo, path), resp.Body, resp.ContentLength)
+	if err != nil {
+		return err
+	}
+	return uploadResp.Body.Close()
+}
+
+func (c *Client) Exists(ctx context.Context, repoName, path string) (bool, error) {
+	_, err := c.do(ctx, http.MethodHead, c.RepositoryURL(repoName, path), nil)
+	if sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Upload puts a file in a hosted repository that accepts direct uploads (raw, maven...).
+func (c *Client) Upload(ctx context.Context, repoName, path string, content io.Reader) error {
+	_, err := c.do(ctx, http.MethodPut, c.RepositoryURL(repoName, path), content)
+	return err
+}
+
+func (c *Client) Delete(ctx context.Context, repoName, path string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.RepositoryURL(repoName, path), nil)
+	return err
+}
+
+func (c *Client) GetRepository(ctx context.Context, repoName string) (*Repository, error) {
+	btes, err := c.do(ctx, http.MethodGet, c.url+"service/rest/v1/repositories/"+url.PathEscape(repoName), nil)
+	if err != nil {
+		return nil, err
+	}
+	var repo Repository
+	if err := sdk.JSONUnmarshal(btes, &repo); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to read nexus response %s: %v", string(btes), err)
+	}
+	return &repo, nil
+}
+
+// SearchAssets iterates over the assets matching the search query until fn returns false.
+// See https://help.sonatype.com/en/search-api.html for the available parameters.
+func (c *Client) SearchAssets(ctx context.Context, query url.Values, fn func(Asset) bool) error {
+	var continuationToken string
+	for {
+		q := url.Values{}
+		for k, vs := range query {
+			q[k] = vs
+		}
+		if continuationToken != "" {
+			q.Set("continuationToken", continuationToken)
+		}
+		btes, err := c.do(ctx, http.MethodGet, c.url+"service/rest/v1/search/assets?"+q.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		var page assetsPage
+		if err := json.Unmarshal(btes, &page); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to read nexus response %s: %v", string(btes), err)
+		}
+		for _, a := range page.Items {
+			if !fn(a) {
+				return nil
+			}
+		}
+		if page.ContinuationToken == nil || *page.ContinuationToken == "" {
+			return nil
+		}
+		continuationToken = *page.ContinuationToken
+	}
+}
+
+// GetAsset returns the asset stored at the given path of a repository.
+// The asset is searched by its component name, that is the path of the file on raw repositories.
+func (c *Client) GetAsset(ctx context.Context, repoName, path string) (*Asset, error) {
+	path = strings.TrimPrefix(path, "/")
+	q := url.Values{}
+	q.Set("repository", repoName)
+	q.Set("name", path)
+	var res *Asset
+	if err := c.SearchAssets(ctx, q, func(a Asset) bool {
+		if strings.TrimPrefix(a.Path, "/") == path {
+			res = &a
+			return false
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "asset %s/%s not found", repoName, path)
+	}
+	return res, nil
+}
+
+// StagingMove moves the components matching name and version from a repository to another.
+// This uses the staging API that is available on Nexus Repository Pro.
+func (c *Client) StagingMove(ctx context.Context, sourceRepo, targetRepo, name, version string) error {
+	q := url.Values{}
+	q.Set("repository", sourceRepo)
+	q.Set("name", name)
+	if version != "" {
+		q.Set("version", version)
+	}
+	uri := fmt.Sprintf("%sservice/rest/v1/staging/move/%s?%s", c.url, url.PathEscape(targetRepo), q.Encode())
+	_, err := c.do(ctx, http.MethodPost, uri, nil)
+	return err
+}
@@ -0,0 +1,212 @@
+package artifact_manager
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/artifact_manager/nexus"
+)
+
+// fakeNexus serves the subset of the Nexus 3 API used by the client from in memory repositories.
+type fakeNexus struct {
+	repositories map[string]nexus.Repository
+	files        map[string][]byte
+	moves        []string
+}
+
+func (f *fakeNexus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if u, p, ok := r.BasicAuth(); !ok || u != "user" || p != "password" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/repository/"):
+		key := strings.TrimPrefix(r.URL.Path, "/repository/")
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			btes, has := f.files[key]
+			if !has {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(btes) // nolint
+		case http.MethodPut:
+			btes, _ := io.ReadAll(r.Body)
+			f.files[key] = btes
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			if _, has := f.files[key]; !has {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(f.files, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case strings.HasPrefix(r.URL.Path, "/service/rest/v1/repositories/"):
+		repo, has := f.repositories[strings.TrimPrefix(r.URL.Path, "/service/rest/v1/repositories/")]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(repo) // nolint
+	case r.URL.Path == "/service/rest/v1/search/assets":
+		repoName := r.URL.Query().Get("repository")
+		name, group := r.URL.Query().Get("name"), r.URL.Query().Get("group")
+		keys := make([]string, 0, len(f.files))
+		for k := range f.files {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var items []nexus.Asset
+		for _, k := range keys {
+			repo, p, _ := strings.Cut(k, "/")
+			if repo != repoName || (name != "" && p != name) || (group != "" && "/"+path.Dir(p) != group) {
+				continue
+			}
+			items = append(items, nexus.Asset{Repository: repo, Path: p, FileSize: int64(len(f.files[k])), Checksum: nexus.AssetChecksum{MD5: "md5-" + p}})
+		}
+		// Serve one asset per page to go through the pagination
+		page := map[string]interface{}{"items": []nexus.Asset{}}
+		var idx int
+		if t := r.URL.Query().Get("continuationToken"); t != "" {
+			idx = len(t)
+		}
+		if idx < len(items) {
+			page["items"] = items[idx : idx+1]
+		}
+		if idx+1 < len(items) {
+			page["continuationToken"] = strings.Repeat("x", idx+1)
+		}
+		json.NewEncoder(w).Encode(page) // nolint
+	case strings.HasPrefix(r.URL.Path, "/service/rest/v1/staging/move/"):
+		f.moves = append(f.moves, r.URL.Query().Get("repository")+">"+strings.TrimPrefix(r.URL.Path, "/service/rest/v1/staging/move/")+":"+r.URL.Query().Get("name")+":"+r.URL.Query().Get("version"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestNexusClient(t *testing.T) {
+	fake := &fakeNexus{
+		repositories: map[string]nexus.Repository{
+			"my-repo-snapshot":   {Name: "my-repo-snapshot", Format: "raw", Type: "hosted"},
+			"my-repo-release":    {Name: "my-repo-release", Format: "raw", Type: "hosted"},
+			"my-docker-snapshot": {Name: "my-docker-snapshot", Format: "docker", Type: "hosted"},
+			"my-repo":            {Name: "my-repo", Format: "maven2", Type: "group"},
+			"cds-build-info":     {Name: "cds-build-info", Format: "raw", Type: "hosted"},
+		},
+		files: map[string][]byte{},
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	c, err := newClient(TypeNexus, srv.URL, "user:password")
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	require.Equal(t, srv.URL+"/repository/", c.GetURL())
+
+	repo, err := c.GetRepository("my-repo")
+	require.NoError(t, err)
+	require.Equal(t, RepositoryClassVirtual, repo.Rclass)
+	require.Equal(t, "maven", repo.PackageType)
+
+	maturity, err := c.GetRepositoryMaturity("my-repo-release")
+	require.NoError(t, err)
+	require.Equal(t, "release", maturity)
+
+	require.NoError(t, c.UploadFile(ctx, "my-repo-snapshot", "/app/1.0/app.tar.gz", strings.NewReader("content")))
+	require.NoError(t, c.UploadFile(ctx, "my-repo-snapshot", "app/1.0/app.sig", strings.NewReader("sig")))
+	require.NoError(t, c.UploadFile(ctx, "my-repo-snapshot", "app/2.0/app.tar.gz", strings.NewReader("content")))
+
+	exists, err := c.CheckArtifactExists("my-repo-snapshot", "app/1.0/app.tar.gz")
+	require.NoError(t, err)
+	require.True(t, exists)
+	exists, err = c.CheckArtifactExists("my-repo-release", "app/1.0/app.tar.gz")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	fi, err := c.GetFileInfo("my-repo-snapshot", "app/1.0/app.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, "/app/1.0/app.tar.gz", fi.Path)
+	require.Equal(t, int64(7), fi.Size)
+	require.Equal(t, "md5-app/1.0/app.tar.gz", fi.Checksums.Md5)
+	_, err = c.GetFileInfo("my-repo-snapshot", "unknown")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	props, err := c.GetProperties("my-repo-snapshot", "app/1.0/app.tar.gz")
+	require.NoError(t, err)
+	require.Empty(t, props)
+	p := NewProperties()
+	p.AddProperty("build.name", "my-build")
+	require.NoError(t, c.SetProperties("my-repo-snapshot", "app/1.0/app.tar.gz", p))
+	p = NewProperties()
+	p.AddProperty("build.number", "1", "2")
+	require.NoError(t, c.SetProperties("my-repo-snapshot", "app/1.0/app.tar.gz", p))
+	props, err = c.GetProperties("my-repo-snapshot", "app/1.0/app.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"build.name": {"my-build"}, "build.number": {"1", "2"}}, props)
+
+	// Properties are not supported on docker repositories
+	require.NoError(t, c.SetProperties("my-docker-snapshot", "my/image/1.0", p))
+
+	res, err := c.Search(ctx, SearchQuery{Repository: "my-repo-snapshot", Path: "/app/1.0/"})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	names := []string{res[0].Name, res[1].Name}
+	require.ElementsMatch(t, []string{"app.tar.gz", "app.sig"}, names)
+
+	// Move the file and its properties
+	require.NoError(t, c.Promote(PromoteParams{SourceRepo: "my-repo-snapshot", TargetRepo: "my-repo-release", Path: "app/1.0/app.tar.gz"}))
+	_, has := fake.files["my-repo-snapshot/app/1.0/app.tar.gz"]
+	require.False(t, has)
+	props, err = c.GetProperties("my-repo-release", "app/1.0/app.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, []string{"my-build"}, props["build.name"])
+
+	// Copy keeps the source file
+	require.NoError(t, c.Promote(PromoteParams{SourceRepo: "my-repo-snapshot", TargetRepo: "my-repo-release", Path: "app/2.0/app.tar.gz", Copy: true}))
+	require.Contains(t, fake.files, "my-repo-snapshot/app/2.0/app.tar.gz")
+	require.Contains(t, fake.files, "my-repo-release/app/2.0/app.tar.gz")
+
+	require.NoError(t, c.Promote(PromoteParams{Type: PromoteTypeDocker, SourceRepo: "my-docker-snapshot", TargetRepo: "my-docker-release", Path: "my/image", Tag: "1.0"}))
+	require.Equal(t, []string{"my-docker-snapshot>my-docker-release:my/image:1.0"}, fake.moves)
+	err = c.Promote(PromoteParams{Type: PromoteTypeDocker, SourceRepo: "my-docker-snapshot", TargetRepo: "my-docker-release", Path: "my/image", Tag: "1.0", Copy: true})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotImplemented))
+
+	require.NoError(t, c.DeleteBuild("", "cds/PROJ/my-workflow", "1.0.0"))
+	require.NoError(t, c.PublishBuildInfo("", &BuildInfo{Name: "cds/PROJ/my-workflow", Number: "1.0.0", Modules: []BuildModule{{ID: "generic:app"}}}))
+	var bi BuildInfo
+	require.NoError(t, json.Unmarshal(fake.files["cds-build-info/cds/PROJ/my-workflow/1.0.0/build-info.json"], &bi))
+	require.Equal(t, "generic:app", bi.Modules[0].ID)
+	require.NoError(t, c.DeleteBuild("", "cds/PROJ/my-workflow", "1.0.0"))
+	require.NotContains(t, fake.files, "cds-build-info/cds/PROJ/my-workflow/1.0.0/build-info.json")
+
+	// Build scan is only available on Artifactory
+	_, isScanner := interface{}(c).(BuildScanner)
+	require.False(t, isScanner)
+}
+
+func TestParseProperties(t *testing.T) {
+	props, err := ParseProperties("a=1,2;b=3")
+	require.NoError(t, err)
+	require.Equal(t, "a=1,2;b=3", props.String())
+
+	props, err = ParseProperties(`a=1\,2;b=x=y;;c\;d=4,4`)
+	require.NoError(t, err)
+	require.Equal(t, Properties{"a": {"1,2"}, "b": {"x=y"}, "c;d": {"4"}}, props)
+
+	_, err = ParseProperties("a")
+	require.Error(t, err)
+	_, err = ParseProperties("=1")
+	require.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package artifact_manager
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	TypeArtifactory = "artifactory"
+	TypeNexus       = "nexus"
+
+	PromoteTypeFile   = "file"
+	PromoteTypeDocker = "docker"
+
+	BuildModuleTypeGeneric = "generic"
+	BuildModuleTypeDocker  = "docker"
+	BuildModuleTypeConan   = "conan"
+
+	RepositoryClassLocal   = "local"
+	RepositoryClassRemote  = "remote"
+	RepositoryClassVirtual = "virtual"
+)
+
+// Properties are key/values metadata set on artifacts.
+type Properties map[string][]string
+
+func NewProperties() Properties {
+	return make(Properties)
+}
+
+// ParseProperties reads properties from a "key1=value1,value2;key2=value3" string, a backslash escapes the ";" and
+// "," separators.
+func ParseProperties(s string) (Properties, error) {
+	props := NewProperties()
+	for _, prop := range splitProperties(s, ";") {
+		if prop == "" {
+			continue
+		}
+		i := strings.Index(prop, "=")
+		if i <= 0 || i == len(prop)-1 {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid property format %q, format should be key=value1,value2", prop)
+		}
+		props.AddProperty(prop[:i], splitProperties(prop[i+1:], ",")...)
+	}
+	return props, nil
+}
+
+// splitProperties splits s around the separators that are not prefixed by a backslash.
+func splitProperties(s, sep string) []string {
+	var res []string
+	parts := strings.Split(s, sep)
+	for i, p := range parts {
+		if strings.HasSuffix(p, `\`) && i+1 < len(parts) {
+			parts[i+1] = strings.TrimSuffix(p, `\`) + sep + parts[i+1]
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+func (p Properties) AddProperty(key string, values ...string) {
+	for _, v := range values {
+		if !sliceContains(p[key], v) {
+			p[key] = append(p[key], v)
+		}
+	}
+}
+
+func (p Properties) ToMap() map[string][]string {
+	return p
+}
+
+// String returns the properties in the "key1=value1,value2;key2=value3" format, keys sorted.
+func (p Properties) String() string {
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+strings.Join(p[k], ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+func sliceContains(s []string, v string) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// RepositoryDetails describes a repository. Rclass is one of local, remote or virtual.
+type RepositoryDetails struct {
+	Key         string `json:"key"`
+	Rclass      string `json:"rclass"`
+	PackageType string `json:"packageType"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// PromoteParams moves or copies an artifact between two maturity repositories.
+// For a docker image, Path is the image name and Tag its tag.
+type PromoteParams struct {
+	Type       string
+	SourceRepo string
+	TargetRepo string
+	Path       string
+	Tag        string
+	Copy       bool
+}
+
+// SearchQuery lists the items of a folder in a repository.
+type SearchQuery struct {
+	Repository string
+	Path       string
+}
+
+// BuildInfo is the build metadata published on the artifact manager for a workflow run.
+type BuildInfo struct {
+	Name       string            `json:"name"`
+	Number     string            `json:"number"`
+	Started    string            `json:"started"`
+	URL        string            `json:"url,omitempty"`
+	Principal  string            `json:"principal,omitempty"`
+	Agent      BuildAgent        `json:"agent"`
+	BuildAgent BuildAgent        `json:"buildAgent"`
+	Properties map[string]string `json:"properties,omitempty"`
+	VCS        []BuildVCS        `json:"vcs,omitempty"`
+	Modules    []BuildModule     `json:"modules"`
+}
+
+type BuildAgent struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type BuildVCS struct {
+	URL      string `json:"url"`
+	Revision string `json:"revision"`
+	Branch   string `json:"branch"`
+	Message  string `json:"message,omitempty"`
+}
+
+type BuildModule struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Artifacts  []BuildArtifact   `json:"artifacts"`
+}
+
+type BuildArtifact struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Path   string `json:"path,omitempty"`
+	MD5    string `json:"md5,omitempty"`
+	SHA1   string `json:"sha1,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import "strings"
+
+// AuthGroupSyncRule maps an external group of a user, given by an OIDC claim value or a LDAP group DN,
+// to a CDS group membership and/or an organization.
+type AuthGroupSyncRule struct {
+	External     string `toml:"external" json:"external" mapstructure:"external"`
+	Group        string `toml:"group" json:"group,omitempty" mapstructure:"group"`
+	Admin        bool   `toml:"admin" json:"admin,omitempty" mapstructure:"admin"`
+	Organization string `toml:"organization" json:"organization,omitempty" mapstructure:"organization"`
+}
+
+type AuthGroupSyncRules []AuthGroupSyncRule
+
+// Apply returns the CDS groups, with their admin flag, and the organization matching given external groups.
+// External groups are compared case insensitively as LDAP DNs are. The organization is the one of the first
+// matching rule that gives one.
+func (rs AuthGroupSyncRules) Apply(externalGroups []string) (map[string]bool, string) {
+	groups := make(map[string]bool)
+	var organization string
+	for _, r := range rs {
+		var match bool
+		for _, e := range externalGroups {
+			if strings.EqualFold(r.External, e) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if r.Group != "" {
+			groups[r.Group] = groups[r.Group] || r.Admin
+		}
+		if organization == "" {
+			organization = r.Organization
+		}
+	}
+	return groups, organization
+}
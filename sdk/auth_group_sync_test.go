@@ -0,0 +1,28 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthGroupSyncRulesApply(t *testing.T) {
+	rules := AuthGroupSyncRules{
+		{External: "cn=devs,ou=groups,dc=ovh,dc=com", Group: "devs"},
+		{External: "cn=leads,ou=groups,dc=ovh,dc=com", Group: "devs", Admin: true, Organization: "ovh"},
+		{External: "ops", Group: "ops"},
+		{External: "cn=devs,ou=groups,dc=ovh,dc=com", Organization: "other"},
+	}
+
+	groups, org := rules.Apply([]string{"CN=devs,ou=groups,dc=ovh,dc=com"})
+	require.Equal(t, map[string]bool{"devs": false}, groups)
+	require.Equal(t, "other", org)
+
+	groups, org = rules.Apply([]string{"cn=devs,ou=groups,dc=ovh,dc=com", "cn=leads,ou=groups,dc=ovh,dc=com", "ops"})
+	require.Equal(t, map[string]bool{"devs": true, "ops": false}, groups)
+	require.Equal(t, "ovh", org)
+
+	groups, org = rules.Apply(nil)
+	require.Empty(t, groups)
+	require.Empty(t, org)
+}
@@ -30,6 +30,7 @@ type CDNItem struct {
 	Size         int64           `json:"size" db:"size"`
 	MD5          string          `json:"md5" db:"md5"`
 	ToDelete     bool            `json:"to_delete" db:"to_delete"`
+	LastAccess   *time.Time      `json:"last_access,omitempty" db:"last_access"`
 }
 
 type CDNItemLinks struct {
@@ -116,6 +117,13 @@ type CDNLogsLines struct {
 	LinesCount int64  `json:"lines_count"`
 }
 
+// CDNLogLine is a log line returned by the CDN.
+type CDNLogLine struct {
+	Number int64  `json:"number"`
+	Value  string `json:"value"`
+	Since  int64  `json:"since,omitempty"`
+}
+
 type CDNLogLinks struct {
 	CDNURL string       `json:"cdn_url,omitempty"`
 	Data   []CDNLogLink `json:"datas"`
@@ -203,6 +211,7 @@ type CDNRunResultAPIRef struct {
 type CDNWorkerCacheAPIRef struct {
 	ProjectKey string    `json:"project_key"`
 	CacheTag   string    `json:"cache_tag"`
+	Ref        string    `json:"ref,omitempty"`
 	ExpireAt   time.Time `json:"expire_at"`
 }
 
@@ -227,6 +236,7 @@ func NewCDNWorkerCacheApiRef(signature cdn.Signature) CDNApiRef {
 		ProjectKey: signature.ProjectKey,
 		ExpireAt:   time.Now().AddDate(0, 6, 0),
 		CacheTag:   signature.Worker.CacheTag,
+		Ref:        signature.Worker.CacheRef,
 	}
 	return &apiRef
 }
@@ -407,6 +417,10 @@ func (a *CDNWorkerCacheAPIRef) ToHash() (string, error) {
 	m["project_key"] = a.ProjectKey
 	m["cache_tag"] = a.CacheTag
 	m["expireAt"] = a.ExpireAt.String()
+	// Unscoped caches keep their previous hash
+	if a.Ref != "" {
+		m["ref"] = a.Ref
+	}
 
 	hashRefU, err := hashstructure.Hash(m, nil)
 	if err != nil {
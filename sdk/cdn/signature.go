@@ -28,6 +28,7 @@ type SignatureWorker struct {
 	FileName      string
 	FilePerm      uint32
 	CacheTag      string
+	CacheRef      string // V2 git ref of the job, used to scope the cache
 	RunResultID   string // V2Runresult required
 	RunResultName string // V2Runresult required
 	RunResultType string // V2Runresult required
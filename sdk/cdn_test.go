@@ -2,10 +2,14 @@ package sdk
 
 import (
 	"encoding/json"
-	"github.com/ovh/cds/sdk/cdn"
-	"github.com/stretchr/testify/require"
+	"strconv"
 	"testing"
 	"time"
+
+	"github.com/mitchellh/hashstructure"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk/cdn"
 )
 
 func TestCDNItemLogJSON(t *testing.T) {
@@ -95,3 +99,22 @@ func TestCDNItemWorkerCacheJSON(t *testing.T) {
 	require.True(t, workerCacheApiRef.ExpireAt.After(time.Now()))
 	require.Equal(t, "mycache", itemU.APIRef.ToFilename())
 }
+
+func TestCDNWorkerCacheAPIRefHash(t *testing.T) {
+	expireAt := time.Now()
+	unscoped := CDNWorkerCacheAPIRef{ProjectKey: "KEY", CacheTag: "go-mod", ExpireAt: expireAt}
+	h1, err := unscoped.ToHash()
+	require.NoError(t, err)
+
+	// Hash of caches without ref must not change
+	m := map[string]string{"project_key": "KEY", "cache_tag": "go-mod", "expireAt": expireAt.String()}
+	hashRefU, err := hashstructure.Hash(m, nil)
+	require.NoError(t, err)
+	require.Equal(t, strconv.FormatUint(hashRefU, 10), h1)
+
+	scoped := unscoped
+	scoped.Ref = "refs/heads/main"
+	h2, err := scoped.ToHash()
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h2)
+}
@@ -0,0 +1,22 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectCacheList(ctx context.Context, pKey string, mods ...RequestModifier) ([]sdk.V2WorkerCache, error) {
+	var caches []sdk.V2WorkerCache
+	path := fmt.Sprintf("/v2/project/%s/cache", pKey)
+	_, err := c.GetJSON(ctx, path, &caches, mods...)
+	return caches, err
+}
+
+func (c *client) ProjectCacheDelete(ctx context.Context, pKey string, cacheKey string, mods ...RequestModifier) error {
+	path := fmt.Sprintf("/v2/project/%s/cache", pKey)
+	mods = append(mods, WithQueryParameter("key", cacheKey))
+	_, err := c.DeleteJSON(ctx, path, nil, mods...)
+	return err
+}
@@ -0,0 +1,47 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectEnvironmentCreate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error {
+	path := fmt.Sprintf("/v2/project/%s/environment", pKey)
+	_, err := c.PostJSON(ctx, path, env, env)
+	return err
+}
+
+func (c *client) ProjectEnvironmentDelete(ctx context.Context, pKey string, name string) error {
+	path := fmt.Sprintf("/v2/project/%s/environment/%s", pKey, name)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
+
+func (c *client) ProjectEnvironmentList(ctx context.Context, pKey string) ([]sdk.ProjectEnvironment, error) {
+	var envs []sdk.ProjectEnvironment
+	path := fmt.Sprintf("/v2/project/%s/environment", pKey)
+	_, err := c.GetJSON(ctx, path, &envs)
+	return envs, err
+}
+
+func (c *client) ProjectEnvironmentGet(ctx context.Context, pKey string, name string) (*sdk.ProjectEnvironment, error) {
+	var env sdk.ProjectEnvironment
+	path := fmt.Sprintf("/v2/project/%s/environment/%s", pKey, name)
+	_, err := c.GetJSON(ctx, path, &env)
+	return &env, err
+}
+
+func (c *client) ProjectEnvironmentUpdate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error {
+	path := fmt.Sprintf("/v2/project/%s/environment/%s", pKey, env.Name)
+	_, err := c.PutJSON(ctx, path, env, env)
+	return err
+}
+
+func (c *client) ProjectEnvironmentListDeployments(ctx context.Context, pKey string, name string, mods ...RequestModifier) ([]sdk.ProjectEnvironmentDeployment, error) {
+	var deployments []sdk.ProjectEnvironmentDeployment
+	path := fmt.Sprintf("/v2/project/%s/environment/%s/deployment", pKey, name)
+	_, err := c.GetJSON(ctx, path, &deployments, mods...)
+	return deployments, err
+}
@@ -0,0 +1,54 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectFreezeCreate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error {
+	path := fmt.Sprintf("/v2/project/%s/freeze", pKey)
+	_, err := c.PostJSON(ctx, path, f, f)
+	return err
+}
+
+func (c *client) ProjectFreezeDelete(ctx context.Context, pKey string, name string) error {
+	path := fmt.Sprintf("/v2/project/%s/freeze/%s", pKey, name)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
+
+func (c *client) ProjectFreezeList(ctx context.Context, pKey string) ([]sdk.ProjectFreeze, error) {
+	var freezes []sdk.ProjectFreeze
+	path := fmt.Sprintf("/v2/project/%s/freeze", pKey)
+	_, err := c.GetJSON(ctx, path, &freezes)
+	return freezes, err
+}
+
+func (c *client) ProjectFreezeGet(ctx context.Context, pKey string, name string) (*sdk.ProjectFreeze, error) {
+	var f sdk.ProjectFreeze
+	path := fmt.Sprintf("/v2/project/%s/freeze/%s", pKey, name)
+	_, err := c.GetJSON(ctx, path, &f)
+	return &f, err
+}
+
+func (c *client) ProjectFreezeUpdate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error {
+	path := fmt.Sprintf("/v2/project/%s/freeze/%s", pKey, f.Name)
+	_, err := c.PutJSON(ctx, path, f, f)
+	return err
+}
+
+func (c *client) ProjectFreezeOverride(ctx context.Context, pKey string, name string, req sdk.ProjectFreezeOverrideRequest) (*sdk.ProjectFreezeOverride, error) {
+	var o sdk.ProjectFreezeOverride
+	path := fmt.Sprintf("/v2/project/%s/freeze/%s/override", pKey, name)
+	_, err := c.PostJSON(ctx, path, req, &o)
+	return &o, err
+}
+
+func (c *client) ProjectFreezeListOverrides(ctx context.Context, pKey string, name string) ([]sdk.ProjectFreezeOverride, error) {
+	var overrides []sdk.ProjectFreezeOverride
+	path := fmt.Sprintf("/v2/project/%s/freeze/%s/override", pKey, name)
+	_, err := c.GetJSON(ctx, path, &overrides)
+	return overrides, err
+}
@@ -0,0 +1,27 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectRBACAsCodeGet(ctx context.Context, pKey string) (*sdk.ProjectRBACAsCode, error) {
+	var r sdk.ProjectRBACAsCode
+	path := fmt.Sprintf("/v2/project/%s/rbac/ascode", pKey)
+	_, err := c.GetJSON(ctx, path, &r)
+	return &r, err
+}
+
+func (c *client) ProjectRBACAsCodeUpdate(ctx context.Context, pKey string, r *sdk.ProjectRBACAsCode) error {
+	path := fmt.Sprintf("/v2/project/%s/rbac/ascode", pKey)
+	_, err := c.PutJSON(ctx, path, r, r)
+	return err
+}
+
+func (c *client) ProjectRBACAsCodeDelete(ctx context.Context, pKey string) error {
+	path := fmt.Sprintf("/v2/project/%s/rbac/ascode", pKey)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
@@ -0,0 +1,41 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectUsageList(ctx context.Context, pKey string, mods ...RequestModifier) ([]sdk.V2ProjectUsage, error) {
+	var usages []sdk.V2ProjectUsage
+	path := fmt.Sprintf("/v2/project/%s/usage", pKey)
+	_, err := c.GetJSON(ctx, path, &usages, mods...)
+	return usages, err
+}
+
+func (c *client) ProjectUsageGet(ctx context.Context, pKey string, month string) ([]sdk.V2ProjectUsageDetail, error) {
+	var details []sdk.V2ProjectUsageDetail
+	path := fmt.Sprintf("/v2/project/%s/usage/%s", pKey, month)
+	_, err := c.GetJSON(ctx, path, &details)
+	return details, err
+}
+
+func (c *client) ProjectQuotaGet(ctx context.Context, pKey string) (*sdk.ProjectQuota, error) {
+	var quota sdk.ProjectQuota
+	path := fmt.Sprintf("/v2/project/%s/quota", pKey)
+	_, err := c.GetJSON(ctx, path, &quota)
+	return &quota, err
+}
+
+func (c *client) ProjectQuotaUpdate(ctx context.Context, pKey string, quota *sdk.ProjectQuota) error {
+	path := fmt.Sprintf("/v2/project/%s/quota", pKey)
+	_, err := c.PutJSON(ctx, path, quota, quota)
+	return err
+}
+
+func (c *client) ProjectQuotaDelete(ctx context.Context, pKey string) error {
+	path := fmt.Sprintf("/v2/project/%s/quota", pKey)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
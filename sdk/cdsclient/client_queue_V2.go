@@ -13,15 +13,24 @@ import (
 	"github.com/rockbears/log"
 )
 
-func (c *client) V2QueueGetCacheLinks(ctx context.Context, regionName string, id string, cacheKey string) (*sdk.CDNItemLinks, error) {
+func (c *client) V2QueueGetCacheLinks(ctx context.Context, regionName string, id string, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
 	path := fmt.Sprintf("/v2/queue/%s/job/%s/cache/%s/link", regionName, id, url.PathEscape(cacheKey))
 	var result sdk.CDNItemLinks
-	if _, err := c.GetJSON(ctx, path, &result); err != nil {
+	if _, err := c.GetJSON(ctx, path, &result, RestoreKeys(restoreKeys...)); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+func (c *client) V2QueueGetTestQuarantines(ctx context.Context, regionName string, id string) (sdk.V2WorkflowTestQuarantines, error) {
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/test/quarantine", regionName, id)
+	var quarantines sdk.V2WorkflowTestQuarantines
+	if _, err := c.GetJSON(ctx, path, &quarantines); err != nil {
+		return nil, err
+	}
+	return quarantines, nil
+}
+
 func (c *client) V2QueueJobStepUpdate(ctx context.Context, regionName string, jobRunID string, stepsStatus sdk.JobStepsStatus) error {
 	path := fmt.Sprintf("/v2/queue/%s/job/%s/step", regionName, jobRunID)
 	if _, err := c.PostJSON(ctx, path, stepsStatus, nil); err != nil {
@@ -46,6 +55,19 @@ func (c *client) V2QueuePushJobInfo(ctx context.Context, regionName string, jobR
 	return nil
 }
 
+func (c *client) V2QueueJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, regionName string, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/debug", regionName, jobRunID)
+	return c.RequestWebsocket(ctx, goRoutines, path, msgToSend, msgReceived, errorReceived)
+}
+
+func (c *client) V2QueuePushJobSummary(ctx context.Context, regionName string, jobRunID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/summary", regionName, jobRunID)
+	if _, err := c.PostJSON(ctx, path, req, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *client) V2QueueJobResult(ctx context.Context, regionName string, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	path := fmt.Sprintf("/v2/queue/%s/job/%s/result", regionName, jobRunID)
 	if _, err := c.PostJSON(ctx, path, result, nil); err != nil {
@@ -88,6 +110,15 @@ func (c *client) V2QueueJobRunResultCreate(ctx context.Context, regionName strin
 	return nil
 }
 
+func (c *client) V2QueueJobRunAttestationSign(ctx context.Context, regionName string, jobRunID string, req sdk.V2AttestationSignRequest) (*sdk.V2AttestationSignResponse, error) {
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/attestation/sign", regionName, jobRunID)
+	var resp sdk.V2AttestationSignResponse
+	if _, err := c.PostJSON(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *client) V2QueueJobRunResultUpdate(ctx context.Context, regionName string, jobRunID string, result *sdk.V2WorkflowRunResult) error {
 	path := fmt.Sprintf("/v2/queue/%s/job/%s/runresult", regionName, jobRunID)
 	if _, err := c.PutJSON(ctx, path, result, result); err != nil {
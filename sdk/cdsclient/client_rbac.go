@@ -45,3 +45,75 @@ func (c *client) RBACGroupPermission(ctx context.Context, groupName string) (sdk
 	_, err := c.GetJSON(ctx, path, &summary)
 	return summary, err
 }
+
+func (c *client) RBACUserPermissionRules(ctx context.Context, username string) ([]sdk.RBACRule, error) {
+	path := "/v2/user/" + username + "/permissions/rules"
+	var rules []sdk.RBACRule
+	_, err := c.GetJSON(ctx, path, &rules)
+	return rules, err
+}
+
+func (c *client) RBACUserPermissionExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error) {
+	path := "/v2/user/" + req.Username + "/permissions/explain"
+	mods := []RequestModifier{WithQueryParameter("role", req.Role)}
+	params := map[string]string{
+		"project":     req.ProjectKey,
+		"vcs":         req.VCSServer,
+		"repository":  req.Repository,
+		"workflow":    req.Workflow,
+		"variableset": req.VariableSet,
+		"region":      req.Region,
+	}
+	for k, v := range params {
+		if v != "" {
+			mods = append(mods, WithQueryParameter(k, v))
+		}
+	}
+	var explanation sdk.RBACExplanation
+	_, err := c.GetJSON(ctx, path, &explanation, mods...)
+	return explanation, err
+}
+
+func (c *client) RBACUserGrantRequest(ctx context.Context, g sdk.RBACGrantRequest) (sdk.RBACGrantRequest, error) {
+	path := "/v2/user/" + g.Username + "/permissions/grant"
+	_, err := c.PostJSON(ctx, path, &g, &g)
+	return g, err
+}
+
+func (c *client) RBACUserGrantList(ctx context.Context, username string, status string) ([]sdk.RBACGrantRequest, error) {
+	path := "/v2/user/" + username + "/permissions/grant"
+	var grants []sdk.RBACGrantRequest
+	_, err := c.GetJSON(ctx, path, &grants, WithQueryParameter("status", status))
+	return grants, err
+}
+
+func (c *client) RBACGrantList(ctx context.Context, status string) ([]sdk.RBACGrantRequest, error) {
+	var grants []sdk.RBACGrantRequest
+	_, err := c.GetJSON(ctx, "/v2/rbac/grant", &grants, WithQueryParameter("status", status))
+	return grants, err
+}
+
+func (c *client) RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrantRequest, error) {
+	var g sdk.RBACGrantRequest
+	_, err := c.GetJSON(ctx, "/v2/rbac/grant/"+grantID, &g)
+	return g, err
+}
+
+func (c *client) RBACGrantApprove(ctx context.Context, grantID string, comment string) (sdk.RBACGrantRequest, error) {
+	return c.rbacGrantReview(ctx, grantID, "approve", comment)
+}
+
+func (c *client) RBACGrantReject(ctx context.Context, grantID string, comment string) (sdk.RBACGrantRequest, error) {
+	return c.rbacGrantReview(ctx, grantID, "reject", comment)
+}
+
+func (c *client) RBACGrantRevoke(ctx context.Context, grantID string, comment string) (sdk.RBACGrantRequest, error) {
+	return c.rbacGrantReview(ctx, grantID, "revoke", comment)
+}
+
+func (c *client) rbacGrantReview(ctx context.Context, grantID string, action string, comment string) (sdk.RBACGrantRequest, error) {
+	path := "/v2/rbac/grant/" + grantID + "/" + action
+	var g sdk.RBACGrantRequest
+	_, err := c.PostJSON(ctx, path, sdk.RBACGrantRequestReview{Comment: comment}, &g)
+	return g, err
+}
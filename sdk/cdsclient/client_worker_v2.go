@@ -27,6 +27,14 @@ func (c *client) V2WorkerList(ctx context.Context) ([]sdk.V2Worker, error) {
 	return workers, nil
 }
 
+func (c *client) V2WorkerAgentList(ctx context.Context) ([]sdk.V2WorkerAgent, error) {
+	var agents []sdk.V2WorkerAgent
+	if _, err := c.GetJSON(ctx, "/v2/agent", &agents); err != nil {
+		return nil, err
+	}
+	return agents, nil
+}
+
 func (c *client) V2WorkerGet(ctx context.Context, name string, mods ...RequestModifier) (*sdk.V2Worker, error) {
 	var worker sdk.V2Worker
 	url := "/v2/worker/" + name
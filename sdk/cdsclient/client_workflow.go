@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -260,6 +261,27 @@ func (c *client) WorkflowLogDownload(ctx context.Context, link sdk.CDNLogLink) (
 	return data, nil
 }
 
+func (c *client) WorkflowLogLines(ctx context.Context, link sdk.CDNLogLink, offset int64, limit uint) ([]sdk.CDNLogLine, int64, error) {
+	cdnURL, err := c.CDNURL()
+	if err != nil {
+		return nil, 0, err
+	}
+	linesURL := fmt.Sprintf("%s/item/%s/%s/lines?offset=%d&limit=%d", cdnURL, link.ItemType, link.APIRef, offset, limit)
+	data, headers, _, err := c.Request(ctx, http.MethodGet, linesURL, nil, func(req *http.Request) {
+		auth := "Bearer " + c.config.SessionToken
+		req.Header.Add("Authorization", auth)
+	})
+	if err != nil {
+		return nil, 0, newError(fmt.Errorf("can't get log lines from: %s: %v", linesURL, err))
+	}
+	var lines []sdk.CDNLogLine
+	if err := sdk.JSONUnmarshal(data, &lines); err != nil {
+		return nil, 0, newError(err)
+	}
+	total, _ := strconv.ParseInt(headers.Get("X-Total-Count"), 10, 64)
+	return lines, total, nil
+}
+
 func (c *client) WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/release", projectKey, workflowName, runNumber, nodeRunID)
 	btes, _ := json.Marshal(release)
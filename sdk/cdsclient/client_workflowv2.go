@@ -2,6 +2,7 @@ package cdsclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -69,6 +70,76 @@ func (c *client) WorkflowV2RunSearch(ctx context.Context, projectKey string, mod
 	return runs, nil
 }
 
+func (c *client) WorkflowV2RunStats(ctx context.Context, projectKey string, mods ...RequestModifier) (*sdk.V2WorkflowRunStats, error) {
+	var stats sdk.V2WorkflowRunStats
+	path := fmt.Sprintf("/v2/project/%s/run/stats", projectKey)
+	if _, err := c.GetJSON(ctx, path, &stats, mods...); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (c *client) WorkflowV2AttestationList(ctx context.Context, projectKey, digest string, mods ...RequestModifier) ([]sdk.V2WorkflowRunAttestation, error) {
+	var attestations []sdk.V2WorkflowRunAttestation
+	path := fmt.Sprintf("/v2/project/%s/attestation", projectKey)
+	mods = append(mods, WithQueryParameter("digest", digest))
+	if _, err := c.GetJSON(ctx, path, &attestations, mods...); err != nil {
+		return nil, err
+	}
+	return attestations, nil
+}
+
+func (c *client) WorkflowV2AttestationKeys(ctx context.Context, projectKey string) ([]sdk.V2AttestationPublicKey, error) {
+	var keys []sdk.V2AttestationPublicKey
+	if _, err := c.GetJSON(ctx, fmt.Sprintf("/v2/project/%s/attestation/key", projectKey), &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (c *client) WorkflowV2TestFlaky(ctx context.Context, projectKey, workflow string, mods ...RequestModifier) ([]sdk.V2WorkflowTestHistory, error) {
+	var tests []sdk.V2WorkflowTestHistory
+	path := fmt.Sprintf("/v2/project/%s/test/flaky", projectKey)
+	mods = append(mods, WithQueryParameter("workflow", workflow))
+	if _, err := c.GetJSON(ctx, path, &tests, mods...); err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
+
+func (c *client) WorkflowV2TestHistory(ctx context.Context, projectKey, workflow, suite, name string, mods ...RequestModifier) (*sdk.V2WorkflowTestHistory, error) {
+	var history sdk.V2WorkflowTestHistory
+	path := fmt.Sprintf("/v2/project/%s/test/history", projectKey)
+	mods = append(mods, WithQueryParameter("workflow", workflow), WithQueryParameter("suite", suite), WithQueryParameter("name", name))
+	if _, err := c.GetJSON(ctx, path, &history, mods...); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+func (c *client) WorkflowV2TestQuarantineList(ctx context.Context, projectKey string, mods ...RequestModifier) ([]sdk.V2WorkflowTestQuarantine, error) {
+	var quarantines []sdk.V2WorkflowTestQuarantine
+	path := fmt.Sprintf("/v2/project/%s/test/quarantine", projectKey)
+	if _, err := c.GetJSON(ctx, path, &quarantines, mods...); err != nil {
+		return nil, err
+	}
+	return quarantines, nil
+}
+
+func (c *client) WorkflowV2TestQuarantineAdd(ctx context.Context, projectKey string, quarantine sdk.V2WorkflowTestQuarantine) (*sdk.V2WorkflowTestQuarantine, error) {
+	path := fmt.Sprintf("/v2/project/%s/test/quarantine", projectKey)
+	if _, err := c.PostJSON(ctx, path, quarantine, &quarantine); err != nil {
+		return nil, err
+	}
+	return &quarantine, nil
+}
+
+func (c *client) WorkflowV2TestQuarantineDelete(ctx context.Context, projectKey, quarantineID string) error {
+	path := fmt.Sprintf("/v2/project/%s/test/quarantine/%s", projectKey, quarantineID)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
+
 func (c *client) WorkflowV2RunInfoList(ctx context.Context, projectKey, workflowRunID string, mods ...RequestModifier) ([]sdk.V2WorkflowRunInfo, error) {
 	var runInfos []sdk.V2WorkflowRunInfo
 	path := fmt.Sprintf("/v2/project/%s/run/%s/infos", projectKey, workflowRunID)
@@ -79,10 +150,19 @@ func (c *client) WorkflowV2RunInfoList(ctx context.Context, projectKey, workflow
 	return runInfos, nil
 }
 
-func (c *client) WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, mods ...RequestModifier) (*sdk.V2WorkflowRun, error) {
+func (c *client) WorkflowV2RunSummaryList(ctx context.Context, projectKey, workflowRunID string, mods ...RequestModifier) ([]sdk.V2WorkflowRunJobSummary, error) {
+	var summaries []sdk.V2WorkflowRunJobSummary
+	path := fmt.Sprintf("/v2/project/%s/run/%s/summary", projectKey, workflowRunID)
+	if _, err := c.GetJSON(ctx, path, &summaries, mods...); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (c *client) WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, payload sdk.V2WorkflowRunRestartRequest, mods ...RequestModifier) (*sdk.V2WorkflowRun, error) {
 	var run sdk.V2WorkflowRun
 	path := fmt.Sprintf("/v2/project/%s/run/%s/restart", projectKey, workflowRunID)
-	_, _, _, err := c.RequestJSON(ctx, http.MethodPost, path, nil, &run, mods...)
+	_, _, _, err := c.RequestJSON(ctx, http.MethodPost, path, payload, &run, mods...)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +239,15 @@ func (c *client) WorkflowV2RunJobLogLinks(ctx context.Context, projKey, workflow
 	return logsLinks, nil
 }
 
+func (c *client) WorkflowV2RunJobReplay(ctx context.Context, projKey, workflowRunID, jobRunID string) (*sdk.V2WorkflowRunJobReplay, error) {
+	var replay sdk.V2WorkflowRunJobReplay
+	path := fmt.Sprintf("/v2/project/%s/run/%s/job/%s/replay", projKey, workflowRunID, jobRunID)
+	if _, err := c.GetJSON(ctx, path, &replay); err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
+
 func (c *client) WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error {
 	path := fmt.Sprintf("/v2/project/%s/run/%s/stop", projKey, workflowRunID)
 	if _, _, _, err := c.RequestJSON(ctx, http.MethodPost, path, nil, nil); err != nil {
@@ -184,6 +273,20 @@ func (c *client) WorkflowV2RunJobInfoList(ctx context.Context, projKey, workflow
 	return infos, nil
 }
 
+func (c *client) WorkflowV2RunJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	path := fmt.Sprintf("/v2/project/%s/run/%s/job/%s/debug", projKey, workflowRunID, jobRunID)
+	return c.RequestWebsocket(ctx, goRoutines, path, msgToSend, msgReceived, errorReceived)
+}
+
+func (c *client) WorkflowV2RunJobDebugSessions(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobDebugSession, error) {
+	var sessions []sdk.V2WorkflowRunJobDebugSession
+	path := fmt.Sprintf("/v2/project/%s/run/%s/job/%s/debug/session", projKey, workflowRunID, jobRunID)
+	if _, _, _, err := c.RequestJSON(ctx, "GET", path, nil, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
 func (c *client) WorkflowV2VersionList(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string) ([]sdk.V2WorkflowVersion, error) {
 	var versions []sdk.V2WorkflowVersion
 	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/workflow/%s/version", projKey, url.PathEscape(vcsIdentifier), url.PathEscape(repoIdentifier), wkfName)
@@ -275,6 +275,34 @@ type ProjectClientV2 interface {
 	ProjectConcurrencyDelete(ctx context.Context, pKey string, name string) error
 	ProjectConcurrencyListRuns(ctx context.Context, pKey string, name string) ([]sdk.ProjectConcurrencyRunObject, error)
 
+	ProjectEnvironmentCreate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error
+	ProjectEnvironmentGet(ctx context.Context, pKey string, name string) (*sdk.ProjectEnvironment, error)
+	ProjectEnvironmentList(ctx context.Context, pKey string) ([]sdk.ProjectEnvironment, error)
+	ProjectEnvironmentUpdate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error
+	ProjectEnvironmentDelete(ctx context.Context, pKey string, name string) error
+	ProjectEnvironmentListDeployments(ctx context.Context, pKey string, name string, mods ...RequestModifier) ([]sdk.ProjectEnvironmentDeployment, error)
+
+	ProjectFreezeCreate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error
+	ProjectFreezeGet(ctx context.Context, pKey string, name string) (*sdk.ProjectFreeze, error)
+	ProjectFreezeList(ctx context.Context, pKey string) ([]sdk.ProjectFreeze, error)
+	ProjectFreezeUpdate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error
+	ProjectFreezeDelete(ctx context.Context, pKey string, name string) error
+	ProjectFreezeOverride(ctx context.Context, pKey string, name string, req sdk.ProjectFreezeOverrideRequest) (*sdk.ProjectFreezeOverride, error)
+	ProjectFreezeListOverrides(ctx context.Context, pKey string, name string) ([]sdk.ProjectFreezeOverride, error)
+
+	ProjectUsageList(ctx context.Context, pKey string, mods ...RequestModifier) ([]sdk.V2ProjectUsage, error)
+	ProjectUsageGet(ctx context.Context, pKey string, month string) ([]sdk.V2ProjectUsageDetail, error)
+	ProjectQuotaGet(ctx context.Context, pKey string) (*sdk.ProjectQuota, error)
+	ProjectQuotaUpdate(ctx context.Context, pKey string, quota *sdk.ProjectQuota) error
+	ProjectQuotaDelete(ctx context.Context, pKey string) error
+
+	ProjectRBACAsCodeGet(ctx context.Context, pKey string) (*sdk.ProjectRBACAsCode, error)
+	ProjectRBACAsCodeUpdate(ctx context.Context, pKey string, r *sdk.ProjectRBACAsCode) error
+	ProjectRBACAsCodeDelete(ctx context.Context, pKey string) error
+
+	ProjectCacheList(ctx context.Context, pKey string, mods ...RequestModifier) ([]sdk.V2WorkerCache, error)
+	ProjectCacheDelete(ctx context.Context, pKey string, cacheKey string, mods ...RequestModifier) error
+
 	ProjectV2Access(ctx context.Context, projectKey, sessionID string, itemType sdk.CDNItemType) error
 
 	ProjectWebHookAdd(ctx context.Context, projectKey string, r sdk.PostProjectWebHook) (*sdk.HookAccessData, error)
@@ -328,6 +356,15 @@ type RBACClient interface {
 	RBACList(ctx context.Context) ([]sdk.RBAC, error)
 	RBACUserPermission(ctx context.Context, username string) (sdk.PermissionSummary, error)
 	RBACGroupPermission(ctx context.Context, groupName string) (sdk.PermissionSummary, error)
+	RBACUserPermissionRules(ctx context.Context, username string) ([]sdk.RBACRule, error)
+	RBACUserPermissionExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error)
+	RBACUserGrantRequest(ctx context.Context, g sdk.RBACGrantRequest) (sdk.RBACGrantRequest, error)
+	RBACUserGrantList(ctx context.Context, username string, status string) ([]sdk.RBACGrantRequest, error)
+	RBACGrantList(ctx context.Context, status string) ([]sdk.RBACGrantRequest, error)
+	RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrantRequest, error)
+	RBACGrantApprove(ctx context.Context, grantID string, comment string) (sdk.RBACGrantRequest, error)
+	RBACGrantReject(ctx context.Context, grantID string, comment string) (sdk.RBACGrantRequest, error)
+	RBACGrantRevoke(ctx context.Context, grantID string, comment string) (sdk.RBACGrantRequest, error)
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -360,11 +397,15 @@ type V2QueueClient interface {
 	V2QueueJobRunResultsSynchronize(ctx context.Context, regionName string, jobRunID string) error
 	V2QueueJobRunResultCreate(ctx context.Context, regionName string, jobRunID string, result *sdk.V2WorkflowRunResult) error
 	V2QueueJobRunResultUpdate(ctx context.Context, regionName string, jobRunID string, result *sdk.V2WorkflowRunResult) error
+	V2QueueJobRunAttestationSign(ctx context.Context, regionName string, jobRunID string, req sdk.V2AttestationSignRequest) (*sdk.V2AttestationSignResponse, error)
 	V2QueuePushRunInfo(ctx context.Context, regionName string, jobRunID string, msg sdk.V2WorkflowRunInfo) error
 	V2QueuePushJobInfo(ctx context.Context, regionName string, jobRunID string, msg sdk.V2SendJobRunInfo) error
+	V2QueueJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, regionName string, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error
+	V2QueuePushJobSummary(ctx context.Context, regionName string, jobRunID string, req sdk.V2WorkflowRunJobSummaryRequest) error
 	V2QueueWorkerTakeJob(ctx context.Context, region, runJobID string) (*sdk.V2TakeJobResponse, error)
 	V2QueueJobStepUpdate(ctx context.Context, regionName string, id string, stepsStatus sdk.JobStepsStatus) error
-	V2QueueGetCacheLinks(ctx context.Context, regionName string, id string, cacheKey string) (*sdk.CDNItemLinks, error)
+	V2QueueGetCacheLinks(ctx context.Context, regionName string, id string, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error)
+	V2QueueGetTestQuarantines(ctx context.Context, regionName string, id string) (sdk.V2WorkflowTestQuarantines, error)
 }
 
 // QueueClient exposes queue related functions
@@ -436,6 +477,7 @@ type WorkerClient interface {
 	WorkerModelv2List(ctx context.Context, projKey string, vcsIdentifier string, repoIdentifier string, filter *WorkerModelV2Filter) ([]sdk.V2WorkerModel, error)
 	V2WorkerGet(ctx context.Context, name string, mods ...RequestModifier) (*sdk.V2Worker, error)
 	V2WorkerList(ctx context.Context) ([]sdk.V2Worker, error)
+	V2WorkerAgentList(ctx context.Context) ([]sdk.V2WorkerAgent, error)
 	CDNClient
 }
 
@@ -470,17 +512,29 @@ type WorkflowV2Client interface {
 	WorkflowV2RunFromHook(ctx context.Context, projectKey, vcsIdentifier, repoIdentifier, wkfName string, runRequest sdk.V2WorkflowRunHookRequest, mods ...RequestModifier) (*sdk.V2WorkflowRun, error)
 	WorkflowV2Run(ctx context.Context, projectKey, vcsIdentifier, repoIdentifier, wkfName string, payload sdk.V2WorkflowRunManualRequest, mods ...RequestModifier) (*sdk.V2WorkflowRunManualResponse, error)
 	WorkflowV2RunDelete(ctx context.Context, projectKey, runIdentifier string) error
-	WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, mods ...RequestModifier) (*sdk.V2WorkflowRun, error)
+	WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, payload sdk.V2WorkflowRunRestartRequest, mods ...RequestModifier) (*sdk.V2WorkflowRun, error)
 	WorkflowV2JobStart(ctx context.Context, projectKey, workflowRunID, jobIdentifier string, payload map[string]interface{}, mods ...RequestModifier) (*sdk.V2WorkflowRun, error)
 	WorkflowV2JobsStart(ctx context.Context, projectKey, workflowRunID string, payload sdk.V2WorkflowRunTriggerJobsRequest, mods ...RequestModifier) (*sdk.V2WorkflowRun, error)
 	WorkflowV2RunSearchAllProjects(ctx context.Context, offset, limit int64, mods ...RequestModifier) ([]sdk.V2WorkflowRun, error)
 	WorkflowV2RunSearch(ctx context.Context, projectKey string, mods ...RequestModifier) ([]sdk.V2WorkflowRun, error)
+	WorkflowV2RunStats(ctx context.Context, projectKey string, mods ...RequestModifier) (*sdk.V2WorkflowRunStats, error)
+	WorkflowV2AttestationList(ctx context.Context, projectKey, digest string, mods ...RequestModifier) ([]sdk.V2WorkflowRunAttestation, error)
+	WorkflowV2AttestationKeys(ctx context.Context, projectKey string) ([]sdk.V2AttestationPublicKey, error)
+	WorkflowV2TestFlaky(ctx context.Context, projectKey, workflow string, mods ...RequestModifier) ([]sdk.V2WorkflowTestHistory, error)
+	WorkflowV2TestHistory(ctx context.Context, projectKey, workflow, suite, name string, mods ...RequestModifier) (*sdk.V2WorkflowTestHistory, error)
+	WorkflowV2TestQuarantineList(ctx context.Context, projectKey string, mods ...RequestModifier) ([]sdk.V2WorkflowTestQuarantine, error)
+	WorkflowV2TestQuarantineAdd(ctx context.Context, projectKey string, quarantine sdk.V2WorkflowTestQuarantine) (*sdk.V2WorkflowTestQuarantine, error)
+	WorkflowV2TestQuarantineDelete(ctx context.Context, projectKey, quarantineID string) error
 	WorkflowV2RunInfoList(ctx context.Context, projectKey, workflowRunID string, mods ...RequestModifier) ([]sdk.V2WorkflowRunInfo, error)
+	WorkflowV2RunSummaryList(ctx context.Context, projectKey, workflowRunID string, mods ...RequestModifier) ([]sdk.V2WorkflowRunJobSummary, error)
 	WorkflowV2RunStatus(ctx context.Context, projectKey, workflowRunID string) (*sdk.V2WorkflowRun, error)
 	WorkflowV2RunJobs(ctx context.Context, projKey, workflowRunID string) ([]sdk.V2WorkflowRunJob, error)
 	WorkflowV2RunJob(ctx context.Context, projKey, workflowRunID, jobRunID string) (*sdk.V2WorkflowRunJob, error)
 	WorkflowV2RunJobInfoList(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobInfo, error)
 	WorkflowV2RunJobLogLinks(ctx context.Context, projKey, workflowRunID, jobRunID string) (sdk.CDNLogLinks, error)
+	WorkflowV2RunJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error
+	WorkflowV2RunJobDebugSessions(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobDebugSession, error)
+	WorkflowV2RunJobReplay(ctx context.Context, projKey, workflowRunID, jobRunID string) (*sdk.V2WorkflowRunJobReplay, error)
 	WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error
 	WorkflowV2StopJob(ctx context.Context, projKey, workflowRunID, jobIdentifier string) error
 	WorkflowV2RunResultList(ctx context.Context, projKey, runIdentifier string) ([]sdk.V2WorkflowRunResult, error)
@@ -521,6 +575,8 @@ type WorkflowClient interface {
 	WorkflowAccess(ctx context.Context, projectKey string, workflowID int64, sessionID string, itemType sdk.CDNItemType) error
 	WorkflowRunExist(ctx context.Context, id int64) (bool, error)
 	WorkflowLogDownload(ctx context.Context, link sdk.CDNLogLink) ([]byte, error)
+	// WorkflowLogLines returns limit lines of the log from offset, a negative offset returns the last lines. The total count of lines is returned.
+	WorkflowLogLines(ctx context.Context, link sdk.CDNLogLink, offset int64, limit uint) ([]sdk.CDNLogLine, int64, error)
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
 	WorkflowAllHooksList() ([]sdk.NodeHook, error)
 	WorkflowAllHooksExecutions() ([]string, error)
@@ -813,6 +869,18 @@ func ModelType(modelType string) RequestModifier {
 	}
 }
 
+func RestoreKeys(keys ...string) RequestModifier {
+	return func(r *http.Request) {
+		if len(keys) > 0 {
+			q := r.URL.Query()
+			for _, k := range keys {
+				q.Add("restoreKey", k)
+			}
+			r.URL.RawQuery = q.Encode()
+		}
+	}
+}
+
 func Workflows(ws ...string) RequestModifier {
 	return func(r *http.Request) {
 		q := r.URL.Query()
@@ -2884,18 +2884,18 @@ func (mr *MockHatcheryServiceClientMockRecorder) V2HatcheryTakeJob(ctx, regionNa
 }
 
 // V2QueueGetCacheLinks mocks base method.
-func (m *MockHatcheryServiceClient) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string) (*sdk.CDNItemLinks, error) {
+func (m *MockHatcheryServiceClient) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey)
+	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey, restoreKeys)
 	ret0, _ := ret[0].(*sdk.CDNItemLinks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // V2QueueGetCacheLinks indicates an expected call of V2QueueGetCacheLinks.
-func (mr *MockHatcheryServiceClientMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey any) *gomock.Call {
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey, restoreKeys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey, restoreKeys)
 }
 
 // V2QueueGetJobRun mocks base method.
@@ -2913,6 +2913,35 @@ func (mr *MockHatcheryServiceClientMockRecorder) V2QueueGetJobRun(ctx, regionNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueGetTestQuarantines mocks base method.
+func (m *MockHatcheryServiceClient) V2QueueGetTestQuarantines(ctx context.Context, regionName, id string) (sdk.V2WorkflowTestQuarantines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueGetTestQuarantines", ctx, regionName, id)
+	ret0, _ := ret[0].(sdk.V2WorkflowTestQuarantines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueGetTestQuarantines indicates an expected call of V2QueueGetTestQuarantines.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueueGetTestQuarantines(ctx, regionName, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetTestQuarantines", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueGetTestQuarantines), ctx, regionName, id)
+}
+
+// V2QueueJobDebug mocks base method.
+func (m *MockHatcheryServiceClient) V2QueueJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebug", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebug indicates an expected call of V2QueueJobDebug.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueueJobDebug(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebug", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueJobDebug), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockHatcheryServiceClient) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -2927,6 +2956,21 @@ func (mr *MockHatcheryServiceClientMockRecorder) V2QueueJobResult(ctx, region, j
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobResult", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueJobResult), ctx, region, jobRunID, result)
 }
 
+// V2QueueJobRunAttestationSign mocks base method.
+func (m *MockHatcheryServiceClient) V2QueueJobRunAttestationSign(ctx context.Context, regionName, jobRunID string, req sdk.V2AttestationSignRequest) (*sdk.V2AttestationSignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobRunAttestationSign", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(*sdk.V2AttestationSignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobRunAttestationSign indicates an expected call of V2QueueJobRunAttestationSign.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueueJobRunAttestationSign(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobRunAttestationSign", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueJobRunAttestationSign), ctx, regionName, jobRunID, req)
+}
+
 // V2QueueJobRunResultCreate mocks base method.
 func (m *MockHatcheryServiceClient) V2QueueJobRunResultCreate(ctx context.Context, regionName, jobRunID string, result *sdk.V2WorkflowRunResult) error {
 	m.ctrl.T.Helper()
@@ -3046,6 +3090,20 @@ func (mr *MockHatcheryServiceClientMockRecorder) V2QueuePushJobInfo(ctx, regionN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobInfo", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueuePushJobInfo), ctx, regionName, jobRunID, msg)
 }
 
+// V2QueuePushJobSummary mocks base method.
+func (m *MockHatcheryServiceClient) V2QueuePushJobSummary(ctx context.Context, regionName, jobRunID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobSummary", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobSummary indicates an expected call of V2QueuePushJobSummary.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueuePushJobSummary(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobSummary", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueuePushJobSummary), ctx, regionName, jobRunID, req)
+}
+
 // V2QueuePushRunInfo mocks base method.
 func (m *MockHatcheryServiceClient) V2QueuePushRunInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2WorkflowRunInfo) error {
 	m.ctrl.T.Helper()
@@ -3114,6 +3172,45 @@ func (m *MockProjectClientV2) EXPECT() *MockProjectClientV2MockRecorder {
 	return m.recorder
 }
 
+// ProjectCacheDelete mocks base method.
+func (m *MockProjectClientV2) ProjectCacheDelete(ctx context.Context, pKey, cacheKey string, mods ...cdsclient.RequestModifier) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey, cacheKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectCacheDelete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCacheDelete indicates an expected call of ProjectCacheDelete.
+func (mr *MockProjectClientV2MockRecorder) ProjectCacheDelete(ctx, pKey, cacheKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey, cacheKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCacheDelete", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectCacheDelete), varargs...)
+}
+
+// ProjectCacheList mocks base method.
+func (m *MockProjectClientV2) ProjectCacheList(ctx context.Context, pKey string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkerCache, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectCacheList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkerCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCacheList indicates an expected call of ProjectCacheList.
+func (mr *MockProjectClientV2MockRecorder) ProjectCacheList(ctx, pKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCacheList", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectCacheList), varargs...)
+}
+
 // ProjectConcurrencyCreate mocks base method.
 func (m *MockProjectClientV2) ProjectConcurrencyCreate(ctx context.Context, pKey string, c *sdk.ProjectConcurrency) error {
 	m.ctrl.T.Helper()
@@ -3201,6 +3298,200 @@ func (mr *MockProjectClientV2MockRecorder) ProjectConcurrencyUpdate(ctx, pKey, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectConcurrencyUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectConcurrencyUpdate), ctx, pKey, c)
 }
 
+// ProjectEnvironmentCreate mocks base method.
+func (m *MockProjectClientV2) ProjectEnvironmentCreate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentCreate", ctx, pKey, env)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectEnvironmentCreate indicates an expected call of ProjectEnvironmentCreate.
+func (mr *MockProjectClientV2MockRecorder) ProjectEnvironmentCreate(ctx, pKey, env any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentCreate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectEnvironmentCreate), ctx, pKey, env)
+}
+
+// ProjectEnvironmentDelete mocks base method.
+func (m *MockProjectClientV2) ProjectEnvironmentDelete(ctx context.Context, pKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentDelete", ctx, pKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectEnvironmentDelete indicates an expected call of ProjectEnvironmentDelete.
+func (mr *MockProjectClientV2MockRecorder) ProjectEnvironmentDelete(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentDelete", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectEnvironmentDelete), ctx, pKey, name)
+}
+
+// ProjectEnvironmentGet mocks base method.
+func (m *MockProjectClientV2) ProjectEnvironmentGet(ctx context.Context, pKey, name string) (*sdk.ProjectEnvironment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentGet", ctx, pKey, name)
+	ret0, _ := ret[0].(*sdk.ProjectEnvironment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectEnvironmentGet indicates an expected call of ProjectEnvironmentGet.
+func (mr *MockProjectClientV2MockRecorder) ProjectEnvironmentGet(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentGet", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectEnvironmentGet), ctx, pKey, name)
+}
+
+// ProjectEnvironmentList mocks base method.
+func (m *MockProjectClientV2) ProjectEnvironmentList(ctx context.Context, pKey string) ([]sdk.ProjectEnvironment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentList", ctx, pKey)
+	ret0, _ := ret[0].([]sdk.ProjectEnvironment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectEnvironmentList indicates an expected call of ProjectEnvironmentList.
+func (mr *MockProjectClientV2MockRecorder) ProjectEnvironmentList(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentList", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectEnvironmentList), ctx, pKey)
+}
+
+// ProjectEnvironmentListDeployments mocks base method.
+func (m *MockProjectClientV2) ProjectEnvironmentListDeployments(ctx context.Context, pKey, name string, mods ...cdsclient.RequestModifier) ([]sdk.ProjectEnvironmentDeployment, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectEnvironmentListDeployments", varargs...)
+	ret0, _ := ret[0].([]sdk.ProjectEnvironmentDeployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectEnvironmentListDeployments indicates an expected call of ProjectEnvironmentListDeployments.
+func (mr *MockProjectClientV2MockRecorder) ProjectEnvironmentListDeployments(ctx, pKey, name any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentListDeployments", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectEnvironmentListDeployments), varargs...)
+}
+
+// ProjectEnvironmentUpdate mocks base method.
+func (m *MockProjectClientV2) ProjectEnvironmentUpdate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentUpdate", ctx, pKey, env)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectEnvironmentUpdate indicates an expected call of ProjectEnvironmentUpdate.
+func (mr *MockProjectClientV2MockRecorder) ProjectEnvironmentUpdate(ctx, pKey, env any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectEnvironmentUpdate), ctx, pKey, env)
+}
+
+// ProjectFreezeCreate mocks base method.
+func (m *MockProjectClientV2) ProjectFreezeCreate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeCreate", ctx, pKey, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeCreate indicates an expected call of ProjectFreezeCreate.
+func (mr *MockProjectClientV2MockRecorder) ProjectFreezeCreate(ctx, pKey, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeCreate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectFreezeCreate), ctx, pKey, f)
+}
+
+// ProjectFreezeDelete mocks base method.
+func (m *MockProjectClientV2) ProjectFreezeDelete(ctx context.Context, pKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeDelete", ctx, pKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeDelete indicates an expected call of ProjectFreezeDelete.
+func (mr *MockProjectClientV2MockRecorder) ProjectFreezeDelete(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeDelete", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectFreezeDelete), ctx, pKey, name)
+}
+
+// ProjectFreezeGet mocks base method.
+func (m *MockProjectClientV2) ProjectFreezeGet(ctx context.Context, pKey, name string) (*sdk.ProjectFreeze, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeGet", ctx, pKey, name)
+	ret0, _ := ret[0].(*sdk.ProjectFreeze)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeGet indicates an expected call of ProjectFreezeGet.
+func (mr *MockProjectClientV2MockRecorder) ProjectFreezeGet(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeGet", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectFreezeGet), ctx, pKey, name)
+}
+
+// ProjectFreezeList mocks base method.
+func (m *MockProjectClientV2) ProjectFreezeList(ctx context.Context, pKey string) ([]sdk.ProjectFreeze, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeList", ctx, pKey)
+	ret0, _ := ret[0].([]sdk.ProjectFreeze)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeList indicates an expected call of ProjectFreezeList.
+func (mr *MockProjectClientV2MockRecorder) ProjectFreezeList(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeList", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectFreezeList), ctx, pKey)
+}
+
+// ProjectFreezeListOverrides mocks base method.
+func (m *MockProjectClientV2) ProjectFreezeListOverrides(ctx context.Context, pKey, name string) ([]sdk.ProjectFreezeOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeListOverrides", ctx, pKey, name)
+	ret0, _ := ret[0].([]sdk.ProjectFreezeOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeListOverrides indicates an expected call of ProjectFreezeListOverrides.
+func (mr *MockProjectClientV2MockRecorder) ProjectFreezeListOverrides(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeListOverrides", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectFreezeListOverrides), ctx, pKey, name)
+}
+
+// ProjectFreezeOverride mocks base method.
+func (m *MockProjectClientV2) ProjectFreezeOverride(ctx context.Context, pKey, name string, req sdk.ProjectFreezeOverrideRequest) (*sdk.ProjectFreezeOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeOverride", ctx, pKey, name, req)
+	ret0, _ := ret[0].(*sdk.ProjectFreezeOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeOverride indicates an expected call of ProjectFreezeOverride.
+func (mr *MockProjectClientV2MockRecorder) ProjectFreezeOverride(ctx, pKey, name, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeOverride", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectFreezeOverride), ctx, pKey, name, req)
+}
+
+// ProjectFreezeUpdate mocks base method.
+func (m *MockProjectClientV2) ProjectFreezeUpdate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeUpdate", ctx, pKey, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeUpdate indicates an expected call of ProjectFreezeUpdate.
+func (mr *MockProjectClientV2MockRecorder) ProjectFreezeUpdate(ctx, pKey, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectFreezeUpdate), ctx, pKey, f)
+}
+
 // ProjectNotificationCreate mocks base method.
 func (m *MockProjectClientV2) ProjectNotificationCreate(ctx context.Context, pKey string, notif *sdk.ProjectNotification) error {
 	m.ctrl.T.Helper()
@@ -3273,6 +3564,92 @@ func (mr *MockProjectClientV2MockRecorder) ProjectNotificationUpdate(ctx, pKey,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectNotificationUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectNotificationUpdate), ctx, pKey, notif)
 }
 
+// ProjectQuotaDelete mocks base method.
+func (m *MockProjectClientV2) ProjectQuotaDelete(ctx context.Context, pKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectQuotaDelete", ctx, pKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectQuotaDelete indicates an expected call of ProjectQuotaDelete.
+func (mr *MockProjectClientV2MockRecorder) ProjectQuotaDelete(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaDelete", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectQuotaDelete), ctx, pKey)
+}
+
+// ProjectQuotaGet mocks base method.
+func (m *MockProjectClientV2) ProjectQuotaGet(ctx context.Context, pKey string) (*sdk.ProjectQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectQuotaGet", ctx, pKey)
+	ret0, _ := ret[0].(*sdk.ProjectQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectQuotaGet indicates an expected call of ProjectQuotaGet.
+func (mr *MockProjectClientV2MockRecorder) ProjectQuotaGet(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaGet", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectQuotaGet), ctx, pKey)
+}
+
+// ProjectQuotaUpdate mocks base method.
+func (m *MockProjectClientV2) ProjectQuotaUpdate(ctx context.Context, pKey string, quota *sdk.ProjectQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectQuotaUpdate", ctx, pKey, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectQuotaUpdate indicates an expected call of ProjectQuotaUpdate.
+func (mr *MockProjectClientV2MockRecorder) ProjectQuotaUpdate(ctx, pKey, quota any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectQuotaUpdate), ctx, pKey, quota)
+}
+
+// ProjectRBACAsCodeDelete mocks base method.
+func (m *MockProjectClientV2) ProjectRBACAsCodeDelete(ctx context.Context, pKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRBACAsCodeDelete", ctx, pKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRBACAsCodeDelete indicates an expected call of ProjectRBACAsCodeDelete.
+func (mr *MockProjectClientV2MockRecorder) ProjectRBACAsCodeDelete(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRBACAsCodeDelete", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectRBACAsCodeDelete), ctx, pKey)
+}
+
+// ProjectRBACAsCodeGet mocks base method.
+func (m *MockProjectClientV2) ProjectRBACAsCodeGet(ctx context.Context, pKey string) (*sdk.ProjectRBACAsCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRBACAsCodeGet", ctx, pKey)
+	ret0, _ := ret[0].(*sdk.ProjectRBACAsCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRBACAsCodeGet indicates an expected call of ProjectRBACAsCodeGet.
+func (mr *MockProjectClientV2MockRecorder) ProjectRBACAsCodeGet(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRBACAsCodeGet", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectRBACAsCodeGet), ctx, pKey)
+}
+
+// ProjectRBACAsCodeUpdate mocks base method.
+func (m *MockProjectClientV2) ProjectRBACAsCodeUpdate(ctx context.Context, pKey string, r *sdk.ProjectRBACAsCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRBACAsCodeUpdate", ctx, pKey, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRBACAsCodeUpdate indicates an expected call of ProjectRBACAsCodeUpdate.
+func (mr *MockProjectClientV2MockRecorder) ProjectRBACAsCodeUpdate(ctx, pKey, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRBACAsCodeUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectRBACAsCodeUpdate), ctx, pKey, r)
+}
+
 // ProjectRunPurge mocks base method.
 func (m *MockProjectClientV2) ProjectRunPurge(ctx context.Context, projectKey string) error {
 	m.ctrl.T.Helper()
@@ -3316,6 +3693,41 @@ func (mr *MockProjectClientV2MockRecorder) ProjectRunRetentionImport(ctx, projec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRunRetentionImport", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectRunRetentionImport), ctx, projectKey, prr)
 }
 
+// ProjectUsageGet mocks base method.
+func (m *MockProjectClientV2) ProjectUsageGet(ctx context.Context, pKey, month string) ([]sdk.V2ProjectUsageDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectUsageGet", ctx, pKey, month)
+	ret0, _ := ret[0].([]sdk.V2ProjectUsageDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectUsageGet indicates an expected call of ProjectUsageGet.
+func (mr *MockProjectClientV2MockRecorder) ProjectUsageGet(ctx, pKey, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUsageGet", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectUsageGet), ctx, pKey, month)
+}
+
+// ProjectUsageList mocks base method.
+func (m *MockProjectClientV2) ProjectUsageList(ctx context.Context, pKey string, mods ...cdsclient.RequestModifier) ([]sdk.V2ProjectUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectUsageList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2ProjectUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectUsageList indicates an expected call of ProjectUsageList.
+func (mr *MockProjectClientV2MockRecorder) ProjectUsageList(ctx, pKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUsageList", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectUsageList), varargs...)
+}
+
 // ProjectV2Access mocks base method.
 func (m *MockProjectClientV2) ProjectV2Access(ctx context.Context, projectKey, sessionID string, itemType sdk.CDNItemType) error {
 	m.ctrl.T.Helper()
@@ -4272,6 +4684,81 @@ func (mr *MockRBACClientMockRecorder) RBACGet(ctx, permissionIdentifier any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGet", reflect.TypeOf((*MockRBACClient)(nil).RBACGet), ctx, permissionIdentifier)
 }
 
+// RBACGrantApprove mocks base method.
+func (m *MockRBACClient) RBACGrantApprove(ctx context.Context, grantID, comment string) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantApprove", ctx, grantID, comment)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantApprove indicates an expected call of RBACGrantApprove.
+func (mr *MockRBACClientMockRecorder) RBACGrantApprove(ctx, grantID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantApprove", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantApprove), ctx, grantID, comment)
+}
+
+// RBACGrantGet mocks base method.
+func (m *MockRBACClient) RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantGet", ctx, grantID)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantGet indicates an expected call of RBACGrantGet.
+func (mr *MockRBACClientMockRecorder) RBACGrantGet(ctx, grantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantGet", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantGet), ctx, grantID)
+}
+
+// RBACGrantList mocks base method.
+func (m *MockRBACClient) RBACGrantList(ctx context.Context, status string) ([]sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantList", ctx, status)
+	ret0, _ := ret[0].([]sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantList indicates an expected call of RBACGrantList.
+func (mr *MockRBACClientMockRecorder) RBACGrantList(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantList", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantList), ctx, status)
+}
+
+// RBACGrantReject mocks base method.
+func (m *MockRBACClient) RBACGrantReject(ctx context.Context, grantID, comment string) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantReject", ctx, grantID, comment)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantReject indicates an expected call of RBACGrantReject.
+func (mr *MockRBACClientMockRecorder) RBACGrantReject(ctx, grantID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantReject", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantReject), ctx, grantID, comment)
+}
+
+// RBACGrantRevoke mocks base method.
+func (m *MockRBACClient) RBACGrantRevoke(ctx context.Context, grantID, comment string) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantRevoke", ctx, grantID, comment)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantRevoke indicates an expected call of RBACGrantRevoke.
+func (mr *MockRBACClientMockRecorder) RBACGrantRevoke(ctx, grantID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantRevoke", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantRevoke), ctx, grantID, comment)
+}
+
 // RBACGroupPermission mocks base method.
 func (m *MockRBACClient) RBACGroupPermission(ctx context.Context, groupName string) (sdk.PermissionSummary, error) {
 	m.ctrl.T.Helper()
@@ -4322,6 +4809,36 @@ func (mr *MockRBACClientMockRecorder) RBACList(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACList", reflect.TypeOf((*MockRBACClient)(nil).RBACList), ctx)
 }
 
+// RBACUserGrantList mocks base method.
+func (m *MockRBACClient) RBACUserGrantList(ctx context.Context, username, status string) ([]sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserGrantList", ctx, username, status)
+	ret0, _ := ret[0].([]sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserGrantList indicates an expected call of RBACUserGrantList.
+func (mr *MockRBACClientMockRecorder) RBACUserGrantList(ctx, username, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserGrantList", reflect.TypeOf((*MockRBACClient)(nil).RBACUserGrantList), ctx, username, status)
+}
+
+// RBACUserGrantRequest mocks base method.
+func (m *MockRBACClient) RBACUserGrantRequest(ctx context.Context, g sdk.RBACGrantRequest) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserGrantRequest", ctx, g)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserGrantRequest indicates an expected call of RBACUserGrantRequest.
+func (mr *MockRBACClientMockRecorder) RBACUserGrantRequest(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserGrantRequest", reflect.TypeOf((*MockRBACClient)(nil).RBACUserGrantRequest), ctx, g)
+}
+
 // RBACUserPermission mocks base method.
 func (m *MockRBACClient) RBACUserPermission(ctx context.Context, username string) (sdk.PermissionSummary, error) {
 	m.ctrl.T.Helper()
@@ -4337,6 +4854,36 @@ func (mr *MockRBACClientMockRecorder) RBACUserPermission(ctx, username any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermission", reflect.TypeOf((*MockRBACClient)(nil).RBACUserPermission), ctx, username)
 }
 
+// RBACUserPermissionExplain mocks base method.
+func (m *MockRBACClient) RBACUserPermissionExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserPermissionExplain", ctx, req)
+	ret0, _ := ret[0].(sdk.RBACExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserPermissionExplain indicates an expected call of RBACUserPermissionExplain.
+func (mr *MockRBACClientMockRecorder) RBACUserPermissionExplain(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermissionExplain", reflect.TypeOf((*MockRBACClient)(nil).RBACUserPermissionExplain), ctx, req)
+}
+
+// RBACUserPermissionRules mocks base method.
+func (m *MockRBACClient) RBACUserPermissionRules(ctx context.Context, username string) ([]sdk.RBACRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserPermissionRules", ctx, username)
+	ret0, _ := ret[0].([]sdk.RBACRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserPermissionRules indicates an expected call of RBACUserPermissionRules.
+func (mr *MockRBACClientMockRecorder) RBACUserPermissionRules(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermissionRules", reflect.TypeOf((*MockRBACClient)(nil).RBACUserPermissionRules), ctx, username)
+}
+
 // MockProjectKeysClient is a mock of ProjectKeysClient interface.
 type MockProjectKeysClient struct {
 	ctrl     *gomock.Controller
@@ -4597,18 +5144,18 @@ func (m *MockV2QueueClient) EXPECT() *MockV2QueueClientMockRecorder {
 }
 
 // V2QueueGetCacheLinks mocks base method.
-func (m *MockV2QueueClient) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string) (*sdk.CDNItemLinks, error) {
+func (m *MockV2QueueClient) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey)
+	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey, restoreKeys)
 	ret0, _ := ret[0].(*sdk.CDNItemLinks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // V2QueueGetCacheLinks indicates an expected call of V2QueueGetCacheLinks.
-func (mr *MockV2QueueClientMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey any) *gomock.Call {
+func (mr *MockV2QueueClientMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey, restoreKeys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey, restoreKeys)
 }
 
 // V2QueueGetJobRun mocks base method.
@@ -4626,6 +5173,35 @@ func (mr *MockV2QueueClientMockRecorder) V2QueueGetJobRun(ctx, regionName, id an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueGetTestQuarantines mocks base method.
+func (m *MockV2QueueClient) V2QueueGetTestQuarantines(ctx context.Context, regionName, id string) (sdk.V2WorkflowTestQuarantines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueGetTestQuarantines", ctx, regionName, id)
+	ret0, _ := ret[0].(sdk.V2WorkflowTestQuarantines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueGetTestQuarantines indicates an expected call of V2QueueGetTestQuarantines.
+func (mr *MockV2QueueClientMockRecorder) V2QueueGetTestQuarantines(ctx, regionName, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetTestQuarantines", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueGetTestQuarantines), ctx, regionName, id)
+}
+
+// V2QueueJobDebug mocks base method.
+func (m *MockV2QueueClient) V2QueueJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebug", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebug indicates an expected call of V2QueueJobDebug.
+func (mr *MockV2QueueClientMockRecorder) V2QueueJobDebug(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebug", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueJobDebug), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockV2QueueClient) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -4640,6 +5216,21 @@ func (mr *MockV2QueueClientMockRecorder) V2QueueJobResult(ctx, region, jobRunID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobResult", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueJobResult), ctx, region, jobRunID, result)
 }
 
+// V2QueueJobRunAttestationSign mocks base method.
+func (m *MockV2QueueClient) V2QueueJobRunAttestationSign(ctx context.Context, regionName, jobRunID string, req sdk.V2AttestationSignRequest) (*sdk.V2AttestationSignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobRunAttestationSign", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(*sdk.V2AttestationSignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobRunAttestationSign indicates an expected call of V2QueueJobRunAttestationSign.
+func (mr *MockV2QueueClientMockRecorder) V2QueueJobRunAttestationSign(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobRunAttestationSign", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueJobRunAttestationSign), ctx, regionName, jobRunID, req)
+}
+
 // V2QueueJobRunResultCreate mocks base method.
 func (m *MockV2QueueClient) V2QueueJobRunResultCreate(ctx context.Context, regionName, jobRunID string, result *sdk.V2WorkflowRunResult) error {
 	m.ctrl.T.Helper()
@@ -4759,6 +5350,20 @@ func (mr *MockV2QueueClientMockRecorder) V2QueuePushJobInfo(ctx, regionName, job
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobInfo", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueuePushJobInfo), ctx, regionName, jobRunID, msg)
 }
 
+// V2QueuePushJobSummary mocks base method.
+func (m *MockV2QueueClient) V2QueuePushJobSummary(ctx context.Context, regionName, jobRunID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobSummary", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobSummary indicates an expected call of V2QueuePushJobSummary.
+func (mr *MockV2QueueClientMockRecorder) V2QueuePushJobSummary(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobSummary", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueuePushJobSummary), ctx, regionName, jobRunID, req)
+}
+
 // V2QueuePushRunInfo mocks base method.
 func (m *MockV2QueueClient) V2QueuePushRunInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2WorkflowRunInfo) error {
 	m.ctrl.T.Helper()
@@ -5461,6 +6066,21 @@ func (mr *MockWorkerClientMockRecorder) CDNItemUpload(ctx, cdnAddr, signature, f
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNItemUpload", reflect.TypeOf((*MockWorkerClient)(nil).CDNItemUpload), ctx, cdnAddr, signature, fs, path)
 }
 
+// V2WorkerAgentList mocks base method.
+func (m *MockWorkerClient) V2WorkerAgentList(ctx context.Context) ([]sdk.V2WorkerAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2WorkerAgentList", ctx)
+	ret0, _ := ret[0].([]sdk.V2WorkerAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2WorkerAgentList indicates an expected call of V2WorkerAgentList.
+func (mr *MockWorkerClientMockRecorder) V2WorkerAgentList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2WorkerAgentList", reflect.TypeOf((*MockWorkerClient)(nil).V2WorkerAgentList), ctx)
+}
+
 // V2WorkerGet mocks base method.
 func (m *MockWorkerClient) V2WorkerGet(ctx context.Context, name string, mods ...cdsclient.RequestModifier) (*sdk.V2Worker, error) {
 	m.ctrl.T.Helper()
@@ -6075,6 +6695,41 @@ func (m *MockWorkflowV2Client) EXPECT() *MockWorkflowV2ClientMockRecorder {
 	return m.recorder
 }
 
+// WorkflowV2AttestationKeys mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2AttestationKeys(ctx context.Context, projectKey string) ([]sdk.V2AttestationPublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2AttestationKeys", ctx, projectKey)
+	ret0, _ := ret[0].([]sdk.V2AttestationPublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2AttestationKeys indicates an expected call of WorkflowV2AttestationKeys.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2AttestationKeys(ctx, projectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2AttestationKeys", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2AttestationKeys), ctx, projectKey)
+}
+
+// WorkflowV2AttestationList mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2AttestationList(ctx context.Context, projectKey, digest string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunAttestation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, digest}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2AttestationList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunAttestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2AttestationList indicates an expected call of WorkflowV2AttestationList.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2AttestationList(ctx, projectKey, digest any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, digest}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2AttestationList", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2AttestationList), varargs...)
+}
+
 // WorkflowV2JobStart mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2JobStart(ctx context.Context, projectKey, workflowRunID, jobIdentifier string, payload map[string]any, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -6116,9 +6771,9 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2JobsStart(ctx, projectKey,
 }
 
 // WorkflowV2Restart mocks base method.
-func (m *MockWorkflowV2Client) WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
+func (m *MockWorkflowV2Client) WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, payload sdk.V2WorkflowRunRestartRequest, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx, projectKey, workflowRunID}
+	varargs := []any{ctx, projectKey, workflowRunID, payload}
 	for _, a := range mods {
 		varargs = append(varargs, a)
 	}
@@ -6129,9 +6784,9 @@ func (m *MockWorkflowV2Client) WorkflowV2Restart(ctx context.Context, projectKey
 }
 
 // WorkflowV2Restart indicates an expected call of WorkflowV2Restart.
-func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2Restart(ctx, projectKey, workflowRunID any, mods ...any) *gomock.Call {
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2Restart(ctx, projectKey, workflowRunID, payload any, mods ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx, projectKey, workflowRunID}, mods...)
+	varargs := append([]any{ctx, projectKey, workflowRunID, payload}, mods...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2Restart", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2Restart), varargs...)
 }
 
@@ -6224,6 +6879,35 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunJob(ctx, projKey, workf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJob", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunJob), ctx, projKey, workflowRunID, jobRunID)
 }
 
+// WorkflowV2RunJobDebug mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobDebug", ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowV2RunJobDebug indicates an expected call of WorkflowV2RunJobDebug.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunJobDebug(ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobDebug", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunJobDebug), ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
+// WorkflowV2RunJobDebugSessions mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunJobDebugSessions(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobDebugSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobDebugSessions", ctx, projKey, workflowRunID, jobRunID)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunJobDebugSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunJobDebugSessions indicates an expected call of WorkflowV2RunJobDebugSessions.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunJobDebugSessions(ctx, projKey, workflowRunID, jobRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobDebugSessions", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunJobDebugSessions), ctx, projKey, workflowRunID, jobRunID)
+}
+
 // WorkflowV2RunJobInfoList mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2RunJobInfoList(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobInfo, error) {
 	m.ctrl.T.Helper()
@@ -6254,6 +6938,21 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunJobLogLinks(ctx, projKe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobLogLinks", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunJobLogLinks), ctx, projKey, workflowRunID, jobRunID)
 }
 
+// WorkflowV2RunJobReplay mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunJobReplay(ctx context.Context, projKey, workflowRunID, jobRunID string) (*sdk.V2WorkflowRunJobReplay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobReplay", ctx, projKey, workflowRunID, jobRunID)
+	ret0, _ := ret[0].(*sdk.V2WorkflowRunJobReplay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunJobReplay indicates an expected call of WorkflowV2RunJobReplay.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunJobReplay(ctx, projKey, workflowRunID, jobRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobReplay", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunJobReplay), ctx, projKey, workflowRunID, jobRunID)
+}
+
 // WorkflowV2RunJobRetries mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2RunJobRetries(ctx context.Context, projectKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJob, error) {
 	m.ctrl.T.Helper()
@@ -6339,6 +7038,26 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunSearchAllProjects(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunSearchAllProjects", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunSearchAllProjects), varargs...)
 }
 
+// WorkflowV2RunStats mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunStats(ctx context.Context, projectKey string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRunStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunStats", varargs...)
+	ret0, _ := ret[0].(*sdk.V2WorkflowRunStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunStats indicates an expected call of WorkflowV2RunStats.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunStats(ctx, projectKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunStats", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunStats), varargs...)
+}
+
 // WorkflowV2RunStatus mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2RunStatus(ctx context.Context, projectKey, workflowRunID string) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -6354,6 +7073,26 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunStatus(ctx, projectKey,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunStatus", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunStatus), ctx, projectKey, workflowRunID)
 }
 
+// WorkflowV2RunSummaryList mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunSummaryList(ctx context.Context, projectKey, workflowRunID string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunJobSummary, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, workflowRunID}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunSummaryList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunJobSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunSummaryList indicates an expected call of WorkflowV2RunSummaryList.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunSummaryList(ctx, projectKey, workflowRunID any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, workflowRunID}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunSummaryList", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunSummaryList), varargs...)
+}
+
 // WorkflowV2Stop mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error {
 	m.ctrl.T.Helper()
@@ -6376,10 +7115,99 @@ func (m *MockWorkflowV2Client) WorkflowV2StopJob(ctx context.Context, projKey, w
 	return ret0
 }
 
-// WorkflowV2StopJob indicates an expected call of WorkflowV2StopJob.
-func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2StopJob(ctx, projKey, workflowRunID, jobIdentifier any) *gomock.Call {
+// WorkflowV2StopJob indicates an expected call of WorkflowV2StopJob.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2StopJob(ctx, projKey, workflowRunID, jobIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2StopJob", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2StopJob), ctx, projKey, workflowRunID, jobIdentifier)
+}
+
+// WorkflowV2TestFlaky mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2TestFlaky(ctx context.Context, projectKey, workflow string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestHistory, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, workflow}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestFlaky", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestFlaky indicates an expected call of WorkflowV2TestFlaky.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2TestFlaky(ctx, projectKey, workflow any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, workflow}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestFlaky", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2TestFlaky), varargs...)
+}
+
+// WorkflowV2TestHistory mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2TestHistory(ctx context.Context, projectKey, workflow, suite, name string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowTestHistory, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, workflow, suite, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestHistory", varargs...)
+	ret0, _ := ret[0].(*sdk.V2WorkflowTestHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestHistory indicates an expected call of WorkflowV2TestHistory.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2TestHistory(ctx, projectKey, workflow, suite, name any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, workflow, suite, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestHistory", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2TestHistory), varargs...)
+}
+
+// WorkflowV2TestQuarantineAdd mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2TestQuarantineAdd(ctx context.Context, projectKey string, quarantine sdk.V2WorkflowTestQuarantine) (*sdk.V2WorkflowTestQuarantine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2TestQuarantineAdd", ctx, projectKey, quarantine)
+	ret0, _ := ret[0].(*sdk.V2WorkflowTestQuarantine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestQuarantineAdd indicates an expected call of WorkflowV2TestQuarantineAdd.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2TestQuarantineAdd(ctx, projectKey, quarantine any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestQuarantineAdd", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2TestQuarantineAdd), ctx, projectKey, quarantine)
+}
+
+// WorkflowV2TestQuarantineDelete mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2TestQuarantineDelete(ctx context.Context, projectKey, quarantineID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2TestQuarantineDelete", ctx, projectKey, quarantineID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowV2TestQuarantineDelete indicates an expected call of WorkflowV2TestQuarantineDelete.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2TestQuarantineDelete(ctx, projectKey, quarantineID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestQuarantineDelete", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2TestQuarantineDelete), ctx, projectKey, quarantineID)
+}
+
+// WorkflowV2TestQuarantineList mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2TestQuarantineList(ctx context.Context, projectKey string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestQuarantine, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestQuarantineList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestQuarantine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestQuarantineList indicates an expected call of WorkflowV2TestQuarantineList.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2TestQuarantineList(ctx, projectKey any, mods ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2StopJob", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2StopJob), ctx, projKey, workflowRunID, jobIdentifier)
+	varargs := append([]any{ctx, projectKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestQuarantineList", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2TestQuarantineList), varargs...)
 }
 
 // WorkflowV2VersionDelete mocks base method.
@@ -6624,6 +7452,22 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowLogDownload(ctx, link any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowLogDownload), ctx, link)
 }
 
+// WorkflowLogLines mocks base method.
+func (m *MockWorkflowClient) WorkflowLogLines(ctx context.Context, link sdk.CDNLogLink, offset int64, limit uint) ([]sdk.CDNLogLine, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowLogLines", ctx, link, offset, limit)
+	ret0, _ := ret[0].([]sdk.CDNLogLine)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WorkflowLogLines indicates an expected call of WorkflowLogLines.
+func (mr *MockWorkflowClientMockRecorder) WorkflowLogLines(ctx, link, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogLines", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowLogLines), ctx, link, offset, limit)
+}
+
 // WorkflowNodeRun mocks base method.
 func (m *MockWorkflowClient) WorkflowNodeRun(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	m.ctrl.T.Helper()
@@ -9440,6 +10284,45 @@ func (mr *MockInterfaceMockRecorder) ProjectAccess(ctx, projectKey, sessionID, i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectAccess", reflect.TypeOf((*MockInterface)(nil).ProjectAccess), ctx, projectKey, sessionID, itemType)
 }
 
+// ProjectCacheDelete mocks base method.
+func (m *MockInterface) ProjectCacheDelete(ctx context.Context, pKey, cacheKey string, mods ...cdsclient.RequestModifier) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey, cacheKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectCacheDelete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCacheDelete indicates an expected call of ProjectCacheDelete.
+func (mr *MockInterfaceMockRecorder) ProjectCacheDelete(ctx, pKey, cacheKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey, cacheKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCacheDelete", reflect.TypeOf((*MockInterface)(nil).ProjectCacheDelete), varargs...)
+}
+
+// ProjectCacheList mocks base method.
+func (m *MockInterface) ProjectCacheList(ctx context.Context, pKey string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkerCache, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectCacheList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkerCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCacheList indicates an expected call of ProjectCacheList.
+func (mr *MockInterfaceMockRecorder) ProjectCacheList(ctx, pKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCacheList", reflect.TypeOf((*MockInterface)(nil).ProjectCacheList), varargs...)
+}
+
 // ProjectConcurrencyCreate mocks base method.
 func (m *MockInterface) ProjectConcurrencyCreate(ctx context.Context, pKey string, c *sdk.ProjectConcurrency) error {
 	m.ctrl.T.Helper()
@@ -9555,6 +10438,200 @@ func (mr *MockInterfaceMockRecorder) ProjectDelete(projectKey any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDelete", reflect.TypeOf((*MockInterface)(nil).ProjectDelete), projectKey)
 }
 
+// ProjectEnvironmentCreate mocks base method.
+func (m *MockInterface) ProjectEnvironmentCreate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentCreate", ctx, pKey, env)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectEnvironmentCreate indicates an expected call of ProjectEnvironmentCreate.
+func (mr *MockInterfaceMockRecorder) ProjectEnvironmentCreate(ctx, pKey, env any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentCreate", reflect.TypeOf((*MockInterface)(nil).ProjectEnvironmentCreate), ctx, pKey, env)
+}
+
+// ProjectEnvironmentDelete mocks base method.
+func (m *MockInterface) ProjectEnvironmentDelete(ctx context.Context, pKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentDelete", ctx, pKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectEnvironmentDelete indicates an expected call of ProjectEnvironmentDelete.
+func (mr *MockInterfaceMockRecorder) ProjectEnvironmentDelete(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentDelete", reflect.TypeOf((*MockInterface)(nil).ProjectEnvironmentDelete), ctx, pKey, name)
+}
+
+// ProjectEnvironmentGet mocks base method.
+func (m *MockInterface) ProjectEnvironmentGet(ctx context.Context, pKey, name string) (*sdk.ProjectEnvironment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentGet", ctx, pKey, name)
+	ret0, _ := ret[0].(*sdk.ProjectEnvironment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectEnvironmentGet indicates an expected call of ProjectEnvironmentGet.
+func (mr *MockInterfaceMockRecorder) ProjectEnvironmentGet(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentGet", reflect.TypeOf((*MockInterface)(nil).ProjectEnvironmentGet), ctx, pKey, name)
+}
+
+// ProjectEnvironmentList mocks base method.
+func (m *MockInterface) ProjectEnvironmentList(ctx context.Context, pKey string) ([]sdk.ProjectEnvironment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentList", ctx, pKey)
+	ret0, _ := ret[0].([]sdk.ProjectEnvironment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectEnvironmentList indicates an expected call of ProjectEnvironmentList.
+func (mr *MockInterfaceMockRecorder) ProjectEnvironmentList(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentList", reflect.TypeOf((*MockInterface)(nil).ProjectEnvironmentList), ctx, pKey)
+}
+
+// ProjectEnvironmentListDeployments mocks base method.
+func (m *MockInterface) ProjectEnvironmentListDeployments(ctx context.Context, pKey, name string, mods ...cdsclient.RequestModifier) ([]sdk.ProjectEnvironmentDeployment, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectEnvironmentListDeployments", varargs...)
+	ret0, _ := ret[0].([]sdk.ProjectEnvironmentDeployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectEnvironmentListDeployments indicates an expected call of ProjectEnvironmentListDeployments.
+func (mr *MockInterfaceMockRecorder) ProjectEnvironmentListDeployments(ctx, pKey, name any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentListDeployments", reflect.TypeOf((*MockInterface)(nil).ProjectEnvironmentListDeployments), varargs...)
+}
+
+// ProjectEnvironmentUpdate mocks base method.
+func (m *MockInterface) ProjectEnvironmentUpdate(ctx context.Context, pKey string, env *sdk.ProjectEnvironment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectEnvironmentUpdate", ctx, pKey, env)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectEnvironmentUpdate indicates an expected call of ProjectEnvironmentUpdate.
+func (mr *MockInterfaceMockRecorder) ProjectEnvironmentUpdate(ctx, pKey, env any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectEnvironmentUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectEnvironmentUpdate), ctx, pKey, env)
+}
+
+// ProjectFreezeCreate mocks base method.
+func (m *MockInterface) ProjectFreezeCreate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeCreate", ctx, pKey, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeCreate indicates an expected call of ProjectFreezeCreate.
+func (mr *MockInterfaceMockRecorder) ProjectFreezeCreate(ctx, pKey, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeCreate", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeCreate), ctx, pKey, f)
+}
+
+// ProjectFreezeDelete mocks base method.
+func (m *MockInterface) ProjectFreezeDelete(ctx context.Context, pKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeDelete", ctx, pKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeDelete indicates an expected call of ProjectFreezeDelete.
+func (mr *MockInterfaceMockRecorder) ProjectFreezeDelete(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeDelete", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeDelete), ctx, pKey, name)
+}
+
+// ProjectFreezeGet mocks base method.
+func (m *MockInterface) ProjectFreezeGet(ctx context.Context, pKey, name string) (*sdk.ProjectFreeze, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeGet", ctx, pKey, name)
+	ret0, _ := ret[0].(*sdk.ProjectFreeze)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeGet indicates an expected call of ProjectFreezeGet.
+func (mr *MockInterfaceMockRecorder) ProjectFreezeGet(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeGet", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeGet), ctx, pKey, name)
+}
+
+// ProjectFreezeList mocks base method.
+func (m *MockInterface) ProjectFreezeList(ctx context.Context, pKey string) ([]sdk.ProjectFreeze, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeList", ctx, pKey)
+	ret0, _ := ret[0].([]sdk.ProjectFreeze)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeList indicates an expected call of ProjectFreezeList.
+func (mr *MockInterfaceMockRecorder) ProjectFreezeList(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeList", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeList), ctx, pKey)
+}
+
+// ProjectFreezeListOverrides mocks base method.
+func (m *MockInterface) ProjectFreezeListOverrides(ctx context.Context, pKey, name string) ([]sdk.ProjectFreezeOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeListOverrides", ctx, pKey, name)
+	ret0, _ := ret[0].([]sdk.ProjectFreezeOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeListOverrides indicates an expected call of ProjectFreezeListOverrides.
+func (mr *MockInterfaceMockRecorder) ProjectFreezeListOverrides(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeListOverrides", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeListOverrides), ctx, pKey, name)
+}
+
+// ProjectFreezeOverride mocks base method.
+func (m *MockInterface) ProjectFreezeOverride(ctx context.Context, pKey, name string, req sdk.ProjectFreezeOverrideRequest) (*sdk.ProjectFreezeOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeOverride", ctx, pKey, name, req)
+	ret0, _ := ret[0].(*sdk.ProjectFreezeOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeOverride indicates an expected call of ProjectFreezeOverride.
+func (mr *MockInterfaceMockRecorder) ProjectFreezeOverride(ctx, pKey, name, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeOverride", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeOverride), ctx, pKey, name, req)
+}
+
+// ProjectFreezeUpdate mocks base method.
+func (m *MockInterface) ProjectFreezeUpdate(ctx context.Context, pKey string, f *sdk.ProjectFreeze) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeUpdate", ctx, pKey, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeUpdate indicates an expected call of ProjectFreezeUpdate.
+func (mr *MockInterfaceMockRecorder) ProjectFreezeUpdate(ctx, pKey, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeUpdate), ctx, pKey, f)
+}
+
 // ProjectGet mocks base method.
 func (m *MockInterface) ProjectGet(projectKey string, opts ...cdsclient.RequestModifier) (*sdk.Project, error) {
 	m.ctrl.T.Helper()
@@ -9859,6 +10936,92 @@ func (mr *MockInterfaceMockRecorder) ProjectNotificationUpdate(ctx, pKey, notif
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectNotificationUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectNotificationUpdate), ctx, pKey, notif)
 }
 
+// ProjectQuotaDelete mocks base method.
+func (m *MockInterface) ProjectQuotaDelete(ctx context.Context, pKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectQuotaDelete", ctx, pKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectQuotaDelete indicates an expected call of ProjectQuotaDelete.
+func (mr *MockInterfaceMockRecorder) ProjectQuotaDelete(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaDelete", reflect.TypeOf((*MockInterface)(nil).ProjectQuotaDelete), ctx, pKey)
+}
+
+// ProjectQuotaGet mocks base method.
+func (m *MockInterface) ProjectQuotaGet(ctx context.Context, pKey string) (*sdk.ProjectQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectQuotaGet", ctx, pKey)
+	ret0, _ := ret[0].(*sdk.ProjectQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectQuotaGet indicates an expected call of ProjectQuotaGet.
+func (mr *MockInterfaceMockRecorder) ProjectQuotaGet(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaGet", reflect.TypeOf((*MockInterface)(nil).ProjectQuotaGet), ctx, pKey)
+}
+
+// ProjectQuotaUpdate mocks base method.
+func (m *MockInterface) ProjectQuotaUpdate(ctx context.Context, pKey string, quota *sdk.ProjectQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectQuotaUpdate", ctx, pKey, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectQuotaUpdate indicates an expected call of ProjectQuotaUpdate.
+func (mr *MockInterfaceMockRecorder) ProjectQuotaUpdate(ctx, pKey, quota any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectQuotaUpdate), ctx, pKey, quota)
+}
+
+// ProjectRBACAsCodeDelete mocks base method.
+func (m *MockInterface) ProjectRBACAsCodeDelete(ctx context.Context, pKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRBACAsCodeDelete", ctx, pKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRBACAsCodeDelete indicates an expected call of ProjectRBACAsCodeDelete.
+func (mr *MockInterfaceMockRecorder) ProjectRBACAsCodeDelete(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRBACAsCodeDelete", reflect.TypeOf((*MockInterface)(nil).ProjectRBACAsCodeDelete), ctx, pKey)
+}
+
+// ProjectRBACAsCodeGet mocks base method.
+func (m *MockInterface) ProjectRBACAsCodeGet(ctx context.Context, pKey string) (*sdk.ProjectRBACAsCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRBACAsCodeGet", ctx, pKey)
+	ret0, _ := ret[0].(*sdk.ProjectRBACAsCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRBACAsCodeGet indicates an expected call of ProjectRBACAsCodeGet.
+func (mr *MockInterfaceMockRecorder) ProjectRBACAsCodeGet(ctx, pKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRBACAsCodeGet", reflect.TypeOf((*MockInterface)(nil).ProjectRBACAsCodeGet), ctx, pKey)
+}
+
+// ProjectRBACAsCodeUpdate mocks base method.
+func (m *MockInterface) ProjectRBACAsCodeUpdate(ctx context.Context, pKey string, r *sdk.ProjectRBACAsCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRBACAsCodeUpdate", ctx, pKey, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRBACAsCodeUpdate indicates an expected call of ProjectRBACAsCodeUpdate.
+func (mr *MockInterfaceMockRecorder) ProjectRBACAsCodeUpdate(ctx, pKey, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRBACAsCodeUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectRBACAsCodeUpdate), ctx, pKey, r)
+}
+
 // ProjectRepositoryAnalysis mocks base method.
 func (m *MockInterface) ProjectRepositoryAnalysis(ctx context.Context, analysis sdk.AnalysisRequest) (sdk.AnalysisResponse, error) {
 	m.ctrl.T.Helper()
@@ -10005,6 +11168,41 @@ func (mr *MockInterfaceMockRecorder) ProjectUpdate(key, project any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectUpdate), key, project)
 }
 
+// ProjectUsageGet mocks base method.
+func (m *MockInterface) ProjectUsageGet(ctx context.Context, pKey, month string) ([]sdk.V2ProjectUsageDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectUsageGet", ctx, pKey, month)
+	ret0, _ := ret[0].([]sdk.V2ProjectUsageDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectUsageGet indicates an expected call of ProjectUsageGet.
+func (mr *MockInterfaceMockRecorder) ProjectUsageGet(ctx, pKey, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUsageGet", reflect.TypeOf((*MockInterface)(nil).ProjectUsageGet), ctx, pKey, month)
+}
+
+// ProjectUsageList mocks base method.
+func (m *MockInterface) ProjectUsageList(ctx context.Context, pKey string, mods ...cdsclient.RequestModifier) ([]sdk.V2ProjectUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectUsageList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2ProjectUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectUsageList indicates an expected call of ProjectUsageList.
+func (mr *MockInterfaceMockRecorder) ProjectUsageList(ctx, pKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUsageList", reflect.TypeOf((*MockInterface)(nil).ProjectUsageList), varargs...)
+}
+
 // ProjectV2Access mocks base method.
 func (m *MockInterface) ProjectV2Access(ctx context.Context, projectKey, sessionID string, itemType sdk.CDNItemType) error {
 	m.ctrl.T.Helper()
@@ -10759,25 +11957,100 @@ func (m *MockInterface) RBACDelete(ctx context.Context, permissionIdentifier str
 	return ret0
 }
 
-// RBACDelete indicates an expected call of RBACDelete.
-func (mr *MockInterfaceMockRecorder) RBACDelete(ctx, permissionIdentifier any) *gomock.Call {
+// RBACDelete indicates an expected call of RBACDelete.
+func (mr *MockInterfaceMockRecorder) RBACDelete(ctx, permissionIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACDelete", reflect.TypeOf((*MockInterface)(nil).RBACDelete), ctx, permissionIdentifier)
+}
+
+// RBACGet mocks base method.
+func (m *MockInterface) RBACGet(ctx context.Context, permissionIdentifier string) (sdk.RBAC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGet", ctx, permissionIdentifier)
+	ret0, _ := ret[0].(sdk.RBAC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGet indicates an expected call of RBACGet.
+func (mr *MockInterfaceMockRecorder) RBACGet(ctx, permissionIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGet", reflect.TypeOf((*MockInterface)(nil).RBACGet), ctx, permissionIdentifier)
+}
+
+// RBACGrantApprove mocks base method.
+func (m *MockInterface) RBACGrantApprove(ctx context.Context, grantID, comment string) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantApprove", ctx, grantID, comment)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantApprove indicates an expected call of RBACGrantApprove.
+func (mr *MockInterfaceMockRecorder) RBACGrantApprove(ctx, grantID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantApprove", reflect.TypeOf((*MockInterface)(nil).RBACGrantApprove), ctx, grantID, comment)
+}
+
+// RBACGrantGet mocks base method.
+func (m *MockInterface) RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantGet", ctx, grantID)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantGet indicates an expected call of RBACGrantGet.
+func (mr *MockInterfaceMockRecorder) RBACGrantGet(ctx, grantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantGet", reflect.TypeOf((*MockInterface)(nil).RBACGrantGet), ctx, grantID)
+}
+
+// RBACGrantList mocks base method.
+func (m *MockInterface) RBACGrantList(ctx context.Context, status string) ([]sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantList", ctx, status)
+	ret0, _ := ret[0].([]sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantList indicates an expected call of RBACGrantList.
+func (mr *MockInterfaceMockRecorder) RBACGrantList(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantList", reflect.TypeOf((*MockInterface)(nil).RBACGrantList), ctx, status)
+}
+
+// RBACGrantReject mocks base method.
+func (m *MockInterface) RBACGrantReject(ctx context.Context, grantID, comment string) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantReject", ctx, grantID, comment)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantReject indicates an expected call of RBACGrantReject.
+func (mr *MockInterfaceMockRecorder) RBACGrantReject(ctx, grantID, comment any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACDelete", reflect.TypeOf((*MockInterface)(nil).RBACDelete), ctx, permissionIdentifier)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantReject", reflect.TypeOf((*MockInterface)(nil).RBACGrantReject), ctx, grantID, comment)
 }
 
-// RBACGet mocks base method.
-func (m *MockInterface) RBACGet(ctx context.Context, permissionIdentifier string) (sdk.RBAC, error) {
+// RBACGrantRevoke mocks base method.
+func (m *MockInterface) RBACGrantRevoke(ctx context.Context, grantID, comment string) (sdk.RBACGrantRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RBACGet", ctx, permissionIdentifier)
-	ret0, _ := ret[0].(sdk.RBAC)
+	ret := m.ctrl.Call(m, "RBACGrantRevoke", ctx, grantID, comment)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RBACGet indicates an expected call of RBACGet.
-func (mr *MockInterfaceMockRecorder) RBACGet(ctx, permissionIdentifier any) *gomock.Call {
+// RBACGrantRevoke indicates an expected call of RBACGrantRevoke.
+func (mr *MockInterfaceMockRecorder) RBACGrantRevoke(ctx, grantID, comment any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGet", reflect.TypeOf((*MockInterface)(nil).RBACGet), ctx, permissionIdentifier)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantRevoke", reflect.TypeOf((*MockInterface)(nil).RBACGrantRevoke), ctx, grantID, comment)
 }
 
 // RBACGroupPermission mocks base method.
@@ -10830,6 +12103,36 @@ func (mr *MockInterfaceMockRecorder) RBACList(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACList", reflect.TypeOf((*MockInterface)(nil).RBACList), ctx)
 }
 
+// RBACUserGrantList mocks base method.
+func (m *MockInterface) RBACUserGrantList(ctx context.Context, username, status string) ([]sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserGrantList", ctx, username, status)
+	ret0, _ := ret[0].([]sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserGrantList indicates an expected call of RBACUserGrantList.
+func (mr *MockInterfaceMockRecorder) RBACUserGrantList(ctx, username, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserGrantList", reflect.TypeOf((*MockInterface)(nil).RBACUserGrantList), ctx, username, status)
+}
+
+// RBACUserGrantRequest mocks base method.
+func (m *MockInterface) RBACUserGrantRequest(ctx context.Context, g sdk.RBACGrantRequest) (sdk.RBACGrantRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserGrantRequest", ctx, g)
+	ret0, _ := ret[0].(sdk.RBACGrantRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserGrantRequest indicates an expected call of RBACUserGrantRequest.
+func (mr *MockInterfaceMockRecorder) RBACUserGrantRequest(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserGrantRequest", reflect.TypeOf((*MockInterface)(nil).RBACUserGrantRequest), ctx, g)
+}
+
 // RBACUserPermission mocks base method.
 func (m *MockInterface) RBACUserPermission(ctx context.Context, username string) (sdk.PermissionSummary, error) {
 	m.ctrl.T.Helper()
@@ -10845,6 +12148,36 @@ func (mr *MockInterfaceMockRecorder) RBACUserPermission(ctx, username any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermission", reflect.TypeOf((*MockInterface)(nil).RBACUserPermission), ctx, username)
 }
 
+// RBACUserPermissionExplain mocks base method.
+func (m *MockInterface) RBACUserPermissionExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserPermissionExplain", ctx, req)
+	ret0, _ := ret[0].(sdk.RBACExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserPermissionExplain indicates an expected call of RBACUserPermissionExplain.
+func (mr *MockInterfaceMockRecorder) RBACUserPermissionExplain(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermissionExplain", reflect.TypeOf((*MockInterface)(nil).RBACUserPermissionExplain), ctx, req)
+}
+
+// RBACUserPermissionRules mocks base method.
+func (m *MockInterface) RBACUserPermissionRules(ctx context.Context, username string) ([]sdk.RBACRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACUserPermissionRules", ctx, username)
+	ret0, _ := ret[0].([]sdk.RBACRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACUserPermissionRules indicates an expected call of RBACUserPermissionRules.
+func (mr *MockInterfaceMockRecorder) RBACUserPermissionRules(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermissionRules", reflect.TypeOf((*MockInterface)(nil).RBACUserPermissionRules), ctx, username)
+}
+
 // RegionAdd mocks base method.
 func (m *MockInterface) RegionAdd(ctx context.Context, region sdk.Region) error {
 	m.ctrl.T.Helper()
@@ -11612,18 +12945,18 @@ func (mr *MockInterfaceMockRecorder) V2ConfigCDN() *gomock.Call {
 }
 
 // V2QueueGetCacheLinks mocks base method.
-func (m *MockInterface) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string) (*sdk.CDNItemLinks, error) {
+func (m *MockInterface) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey)
+	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey, restoreKeys)
 	ret0, _ := ret[0].(*sdk.CDNItemLinks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // V2QueueGetCacheLinks indicates an expected call of V2QueueGetCacheLinks.
-func (mr *MockInterfaceMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey any) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey, restoreKeys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockInterface)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockInterface)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey, restoreKeys)
 }
 
 // V2QueueGetJobRun mocks base method.
@@ -11641,6 +12974,35 @@ func (mr *MockInterfaceMockRecorder) V2QueueGetJobRun(ctx, regionName, id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockInterface)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueGetTestQuarantines mocks base method.
+func (m *MockInterface) V2QueueGetTestQuarantines(ctx context.Context, regionName, id string) (sdk.V2WorkflowTestQuarantines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueGetTestQuarantines", ctx, regionName, id)
+	ret0, _ := ret[0].(sdk.V2WorkflowTestQuarantines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueGetTestQuarantines indicates an expected call of V2QueueGetTestQuarantines.
+func (mr *MockInterfaceMockRecorder) V2QueueGetTestQuarantines(ctx, regionName, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetTestQuarantines", reflect.TypeOf((*MockInterface)(nil).V2QueueGetTestQuarantines), ctx, regionName, id)
+}
+
+// V2QueueJobDebug mocks base method.
+func (m *MockInterface) V2QueueJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebug", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebug indicates an expected call of V2QueueJobDebug.
+func (mr *MockInterfaceMockRecorder) V2QueueJobDebug(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebug", reflect.TypeOf((*MockInterface)(nil).V2QueueJobDebug), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockInterface) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -11655,6 +13017,21 @@ func (mr *MockInterfaceMockRecorder) V2QueueJobResult(ctx, region, jobRunID, res
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobResult", reflect.TypeOf((*MockInterface)(nil).V2QueueJobResult), ctx, region, jobRunID, result)
 }
 
+// V2QueueJobRunAttestationSign mocks base method.
+func (m *MockInterface) V2QueueJobRunAttestationSign(ctx context.Context, regionName, jobRunID string, req sdk.V2AttestationSignRequest) (*sdk.V2AttestationSignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobRunAttestationSign", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(*sdk.V2AttestationSignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobRunAttestationSign indicates an expected call of V2QueueJobRunAttestationSign.
+func (mr *MockInterfaceMockRecorder) V2QueueJobRunAttestationSign(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobRunAttestationSign", reflect.TypeOf((*MockInterface)(nil).V2QueueJobRunAttestationSign), ctx, regionName, jobRunID, req)
+}
+
 // V2QueueJobRunResultCreate mocks base method.
 func (m *MockInterface) V2QueueJobRunResultCreate(ctx context.Context, regionName, jobRunID string, result *sdk.V2WorkflowRunResult) error {
 	m.ctrl.T.Helper()
@@ -11774,6 +13151,20 @@ func (mr *MockInterfaceMockRecorder) V2QueuePushJobInfo(ctx, regionName, jobRunI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobInfo", reflect.TypeOf((*MockInterface)(nil).V2QueuePushJobInfo), ctx, regionName, jobRunID, msg)
 }
 
+// V2QueuePushJobSummary mocks base method.
+func (m *MockInterface) V2QueuePushJobSummary(ctx context.Context, regionName, jobRunID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobSummary", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobSummary indicates an expected call of V2QueuePushJobSummary.
+func (mr *MockInterfaceMockRecorder) V2QueuePushJobSummary(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobSummary", reflect.TypeOf((*MockInterface)(nil).V2QueuePushJobSummary), ctx, regionName, jobRunID, req)
+}
+
 // V2QueuePushRunInfo mocks base method.
 func (m *MockInterface) V2QueuePushRunInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2WorkflowRunInfo) error {
 	m.ctrl.T.Helper()
@@ -11803,6 +13194,21 @@ func (mr *MockInterfaceMockRecorder) V2QueueWorkerTakeJob(ctx, region, runJobID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueWorkerTakeJob", reflect.TypeOf((*MockInterface)(nil).V2QueueWorkerTakeJob), ctx, region, runJobID)
 }
 
+// V2WorkerAgentList mocks base method.
+func (m *MockInterface) V2WorkerAgentList(ctx context.Context) ([]sdk.V2WorkerAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2WorkerAgentList", ctx)
+	ret0, _ := ret[0].([]sdk.V2WorkerAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2WorkerAgentList indicates an expected call of V2WorkerAgentList.
+func (mr *MockInterfaceMockRecorder) V2WorkerAgentList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2WorkerAgentList", reflect.TypeOf((*MockInterface)(nil).V2WorkerAgentList), ctx)
+}
+
 // V2WorkerGet mocks base method.
 func (m *MockInterface) V2WorkerGet(ctx context.Context, name string, mods ...cdsclient.RequestModifier) (*sdk.V2Worker, error) {
 	m.ctrl.T.Helper()
@@ -12492,6 +13898,22 @@ func (mr *MockInterfaceMockRecorder) WorkflowLogDownload(ctx, link any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowLogDownload), ctx, link)
 }
 
+// WorkflowLogLines mocks base method.
+func (m *MockInterface) WorkflowLogLines(ctx context.Context, link sdk.CDNLogLink, offset int64, limit uint) ([]sdk.CDNLogLine, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowLogLines", ctx, link, offset, limit)
+	ret0, _ := ret[0].([]sdk.CDNLogLine)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WorkflowLogLines indicates an expected call of WorkflowLogLines.
+func (mr *MockInterfaceMockRecorder) WorkflowLogLines(ctx, link, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogLines", reflect.TypeOf((*MockInterface)(nil).WorkflowLogLines), ctx, link, offset, limit)
+}
+
 // WorkflowNodeRun mocks base method.
 func (m *MockInterface) WorkflowNodeRun(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	m.ctrl.T.Helper()
@@ -12867,6 +14289,41 @@ func (mr *MockInterfaceMockRecorder) WorkflowUpdate(projectKey, name, wf any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowUpdate", reflect.TypeOf((*MockInterface)(nil).WorkflowUpdate), projectKey, name, wf)
 }
 
+// WorkflowV2AttestationKeys mocks base method.
+func (m *MockInterface) WorkflowV2AttestationKeys(ctx context.Context, projectKey string) ([]sdk.V2AttestationPublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2AttestationKeys", ctx, projectKey)
+	ret0, _ := ret[0].([]sdk.V2AttestationPublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2AttestationKeys indicates an expected call of WorkflowV2AttestationKeys.
+func (mr *MockInterfaceMockRecorder) WorkflowV2AttestationKeys(ctx, projectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2AttestationKeys", reflect.TypeOf((*MockInterface)(nil).WorkflowV2AttestationKeys), ctx, projectKey)
+}
+
+// WorkflowV2AttestationList mocks base method.
+func (m *MockInterface) WorkflowV2AttestationList(ctx context.Context, projectKey, digest string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunAttestation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, digest}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2AttestationList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunAttestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2AttestationList indicates an expected call of WorkflowV2AttestationList.
+func (mr *MockInterfaceMockRecorder) WorkflowV2AttestationList(ctx, projectKey, digest any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, digest}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2AttestationList", reflect.TypeOf((*MockInterface)(nil).WorkflowV2AttestationList), varargs...)
+}
+
 // WorkflowV2JobStart mocks base method.
 func (m *MockInterface) WorkflowV2JobStart(ctx context.Context, projectKey, workflowRunID, jobIdentifier string, payload map[string]any, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -12908,9 +14365,9 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2JobsStart(ctx, projectKey, workfl
 }
 
 // WorkflowV2Restart mocks base method.
-func (m *MockInterface) WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
+func (m *MockInterface) WorkflowV2Restart(ctx context.Context, projectKey, workflowRunID string, payload sdk.V2WorkflowRunRestartRequest, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx, projectKey, workflowRunID}
+	varargs := []any{ctx, projectKey, workflowRunID, payload}
 	for _, a := range mods {
 		varargs = append(varargs, a)
 	}
@@ -12921,9 +14378,9 @@ func (m *MockInterface) WorkflowV2Restart(ctx context.Context, projectKey, workf
 }
 
 // WorkflowV2Restart indicates an expected call of WorkflowV2Restart.
-func (mr *MockInterfaceMockRecorder) WorkflowV2Restart(ctx, projectKey, workflowRunID any, mods ...any) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) WorkflowV2Restart(ctx, projectKey, workflowRunID, payload any, mods ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx, projectKey, workflowRunID}, mods...)
+	varargs := append([]any{ctx, projectKey, workflowRunID, payload}, mods...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2Restart", reflect.TypeOf((*MockInterface)(nil).WorkflowV2Restart), varargs...)
 }
 
@@ -13016,6 +14473,35 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2RunJob(ctx, projKey, workflowRunI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJob", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunJob), ctx, projKey, workflowRunID, jobRunID)
 }
 
+// WorkflowV2RunJobDebug mocks base method.
+func (m *MockInterface) WorkflowV2RunJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobDebug", ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowV2RunJobDebug indicates an expected call of WorkflowV2RunJobDebug.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunJobDebug(ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobDebug", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunJobDebug), ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
+// WorkflowV2RunJobDebugSessions mocks base method.
+func (m *MockInterface) WorkflowV2RunJobDebugSessions(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobDebugSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobDebugSessions", ctx, projKey, workflowRunID, jobRunID)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunJobDebugSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunJobDebugSessions indicates an expected call of WorkflowV2RunJobDebugSessions.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunJobDebugSessions(ctx, projKey, workflowRunID, jobRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobDebugSessions", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunJobDebugSessions), ctx, projKey, workflowRunID, jobRunID)
+}
+
 // WorkflowV2RunJobInfoList mocks base method.
 func (m *MockInterface) WorkflowV2RunJobInfoList(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobInfo, error) {
 	m.ctrl.T.Helper()
@@ -13046,6 +14532,21 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2RunJobLogLinks(ctx, projKey, work
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobLogLinks", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunJobLogLinks), ctx, projKey, workflowRunID, jobRunID)
 }
 
+// WorkflowV2RunJobReplay mocks base method.
+func (m *MockInterface) WorkflowV2RunJobReplay(ctx context.Context, projKey, workflowRunID, jobRunID string) (*sdk.V2WorkflowRunJobReplay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobReplay", ctx, projKey, workflowRunID, jobRunID)
+	ret0, _ := ret[0].(*sdk.V2WorkflowRunJobReplay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunJobReplay indicates an expected call of WorkflowV2RunJobReplay.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunJobReplay(ctx, projKey, workflowRunID, jobRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobReplay", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunJobReplay), ctx, projKey, workflowRunID, jobRunID)
+}
+
 // WorkflowV2RunJobRetries mocks base method.
 func (m *MockInterface) WorkflowV2RunJobRetries(ctx context.Context, projectKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJob, error) {
 	m.ctrl.T.Helper()
@@ -13131,6 +14632,26 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2RunSearchAllProjects(ctx, offset,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunSearchAllProjects", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunSearchAllProjects), varargs...)
 }
 
+// WorkflowV2RunStats mocks base method.
+func (m *MockInterface) WorkflowV2RunStats(ctx context.Context, projectKey string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRunStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunStats", varargs...)
+	ret0, _ := ret[0].(*sdk.V2WorkflowRunStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunStats indicates an expected call of WorkflowV2RunStats.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunStats(ctx, projectKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunStats", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunStats), varargs...)
+}
+
 // WorkflowV2RunStatus mocks base method.
 func (m *MockInterface) WorkflowV2RunStatus(ctx context.Context, projectKey, workflowRunID string) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -13146,6 +14667,26 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2RunStatus(ctx, projectKey, workfl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunStatus", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunStatus), ctx, projectKey, workflowRunID)
 }
 
+// WorkflowV2RunSummaryList mocks base method.
+func (m *MockInterface) WorkflowV2RunSummaryList(ctx context.Context, projectKey, workflowRunID string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunJobSummary, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, workflowRunID}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunSummaryList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunJobSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunSummaryList indicates an expected call of WorkflowV2RunSummaryList.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunSummaryList(ctx, projectKey, workflowRunID any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, workflowRunID}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunSummaryList", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunSummaryList), varargs...)
+}
+
 // WorkflowV2Stop mocks base method.
 func (m *MockInterface) WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error {
 	m.ctrl.T.Helper()
@@ -13174,6 +14715,95 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2StopJob(ctx, projKey, workflowRun
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2StopJob", reflect.TypeOf((*MockInterface)(nil).WorkflowV2StopJob), ctx, projKey, workflowRunID, jobIdentifier)
 }
 
+// WorkflowV2TestFlaky mocks base method.
+func (m *MockInterface) WorkflowV2TestFlaky(ctx context.Context, projectKey, workflow string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestHistory, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, workflow}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestFlaky", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestFlaky indicates an expected call of WorkflowV2TestFlaky.
+func (mr *MockInterfaceMockRecorder) WorkflowV2TestFlaky(ctx, projectKey, workflow any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, workflow}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestFlaky", reflect.TypeOf((*MockInterface)(nil).WorkflowV2TestFlaky), varargs...)
+}
+
+// WorkflowV2TestHistory mocks base method.
+func (m *MockInterface) WorkflowV2TestHistory(ctx context.Context, projectKey, workflow, suite, name string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowTestHistory, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey, workflow, suite, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestHistory", varargs...)
+	ret0, _ := ret[0].(*sdk.V2WorkflowTestHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestHistory indicates an expected call of WorkflowV2TestHistory.
+func (mr *MockInterfaceMockRecorder) WorkflowV2TestHistory(ctx, projectKey, workflow, suite, name any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey, workflow, suite, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestHistory", reflect.TypeOf((*MockInterface)(nil).WorkflowV2TestHistory), varargs...)
+}
+
+// WorkflowV2TestQuarantineAdd mocks base method.
+func (m *MockInterface) WorkflowV2TestQuarantineAdd(ctx context.Context, projectKey string, quarantine sdk.V2WorkflowTestQuarantine) (*sdk.V2WorkflowTestQuarantine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2TestQuarantineAdd", ctx, projectKey, quarantine)
+	ret0, _ := ret[0].(*sdk.V2WorkflowTestQuarantine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestQuarantineAdd indicates an expected call of WorkflowV2TestQuarantineAdd.
+func (mr *MockInterfaceMockRecorder) WorkflowV2TestQuarantineAdd(ctx, projectKey, quarantine any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestQuarantineAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowV2TestQuarantineAdd), ctx, projectKey, quarantine)
+}
+
+// WorkflowV2TestQuarantineDelete mocks base method.
+func (m *MockInterface) WorkflowV2TestQuarantineDelete(ctx context.Context, projectKey, quarantineID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2TestQuarantineDelete", ctx, projectKey, quarantineID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowV2TestQuarantineDelete indicates an expected call of WorkflowV2TestQuarantineDelete.
+func (mr *MockInterfaceMockRecorder) WorkflowV2TestQuarantineDelete(ctx, projectKey, quarantineID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestQuarantineDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowV2TestQuarantineDelete), ctx, projectKey, quarantineID)
+}
+
+// WorkflowV2TestQuarantineList mocks base method.
+func (m *MockInterface) WorkflowV2TestQuarantineList(ctx context.Context, projectKey string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestQuarantine, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projectKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestQuarantineList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestQuarantine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestQuarantineList indicates an expected call of WorkflowV2TestQuarantineList.
+func (mr *MockInterfaceMockRecorder) WorkflowV2TestQuarantineList(ctx, projectKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projectKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestQuarantineList", reflect.TypeOf((*MockInterface)(nil).WorkflowV2TestQuarantineList), varargs...)
+}
+
 // WorkflowV2VersionDelete mocks base method.
 func (m *MockInterface) WorkflowV2VersionDelete(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, version string) error {
 	m.ctrl.T.Helper()
@@ -13387,18 +15017,18 @@ func (mr *MockV2WorkerInterfaceMockRecorder) ProjectV2IntegrationWorkerHookGet(p
 }
 
 // V2QueueGetCacheLinks mocks base method.
-func (m *MockV2WorkerInterface) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string) (*sdk.CDNItemLinks, error) {
+func (m *MockV2WorkerInterface) V2QueueGetCacheLinks(ctx context.Context, regionName, id, cacheKey string, restoreKeys []string) (*sdk.CDNItemLinks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey)
+	ret := m.ctrl.Call(m, "V2QueueGetCacheLinks", ctx, regionName, id, cacheKey, restoreKeys)
 	ret0, _ := ret[0].(*sdk.CDNItemLinks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // V2QueueGetCacheLinks indicates an expected call of V2QueueGetCacheLinks.
-func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey any) *gomock.Call {
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueGetCacheLinks(ctx, regionName, id, cacheKey, restoreKeys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetCacheLinks", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueGetCacheLinks), ctx, regionName, id, cacheKey, restoreKeys)
 }
 
 // V2QueueGetJobRun mocks base method.
@@ -13416,6 +15046,35 @@ func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueGetJobRun(ctx, regionName, i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueGetTestQuarantines mocks base method.
+func (m *MockV2WorkerInterface) V2QueueGetTestQuarantines(ctx context.Context, regionName, id string) (sdk.V2WorkflowTestQuarantines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueGetTestQuarantines", ctx, regionName, id)
+	ret0, _ := ret[0].(sdk.V2WorkflowTestQuarantines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueGetTestQuarantines indicates an expected call of V2QueueGetTestQuarantines.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueGetTestQuarantines(ctx, regionName, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetTestQuarantines", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueGetTestQuarantines), ctx, regionName, id)
+}
+
+// V2QueueJobDebug mocks base method.
+func (m *MockV2WorkerInterface) V2QueueJobDebug(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebug", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebug indicates an expected call of V2QueueJobDebug.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueJobDebug(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebug", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueJobDebug), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockV2WorkerInterface) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -13430,6 +15089,21 @@ func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueJobResult(ctx, region, jobRu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobResult", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueJobResult), ctx, region, jobRunID, result)
 }
 
+// V2QueueJobRunAttestationSign mocks base method.
+func (m *MockV2WorkerInterface) V2QueueJobRunAttestationSign(ctx context.Context, regionName, jobRunID string, req sdk.V2AttestationSignRequest) (*sdk.V2AttestationSignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobRunAttestationSign", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(*sdk.V2AttestationSignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobRunAttestationSign indicates an expected call of V2QueueJobRunAttestationSign.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueJobRunAttestationSign(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobRunAttestationSign", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueJobRunAttestationSign), ctx, regionName, jobRunID, req)
+}
+
 // V2QueueJobRunResultCreate mocks base method.
 func (m *MockV2WorkerInterface) V2QueueJobRunResultCreate(ctx context.Context, regionName, jobRunID string, result *sdk.V2WorkflowRunResult) error {
 	m.ctrl.T.Helper()
@@ -13549,6 +15223,20 @@ func (mr *MockV2WorkerInterfaceMockRecorder) V2QueuePushJobInfo(ctx, regionName,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobInfo", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueuePushJobInfo), ctx, regionName, jobRunID, msg)
 }
 
+// V2QueuePushJobSummary mocks base method.
+func (m *MockV2WorkerInterface) V2QueuePushJobSummary(ctx context.Context, regionName, jobRunID string, req sdk.V2WorkflowRunJobSummaryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobSummary", ctx, regionName, jobRunID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobSummary indicates an expected call of V2QueuePushJobSummary.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueuePushJobSummary(ctx, regionName, jobRunID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobSummary", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueuePushJobSummary), ctx, regionName, jobRunID, req)
+}
+
 // V2QueuePushRunInfo mocks base method.
 func (m *MockV2WorkerInterface) V2QueuePushRunInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2WorkflowRunInfo) error {
 	m.ctrl.T.Helper()
@@ -14161,6 +15849,21 @@ func (mr *MockWorkerInterfaceMockRecorder) ServiceConfigurationGet(arg0, arg1 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceConfigurationGet", reflect.TypeOf((*MockWorkerInterface)(nil).ServiceConfigurationGet), arg0, arg1)
 }
 
+// V2WorkerAgentList mocks base method.
+func (m *MockWorkerInterface) V2WorkerAgentList(ctx context.Context) ([]sdk.V2WorkerAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2WorkerAgentList", ctx)
+	ret0, _ := ret[0].([]sdk.V2WorkerAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2WorkerAgentList indicates an expected call of V2WorkerAgentList.
+func (mr *MockWorkerInterfaceMockRecorder) V2WorkerAgentList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2WorkerAgentList", reflect.TypeOf((*MockWorkerInterface)(nil).V2WorkerAgentList), ctx)
+}
+
 // V2WorkerGet mocks base method.
 func (m *MockWorkerInterface) V2WorkerGet(ctx context.Context, name string, mods ...cdsclient.RequestModifier) (*sdk.V2Worker, error) {
 	m.ctrl.T.Helper()
@@ -106,11 +106,13 @@ type GitContext struct {
 	CommitMessage          string   `json:"commit_message,omitempty" jsonschema:"example=feat: add new feature" jsonschema_description:"Commit message"`
 	Author                 string   `json:"author,omitempty" jsonschema:"example=John Doe" jsonschema_description:"Commit author name"`
 	AuthorEmail            string   `json:"author_email,omitempty" jsonschema:"example=john.doe@example.com" jsonschema_description:"Commit author email"`
+	CommitDate             string   `json:"commit_date,omitempty" jsonschema:"example=2024-03-04T10:00:00Z" jsonschema_description:"Commit date (RFC3339)"`
 	Ref                    string   `json:"ref,omitempty" jsonschema:"example=refs/heads/main" jsonschema_description:"Full git reference"`
 	RefName                string   `json:"ref_name,omitempty" jsonschema:"example=main" jsonschema_description:"Short reference name"`
 	Sha                    string   `json:"sha,omitempty" jsonschema:"example=a1b2c3d4e5f6g7h8i9j0k1l2m3n4o5p6q7r8s9t0" jsonschema_description:"Full commit SHA"`
 	ShaShort               string   `json:"sha_short,omitempty" jsonschema:"example=a1b2c3d" jsonschema_description:"Short commit SHA (7 characters)"`
 	RefType                string   `json:"ref_type,omitempty" jsonschema:"example=branch" jsonschema_description:"Type of reference (branch, tag)"`
+	DefaultBranch          string   `json:"default_branch,omitempty" jsonschema:"example=refs/heads/main" jsonschema_description:"Full git reference of the default branch of the repository"`
 	Connection             string   `json:"connection,omitempty" jsonschema:"example=my-github-connection" jsonschema_description:"VCS connection name"`
 	SSHKey                 string   `json:"ssh_key,omitempty" jsonschema_description:"SSH private key for git operations"`
 	Username               string   `json:"username,omitempty" jsonschema:"example=git-user" jsonschema_description:"Git username for authentication"`
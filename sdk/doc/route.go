@@ -153,6 +153,16 @@ func CleanURLParameter(u string) string {
 		u = "cache-key"
 	case "hookID":
 		u = "hook-id"
+	case "quarantineID":
+		u = "quarantine-id"
+	case "freezeName":
+		u = "freeze-name"
+	case "grantID":
+		u = "grant-id"
+	case "scimUserID":
+		u = "scim-user-id"
+	case "scimGroupID":
+		u = "scim-group-id"
 	case "keyID":
 		u = "key-id"
 	case "concurrencyName":
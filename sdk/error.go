@@ -204,6 +204,7 @@ var (
 	ErrRegionNotAllowed                              = Error{ID: 196, Status: http.StatusInternalServerError}
 	ErrConditionNotSatisfied                         = Error{ID: 197, Status: http.StatusForbidden}
 	ErrUserDisabled                                  = Error{ID: 198, Status: http.StatusForbidden}
+	ErrQuotaExceeded                                 = Error{ID: 199, Status: http.StatusTooManyRequests}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -390,6 +391,7 @@ var errorsAmericanEnglish = map[int]string{
 	ErrRegionNotAllowed.ID:                              "Region not allowed",
 	ErrConditionNotSatisfied.ID:                         "Conditions are not satisfied",
 	ErrUserDisabled.ID:                                  "User is disabled",
+	ErrQuotaExceeded.ID:                                 "Quota exceeded",
 }
 
 // Error type.
@@ -25,6 +25,9 @@ const (
 	// EventRunJobStepUpdated is sent when a worker reports the progress of the steps of a job. Only
 	// the steps moved, the job status did not change.
 	EventRunJobStepUpdated EventType = "RunJobStepUpdated"
+	// EventRunJobDebugStarted and EventRunJobDebugEnded audit the debug sessions opened on a job.
+	EventRunJobDebugStarted EventType = "RunJobDebugStarted"
+	EventRunJobDebugEnded   EventType = "RunJobDebugEnded"
 
 	EventRunCrafted  EventType = "RunCrafted"
 	EventRunBuilding EventType = "RunBuilding"
@@ -60,6 +63,13 @@ const (
 	EventPermissionCreated EventType = "PermissionCreated"
 	EventPermissionUpdated EventType = "PermissionUpdated"
 	EventPermissionDeleted EventType = "PermissionDeleted"
+	EventPermissionExpired EventType = "PermissionExpired"
+
+	EventPermissionGrantRequested EventType = "PermissionGrantRequested"
+	EventPermissionGrantApproved  EventType = "PermissionGrantApproved"
+	EventPermissionGrantRejected  EventType = "PermissionGrantRejected"
+	EventPermissionGrantRevoked   EventType = "PermissionGrantRevoked"
+	EventPermissionGrantExpired   EventType = "PermissionGrantExpired"
 
 	EventUserCreated       EventType = "UserCreated"
 	EventUserUpdated       EventType = "UserUpdated"
@@ -98,6 +108,15 @@ const (
 	EventConcurrencyCreated EventType = "ConcurrencyCreated"
 	EventConcurrencyUpdated EventType = "ConcurrencyUpdated"
 	EventConcurrencyDeleted EventType = "ConcurrencyDeleted"
+
+	EventEnvironmentCreated EventType = "EnvironmentCreated"
+	EventEnvironmentUpdated EventType = "EnvironmentUpdated"
+	EventEnvironmentDeleted EventType = "EnvironmentDeleted"
+
+	EventFreezeCreated    EventType = "FreezeCreated"
+	EventFreezeUpdated    EventType = "FreezeUpdated"
+	EventFreezeDeleted    EventType = "FreezeDeleted"
+	EventFreezeOverridden EventType = "FreezeOverridden"
 )
 
 // FullEventV2 uses to process event
@@ -139,6 +158,8 @@ type FullEventV2 struct {
 	VariableSet      string          `json:"variable_set,omitempty"`
 	Item             string          `json:"item,omitempty"`
 	Concurrency      string          `json:"concurrency"`
+	Environment      string          `json:"environment,omitempty"`
+	Freeze           string          `json:"freeze,omitempty"`
 	Timestamp        time.Time       `json:"timestamp"`
 }
 
@@ -225,6 +246,22 @@ type ConcurrencyEvent struct {
 	Username    string `json:"username"`
 }
 
+type EnvironmentEvent struct {
+	GlobalEventV2
+	ProjectEventV2
+	Environment string `json:"environment"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+}
+
+type FreezeEvent struct {
+	GlobalEventV2
+	ProjectEventV2
+	Freeze   string `json:"freeze"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
 type VCSEvent struct {
 	GlobalEventV2
 	ProjectEventV2
@@ -159,6 +159,8 @@ type GroupMember struct {
 	Organization string `json:"organization,omitempty" yaml:"organization,omitempty" cli:"organization"`
 	// Disabled is read only, it is set from the member's user and ignored on write
 	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty" cli:"disabled"`
+	// Source is read only, it is the auth driver that synchronized the membership, empty for a manual one
+	Source AuthConsumerType `json:"source,omitempty" yaml:"source,omitempty" cli:"source"`
 }
 
 // GroupPermission represent a group and his role in the project
@@ -8,7 +8,7 @@ import (
 	"sync"
 	"time"
 
-	"go.opencensus.io/stats"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 type AuthConsumerHatcherySigninRequest struct {
@@ -44,6 +44,13 @@ type Hatchery struct {
 	LastHeartbeat time.Time     `json:"last_heartbeat,omitempty" db:"last_heartbeat" cli:"last_heartbeat"`
 	PublicKey     []byte        `json:"public_key" db:"public_key"`
 	HTTPURL       string        `json:"http_url" db:"http_url"`
+	ProjectKeys   StringSlice   `json:"project_keys,omitempty" db:"project_keys" cli:"project_keys"`
+}
+
+// CanRunProject checks that the hatchery is allowed to run the jobs of the given project.
+// The allowed projects are set by an administrator when the hatchery is created, an empty list allows all the projects.
+func (h Hatchery) CanRunProject(projectKey string) bool {
+	return len(h.ProjectKeys) == 0 || h.ProjectKeys.Contains(projectKey)
 }
 
 type HatcheryGetResponse struct {
@@ -53,23 +60,23 @@ type HatcheryGetResponse struct {
 }
 
 type HatcheryMetrics struct {
-	Jobs                          *stats.Int64Measure
-	JobsWebsocket                 *stats.Int64Measure
-	JobsProcessed                 *stats.Int64Measure
-	SpawningWorkers               *stats.Int64Measure
-	SpawnedWorkers                *stats.Int64Measure
-	SpawningWorkersErrors         *stats.Int64Measure
-	JobReceivedInQueuePollingWSv1 *stats.Int64Measure
-	JobReceivedInQueuePollingWSv2 *stats.Int64Measure
-	ChanV1JobAdd                  *stats.Int64Measure
-	ChanV2JobAdd                  *stats.Int64Measure
-	ChanWorkerStarterPop          *stats.Int64Measure
-	PendingWorkers                *stats.Int64Measure
-	RegisteringWorkers            *stats.Int64Measure
-	CheckingWorkers               *stats.Int64Measure
-	WaitingWorkers                *stats.Int64Measure
-	BuildingWorkers               *stats.Int64Measure
-	DisabledWorkers               *stats.Int64Measure
+	Jobs                          *telemetry.Int64Measure
+	JobsWebsocket                 *telemetry.Int64Measure
+	JobsProcessed                 *telemetry.Int64Measure
+	SpawningWorkers               *telemetry.Int64Measure
+	SpawnedWorkers                *telemetry.Int64Measure
+	SpawningWorkersErrors         *telemetry.Int64Measure
+	JobReceivedInQueuePollingWSv1 *telemetry.Int64Measure
+	JobReceivedInQueuePollingWSv2 *telemetry.Int64Measure
+	ChanV1JobAdd                  *telemetry.Int64Measure
+	ChanV2JobAdd                  *telemetry.Int64Measure
+	ChanWorkerStarterPop          *telemetry.Int64Measure
+	PendingWorkers                *telemetry.Int64Measure
+	RegisteringWorkers            *telemetry.Int64Measure
+	CheckingWorkers               *telemetry.Int64Measure
+	WaitingWorkers                *telemetry.Int64Measure
+	BuildingWorkers               *telemetry.Int64Measure
+	DisabledWorkers               *telemetry.Int64Measure
 }
 
 type HatcheryPendingWorkerCreation struct {
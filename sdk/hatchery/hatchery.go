@@ -13,8 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rockbears/log"
 	"github.com/rockbears/yaml"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
@@ -225,7 +224,7 @@ func handleJob(ctx context.Context, hostname string, h Interface, jobID int64, c
 		w, _ := j.Header.Get(sdk.WorkflowHeader)
 		p, _ := j.Header.Get(sdk.ProjectKeyHeader)
 
-		currentCtx = telemetry.New(currentCtx, h, "hatchery.JobReceive", trace.AlwaysSample(), trace.SpanKindServer)
+		currentCtx = telemetry.New(telemetry.ContextWithSampling(currentCtx), h, "hatchery.JobReceive", trace.SpanKindServer)
 		currentCtx, endCurrentCtx = telemetry.Span(currentCtx, "hatchery.JobReceive", telemetry.Tag(telemetry.TagWorkflow, w),
 			telemetry.Tag(telemetry.TagWorkflowRun, r),
 			telemetry.Tag(telemetry.TagProjectKey, p),
@@ -253,7 +252,7 @@ func handleJob(ctx context.Context, hostname string, h Interface, jobID int64, c
 		endTrace(currentCtx.Err().Error(), "")
 	}()
 
-	stats.Record(currentCtx, GetMetrics().Jobs.M(1))
+	telemetry.Record(currentCtx, GetMetrics().Jobs, 1)
 
 	// Check bookedBy current hatchery
 	if j.Status != sdk.StatusWaiting {
@@ -419,7 +418,7 @@ func handleJob(ctx context.Context, hostname string, h Interface, jobID int64, c
 	}
 
 	logStepInfo(currentCtx, "processed", j.Queued)
-	stats.Record(currentCtx, GetMetrics().JobsProcessed.M(1))
+	telemetry.Record(currentCtx, GetMetrics().JobsProcessed, 1)
 	workersStartChan <- workerRequest
 
 	return nil
@@ -439,7 +438,7 @@ func handleJobV2(ctx context.Context, h Interface, jobRunID string, cacheAttempt
 		telemetry.TagServiceName, h.Name(),
 		telemetry.TagServiceType, h.Type(),
 	)
-	ctx = telemetry.New(ctx, h, "hatchery.V2JobReceive", trace.AlwaysSample(), trace.SpanKindServer)
+	ctx = telemetry.New(telemetry.ContextWithSampling(ctx), h, "hatchery.V2JobReceive", trace.SpanKindServer)
 	ctx, end := telemetry.Span(ctx, "hatchery.V2JobReceive", telemetry.Tag(telemetry.TagWorkflow, jobInfo.RunJob.WorkflowName),
 		telemetry.Tag(telemetry.TagWorkflowRunNumber, jobInfo.RunJob.RunNumber),
 		telemetry.Tag(telemetry.TagProjectKey, jobInfo.RunJob.ProjectKey),
@@ -481,7 +480,7 @@ func handleJobV2(ctx context.Context, h Interface, jobRunID string, cacheAttempt
 	ctx = context.WithValue(ctx, LogFieldProject, jobInfo.RunJob.ProjectKey)
 	logStepInfo(ctx, "dequeue", jobInfo.RunJob.Queued)
 
-	stats.Record(ctx, GetMetrics().Jobs.M(1))
+	telemetry.Record(ctx, GetMetrics().Jobs, 1)
 
 	//Check if hatchery is able to start a new worker
 	if !checkCapacities(ctx, h) {
@@ -556,7 +555,7 @@ func handleJobV2(ctx context.Context, h Interface, jobRunID string, cacheAttempt
 	cacheAttempts.NewAttempt(jobInfo.RunJob.ID)
 
 	logStepInfo(ctx, "processed", jobInfo.RunJob.Queued)
-	stats.Record(ctx, GetMetrics().JobsProcessed.M(1))
+	telemetry.Record(ctx, GetMetrics().JobsProcessed, 1)
 	workersStartChan <- workerRequest
 	return nil
 }
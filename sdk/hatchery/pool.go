@@ -6,8 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"go.opencensus.io/stats"
-
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/telemetry"
 	"github.com/rockbears/log"
@@ -132,7 +130,7 @@ func WorkerPool(ctx context.Context, h Interface, statusFilter ...string) ([]sdk
 		nbPerStatus[w.GetStatus()] = nbPerStatus[w.GetStatus()] + 1
 	}
 
-	measures := []stats.Measurement{
+	measures := []telemetry.Measurement{
 		GetMetrics().PendingWorkers.M(int64(nbPerStatus[sdk.StatusWorkerPending])),
 		GetMetrics().RegisteringWorkers.M(int64(nbPerStatus[sdk.StatusWorkerRegistering])),
 		GetMetrics().WaitingWorkers.M(int64(nbPerStatus[sdk.StatusWaiting])),
@@ -140,7 +138,7 @@ func WorkerPool(ctx context.Context, h Interface, statusFilter ...string) ([]sdk
 		GetMetrics().BuildingWorkers.M(int64(nbPerStatus[sdk.StatusBuilding])),
 		GetMetrics().DisabledWorkers.M(int64(nbPerStatus[sdk.StatusDisabled])),
 	}
-	stats.Record(ctx, measures...)
+	telemetry.RecordMeasurements(ctx, measures...)
 
 	// no filter on status, returns the workers list as is.
 	if len(statusFilter) == 0 {
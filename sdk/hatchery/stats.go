@@ -5,8 +5,6 @@ import (
 	"sync"
 
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/telemetry"
@@ -26,25 +24,25 @@ func InitMetrics(ctx context.Context) error {
 	log.Debug(ctx, "hatchery> initializing metrics")
 	var err error
 	onceMetrics.Do(func() {
-		metrics.Jobs = stats.Int64("cds/jobs", "number of analyzed jobs", stats.UnitDimensionless)
-		metrics.JobsWebsocket = stats.Int64("cds/jobs_websocket", "number of analyzed jobs from SSE", stats.UnitDimensionless)
-		metrics.JobsProcessed = stats.Int64("cds/jobs_processed", "number of process jobs in main routine", stats.UnitDimensionless)
-		metrics.SpawnedWorkers = stats.Int64("cds/spawned_workers", "number of spawned workers", stats.UnitDimensionless)
-		metrics.SpawningWorkersErrors = stats.Int64("cds/spawning_workers_errors", "number of error in spawning workers", stats.UnitDimensionless)
-		metrics.SpawningWorkers = stats.Int64("cds/spawning_workers", "number of spawning workers", stats.UnitDimensionless)
-		metrics.JobReceivedInQueuePollingWSv1 = stats.Int64("cds/job_received_in_queue_polling_ws_v1", "number of job received in queue polling v1 ws", stats.UnitDimensionless)
-		metrics.JobReceivedInQueuePollingWSv2 = stats.Int64("cds/job_received_in_queue_polling_ws_v2", "number of job received in queue polling v2 ws", stats.UnitDimensionless)
-		metrics.ChanV1JobAdd = stats.Int64("cds/chan_v1_job_add", "number of add into chan jobs v1", stats.UnitDimensionless)
-		metrics.ChanV2JobAdd = stats.Int64("cds/chan_v2_job_add", "number of add into chan jobs v2", stats.UnitDimensionless)
-		metrics.ChanWorkerStarterPop = stats.Int64("cds/chan_worker_starter_job_pop", "number of pop from chan in a worker starter func", stats.UnitDimensionless)
-		metrics.PendingWorkers = stats.Int64("cds/pending_workers", "number of pending workers", stats.UnitDimensionless)
-		metrics.RegisteringWorkers = stats.Int64("cds/registering_workers", "number of registering workers", stats.UnitDimensionless)
-		metrics.WaitingWorkers = stats.Int64("cds/waiting_workers", "number of waiting workers", stats.UnitDimensionless)
-		metrics.CheckingWorkers = stats.Int64("cds/checking_workers", "number of checking workers", stats.UnitDimensionless)
-		metrics.BuildingWorkers = stats.Int64("cds/building_workers", "number of building workers", stats.UnitDimensionless)
-		metrics.DisabledWorkers = stats.Int64("cds/disabled_workers", "number of disabled workers", stats.UnitDimensionless)
+		metrics.Jobs = telemetry.Int64("cds/jobs", "number of analyzed jobs", telemetry.UnitDimensionless)
+		metrics.JobsWebsocket = telemetry.Int64("cds/jobs_websocket", "number of analyzed jobs from SSE", telemetry.UnitDimensionless)
+		metrics.JobsProcessed = telemetry.Int64("cds/jobs_processed", "number of process jobs in main routine", telemetry.UnitDimensionless)
+		metrics.SpawnedWorkers = telemetry.Int64("cds/spawned_workers", "number of spawned workers", telemetry.UnitDimensionless)
+		metrics.SpawningWorkersErrors = telemetry.Int64("cds/spawning_workers_errors", "number of error in spawning workers", telemetry.UnitDimensionless)
+		metrics.SpawningWorkers = telemetry.Int64("cds/spawning_workers", "number of spawning workers", telemetry.UnitDimensionless)
+		metrics.JobReceivedInQueuePollingWSv1 = telemetry.Int64("cds/job_received_in_queue_polling_ws_v1", "number of job received in queue polling v1 ws", telemetry.UnitDimensionless)
+		metrics.JobReceivedInQueuePollingWSv2 = telemetry.Int64("cds/job_received_in_queue_polling_ws_v2", "number of job received in queue polling v2 ws", telemetry.UnitDimensionless)
+		metrics.ChanV1JobAdd = telemetry.Int64("cds/chan_v1_job_add", "number of add into chan jobs v1", telemetry.UnitDimensionless)
+		metrics.ChanV2JobAdd = telemetry.Int64("cds/chan_v2_job_add", "number of add into chan jobs v2", telemetry.UnitDimensionless)
+		metrics.ChanWorkerStarterPop = telemetry.Int64("cds/chan_worker_starter_job_pop", "number of pop from chan in a worker starter func", telemetry.UnitDimensionless)
+		metrics.PendingWorkers = telemetry.Int64("cds/pending_workers", "number of pending workers", telemetry.UnitDimensionless)
+		metrics.RegisteringWorkers = telemetry.Int64("cds/registering_workers", "number of registering workers", telemetry.UnitDimensionless)
+		metrics.WaitingWorkers = telemetry.Int64("cds/waiting_workers", "number of waiting workers", telemetry.UnitDimensionless)
+		metrics.CheckingWorkers = telemetry.Int64("cds/checking_workers", "number of checking workers", telemetry.UnitDimensionless)
+		metrics.BuildingWorkers = telemetry.Int64("cds/building_workers", "number of building workers", telemetry.UnitDimensionless)
+		metrics.DisabledWorkers = telemetry.Int64("cds/disabled_workers", "number of disabled workers", telemetry.UnitDimensionless)
 
-		tags := []tag.Key{telemetry.MustNewKey(telemetry.TagServiceType), telemetry.MustNewKey(telemetry.TagServiceName)}
+		tags := []telemetry.TagKey{telemetry.MustNewKey(telemetry.TagServiceType), telemetry.MustNewKey(telemetry.TagServiceName)}
 		err = telemetry.RegisterView(ctx,
 			telemetry.NewViewCount("cds/hatchery/jobs_count", metrics.Jobs, tags),
 			telemetry.NewViewCount("cds/hatchery/jobs_websocket_count", metrics.JobsWebsocket, tags),
@@ -313,6 +313,7 @@ type HookRepositoryEventExtractData struct {
 	CommitMessage      string                                       `json:"commit_message"`
 	CommitAuthor       string                                       `json:"commit_author,omitempty"`
 	CommitAuthorEmail  string                                       `json:"commit_author_email,omitempty"`
+	CommitDate         string                                       `json:"commit_date,omitempty"`
 	Paths              []string                                     `json:"paths,omitempty"`
 	Ref                string                                       `json:"ref"`
 	PullRequestID      int64                                        `json:"pullrequest_id,omitempty"`
@@ -102,6 +102,20 @@ func GetJobJsonSchema(publicActionNames []string, regionNames []string, workerMo
 		})
 	}
 
+	// Matrix can be given as values or as an expression returning the whole matrix
+	if strategySchema, has := jobSchema.Definitions["V2JobStrategy"]; has {
+		propMatrix, _ := strategySchema.Properties.Get("matrix")
+		if matrixSchema, ok := propMatrix.(*jsonschema.Schema); ok {
+			strategySchema.Properties.Set("matrix", &jsonschema.Schema{
+				Description: matrixSchema.Description,
+				OneOf: []*jsonschema.Schema{
+					{Type: "object"},
+					{Type: "string", Pattern: `^\$\{\{.*\}\}$`},
+				},
+			})
+		}
+	}
+
 	// Enum on region
 	propRegion, _ := jobSchema.Definitions["V2Job"].Properties.Get("region")
 	regionSchema := propRegion.(*jsonschema.Schema)
@@ -159,6 +173,7 @@ func GetWorkflowJsonSchema(publicActionNames, regionNames, workerModelNames []st
 
 	workflowSchema.Definitions["ActionStep"] = actionStepSchema.Definitions["ActionStep"]
 	workflowSchema.Definitions["V2Job"] = jobSchema.Definitions["V2Job"]
+	workflowSchema.Definitions["V2JobStrategy"] = jobSchema.Definitions["V2JobStrategy"]
 	workflowSchema.Definitions["WorkflowOn"] = workflowOn.Definitions["WorkflowOn"]
 	workflowSchema.Definitions["WorkflowOnPush"] = workflowOn.Definitions["WorkflowOnPush"]
 	workflowSchema.Definitions["WorkflowOnPullRequest"] = workflowOn.Definitions["WorkflowOnPullRequest"]
@@ -40,6 +40,7 @@ type RBAC struct {
 	Name           string              `json:"name" db:"name" cli:"name"`
 	Created        time.Time           `json:"created" db:"created"`
 	LastModified   time.Time           `json:"last_modified" db:"last_modified" cli:"last_modified"`
+	ExpireAt       *time.Time          `json:"expire_at,omitempty" db:"expire_at" cli:"expire_at"`
 	Global         []RBACGlobal        `json:"global,omitempty" db:"-"`
 	Projects       []RBACProject       `json:"projects,omitempty" db:"-"`
 	Regions        []RBACRegion        `json:"regions,omitempty" db:"-"`
@@ -47,12 +48,19 @@ type RBAC struct {
 	Workflows      []RBACWorkflow      `json:"workflows,omitempty" db:"-"`
 	VariableSets   []RBACVariableSet   `json:"variablesets,omitempty" db:"-"`
 	RegionProjects []RBACRegionProject `json:"region_projects,omitempty" db:"-"`
+	// ProjectKeyAsCode is set when the permission is managed as code by a project
+	ProjectKeyAsCode string `json:"ascode_project,omitempty" db:"ascode_project_key" cli:"ascode_project"`
 }
 
 func (rbac *RBAC) IsEmpty() bool {
 	return len(rbac.Projects) == 0 && len(rbac.Hatcheries) == 0 && len(rbac.Global) == 0 && len(rbac.Regions) == 0 && len(rbac.VariableSets) == 0 && len(rbac.Workflows) == 0
 }
 
+// IsExpired returns true if the permission has an expiry date in the past.
+func (rbac *RBAC) IsExpired(now time.Time) bool {
+	return rbac.ExpireAt != nil && !rbac.ExpireAt.After(now)
+}
+
 type PermissionSummary struct {
 	Global   []string                            `json:"global,omitempty"`
 	Regions  map[string][]string                 `json:"regions,omitempty"`
@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// RBACAsCodeFilePaths are the paths of the file that contains the permissions of a project managed as code.
+var RBACAsCodeFilePaths = []string{".cds/rbac.yml", ".cds/rbac.yaml"}
+
+// ProjectRBACAsCode designates the repository from which the permissions of a project are managed as code.
+type ProjectRBACAsCode struct {
+	ProjectKey          string     `json:"project_key" db:"project_key" cli:"project_key"`
+	VCSProjectID        string     `json:"-" db:"vcs_project_id"`
+	ProjectRepositoryID string     `json:"-" db:"project_repository_id"`
+	VCSServer           string     `json:"vcs_server" db:"-" cli:"vcs_server"`
+	Repository          string     `json:"repository" db:"-" cli:"repository"`
+	Created             time.Time  `json:"created" db:"created" cli:"created"`
+	CreatedBy           string     `json:"created_by" db:"created_by" cli:"created_by"`
+	LastCommit          string     `json:"last_commit,omitempty" db:"last_commit" cli:"last_commit"`
+	LastApplied         *time.Time `json:"last_applied,omitempty" db:"last_applied" cli:"last_applied"`
+}
+
+// RBACAsCode is the content of the permissions file of a project.
+type RBACAsCode struct {
+	Permissions []RBAC `json:"permissions"`
+}
+
+// Scope checks that the permissions only target the given project, on its project, workflow and variable set scopes.
+// The project is set on the rules that omit it.
+func (r *RBACAsCode) Scope(projectKey string) error {
+	names := make(map[string]struct{}, len(r.Permissions))
+	for i := range r.Permissions {
+		rb := &r.Permissions[i]
+		if rb.Name == "" {
+			return NewErrorFrom(ErrInvalidData, "permission name is mandatory")
+		}
+		if _, has := names[rb.Name]; has {
+			return NewErrorFrom(ErrInvalidData, "there is at least 2 permissions with the name %s", rb.Name)
+		}
+		names[rb.Name] = struct{}{}
+		if len(rb.Global) > 0 || len(rb.Regions) > 0 || len(rb.Hatcheries) > 0 || len(rb.RegionProjects) > 0 {
+			return NewErrorFrom(ErrForbidden, "permission %s: only project, workflow and variable set permissions can be managed as code", rb.Name)
+		}
+		if rb.ExpireAt != nil {
+			return NewErrorFrom(ErrInvalidData, "permission %s: an expiry date can't be managed as code", rb.Name)
+		}
+		if rb.IsEmpty() {
+			return NewErrorFrom(ErrInvalidData, "permission %s is empty", rb.Name)
+		}
+		for j := range rb.Projects {
+			p := &rb.Projects[j]
+			if len(p.RBACProjectKeys) == 0 {
+				p.RBACProjectKeys = []string{projectKey}
+			}
+			for _, k := range p.RBACProjectKeys {
+				if k != projectKey {
+					return NewErrorFrom(ErrForbidden, "permission %s: project %s is out of the scope of project %s", rb.Name, k, projectKey)
+				}
+			}
+		}
+		for j := range rb.Workflows {
+			w := &rb.Workflows[j]
+			if w.ProjectKey == "" {
+				w.ProjectKey = projectKey
+			}
+			if w.ProjectKey != projectKey {
+				return NewErrorFrom(ErrForbidden, "permission %s: project %s is out of the scope of project %s", rb.Name, w.ProjectKey, projectKey)
+			}
+		}
+		for j := range rb.VariableSets {
+			vs := &rb.VariableSets[j]
+			if vs.ProjectKey == "" {
+				vs.ProjectKey = projectKey
+			}
+			if vs.ProjectKey != projectKey {
+				return NewErrorFrom(ErrForbidden, "permission %s: project %s is out of the scope of project %s", rb.Name, vs.ProjectKey, projectKey)
+			}
+		}
+	}
+	return nil
+}
+
+// RBACAsCodeDiff is the result of the comparison between the permissions of a project managed as code
+// and the ones of its permissions file.
+type RBACAsCodeDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Applied bool     `json:"applied"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func (d RBACAsCodeDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && len(d.Removed) == 0
+}
+
+// NewRBACAsCodeDiff compares the permissions currently managed as code with the wanted ones, by name.
+func NewRBACAsCodeDiff(current, wanted []RBAC) RBACAsCodeDiff {
+	var d RBACAsCodeDiff
+	mCurrent := make(map[string]RBAC, len(current))
+	for _, rb := range current {
+		mCurrent[rb.Name] = rb
+	}
+	mWanted := make(map[string]struct{}, len(wanted))
+	for _, rb := range wanted {
+		mWanted[rb.Name] = struct{}{}
+		existing, has := mCurrent[rb.Name]
+		switch {
+		case !has:
+			d.Added = append(d.Added, rb.Name)
+		case rbacAsCodeContent(existing) != rbacAsCodeContent(rb):
+			d.Updated = append(d.Updated, rb.Name)
+		}
+	}
+	for _, rb := range current {
+		if _, has := mWanted[rb.Name]; !has {
+			d.Removed = append(d.Removed, rb.Name)
+		}
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Updated)
+	sort.Strings(d.Removed)
+	return d
+}
+
+// rbacAsCodeContent returns a comparable form of the rules managed as code, ignoring the order of the names.
+func rbacAsCodeContent(rb RBAC) string {
+	sorted := func(ss []string) []string {
+		res := append([]string{}, ss...)
+		sort.Strings(res)
+		return res
+	}
+	projects := make([]RBACProject, 0, len(rb.Projects))
+	for _, p := range rb.Projects {
+		p.RBACProjectKeys = sorted(p.RBACProjectKeys)
+		p.RBACUsersName = sorted(p.RBACUsersName)
+		p.RBACGroupsName = sorted(p.RBACGroupsName)
+		projects = append(projects, p)
+	}
+	workflows := make([]RBACWorkflow, 0, len(rb.Workflows))
+	for _, w := range rb.Workflows {
+		w.RBACUsersName = sorted(w.RBACUsersName)
+		w.RBACGroupsName = sorted(w.RBACGroupsName)
+		w.RBACWorkflowsNames = sorted(w.RBACWorkflowsNames)
+		workflows = append(workflows, w)
+	}
+	variableSets := make([]RBACVariableSet, 0, len(rb.VariableSets))
+	for _, vs := range rb.VariableSets {
+		vs.RBACUsersName = sorted(vs.RBACUsersName)
+		vs.RBACGroupsName = sorted(vs.RBACGroupsName)
+		vs.RBACVariableSetNames = sorted(vs.RBACVariableSetNames)
+		variableSets = append(variableSets, vs)
+	}
+	btes, _ := json.Marshal(RBAC{Projects: projects, Workflows: workflows, VariableSets: variableSets})
+	return string(btes)
+}
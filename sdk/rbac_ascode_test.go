@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACAsCodeScope(t *testing.T) {
+	r := RBACAsCode{Permissions: []RBAC{
+		{
+			Name:      "proj-manage",
+			Projects:  []RBACProject{{Role: ProjectRoleManage, RBACUsersName: []string{"foo"}}},
+			Workflows: []RBACWorkflow{{Role: WorkflowRoleTrigger, AllWorkflows: true, RBACGroupsName: []string{"devs"}}},
+		},
+		{
+			Name:         "proj-vs",
+			VariableSets: []RBACVariableSet{{ProjectKey: "PROJ", Role: VariableSetRoleUse, AllVariableSets: true, AllUsers: true}},
+		},
+	}}
+	require.NoError(t, r.Scope("PROJ"))
+	require.Equal(t, []string{"PROJ"}, r.Permissions[0].Projects[0].RBACProjectKeys)
+	require.Equal(t, "PROJ", r.Permissions[0].Workflows[0].ProjectKey)
+
+	otherProject := RBACAsCode{Permissions: []RBAC{{Name: "other", Workflows: []RBACWorkflow{{ProjectKey: "OTHER", Role: WorkflowRoleTrigger, AllWorkflows: true, AllUsers: true}}}}}
+	require.True(t, ErrorIs(otherProject.Scope("PROJ"), ErrForbidden))
+
+	global := RBACAsCode{Permissions: []RBAC{{Name: "global", Global: []RBACGlobal{{Role: GlobalRoleManagePermission, RBACUsersName: []string{"foo"}}}}}}
+	require.True(t, ErrorIs(global.Scope("PROJ"), ErrForbidden))
+
+	duplicate := RBACAsCode{Permissions: []RBAC{r.Permissions[1], r.Permissions[1]}}
+	require.True(t, ErrorIs(duplicate.Scope("PROJ"), ErrInvalidData))
+}
+
+func TestNewRBACAsCodeDiff(t *testing.T) {
+	current := []RBAC{
+		{Name: "a", Projects: []RBACProject{{Role: ProjectRoleRead, RBACProjectKeys: []string{"PROJ"}, RBACUsersName: []string{"foo", "bar"}, RBACUsersIDs: []string{"1", "2"}}}},
+		{Name: "b", Projects: []RBACProject{{Role: ProjectRoleRead, RBACProjectKeys: []string{"PROJ"}, AllUsers: true}}},
+		{Name: "c", Projects: []RBACProject{{Role: ProjectRoleRead, RBACProjectKeys: []string{"PROJ"}, AllUsers: true}}},
+	}
+	wanted := []RBAC{
+		{Name: "a", Projects: []RBACProject{{Role: ProjectRoleRead, RBACProjectKeys: []string{"PROJ"}, RBACUsersName: []string{"bar", "foo"}}}},
+		{Name: "b", Projects: []RBACProject{{Role: ProjectRoleManage, RBACProjectKeys: []string{"PROJ"}, AllUsers: true}}},
+		{Name: "d", Projects: []RBACProject{{Role: ProjectRoleRead, RBACProjectKeys: []string{"PROJ"}, AllUsers: true}}},
+	}
+	d := NewRBACAsCodeDiff(current, wanted)
+	require.Equal(t, []string{"d"}, d.Added)
+	require.Equal(t, []string{"b"}, d.Updated)
+	require.Equal(t, []string{"c"}, d.Removed)
+	require.True(t, NewRBACAsCodeDiff(current, current).IsEmpty())
+}
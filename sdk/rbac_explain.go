@@ -0,0 +1,362 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	RBACScopeGlobal      = "global"
+	RBACScopeProject     = "project"
+	RBACScopeWorkflow    = "workflow"
+	RBACScopeVariableSet = "variableset"
+	RBACScopeRegion      = "region"
+	// Hatchery and region-project rules are not given to users, they are listed for the regions and projects of the user
+	RBACScopeHatchery      = "hatchery"
+	RBACScopeRegionProject = "region-project"
+)
+
+// RBACSubject is who the permissions are computed for: a user, the groups it belongs to, its organization
+// and the VCS users linked to it.
+type RBACSubject struct {
+	Username     string        `json:"username"`
+	Groups       []string      `json:"groups,omitempty"`
+	Organization string        `json:"organization,omitempty"`
+	VCSUsers     []RBACVCSUser `json:"vcs_users,omitempty"`
+}
+
+// RBACExplainRequest asks why a user has, or has not, a role on a resource.
+// The scope is given by the resource: region, variable set, workflow, project, or global when none is set.
+type RBACExplainRequest struct {
+	Username    string `json:"username"`
+	Role        string `json:"role"`
+	ProjectKey  string `json:"project,omitempty"`
+	VCSServer   string `json:"vcs,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+	Workflow    string `json:"workflow,omitempty"`
+	VariableSet string `json:"variableset,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+func (r RBACExplainRequest) Scope() string {
+	switch {
+	case r.Region != "":
+		return RBACScopeRegion
+	case r.VariableSet != "":
+		return RBACScopeVariableSet
+	case r.Workflow != "":
+		return RBACScopeWorkflow
+	case r.ProjectKey != "":
+		return RBACScopeProject
+	default:
+		return RBACScopeGlobal
+	}
+}
+
+// Resource returns the resource the request is about, written as in RBACRule.
+func (r RBACExplainRequest) Resource() string {
+	switch r.Scope() {
+	case RBACScopeRegion:
+		return r.Region
+	case RBACScopeVariableSet:
+		return r.ProjectKey + "/" + r.VariableSet
+	case RBACScopeWorkflow:
+		return r.ProjectKey + "/" + r.workflowPath()
+	case RBACScopeProject:
+		return r.ProjectKey
+	default:
+		return ""
+	}
+}
+
+func (r RBACExplainRequest) workflowPath() string {
+	return fmt.Sprintf("%s/%s/%s", r.VCSServer, r.Repository, r.Workflow)
+}
+
+func (r RBACExplainRequest) Check() error {
+	if r.Username == "" {
+		return NewErrorFrom(ErrWrongRequest, "user is mandatory")
+	}
+	if r.Role == "" {
+		return NewErrorFrom(ErrWrongRequest, "role is mandatory")
+	}
+	scope := r.Scope()
+	if scope != RBACScopeRegion && scope != RBACScopeProject && scope != RBACScopeGlobal && r.ProjectKey == "" {
+		return NewErrorFrom(ErrWrongRequest, "project is mandatory for a %s", scope)
+	}
+	if scope == RBACScopeWorkflow && (r.VCSServer == "" || r.Repository == "") {
+		return NewErrorFrom(ErrWrongRequest, "vcs and repository are mandatory for a workflow")
+	}
+	roles := RBACScopeRoles(scope)
+	if !IsInArray(r.Role, roles) {
+		return NewErrorFrom(ErrWrongRequest, "invalid %s role %q, must be one of: %s", scope, r.Role, strings.Join(roles, ", "))
+	}
+	return nil
+}
+
+// RBACScopeRoles returns the roles that can be given on a scope.
+func RBACScopeRoles(scope string) []string {
+	switch scope {
+	case RBACScopeGlobal:
+		return GlobalRoles
+	case RBACScopeProject:
+		return ProjectRoles
+	case RBACScopeWorkflow:
+		return WorkflowRoles
+	case RBACScopeVariableSet:
+		return VariableSetRoles
+	case RBACScopeRegion:
+		return RegionRoles
+	}
+	return nil
+}
+
+// RBACRule is a role given to a subject on a resource by a permission.
+type RBACRule struct {
+	RBACName string `json:"rbac" cli:"rbac"`
+	Scope    string `json:"scope" cli:"scope"`
+	Role     string `json:"role" cli:"role"`
+	Resource string `json:"resource" cli:"resource"`
+	// Through tells how the rule applies to the subject: "user foo", "group bar", "all users", "vcs user github/foo"...
+	Through string `json:"through" cli:"through"`
+
+	// allResources is set for the rules given on all the workflows or variable sets of a project
+	allResources bool
+	// vcsUser is set for the rules given to the VCS users of the subject, they apply to the runs triggered from a repository
+	vcsUser bool
+}
+
+// RBACExplanation is the answer to a RBACExplainRequest.
+type RBACExplanation struct {
+	Request   RBACExplainRequest `json:"request"`
+	Allowed   bool               `json:"allowed"`
+	Reason    string             `json:"reason"`
+	GrantedBy []RBACRule         `json:"granted_by,omitempty"`
+	// OtherRules are the rules of the subject on the same scope that do not grant the request: another role, or another resource.
+	OtherRules []RBACRule `json:"other_rules,omitempty"`
+}
+
+// RBACSubjectRules returns the rules of the permissions that apply to the subject, sorted by scope, resource and role.
+// The hatchery rules of the regions and the region-project rules of the projects the subject has a role on are also returned.
+func RBACSubjectRules(rbs []RBAC, s RBACSubject) []RBACRule {
+	through := func(allUsers bool, users, groups []string) string {
+		if IsInArray(s.Username, users) {
+			return "user " + s.Username
+		}
+		for _, g := range groups {
+			if IsInArray(g, s.Groups) {
+				return "group " + g
+			}
+		}
+		if allUsers {
+			return "all users"
+		}
+		return ""
+	}
+	// VCS server names are given by the projects, so linked VCS users are matched on their username
+	throughVCSUser := func(allVCSUsers bool, vcsUsers RBACVCSUsers) string {
+		for _, u := range vcsUsers {
+			for _, su := range s.VCSUsers {
+				if u.VCSUsername == su.VCSUsername {
+					return "vcs user " + u.VCSServer + "/" + u.VCSUsername
+				}
+			}
+		}
+		if allVCSUsers && len(s.VCSUsers) > 0 {
+			return "all vcs users"
+		}
+		return ""
+	}
+
+	rules := make([]RBACRule, 0)
+	projectKeys := make(map[string]struct{})
+	regionNames := make(map[string]struct{})
+	for _, rb := range rbs {
+		add := func(scope, role, resource, t string) *RBACRule {
+			rules = append(rules, RBACRule{RBACName: rb.Name, Scope: scope, Role: role, Resource: resource, Through: t})
+			return &rules[len(rules)-1]
+		}
+		for _, g := range rb.Global {
+			if t := through(false, g.RBACUsersName, g.RBACGroupsName); t != "" {
+				add(RBACScopeGlobal, g.Role, "", t)
+			}
+		}
+		for _, p := range rb.Projects {
+			t := through(p.AllUsers, p.RBACUsersName, p.RBACGroupsName)
+			vcsT := throughVCSUser(p.AllVCSUsers, p.RBACVCSUsers)
+			for _, key := range p.RBACProjectKeys {
+				if t != "" {
+					add(RBACScopeProject, p.Role, key, t)
+					projectKeys[key] = struct{}{}
+				}
+				if vcsT != "" {
+					add(RBACScopeProject, p.Role, key, vcsT).vcsUser = true
+				}
+			}
+		}
+		for _, w := range rb.Workflows {
+			names := []string(w.RBACWorkflowsNames)
+			if w.AllWorkflows {
+				names = []string{"*"}
+			}
+			for _, ts := range []struct {
+				through string
+				vcsUser bool
+			}{
+				{through(w.AllUsers, w.RBACUsersName, w.RBACGroupsName), false},
+				{throughVCSUser(false, w.RBACVCSUsers), true},
+			} {
+				if ts.through == "" {
+					continue
+				}
+				for _, name := range names {
+					r := add(RBACScopeWorkflow, w.Role, w.ProjectKey+"/"+name, ts.through)
+					r.allResources = w.AllWorkflows
+					r.vcsUser = ts.vcsUser
+				}
+			}
+		}
+		for _, vs := range rb.VariableSets {
+			names := []string(vs.RBACVariableSetNames)
+			if vs.AllVariableSets {
+				names = []string{"*"}
+			}
+			for _, ts := range []struct {
+				through string
+				vcsUser bool
+			}{
+				{through(vs.AllUsers, vs.RBACUsersName, vs.RBACGroupsName), false},
+				{throughVCSUser(false, vs.RBACVCSUsers), true},
+			} {
+				if ts.through == "" {
+					continue
+				}
+				for _, name := range names {
+					r := add(RBACScopeVariableSet, vs.Role, vs.ProjectKey+"/"+name, ts.through)
+					r.allResources = vs.AllVariableSets
+					r.vcsUser = ts.vcsUser
+				}
+			}
+		}
+		for _, r := range rb.Regions {
+			if t := throughVCSUser(r.AllVCSUsers, r.RBACVCSUsers); t != "" {
+				add(RBACScopeRegion, r.Role, r.RegionName, t).vcsUser = true
+			}
+			// A region rule only applies to the users of its organizations
+			if !IsInArray(s.Organization, r.RBACOrganizations) {
+				continue
+			}
+			if t := through(r.AllUsers, r.RBACUsersName, r.RBACGroupsName); t != "" {
+				add(RBACScopeRegion, r.Role, r.RegionName, t+", organization "+s.Organization)
+				regionNames[r.RegionName] = struct{}{}
+			}
+		}
+	}
+
+	// Regions and projects are known once all the user rules are read
+	for _, rb := range rbs {
+		for _, h := range rb.Hatcheries {
+			if _, has := regionNames[h.RegionName]; has {
+				rules = append(rules, RBACRule{RBACName: rb.Name, Scope: RBACScopeHatchery, Role: h.Role, Resource: h.RegionName, Through: "hatchery " + h.HatcheryName})
+			}
+		}
+		for _, rp := range rb.RegionProjects {
+			if rp.AllProjects {
+				rules = append(rules, RBACRule{RBACName: rb.Name, Scope: RBACScopeRegionProject, Role: rp.Role, Resource: rp.RegionName + "/*", Through: "all projects", allResources: true})
+				continue
+			}
+			for _, key := range rp.RBACProjectKeys {
+				if _, has := projectKeys[key]; has {
+					rules = append(rules, RBACRule{RBACName: rb.Name, Scope: RBACScopeRegionProject, Role: rp.Role, Resource: rp.RegionName + "/" + key, Through: "project " + key})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Scope != rules[j].Scope {
+			return rules[i].Scope < rules[j].Scope
+		}
+		if rules[i].Resource != rules[j].Resource {
+			return rules[i].Resource < rules[j].Resource
+		}
+		if rules[i].Role != rules[j].Role {
+			return rules[i].Role < rules[j].Role
+		}
+		return rules[i].RBACName < rules[j].RBACName
+	})
+	return rules
+}
+
+// grants returns true if the rule gives the role of the request to the user, with the same matching as the permission checks.
+// Rules given to VCS users never grant a request, the checks are run for the user.
+func (r RBACExplainRequest) grants(rule RBACRule) bool {
+	if rule.Scope != r.Scope() || rule.Role != r.Role || rule.vcsUser {
+		return false
+	}
+	switch r.Scope() {
+	case RBACScopeGlobal:
+		return true
+	case RBACScopeProject, RBACScopeRegion:
+		return rule.Resource == r.Resource()
+	}
+	pattern := strings.TrimPrefix(rule.Resource, r.ProjectKey+"/")
+	if pattern == rule.Resource {
+		return false
+	}
+	if rule.allResources {
+		return true
+	}
+	name := r.VariableSet
+	if r.Scope() == RBACScopeWorkflow {
+		name = r.workflowPath()
+	}
+	match, err := RBACNamesMatch([]string{pattern}, name)
+	return err == nil && match
+}
+
+// Explain fills the rules of the explanation from the subject rules, and gives a reason for the result of the permission check.
+func (e *RBACExplanation) Explain(subject RBACSubject, rules []RBACRule) {
+	scope := e.Request.Scope()
+	e.GrantedBy = nil
+	e.OtherRules = nil
+	for _, rule := range rules {
+		if rule.Scope != scope {
+			continue
+		}
+		if e.Request.grants(rule) {
+			e.GrantedBy = append(e.GrantedBy, rule)
+		} else {
+			e.OtherRules = append(e.OtherRules, rule)
+		}
+	}
+
+	on := ""
+	if scope != RBACScopeGlobal {
+		on = fmt.Sprintf(" on %s %s", scope, e.Request.Resource())
+	}
+	switch {
+	case e.Allowed && len(e.GrantedBy) > 0:
+		names := make([]string, 0, len(e.GrantedBy))
+		for _, r := range e.GrantedBy {
+			names = append(names, fmt.Sprintf("%s (%s)", r.RBACName, r.Through))
+		}
+		e.Reason = fmt.Sprintf("role %s%s is granted by %s", e.Request.Role, on, strings.Join(names, ", "))
+	case e.Allowed:
+		e.Reason = fmt.Sprintf("role %s%s is not granted by a permission but by the user ring", e.Request.Role, on)
+	default:
+		who := "user " + subject.Username
+		if len(subject.Groups) > 0 {
+			who += ", its groups " + strings.Join(subject.Groups, ", ")
+		}
+		e.Reason = fmt.Sprintf("no permission gives role %s%s to %s or to all users", e.Request.Role, on, who)
+		if scope == RBACScopeRegion {
+			e.Reason += fmt.Sprintf(" for organization %s", subject.Organization)
+		}
+		if len(e.GrantedBy) > 0 {
+			// A rule matches but the check still fails: the resource does not exist or is restricted elsewhere
+			e.Reason = fmt.Sprintf("role %s%s is given by a permission but the check failed", e.Request.Role, on)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACExplain(t *testing.T) {
+	rbs := []RBAC{
+		{
+			Name: "admins",
+			Global: []RBACGlobal{
+				{Role: GlobalRoleManagePermission, RBACGroupsName: []string{"admins"}},
+			},
+		},
+		{
+			Name: "project",
+			Projects: []RBACProject{
+				{Role: ProjectRoleRead, AllUsers: true, RBACProjectKeys: []string{"PROJ", "OTHER"}},
+				{Role: ProjectRoleManageWorkflow, RBACUsersName: []string{"bar"}, RBACProjectKeys: []string{"PROJ"}},
+			},
+			Workflows: []RBACWorkflow{
+				{Role: WorkflowRoleTrigger, ProjectKey: "PROJ", RBACGroupsName: []string{"devs"}, RBACWorkflowsNames: []string{"github/ovh/*"}},
+			},
+			Regions: []RBACRegion{
+				{Role: RegionRoleExecute, RegionName: "build", AllUsers: true, RBACOrganizations: []string{"ovh"}},
+				{Role: RegionRoleExecute, RegionName: "deploy", AllUsers: true, RBACOrganizations: []string{"other"}},
+			},
+		},
+	}
+	subject := RBACSubject{Username: "foo", Groups: []string{"devs"}, Organization: "ovh"}
+
+	rules := RBACSubjectRules(rbs, subject)
+	require.Equal(t, []RBACRule{
+		{RBACName: "project", Scope: RBACScopeProject, Role: ProjectRoleRead, Resource: "OTHER", Through: "all users"},
+		{RBACName: "project", Scope: RBACScopeProject, Role: ProjectRoleRead, Resource: "PROJ", Through: "all users"},
+		{RBACName: "project", Scope: RBACScopeRegion, Role: RegionRoleExecute, Resource: "build", Through: "all users, organization ovh"},
+		{RBACName: "project", Scope: RBACScopeWorkflow, Role: WorkflowRoleTrigger, Resource: "PROJ/github/ovh/*", Through: "group devs"},
+	}, rules)
+
+	e := RBACExplanation{
+		Request: RBACExplainRequest{Username: "foo", Role: WorkflowRoleTrigger, ProjectKey: "PROJ", VCSServer: "github", Repository: "ovh", Workflow: "build"},
+		Allowed: true,
+	}
+	require.NoError(t, e.Request.Check())
+	e.Explain(subject, rules)
+	require.Len(t, e.GrantedBy, 1)
+	require.Equal(t, "role trigger on workflow PROJ/github/ovh/build is granted by project (group devs)", e.Reason)
+
+	e = RBACExplanation{Request: RBACExplainRequest{Username: "foo", Role: ProjectRoleManageWorkflow, ProjectKey: "PROJ"}}
+	require.NoError(t, e.Request.Check())
+	e.Explain(subject, rules)
+	require.Empty(t, e.GrantedBy)
+	require.Len(t, e.OtherRules, 2)
+	require.Equal(t, "no permission gives role manage-workflow on project PROJ to user foo, its groups devs or to all users", e.Reason)
+
+	require.Error(t, (&RBACExplainRequest{Username: "foo", Role: "trigger", ProjectKey: "PROJ"}).Check())
+	require.Error(t, (&RBACExplainRequest{Username: "foo", Role: "trigger", ProjectKey: "PROJ", Workflow: "build"}).Check())
+	require.NoError(t, (&RBACExplainRequest{Username: "foo", Role: GlobalRoleManageUser}).Check())
+}
+
+func TestRBACExplainResources(t *testing.T) {
+	rbs := []RBAC{
+		{
+			Name: "project",
+			Projects: []RBACProject{
+				{Role: ProjectRoleRead, RBACUsersName: []string{"foo"}, RBACProjectKeys: []string{"PROJ"}},
+				{Role: ProjectRoleManage, RBACVCSUsers: RBACVCSUsers{{VCSServer: "github", VCSUsername: "foo-gh"}}, RBACProjectKeys: []string{"PROJ"}},
+			},
+			Workflows: []RBACWorkflow{
+				{Role: WorkflowRoleTrigger, ProjectKey: "PROJ", RBACUsersName: []string{"foo"}, AllWorkflows: true},
+				{Role: WorkflowRoleDebug, ProjectKey: "PROJ", RBACUsersName: []string{"foo"}, RBACWorkflowsNames: []string{"*"}},
+			},
+			Regions: []RBACRegion{
+				{Role: RegionRoleExecute, RegionName: "build", AllUsers: true, RBACOrganizations: []string{"ovh"}},
+			},
+			Hatcheries: []RBACHatchery{
+				{Role: HatcheryRoleSpawn, RegionName: "build", HatcheryName: "swarm"},
+				{Role: HatcheryRoleSpawn, RegionName: "deploy", HatcheryName: "openstack"},
+			},
+			RegionProjects: []RBACRegionProject{
+				{Role: RegionRoleExecute, RegionName: "build", RBACProjectKeys: []string{"PROJ", "OTHER"}},
+			},
+		},
+	}
+	subject := RBACSubject{Username: "foo", Organization: "ovh", VCSUsers: []RBACVCSUser{{VCSServer: "github", VCSUsername: "foo-gh"}}}
+
+	rules := RBACSubjectRules(rbs, subject)
+	var scopes []string
+	for _, r := range rules {
+		scopes = append(scopes, r.Scope+" "+r.Role+" "+r.Resource+" "+r.Through)
+	}
+	require.Equal(t, []string{
+		"hatchery start-worker build hatchery swarm",
+		"project manage PROJ vcs user github/foo-gh",
+		"project read PROJ user foo",
+		"region execute build all users, organization ovh",
+		"region-project execute build/PROJ project PROJ",
+		"workflow debug PROJ/* user foo",
+		"workflow trigger PROJ/* user foo",
+	}, scopes)
+
+	// The rule given on all the workflows grants the role, a "*" pattern does not match the workflow path as in the checks
+	workflowRequest := RBACExplainRequest{Username: "foo", ProjectKey: "PROJ", VCSServer: "github", Repository: "ovh/cds", Workflow: "build"}
+	e := RBACExplanation{Request: workflowRequest}
+	e.Request.Role = WorkflowRoleTrigger
+	e.Explain(subject, rules)
+	require.Len(t, e.GrantedBy, 1)
+	e = RBACExplanation{Request: workflowRequest}
+	e.Request.Role = WorkflowRoleDebug
+	e.Explain(subject, rules)
+	require.Empty(t, e.GrantedBy)
+
+	// Rules given to the VCS users of the subject do not grant the user checks
+	e = RBACExplanation{Request: RBACExplainRequest{Username: "foo", Role: ProjectRoleManage, ProjectKey: "PROJ"}}
+	e.Explain(subject, rules)
+	require.Empty(t, e.GrantedBy)
+	require.Len(t, e.OtherRules, 2)
+}
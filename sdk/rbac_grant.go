@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"time"
+)
+
+const (
+	RBACGrantRequestStatusPending  = "Pending"
+	RBACGrantRequestStatusApproved = "Approved"
+	RBACGrantRequestStatusRejected = "Rejected"
+	RBACGrantRequestStatusRevoked  = "Revoked"
+	RBACGrantRequestStatusExpired  = "Expired"
+
+	// RBACGrantNamePrefix prefixes the name of the permissions created by an approved grant request.
+	RBACGrantNamePrefix = "jit-"
+)
+
+// RBACGrantRequest is a request from a user for a temporary role on a resource.
+// Once approved by a user with the manage-permission role, a permission expiring after Duration minutes is created.
+type RBACGrantRequest struct {
+	ID            string      `json:"id" db:"id" cli:"id"`
+	UserID        string      `json:"user_id" db:"user_id" cli:"-"`
+	Username      string      `json:"username" db:"username" cli:"username"`
+	Organization  string      `json:"organization,omitempty" db:"organization" cli:"-"`
+	Role          string      `json:"role" db:"role" cli:"role"`
+	ProjectKey    string      `json:"project,omitempty" db:"project_key" cli:"project"`
+	VCSServer     string      `json:"vcs,omitempty" db:"vcs_server" cli:"-"`
+	Repository    string      `json:"repository,omitempty" db:"repository" cli:"-"`
+	Workflow      string      `json:"workflow,omitempty" db:"workflow" cli:"workflow"`
+	VariableSet   string      `json:"variableset,omitempty" db:"variableset" cli:"variableset"`
+	Region        string      `json:"region,omitempty" db:"region" cli:"region"`
+	Justification string      `json:"justification" db:"justification" cli:"justification"`
+	Duration      int64       `json:"duration" db:"duration" cli:"duration"`
+	Status        string      `json:"status" db:"status" cli:"status"`
+	Approvers     StringSlice `json:"approvers,omitempty" db:"approvers" cli:"-"`
+	Reviewer      string      `json:"reviewer,omitempty" db:"reviewer" cli:"reviewer"`
+	ReviewComment string      `json:"review_comment,omitempty" db:"review_comment" cli:"-"`
+	RBACName      string      `json:"rbac,omitempty" db:"rbac_name" cli:"rbac"`
+	Created       time.Time   `json:"created" db:"created" cli:"created"`
+	Reviewed      *time.Time  `json:"reviewed,omitempty" db:"reviewed" cli:"-"`
+	ExpireAt      *time.Time  `json:"expire_at,omitempty" db:"expire_at" cli:"expire_at"`
+}
+
+// RBACGrantRequestReview is the comment of a user approving, rejecting or revoking a grant request.
+type RBACGrantRequestReview struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// Access returns the role and the resource requested, as an explain request.
+func (g RBACGrantRequest) Access() RBACExplainRequest {
+	return RBACExplainRequest{
+		Username:    g.Username,
+		Role:        g.Role,
+		ProjectKey:  g.ProjectKey,
+		VCSServer:   g.VCSServer,
+		Repository:  g.Repository,
+		Workflow:    g.Workflow,
+		VariableSet: g.VariableSet,
+		Region:      g.Region,
+	}
+}
+
+// Check validates the requested access, the justification and the duration (in minutes) against the maximum allowed.
+func (g RBACGrantRequest) Check(maxDuration int64) error {
+	if err := g.Access().Check(); err != nil {
+		return err
+	}
+	if g.Justification == "" {
+		return NewErrorFrom(ErrWrongRequest, "justification is mandatory")
+	}
+	if g.Duration <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "duration must be a positive number of minutes")
+	}
+	if maxDuration > 0 && g.Duration > maxDuration {
+		return NewErrorFrom(ErrWrongRequest, "duration cannot exceed %d minutes", maxDuration)
+	}
+	return nil
+}
+
+// RBAC returns the permission that gives the requested role to the user until expireAt.
+func (g RBACGrantRequest) RBAC(expireAt time.Time) RBAC {
+	id := g.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	rb := RBAC{
+		Name:     RBACGrantNamePrefix + g.Username + "-" + id,
+		ExpireAt: &expireAt,
+	}
+	users := []string{g.Username}
+	switch g.Access().Scope() {
+	case RBACScopeGlobal:
+		rb.Global = []RBACGlobal{{Role: g.Role, RBACUsersName: users}}
+	case RBACScopeProject:
+		rb.Projects = []RBACProject{{Role: g.Role, RBACProjectKeys: []string{g.ProjectKey}, RBACUsersName: users}}
+	case RBACScopeWorkflow:
+		rb.Workflows = []RBACWorkflow{{
+			Role:               g.Role,
+			ProjectKey:         g.ProjectKey,
+			RBACWorkflowsNames: RBACWorkflowNames{g.Access().workflowPath()},
+			RBACUsersName:      users,
+		}}
+	case RBACScopeVariableSet:
+		rb.VariableSets = []RBACVariableSet{{
+			Role:                 g.Role,
+			ProjectKey:           g.ProjectKey,
+			RBACVariableSetNames: RBACVariableSetNames{g.VariableSet},
+			RBACUsersName:        users,
+		}}
+	case RBACScopeRegion:
+		rb.Regions = []RBACRegion{{
+			Role:              g.Role,
+			RegionName:        g.Region,
+			RBACOrganizations: []string{g.Organization},
+			RBACUsersName:     users,
+		}}
+	}
+	return rb
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACGrantRequest(t *testing.T) {
+	g := RBACGrantRequest{
+		ID:            "0123456789abcdef",
+		Username:      "foo",
+		Organization:  "ovh",
+		Role:          ProjectRoleManage,
+		ProjectKey:    "PROJ",
+		Justification: "incident #42",
+		Duration:      120,
+	}
+	require.NoError(t, g.Check(480))
+	require.Error(t, g.Check(60))
+
+	noJustification := g
+	noJustification.Justification = ""
+	require.Error(t, noJustification.Check(0))
+
+	invalidRole := g
+	invalidRole.Role = WorkflowRoleTrigger
+	require.Error(t, invalidRole.Check(0))
+
+	expireAt := time.Now().Add(2 * time.Hour)
+	rb := g.RBAC(expireAt)
+	require.Equal(t, "jit-foo-01234567", rb.Name)
+	require.Equal(t, &expireAt, rb.ExpireAt)
+	require.Equal(t, []RBACProject{{Role: ProjectRoleManage, RBACProjectKeys: []string{"PROJ"}, RBACUsersName: []string{"foo"}}}, rb.Projects)
+	require.False(t, rb.IsExpired(time.Now()))
+	require.True(t, rb.IsExpired(expireAt))
+
+	workflow := g
+	workflow.Role = WorkflowRoleTrigger
+	workflow.VCSServer, workflow.Repository, workflow.Workflow = "github", "ovh/cds", "build"
+	require.NoError(t, workflow.Check(0))
+	rb = workflow.RBAC(expireAt)
+	require.Len(t, rb.Workflows, 1)
+	require.Equal(t, RBACWorkflowNames{"github/ovh/cds/build"}, rb.Workflows[0].RBACWorkflowsNames)
+
+	region := RBACGrantRequest{ID: g.ID, Username: "foo", Organization: "ovh", Role: RegionRoleExecute, Region: "build", Justification: "deploy", Duration: 30}
+	require.NoError(t, region.Check(0))
+	rb = region.RBAC(expireAt)
+	require.Equal(t, []string{"ovh"}, rb.Regions[0].RBACOrganizations)
+}
@@ -4,11 +4,14 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+
+	"github.com/ovh/cds/sdk/glob"
 )
 
 var (
 	WorkflowRoleTrigger = "trigger"
-	WorkflowRoles       = []string{WorkflowRoleTrigger}
+	WorkflowRoleDebug   = "debug"
+	WorkflowRoles       = []string{WorkflowRoleTrigger, WorkflowRoleDebug}
 )
 
 type RBACWorkflow struct {
@@ -67,3 +70,17 @@ func (rwn *RBACWorkflowNames) Scan(src interface{}) error {
 	}
 	return WrapError(JSONUnmarshal(source, rwn), "cannot unmarshal RBACWorkflowNames")
 }
+
+// RBACNamesMatch returns true if one of the workflow or variable set patterns of a permission matches the name.
+func RBACNamesMatch(patterns []string, name string) (bool, error) {
+	for _, item := range patterns {
+		r, err := glob.New(item).MatchString(name)
+		if err != nil {
+			return false, err
+		}
+		if r != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
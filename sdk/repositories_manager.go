@@ -102,6 +102,25 @@ type VCSInsight struct {
 	Title  string           `json:"title"`
 	Detail string           `json:"detail"`
 	Datas  []VCSInsightData `json:"data"`
+	// Result is one of PASS or FAIL, left empty to not give any
+	Result      string                 `json:"result,omitempty"`
+	Annotations []VCSInsightAnnotation `json:"annotations,omitempty"`
+}
+
+const (
+	VCSInsightResultPass = "PASS"
+	VCSInsightResultFail = "FAIL"
+
+	VCSInsightAnnotationSeverityLow    = "LOW"
+	VCSInsightAnnotationSeverityMedium = "MEDIUM"
+	VCSInsightAnnotationSeverityHigh   = "HIGH"
+)
+
+type VCSInsightAnnotation struct {
+	Path     string `json:"path"`
+	Line     int64  `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
 }
 
 type VCSInsightData struct {
@@ -108,6 +108,7 @@ type OperationCheckout struct {
 			Next    string `json:"next"`
 		} `json:"semver"`
 		CommitMessage string `json:"commit_message"`
+		CommitDate    string `json:"commit_date,omitempty"`
 		Files         map[string]OperationChangetsetFile
 	} `json:"result"`
 }
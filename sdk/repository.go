@@ -54,6 +54,7 @@ type ProjectRepositoryData struct {
 	Error                     string                        `json:"error"`
 	Entities                  []ProjectRepositoryDataEntity `json:"entities"`
 	Initiator                 *V2Initiator                  `json:"initiator"`
+	RBAC                      *RBACAsCodeDiff               `json:"rbac,omitempty"`
 }
 
 type ProjectRepositoryDataEntity struct {
@@ -0,0 +1,573 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SCIM 2.0 schemas, see RFC 7643 and RFC 7644.
+const (
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SCIMSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	SCIMResourceTypeUser  = "User"
+	SCIMResourceTypeGroup = "Group"
+
+	// SCIMErrorTypeUniqueness is the scimType of the errors returned when a resource already exists.
+	SCIMErrorTypeUniqueness = "uniqueness"
+)
+
+// SCIMUser is a CDS user as a SCIM User resource.
+type SCIMUser struct {
+	Schemas     []string            `json:"schemas"`
+	ID          string              `json:"id,omitempty"`
+	ExternalID  string              `json:"externalId,omitempty"`
+	UserName    string              `json:"userName"`
+	Name        *SCIMUserName       `json:"name,omitempty"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Emails      []SCIMMultiValued   `json:"emails,omitempty"`
+	Active      *bool               `json:"active,omitempty"`
+	Groups      []SCIMMultiValued   `json:"groups,omitempty"`
+	Enterprise  *SCIMEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *SCIMMeta           `json:"meta,omitempty"`
+}
+
+// SCIMUserName is the name of a SCIM User.
+type SCIMUserName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMEnterpriseUser is the enterprise extension of a SCIM User, only the organization is used by CDS.
+type SCIMEnterpriseUser struct {
+	Organization string `json:"organization,omitempty"`
+}
+
+// SCIMMultiValued is an item of a multi-valued attribute: emails, groups or members.
+type SCIMMultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// SCIMMeta is the metadata of a SCIM resource.
+type SCIMMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// SCIMGroup is a CDS group as a SCIM Group resource.
+type SCIMGroup struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id,omitempty"`
+	ExternalID  string            `json:"externalId,omitempty"`
+	DisplayName string            `json:"displayName"`
+	Members     []SCIMMultiValued `json:"members,omitempty"`
+	Meta        *SCIMMeta         `json:"meta,omitempty"`
+}
+
+// SCIMListResponse is the response of a SCIM query.
+type SCIMListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// SCIMError is the body of a SCIM error response, the status is the HTTP status code as a string.
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// IsActive returns false only if the user was explicitly deactivated.
+func (u SCIMUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// PrimaryEmail returns the primary email of the user, or the first one.
+func (u SCIMUser) PrimaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Fullname returns the displayed name of the user, computed from its name if not given.
+func (u SCIMUser) Fullname() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if n := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); n != "" {
+			return n
+		}
+	}
+	return u.UserName
+}
+
+// Organization returns the organization given by the enterprise extension.
+func (u SCIMUser) Organization() string {
+	if u.Enterprise == nil {
+		return ""
+	}
+	return u.Enterprise.Organization
+}
+
+// SCIMFilter is a SCIM filter expression, a list of comparisons joined by "and".
+// Only the attribute operators are supported: eq, ne, co, sw, ew and pr.
+type SCIMFilter []SCIMFilterComparison
+
+// SCIMFilterComparison compares an attribute to a value.
+type SCIMFilterComparison struct {
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+// ParseSCIMFilter parses filters like `userName eq "john"` or `displayName sw "team" and members pr`.
+func ParseSCIMFilter(s string) (SCIMFilter, error) {
+	var f SCIMFilter
+	s = strings.TrimSpace(s)
+	for s != "" {
+		var c SCIMFilterComparison
+		var err error
+		c.Attribute, s = scimNextToken(s)
+		c.Operator, s = scimNextToken(s)
+		c.Operator = strings.ToLower(c.Operator)
+		switch c.Operator {
+		case "pr":
+		case "eq", "ne", "co", "sw", "ew":
+			c.Value, s, err = scimNextValue(s)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, NewErrorFrom(ErrWrongRequest, "unsupported filter operator %q", c.Operator)
+		}
+		if c.Attribute == "" {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid filter: missing attribute")
+		}
+		f = append(f, c)
+
+		if s == "" {
+			break
+		}
+		var and string
+		and, s = scimNextToken(s)
+		if !strings.EqualFold(and, "and") {
+			return nil, NewErrorFrom(ErrWrongRequest, "unsupported filter expression %q, only \"and\" is supported", and)
+		}
+	}
+	return f, nil
+}
+
+func scimNextToken(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexAny(s, " \t")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimSpace(s[i:])
+}
+
+func scimNextValue(s string) (string, string, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, `"`) {
+		// Unquoted values are booleans, numbers or null
+		v, rest := scimNextToken(s)
+		if v == "" {
+			return "", "", NewErrorFrom(ErrWrongRequest, "invalid filter: missing value")
+		}
+		return v, rest, nil
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			v, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", "", NewErrorFrom(ErrWrongRequest, "invalid filter value %s", s[:i+1])
+			}
+			return v, strings.TrimSpace(s[i+1:]), nil
+		}
+	}
+	return "", "", NewErrorFrom(ErrWrongRequest, "invalid filter: unterminated value")
+}
+
+// Match returns true if the given attribute getter matches all the comparisons of the filter.
+// The getter returns all the values of an attribute, and the comparisons are case insensitive.
+func (f SCIMFilter) Match(values func(attribute string) []string) bool {
+	for _, c := range f {
+		if !c.Match(values(c.Attribute)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match returns true if one of the given values matches the comparison.
+func (c SCIMFilterComparison) Match(values []string) bool {
+	if c.Operator == "pr" {
+		for _, v := range values {
+			if v != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if c.Operator == "ne" {
+		return !SCIMFilterComparison{Attribute: c.Attribute, Operator: "eq", Value: c.Value}.Match(values)
+	}
+	expected := strings.ToLower(c.Value)
+	for _, v := range values {
+		v = strings.ToLower(v)
+		var ok bool
+		switch c.Operator {
+		case "eq":
+			ok = v == expected
+		case "co":
+			ok = strings.Contains(v, expected)
+		case "sw":
+			ok = strings.HasPrefix(v, expected)
+		case "ew":
+			ok = strings.HasSuffix(v, expected)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns the values of an attribute of the user, used to filter users.
+func (u SCIMUser) Values(attribute string) []string {
+	switch strings.ToLower(strings.TrimPrefix(attribute, SCIMSchemaUser+":")) {
+	case "id":
+		return []string{u.ID}
+	case "externalid":
+		return []string{u.ExternalID}
+	case "username":
+		return []string{u.UserName}
+	case "displayname":
+		return []string{u.DisplayName}
+	case "name.formatted":
+		if u.Name != nil {
+			return []string{u.Name.Formatted}
+		}
+	case "active":
+		return []string{strconv.FormatBool(u.IsActive())}
+	case "emails", "emails.value":
+		vs := make([]string, 0, len(u.Emails))
+		for _, e := range u.Emails {
+			vs = append(vs, e.Value)
+		}
+		return vs
+	case "groups", "groups.value":
+		vs := make([]string, 0, len(u.Groups))
+		for _, g := range u.Groups {
+			vs = append(vs, g.Value)
+		}
+		return vs
+	case strings.ToLower(SCIMSchemaEnterpriseUser + ":organization"):
+		return []string{u.Organization()}
+	}
+	return nil
+}
+
+// Values returns the values of an attribute of the group, used to filter groups.
+func (g SCIMGroup) Values(attribute string) []string {
+	switch strings.ToLower(strings.TrimPrefix(attribute, SCIMSchemaGroup+":")) {
+	case "id":
+		return []string{g.ID}
+	case "externalid":
+		return []string{g.ExternalID}
+	case "displayname":
+		return []string{g.DisplayName}
+	case "members", "members.value":
+		vs := make([]string, 0, len(g.Members))
+		for _, m := range g.Members {
+			vs = append(vs, m.Value)
+		}
+		return vs
+	}
+	return nil
+}
+
+// SCIMPatchRequest is the body of a SCIM PATCH request.
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation is an add, replace or remove operation on a SCIM resource.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies all the operations on the given resource, a pointer to a SCIMUser or a SCIMGroup.
+func (p SCIMPatchRequest) Apply(resource interface{}) error {
+	btes, err := json.Marshal(resource)
+	if err != nil {
+		return WithStack(err)
+	}
+	var m map[string]interface{}
+	if err := JSONUnmarshal(btes, &m); err != nil {
+		return err
+	}
+
+	for _, op := range p.Operations {
+		var value interface{}
+		if len(op.Value) > 0 {
+			if err := JSONUnmarshal(op.Value, &value); err != nil {
+				return NewErrorFrom(ErrWrongRequest, "invalid value for operation %q on %q", op.Op, op.Path)
+			}
+		}
+		opName := strings.ToLower(op.Op)
+		switch opName {
+		case "add", "replace", "remove":
+		default:
+			return NewErrorFrom(ErrWrongRequest, "unsupported patch operation %q", op.Op)
+		}
+
+		if op.Path == "" {
+			if opName == "remove" {
+				return NewErrorFrom(ErrWrongRequest, "a path is required to remove an attribute")
+			}
+			values, ok := value.(map[string]interface{})
+			if !ok {
+				return NewErrorFrom(ErrWrongRequest, "value of operation %q without path must be an object", op.Op)
+			}
+			for k, v := range values {
+				if err := scimPatchPath(m, opName, k, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := scimPatchPath(m, opName, op.Path, value); err != nil {
+			return err
+		}
+	}
+
+	// Some identity providers send booleans as strings
+	if k := scimKey(m, "active"); k != "" {
+		if s, ok := m[k].(string); ok {
+			b, err := strconv.ParseBool(strings.ToLower(s))
+			if err != nil {
+				return NewErrorFrom(ErrWrongRequest, "invalid value %q for active", s)
+			}
+			m[k] = b
+		}
+	}
+
+	btes, err = json.Marshal(m)
+	if err != nil {
+		return WithStack(err)
+	}
+	// Reset the resource so that removed attributes are not kept
+	v := reflect.ValueOf(resource).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	return JSONUnmarshal(btes, resource)
+}
+
+// scimPatchPath applies an operation on a path: attr, attr.subAttr, attr[filter] or attr[filter].subAttr,
+// optionally prefixed by a schema URN.
+func scimPatchPath(m map[string]interface{}, op, path string, value interface{}) error {
+	container := m
+	for _, schema := range []string{SCIMSchemaUser, SCIMSchemaGroup, SCIMSchemaEnterpriseUser} {
+		if !strings.HasPrefix(strings.ToLower(path), strings.ToLower(schema)) {
+			continue
+		}
+		path = strings.TrimPrefix(path[len(schema):], ":")
+		if schema == SCIMSchemaEnterpriseUser {
+			// The whole extension can be given as value, or one of its attributes
+			if path == "" {
+				return scimPatchAttribute(m, op, schema, value)
+			}
+			container = scimSubMap(m, schema)
+		}
+		break
+	}
+
+	var attr, filter, sub string
+	attr = path
+	if i := strings.Index(path, "["); i >= 0 {
+		j := strings.Index(path, "]")
+		if j < i {
+			return NewErrorFrom(ErrWrongRequest, "invalid path %q", path)
+		}
+		attr, filter, sub = path[:i], path[i+1:j], strings.TrimPrefix(path[j+1:], ".")
+	} else if i := strings.Index(path, "."); i >= 0 {
+		attr, sub = path[:i], path[i+1:]
+	}
+	if attr == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid path %q", path)
+	}
+
+	if filter == "" {
+		if sub == "" {
+			return scimPatchAttribute(container, op, attr, value)
+		}
+		return scimPatchAttribute(scimSubMap(container, attr), op, sub, value)
+	}
+
+	f, err := ParseSCIMFilter(filter)
+	if err != nil {
+		return err
+	}
+	key := scimKey(container, attr)
+	if key == "" {
+		key = attr
+	}
+	items, _ := container[key].([]interface{})
+	var kept []interface{}
+	var matched bool
+	for _, it := range items {
+		item, ok := it.(map[string]interface{})
+		if !ok || !f.Match(func(a string) []string { return scimStringValues(item, a) }) {
+			kept = append(kept, it)
+			continue
+		}
+		matched = true
+		switch {
+		case op == "remove" && sub == "":
+			continue
+		case sub == "":
+			if v, ok := value.(map[string]interface{}); ok {
+				for k := range v {
+					item[k] = v[k]
+				}
+			}
+		default:
+			if err := scimPatchAttribute(item, op, sub, value); err != nil {
+				return err
+			}
+		}
+		kept = append(kept, item)
+	}
+
+	// Adding a sub attribute to an item that doesn't exist yet creates it, ex: emails[type eq "work"].value
+	if !matched && op != "remove" && sub != "" && len(f) == 1 && f[0].Operator == "eq" {
+		kept = append(kept, map[string]interface{}{f[0].Attribute: f[0].Value, sub: value})
+	}
+	container[key] = kept
+	return nil
+}
+
+func scimPatchAttribute(m map[string]interface{}, op, attr string, value interface{}) error {
+	key := scimKey(m, attr)
+	if key == "" {
+		key = attr
+	}
+	existing, isList := m[key].([]interface{})
+	switch op {
+	case "remove":
+		// Removing given values from a multi-valued attribute, ex: members with [{"value": "id"}]
+		if isList && value != nil {
+			toRemove := make(map[string]struct{})
+			for _, v := range scimAsList(value) {
+				if item, ok := v.(map[string]interface{}); ok {
+					toRemove[fmt.Sprint(item["value"])] = struct{}{}
+				}
+			}
+			kept := make([]interface{}, 0, len(existing))
+			for _, it := range existing {
+				if item, ok := it.(map[string]interface{}); ok {
+					if _, ok := toRemove[fmt.Sprint(item["value"])]; ok {
+						continue
+					}
+				}
+				kept = append(kept, it)
+			}
+			m[key] = kept
+			return nil
+		}
+		delete(m, key)
+	case "add":
+		if isList {
+			m[key] = append(existing, scimAsList(value)...)
+			return nil
+		}
+		if v, ok := value.(map[string]interface{}); ok {
+			sub := scimSubMap(m, key)
+			for k := range v {
+				sub[k] = v[k]
+			}
+			return nil
+		}
+		m[key] = value
+	case "replace":
+		m[key] = value
+	}
+	return nil
+}
+
+// scimKey returns the key of the map matching the attribute name, as SCIM attribute names are case insensitive.
+func scimKey(m map[string]interface{}, attr string) string {
+	if _, ok := m[attr]; ok {
+		return attr
+	}
+	for k := range m {
+		if strings.EqualFold(k, attr) {
+			return k
+		}
+	}
+	return ""
+}
+
+func scimSubMap(m map[string]interface{}, attr string) map[string]interface{} {
+	key := scimKey(m, attr)
+	if key == "" {
+		key = attr
+	}
+	sub, ok := m[key].(map[string]interface{})
+	if !ok {
+		sub = make(map[string]interface{})
+		m[key] = sub
+	}
+	return sub
+}
+
+func scimAsList(value interface{}) []interface{} {
+	if l, ok := value.([]interface{}); ok {
+		return l
+	}
+	return []interface{}{value}
+}
+
+func scimStringValues(m map[string]interface{}, attr string) []string {
+	key := scimKey(m, attr)
+	if key == "" {
+		return nil
+	}
+	return []string{fmt.Sprint(m[key])}
+}
@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	f, err := ParseSCIMFilter(`userName eq "John.Doe"`)
+	require.NoError(t, err)
+	require.Equal(t, SCIMFilter{{Attribute: "userName", Operator: "eq", Value: "John.Doe"}}, f)
+
+	f, err = ParseSCIMFilter(`displayName sw "team \"a\"" and members pr`)
+	require.NoError(t, err)
+	require.Equal(t, SCIMFilter{
+		{Attribute: "displayName", Operator: "sw", Value: `team "a"`},
+		{Attribute: "members", Operator: "pr"},
+	}, f)
+
+	f, err = ParseSCIMFilter(`active eq false`)
+	require.NoError(t, err)
+	require.Equal(t, "false", f[0].Value)
+
+	_, err = ParseSCIMFilter(`userName gt "a"`)
+	require.Error(t, err)
+	_, err = ParseSCIMFilter(`userName eq "a" or userName eq "b"`)
+	require.Error(t, err)
+	_, err = ParseSCIMFilter(`userName eq "a`)
+	require.Error(t, err)
+}
+
+func TestSCIMFilterMatch(t *testing.T) {
+	active := false
+	u := SCIMUser{
+		UserName: "john.doe",
+		Emails:   []SCIMMultiValued{{Value: "john@example.com"}, {Value: "jd@example.org"}},
+		Active:   &active,
+	}
+
+	for _, tt := range []struct {
+		filter string
+		match  bool
+	}{
+		{`userName eq "John.Doe"`, true},
+		{`userName ne "john.doe"`, false},
+		{`emails co "example.org"`, true},
+		{`emails.value ew ".net"`, false},
+		{`userName sw "john" and active eq false`, true},
+		{`userName sw "john" and active eq true`, false},
+		{`displayName pr`, false},
+	} {
+		f, err := ParseSCIMFilter(tt.filter)
+		require.NoError(t, err)
+		require.Equal(t, tt.match, f.Match(u.Values), tt.filter)
+	}
+}
+
+func TestSCIMPatchRequestApplyUser(t *testing.T) {
+	active := true
+	u := SCIMUser{
+		Schemas:  []string{SCIMSchemaUser},
+		ID:       "123",
+		UserName: "john.doe",
+		Name:     &SCIMUserName{GivenName: "John", FamilyName: "Doe"},
+		Emails:   []SCIMMultiValued{{Value: "john@example.com", Type: "work", Primary: true}},
+		Active:   &active,
+	}
+
+	var p SCIMPatchRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+  "Operations": [
+    {"op": "Replace", "path": "name.givenName", "value": "Johnny"},
+    {"op": "replace", "path": "emails[type eq \"work\"].value", "value": "johnny@example.com"},
+    {"op": "add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:organization", "value": "ovh"},
+    {"op": "replace", "value": {"displayName": "Johnny Doe", "active": "False"}}
+  ]
+}`), &p))
+	require.NoError(t, p.Apply(&u))
+
+	require.Equal(t, "123", u.ID)
+	require.Equal(t, "Johnny", u.Name.GivenName)
+	require.Equal(t, "Johnny Doe", u.Fullname())
+	require.Equal(t, "johnny@example.com", u.PrimaryEmail())
+	require.Equal(t, "ovh", u.Organization())
+	require.False(t, u.IsActive())
+
+	p = SCIMPatchRequest{Operations: []SCIMPatchOperation{{Op: "unknown", Path: "userName"}}}
+	require.Error(t, p.Apply(&u))
+}
+
+func TestSCIMPatchRequestApplyGroup(t *testing.T) {
+	g := SCIMGroup{
+		ID:          "1",
+		DisplayName: "team",
+		Members:     []SCIMMultiValued{{Value: "a"}, {Value: "b"}},
+	}
+
+	var p SCIMPatchRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "Operations": [
+    {"op": "add", "path": "members", "value": [{"value": "c"}]},
+    {"op": "remove", "path": "members[value eq \"a\"]"},
+    {"op": "remove", "path": "members", "value": [{"value": "b"}]}
+  ]
+}`), &p))
+	require.NoError(t, p.Apply(&g))
+	require.Equal(t, []SCIMMultiValued{{Value: "c"}}, g.Members)
+
+	p = SCIMPatchRequest{Operations: []SCIMPatchOperation{{Op: "replace", Path: "members", Value: json.RawMessage(`[{"value":"d"},{"value":"e"}]`)}}}
+	require.NoError(t, p.Apply(&g))
+	require.Equal(t, []SCIMMultiValued{{Value: "d"}, {Value: "e"}}, g.Members)
+
+	p = SCIMPatchRequest{Operations: []SCIMPatchOperation{{Op: "remove", Path: "members"}}}
+	require.NoError(t, p.Apply(&g))
+	require.Empty(t, g.Members)
+	require.Equal(t, "team", g.DisplayName)
+}
@@ -2,16 +2,342 @@ package telemetry
 
 import (
 	"context"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
+const meterName = "github.com/ovh/cds"
+
+// Measure is a value recorded by the services, aggregated by the views
+type Measure interface {
+	Name() string
+	Description() string
+	Unit() string
+	base() *measure
+}
+
+type measure struct {
+	name        string
+	description string
+	unit        string
+	// views registered on the measure, replaced on Register and Unregister so that
+	// the recording does not take any lock
+	views atomic.Pointer[[]*View]
+}
+
+func (m *measure) Name() string        { return m.name }
+func (m *measure) Description() string { return m.description }
+func (m *measure) Unit() string        { return m.unit }
+func (m *measure) base() *measure      { return m }
+
+// Int64Measure is a measure of int64 values
+type Int64Measure struct{ measure }
+
+// Float64Measure is a measure of float64 values
+type Float64Measure struct{ measure }
+
+// Int64 creates a new int64 measure
+func Int64(name, description, unit string) *Int64Measure {
+	return &Int64Measure{measure{name: name, description: description, unit: unit}}
+}
+
+// Float64 creates a new float64 measure
+func Float64(name, description, unit string) *Float64Measure {
+	return &Float64Measure{measure{name: name, description: description, unit: unit}}
+}
+
+// TagKey is the key of a tag set in the context with ContextWithTag
+type TagKey struct {
+	name string
+}
+
+// Name returns the name of the tag key
+func (k TagKey) Name() string { return k.name }
+
+// AggregationType is the type of aggregation of a view
+type AggregationType int
+
+// Aggregation types
+const (
+	AggTypeCount AggregationType = iota
+	AggTypeSum
+	AggTypeDistribution
+	AggTypeLastValue
+)
+
+func (t AggregationType) String() string {
+	switch t {
+	case AggTypeCount:
+		return "Count"
+	case AggTypeSum:
+		return "Sum"
+	case AggTypeDistribution:
+		return "Distribution"
+	case AggTypeLastValue:
+		return "LastValue"
+	}
+	return "Unknown"
+}
+
+// Aggregation describes how the recorded values are aggregated by a view
+type Aggregation struct {
+	Type    AggregationType
+	Buckets []float64
+}
+
+// Count counts the recorded values
+func Count() *Aggregation { return &Aggregation{Type: AggTypeCount} }
+
+// Sum sums the recorded values
+func Sum() *Aggregation { return &Aggregation{Type: AggTypeSum} }
+
+// LastValue keeps the last recorded value
+func LastValue() *Aggregation { return &Aggregation{Type: AggTypeLastValue} }
+
+// Distribution aggregates the recorded values in a histogram with the given bounds
+func Distribution(bounds ...float64) *Aggregation {
+	return &Aggregation{Type: AggTypeDistribution, Buckets: bounds}
+}
+
+// View aggregates the values of a measure by tags. Each registered view is exported as an OpenTelemetry instrument:
+// a counter for Count and Sum, a histogram for Distribution and an observable gauge for LastValue.
+type View struct {
+	Name        string
+	Description string
+	Measure     Measure
+	TagKeys     []TagKey
+	Aggregation *Aggregation
+
+	instrument *instrument
+	lastValues *lastValues
+}
+
+// Row is the last value of a LastValue view for a set of tags
+type Row struct {
+	Tags map[string]string
+	Last float64
+}
+
+// instrument is the OpenTelemetry instrument of a view, only one is set according to the aggregation
+type instrument struct {
+	aggregation AggregationType
+	counter     metric.Int64Counter
+	sum         metric.Float64Counter
+	histogram   metric.Float64Histogram
+}
+
+// lastValues keeps the last values of a LastValue view, observed by its gauge. They are dropped on Unregister,
+// the synchronous instruments of the other aggregations keep their series until the end of the process.
+type lastValues struct {
+	mutex sync.Mutex
+	rows  map[attribute.Distinct]*Row
+	attrs map[attribute.Distinct]attribute.Set
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[string]*View)
+	// OpenTelemetry instruments cannot be removed, they are created once by view name
+	// and reused by the views registered later with this name.
+	instruments = make(map[string]*instrument)
+)
+
+// Find returns a registered view
+func Find(name string) *View {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return registry[name]
+}
+
+// Register begins collecting data for the given views
+func Register(views ...*View) error {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	for _, v := range views {
+		if v == nil || v.Measure == nil || v.Aggregation == nil {
+			return errors.Errorf("invalid view %+v", v)
+		}
+		if existing, has := registry[v.Name]; has {
+			if existing != v {
+				return errors.Errorf("a different view with the name %q is already registered", v.Name)
+			}
+			continue
+		}
+		i, err := newInstrument(v)
+		if err != nil {
+			return err
+		}
+		v.instrument = i
+		if v.Aggregation.Type == AggTypeLastValue && v.lastValues == nil {
+			v.lastValues = &lastValues{
+				rows:  make(map[attribute.Distinct]*Row),
+				attrs: make(map[attribute.Distinct]attribute.Set),
+			}
+		}
+		registry[v.Name] = v
+		m := v.Measure.base()
+		var measureViews []*View
+		if current := m.views.Load(); current != nil {
+			measureViews = append(measureViews, *current...)
+		}
+		measureViews = append(measureViews, v)
+		m.views.Store(&measureViews)
+	}
+	return nil
+}
+
+func newInstrument(v *View) (*instrument, error) {
+	name := v.Name
+	if i, has := instruments[name]; has {
+		if i.aggregation != v.Aggregation.Type {
+			return nil, errors.Errorf("an instrument with the name %q already exists with the aggregation %v", name, i.aggregation)
+		}
+		return i, nil
+	}
+
+	meter := otel.Meter(meterName)
+	i := &instrument{aggregation: v.Aggregation.Type}
+	var err error
+	switch v.Aggregation.Type {
+	case AggTypeCount:
+		i.counter, err = meter.Int64Counter(name, metric.WithDescription(v.Description))
+	case AggTypeSum:
+		i.sum, err = meter.Float64Counter(name, metric.WithDescription(v.Description), metric.WithUnit(v.Measure.Unit()))
+	case AggTypeDistribution:
+		i.histogram, err = meter.Float64Histogram(name, metric.WithDescription(v.Description), metric.WithUnit(v.Measure.Unit()),
+			metric.WithExplicitBucketBoundaries(v.Aggregation.Buckets...))
+	case AggTypeLastValue:
+		_, err = meter.Float64ObservableGauge(name, metric.WithDescription(v.Description), metric.WithUnit(v.Measure.Unit()),
+			metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+				observeLastValues(name, o)
+				return nil
+			}))
+	default:
+		return nil, errors.Errorf("unsupported aggregation %v", v.Aggregation.Type)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	instruments[name] = i
+	return i, nil
+}
+
+func observeLastValues(name string, o metric.Float64Observer) {
+	v := Find(name)
+	if v == nil || v.lastValues == nil {
+		return
+	}
+	v.lastValues.mutex.Lock()
+	defer v.lastValues.mutex.Unlock()
+	for k, r := range v.lastValues.rows {
+		o.Observe(r.Last, metric.WithAttributeSet(v.lastValues.attrs[k]))
+	}
+}
+
+func (v *View) attributes(tags map[string]string) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(v.TagKeys))
+	for _, k := range v.TagKeys {
+		// Missing tags are exported with an empty value to keep the same labels for all the series of a view
+		kvs = append(kvs, attribute.String(k.name, tags[k.name]))
+	}
+	return attribute.NewSet(kvs...)
+}
+
+func (v *View) record(ctx context.Context, tags map[string]string, value float64) {
+	attrs := v.attributes(tags)
+	switch v.Aggregation.Type {
+	case AggTypeCount:
+		v.instrument.counter.Add(ctx, 1, metric.WithAttributeSet(attrs))
+	case AggTypeSum:
+		v.instrument.sum.Add(ctx, value, metric.WithAttributeSet(attrs))
+	case AggTypeDistribution:
+		v.instrument.histogram.Record(ctx, value, metric.WithAttributeSet(attrs))
+	case AggTypeLastValue:
+		key := attrs.Equivalent()
+		v.lastValues.mutex.Lock()
+		defer v.lastValues.mutex.Unlock()
+		row, has := v.lastValues.rows[key]
+		if !has {
+			row = &Row{Tags: make(map[string]string, len(v.TagKeys))}
+			for _, k := range v.TagKeys {
+				if val, has := tags[k.name]; has {
+					row.Tags[k.name] = val
+				}
+			}
+			v.lastValues.rows[key] = row
+			v.lastValues.attrs[key] = attrs
+		}
+		row.Last = value
+	}
+}
+
+// Unregister stops collecting data for the given views and drops the last values of the LastValue views
+func Unregister(views ...*View) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	for _, v := range views {
+		if v == nil {
+			continue
+		}
+		if existing, has := registry[v.Name]; !has || existing != v {
+			continue
+		}
+		delete(registry, v.Name)
+		if v.lastValues != nil {
+			v.lastValues.mutex.Lock()
+			clear(v.lastValues.rows)
+			clear(v.lastValues.attrs)
+			v.lastValues.mutex.Unlock()
+		}
+		m := v.Measure.base()
+		var measureViews []*View
+		if current := m.views.Load(); current != nil {
+			for _, mv := range *current {
+				if mv != v {
+					measureViews = append(measureViews, mv)
+				}
+			}
+		}
+		m.views.Store(&measureViews)
+	}
+}
+
+// RetrieveData returns the last values of a registered LastValue view
+func RetrieveData(name string) ([]Row, error) {
+	v := Find(name)
+	if v == nil {
+		return nil, errors.Errorf("view %q is not registered", name)
+	}
+	if v.lastValues == nil {
+		return nil, errors.Errorf("view %q is not a LastValue view", name)
+	}
+	v.lastValues.mutex.Lock()
+	defer v.lastValues.mutex.Unlock()
+	keys := make([]string, 0, len(v.lastValues.rows))
+	rowsByKey := make(map[string]Row, len(v.lastValues.rows))
+	for k, r := range v.lastValues.rows {
+		attrs := v.lastValues.attrs[k]
+		key := attrs.Encoded(attribute.DefaultEncoder())
+		keys = append(keys, key)
+		rowsByKey[key] = Row{Tags: r.Tags, Last: r.Last}
+	}
+	sort.Strings(keys)
+	rows := make([]Row, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, rowsByKey[k])
+	}
+	return rows, nil
+}
+
 // RegisterView begins collecting data for the given views
-func RegisterView(ctx context.Context, views ...*view.View) error {
+func RegisterView(ctx context.Context, views ...*View) error {
 	e := StatsExporter(ctx)
 	if e == nil {
 		return nil
@@ -20,9 +346,9 @@ func RegisterView(ctx context.Context, views ...*view.View) error {
 	defer e.exposedViewMutex.Unlock()
 
 	for _, v := range views {
-		if view.Find(v.Name) == nil {
-			if err := view.Register(v); err != nil {
-				return errors.WithStack(err)
+		if Find(v.Name) == nil {
+			if err := Register(v); err != nil {
+				return err
 			}
 			var ev = ExposedView{
 				Name:        v.Name,
@@ -41,88 +367,113 @@ func RegisterView(ctx context.Context, views ...*view.View) error {
 }
 
 // FindAndRegisterViewLast begins collecting data for the given views
-func FindAndRegisterViewLast(nameInput string, tags []tag.Key) (*view.View, error) {
+func FindAndRegisterViewLast(nameInput string, tags []TagKey) (*View, error) {
 	name := strings.ToLower(nameInput)
-	viewFind := view.Find(name)
+	viewFind := Find(name)
 	if viewFind != nil {
 		return viewFind, nil
 	}
-	value := stats.Int64("cds/cds-api/"+name, name, stats.UnitDimensionless)
+	value := Int64("cds/cds-api/"+name, name, UnitDimensionless)
 	newView := NewViewLast(name, value, tags)
-	return newView, view.Register(newView)
+	return newView, Register(newView)
 }
 
 // FindAndRegisterViewLastFloat64 begins collecting data for the given views
-func FindAndRegisterViewLastFloat64(nameInput string, tags []tag.Key) (*view.View, error) {
+func FindAndRegisterViewLastFloat64(nameInput string, tags []TagKey) (*View, error) {
 	name := strings.ToLower(nameInput)
-	viewFind := view.Find(name)
+	viewFind := Find(name)
 	if viewFind != nil {
 		return viewFind, nil
 	}
-	value := stats.Float64("cds/cds-api/"+name, name, stats.UnitDimensionless)
+	value := Float64("cds/cds-api/"+name, name, UnitDimensionless)
 	newView := NewViewLastFloat64(name, value, tags)
-	return newView, view.Register(newView)
+	return newView, Register(newView)
 }
 
 // Record an int64 measure
-func Record(ctx context.Context, m stats.Measure, v int64) {
-	if m == nil {
-		return
-	}
-	mInt64, ok := m.(*stats.Int64Measure)
-	if !ok {
-		return
-	}
-	if mInt64 == nil {
+func Record(ctx context.Context, m Measure, v int64) {
+	mInt64, ok := m.(*Int64Measure)
+	if !ok || mInt64 == nil {
 		return
 	}
-	stats.Record(ctx, mInt64.M(v))
+	record(ctx, &mInt64.measure, float64(v))
 }
 
 // RecordFloat64 a float64 measure
-func RecordFloat64(ctx context.Context, m stats.Measure, v float64) {
-	if m == nil {
+func RecordFloat64(ctx context.Context, m Measure, v float64) {
+	mFloat64, ok := m.(*Float64Measure)
+	if !ok || mFloat64 == nil {
 		return
 	}
-	mFloat64, ok := m.(*stats.Float64Measure)
-	if !ok {
+	record(ctx, &mFloat64.measure, v)
+}
+
+func record(ctx context.Context, m *measure, v float64) {
+	views := m.views.Load()
+	if views == nil || len(*views) == 0 {
 		return
 	}
-	if mFloat64 == nil {
-		return
+	tags := contextTagMap(ctx)
+	for _, view := range *views {
+		view.record(ctx, tags, v)
 	}
-	stats.Record(ctx, mFloat64.M(v))
 }
 
 // NewViewLast creates a new view via aggregation LastValue()
-func NewViewLast(name string, s *stats.Int64Measure, tags []tag.Key) *view.View {
-	return &view.View{
+func NewViewLast(name string, s *Int64Measure, tags []TagKey) *View {
+	return &View{
 		Name:        name,
 		Description: s.Description(),
 		Measure:     s,
-		Aggregation: view.LastValue(),
+		Aggregation: LastValue(),
 		TagKeys:     tags,
 	}
 }
 
 // NewViewLastFloat64 creates a new view via aggregation LastValue()
-func NewViewLastFloat64(name string, s *stats.Float64Measure, tags []tag.Key) *view.View {
-	return &view.View{
+func NewViewLastFloat64(name string, s *Float64Measure, tags []TagKey) *View {
+	return &View{
 		Name:        name,
 		Description: s.Description(),
 		Measure:     s,
-		Aggregation: view.LastValue(),
+		Aggregation: LastValue(),
 		TagKeys:     tags,
 	}
 }
 
 // NewViewCount creates a new view via aggregation Count()
-func NewViewCount(name string, s *stats.Int64Measure, tags []tag.Key) *view.View {
-	return &view.View{
+func NewViewCount(name string, s *Int64Measure, tags []TagKey) *View {
+	return &View{
 		Name:        name,
 		Description: s.Description(),
 		Measure:     s,
-		Aggregation: view.Count(),
+		Aggregation: Count(),
 		TagKeys:     tags,
 	}
 }
+
+// Measurement is a value of a measure, recorded with RecordMeasurements
+type Measurement struct {
+	measure *measure
+	value   float64
+}
+
+// M returns a measurement of the measure
+func (m *Int64Measure) M(v int64) Measurement {
+	return Measurement{measure: &m.measure, value: float64(v)}
+}
+
+// M returns a measurement of the measure
+func (m *Float64Measure) M(v float64) Measurement {
+	return Measurement{measure: &m.measure, value: v}
+}
+
+// RecordMeasurements records several measurements with the tags of the context
+func RecordMeasurements(ctx context.Context, ms ...Measurement) {
+	for _, m := range ms {
+		if m.measure == nil {
+			continue
+		}
+		record(ctx, m.measure, m.value)
+	}
+}
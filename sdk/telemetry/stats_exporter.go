@@ -1,54 +1,96 @@
 package telemetry
 
 import (
-	"reflect"
-	"time"
+	"context"
 
-	"go.opencensus.io/stats/view"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
-func (e *HTTPExporter) GetView(name string, tags map[string]string) *HTTPExporterView {
-	for i := range e.Views {
-		if e.Views[i].Name == name && reflect.DeepEqual(e.Views[i].Tags, tags) {
-			return &e.Views[i]
+// Views collects the current value of the registered views for each set of tags.
+// The values are read from the meter provider only when requested, nothing is kept on record.
+func (e *HTTPExporter) Views(ctx context.Context) ([]HTTPExporterView, error) {
+	if e.reader == nil {
+		return nil, nil
+	}
+	var rm metricdata.ResourceMetrics
+	if err := e.reader.Collect(ctx, &rm); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var views []HTTPExporterView
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if Find(m.Name) == nil {
+				continue
+			}
+			views = append(views, exporterViews(m)...)
 		}
 	}
-	return nil
+	return views, nil
 }
 
-func (e *HTTPExporter) NewView(name string, tags map[string]string) *HTTPExporterView {
-	v := HTTPExporterView{
-		Name: name,
-		Tags: tags,
+// GetView returns the current value of a registered view for the given tags
+func (e *HTTPExporter) GetView(ctx context.Context, name string, tags map[string]string) (*HTTPExporterView, error) {
+	views, err := e.Views(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range views {
+		if views[i].Name == name && equalTags(views[i].Tags, tags) {
+			return &views[i], nil
+		}
 	}
-	e.Views = append(e.Views, v)
-	return &v
+	return nil, nil
 }
 
-func (e *HTTPExporter) ExportView(vd *view.Data) {
-	for _, row := range vd.Rows {
-		tags := make(map[string]string)
-		for _, t := range row.Tags {
-			tags[t.Key.Name()] = t.Value
+func exporterViews(m metricdata.Metrics) []HTTPExporterView {
+	var views []HTTPExporterView
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range data.DataPoints {
+			views = append(views, HTTPExporterView{Name: m.Name, Tags: attributesTags(dp.Attributes), Value: float64(dp.Value), Date: dp.Time})
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range data.DataPoints {
+			views = append(views, HTTPExporterView{Name: m.Name, Tags: attributesTags(dp.Attributes), Value: dp.Value, Date: dp.Time})
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range data.DataPoints {
+			views = append(views, HTTPExporterView{Name: m.Name, Tags: attributesTags(dp.Attributes), Value: dp.Value, Date: dp.Time})
 		}
-		view := e.GetView(vd.View.Name, tags)
-		if view == nil {
-			view = e.NewView(vd.View.Name, tags)
+	case metricdata.Histogram[float64]:
+		// The value of a distribution is the mean of the recorded values
+		for _, dp := range data.DataPoints {
+			var value float64
+			if dp.Count > 0 {
+				value = dp.Sum / float64(dp.Count)
+			}
+			views = append(views, HTTPExporterView{Name: m.Name, Tags: attributesTags(dp.Attributes), Value: value, Date: dp.Time})
 		}
-		view.Record(row.Data)
 	}
+	return views
 }
 
-func (v *HTTPExporterView) Record(data view.AggregationData) {
-	v.Date = time.Now()
-	switch x := data.(type) {
-	case *view.DistributionData:
-		v.Value = x.Mean
-	case *view.CountData:
-		v.Value = float64(x.Value)
-	case *view.SumData:
-		v.Value = x.Value
-	case *view.LastValueData:
-		v.Value = x.Value
+// attributesTags returns the tags of a data point, without the tags missing when the value was recorded
+func attributesTags(attrs attribute.Set) map[string]string {
+	tags := make(map[string]string, attrs.Len())
+	for _, kv := range attrs.ToSlice() {
+		if v := kv.Value.AsString(); v != "" {
+			tags[string(kv.Key)] = v
+		}
+	}
+	return tags
+}
+
+func equalTags(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, has := b[k]; !has || bv != v {
+			return false
+		}
 	}
+	return true
 }
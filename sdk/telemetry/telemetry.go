@@ -3,15 +3,23 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
-	"contrib.go.opencensus.io/exporter/jaeger"
-	"contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/otlptranslator"
 	"github.com/rockbears/log"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func serviceName(s Service) string {
@@ -27,9 +35,9 @@ func StatsExporter(ctx context.Context) *HTTPExporter {
 	return nil
 }
 
-func TraceExporter(ctx context.Context) trace.Exporter {
+func TraceExporter(ctx context.Context) trace.TracerProvider {
 	i := ctx.Value(contextTraceExporter)
-	exp, ok := i.(trace.Exporter)
+	exp, ok := i.(trace.TracerProvider)
 	if ok {
 		return exp
 	}
@@ -48,50 +56,80 @@ func ContextWithTelemetry(from, to context.Context) context.Context {
 	return to
 }
 
-// Init the opencensus exporter
+// Init the OpenTelemetry tracer and meter providers
 func Init(ctx context.Context, cfg Configuration, s Service) (context.Context, error) {
 	ctx = ContextWithTag(ctx,
 		TagServiceType, s.Type(),
 		TagServiceName, s.Name(),
 	)
 
+	var svcName = cfg.Exporters.OTLP.ServiceName
+	if svcName == "" {
+		svcName = serviceName(s)
+	}
+	res := resource.NewSchemaless(attribute.String("service.name", svcName))
+	endpoint := strings.TrimSuffix(cfg.Exporters.OTLP.Endpoint, "/")
+
+	otel.SetTextMapPropagator(DefaultFormat.Propagator())
+
 	if cfg.TracingEnabled {
-		trace.ApplyConfig(
-			trace.Config{
-				DefaultSampler: trace.ProbabilitySampler(cfg.Exporters.Jaeger.SamplingProbability),
-			},
-		)
-		var svcName = cfg.Exporters.Jaeger.ServiceName
-		if svcName == "" {
-			svcName = serviceName(s)
+		log.Info(ctx, "observability> initializing OTLP trace exporter for %q on %q", svcName, endpoint)
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(endpoint + "/v1/traces")}
+		if len(cfg.Exporters.OTLP.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Exporters.OTLP.Headers))
 		}
-		log.Info(ctx, "observability> initializing jaeger exporter for %q on %q", svcName, cfg.Exporters.Jaeger.CollectorEndpoint)
-		e, err := jaeger.NewExporter(jaeger.Options{
-			CollectorEndpoint: cfg.Exporters.Jaeger.CollectorEndpoint,
-			ServiceName:       svcName,
-		})
+		e, err := otlptracehttp.New(ctx, opts...)
 		if err != nil {
 			return ctx, errors.WithStack(err)
 		}
-		trace.RegisterExporter(e)
-		ctx = context.WithValue(ctx, contextTraceExporter, e)
+		tp := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(e),
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(newSampler(cfg.Exporters.OTLP.SamplingProbability)),
+		)
+		otel.SetTracerProvider(tp)
+		ctx = context.WithValue(ctx, contextTraceExporter, trace.TracerProvider(tp))
 	}
 
 	if cfg.Exporters.Prometheus.ReporteringPeriod == 0 {
 		cfg.Exporters.Prometheus.ReporteringPeriod = 10
 	}
-	view.SetReportingPeriod(time.Duration(cfg.Exporters.Prometheus.ReporteringPeriod) * time.Second)
 
 	log.Info(ctx, "observability> initializing prometheus exporter for %q", serviceName(s))
 
-	e, err := prometheus.NewExporter(prometheus.Options{})
+	// Keep the metric names of the views, without unit and type suffixes
+	registry := prometheus.NewRegistry()
+	e, err := otelprometheus.New(
+		otelprometheus.WithRegisterer(registry),
+		otelprometheus.WithTranslationStrategy(otlptranslator.UnderscoreEscapingWithoutSuffixes),
+		otelprometheus.WithoutScopeInfo(),
+		otelprometheus.WithoutTargetInfo(),
+	)
 	if err != nil {
 		return ctx, errors.WithStack(err)
 	}
-	view.RegisterExporter(e)
-	he := new(HTTPExporter)
-	he.Exporter = e
-	view.RegisterExporter(he)
+	// The manual reader collects the values of the views requested on the metrics handlers
+	reader := sdkmetric.NewManualReader()
+	meterOpts := []sdkmetric.Option{sdkmetric.WithReader(e), sdkmetric.WithReader(reader), sdkmetric.WithResource(res)}
+
+	if cfg.Exporters.OTLP.MetricsEnabled {
+		log.Info(ctx, "observability> initializing OTLP metric exporter for %q on %q", svcName, endpoint)
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpointURL(endpoint + "/v1/metrics")}
+		if len(cfg.Exporters.OTLP.Headers) > 0 {
+			opts = append(opts, otlpmetrichttp.WithHeaders(cfg.Exporters.OTLP.Headers))
+		}
+		me, err := otlpmetrichttp.New(ctx, opts...)
+		if err != nil {
+			return ctx, errors.WithStack(err)
+		}
+		period := time.Duration(cfg.Exporters.Prometheus.ReporteringPeriod) * time.Second
+		meterOpts = append(meterOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(me, sdkmetric.WithInterval(period))))
+	}
+	mp := sdkmetric.NewMeterProvider(meterOpts...)
+	otel.SetMeterProvider(mp)
+
+	he := &HTTPExporter{reader: reader, meterProvider: mp}
+	he.Handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 	ctx = context.WithValue(ctx, contextStatsExporter, he)
 
 	return ctx, nil
@@ -130,45 +168,79 @@ const (
 	TagEventID            = "event_id"
 )
 
+func contextTagMap(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	m, _ := ctx.Value(contextTags).(map[string]string)
+	return m
+}
+
+// ContextWithTag returns a context with the given tags key/value, used as attributes of the recorded metrics
 func ContextWithTag(ctx context.Context, s ...interface{}) context.Context {
 	if len(s)%2 != 0 {
 		panic("tags key/value are incorrect")
 	}
-	var tags []tag.Mutator
+	current := contextTagMap(ctx)
+	tags := make(map[string]string, len(current)+len(s)/2)
+	for k, v := range current {
+		tags[k] = v
+	}
 	for i := 0; i < len(s)-1; i = i + 2 {
-		k, err := tag.NewKey(s[i].(string))
-		if err != nil {
-			log.Error(ctx, "ContextWithTag> %v", err)
+		k, ok := s[i].(string)
+		if !ok || !validTagKey(k) {
+			log.Error(ctx, "ContextWithTag> invalid tag key %v", s[i])
 			continue
 		}
-		tags = append(tags, tag.Upsert(k, fmt.Sprintf("%v", s[i+1])))
+		tags[k] = fmt.Sprintf("%v", s[i+1])
 	}
-	ctx, _ = tag.New(ctx, tags...)
-	return ctx
+	return context.WithValue(ctx, contextTags, tags)
 }
 
-func ContextGetTags(ctx context.Context, s ...string) []tag.Mutator {
-	m := tag.FromContext(ctx)
-	var tags []tag.Mutator
-
+// ContextGetTags returns the given tags key/value from the context, to be set on another context with ContextWithTag
+func ContextGetTags(ctx context.Context, s ...string) []interface{} {
+	m := contextTagMap(ctx)
+	var tags []interface{}
 	for i := 0; i < len(s); i++ {
-		k, err := tag.NewKey(s[i])
-		if err != nil {
-			log.Error(ctx, "ContextGetTags> %v", err)
-			continue
-		}
-		val, ok := m.Value(k)
-		if ok {
-			tags = append(tags, tag.Upsert(k, val))
+		if val, ok := m[s[i]]; ok {
+			tags = append(tags, s[i], val)
 		}
 	}
 	return tags
 }
 
-func MustNewKey(s string) tag.Key {
-	k, err := tag.NewKey(s)
-	if err != nil {
-		panic(err)
+func validTagKey(s string) bool {
+	if s == "" || len(s) > 255 {
+		return false
+	}
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func MustNewKey(s string) TagKey {
+	if !validTagKey(s) {
+		panic(fmt.Errorf("invalid tag key %q", s))
+	}
+	return TagKey{name: s}
+}
+
+// Shutdown flushes and stops the tracer and meter providers initialized in the context by Init,
+// the spans and the metrics that were not exported yet are sent to the exporters.
+func Shutdown(ctx context.Context) error {
+	var shutdownErr error
+	if tp, ok := TraceExporter(ctx).(*sdktrace.TracerProvider); ok {
+		if err := tp.Shutdown(ctx); err != nil {
+			shutdownErr = errors.Wrap(err, "unable to shutdown tracer provider")
+		}
+	}
+	if e := StatsExporter(ctx); e != nil && e.meterProvider != nil {
+		if err := e.meterProvider.Shutdown(ctx); err != nil && shutdownErr == nil {
+			shutdownErr = errors.Wrap(err, "unable to shutdown meter provider")
+		}
 	}
-	return k
+	return shutdownErr
 }
@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type testService struct{}
+
+func (testService) Name() string { return "test" }
+func (testService) Type() string { return "api" }
+
+func TestInitPrometheusMetrics(t *testing.T) {
+	ctx, err := Init(context.Background(), Configuration{}, testService{})
+	require.NoError(t, err)
+
+	hits := Int64("cds/test/hits", "number of hits", UnitDimensionless)
+	clients := Int64("cds/test/clients", "number of clients", UnitDimensionless)
+	latency := Float64("cds/test/latency", "latency", UnitMilliseconds)
+	tags := []TagKey{MustNewKey(TagServiceType), MustNewKey(TagServiceName), MustNewKey(TagStatus)}
+	require.NoError(t, RegisterView(ctx,
+		NewViewCount("cds/test/hits_count", hits, tags),
+		NewViewLast("cds/test/clients", clients, tags),
+		&View{Name: "cds/test/latency", Measure: latency, TagKeys: tags, Aggregation: DefaultLatencyDistribution},
+	))
+
+	Record(ContextWithTag(ctx, TagStatus, "ok"), hits, 1)
+	Record(ContextWithTag(ctx, TagStatus, "ok"), hits, 1)
+	Record(ctx, clients, 3)
+	Record(ctx, clients, 5)
+	RecordMeasurements(ctx, latency.M(100), latency.M(300))
+
+	e := StatsExporter(ctx)
+	require.NotNil(t, e)
+	require.Len(t, e.ExposedViews, 3)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mon/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `cds_test_hits_count{service_name="test",service_type="api",status="ok"} 2`)
+	require.Contains(t, string(body), `cds_test_clients{service_name="test",service_type="api",status=""} 5`)
+
+	v, err := e.GetView(ctx, "cds/test/hits_count", map[string]string{TagServiceType: "api", TagServiceName: "test", TagStatus: "ok"})
+	require.NoError(t, err)
+	require.NotNil(t, v)
+	require.Equal(t, float64(2), v.Value)
+
+	v, err = e.GetView(ctx, "cds/test/latency", map[string]string{TagServiceType: "api", TagServiceName: "test"})
+	require.NoError(t, err)
+	require.NotNil(t, v)
+	require.Equal(t, float64(200), v.Value)
+
+	rows, err := RetrieveData("cds/test/clients")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, float64(5), rows[0].Last)
+
+	// Unregistered views are not exported anymore
+	Unregister(Find("cds/test/clients"))
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mon/metrics", nil))
+	body, err = io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	require.NotContains(t, string(body), `cds_test_clients{`)
+	Unregister(Find("cds/test/hits_count"), Find("cds/test/latency"))
+}
+
+func TestSampler(t *testing.T) {
+	s := newSampler(0)
+	params := sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: trace.TraceID{1}, Name: "test"}
+	require.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision)
+
+	params.ParentContext = ContextWithSampling(context.Background())
+	require.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params).Decision)
+
+	params.ParentContext = trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	require.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params).Decision)
+}
+
+func TestB3Format(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	DefaultFormat.SpanContextToRequest(sc, req)
+	require.Equal(t, sc.TraceID().String(), req.Header.Get(TraceIDHeader))
+	require.Equal(t, "1", req.Header.Get(SampledHeader))
+
+	got, ok := DefaultFormat.SpanContextFromRequest(req)
+	require.True(t, ok)
+	require.Equal(t, sc.TraceID(), got.TraceID())
+	require.Equal(t, sc.SpanID(), got.SpanID())
+	require.True(t, got.IsSampled())
+}
+
+func TestShutdown(t *testing.T) {
+	cfg := Configuration{TracingEnabled: true}
+	cfg.Exporters.OTLP.Endpoint = "http://localhost:4318"
+	ctx, err := Init(context.Background(), cfg, testService{})
+	require.NoError(t, err)
+	require.NoError(t, Shutdown(ctx))
+
+	// The views cannot be collected once the meter provider is shut down
+	e := StatsExporter(ctx)
+	require.NotNil(t, e)
+	_, err = e.Views(ctx)
+	require.Error(t, err)
+}
@@ -2,127 +2,159 @@ package telemetry
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
+	"net/http"
 
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// This is a copy and paste of https://github.com/census-instrumentation/opencensus-go/blob/3a827557227b08de330abfac83b8299810c42ac2/plugin/ochttp/propagation/b3/b3.go
-// Waiting it to be released
-//
-// Copyright 2018, OpenCensus Authors
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//     http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-
-// ParseTraceID parses the value of the X-B3-TraceId header.
-func ParseTraceID(tid string) (trace.TraceID, bool) {
-	if tid == "" {
-		return trace.TraceID{}, false
-	}
-	b, err := hex.DecodeString(tid)
-	if err != nil {
-		return trace.TraceID{}, false
-	}
-	var traceID trace.TraceID
-	if len(b) <= 8 {
-		// The lower 64-bits.
-		start := 8 + (8 - len(b))
-		copy(traceID[start:], b)
-	} else {
-		start := 16 - len(b)
-		copy(traceID[start:], b)
+const tracerName = "github.com/ovh/cds"
+
+func tracer(ctx context.Context) trace.Tracer {
+	if tp := TraceExporter(ctx); tp != nil {
+		return tp.Tracer(tracerName)
 	}
+	return otel.Tracer(tracerName)
+}
 
-	return traceID, true
+// sampler samples the traces with a probability, unless the parent span is sampled
+// or the sampling is forced in the context with ContextWithSampling.
+type sampler struct {
+	sdktrace.Sampler
 }
 
-// ParseSpanID parses the value of the X-B3-SpanId or X-B3-ParentSpanId headers.
-func ParseSpanID(sid string) (spanID trace.SpanID, ok bool) {
-	if sid == "" {
-		return trace.SpanID{}, false
+func newSampler(probability float64) sdktrace.Sampler {
+	ratio := sdktrace.TraceIDRatioBased(probability)
+	return sampler{
+		Sampler: sdktrace.ParentBased(ratio,
+			sdktrace.WithRemoteParentNotSampled(ratio),
+			sdktrace.WithLocalParentNotSampled(ratio),
+		),
 	}
-	b, err := hex.DecodeString(sid)
-	if err != nil {
-		return trace.SpanID{}, false
-	}
-	start := 8 - len(b)
-	copy(spanID[start:], b)
-	return spanID, true
 }
 
-// ParseSampled parses the value of the X-B3-Sampled header.
-func ParseSampled(sampled string) (trace.TraceOptions, bool) {
-	switch sampled {
-	case "true", "1":
-		return trace.TraceOptions(1), true
-	default:
-		return trace.TraceOptions(0), false
+func (s sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if forced, _ := p.ParentContext.Value(contextForceSampling).(bool); forced {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
 	}
+	return s.Sampler.ShouldSample(p)
 }
 
-// SpanContextToContext merge a span context in a context
-func SpanContextToContext(ctx context.Context, sc trace.SpanContext) context.Context {
-	if ctx == nil {
-		ctx = context.Background()
+func (s sampler) Description() string {
+	return "CDSSampler{" + s.Sampler.Description() + "}"
+}
+
+// ContextWithSampling forces the sampling of the spans started from the returned context
+func ContextWithSampling(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextForceSampling, true)
+}
+
+// New may start a tracing span
+func New(ctx context.Context, s Service, name string, spanKind trace.SpanKind) context.Context {
+	if TraceExporter(ctx) == nil {
+		return ctx
 	}
+	ctx, span := tracer(ctx).Start(ctx, name, trace.WithSpanKind(spanKind))
+	ctx = ContextWithTag(ctx, TagServiceType, s.Type(), TagServiceName, s.Name())
 
-	ctx = context.WithValue(ctx, ContextTraceIDHeader, sc.TraceID)
-	ctx = context.WithValue(ctx, ContextSpanIDHeader, sc.SpanID)
-	ctx = context.WithValue(ctx, ContextSampledHeader, sc.IsSampled())
+	ctx = context.WithValue(ctx, ContextMainSpan, span)
 	return ctx
 }
 
-// DumpContext is an helper function
-func DumpContext(ctx context.Context) string {
-	return fmt.Sprintf("%s=%v %s=%v %s=%v",
-		TraceIDHeader, ctx.Value(ContextTraceIDHeader),
-		SpanIDHeader, ctx.Value(ContextSpanIDHeader),
-		SampledHeader, ctx.Value(ContextSampledHeader),
+// NewWithRequest may start a tracing span, child of the span context found in the request headers
+func NewWithRequest(ctx context.Context, s Service, _ http.ResponseWriter, req *http.Request, opt Options, tags ...attribute.KeyValue) (context.Context, error) {
+	if TraceExporter(ctx) == nil {
+		return ctx, nil
+	}
+
+	spanCtx := ctx
+	if rootSpanContext, hasSpanContext := DefaultFormat.SpanContextFromRequest(req); hasSpanContext {
+		spanCtx = trace.ContextWithRemoteSpanContext(ctx, rootSpanContext)
+	}
+
+	tags = append(tags,
+		attribute.String(PathAttribute, req.URL.Path),
+		attribute.String(HostAttribute, req.URL.Host),
+		attribute.String(MethodAttribute, req.Method),
+		attribute.String(UserAgentAttribute, req.UserAgent()),
+	)
+	ctx, span := tracer(ctx).Start(spanCtx, opt.Name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(tags...),
+	)
+
+	ctx = context.WithValue(ctx, ContextMainSpan, span)
+	ctx = ContextWithTag(ctx,
+		TagServiceType, s.Type(),
+		TagServiceName, s.Name(),
 	)
+	return ctx, nil
 }
 
-// ContextToSpanContext instanciates a span context from a context.Context
-func ContextToSpanContext(ctx context.Context) (trace.SpanContext, bool) {
-	if ctx == nil {
-		return trace.SpanContext{}, false
+// End may close a tracing span
+func End(ctx context.Context, _ http.ResponseWriter, _ *http.Request) (context.Context, error) {
+	span, has := mainSpan(ctx)
+	if !has {
+		return ctx, nil
 	}
 
-	val := ctx.Value(ContextTraceIDHeader)
-	if val == nil {
-		return trace.SpanContext{}, false
-	}
-	traceID := val.(trace.TraceID)
+	span.End()
+	return ctx, nil
+}
 
-	val = ctx.Value(ContextSpanIDHeader)
-	if val == nil {
-		return trace.SpanContext{}, false
+// Current return the current span
+func Current(ctx context.Context, tags ...attribute.KeyValue) trace.Span {
+	span := trace.SpanFromContext(ctx)
+	if len(tags) > 0 {
+		span.SetAttributes(tags...)
 	}
-	spanID := val.(trace.SpanID)
+	return span
+}
+
+// Tag is helper function to instantiate an attribute
+func Tag(key string, value interface{}) attribute.KeyValue {
+	return attribute.String(key, fmt.Sprintf("%v", value))
+}
 
-	val = ctx.Value(ContextSampledHeader)
-	if val == nil {
-		return trace.SpanContext{}, false
+func mainSpan(ctx context.Context) (trace.Span, bool) {
+	if ctx == nil {
+		return nil, false
 	}
-	sampled := val.(bool)
+	rootSpan, ok := ctx.Value(ContextMainSpan).(trace.Span)
+	return rootSpan, ok
+}
 
-	sc := trace.SpanContext{
-		TraceID: traceID,
-		SpanID:  spanID,
+// MainSpan returns the main span of the context, or a non recording span
+func MainSpan(ctx context.Context) trace.Span {
+	if rootSpan, ok := mainSpan(ctx); ok {
+		return rootSpan
 	}
-	if sampled {
-		sc.TraceOptions = trace.TraceOptions(1)
+	return trace.SpanFromContext(context.Background())
+}
+
+func SpanFromMain(ctx context.Context, name string, tags ...attribute.KeyValue) (context.Context, func()) {
+	rootSpan, has := mainSpan(ctx)
+	if !has {
+		return ctx, func() {}
 	}
 
-	return sc, true
+	ctx, span := tracer(ctx).Start(trace.ContextWithSpan(ctx, rootSpan), name, trace.WithAttributes(tags...))
+	return ctx, func() { span.End() }
+}
+
+// Span start a new span from the parent context
+func Span(ctx context.Context, name string, tags ...attribute.KeyValue) (context.Context, func()) {
+	if ctx == nil {
+		return context.Background(), func() {}
+	}
+	ctx, span := tracer(ctx).Start(ctx, name, trace.WithAttributes(tags...))
+	return ctx, func() {
+		span.End()
+	}
 }
@@ -5,180 +5,59 @@ import (
 	"fmt"
 	"net/http"
 
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// New may start a tracing span
-func New(ctx context.Context, s Service, name string, sampler trace.Sampler, spanKind int) context.Context {
-	exp := TraceExporter(ctx)
-	if exp == nil {
-		return ctx
-	}
-	ctx, span := trace.StartSpan(ctx, name, trace.WithSampler(sampler), trace.WithSpanKind(spanKind))
-	ctx = ContextWithTag(ctx, TagServiceType, s.Type(), TagServiceName, s.Name())
-	ctx = SpanContextToContext(ctx, span.SpanContext())
-
-	ctx = context.WithValue(ctx, ContextMainSpan, span)
-	return ctx
-}
-
-// Start may start a tracing span
-func NewWithRequest(ctx context.Context, s Service, w http.ResponseWriter, req *http.Request, opt Options) (context.Context, error) {
-	exp := TraceExporter(ctx)
-	if exp == nil {
-		return ctx, nil
-	}
-
-	tags := []trace.Attribute{}
-
-	var span *trace.Span
-	rootSpanContext, hasSpanContext := DefaultFormat.SpanContextFromRequest(req)
-
-	var traceOpts = []trace.StartOption{
-		trace.WithSpanKind(trace.SpanKindServer),
-	}
-
-	var sampler trace.Sampler
-	if hasSpanContext && rootSpanContext.IsSampled() {
-		sampler = trace.AlwaysSample()
-	}
-
-	if sampler != nil {
-		traceOpts = append(traceOpts, trace.WithSampler(sampler))
-	}
-
-	if hasSpanContext {
-		ctx, span = trace.StartSpanWithRemoteParent(ctx, opt.Name, rootSpanContext, traceOpts...)
-		span.AddLink(
-			trace.Link{
-				TraceID: rootSpanContext.TraceID,
-				SpanID:  rootSpanContext.SpanID,
-				Type:    trace.LinkTypeChild,
-			},
-		)
-	} else {
-		ctx, span = trace.StartSpan(ctx, opt.Name, traceOpts...)
-	}
-
-	span.AddAttributes(tags...)
-	span.AddAttributes(
-		trace.StringAttribute(PathAttribute, req.URL.Path),
-		trace.StringAttribute(HostAttribute, req.URL.Host),
-		trace.StringAttribute(MethodAttribute, req.Method),
-		trace.StringAttribute(UserAgentAttribute, req.UserAgent()),
-	)
-
-	ctx = context.WithValue(ctx, ContextMainSpan, span)
-
-	ctx = SpanContextToContext(ctx, span.SpanContext())
-	ctx = ContextWithTag(ctx,
-		TagServiceType, s.Type(),
-		TagServiceName, s.Name(),
-	)
-	return ctx, nil
+// HTTPFormat reads and writes the span context in the HTTP headers
+type HTTPFormat interface {
+	SpanContextFromRequest(req *http.Request) (trace.SpanContext, bool)
+	SpanContextToRequest(sc trace.SpanContext, req *http.Request)
+	Propagator() propagation.TextMapPropagator
 }
 
-// End may close a tracing span
-func End(ctx context.Context, _ http.ResponseWriter, _ *http.Request) (context.Context, error) {
-	span := MainSpan(ctx)
-	if span == nil {
-		return ctx, nil
-	}
-
-	span.End()
-	return ctx, nil
+// b3Format propagates the span context with the B3 multiple headers, the single header is also accepted on extraction
+type b3Format struct {
+	propagator propagation.TextMapPropagator
 }
 
-// LinkTo a traceID
-func LinkTo(ctx context.Context, traceID [16]byte) {
-	s := Current(ctx)
-	if s == nil {
-		return
-	}
-
-	s.AddLink(
-		trace.Link{
-			TraceID: traceID,
-		},
-	)
+func newB3Format() *b3Format {
+	return &b3Format{propagator: b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))}
 }
 
-// Current return the current span
-func Current(ctx context.Context, tags ...trace.Attribute) *trace.Span {
-	if ctx == nil {
-		return nil
-	}
-	span := trace.FromContext(ctx)
-	if span == nil {
-		return nil
-	}
-	if len(tags) > 0 {
-		span.AddAttributes(tags...)
-	}
-	return span
+// SpanContextFromRequest extracts the remote span context from the request headers
+func (f *b3Format) SpanContextFromRequest(req *http.Request) (trace.SpanContext, bool) {
+	ctx := f.propagator.Extract(context.Background(), propagation.HeaderCarrier(req.Header))
+	sc := trace.SpanContextFromContext(ctx)
+	return sc, sc.IsValid()
 }
 
-// Tag is helper function to instantiate trace.Attribute
-func Tag(key string, value interface{}) trace.Attribute {
-	return trace.StringAttribute(key, fmt.Sprintf("%v", value))
+// SpanContextToRequest writes the span context in the request headers
+func (f *b3Format) SpanContextToRequest(sc trace.SpanContext, req *http.Request) {
+	f.propagator.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), propagation.HeaderCarrier(req.Header))
 }
 
-func MainSpan(ctx context.Context) *trace.Span {
-	spanI := ctx.Value(ContextMainSpan)
-	if spanI == nil {
-		return nil
-	}
-
-	rootSpan, ok := spanI.(*trace.Span)
-	if !ok {
-		return nil
-	}
-
-	return rootSpan
+// Propagator returns the OpenTelemetry propagator of the format
+func (f *b3Format) Propagator() propagation.TextMapPropagator {
+	return f.propagator
 }
 
-func SpanFromMain(ctx context.Context, name string, tags ...trace.Attribute) (context.Context, func()) {
-	rootSpan := MainSpan(ctx)
-	if rootSpan == nil {
-		return ctx, func() {}
-	}
-	rootSpanContext := rootSpan.SpanContext()
-
-	var traceOpts = []trace.StartOption{}
-
-	var sampler trace.Sampler
-	if rootSpanContext.IsSampled() {
-		sampler = trace.AlwaysSample()
-	}
-
-	if sampler != nil {
-		traceOpts = append(traceOpts, trace.WithSampler(sampler))
-	}
-
-	ctx, span := trace.StartSpanWithRemoteParent(ctx, name, rootSpanContext, traceOpts...)
-	span.AddLink(trace.Link{
-		TraceID: rootSpanContext.TraceID,
-		SpanID:  rootSpanContext.SpanID,
-	})
-	span.AddAttributes(tags...)
-
-	return ctx, span.End
+// DumpContext is an helper function
+func DumpContext(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	return fmt.Sprintf("%s=%v %s=%v %s=%v",
+		TraceIDHeader, sc.TraceID(),
+		SpanIDHeader, sc.SpanID(),
+		SampledHeader, sc.IsSampled(),
+	)
 }
 
-// Span start a new span from the parent context
-func Span(ctx context.Context, name string, tags ...trace.Attribute) (context.Context, func()) {
-	// log.Debug(ctx, "# %s - begin", name)
+// ContextToSpanContext returns the span context of the current span of the context
+func ContextToSpanContext(ctx context.Context) (trace.SpanContext, bool) {
 	if ctx == nil {
-		return context.Background(), func() {}
-	}
-	var span *trace.Span
-	ctx, span = trace.StartSpan(ctx, name)
-	if len(tags) > 0 {
-		span.AddAttributes(tags...)
-	}
-	ctx = SpanContextToContext(ctx, span.SpanContext())
-	return ctx, func() {
-		// log.Debug(ctx, "# %s - end", name)
-		span.End()
+		return trace.SpanContext{}, false
 	}
+	sc := trace.SpanContextFromContext(ctx)
+	return sc, sc.IsValid()
 }
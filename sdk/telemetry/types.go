@@ -1,13 +1,11 @@
 package telemetry
 
 import (
+	"net/http"
 	"sync"
 	"time"
 
-	"contrib.go.opencensus.io/exporter/prometheus"
-	"go.opencensus.io/plugin/ochttp/propagation/b3"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/trace/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
 // Attributes recorded on the span for the requests.
@@ -20,17 +18,19 @@ const (
 )
 
 // DefaultFormat used by observability as: observability.DefaultFormat.SpanContextToRequest
-var DefaultFormat propagation.HTTPFormat = &b3.HTTPFormat{}
+var DefaultFormat HTTPFormat = newB3Format()
 
 // Configuration is the global tracing configuration
 type Configuration struct {
 	TracingEnabled bool `toml:"tracingEnabled" json:"tracingEnabled"`
 	Exporters      struct {
-		Jaeger struct {
-			ServiceName         string  `toml:"serviceName" default:"" json:"serviceName"`
-			CollectorEndpoint   string  `toml:"collectorEndpoint" default:"http://localhost:14268/api/traces" json:"collectorEndpoint"`
-			SamplingProbability float64 `toml:"samplingProbability" json:"metricSamplingProbability"`
-		} `json:"jaeger"`
+		OTLP struct {
+			ServiceName         string            `toml:"serviceName" default:"" json:"serviceName" comment:"Service name of the traces and metrics, default to <service type>/<service name>"`
+			Endpoint            string            `toml:"endpoint" default:"http://localhost:4318" json:"endpoint" comment:"OTLP/HTTP collector endpoint, traces are sent to <endpoint>/v1/traces and metrics to <endpoint>/v1/metrics"`
+			Headers             map[string]string `toml:"headers" json:"-" comment:"Headers sent to the OTLP collector (ex: authentication)"`
+			SamplingProbability float64           `toml:"samplingProbability" json:"samplingProbability"`
+			MetricsEnabled      bool              `toml:"metricsEnabled" default:"false" json:"metricsEnabled" comment:"Push metrics to the OTLP collector"`
+		} `toml:"otlp" json:"otlp"`
 		Prometheus struct {
 			ReporteringPeriod int `toml:"ReporteringPeriod" default:"10" json:"reporteringPeriod"`
 		} `json:"prometheus"`
@@ -39,9 +39,9 @@ type Configuration struct {
 
 var (
 	// DefaultSizeDistribution 25k, 100k, 250, 500, 1M, 1.5M, 5M, 10M,
-	DefaultSizeDistribution = view.Distribution(25*1024, 100*1024, 250*1024, 500*1024, 1024*1024, 1.5*1024*1024, 5*1024*1024, 10*1024*1024)
+	DefaultSizeDistribution = Distribution(25*1024, 100*1024, 250*1024, 500*1024, 1024*1024, 1.5*1024*1024, 5*1024*1024, 10*1024*1024)
 	// DefaultLatencyDistribution 100ms, ...
-	DefaultLatencyDistribution = view.Distribution(100, 200, 300, 400, 500, 750, 1000, 2000, 5000)
+	DefaultLatencyDistribution = Distribution(100, 200, 300, 400, 500, 750, 1000, 2000, 5000)
 )
 
 const (
@@ -53,6 +53,13 @@ const (
 	RequestID  = "http.request-id"
 )
 
+// Units of the measures
+const (
+	UnitDimensionless = "1"
+	UnitBytes         = "By"
+	UnitMilliseconds  = "ms"
+)
+
 type ExposedView struct {
 	Name        string   `json:"name"`
 	Description string   `json:"description"`
@@ -62,10 +69,11 @@ type ExposedView struct {
 }
 
 type HTTPExporter struct {
-	*prometheus.Exporter `json:"-"`
-	ExposedViews         []ExposedView `json:"-"`
-	exposedViewMutex     sync.Mutex    `json:"-"`
-	Views                []HTTPExporterView
+	http.Handler     `json:"-"`
+	ExposedViews     []ExposedView            `json:"-"`
+	exposedViewMutex sync.Mutex               `json:"-"`
+	reader           *sdkmetric.ManualReader  `json:"-"`
+	meterProvider    *sdkmetric.MeterProvider `json:"-"`
 }
 
 type HTTPExporterView struct {
@@ -80,6 +88,8 @@ type contextKey int
 const (
 	contextTraceExporter contextKey = iota
 	contextStatsExporter
+	contextTags
+	contextForceSampling
 )
 
 type Service interface {
@@ -87,19 +97,16 @@ type Service interface {
 	Type() string
 }
 
-// B3 headers that OpenCensus understands.
+// B3 headers used to propagate the traces.
 const (
 	TraceIDHeader = "X-B3-TraceId"
 	SpanIDHeader  = "X-B3-SpanId"
 	SampledHeader = "X-B3-Sampled"
 
-	ContextTraceIDHeader contextKey = iota
-	ContextSpanIDHeader
-	ContextSampledHeader
-	ContextMainSpan
+	ContextMainSpan contextKey = iota + 100
 )
 
-//Options is the options struct for a new tracing span
+// Options is the options struct for a new tracing span
 type Options struct {
 	Init     bool
 	Name     string
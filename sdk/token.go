@@ -33,6 +33,13 @@ type DriverWithSigninStateToken interface {
 	CheckSigninStateToken(AuthConsumerSigninRequest) error
 }
 
+// DriverWithUserGroups is a driver that can retrieve the external groups of a user outside of a signin.
+// GetUserGroups returns ErrUserNotFound when the user doesn't exist anymore in the external system.
+type DriverWithUserGroups interface {
+	Driver
+	GetUserGroups(ctx context.Context, username string) ([]string, error)
+}
+
 // AuthDriver interface.
 type AuthDriver interface {
 	GetManifest() AuthDriverManifest
@@ -107,6 +114,9 @@ type AuthConsumerScopeDetail struct {
 
 // IsValid returns validity for scope.
 func (s AuthConsumerScope) IsValid() bool {
+	if s == AuthConsumerScopeSCIM {
+		return true
+	}
 	for i := range AuthConsumerScopes {
 		if AuthConsumerScopes[i] == s {
 			return true
@@ -130,9 +140,11 @@ const (
 	AuthConsumerScopeWorkerModel  AuthConsumerScope = "WorkerModel"
 	AuthConsumerScopeHatchery     AuthConsumerScope = "Hatchery"
 	AuthConsumerScopeService      AuthConsumerScope = "Service"
+	AuthConsumerScopeSCIM         AuthConsumerScope = "SCIM"
 )
 
-// AuthConsumerScopes list.
+// AuthConsumerScopes list. The SCIM scope is not in the list, it is given explicitly to the consumer of the
+// identity provider and never to a consumer created with all the scopes.
 var AuthConsumerScopes = []AuthConsumerScope{
 	AuthConsumerScopeUser,
 	AuthConsumerScopeAccessToken,
@@ -283,6 +295,7 @@ type AuthDriverUserInfo struct {
 	MFA             bool
 	ExternalTokenID string
 	Organization    string
+	Groups          []string
 }
 
 // AuthCurrentConsumerResponse describe the current consumer and the current session
@@ -310,6 +323,8 @@ const (
 	ConsumerHatchery        AuthConsumerType = "hatchery"
 	ConsumerTest            AuthConsumerType = "futurama"
 	ConsumerTest2           AuthConsumerType = "planet-express"
+	// ConsumerSCIM is not an auth driver, it is the source of the group memberships provisioned by SCIM
+	ConsumerSCIM AuthConsumerType = "scim"
 )
 
 // IsValid returns validity of given auth consumer type.
@@ -353,9 +368,10 @@ type AuthConsumerWarningType string
 
 // Consumer warning types.
 const (
-	WarningGroupInvalid     AuthConsumerWarningType = "group-invalid"
-	WarningGroupRemoved     AuthConsumerWarningType = "group-removed"
-	WarningLastGroupRemoved AuthConsumerWarningType = "last-group-removed"
+	WarningGroupInvalid      AuthConsumerWarningType = "group-invalid"
+	WarningGroupRemoved      AuthConsumerWarningType = "group-removed"
+	WarningLastGroupRemoved  AuthConsumerWarningType = "last-group-removed"
+	WarningUserDeprovisioned AuthConsumerWarningType = "user-deprovisioned"
 )
 
 // AuthConsumerWarnings contains specific information from the auth driver.
@@ -384,6 +400,11 @@ func NewConsumerWarningLastGroupRemoved() AuthConsumerWarning {
 	return AuthConsumerWarning{Type: WarningLastGroupRemoved}
 }
 
+// NewConsumerWarningUserDeprovisioned returns a new warning.
+func NewConsumerWarningUserDeprovisioned() AuthConsumerWarning {
+	return AuthConsumerWarning{Type: WarningUserDeprovisioned}
+}
+
 // AuthConsumerWarning contains info about a warning.
 type AuthConsumerWarning struct {
 	Type      AuthConsumerWarningType `json:"type"`
@@ -51,6 +51,8 @@ type AuthentifiedUser struct {
 	Fullname string    `json:"fullname" yaml:"fullname,omitempty" cli:"fullname" db:"fullname"`
 	Ring     string    `json:"ring" yaml:"ring,omitempty" cli:"ring" db:"ring"`
 	Disabled bool      `json:"disabled" yaml:"disabled,omitempty" cli:"disabled" db:"disabled"`
+	// ExternalID is the id of the user in the identity provider that provisions it with SCIM
+	ExternalID string `json:"external_id,omitempty" yaml:"external_id,omitempty" cli:"-" db:"external_id"`
 	// aggregates
 	Contacts     UserContacts `json:"-" yaml:"-" db:"-"`
 	Groups       Groups       `json:"groups" yaml:"groups" db:"-"`
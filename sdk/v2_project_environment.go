@@ -0,0 +1,102 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk/glob"
+)
+
+// EnvironmentConcurrencyPrefix prefixes the implicit concurrency rule that allows only one deployment at a time on an environment.
+const EnvironmentConcurrencyPrefix = "environment:"
+
+// ProjectEnvironment is a deployment target that jobs can use with protection rules.
+type ProjectEnvironment struct {
+	ID                string                        `json:"id" db:"id" cli:"-"`
+	ProjectKey        string                        `json:"project_key" db:"project_key" cli:"-"`
+	Name              string                        `json:"name" db:"name" cli:"name"`
+	Description       string                        `json:"description" db:"description" cli:"description"`
+	ReviewerUsers     StringSlice                   `json:"reviewer_users,omitempty" db:"reviewer_users" cli:"reviewer_users"`
+	ReviewerGroups    StringSlice                   `json:"reviewer_groups,omitempty" db:"reviewer_groups" cli:"reviewer_groups"`
+	AllowedRefs       StringSlice                   `json:"allowed_refs,omitempty" db:"allowed_refs" cli:"allowed_refs"`
+	WaitTimer         int64                         `json:"wait_timer,omitempty" db:"wait_timer" cli:"wait_timer"`
+	VariableSet       string                        `json:"variable_set,omitempty" db:"variable_set" cli:"variable_set"`
+	LastModified      time.Time                     `json:"last_modified" db:"last_modified" cli:"last_modified"`
+	CurrentDeployment *ProjectEnvironmentDeployment `json:"current_deployment,omitempty" db:"-" cli:"-"`
+}
+
+func (e *ProjectEnvironment) Check() error {
+	namePattern, err := regexp.Compile(EntityNamePattern)
+	if err != nil {
+		return WrapError(err, "unable to compile regexp %s", namePattern)
+	}
+	if !namePattern.MatchString(e.Name) {
+		return NewErrorFrom(ErrInvalidData, "name %s doesn't match %s", e.Name, EntityNamePattern)
+	}
+	if e.WaitTimer < 0 {
+		return NewErrorFrom(ErrInvalidData, "wait timer must be a positive number of minutes")
+	}
+	return nil
+}
+
+// Reviewers returns the users and groups that have to trigger the jobs deploying on the environment.
+func (e ProjectEnvironment) Reviewers() V2JobGateReviewers {
+	return V2JobGateReviewers{Users: e.ReviewerUsers, Groups: e.ReviewerGroups}
+}
+
+// IsRefAllowed checks the full git ref (refs/heads/main, refs/tags/v1.0.0) against the allowed refs patterns.
+func (e ProjectEnvironment) IsRefAllowed(ref string) (bool, error) {
+	if len(e.AllowedRefs) == 0 {
+		return true, nil
+	}
+	for _, r := range e.AllowedRefs {
+		result, err := glob.New(r).MatchString(ref)
+		if err != nil {
+			return false, NewErrorFrom(ErrInvalidData, "unable to check allowed ref with pattern %s: %v", r, err)
+		}
+		if result != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Concurrency returns the project scoped concurrency rule used by jobs deploying on the environment.
+func (e ProjectEnvironment) Concurrency() V2RunConcurrency {
+	return V2RunConcurrency{
+		Scope: V2RunConcurrencyScopeProject,
+		WorkflowConcurrency: WorkflowConcurrency{
+			Name:  EnvironmentConcurrencyPrefix + e.Name,
+			Order: ConcurrencyOrderOldestFirst,
+			Pool:  1,
+		},
+	}
+}
+
+// IsEnvironmentConcurrency returns true if the concurrency name is the one of an environment.
+func IsEnvironmentConcurrency(name string) bool {
+	return strings.HasPrefix(name, EnvironmentConcurrencyPrefix)
+}
+
+// ProjectEnvironmentDeployment is a run job that deployed on an environment.
+type ProjectEnvironmentDeployment struct {
+	ID               string    `json:"id" db:"id" cli:"-"`
+	ProjectKey       string    `json:"project_key" db:"project_key" cli:"-"`
+	Environment      string    `json:"environment" db:"environment" cli:"environment"`
+	WorkflowRunID    string    `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowRunJobID string    `json:"workflow_run_job_id" db:"workflow_run_job_id" cli:"-"`
+	VCSServer        string    `json:"vcs_server" db:"vcs_server" cli:"-"`
+	Repository       string    `json:"repository" db:"repository" cli:"-"`
+	WorkflowName     string    `json:"workflow_name" db:"workflow_name" cli:"workflow_name"`
+	RunNumber        int64     `json:"run_number" db:"run_number" cli:"run_number"`
+	RunAttempt       int64     `json:"run_attempt" db:"run_attempt" cli:"run_attempt"`
+	JobID            string    `json:"job_id" db:"job_id" cli:"job"`
+	Status           string    `json:"status" db:"status" cli:"status"`
+	Version          string    `json:"version" db:"version" cli:"version"`
+	GitRef           string    `json:"git_ref" db:"git_ref" cli:"git_ref"`
+	GitSha           string    `json:"git_sha" db:"git_sha" cli:"git_sha"`
+	Username         string    `json:"username" db:"username" cli:"username"`
+	Created          time.Time `json:"created" db:"created" cli:"created"`
+	LastModified     time.Time `json:"last_modified" db:"last_modified" cli:"last_modified"`
+}
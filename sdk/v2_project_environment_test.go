@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectEnvironmentIsRefAllowed(t *testing.T) {
+	env := ProjectEnvironment{Name: "production"}
+	allowed, err := env.IsRefAllowed("refs/heads/feat/foo")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	env.AllowedRefs = []string{"refs/heads/master", "refs/tags/v*"}
+	for ref, expected := range map[string]bool{
+		"refs/heads/master":   true,
+		"refs/tags/v1.2.0":    true,
+		"refs/heads/feat/foo": false,
+		"refs/tags/1.2.0":     false,
+	} {
+		allowed, err := env.IsRefAllowed(ref)
+		require.NoError(t, err)
+		require.Equal(t, expected, allowed, ref)
+	}
+}
+
+func TestProjectEnvironmentCheck(t *testing.T) {
+	require.NoError(t, (&ProjectEnvironment{Name: "production"}).Check())
+	require.Error(t, (&ProjectEnvironment{Name: "prod uction"}).Check())
+	require.Error(t, (&ProjectEnvironment{Name: "production", WaitTimer: -1}).Check())
+}
+
+func TestProjectEnvironmentConcurrency(t *testing.T) {
+	c := ProjectEnvironment{Name: "production"}.Concurrency()
+	require.Equal(t, "environment:production", c.Name)
+	require.Equal(t, V2RunConcurrencyScopeProject, c.Scope)
+	require.Equal(t, int64(1), c.Pool)
+	require.True(t, IsEnvironmentConcurrency(c.Name))
+	require.False(t, IsEnvironmentConcurrency("production"))
+}
@@ -0,0 +1,143 @@
+package sdk
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+
+	"github.com/ovh/cds/sdk/glob"
+)
+
+// ProjectFreeze is a change freeze period during which matching jobs are held by the run engine.
+// The period is either a date range (Start and End) or recurring windows (Cron and Duration).
+type ProjectFreeze struct {
+	ID           string      `json:"id" db:"id" cli:"-"`
+	ProjectKey   string      `json:"project_key" db:"project_key" cli:"-"`
+	Name         string      `json:"name" db:"name" cli:"name"`
+	Description  string      `json:"description" db:"description" cli:"description"`
+	Environments StringSlice `json:"environments,omitempty" db:"environments" cli:"environments"`
+	Workflows    StringSlice `json:"workflows,omitempty" db:"workflows" cli:"workflows"`
+	Start        *time.Time  `json:"start,omitempty" db:"start_date" cli:"start"`
+	End          *time.Time  `json:"end,omitempty" db:"end_date" cli:"end"`
+	Cron         string      `json:"cron,omitempty" db:"cron" cli:"cron"`
+	Duration     int64       `json:"duration,omitempty" db:"duration" cli:"duration"`
+	Timezone     string      `json:"timezone,omitempty" db:"timezone" cli:"timezone"`
+	LastModified time.Time   `json:"last_modified" db:"last_modified" cli:"last_modified"`
+}
+
+func (f *ProjectFreeze) Check() error {
+	namePattern, err := regexp.Compile(EntityNamePattern)
+	if err != nil {
+		return WrapError(err, "unable to compile regexp %s", namePattern)
+	}
+	if !namePattern.MatchString(f.Name) {
+		return NewErrorFrom(ErrInvalidData, "name %s doesn't match %s", f.Name, EntityNamePattern)
+	}
+	if _, err := time.LoadLocation(f.Timezone); err != nil {
+		return NewErrorFrom(ErrInvalidData, "invalid timezone %s", f.Timezone)
+	}
+	for _, w := range f.Workflows {
+		if _, err := glob.New(w).MatchString(""); err != nil {
+			return NewErrorFrom(ErrInvalidData, "invalid workflow pattern %s: %v", w, err)
+		}
+	}
+	switch {
+	case f.Cron != "" && (f.Start != nil || f.End != nil):
+		return NewErrorFrom(ErrInvalidData, "a freeze is defined either by a date range or by a cron expression")
+	case f.Cron != "":
+		if _, err := cronexpr.Parse(f.Cron); err != nil {
+			return NewErrorFrom(ErrInvalidData, "unable to parse cron expression %s: %v", f.Cron, err)
+		}
+		if f.Duration <= 0 {
+			return NewErrorFrom(ErrInvalidData, "duration of a recurring freeze must be a positive number of minutes")
+		}
+	case f.Start != nil && f.End != nil:
+		if !f.End.After(*f.Start) {
+			return NewErrorFrom(ErrInvalidData, "freeze end must be after its start")
+		}
+	default:
+		return NewErrorFrom(ErrInvalidData, "missing freeze date range or cron expression")
+	}
+	return nil
+}
+
+// ActiveUntil returns the end of the freeze window that contains the given time, or nil if the freeze is not active.
+func (f ProjectFreeze) ActiveUntil(now time.Time) *time.Time {
+	if f.Cron == "" {
+		if f.Start == nil || f.End == nil || now.Before(*f.Start) || !now.Before(*f.End) {
+			return nil
+		}
+		end := *f.End
+		return &end
+	}
+
+	expr, err := cronexpr.Parse(f.Cron)
+	if err != nil {
+		return nil
+	}
+	loc, err := time.LoadLocation(f.Timezone)
+	if err != nil {
+		return nil
+	}
+	duration := time.Duration(f.Duration) * time.Minute
+	t := now.In(loc)
+	start := expr.Next(t.Add(-duration))
+	if start.IsZero() || start.After(t) {
+		return nil
+	}
+	// Overlapping windows extend the freeze, up to a limit for expressions that never end
+	end := start.Add(duration)
+	for i, next := 0, expr.Next(start); i < 1000 && !next.IsZero() && !next.After(end); i, next = i+1, expr.Next(next) {
+		end = next.Add(duration)
+	}
+	return &end
+}
+
+// HoldsAllJobs returns true if the freeze has no environment nor workflow filter, all the jobs of the project are held while it is active.
+func (f ProjectFreeze) HoldsAllJobs() bool {
+	return len(f.Environments) == 0 && len(f.Workflows) == 0
+}
+
+// Matches returns true if the freeze applies to a job of the given workflow deploying on the given environment.
+// A freeze without environments applies to all the jobs, a freeze without workflows to all the workflows.
+func (f ProjectFreeze) Matches(workflowName, environment string) (bool, error) {
+	if len(f.Environments) > 0 && !IsInArray(environment, f.Environments) {
+		return false, nil
+	}
+	if len(f.Workflows) == 0 {
+		return true, nil
+	}
+	for _, w := range f.Workflows {
+		result, err := glob.New(w).MatchString(workflowName)
+		if err != nil {
+			return false, NewErrorFrom(ErrInvalidData, "unable to check workflow with pattern %s: %v", w, err)
+		}
+		if result != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ProjectFreezeOverride is the audit record of a break-glass override of a freeze for a workflow run.
+type ProjectFreezeOverride struct {
+	ID            string    `json:"id" db:"id" cli:"-"`
+	ProjectKey    string    `json:"project_key" db:"project_key" cli:"-"`
+	Freeze        string    `json:"freeze" db:"freeze" cli:"freeze"`
+	WorkflowRunID string    `json:"workflow_run_id" db:"workflow_run_id" cli:"workflow_run_id"`
+	VCSServer     string    `json:"vcs_server" db:"vcs_server" cli:"-"`
+	Repository    string    `json:"repository" db:"repository" cli:"-"`
+	WorkflowName  string    `json:"workflow_name" db:"workflow_name" cli:"workflow_name"`
+	RunNumber     int64     `json:"run_number" db:"run_number" cli:"run_number"`
+	UserID        string    `json:"user_id" db:"user_id" cli:"-"`
+	Username      string    `json:"username" db:"username" cli:"username"`
+	Reason        string    `json:"reason" db:"reason" cli:"reason"`
+	Created       time.Time `json:"created" db:"created" cli:"created"`
+}
+
+// ProjectFreezeOverrideRequest is the body of a break-glass override request.
+type ProjectFreezeOverrideRequest struct {
+	WorkflowRunID string `json:"workflow_run_id"`
+	Reason        string `json:"reason"`
+}
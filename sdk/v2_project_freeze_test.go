@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectFreezeCheck(t *testing.T) {
+	start := time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	require.NoError(t, (&ProjectFreeze{Name: "christmas", Start: &start, End: &end}).Check())
+	require.NoError(t, (&ProjectFreeze{Name: "weekend", Cron: "0 18 * * 5", Duration: 60, Timezone: "Europe/Paris"}).Check())
+
+	require.Error(t, (&ProjectFreeze{Name: "christmas"}).Check())
+	require.Error(t, (&ProjectFreeze{Name: "christmas", Start: &end, End: &start}).Check())
+	require.Error(t, (&ProjectFreeze{Name: "weekend", Cron: "0 18 * * 5"}).Check())
+	require.Error(t, (&ProjectFreeze{Name: "weekend", Cron: "not a cron", Duration: 60}).Check())
+	require.Error(t, (&ProjectFreeze{Name: "weekend", Cron: "0 18 * * 5", Duration: 60, Timezone: "Nowhere/Unknown"}).Check())
+	require.Error(t, (&ProjectFreeze{Name: "both", Cron: "0 18 * * 5", Duration: 60, Start: &start, End: &end}).Check())
+}
+
+func TestProjectFreezeActiveUntil(t *testing.T) {
+	start := time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	dateRange := ProjectFreeze{Start: &start, End: &end}
+	require.Nil(t, dateRange.ActiveUntil(start.Add(-time.Minute)))
+	require.Equal(t, end, *dateRange.ActiveUntil(start))
+	require.Nil(t, dateRange.ActiveUntil(end))
+
+	// Every friday from 18:00 to 20:00
+	recurring := ProjectFreeze{Cron: "0 18 * * 5", Duration: 120}
+	friday := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	require.Nil(t, recurring.ActiveUntil(friday.Add(-time.Minute)))
+	require.Equal(t, friday.Add(2*time.Hour), *recurring.ActiveUntil(friday))
+	require.Equal(t, friday.Add(2*time.Hour), *recurring.ActiveUntil(friday.Add(119 * time.Minute)))
+	require.Nil(t, recurring.ActiveUntil(friday.Add(2*time.Hour)))
+
+	// Overlapping windows: every hour for 90 minutes
+	overlapping := ProjectFreeze{Cron: "0 * * * *", Duration: 90}
+	until := overlapping.ActiveUntil(friday.Add(10 * time.Minute))
+	require.NotNil(t, until)
+	require.True(t, until.After(friday.Add(24*time.Hour)))
+}
+
+func TestProjectFreezeMatches(t *testing.T) {
+	f := ProjectFreeze{}
+	require.True(t, f.HoldsAllJobs())
+	match, err := f.Matches("deploy", "")
+	require.NoError(t, err)
+	require.True(t, match)
+
+	f.Environments = []string{"production"}
+	require.False(t, f.HoldsAllJobs())
+	match, err = f.Matches("deploy", "staging")
+	require.NoError(t, err)
+	require.False(t, match)
+	match, err = f.Matches("deploy", "production")
+	require.NoError(t, err)
+	require.True(t, match)
+
+	f.Workflows = []string{"deploy-*"}
+	match, err = f.Matches("build", "production")
+	require.NoError(t, err)
+	require.False(t, match)
+	match, err = f.Matches("deploy-api", "production")
+	require.NoError(t, err)
+	require.True(t, match)
+}
@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"regexp"
+	"time"
+)
+
+// UsageMonthLayout is the layout used to identify an accounting month
+const UsageMonthLayout = "2006-01"
+
+var usageMonthPattern = regexp.MustCompile(`^[0-9]{4}-(0[1-9]|1[0-2])$`)
+
+// IsValidUsageMonth checks that the given month matches YYYY-MM
+func IsValidUsageMonth(month string) bool {
+	return usageMonthPattern.MatchString(month)
+}
+
+// V2WorkflowRunJobUsage stores the compute time consumed by a run job
+type V2WorkflowRunJobUsage struct {
+	RunJobID      string     `json:"run_job_id" db:"run_job_id"`
+	WorkflowRunID string     `json:"workflow_run_id" db:"workflow_run_id"`
+	ProjectKey    string     `json:"project_key" db:"project_key"`
+	VCSServer     string     `json:"vcs_server" db:"vcs_server"`
+	Repository    string     `json:"repository" db:"repository"`
+	WorkflowName  string     `json:"workflow_name" db:"workflow_name"`
+	JobID         string     `json:"job_id" db:"job_id"`
+	Region        string     `json:"region" db:"region"`
+	HatcheryName  string     `json:"hatchery_name" db:"hatchery_name"`
+	WorkerModel   string     `json:"worker_model" db:"worker_model"`
+	ModelType     string     `json:"model_type" db:"model_type"`
+	Flavor        string     `json:"flavor" db:"flavor"`
+	Started       time.Time  `json:"started" db:"started"`
+	Ended         *time.Time `json:"ended,omitempty" db:"ended"`
+}
+
+func NewV2WorkflowRunJobUsage(rj V2WorkflowRunJob) V2WorkflowRunJobUsage {
+	u := V2WorkflowRunJobUsage{
+		RunJobID:      rj.ID,
+		WorkflowRunID: rj.WorkflowRunID,
+		ProjectKey:    rj.ProjectKey,
+		VCSServer:     rj.VCSServer,
+		Repository:    rj.Repository,
+		WorkflowName:  rj.WorkflowName,
+		JobID:         rj.JobID,
+		Region:        rj.Region,
+		HatcheryName:  rj.HatcheryName,
+		WorkerModel:   rj.Job.RunsOn.Model,
+		ModelType:     rj.ModelType,
+		Flavor:        rj.Job.RunsOn.Flavor,
+		Ended:         rj.Ended,
+	}
+	if rj.Started != nil {
+		u.Started = *rj.Started
+	}
+	return u
+}
+
+// V2ProjectUsage is the aggregated compute usage of a project for a month
+type V2ProjectUsage struct {
+	Month   string  `json:"month" db:"month" cli:"month,key"`
+	Jobs    int64   `json:"jobs" db:"jobs" cli:"jobs"`
+	Minutes float64 `json:"minutes" db:"minutes" cli:"minutes"`
+}
+
+// V2ProjectUsageDetail is the compute usage of a month split by workflow, worker model and hatchery
+type V2ProjectUsageDetail struct {
+	Month        string  `json:"month" db:"month" cli:"month"`
+	VCSServer    string  `json:"vcs_server" db:"vcs_server" cli:"vcs_server"`
+	Repository   string  `json:"repository" db:"repository" cli:"repository"`
+	WorkflowName string  `json:"workflow_name" db:"workflow_name" cli:"workflow_name"`
+	WorkerModel  string  `json:"worker_model" db:"worker_model" cli:"worker_model"`
+	Flavor       string  `json:"flavor" db:"flavor" cli:"flavor"`
+	Region       string  `json:"region" db:"region" cli:"region"`
+	HatcheryName string  `json:"hatchery_name" db:"hatchery_name" cli:"hatchery_name"`
+	Jobs         int64   `json:"jobs" db:"jobs" cli:"jobs"`
+	Minutes      float64 `json:"minutes" db:"minutes" cli:"minutes"`
+}
+
+// ProjectQuota limits the compute resources that a project can consume. A zero value means unlimited.
+type ProjectQuota struct {
+	ProjectKey        string    `json:"project_key" db:"project_key" cli:"project_key"`
+	MaxConcurrentJobs int64     `json:"max_concurrent_jobs" db:"max_concurrent_jobs" cli:"max_concurrent_jobs"`
+	MaxMonthlyMinutes int64     `json:"max_monthly_minutes" db:"max_monthly_minutes" cli:"max_monthly_minutes"`
+	LastModified      time.Time `json:"last_modified" db:"last_modified" cli:"last_modified"`
+}
+
+func (q ProjectQuota) Check() error {
+	if q.MaxConcurrentJobs < 0 {
+		return NewErrorFrom(ErrInvalidData, "max_concurrent_jobs must be positive")
+	}
+	if q.MaxMonthlyMinutes < 0 {
+		return NewErrorFrom(ErrInvalidData, "max_monthly_minutes must be positive")
+	}
+	return nil
+}
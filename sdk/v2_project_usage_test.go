@@ -0,0 +1,22 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidUsageMonth(t *testing.T) {
+	require.True(t, IsValidUsageMonth("2025-01"))
+	require.True(t, IsValidUsageMonth("2025-12"))
+	require.False(t, IsValidUsageMonth("2025-13"))
+	require.False(t, IsValidUsageMonth("2025-1"))
+	require.False(t, IsValidUsageMonth("2025-01-01"))
+}
+
+func TestProjectQuotaCheck(t *testing.T) {
+	require.NoError(t, ProjectQuota{}.Check())
+	require.NoError(t, ProjectQuota{MaxConcurrentJobs: 10, MaxMonthlyMinutes: 1000}.Check())
+	require.Error(t, ProjectQuota{MaxConcurrentJobs: -1}.Check())
+	require.Error(t, ProjectQuota{MaxMonthlyMinutes: -1}.Check())
+}
@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// V2WorkerAgentHeartbeatTimeout is the delay after which an agent without heartbeat is considered as unhealthy
+const V2WorkerAgentHeartbeatTimeout = 2 * time.Minute
+
+const (
+	V2WorkerAgentHealthHealthy   = "Healthy"
+	V2WorkerAgentHealthUnhealthy = "Unhealthy"
+)
+
+// V2WorkerAgentConfig is advertised by a worker agent when it signs in, it is stored as the hatchery config.
+type V2WorkerAgentConfig struct {
+	Labels       []string `json:"labels"`
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// ServiceConfig returns the agent configuration as a hatchery config.
+func (c V2WorkerAgentConfig) ServiceConfig() (ServiceConfig, error) {
+	btes, err := json.Marshal(c)
+	if err != nil {
+		return nil, WithStack(err)
+	}
+	var cfg ServiceConfig
+	if err := JSONUnmarshal(btes, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// NewV2WorkerAgentConfig reads the agent configuration from a hatchery config.
+func NewV2WorkerAgentConfig(cfg ServiceConfig) (V2WorkerAgentConfig, error) {
+	var c V2WorkerAgentConfig
+	btes, err := json.Marshal(cfg)
+	if err != nil {
+		return c, WithStack(err)
+	}
+	if err := JSONUnmarshal(btes, &c); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// CanRunJob checks that the agent owns all the labels requested by the job.
+// The projects allowed for the agent are checked on its hatchery with Hatchery.CanRunProject.
+func (c V2WorkerAgentConfig) CanRunJob(rj V2WorkflowRunJob) bool {
+	if rj.ModelType != WorkerModelTypeAgent {
+		return false
+	}
+	for _, l := range rj.Job.RunsOn.Labels {
+		if !IsInArray(l, c.Labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// V2WorkerAgent represents a persistent worker registered without hatchery.
+type V2WorkerAgent struct {
+	Name          string    `json:"name" cli:"name,key"`
+	Region        string    `json:"region,omitempty" cli:"region"`
+	ProjectKeys   []string  `json:"project_keys,omitempty" cli:"projects"`
+	Labels        []string  `json:"labels" cli:"labels"`
+	Capabilities  []string  `json:"capabilities,omitempty" cli:"capabilities"`
+	LastHeartbeat time.Time `json:"last_heartbeat" cli:"last_heartbeat"`
+	Health        string    `json:"health" cli:"health"`
+	Status        string    `json:"status" cli:"status"` // Idle or status of the current worker
+	WorkerName    string    `json:"worker_name,omitempty" cli:"worker"`
+	JobRunID      string    `json:"run_job_id,omitempty" cli:"-"`
+}
+
+// NewV2WorkerAgent computes the agent information from its hatchery and its current workers.
+func NewV2WorkerAgent(h Hatchery, region string, workers []V2Worker, now time.Time) (V2WorkerAgent, error) {
+	cfg, err := NewV2WorkerAgentConfig(h.Config)
+	if err != nil {
+		return V2WorkerAgent{}, err
+	}
+	a := V2WorkerAgent{
+		Name:          h.Name,
+		Region:        region,
+		ProjectKeys:   h.ProjectKeys,
+		Labels:        cfg.Labels,
+		Capabilities:  cfg.Capabilities,
+		LastHeartbeat: h.LastHeartbeat,
+		Health:        V2WorkerAgentHealthHealthy,
+		Status:        "Idle",
+	}
+	if now.Sub(h.LastHeartbeat) > V2WorkerAgentHeartbeatTimeout {
+		a.Health = V2WorkerAgentHealthUnhealthy
+	}
+	for _, w := range workers {
+		if w.Status == StatusDisabled {
+			continue
+		}
+		a.Status = w.Status
+		a.WorkerName = w.Name
+		a.JobRunID = w.JobRunID
+	}
+	return a, nil
+}
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2WorkerAgentConfigCanRunJob(t *testing.T) {
+	cfg := V2WorkerAgentConfig{Labels: []string{"macos", "gpu", "xcode-15"}}
+
+	rj := V2WorkflowRunJob{
+		ProjectKey: "PROJ",
+		ModelType:  WorkerModelTypeAgent,
+		Job:        V2Job{RunsOn: V2JobRunsOn{Labels: []string{"macos", "gpu"}}},
+	}
+	require.True(t, cfg.CanRunJob(rj))
+
+	rj.Job.RunsOn.Labels = []string{"macos", "linux"}
+	require.False(t, cfg.CanRunJob(rj))
+
+	rj.Job.RunsOn.Labels = []string{"macos"}
+	rj.ModelType = WorkerModelTypeDocker
+	require.False(t, cfg.CanRunJob(rj))
+}
+
+func TestHatcheryCanRunProject(t *testing.T) {
+	h := Hatchery{Name: "lab-gpu-1"}
+	require.True(t, h.CanRunProject("PROJ"))
+
+	h.ProjectKeys = StringSlice{"OTHER"}
+	require.False(t, h.CanRunProject("PROJ"))
+
+	h.ProjectKeys = append(h.ProjectKeys, "PROJ")
+	require.True(t, h.CanRunProject("PROJ"))
+}
+
+func TestNewV2WorkerAgent(t *testing.T) {
+	cfg, err := V2WorkerAgentConfig{Labels: []string{"gpu"}, Capabilities: []string{"cuda"}}.ServiceConfig()
+	require.NoError(t, err)
+
+	now := time.Now()
+	h := Hatchery{Name: "lab-gpu-1", ModelType: WorkerModelTypeAgent, Config: cfg, LastHeartbeat: now.Add(-time.Minute), ProjectKeys: StringSlice{"PROJ"}}
+
+	a, err := NewV2WorkerAgent(h, "build", nil, now)
+	require.NoError(t, err)
+	require.Equal(t, "lab-gpu-1", a.Name)
+	require.Equal(t, "build", a.Region)
+	require.Equal(t, []string{"PROJ"}, a.ProjectKeys)
+	require.Equal(t, []string{"gpu"}, a.Labels)
+	require.Equal(t, []string{"cuda"}, a.Capabilities)
+	require.Equal(t, V2WorkerAgentHealthHealthy, a.Health)
+	require.Equal(t, "Idle", a.Status)
+
+	h.LastHeartbeat = now.Add(-time.Hour)
+	a, err = NewV2WorkerAgent(h, "build", []V2Worker{{Name: "lab-gpu-1-worker", Status: StatusBuilding, JobRunID: "123"}}, now)
+	require.NoError(t, err)
+	require.Equal(t, V2WorkerAgentHealthUnhealthy, a.Health)
+	require.Equal(t, StatusBuilding, a.Status)
+	require.Equal(t, "lab-gpu-1-worker", a.WorkerName)
+}
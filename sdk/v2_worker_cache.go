@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"time"
+)
+
+// V2WorkerCache is a dependency cache saved by a v2 job in the CDN, scoped to the git ref of the job.
+type V2WorkerCache struct {
+	Key        string    `json:"key" cli:"key,key"`
+	Ref        string    `json:"ref,omitempty" cli:"ref"`
+	Size       int64     `json:"size" cli:"size"`
+	Created    time.Time `json:"created" cli:"created"`
+	LastAccess time.Time `json:"last_access" cli:"last_access"`
+	ExpireAt   time.Time `json:"expire_at" cli:"expire_at"`
+}
+
+// NewV2WorkerCache returns the cache stored by the given CDN item.
+func NewV2WorkerCache(it CDNItem) (V2WorkerCache, bool) {
+	apiRef, is := it.GetCDNWorkerCacheApiRef()
+	if !is {
+		return V2WorkerCache{}, false
+	}
+	c := V2WorkerCache{
+		Key:        apiRef.CacheTag,
+		Ref:        apiRef.Ref,
+		Size:       it.Size,
+		Created:    it.Created,
+		LastAccess: it.Created,
+		ExpireAt:   apiRef.ExpireAt,
+	}
+	if it.LastAccess != nil {
+		c.LastAccess = *it.LastAccess
+	}
+	return c, true
+}
+
+// WorkerCacheRefs returns the ordered list of git refs searched to restore a cache:
+// the ref of the job, the target ref of the pull request, the default branch and
+// finally the caches saved without ref.
+func WorkerCacheRefs(git GitContext) []string {
+	var refs []string
+	for _, r := range []string{git.Ref, git.PullRequestToRef, git.DefaultBranch} {
+		if r != "" && !IsInArray(r, refs) {
+			refs = append(refs, r)
+		}
+	}
+	return append(refs, "")
+}
@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerCacheRefs(t *testing.T) {
+	require.Equal(t, []string{"refs/heads/feat", "refs/heads/main", ""}, WorkerCacheRefs(GitContext{Ref: "refs/heads/feat", DefaultBranch: "refs/heads/main"}))
+	require.Equal(t, []string{"refs/heads/main", ""}, WorkerCacheRefs(GitContext{Ref: "refs/heads/main", DefaultBranch: "refs/heads/main"}))
+	require.Equal(t, []string{"refs/pull/1", "refs/heads/dev", "refs/heads/main", ""}, WorkerCacheRefs(GitContext{Ref: "refs/pull/1", PullRequestToRef: "refs/heads/dev", DefaultBranch: "refs/heads/main"}))
+	require.Equal(t, []string{""}, WorkerCacheRefs(GitContext{}))
+}
+
+func TestNewV2WorkerCache(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	it := CDNItem{
+		Type:    CDNTypeItemWorkerCacheV2,
+		Size:    42,
+		Created: created,
+		APIRef:  &CDNWorkerCacheAPIRef{ProjectKey: "KEY", CacheTag: "go-mod-abc", Ref: "refs/heads/main"},
+	}
+	c, is := NewV2WorkerCache(it)
+	require.True(t, is)
+	require.Equal(t, "go-mod-abc", c.Key)
+	require.Equal(t, "refs/heads/main", c.Ref)
+	require.Equal(t, int64(42), c.Size)
+	require.Equal(t, created, c.LastAccess)
+
+	access := time.Now()
+	it.LastAccess = &access
+	c, _ = NewV2WorkerCache(it)
+	require.Equal(t, access, c.LastAccess)
+}
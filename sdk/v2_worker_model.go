@@ -10,6 +10,8 @@ const (
 	WorkerModelTypeOpenstack = "openstack"
 	WorkerModelTypeDocker    = "docker"
 	WorkerModelTypeVSphere   = "vsphere"
+	// WorkerModelTypeAgent is the type of jobs run by persistent worker agents, without worker model
+	WorkerModelTypeAgent = "agent"
 )
 
 type V2WorkerModel struct {
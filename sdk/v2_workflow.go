@@ -312,7 +312,10 @@ type V2Job struct {
 	From            string                  `json:"from,omitempty" jsonschema:"oneof=from" jsonschema_description:"Job template name used to create the job"`
 	Parameters      map[string]string       `json:"parameters,omitempty" jsonschema:"oneof=from" jsonschema_description:"Job template parameters"`
 	Concurrency     string                  `json:"concurrency,omitempty" jsonschema_description:"Concurrency rule to apply to the job"`
+	Environment     string                  `json:"environment,omitempty" jsonschema:"example=production" jsonschema_description:"Project environment the job deploys to. Its protection rules and variable set apply to the job"`
 	Retry           int64                   `json:"retry,omitempty" jsonschema_description:"The job retry in case of error"`
+	Debug           string                  `json:"debug,omitempty" jsonschema:"enum=always,enum=failure" jsonschema_description:"Keep the worker alive at the end of the job to open debug sessions: always or on failure only"`
+	DebugTimeout    int64                   `json:"debug-timeout,omitempty" jsonschema_description:"Time in minutes the worker waits for debug sessions, 30 by default and 120 at most"`
 }
 
 func (j V2Job) Copy() V2Job {
@@ -358,9 +361,10 @@ func (j V2Job) Copy() V2Job {
 }
 
 type V2JobRunsOn struct {
-	Model  string `json:"model" jsonschema_description:"Worker model name to use for the job"`
-	Memory string `json:"memory" jsonschema_description:"Amount of memory to use for the job"`
-	Flavor string `json:"flavor" jsonschema_description:"Worker flavor to use for the job"`
+	Model  string   `json:"model" jsonschema_description:"Worker model name to use for the job"`
+	Memory string   `json:"memory" jsonschema_description:"Amount of memory to use for the job"`
+	Flavor string   `json:"flavor" jsonschema_description:"Worker flavor to use for the job"`
+	Labels []string `json:"labels,omitempty" jsonschema_description:"Labels of the worker agent that must run the job"`
 }
 
 type V2JobGate struct {
@@ -416,7 +420,7 @@ func (job V2Job) MarshalJSON() ([]byte, error) {
 	type Alias V2Job // prevent recursion
 	jobAlias := Alias(job)
 
-	if jobAlias.RunsOn.Memory == "" && jobAlias.RunsOn.Flavor == "" {
+	if jobAlias.RunsOn.Memory == "" && jobAlias.RunsOn.Flavor == "" && len(jobAlias.RunsOn.Labels) == 0 {
 		runOnsBts, err := json.Marshal(jobAlias.RunsOn.Model)
 		if err != nil {
 			return nil, WrapError(err, "unable to marshal RunsOn field")
@@ -554,7 +558,41 @@ func (w *V2WorkflowHookData) Scan(src interface{}) error {
 }
 
 type V2JobStrategy struct {
-	Matrix map[string]interface{} `json:"matrix" jsonschema_description:"Matrix values for the job"`
+	Matrix map[string]interface{} `json:"matrix" jsonschema_description:"Matrix values for the job, or an expression returning the whole matrix"`
+	// MatrixExpression is set instead of Matrix when the whole matrix is given by an expression: ${{ fromJSON(jobs.detect.outputs.matrix) }}
+	MatrixExpression string `json:"-"`
+}
+
+// HasMatrix returns true if the job defines a matrix, given as values or as an expression.
+func (s *V2JobStrategy) HasMatrix() bool {
+	return s != nil && (len(s.Matrix) > 0 || s.MatrixExpression != "")
+}
+
+func (s V2JobStrategy) MarshalJSON() ([]byte, error) {
+	if s.MatrixExpression != "" {
+		return json.Marshal(map[string]interface{}{"matrix": s.MatrixExpression})
+	}
+	type strategy V2JobStrategy
+	return json.Marshal(strategy(s))
+}
+
+func (s *V2JobStrategy) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Matrix json.RawMessage `json:"matrix"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = V2JobStrategy{}
+	if len(raw.Matrix) == 0 || string(raw.Matrix) == "null" {
+		return nil
+	}
+	var expression string
+	if err := json.Unmarshal(raw.Matrix, &expression); err == nil {
+		s.MatrixExpression = expression
+		return nil
+	}
+	return json.Unmarshal(raw.Matrix, &s.Matrix)
 }
 
 type V2JobConcurrency struct{}
@@ -587,6 +625,12 @@ func (w V2Workflow) Lint() []error {
 		if j.Retry < 0 || j.Retry > 2 {
 			errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s job %s: retry must be 0, 1 or 2", w.Name, j.Name))
 		}
+		if err := j.CheckDebug(); err != nil {
+			errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s job %s: %v", w.Name, j.Name, err))
+		}
+		if j.Environment != "" && j.Concurrency != "" {
+			errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s job %s: concurrency is not allowed on a job deploying on an environment", w.Name, j.Name))
+		}
 	}
 
 	if err := w.CheckSemver(); err != nil {
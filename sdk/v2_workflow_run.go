@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -37,6 +38,7 @@ type V2WorkflowRunHookRequest struct {
 	CommitMessage      string                 `json:"commit_message,omitempty"`
 	CommitAuthor       string                 `json:"commit_author,omitempty"`
 	CommitAuthorEmail  string                 `json:"commit_author_email,omitempty"`
+	CommitDate         string                 `json:"commit_date,omitempty"`
 	Payload            map[string]interface{} `json:"payload"`
 	HookType           string                 `json:"hook_type"`
 	EntityUpdated      string                 `json:"entity_updated"`
@@ -160,7 +162,7 @@ type WorkflowRunJobsContext struct {
 	Jobs         JobsResultContext        `json:"jobs" jsonschema_description:"Status and outputs of all jobs in the workflow run"`
 	Needs        NeedsContext             `json:"needs" jsonschema_description:"Status and outputs of jobs that this job depends on (specified in needs)"`
 	Steps        StepsContext             `json:"steps" jsonschema_description:"Status and outputs of previous steps in the current job"`
-	Matrix       map[string]string        `json:"matrix" jsonschema:"example=os" jsonschema_description:"Matrix values for the current job instance"`
+	Matrix       JobMatrix                `json:"matrix" jsonschema:"example=os" jsonschema_description:"Matrix values for the current job instance"`
 	Integrations *JobIntegrationsContexts `json:"integrations,omitempty" jsonschema_description:"Integration configurations (artifact_manager, deployment)"`
 	Gate         map[string]interface{}   `json:"gate" jsonschema:"example=approved" jsonschema_description:"Gate input parameters for manual approval"`
 	Vars         map[string]interface{}   `json:"vars" jsonschema:"example=my-var" jsonschema_description:"Variables defined in the workflow"`
@@ -207,11 +209,23 @@ func (w *V2WorkflowRunData) Scan(src interface{}) error {
 }
 
 type V2WorkflowRunJobEvent struct {
-	UserID     string                 `json:"user_id"`
-	Username   string                 `json:"username"`
-	JobID      string                 `json:"job_id"`
-	Inputs     map[string]interface{} `json:"inputs"`
-	RunAttempt int64                  `json:"run_attempt"`
+	UserID     string                     `json:"user_id"`
+	Username   string                     `json:"username"`
+	JobID      string                     `json:"job_id"`
+	Inputs     map[string]interface{}     `json:"inputs"`
+	RunAttempt int64                      `json:"run_attempt"`
+	Overrides  *V2WorkflowRunJobOverrides `json:"overrides,omitempty"`
+}
+
+// GetRunJobEvent returns the last event given for the job on the current run attempt.
+func (r V2WorkflowRun) GetRunJobEvent(jobID string) *V2WorkflowRunJobEvent {
+	var event *V2WorkflowRunJobEvent
+	for i := range r.RunJobEvent {
+		if r.RunJobEvent[i].RunAttempt == r.RunAttempt && r.RunJobEvent[i].JobID == jobID {
+			event = &r.RunJobEvent[i]
+		}
+	}
+	return event
 }
 
 type V2WorkflowRunJobEvents []V2WorkflowRunJobEvent
@@ -244,6 +258,7 @@ type V2WorkflowRunEvent struct {
 	CommitMessage      string                 `json:"commit_message"`
 	CommitAuthor       string                 `json:"commit_author,omitempty"`
 	CommitAuthorEmail  string                 `json:"commit_author_email,omitempty"`
+	CommitDate         string                 `json:"commit_date,omitempty"`
 	SemverCurrent      string                 `json:"semver_current"`
 	SemverNext         string                 `json:"semver_next"`
 	ChangeSets         []string               `json:"changesets"`
@@ -446,7 +461,48 @@ func (gi *GateInputs) Scan(src interface{}) error {
 	return WrapError(yaml.Unmarshal([]byte(source), gi), "cannot unmarshal GateInputs")
 }
 
-type JobMatrix map[string]string
+// JobMatrix is a permutation of a job matrix. Values are strings, or structured values (objects, lists, numbers) given by the matrix.
+type JobMatrix map[string]interface{}
+
+// String returns a readable name of the permutation: os=linux, service=api
+func (jm JobMatrix) String() string {
+	keys := make([]string, 0, len(jm))
+	for k := range jm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+MatrixValueLabel(jm[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MatrixValueEqual compares two matrix values, whatever the types used to read structured values.
+func MatrixValueEqual(a, b interface{}) bool {
+	btsA, errA := json.Marshal(a)
+	btsB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(btsA) == string(btsB)
+}
+
+// MatrixValueLabel returns a short label for a matrix value: the value itself for a scalar, the name of an object if it has one, its JSON otherwise.
+func MatrixValueLabel(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case map[string]interface{}:
+		if name, ok := x["name"].(string); ok && name != "" {
+			return name
+		}
+	case nil:
+		return ""
+	}
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bts)
+}
 
 func (jm JobMatrix) Value() (driver.Value, error) {
 	m, err := yaml.Marshal(jm)
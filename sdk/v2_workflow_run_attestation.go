@@ -0,0 +1,462 @@
+package sdk
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	InTotoStatementType         = "https://in-toto.io/Statement/v1"
+	InTotoPayloadType           = "application/vnd.in-toto+json"
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v1"
+	SPDXPredicateType           = "https://spdx.dev/Document"
+	CycloneDXPredicateType      = "https://cyclonedx.org/bom"
+
+	V2WorkflowBuildType = "https://github.com/ovh/cds/workflow/v2"
+	V2WorkerBuilderID   = "https://github.com/ovh/cds/worker"
+
+	// AttestationProjectKeyName is the name of the project PGP key used to sign attestations.
+	// Attestations are signed with the instance key when the project does not have such a key.
+	AttestationProjectKeyName = "attestation"
+
+	AttestationSignerProject  = "project"
+	AttestationSignerInstance = "instance"
+
+	AttestationKeyTypePGP = "pgp"
+	AttestationKeyTypeRSA = "rsa"
+)
+
+// InTotoStatement is an in-toto attestation statement (https://github.com/in-toto/attestation/tree/main/spec/v1).
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+type InTotoSubject struct {
+	Name   string            `json:"name" mapstructure:"name"`
+	Digest map[string]string `json:"digest" mapstructure:"digest"`
+}
+
+// SLSAProvenance is the SLSA provenance v1 predicate (https://slsa.dev/spec/v1.0/provenance).
+type SLSAProvenance struct {
+	BuildDefinition SLSABuildDefinition `json:"buildDefinition"`
+	RunDetails      SLSARunDetails      `json:"runDetails"`
+}
+
+type SLSABuildDefinition struct {
+	BuildType            string                   `json:"buildType"`
+	ExternalParameters   map[string]interface{}   `json:"externalParameters"`
+	InternalParameters   map[string]interface{}   `json:"internalParameters,omitempty"`
+	ResolvedDependencies []SLSAResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type SLSAResourceDescriptor struct {
+	URI    string            `json:"uri,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type SLSARunDetails struct {
+	Builder  SLSABuilder       `json:"builder"`
+	Metadata SLSABuildMetadata `json:"metadata"`
+}
+
+type SLSABuilder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type SLSABuildMetadata struct {
+	InvocationID string     `json:"invocationId"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+	FinishedOn   *time.Time `json:"finishedOn,omitempty"`
+}
+
+// DSSEEnvelope is a signed envelope (https://github.com/secure-systems-lab/dsse), payload and signatures are base64 encoded.
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType" mapstructure:"payloadType"`
+	Payload     string          `json:"payload" mapstructure:"payload"`
+	Signatures  []DSSESignature `json:"signatures" mapstructure:"signatures"`
+}
+
+type DSSESignature struct {
+	KeyID string `json:"keyid" mapstructure:"keyid"`
+	Sig   string `json:"sig" mapstructure:"sig"`
+}
+
+// DSSEPreAuthEncoding returns the message that is signed for the given payload.
+func DSSEPreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// NewDSSEEnvelope signs the payload with the given func and returns the envelope.
+func NewDSSEEnvelope(payloadType string, payload []byte, keyID string, sign func([]byte) ([]byte, error)) (*DSSEEnvelope, error) {
+	sig, err := sign(DSSEPreAuthEncoding(payloadType, payload))
+	if err != nil {
+		return nil, err
+	}
+	return &DSSEEnvelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []DSSESignature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}, nil
+}
+
+// DecodePayload returns the decoded payload of the envelope.
+func (e DSSEEnvelope) DecodePayload() ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid envelope payload: %v", err)
+	}
+	return payload, nil
+}
+
+// Verify checks the signature of the envelope made with the given key.
+func (e DSSEEnvelope) Verify(keyID string, verify func(message, sig []byte) error) error {
+	payload, err := e.DecodePayload()
+	if err != nil {
+		return err
+	}
+	for _, s := range e.Signatures {
+		if s.KeyID != keyID {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			return NewErrorFrom(ErrInvalidData, "invalid envelope signature: %v", err)
+		}
+		if err := verify(DSSEPreAuthEncoding(e.PayloadType, payload), sig); err != nil {
+			return NewErrorFrom(ErrInvalidData, "invalid envelope signature: %v", err)
+		}
+		return nil
+	}
+	return NewErrorFrom(ErrInvalidData, "envelope is not signed with key %s", keyID)
+}
+
+// VerifyStatement checks the signature of the envelope made with the given key and returns its statement if one of
+// its subjects has the given digest.
+func (e DSSEEnvelope) VerifyStatement(keyID string, verify func(message, sig []byte) error, algorithm, digest string) (*InTotoStatement, error) {
+	if err := e.Verify(keyID, verify); err != nil {
+		return nil, err
+	}
+	statement, err := e.Statement()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statement.Subject {
+		if s.Digest[algorithm] == digest {
+			return statement, nil
+		}
+	}
+	return nil, NewErrorFrom(ErrInvalidData, "signed statement is not about %s:%s", algorithm, digest)
+}
+
+// Statement returns the in-toto statement of the envelope.
+func (e DSSEEnvelope) Statement() (*InTotoStatement, error) {
+	payload, err := e.DecodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return ParseInTotoStatement(e.PayloadType, payload)
+}
+
+// ParseInTotoStatement reads an in-toto statement and checks that it has at least one subject.
+func ParseInTotoStatement(payloadType string, payload []byte) (*InTotoStatement, error) {
+	if payloadType != InTotoPayloadType {
+		return nil, NewErrorFrom(ErrInvalidData, "unsupported payload type %q", payloadType)
+	}
+	var s InTotoStatement
+	if err := JSONUnmarshal(payload, &s); err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid in-toto statement: %v", err)
+	}
+	if s.Type != InTotoStatementType || len(s.Subject) == 0 {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid in-toto statement")
+	}
+	return &s, nil
+}
+
+// V2AttestationSignRequest asks the API to attest a run result of the job. The statement is built by the API,
+// its subjects are the digests of the run result.
+type V2AttestationSignRequest struct {
+	RunResultID string `json:"run_result_id"`
+	// SBOM is the SPDX or CycloneDX JSON document to attach to the run result, the provenance of the run result is attested when empty.
+	SBOM json.RawMessage `json:"sbom,omitempty"`
+}
+
+type V2AttestationSignResponse struct {
+	Statement InTotoStatement `json:"statement"`
+	Envelope  DSSEEnvelope    `json:"envelope"`
+	Signer    string          `json:"signer"`
+}
+
+// V2AttestationPublicKey is a public key that signs the attestations of a project. Public is an armored PGP key
+// for the project signer, and a PEM encoded RSA key for the instance signer.
+type V2AttestationPublicKey struct {
+	Signer string `json:"signer" cli:"signer"`
+	KeyID  string `json:"key_id" cli:"key_id,key"`
+	Type   string `json:"type" cli:"type"`
+	Public string `json:"public" cli:"-"`
+}
+
+// EncodeRSAAttestationPublicKey returns the PEM encoding of an instance attestation key.
+func EncodeRSAAttestationPublicKey(k *rsa.PublicKey) (string, error) {
+	btes, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		return "", WithStack(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: btes})), nil
+}
+
+// ParseRSAAttestationPublicKey reads a PEM encoded instance attestation key.
+func ParseRSAAttestationPublicKey(s string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid PEM public key")
+	}
+	k, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid public key: %v", err)
+	}
+	rsaKey, ok := k.(*rsa.PublicKey)
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "public key is not a RSA key")
+	}
+	return rsaKey, nil
+}
+
+// VerifyRSAAttestation checks a signature made with an instance attestation key.
+func VerifyRSAAttestation(k *rsa.PublicKey, message, sig []byte) error {
+	sum := sha256.Sum256(message)
+	return rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], sig)
+}
+
+// V2WorkflowRunAttestation is an attestation run result with its verification status.
+type V2WorkflowRunAttestation struct {
+	ProjectKey    string                               `json:"project_key" cli:"-"`
+	WorkflowName  string                               `json:"workflow_name" cli:"workflow"`
+	WorkflowRunID string                               `json:"workflow_run_id" cli:"-"`
+	RunNumber     int64                                `json:"run_number" cli:"run_number"`
+	RunResultID   string                               `json:"run_result_id" cli:"-"`
+	Name          string                               `json:"name" cli:"name"`
+	PredicateType string                               `json:"predicate_type" cli:"predicate_type"`
+	Signer        string                               `json:"signer" cli:"signer"`
+	KeyID         string                               `json:"key_id" cli:"key_id"`
+	Verified      bool                                 `json:"verified" cli:"verified"`
+	Error         string                               `json:"error,omitempty" cli:"error"`
+	Detail        V2WorkflowRunResultAttestationDetail `json:"detail" cli:"-"`
+	Statement     *InTotoStatement                     `json:"statement,omitempty" cli:"-"`
+}
+
+type V2WorkflowRunResultAttestationDetail struct {
+	Name               string          `json:"name" mapstructure:"name"`
+	PredicateType      string          `json:"predicate_type" mapstructure:"predicate_type"`
+	SubjectRunResultID string          `json:"subject_run_result_id" mapstructure:"subject_run_result_id"`
+	Subjects           []InTotoSubject `json:"subjects" mapstructure:"subjects"`
+	Signer             string          `json:"signer" mapstructure:"signer"`
+	Envelope           DSSEEnvelope    `json:"envelope" mapstructure:"envelope"`
+}
+
+// GetLabel implements V2WorkflowRunResultDetailInterface.
+func (v *V2WorkflowRunResultAttestationDetail) GetLabel() string {
+	return fmt.Sprintf("Attestation: %s - Predicate: %s", v.Name, v.PredicateType)
+}
+
+// GetMetadata implements V2WorkflowRunResultDetailInterface.
+func (v *V2WorkflowRunResultAttestationDetail) GetMetadata() map[string]V2WorkflowRunResultDetailMetadata {
+	m := map[string]V2WorkflowRunResultDetailMetadata{
+		"Name":      {Type: V2WorkflowRunResultDetailMetadataTypeText, Value: v.Name},
+		"Predicate": {Type: V2WorkflowRunResultDetailMetadataTypeURL, Value: v.PredicateType},
+		"Signer":    {Type: V2WorkflowRunResultDetailMetadataTypeText, Value: v.Signer},
+	}
+	if len(v.Envelope.Signatures) > 0 {
+		m["Key ID"] = V2WorkflowRunResultDetailMetadata{Type: V2WorkflowRunResultDetailMetadataTypeText, Value: v.Envelope.Signatures[0].KeyID}
+	}
+	return m
+}
+
+// Cast implements V2WorkflowRunResultDetailInterface.
+func (v *V2WorkflowRunResultAttestationDetail) Cast(i any) error {
+	return castV2WorkflowRunResultDetailWithMapStructure(i, v)
+}
+
+// GetName implements V2WorkflowRunResultDetailInterface.
+func (v *V2WorkflowRunResultAttestationDetail) GetName() string {
+	return v.Name
+}
+
+// ParseDigest splits a "<algorithm>:<value>" digest, sha256 is the default algorithm.
+func ParseDigest(s string) (string, string) {
+	algo, value, found := strings.Cut(s, ":")
+	if !found {
+		return "sha256", strings.ToLower(s)
+	}
+	return strings.ToLower(algo), strings.ToLower(value)
+}
+
+// IsAttestable returns true if a provenance can be generated for the run result.
+func (r *V2WorkflowRunResult) IsAttestable() bool {
+	switch r.Type {
+	case V2WorkflowRunResultTypeAttestation, V2WorkflowRunResultTypeVariable, V2WorkflowRunResultTypeRelease,
+		V2WorkflowRunResultTypeArsenalDeployment, V2WorkflowRunResultTypeTest, V2WorkflowRunResultTypeCoverage:
+		return false
+	}
+	return true
+}
+
+// AttestationSubjects returns the artifacts of the run result that have a known sha256 digest.
+func (r *V2WorkflowRunResult) AttestationSubjects() ([]InTotoSubject, error) {
+	detail, err := r.GetDetail()
+	if err != nil {
+		return nil, err
+	}
+	var subjects []InTotoSubject
+	add := func(name, sha256 string) {
+		if sha256 == "" {
+			return
+		}
+		subjects = append(subjects, InTotoSubject{Name: name, Digest: map[string]string{"sha256": strings.TrimPrefix(sha256, "sha256:")}})
+	}
+	switch d := detail.(type) {
+	case *V2WorkflowRunResultDockerDetail:
+		for _, m := range d.Manifests {
+			add(d.Name, m.SHA256)
+		}
+	case *V2WorkflowRunResultConanDetail:
+		for _, f := range d.Files {
+			add(f.FileName, f.SHA256)
+		}
+	case *V2WorkflowRunResultOCIDetail:
+		for _, f := range d.Files {
+			add(f.FileName, f.SHA256)
+		}
+	default:
+		v := reflect.Indirect(reflect.ValueOf(detail))
+		if f := v.FieldByName("SHA256"); f.IsValid() && f.Kind() == reflect.String {
+			add(detail.GetName(), f.String())
+		}
+	}
+	return subjects, nil
+}
+
+// NewV2WorkflowRunResultProvenance returns the SLSA provenance statement of the subjects built by the job of the run.
+func NewV2WorkflowRunResultProvenance(run V2WorkflowRun, runJob V2WorkflowRunJob, subjects []InTotoSubject, finishedOn time.Time) (*InTotoStatement, error) {
+	cds := run.Contexts.CDS
+	git := run.Contexts.Git
+
+	external := map[string]interface{}{
+		"workflow": map[string]interface{}{
+			"name":       cds.Workflow,
+			"repository": cds.WorkflowRepository,
+			"ref":        cds.WorkflowRef,
+			"sha":        cds.WorkflowSha,
+		},
+		"job": runJob.JobID,
+	}
+	if len(runJob.GateInputs) > 0 {
+		external["inputs"] = runJob.GateInputs
+	}
+	if git.Repository != "" {
+		external["repository"] = git.Repository
+		external["ref"] = git.Ref
+	}
+
+	internal := map[string]interface{}{
+		"project_key":    run.ProjectKey,
+		"event_name":     cds.EventName,
+		"worker_model":   runJob.Job.RunsOn.Model,
+		"model_type":     runJob.ModelType,
+		"model_os_arch":  runJob.ModelOSArch,
+		"region":         runJob.Region,
+		"hatchery_name":  runJob.HatcheryName,
+		"worker_name":    runJob.WorkerName,
+		"triggered_by":   cds.TriggeringActor,
+		"run_attempt":    runJob.RunAttempt,
+		"workflow_stage": runJob.Job.Stage,
+	}
+
+	var deps []SLSAResourceDescriptor
+	if git.Sha != "" {
+		deps = append(deps, SLSAResourceDescriptor{
+			URI:    "git+" + git.RepositoryURL + "@" + git.Ref,
+			Name:   git.Repository,
+			Digest: map[string]string{"gitCommit": git.Sha},
+		})
+	}
+	if cds.WorkflowSha != "" && (cds.WorkflowRepository != git.Repository || cds.WorkflowSha != git.Sha) {
+		deps = append(deps, SLSAResourceDescriptor{
+			Name:   cds.WorkflowRepository,
+			Digest: map[string]string{"gitCommit": cds.WorkflowSha},
+		})
+	}
+
+	predicate := SLSAProvenance{
+		BuildDefinition: SLSABuildDefinition{
+			BuildType:            V2WorkflowBuildType,
+			ExternalParameters:   external,
+			InternalParameters:   internal,
+			ResolvedDependencies: deps,
+		},
+		RunDetails: SLSARunDetails{
+			Builder: SLSABuilder{
+				ID:      V2WorkerBuilderID + "/" + runJob.Region,
+				Version: map[string]string{"cds": VERSION},
+			},
+			Metadata: SLSABuildMetadata{
+				InvocationID: cds.RunURL,
+				StartedOn:    runJob.Started,
+				FinishedOn:   &finishedOn,
+			},
+		},
+	}
+	if predicate.RunDetails.Metadata.InvocationID == "" {
+		predicate.RunDetails.Metadata.InvocationID = runJob.WorkflowRunID + "/" + runJob.ID
+	}
+
+	btes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, WithStack(err)
+	}
+	return &InTotoStatement{
+		Type:          InTotoStatementType,
+		Subject:       subjects,
+		PredicateType: SLSAProvenancePredicateType,
+		Predicate:     btes,
+	}, nil
+}
+
+// NewSBOMStatement returns the statement attaching a SPDX or CycloneDX JSON document to the subjects.
+func NewSBOMStatement(subjects []InTotoSubject, sbom []byte) (*InTotoStatement, error) {
+	var doc struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := JSONUnmarshal(sbom, &doc); err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid SBOM, a SPDX or CycloneDX JSON document is expected: %v", err)
+	}
+	var predicateType string
+	switch {
+	case doc.BOMFormat == "CycloneDX":
+		predicateType = CycloneDXPredicateType
+	case doc.SPDXVersion != "":
+		predicateType = SPDXPredicateType
+	default:
+		return nil, NewErrorFrom(ErrInvalidData, "invalid SBOM, a SPDX or CycloneDX JSON document is expected")
+	}
+	return &InTotoStatement{
+		Type:          InTotoStatementType,
+		Subject:       subjects,
+		PredicateType: predicateType,
+		Predicate:     sbom,
+	}, nil
+}
@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSSEEnvelope(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	sign := func(message []byte) ([]byte, error) {
+		h := sha256.Sum256(message)
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+	}
+	verify := func(message, sig []byte) error {
+		h := sha256.Sum256(message)
+		return rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, h[:], sig)
+	}
+
+	subjects := []InTotoSubject{{Name: "my-binary", Digest: map[string]string{"sha256": "abcd"}}}
+	statement, err := NewSBOMStatement(subjects, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`))
+	require.NoError(t, err)
+	require.Equal(t, CycloneDXPredicateType, statement.PredicateType)
+
+	payload, err := json.Marshal(statement)
+	require.NoError(t, err)
+	envelope, err := NewDSSEEnvelope(InTotoPayloadType, payload, "my-key", sign)
+	require.NoError(t, err)
+
+	require.NoError(t, envelope.Verify("my-key", verify))
+	require.Error(t, envelope.Verify("another-key", verify))
+
+	_, err = envelope.VerifyStatement("my-key", verify, "sha256", "abcd")
+	require.NoError(t, err)
+	_, err = envelope.VerifyStatement("my-key", verify, "sha256", "ef01")
+	require.Error(t, err)
+
+	// The instance keys are served PEM encoded
+	public, err := EncodeRSAAttestationPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey, err := ParseRSAAttestationPublicKey(public)
+	require.NoError(t, err)
+	require.NoError(t, envelope.Verify("my-key", func(message, sig []byte) error {
+		return VerifyRSAAttestation(publicKey, message, sig)
+	}))
+	_, err = ParseRSAAttestationPublicKey("not a key")
+	require.Error(t, err)
+
+	s, err := envelope.Statement()
+	require.NoError(t, err)
+	require.Equal(t, subjects, s.Subject)
+
+	// A modified payload must not be verified
+	tampered := *envelope
+	tampered.Payload = envelope.Payload[:len(envelope.Payload)-4] + "AAAA"
+	require.Error(t, tampered.Verify("my-key", verify))
+
+	_, err = ParseInTotoStatement(InTotoPayloadType, []byte(`{"_type":"https://in-toto.io/Statement/v1","subject":[]}`))
+	require.Error(t, err)
+	_, err = ParseInTotoStatement("text/plain", payload)
+	require.Error(t, err)
+}
+
+func TestV2WorkflowRunResultAttestationSubjects(t *testing.T) {
+	r := V2WorkflowRunResult{
+		Type: V2WorkflowRunResultTypeGeneric,
+		Detail: V2WorkflowRunResultDetail{
+			Data: V2WorkflowRunResultGenericDetail{Name: "my-binary", SHA256: "abcd"},
+		},
+	}
+	require.True(t, r.IsAttestable())
+	subjects, err := r.AttestationSubjects()
+	require.NoError(t, err)
+	require.Equal(t, []InTotoSubject{{Name: "my-binary", Digest: map[string]string{"sha256": "abcd"}}}, subjects)
+
+	r = V2WorkflowRunResult{
+		Type: V2WorkflowRunResultTypeDocker,
+		Detail: V2WorkflowRunResultDetail{
+			Data: V2WorkflowRunResultDockerDetail{Name: "my/image:1.0", Manifests: []V2WorkflowRunResultDockerDetailImage{{SHA256: "sha256:ef01"}}},
+		},
+	}
+	subjects, err = r.AttestationSubjects()
+	require.NoError(t, err)
+	require.Equal(t, []InTotoSubject{{Name: "my/image:1.0", Digest: map[string]string{"sha256": "ef01"}}}, subjects)
+
+	require.False(t, (&V2WorkflowRunResult{Type: V2WorkflowRunResultTypeAttestation}).IsAttestable())
+
+	algo, digest := ParseDigest("SHA256:ABCD")
+	require.Equal(t, "sha256", algo)
+	require.Equal(t, "abcd", digest)
+	algo, digest = ParseDigest("abcd")
+	require.Equal(t, "sha256", algo)
+	require.Equal(t, "abcd", digest)
+}
+
+func TestNewV2WorkflowRunResultProvenance(t *testing.T) {
+	run := V2WorkflowRun{
+		ID:         "run-id",
+		ProjectKey: "PROJ",
+		Contexts: WorkflowRunContext{
+			CDS: CDSContext{Workflow: "my-workflow", WorkflowRepository: "my/repo", WorkflowSha: "123456", RunURL: "https://cds/run/1"},
+			Git: GitContext{Repository: "my/repo", Ref: "refs/heads/main", Sha: "123456", RepositoryURL: "https://github.com/my/repo"},
+		},
+	}
+	runJob := V2WorkflowRunJob{ID: "job-id", JobID: "build", WorkflowRunID: "run-id", Region: "my-region", Job: V2Job{Stage: "build"}}
+	subjects := []InTotoSubject{{Name: "my-binary", Digest: map[string]string{"sha256": "abcd"}}}
+
+	s, err := NewV2WorkflowRunResultProvenance(run, runJob, subjects, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, SLSAProvenancePredicateType, s.PredicateType)
+	require.Equal(t, subjects, s.Subject)
+
+	var predicate SLSAProvenance
+	require.NoError(t, json.Unmarshal(s.Predicate, &predicate))
+	require.Equal(t, "build", predicate.BuildDefinition.ExternalParameters["job"])
+	require.Equal(t, "PROJ", predicate.BuildDefinition.InternalParameters["project_key"])
+	require.Equal(t, V2WorkerBuilderID+"/my-region", predicate.RunDetails.Builder.ID)
+	require.Equal(t, "https://cds/run/1", predicate.RunDetails.Metadata.InvocationID)
+	// The workflow is in the same repository than the sources, it is only listed once
+	require.Len(t, predicate.BuildDefinition.ResolvedDependencies, 1)
+	require.Equal(t, "123456", predicate.BuildDefinition.ResolvedDependencies[0].Digest["gitCommit"])
+}
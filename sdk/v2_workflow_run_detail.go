@@ -57,6 +57,7 @@ func init() {
 		&V2WorkflowRunResultPuppetDetail{},
 		&V2WorkflowRunResultConanDetail{},
 		&V2WorkflowRunResultOCIDetail{},
+		&V2WorkflowRunResultAttestationDetail{},
 	)
 }
 
@@ -227,6 +228,7 @@ const (
 	V2WorkflowRunResultTypePuppet            V2WorkflowRunResultType = "puppet"
 	V2WorkflowRunResultTypeConan             V2WorkflowRunResultType = "conan"
 	V2WorkflowRunResultTypeOCI               V2WorkflowRunResultType = "oci"
+	V2WorkflowRunResultTypeAttestation       V2WorkflowRunResultType = "attestation"
 	// Other values may be instantiated from Artifactory Manager repository type
 )
 
@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	V2JobDebugAlways  = "always"
+	V2JobDebugFailure = "failure"
+
+	V2JobDebugDefaultTimeout int64 = 30
+	V2JobDebugMaxTimeout     int64 = 120
+
+	// V2JobDebugInputMaxSize is the size of the input recorded in the audit of a debug session.
+	V2JobDebugInputMaxSize = 64 * 1024
+	// V2JobDebugOutputMaxSize is the size of the output recorded in the audit of a debug session, the output
+	// beyond it is relayed to the user but is not recorded.
+	V2JobDebugOutputMaxSize = 1024 * 1024
+)
+
+// CheckDebug checks the debug options of the job.
+func (j V2Job) CheckDebug() error {
+	switch j.Debug {
+	case "", V2JobDebugAlways, V2JobDebugFailure:
+	default:
+		return fmt.Errorf("debug must be %s or %s", V2JobDebugAlways, V2JobDebugFailure)
+	}
+	if j.DebugTimeout < 0 || j.DebugTimeout > V2JobDebugMaxTimeout {
+		return fmt.Errorf("debug-timeout must be between 0 and %d minutes", V2JobDebugMaxTimeout)
+	}
+	return nil
+}
+
+// DebugEnabled returns true if the worker has to wait for debug sessions at the end of the job.
+func (j V2Job) DebugEnabled(status V2WorkflowRunJobStatus) bool {
+	switch j.Debug {
+	case V2JobDebugAlways:
+		return true
+	case V2JobDebugFailure:
+		return status == V2WorkflowRunJobStatusFail
+	}
+	return false
+}
+
+// GetDebugTimeout returns the time the worker waits for debug sessions.
+func (j V2Job) GetDebugTimeout() time.Duration {
+	timeout := j.DebugTimeout
+	if timeout <= 0 {
+		timeout = V2JobDebugDefaultTimeout
+	}
+	if timeout > V2JobDebugMaxTimeout {
+		timeout = V2JobDebugMaxTimeout
+	}
+	return time.Duration(timeout) * time.Minute
+}
+
+type V2JobDebugMessageType string
+
+const (
+	// V2JobDebugMessageStart is sent to the worker when a user opens a debug session.
+	V2JobDebugMessageStart V2JobDebugMessageType = "start"
+	// V2JobDebugMessageInput carries the input typed by the user.
+	V2JobDebugMessageInput V2JobDebugMessageType = "input"
+	// V2JobDebugMessageOutput carries the output of the shell of the session.
+	V2JobDebugMessageOutput V2JobDebugMessageType = "output"
+	// V2JobDebugMessageEnd is sent when the user or the shell closes the session.
+	V2JobDebugMessageEnd V2JobDebugMessageType = "end"
+	// V2JobDebugMessageRelease stops the debug of the job, the worker sends the job result and exits.
+	V2JobDebugMessageRelease V2JobDebugMessageType = "release"
+)
+
+// V2JobDebugMessage is exchanged through websockets between the user, the API and the worker.
+type V2JobDebugMessage struct {
+	Type      V2JobDebugMessageType `json:"type"`
+	RunJobID  string                `json:"run_job_id,omitempty"`
+	SessionID string                `json:"session_id,omitempty"`
+	Data      string                `json:"data,omitempty"`
+	ToWorker  bool                  `json:"to_worker,omitempty"`
+}
+
+// V2WorkflowRunJobDebug is set by the API while a worker waits for debug sessions.
+type V2WorkflowRunJobDebug struct {
+	RunJobID string    `json:"run_job_id"`
+	Worker   string    `json:"worker"`
+	Until    time.Time `json:"until"`
+}
+
+// V2WorkflowRunJobDebugSession is the audit of a debug session.
+type V2WorkflowRunJobDebugSession struct {
+	ID               string     `json:"id" db:"id" cli:"id,key"`
+	WorkflowRunID    string     `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowRunJobID string     `json:"workflow_run_job_id" db:"workflow_run_job_id"`
+	ProjectKey       string     `json:"project_key" db:"project_key"`
+	UserID           string     `json:"user_id" db:"user_id"`
+	Username         string     `json:"username" db:"username" cli:"username"`
+	Started          time.Time  `json:"started" db:"started" cli:"started"`
+	Ended            *time.Time `json:"ended,omitempty" db:"ended" cli:"ended"`
+	Input            string     `json:"input" db:"input"`
+	Output           string     `json:"output" db:"output"`
+}
+
+// AppendInput records the input typed by the user, up to V2JobDebugInputMaxSize.
+func (s *V2WorkflowRunJobDebugSession) AppendInput(data string) {
+	s.Input = appendDebugAudit(s.Input, data, V2JobDebugInputMaxSize)
+}
+
+// AppendOutput records the output of the shell, already blurred by the worker, up to V2JobDebugOutputMaxSize.
+func (s *V2WorkflowRunJobDebugSession) AppendOutput(data string) {
+	s.Output = appendDebugAudit(s.Output, data, V2JobDebugOutputMaxSize)
+}
+
+func appendDebugAudit(audit, data string, maxSize int) string {
+	if len(audit) >= maxSize {
+		return audit
+	}
+	if len(audit)+len(data) > maxSize {
+		data = data[:maxSize-len(audit)]
+	}
+	return audit + data
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2JobDebug(t *testing.T) {
+	require.NoError(t, V2Job{}.CheckDebug())
+	require.Error(t, V2Job{Debug: "never"}.CheckDebug())
+	require.Error(t, V2Job{Debug: V2JobDebugAlways, DebugTimeout: 500}.CheckDebug())
+
+	require.False(t, V2Job{}.DebugEnabled(V2WorkflowRunJobStatusFail))
+	require.True(t, V2Job{Debug: V2JobDebugAlways}.DebugEnabled(V2WorkflowRunJobStatusSuccess))
+	require.False(t, V2Job{Debug: V2JobDebugFailure}.DebugEnabled(V2WorkflowRunJobStatusSuccess))
+	require.True(t, V2Job{Debug: V2JobDebugFailure}.DebugEnabled(V2WorkflowRunJobStatusFail))
+
+	require.Equal(t, 30*time.Minute, V2Job{Debug: V2JobDebugAlways}.GetDebugTimeout())
+	require.Equal(t, 10*time.Minute, V2Job{Debug: V2JobDebugAlways, DebugTimeout: 10}.GetDebugTimeout())
+}
+
+func TestV2WorkflowRunJobDebugSessionAppendInput(t *testing.T) {
+	var s V2WorkflowRunJobDebugSession
+	s.AppendInput("ls\n")
+	s.AppendInput(strings.Repeat("a", V2JobDebugInputMaxSize))
+	s.AppendInput("pwd\n")
+	require.Len(t, s.Input, V2JobDebugInputMaxSize)
+	require.True(t, strings.HasPrefix(s.Input, "ls\naaa"))
+}
+
+func TestV2WorkflowRunJobDebugSessionAppendOutput(t *testing.T) {
+	var s V2WorkflowRunJobDebugSession
+	s.AppendOutput("$ ")
+	s.AppendOutput(strings.Repeat("a", V2JobDebugOutputMaxSize))
+	s.AppendOutput("$ ")
+	require.Len(t, s.Output, V2JobDebugOutputMaxSize)
+	require.True(t, strings.HasPrefix(s.Output, "$ aaa"))
+	require.Empty(t, s.Input)
+}
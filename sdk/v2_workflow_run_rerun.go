@@ -0,0 +1,117 @@
+package sdk
+
+import (
+	"regexp"
+	"time"
+)
+
+// V2JobDebugLogEnv is set on the jobs re-run with the debug log level. The worker logs at debug level and the
+// steps can read it to enable their own debug logs.
+const V2JobDebugLogEnv = "CDS_DEBUG"
+
+var envNameRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// V2WorkflowRunJobOverrides overrides the definition of a job when it is re-run.
+type V2WorkflowRunJobOverrides struct {
+	Env   map[string]string `json:"env,omitempty"`
+	Model string            `json:"model,omitempty"`
+	Debug bool              `json:"debug,omitempty"`
+}
+
+func (o V2WorkflowRunJobOverrides) IsEmpty() bool {
+	return len(o.Env) == 0 && o.Model == "" && !o.Debug
+}
+
+func (o V2WorkflowRunJobOverrides) Check() error {
+	for k := range o.Env {
+		if !envNameRegex.MatchString(k) {
+			return NewErrorFrom(ErrInvalidData, "invalid env variable name %q", k)
+		}
+	}
+	return nil
+}
+
+// CheckJob checks that the overrides can be applied on the given job. The worker model of a job that runs on labels
+// can't be overridden: the labels select the worker, not the model.
+func (o V2WorkflowRunJobOverrides) CheckJob(jobID string, j V2Job) error {
+	if o.Model != "" && len(j.RunsOn.Labels) > 0 {
+		return NewErrorFrom(ErrInvalidData, "unable to override the worker model of job %q as it runs on labels %v", jobID, j.RunsOn.Labels)
+	}
+	return nil
+}
+
+// Apply returns a copy of the job with the overrides.
+func (o V2WorkflowRunJobOverrides) Apply(j V2Job) V2Job {
+	job := j.Copy()
+	for k, v := range o.Env {
+		job.Env[k] = v
+	}
+	if o.Debug {
+		job.Env[V2JobDebugLogEnv] = "true"
+	}
+	if o.Model != "" {
+		job.RunsOn.Model = o.Model
+	}
+	return job
+}
+
+// V2WorkflowRunJobRerun overrides the gate inputs and the definition of a re-run job.
+type V2WorkflowRunJobRerun struct {
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+	V2WorkflowRunJobOverrides
+}
+
+// V2WorkflowRunRestartRequest is the optional body of a run restart. Env and Debug apply to all the jobs that run
+// again, Jobs applies to the given jobs only.
+type V2WorkflowRunRestartRequest struct {
+	Env   map[string]string                `json:"env,omitempty"`
+	Debug bool                             `json:"debug,omitempty"`
+	Jobs  map[string]V2WorkflowRunJobRerun `json:"jobs,omitempty"`
+}
+
+func (r V2WorkflowRunRestartRequest) Check() error {
+	if err := (V2WorkflowRunJobOverrides{Env: r.Env}).Check(); err != nil {
+		return err
+	}
+	for _, jobRerun := range r.Jobs {
+		if err := jobRerun.Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JobOverrides returns the overrides of the given job, nil if there is none.
+func (r V2WorkflowRunRestartRequest) JobOverrides(jobID string) *V2WorkflowRunJobOverrides {
+	o := V2WorkflowRunJobOverrides{
+		Env:   make(map[string]string),
+		Debug: r.Debug,
+	}
+	for k, v := range r.Env {
+		o.Env[k] = v
+	}
+	if jobRerun, has := r.Jobs[jobID]; has {
+		for k, v := range jobRerun.Env {
+			o.Env[k] = v
+		}
+		o.Model = jobRerun.Model
+		o.Debug = o.Debug || jobRerun.Debug
+	}
+	if o.IsEmpty() {
+		return nil
+	}
+	return &o
+}
+
+// V2WorkflowRunJobReplay bundles a job with its resolved contexts and its actions, without the secrets, so the
+// worker can replay it offline.
+type V2WorkflowRunJobReplay struct {
+	RunJob        V2WorkflowRunJob       `json:"run_job"`
+	AsCodeActions map[string]V2Action    `json:"actions"`
+	Contexts      WorkflowRunJobsContext `json:"contexts"`
+	// Plugins describes the plugins binaries, the worker does not download them.
+	Plugins []GRPCPlugin `json:"plugins,omitempty"`
+	// OmittedSecrets lists the secret variables that are not exported.
+	OmittedSecrets []string  `json:"omitted_secrets,omitempty"`
+	Created        time.Time `json:"created"`
+}
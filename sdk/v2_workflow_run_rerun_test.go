@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2WorkflowRunJobOverridesApply(t *testing.T) {
+	j := V2Job{
+		Env:    map[string]string{"FOO": "foo"},
+		RunsOn: V2JobRunsOn{Model: "old-model"},
+	}
+	o := V2WorkflowRunJobOverrides{
+		Env:   map[string]string{"BAR": "bar"},
+		Model: "my-model",
+		Debug: true,
+	}
+	require.NoError(t, o.CheckJob("build", j))
+	overridden := o.Apply(j)
+	require.Equal(t, map[string]string{"FOO": "foo", "BAR": "bar", V2JobDebugLogEnv: "true"}, overridden.Env)
+	require.Equal(t, "my-model", overridden.RunsOn.Model)
+
+	// The original job must not be modified
+	require.Equal(t, map[string]string{"FOO": "foo"}, j.Env)
+	require.Equal(t, "old-model", j.RunsOn.Model)
+
+	// The worker model of a job running on labels can't be overridden
+	require.Error(t, o.CheckJob("build", V2Job{RunsOn: V2JobRunsOn{Labels: []string{"gpu"}}}))
+	require.NoError(t, V2WorkflowRunJobOverrides{Debug: true}.CheckJob("build", V2Job{RunsOn: V2JobRunsOn{Labels: []string{"gpu"}}}))
+}
+
+func TestV2WorkflowRunRestartRequest(t *testing.T) {
+	r := V2WorkflowRunRestartRequest{
+		Env: map[string]string{"FOO": "foo"},
+		Jobs: map[string]V2WorkflowRunJobRerun{
+			"build": {V2WorkflowRunJobOverrides: V2WorkflowRunJobOverrides{Env: map[string]string{"FOO": "bar"}, Model: "my-model"}},
+		},
+	}
+	require.NoError(t, r.Check())
+
+	o := r.JobOverrides("build")
+	require.NotNil(t, o)
+	require.Equal(t, "bar", o.Env["FOO"])
+	require.Equal(t, "my-model", o.Model)
+
+	o = r.JobOverrides("test")
+	require.NotNil(t, o)
+	require.Equal(t, "foo", o.Env["FOO"])
+	require.Empty(t, o.Model)
+
+	require.Nil(t, V2WorkflowRunRestartRequest{}.JobOverrides("build"))
+
+	r.Env["not valid"] = "foo"
+	require.Error(t, r.Check())
+}
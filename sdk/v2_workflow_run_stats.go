@@ -0,0 +1,168 @@
+package sdk
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	V2WorkflowRunStatsIntervalDay  = "day"
+	V2WorkflowRunStatsIntervalWeek = "week"
+)
+
+// V2WorkflowRunStatsRow is a summary of runs aggregated by the database, for all the runs (no bucket nor workflow),
+// for a trend bucket or for a workflow. Durations are in seconds.
+type V2WorkflowRunStatsRow struct {
+	Bucket             *time.Time `db:"bucket"`
+	Workflow           string     `db:"workflow"`
+	Runs               int        `db:"runs"`
+	Deployments        int        `db:"deployments"`
+	Failures           int        `db:"failures"`
+	LeadTimeCount      int        `db:"lead_time_count"`
+	LeadTimeP50        float64    `db:"lead_time_p50"`
+	LeadTimeP95        float64    `db:"lead_time_p95"`
+	TimeToRestoreCount int        `db:"time_to_restore_count"`
+	TimeToRestoreP50   float64    `db:"time_to_restore_p50"`
+	TimeToRestoreP95   float64    `db:"time_to_restore_p95"`
+	DurationCount      int        `db:"duration_count"`
+	DurationP50        float64    `db:"duration_p50"`
+	DurationP95        float64    `db:"duration_p95"`
+	QueueTimeCount     int        `db:"queue_time_count"`
+	QueueTimeP50       float64    `db:"queue_time_p50"`
+	QueueTimeP95       float64    `db:"queue_time_p95"`
+}
+
+// V2WorkflowRunStatsDuration holds percentiles, in seconds, of a set of durations.
+type V2WorkflowRunStatsDuration struct {
+	Count int     `json:"count" cli:"count"`
+	P50   float64 `json:"p50_seconds" cli:"p50_seconds"`
+	P95   float64 `json:"p95_seconds" cli:"p95_seconds"`
+}
+
+// V2WorkflowRunStatsSummary holds the DORA-style indicators for a set of runs.
+type V2WorkflowRunStatsSummary struct {
+	Runs                int                        `json:"runs"`
+	Deployments         int                        `json:"deployments"`
+	Failures            int                        `json:"failures"`
+	DeploymentFrequency float64                    `json:"deployment_frequency_per_day"`
+	ChangeFailureRate   float64                    `json:"change_failure_rate"`
+	LeadTime            V2WorkflowRunStatsDuration `json:"lead_time"`
+	TimeToRestore       V2WorkflowRunStatsDuration `json:"time_to_restore"`
+	Duration            V2WorkflowRunStatsDuration `json:"duration"`
+	QueueTime           V2WorkflowRunStatsDuration `json:"queue_time"`
+}
+
+type V2WorkflowRunStatsBucket struct {
+	Start time.Time `json:"start"`
+	V2WorkflowRunStatsSummary
+}
+
+type V2WorkflowRunStatsWorkflow struct {
+	Workflow string `json:"workflow"`
+	V2WorkflowRunStatsSummary
+}
+
+// V2WorkflowRunStats is the delivery analytics of a project over a time window.
+// Lead time is measured from the commit date to the end of the first successful run of the commit,
+// runs without commit date in their git context are not counted.
+type V2WorkflowRunStats struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Interval string    `json:"interval"`
+	V2WorkflowRunStatsSummary
+	Trend     []V2WorkflowRunStatsBucket   `json:"trend"`
+	Workflows []V2WorkflowRunStatsWorkflow `json:"workflows"`
+}
+
+// NewV2WorkflowRunStats builds the stats between from and to from the rows aggregated by the database.
+// A deployment is a successful run; only successful and failed runs count in the change failure rate.
+func NewV2WorkflowRunStats(rows []V2WorkflowRunStatsRow, from, to time.Time, interval string) V2WorkflowRunStats {
+	if interval != V2WorkflowRunStatsIntervalWeek {
+		interval = V2WorkflowRunStatsIntervalDay
+	}
+	stats := V2WorkflowRunStats{
+		From:     from,
+		To:       to,
+		Interval: interval,
+	}
+
+	buckets := make(map[time.Time]V2WorkflowRunStatsRow)
+	for _, r := range rows {
+		switch {
+		case r.Bucket != nil:
+			buckets[r.Bucket.UTC()] = r
+		case r.Workflow != "":
+			stats.Workflows = append(stats.Workflows, V2WorkflowRunStatsWorkflow{
+				Workflow:                  r.Workflow,
+				V2WorkflowRunStatsSummary: newV2WorkflowRunStatsSummary(r, to.Sub(from)),
+			})
+		default:
+			stats.V2WorkflowRunStatsSummary = newV2WorkflowRunStatsSummary(r, to.Sub(from))
+		}
+	}
+	sort.Slice(stats.Workflows, func(i, j int) bool { return stats.Workflows[i].Workflow < stats.Workflows[j].Workflow })
+
+	// Buckets are computed in UTC by the database, empty buckets are kept in the trend
+	for start := truncateV2WorkflowRunStatsInterval(from.UTC(), interval); start.Before(to); start = nextV2WorkflowRunStatsInterval(start, interval) {
+		end := nextV2WorkflowRunStatsInterval(start, interval)
+		bucket := V2WorkflowRunStatsBucket{Start: start}
+		if r, has := buckets[start]; has {
+			bucket.V2WorkflowRunStatsSummary = newV2WorkflowRunStatsSummary(r, end.Sub(start))
+		}
+		stats.Trend = append(stats.Trend, bucket)
+	}
+
+	return stats
+}
+
+func newV2WorkflowRunStatsSummary(r V2WorkflowRunStatsRow, window time.Duration) V2WorkflowRunStatsSummary {
+	s := V2WorkflowRunStatsSummary{
+		Runs:          r.Runs,
+		Deployments:   r.Deployments,
+		Failures:      r.Failures,
+		LeadTime:      newV2WorkflowRunStatsDuration(r.LeadTimeCount, r.LeadTimeP50, r.LeadTimeP95),
+		TimeToRestore: newV2WorkflowRunStatsDuration(r.TimeToRestoreCount, r.TimeToRestoreP50, r.TimeToRestoreP95),
+		Duration:      newV2WorkflowRunStatsDuration(r.DurationCount, r.DurationP50, r.DurationP95),
+		QueueTime:     newV2WorkflowRunStatsDuration(r.QueueTimeCount, r.QueueTimeP50, r.QueueTimeP95),
+	}
+	if days := window.Hours() / 24; days > 0 {
+		s.DeploymentFrequency = roundV2WorkflowRunStats(float64(s.Deployments) / days)
+	}
+	if s.Deployments+s.Failures > 0 {
+		s.ChangeFailureRate = roundV2WorkflowRunStats(float64(s.Failures) / float64(s.Deployments+s.Failures))
+	}
+	return s
+}
+
+func newV2WorkflowRunStatsDuration(count int, p50, p95 float64) V2WorkflowRunStatsDuration {
+	if count == 0 {
+		return V2WorkflowRunStatsDuration{}
+	}
+	return V2WorkflowRunStatsDuration{
+		Count: count,
+		P50:   roundV2WorkflowRunStats(p50),
+		P95:   roundV2WorkflowRunStats(p95),
+	}
+}
+
+func roundV2WorkflowRunStats(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+func truncateV2WorkflowRunStatsInterval(t time.Time, interval string) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if interval == V2WorkflowRunStatsIntervalWeek {
+		// Weeks start on monday
+		offset := (int(t.Weekday()) + 6) % 7
+		t = t.AddDate(0, 0, -offset)
+	}
+	return t
+}
+
+func nextV2WorkflowRunStatsInterval(t time.Time, interval string) time.Time {
+	if interval == V2WorkflowRunStatsIntervalWeek {
+		return t.AddDate(0, 0, 7)
+	}
+	return t.AddDate(0, 0, 1)
+}
@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewV2WorkflowRunStats(t *testing.T) {
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC) // monday
+	to := from.AddDate(0, 0, 3)
+
+	secondDay := from.AddDate(0, 0, 1)
+	rows := []V2WorkflowRunStatsRow{
+		{
+			Runs: 4, Deployments: 2, Failures: 1,
+			LeadTimeCount: 2, LeadTimeP50: 7200, LeadTimeP95: 10800,
+			TimeToRestoreCount: 1, TimeToRestoreP50: 7200, TimeToRestoreP95: 7200,
+			DurationCount: 4, DurationP50: 3600, DurationP95: 7200,
+			QueueTimeCount: 2, QueueTimeP50: 10, QueueTimeP95: 30.123,
+		},
+		{Bucket: &from, Runs: 2, Deployments: 1, Failures: 1, DurationCount: 2, DurationP50: 3600, DurationP95: 3600},
+		{Bucket: &secondDay, Runs: 2, Deployments: 1, DurationCount: 2, DurationP50: 3600, DurationP95: 7200},
+		{Workflow: "github/my/repo/deploy", Runs: 1, Deployments: 1},
+		{Workflow: "github/my/repo/build", Runs: 3, Deployments: 1, Failures: 1},
+	}
+
+	stats := NewV2WorkflowRunStats(rows, from, to, "")
+	require.Equal(t, V2WorkflowRunStatsIntervalDay, stats.Interval)
+	require.Equal(t, 4, stats.Runs)
+	require.Equal(t, 2, stats.Deployments)
+	require.Equal(t, 1, stats.Failures)
+	require.Equal(t, 0.67, stats.DeploymentFrequency)
+	require.Equal(t, 0.33, stats.ChangeFailureRate)
+	require.Equal(t, V2WorkflowRunStatsDuration{Count: 2, P50: 7200, P95: 10800}, stats.LeadTime)
+	require.Equal(t, V2WorkflowRunStatsDuration{Count: 1, P50: 7200, P95: 7200}, stats.TimeToRestore)
+	require.Equal(t, V2WorkflowRunStatsDuration{Count: 2, P50: 10, P95: 30.12}, stats.QueueTime)
+
+	// Empty buckets are kept in the trend
+	require.Len(t, stats.Trend, 3)
+	require.Equal(t, from, stats.Trend[0].Start)
+	require.Equal(t, 2, stats.Trend[0].Runs)
+	require.Equal(t, 0.5, stats.Trend[0].ChangeFailureRate)
+	require.Equal(t, 1.0, stats.Trend[1].DeploymentFrequency)
+	require.Equal(t, 0, stats.Trend[2].Runs)
+
+	require.Len(t, stats.Workflows, 2)
+	require.Equal(t, "github/my/repo/build", stats.Workflows[0].Workflow)
+	require.Equal(t, 3, stats.Workflows[0].Runs)
+	require.Equal(t, "github/my/repo/deploy", stats.Workflows[1].Workflow)
+
+	weekly := NewV2WorkflowRunStats([]V2WorkflowRunStatsRow{{Bucket: &from, Runs: 1}}, secondDay, to, V2WorkflowRunStatsIntervalWeek)
+	require.Len(t, weekly.Trend, 1)
+	require.Equal(t, from, weekly.Trend[0].Start)
+	require.Equal(t, 1, weekly.Trend[0].Runs)
+}
@@ -0,0 +1,151 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// V2WorkflowRunJobSummaryMaxSize is the maximum size of the Markdown summary a step can send.
+	V2WorkflowRunJobSummaryMaxSize = 1024 * 1024
+	// V2WorkflowRunJobAnnotationsMax is the maximum number of annotations a step can send.
+	V2WorkflowRunJobAnnotationsMax = 1000
+
+	V2WorkflowRunJobAnnotationLevelNotice  = "notice"
+	V2WorkflowRunJobAnnotationLevelWarning = "warning"
+	V2WorkflowRunJobAnnotationLevelFailure = "failure"
+)
+
+// V2WorkflowRunJobAnnotation is a message attached by a step to a line of a file of the repository.
+type V2WorkflowRunJobAnnotation struct {
+	File    string `json:"file" cli:"file"`
+	Line    int64  `json:"line,omitempty" cli:"line"`
+	Level   string `json:"level" cli:"level"`
+	Title   string `json:"title,omitempty" cli:"title"`
+	Message string `json:"message" cli:"message"`
+}
+
+func (a *V2WorkflowRunJobAnnotation) Check() error {
+	if a.Level == "" {
+		a.Level = V2WorkflowRunJobAnnotationLevelNotice
+	}
+	switch a.Level {
+	case V2WorkflowRunJobAnnotationLevelNotice, V2WorkflowRunJobAnnotationLevelWarning, V2WorkflowRunJobAnnotationLevelFailure:
+	default:
+		return NewErrorFrom(ErrInvalidData, "invalid annotation level %q, must be one of %s, %s or %s", a.Level,
+			V2WorkflowRunJobAnnotationLevelNotice, V2WorkflowRunJobAnnotationLevelWarning, V2WorkflowRunJobAnnotationLevelFailure)
+	}
+	if a.Message == "" {
+		return NewErrorFrom(ErrInvalidData, "annotation message is mandatory")
+	}
+	if a.File == "" {
+		return NewErrorFrom(ErrInvalidData, "annotation file is mandatory")
+	}
+	if a.Line < 0 {
+		return NewErrorFrom(ErrInvalidData, "invalid annotation line %d", a.Line)
+	}
+	a.File = strings.TrimPrefix(a.File, "./")
+	return nil
+}
+
+type V2WorkflowRunJobAnnotations []V2WorkflowRunJobAnnotation
+
+func (m V2WorkflowRunJobAnnotations) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("[]"), nil
+	}
+	j, err := json.Marshal(m)
+	return j, WrapError(err, "cannot marshal V2WorkflowRunJobAnnotations")
+}
+
+func (m *V2WorkflowRunJobAnnotations) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, m), "cannot unmarshal V2WorkflowRunJobAnnotations")
+}
+
+// ParseV2WorkflowRunJobAnnotations reads an annotations file: one JSON annotation per line, empty lines are ignored.
+func ParseV2WorkflowRunJobAnnotations(content []byte) (V2WorkflowRunJobAnnotations, error) {
+	annotations := make(V2WorkflowRunJobAnnotations, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), V2WorkflowRunJobSummaryMaxSize)
+	var lineNumber int
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var a V2WorkflowRunJobAnnotation
+		if err := json.Unmarshal([]byte(line), &a); err != nil {
+			return nil, NewErrorFrom(ErrInvalidData, "invalid annotation at line %d: %v", lineNumber, err)
+		}
+		if err := a.Check(); err != nil {
+			return nil, NewErrorFrom(ErrInvalidData, "invalid annotation at line %d: %v", lineNumber, err)
+		}
+		annotations = append(annotations, a)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "unable to read annotations: %v", err)
+	}
+	return annotations, nil
+}
+
+// V2WorkflowRunJobSummaryRequest is sent by the worker at the end of a step with what the step wrote.
+type V2WorkflowRunJobSummaryRequest struct {
+	StepName    string                      `json:"step_name"`
+	Summary     string                      `json:"summary,omitempty"`
+	Annotations V2WorkflowRunJobAnnotations `json:"annotations,omitempty"`
+}
+
+func (r *V2WorkflowRunJobSummaryRequest) Check() error {
+	if r.Summary == "" && len(r.Annotations) == 0 {
+		return NewErrorFrom(ErrInvalidData, "summary or annotations are mandatory")
+	}
+	if len(r.Summary) > V2WorkflowRunJobSummaryMaxSize {
+		return NewErrorFrom(ErrInvalidData, "summary is too large (%d bytes), maximum is %d bytes", len(r.Summary), V2WorkflowRunJobSummaryMaxSize)
+	}
+	if len(r.Annotations) > V2WorkflowRunJobAnnotationsMax {
+		return NewErrorFrom(ErrInvalidData, "too many annotations (%d), maximum is %d", len(r.Annotations), V2WorkflowRunJobAnnotationsMax)
+	}
+	for i := range r.Annotations {
+		if err := r.Annotations[i].Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type V2WorkflowRunJobSummary struct {
+	ID               string                      `json:"id" db:"id"`
+	WorkflowRunID    string                      `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowRunJobID string                      `json:"workflow_run_job_id" db:"workflow_run_job_id"`
+	JobID            string                      `json:"job_id" db:"job_id" cli:"job"`
+	StepName         string                      `json:"step_name" db:"step_name" cli:"step"`
+	RunAttempt       int64                       `json:"run_attempt" db:"run_attempt"`
+	Summary          string                      `json:"summary" db:"summary"`
+	Annotations      V2WorkflowRunJobAnnotations `json:"annotations" db:"annotations"`
+	Created          time.Time                   `json:"created" db:"created" cli:"created"`
+}
+
+// InsightSeverity converts an annotation level to a VCS insight annotation severity.
+func (a V2WorkflowRunJobAnnotation) InsightSeverity() string {
+	switch a.Level {
+	case V2WorkflowRunJobAnnotationLevelFailure:
+		return VCSInsightAnnotationSeverityHigh
+	case V2WorkflowRunJobAnnotationLevelWarning:
+		return VCSInsightAnnotationSeverityMedium
+	default:
+		return VCSInsightAnnotationSeverityLow
+	}
+}
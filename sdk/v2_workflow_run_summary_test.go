@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseV2WorkflowRunJobAnnotations(t *testing.T) {
+	content := `{"file": "./src/main.go", "line": 12, "level": "warning", "message": "unused variable"}
+
+{"file": "README.md", "message": "typo", "title": "Spelling"}
+`
+	annotations, err := ParseV2WorkflowRunJobAnnotations([]byte(content))
+	require.NoError(t, err)
+	require.Len(t, annotations, 2)
+	require.Equal(t, "src/main.go", annotations[0].File)
+	require.Equal(t, int64(12), annotations[0].Line)
+	require.Equal(t, VCSInsightAnnotationSeverityMedium, annotations[0].InsightSeverity())
+	require.Equal(t, V2WorkflowRunJobAnnotationLevelNotice, annotations[1].Level)
+	require.Equal(t, VCSInsightAnnotationSeverityLow, annotations[1].InsightSeverity())
+
+	_, err = ParseV2WorkflowRunJobAnnotations([]byte(`{"file": "main.go", "message": "ok"}` + "\n" + `not json`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+
+	_, err = ParseV2WorkflowRunJobAnnotations([]byte(`{"file": "main.go"}`))
+	require.Error(t, err)
+
+	annotations, err = ParseV2WorkflowRunJobAnnotations(nil)
+	require.NoError(t, err)
+	require.Len(t, annotations, 0)
+}
+
+func TestV2WorkflowRunJobSummaryRequestCheck(t *testing.T) {
+	require.Error(t, (&V2WorkflowRunJobSummaryRequest{}).Check())
+	require.NoError(t, (&V2WorkflowRunJobSummaryRequest{Summary: "# Title"}).Check())
+	require.Error(t, (&V2WorkflowRunJobSummaryRequest{Summary: strings.Repeat("a", V2WorkflowRunJobSummaryMaxSize+1)}).Check())
+	require.Error(t, (&V2WorkflowRunJobSummaryRequest{Annotations: V2WorkflowRunJobAnnotations{{File: "main.go", Line: -1, Message: "ko"}}}).Check())
+}
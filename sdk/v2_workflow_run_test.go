@@ -121,3 +121,24 @@ func TestJobIntegrationsContext_GetValue(t *testing.T) {
 
 	require.Equal(t, "value_of_token", got)
 }
+
+func TestJobMatrix(t *testing.T) {
+	m := JobMatrix{
+		"os":      "linux",
+		"service": map[string]interface{}{"name": "api", "path": "./api"},
+		"version": []interface{}{"1", "2"},
+	}
+	require.Equal(t, `os=linux, service=api, version=["1","2"]`, m.String())
+
+	v, err := m.Value()
+	require.NoError(t, err)
+	var scanned JobMatrix
+	require.NoError(t, scanned.Scan(string(v.([]byte))))
+	require.Equal(t, "./api", scanned["service"].(map[string]interface{})["path"])
+	for k := range m {
+		require.True(t, MatrixValueEqual(m[k], scanned[k]), k)
+	}
+	require.False(t, MatrixValueEqual(m["os"], "windows"))
+	require.Equal(t, `{"path":"./ui"}`, MatrixValueLabel(map[string]interface{}{"path": "./ui"}))
+	require.Equal(t, "8080", MatrixValueLabel(float64(8080)))
+}
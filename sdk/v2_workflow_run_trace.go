@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// Trace and span identifiers of a workflow run are derived from the run and job identifiers,
+// so the worker can give the job span to the job steps before the run trace is exported.
+
+// V2WorkflowRunTraceID returns the trace identifier of a run attempt.
+func V2WorkflowRunTraceID(runID string, runAttempt int64) [16]byte {
+	h := fnv.New128a()
+	_, _ = h.Write([]byte(runID + "/" + strconv.FormatInt(runAttempt, 10)))
+	var id [16]byte
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
+// V2WorkflowRunSpanID returns a span identifier for the given path of identifiers.
+func V2WorkflowRunSpanID(path ...string) [8]byte {
+	h := fnv.New64a()
+	for _, p := range path {
+		_, _ = h.Write([]byte(p + "/"))
+	}
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], h.Sum64())
+	return id
+}
+
+// V2WorkflowRunJobSpanID returns the span identifier of a run job.
+func V2WorkflowRunJobSpanID(runJobID string) [8]byte {
+	return V2WorkflowRunSpanID(runJobID)
+}
+
+// V2WorkflowRunJobBuildingSpanID returns the span identifier of the building phase of a run job, parent of the steps spans.
+func V2WorkflowRunJobBuildingSpanID(runJobID string) [8]byte {
+	return V2WorkflowRunSpanID(runJobID, "building")
+}
+
+// V2WorkflowRunJobStepSpanID returns the span identifier of a step.
+func V2WorkflowRunJobStepSpanID(runJobID, stepID string) [8]byte {
+	return V2WorkflowRunSpanID(runJobID, "step", stepID)
+}
+
+// V2WorkflowRunJobTraceParent returns the W3C traceparent of the building phase of a run job.
+func V2WorkflowRunJobTraceParent(rj V2WorkflowRunJob) string {
+	traceID := V2WorkflowRunTraceID(rj.WorkflowRunID, rj.RunAttempt)
+	spanID := V2WorkflowRunJobBuildingSpanID(rj.ID)
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(traceID[:]), hex.EncodeToString(spanID[:]))
+}
@@ -0,0 +1,30 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2WorkflowRunJobTraceParent(t *testing.T) {
+	rj := V2WorkflowRunJob{ID: UUID(), WorkflowRunID: UUID(), RunAttempt: 1}
+
+	tp := V2WorkflowRunJobTraceParent(rj)
+	parts := strings.Split(tp, "-")
+	require.Len(t, parts, 4)
+	require.Equal(t, "00", parts[0])
+	require.Equal(t, "01", parts[3])
+
+	traceID := V2WorkflowRunTraceID(rj.WorkflowRunID, rj.RunAttempt)
+	spanID := V2WorkflowRunJobBuildingSpanID(rj.ID)
+	require.Equal(t, hex.EncodeToString(traceID[:]), parts[1])
+	require.Equal(t, hex.EncodeToString(spanID[:]), parts[2])
+
+	// Identifiers are stable and depend on the run attempt
+	require.Equal(t, tp, V2WorkflowRunJobTraceParent(rj))
+	rj.RunAttempt = 2
+	require.NotEqual(t, tp, V2WorkflowRunJobTraceParent(rj))
+	require.NotEqual(t, V2WorkflowRunJobSpanID(rj.ID), V2WorkflowRunJobBuildingSpanID(rj.ID))
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/rockbears/yaml"
@@ -184,3 +185,91 @@ func TestAncestor(t *testing.T) {
 	require.True(t, slices.Contains(parents, "job333"))
 	require.Len(t, parents, 9)
 }
+
+func TestUnmarshalV2JobRunsOnLabels(t *testing.T) {
+	src := `jobs:
+  myFirstJob:
+    runs-on:
+      labels:
+        - macos
+        - gpu
+    steps:
+      - run: 'echo "Workflow: ${{cds.workflow}}"'
+name: MyDistantWorkflow
+`
+	var w V2Workflow
+	require.NoError(t, yaml.Unmarshal([]byte(src), &w))
+
+	require.Equal(t, "", w.Jobs["myFirstJob"].RunsOn.Model)
+	require.Equal(t, []string{"macos", "gpu"}, w.Jobs["myFirstJob"].RunsOn.Labels)
+
+	bts, err := yaml.Marshal(w)
+	require.NoError(t, err)
+
+	var w2 V2Workflow
+	require.NoError(t, yaml.Unmarshal(bts, &w2))
+	require.Equal(t, w.Jobs["myFirstJob"].RunsOn, w2.Jobs["myFirstJob"].RunsOn)
+}
+
+func TestUnmarshalV2JobStrategy(t *testing.T) {
+	src := `jobs:
+  build:
+    runs-on: docker-debian
+    steps:
+      - run: echo ${{ matrix.service.path }}
+    strategy:
+      matrix:
+        service:
+          - name: api
+            path: ./api
+            port: 8080
+          - name: ui
+            path: ./ui
+            port: 80
+  deploy:
+    runs-on: docker-debian
+    steps:
+      - run: echo ${{ matrix.env }}
+    strategy:
+      matrix: ${{ fromJSON(jobs.detect.outputs.matrix) }}
+name: MyWorkflow
+`
+	var w V2Workflow
+	require.NoError(t, yaml.Unmarshal([]byte(src), &w))
+
+	require.True(t, w.Jobs["build"].Strategy.HasMatrix())
+	require.Len(t, w.Jobs["build"].Strategy.Matrix["service"], 2)
+	require.True(t, w.Jobs["deploy"].Strategy.HasMatrix())
+	require.Equal(t, "${{ fromJSON(jobs.detect.outputs.matrix) }}", w.Jobs["deploy"].Strategy.MatrixExpression)
+	require.Empty(t, w.Jobs["deploy"].Strategy.Matrix)
+
+	bts, err := yaml.Marshal(w)
+	require.NoError(t, err)
+	require.Equal(t, src, string(bts))
+}
+
+func TestV2WorkflowLintEnvironmentConcurrency(t *testing.T) {
+	src := `name: my-workflow
+jobs:
+  deploy:
+    runs-on: docker-debian
+    environment: production
+    concurrency: deploy
+    steps:
+      - run: echo deploy
+`
+	var w V2Workflow
+	require.NoError(t, yaml.Unmarshal([]byte(src), &w))
+
+	errs := w.Lint()
+	require.True(t, slices.ContainsFunc(errs, func(err error) bool {
+		return strings.Contains(err.Error(), "concurrency is not allowed on a job deploying on an environment")
+	}), "%v", errs)
+
+	job := w.Jobs["deploy"]
+	job.Concurrency = ""
+	w.Jobs["deploy"] = job
+	for _, err := range w.Lint() {
+		require.NotContains(t, err.Error(), "environment")
+	}
+}
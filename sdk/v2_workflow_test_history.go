@@ -0,0 +1,297 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	V2WorkflowTestCaseStatusPassed  = "passed"
+	V2WorkflowTestCaseStatusFailed  = "failed"
+	V2WorkflowTestCaseStatusSkipped = "skipped"
+
+	v2WorkflowTestCaseMessageMaxSize = 1024
+
+	// A test is flaky when at least this rate of its consecutive executions on a ref changed outcome
+	V2WorkflowTestFlakyFlipRate = 0.3
+)
+
+// V2WorkflowTestCase is the outcome of a single test case in a workflow run, extracted from a junit run result.
+type V2WorkflowTestCase struct {
+	ID            int64     `json:"id" db:"id" cli:"-"`
+	ProjectKey    string    `json:"project_key" db:"project_key" cli:"-"`
+	VCSServer     string    `json:"vcs_server" db:"vcs_server" cli:"-"`
+	Repository    string    `json:"repository" db:"repository" cli:"-"`
+	WorkflowName  string    `json:"workflow_name" db:"workflow_name" cli:"-"`
+	Ref           string    `json:"ref" db:"ref" cli:"ref"`
+	Sha           string    `json:"sha" db:"sha" cli:"sha"`
+	WorkflowRunID string    `json:"workflow_run_id" db:"workflow_run_id" cli:"run_id"`
+	RunNumber     int64     `json:"run_number" db:"run_number" cli:"run_number"`
+	RunAttempt    int64     `json:"run_attempt" db:"run_attempt" cli:"run_attempt"`
+	RunJobID      string    `json:"run_job_id" db:"run_job_id" cli:"-"`
+	JobID         string    `json:"job_id" db:"job_id" cli:"job"`
+	Suite         string    `json:"suite" db:"suite" cli:"suite"`
+	Name          string    `json:"name" db:"name" cli:"name"`
+	Status        string    `json:"status" db:"status" cli:"status"`
+	Duration      float64   `json:"duration" db:"duration" cli:"duration"`
+	Message       string    `json:"message,omitempty" db:"message" cli:"-"`
+	Created       time.Time `json:"created" db:"created" cli:"created"`
+}
+
+// NewV2WorkflowTestCases flattens junit test suites into test cases for the given run job.
+func NewV2WorkflowTestCases(run V2WorkflowRun, runJob V2WorkflowRunJob, suites JUnitTestsSuites) []V2WorkflowTestCase {
+	var cases []V2WorkflowTestCase
+	for _, ts := range suites.TestSuites {
+		for _, tc := range ts.TestCases {
+			c := V2WorkflowTestCase{
+				ProjectKey:    run.ProjectKey,
+				VCSServer:     run.VCSServer,
+				Repository:    run.Repository,
+				WorkflowName:  run.WorkflowName,
+				Ref:           run.Contexts.Git.Ref,
+				Sha:           run.Contexts.Git.Sha,
+				WorkflowRunID: run.ID,
+				RunNumber:     run.RunNumber,
+				RunAttempt:    runJob.RunAttempt,
+				RunJobID:      runJob.ID,
+				JobID:         runJob.JobID,
+				Suite:         ts.Name,
+				Name:          tc.Name,
+				Status:        V2WorkflowTestCaseStatusPassed,
+			}
+			c.Duration, _ = strconv.ParseFloat(tc.Time, 64)
+			switch {
+			case len(tc.Errors) > 0:
+				c.Status = V2WorkflowTestCaseStatusFailed
+				c.Message = tc.Errors[0].Message
+			case len(tc.Failures) > 0:
+				c.Status = V2WorkflowTestCaseStatusFailed
+				c.Message = tc.Failures[0].Message
+			case len(tc.Skipped) > 0:
+				c.Status = V2WorkflowTestCaseStatusSkipped
+				c.Message = tc.Skipped[0].Message
+			}
+			c.Message = truncateTestCaseMessage(c.Message)
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// truncateTestCaseMessage cuts the message to its max size on a rune boundary, an invalid UTF-8 string would be
+// rejected by the database with the other test cases of the run result.
+func truncateTestCaseMessage(msg string) string {
+	if len(msg) > v2WorkflowTestCaseMessageMaxSize {
+		i := v2WorkflowTestCaseMessageMaxSize
+		for i > 0 && !utf8.RuneStart(msg[i]) {
+			i--
+		}
+		msg = msg[:i]
+	}
+	return strings.ToValidUTF8(msg, "")
+}
+
+// V2WorkflowTestQuarantine marks a test of a workflow as known flaky, its failures are not blocking.
+// An empty suite matches the test name in any suite.
+type V2WorkflowTestQuarantine struct {
+	ID           string    `json:"id" db:"id" cli:"id,key"`
+	ProjectKey   string    `json:"project_key" db:"project_key" cli:"-"`
+	VCSServer    string    `json:"vcs_server" db:"vcs_server" cli:"vcs_server"`
+	Repository   string    `json:"repository" db:"repository" cli:"repository"`
+	WorkflowName string    `json:"workflow_name" db:"workflow_name" cli:"workflow_name"`
+	Suite        string    `json:"suite" db:"suite" cli:"suite"`
+	Name         string    `json:"name" db:"name" cli:"name"`
+	Reason       string    `json:"reason" db:"reason" cli:"reason"`
+	Author       string    `json:"author" db:"author" cli:"author"`
+	Created      time.Time `json:"created" db:"created" cli:"created"`
+}
+
+func (q V2WorkflowTestQuarantine) Check() error {
+	if q.VCSServer == "" || q.Repository == "" || q.WorkflowName == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing workflow on test quarantine")
+	}
+	if q.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing test name on test quarantine")
+	}
+	return nil
+}
+
+func (q V2WorkflowTestQuarantine) Match(suite, name string) bool {
+	return q.Name == name && (q.Suite == "" || q.Suite == suite)
+}
+
+type V2WorkflowTestQuarantines []V2WorkflowTestQuarantine
+
+func (qs V2WorkflowTestQuarantines) IsQuarantined(suite, name string) bool {
+	for _, q := range qs {
+		if q.Match(suite, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures returns the failed test cases of the suites, split between blocking and quarantined ones.
+func (qs V2WorkflowTestQuarantines) Failures(suites JUnitTestsSuites) (blocking []string, quarantined []string) {
+	for _, ts := range suites.TestSuites {
+		for _, tc := range ts.TestCases {
+			if len(tc.Failures) == 0 && len(tc.Errors) == 0 {
+				continue
+			}
+			if qs.IsQuarantined(ts.Name, tc.Name) {
+				quarantined = append(quarantined, ts.Name+"/"+tc.Name)
+			} else {
+				blocking = append(blocking, ts.Name+"/"+tc.Name)
+			}
+		}
+	}
+	return
+}
+
+// V2WorkflowTestHistory is the outcome history of a test case across the runs of a workflow.
+type V2WorkflowTestHistory struct {
+	Suite        string               `json:"suite" cli:"suite"`
+	Name         string               `json:"name" cli:"name"`
+	Executions   int                  `json:"executions" cli:"executions"`
+	Passed       int                  `json:"passed" cli:"passed"`
+	Failed       int                  `json:"failed" cli:"failed"`
+	Skipped      int                  `json:"skipped" cli:"skipped"`
+	Flaky        bool                 `json:"flaky" cli:"flaky"`
+	FlakyScore   float64              `json:"flaky_score" cli:"flaky_score"`
+	FlakyReasons []string             `json:"flaky_reasons,omitempty" cli:"flaky_reasons"`
+	Quarantined  bool                 `json:"quarantined" cli:"quarantined"`
+	History      []V2WorkflowTestCase `json:"history,omitempty" cli:"-"`
+}
+
+// ComputeV2WorkflowTestHistories groups test cases by suite and name, and scores their flakiness.
+// A test is flaky when it both passed and failed on the same commit, or when its outcome flip-flops
+// between consecutive executions on a same ref. Skipped executions are ignored in the scoring.
+func ComputeV2WorkflowTestHistories(cases []V2WorkflowTestCase, quarantines V2WorkflowTestQuarantines) []V2WorkflowTestHistory {
+	type key struct{ suite, name string }
+	byTest := make(map[key][]V2WorkflowTestCase)
+	var keys []key
+	for _, c := range cases {
+		k := key{c.Suite, c.Name}
+		if _, has := byTest[k]; !has {
+			keys = append(keys, k)
+		}
+		byTest[k] = append(byTest[k], c)
+	}
+
+	histories := make([]V2WorkflowTestHistory, 0, len(keys))
+	for _, k := range keys {
+		executions := byTest[k]
+		sort.SliceStable(executions, func(i, j int) bool { return executions[i].Created.Before(executions[j].Created) })
+
+		h := V2WorkflowTestHistory{
+			Suite:       k.suite,
+			Name:        k.name,
+			Executions:  len(executions),
+			Quarantined: quarantines.IsQuarantined(k.suite, k.name),
+			History:     executions,
+		}
+
+		outcomesBySha := make(map[string]map[string]struct{})
+		lastByRef := make(map[string]string)
+		var flips, transitions int
+		for _, e := range executions {
+			switch e.Status {
+			case V2WorkflowTestCaseStatusPassed:
+				h.Passed++
+			case V2WorkflowTestCaseStatusFailed:
+				h.Failed++
+			default:
+				h.Skipped++
+				continue
+			}
+			if _, has := outcomesBySha[e.Sha]; !has {
+				outcomesBySha[e.Sha] = make(map[string]struct{})
+			}
+			outcomesBySha[e.Sha][e.Status] = struct{}{}
+			if last, has := lastByRef[e.Ref]; has {
+				transitions++
+				if last != e.Status {
+					flips++
+				}
+			}
+			lastByRef[e.Ref] = e.Status
+		}
+
+		var mixedShas int
+		for _, outcomes := range outcomesBySha {
+			if len(outcomes) > 1 {
+				mixedShas++
+			}
+		}
+		if mixedShas > 0 {
+			h.FlakyReasons = append(h.FlakyReasons, fmt.Sprintf("passed and failed on %d same commit(s)", mixedShas))
+		}
+		if transitions > 0 {
+			h.FlakyScore = roundV2WorkflowRunStats(float64(flips) / float64(transitions))
+			if flips > 1 && h.FlakyScore >= V2WorkflowTestFlakyFlipRate {
+				h.FlakyReasons = append(h.FlakyReasons, fmt.Sprintf("outcome changed %d time(s) in %d consecutive executions", flips, transitions+1))
+			}
+		}
+		if mixedShas > 0 && h.FlakyScore == 0 {
+			h.FlakyScore = 1
+		}
+		h.Flaky = len(h.FlakyReasons) > 0
+		histories = append(histories, h)
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		if histories[i].FlakyScore != histories[j].FlakyScore {
+			return histories[i].FlakyScore > histories[j].FlakyScore
+		}
+		return histories[i].Suite+"/"+histories[i].Name < histories[j].Suite+"/"+histories[j].Name
+	})
+	return histories
+}
+
+// V2WorkflowRunTestsReport summarizes the test cases of a run, it is exposed as "tests" in pull request comments.
+type V2WorkflowRunTestsReport struct {
+	Total       int      `json:"total"`
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	Skipped     int      `json:"skipped"`
+	Failures    []string `json:"failures"`
+	Quarantined []string `json:"quarantined"`
+}
+
+func NewV2WorkflowRunTestsReport(cases []V2WorkflowTestCase, quarantines V2WorkflowTestQuarantines) V2WorkflowRunTestsReport {
+	r := V2WorkflowRunTestsReport{
+		Failures:    []string{},
+		Quarantined: []string{},
+	}
+	for _, c := range cases {
+		r.Total++
+		switch c.Status {
+		case V2WorkflowTestCaseStatusPassed:
+			r.Passed++
+		case V2WorkflowTestCaseStatusSkipped:
+			r.Skipped++
+		case V2WorkflowTestCaseStatusFailed:
+			r.Failed++
+			if quarantines.IsQuarantined(c.Suite, c.Name) {
+				r.Quarantined = append(r.Quarantined, c.Suite+"/"+c.Name)
+			} else {
+				r.Failures = append(r.Failures, c.Suite+"/"+c.Name)
+			}
+		}
+	}
+	return r
+}
+
+// ParseV2WorkflowFullName splits a "<vcs>/<repository>/<workflow>" name.
+func ParseV2WorkflowFullName(s string) (string, string, string, error) {
+	first := strings.Index(s, "/")
+	last := strings.LastIndex(s, "/")
+	if first <= 0 || last <= first+1 || last == len(s)-1 {
+		return "", "", "", NewErrorFrom(ErrWrongRequest, "invalid workflow %q, expected <vcs>/<repository>/<workflow>", s)
+	}
+	return s[:first], s[first+1 : last], s[last+1:], nil
+}
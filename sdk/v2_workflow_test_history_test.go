@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewV2WorkflowTestCases(t *testing.T) {
+	run := V2WorkflowRun{ID: "run", ProjectKey: "PROJ", VCSServer: "github", Repository: "my/repo", WorkflowName: "build", RunNumber: 3}
+	run.Contexts.Git.Ref = "refs/heads/main"
+	run.Contexts.Git.Sha = "aaa"
+	runJob := V2WorkflowRunJob{ID: "runjob", JobID: "test", RunAttempt: 1}
+
+	cases := NewV2WorkflowTestCases(run, runJob, JUnitTestsSuites{TestSuites: []JUnitTestSuite{{
+		Name: "suite",
+		TestCases: []JUnitTestCase{
+			{Name: "ok", Time: "1.5"},
+			{Name: "ko", Failures: []JUnitTestFailure{{Message: "boom"}}},
+			{Name: "skip", Skipped: []JUnitTestSkipped{{}}},
+		},
+	}}})
+	require.Len(t, cases, 3)
+	require.Equal(t, V2WorkflowTestCaseStatusPassed, cases[0].Status)
+	require.Equal(t, 1.5, cases[0].Duration)
+	require.Equal(t, "refs/heads/main", cases[0].Ref)
+	require.Equal(t, "runjob", cases[0].RunJobID)
+	require.Equal(t, V2WorkflowTestCaseStatusFailed, cases[1].Status)
+	require.Equal(t, "boom", cases[1].Message)
+	require.Equal(t, V2WorkflowTestCaseStatusSkipped, cases[2].Status)
+}
+
+func TestNewV2WorkflowTestCasesMessage(t *testing.T) {
+	// The 3 bytes character starts at byte 1023 and straddles the max size
+	long := strings.Repeat("a", v2WorkflowTestCaseMessageMaxSize-1) + "€" + "bbb"
+	cases := NewV2WorkflowTestCases(V2WorkflowRun{}, V2WorkflowRunJob{}, JUnitTestsSuites{TestSuites: []JUnitTestSuite{{
+		Name: "suite",
+		TestCases: []JUnitTestCase{
+			{Name: "long", Failures: []JUnitTestFailure{{Message: long}}},
+			{Name: "invalid", Errors: []JUnitTestFailure{{Message: "bad \xff byte"}}},
+		},
+	}}})
+	require.Len(t, cases, 2)
+	require.True(t, utf8.ValidString(cases[0].Message))
+	require.Equal(t, strings.Repeat("a", v2WorkflowTestCaseMessageMaxSize-1), cases[0].Message)
+	require.Equal(t, "bad  byte", cases[1].Message)
+}
+
+func TestComputeV2WorkflowTestHistories(t *testing.T) {
+	now := time.Now()
+	c := func(i int, name, ref, sha, status string) V2WorkflowTestCase {
+		return V2WorkflowTestCase{Suite: "suite", Name: name, Ref: ref, Sha: sha, Status: status, Created: now.Add(time.Duration(i) * time.Minute)}
+	}
+	cases := []V2WorkflowTestCase{
+		// retried on the same sha
+		c(0, "retried", "main", "a", V2WorkflowTestCaseStatusFailed),
+		c(1, "retried", "main", "a", V2WorkflowTestCaseStatusPassed),
+		// flip-flopping across commits
+		c(0, "flipflop", "main", "a", V2WorkflowTestCaseStatusPassed),
+		c(1, "flipflop", "main", "b", V2WorkflowTestCaseStatusFailed),
+		c(2, "flipflop", "main", "c", V2WorkflowTestCaseStatusPassed),
+		c(3, "flipflop", "main", "d", V2WorkflowTestCaseStatusFailed),
+		// broken then fixed
+		c(0, "fixed", "main", "a", V2WorkflowTestCaseStatusFailed),
+		c(1, "fixed", "main", "b", V2WorkflowTestCaseStatusFailed),
+		c(2, "fixed", "main", "c", V2WorkflowTestCaseStatusPassed),
+		c(3, "fixed", "main", "d", V2WorkflowTestCaseStatusPassed),
+		c(4, "fixed", "main", "e", V2WorkflowTestCaseStatusSkipped),
+	}
+
+	histories := ComputeV2WorkflowTestHistories(cases, V2WorkflowTestQuarantines{{Name: "flipflop"}})
+	require.Len(t, histories, 3)
+
+	require.Equal(t, "flipflop", histories[0].Name)
+	require.True(t, histories[0].Flaky)
+	require.True(t, histories[0].Quarantined)
+	require.Equal(t, 1.0, histories[0].FlakyScore)
+
+	require.Equal(t, "retried", histories[1].Name)
+	require.True(t, histories[1].Flaky)
+	require.Equal(t, []string{"passed and failed on 1 same commit(s)"}, histories[1].FlakyReasons)
+
+	require.Equal(t, "fixed", histories[2].Name)
+	require.False(t, histories[2].Flaky)
+	require.False(t, histories[2].Quarantined)
+	require.Equal(t, 0.33, histories[2].FlakyScore)
+	require.Equal(t, 1, histories[2].Skipped)
+}
+
+func TestV2WorkflowTestQuarantinesFailures(t *testing.T) {
+	qs := V2WorkflowTestQuarantines{{Suite: "s1", Name: "flaky"}, {Name: "any"}}
+	blocking, quarantined := qs.Failures(JUnitTestsSuites{TestSuites: []JUnitTestSuite{
+		{Name: "s1", TestCases: []JUnitTestCase{
+			{Name: "flaky", Failures: []JUnitTestFailure{{}}},
+			{Name: "any", Errors: []JUnitTestFailure{{}}},
+			{Name: "ok"},
+		}},
+		{Name: "s2", TestCases: []JUnitTestCase{
+			{Name: "flaky", Failures: []JUnitTestFailure{{}}},
+		}},
+	}})
+	require.Equal(t, []string{"s2/flaky"}, blocking)
+	require.Equal(t, []string{"s1/flaky", "s1/any"}, quarantined)
+
+	report := NewV2WorkflowRunTestsReport([]V2WorkflowTestCase{
+		{Suite: "s1", Name: "flaky", Status: V2WorkflowTestCaseStatusFailed},
+		{Suite: "s2", Name: "flaky", Status: V2WorkflowTestCaseStatusFailed},
+		{Suite: "s1", Name: "ok", Status: V2WorkflowTestCaseStatusPassed},
+	}, qs)
+	require.Equal(t, 3, report.Total)
+	require.Equal(t, 2, report.Failed)
+	require.Equal(t, []string{"s2/flaky"}, report.Failures)
+	require.Equal(t, []string{"s1/flaky"}, report.Quarantined)
+}
+
+func TestParseV2WorkflowFullName(t *testing.T) {
+	vcs, repo, wf, err := ParseV2WorkflowFullName("github/my/repo/build")
+	require.NoError(t, err)
+	require.Equal(t, "github", vcs)
+	require.Equal(t, "my/repo", repo)
+	require.Equal(t, "build", wf)
+
+	for _, s := range []string{"", "github", "github/build", "/my/repo/build", "github/my/repo/"} {
+		_, _, _, err := ParseV2WorkflowFullName(s)
+		require.Error(t, err, s)
+	}
+}
@@ -58,6 +58,7 @@ type WebsocketHatcheryFilter struct {
 	ModelType        string   `json:"model_type"`
 	DeprecatedOSArch string   `json:"osarch"`
 	OSArchSlice      []string `json:"osarch_slice"`
+	ProjectKeys      []string `json:"project_keys,omitempty"`
 }
 
 // Key generates the unique key associated to given filter.
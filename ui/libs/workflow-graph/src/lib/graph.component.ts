@@ -21,7 +21,7 @@ import { GraphJobNodeComponent } from './node/job-node.component';
 import { GraphNode, GraphNodeType, NavigationGraph } from './graph.model';
 import { GraphDirection, NodeMouseEvent, SelectionMode, WorkflowV2Graph } from './graph.lib';
 import { load, LoadOptions } from 'js-yaml';
-import { V2Workflow, V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunJobStatusIsActive } from './v2.workflow.run.model';
+import { matrixKey, V2Workflow, V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunJobStatusIsActive } from './v2.workflow.run.model';
 import { GraphMatrixNodeComponent } from './node/matrix-node.component';
 import { GraphNodeAction } from './node/model';
 
@@ -359,10 +359,10 @@ export class GraphComponent implements AfterViewInit, OnChanges, OnDestroy {
             if (!navigationKey.startsWith(`${baseKey}-`)) {
                 return null;
             }
-            const matrixKey = navigationKey.substring(baseKey.length + 1);
-            let description = `Job ${n.name} ${matrixKey}`;
+            const matrixKeyFromNav = navigationKey.substring(baseKey.length + 1);
+            let description = `Job ${n.name} ${matrixKeyFromNav}`;
             const run = (n.runs ?? []).find(r =>
-                Object.keys(r.matrix).sort().map(k => `${k}: ${r.matrix[k]}`).join(', ') === matrixKey);
+                matrixKey(r.matrix) === matrixKeyFromNav);
             if (run?.status) {
                 description += `, status ${run.status}`;
             }
@@ -438,8 +438,7 @@ export class GraphComponent implements AfterViewInit, OnChanges, OnDestroy {
                 return null;
             }
             // The matrix node keys its variants this way, and the key is what selection goes by.
-            const matrixKey = Object.keys(run.matrix ?? {}).sort().map(k => `${k}: ${run.matrix[k]}`).join(', ');
-            return { node, matrixKey };
+            return { node, matrixKey: matrixKey(run.matrix) };
         };
 
         for (const node of this.nodes ?? []) {
@@ -589,7 +589,7 @@ export class WorkflowV2Graph<T extends InteractiveNode> {
             case GraphNodeType.Matrix:
                 // Matrix node: 240 px wide, height = 30 per variant row + 10 px gap between rows + 40 header + 40 footer + 20 padding
                 width = 240;
-                const alls = GraphNode.generateMatrixOptions(node.job.strategy.matrix);
+                const alls = GraphNode.generateMatrixOptions(GraphNode.matrixDefinition(node));
                 height = 30 * alls.length + 10 * (alls.length - 1) + 40 + 40 + 20;
                 break;
         }
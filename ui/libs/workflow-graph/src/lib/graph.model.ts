@@ -1,5 +1,5 @@
 import { GraphDirection } from "./graph.lib";
-import { V2Job, V2JobGate, V2WorkflowRunJobEvent } from "./v2.workflow.run.model";
+import { matrixKey, matrixValueLabel, V2Job, V2JobGate, V2WorkflowRunJobEvent } from "./v2.workflow.run.model";
 import { V2WorkflowRunJob } from "./v2.workflow.run.model";
 
 export class StepStatus {
@@ -25,8 +25,22 @@ export class GraphNode {
     runs: Array<V2WorkflowRunJob>;
     event: V2WorkflowRunJobEvent;
 
-    static generateMatrixOptions(matrix: { [key: string]: Array<string> }): Array<Map<string, string>> {
-        const generateMatrix = (matrix: { [key: string]: string[] }, keys: string[], keyIndex: number, current: Map<string, string>, alls: Array<Map<string, string>>) => {
+    /** The matrix of the job: when the workflow gives it by an expression, the values computed for its run jobs. */
+    static matrixDefinition(node: GraphNode): { [key: string]: Array<any> | string } | string {
+        const matrix = node.job?.strategy?.matrix;
+        if (typeof matrix === 'string') {
+            return (node.runs ?? []).find(r => r.job?.strategy?.matrix)?.job.strategy.matrix ?? matrix;
+        }
+        return matrix;
+    }
+
+    static generateMatrixOptions(matrix: { [key: string]: Array<any> | string } | string): Array<Map<string, string>> {
+        let alls = new Array<Map<string, string>>();
+        // A matrix given by an expression is only known once the run jobs are created
+        if (!matrix || typeof matrix === 'string' || Object.keys(matrix).some(k => !Array.isArray(matrix[k]))) {
+            return alls;
+        }
+        const generateMatrix = (matrix: { [key: string]: Array<any> }, keys: string[], keyIndex: number, current: Map<string, string>, alls: Array<Map<string, string>>) => {
             if (current.size == keys.length) {
                 let combi = new Map<string, string>();
                 current.forEach((v, k) => {
@@ -38,13 +52,12 @@ export class GraphNode {
             let key = keys[keyIndex];
             let values = matrix[key];
             values.forEach(v => {
-                current.set(key, v);
+                current.set(key, matrixValueLabel(v));
                 generateMatrix(matrix, keys, keyIndex + 1, current, alls);
                 current.delete(key);
             });
         };
-        let alls = new Array<Map<string, string>>();
-        generateMatrix(matrix, Object.keys(matrix), 0, new Map<string, string>(), alls);
+        generateMatrix(matrix as { [key: string]: Array<any> }, Object.keys(matrix), 0, new Map<string, string>(), alls);
         return alls;
     }
 }
@@ -104,12 +117,12 @@ export class NavigationGraph {
 
                         switch (sub.type) {
                             case GraphNodeType.Matrix:
-                                const alls = GraphNode.generateMatrixOptions(sub.job.strategy.matrix);
+                                const alls = GraphNode.generateMatrixOptions(GraphNode.matrixDefinition(sub));
                                 const keys = alls.map(option => Array.from(option.keys()).sort().map(key => `${key}: ${option.get(key)}`).join(', '));
                                 // Build run job ID mapping for stage-nested matrix variants
                                 const stageMatrixRunMap: { [matrixKey: string]: string } = {};
                                 (sub.runs ?? []).forEach(r => {
-                                    const mk = Object.keys(r.matrix).sort().map(k => `${k}: ${r.matrix[k]}`).join(', ');
+                                    const mk = matrixKey(r.matrix);
                                     stageMatrixRunMap[mk] = r.id;
                                 });
                                 keys.forEach((k, i) => {
@@ -151,12 +164,12 @@ export class NavigationGraph {
                     });
                     break;
                 case GraphNodeType.Matrix:
-                    const alls = GraphNode.generateMatrixOptions(n.job.strategy.matrix);
+                    const alls = GraphNode.generateMatrixOptions(GraphNode.matrixDefinition(n));
                     const keys = alls.map(option => Array.from(option.keys()).sort().map(key => `${key}: ${option.get(key)}`).join(', '));
                     // Build run job ID mapping for top-level matrix variants
                     const matrixRunMap: { [matrixKey: string]: string } = {};
                     (n.runs ?? []).forEach(r => {
-                        const mk = Object.keys(r.matrix).sort().map(k => `${k}: ${r.matrix[k]}`).join(', ');
+                        const mk = matrixKey(r.matrix);
                         matrixRunMap[mk] = r.id;
                     });
                     keys.forEach((k, i) => {
@@ -1,6 +1,6 @@
 import { ChangeDetectionStrategy, ChangeDetectorRef, Component, ElementRef, inject, Input, OnDestroy, OnInit } from '@angular/core';
 import { GraphNode } from '../graph.model'
-import { V2WorkflowRunJobStatus } from '../v2.workflow.run.model';
+import { matrixKey, V2WorkflowRunJobStatus } from '../v2.workflow.run.model';
 import { concatMap, from, interval, Subscription } from 'rxjs';
 import { DurationService } from '../duration.service';
 import { GraphNodeAction } from './model';
@@ -103,16 +103,14 @@ export class GraphMatrixNodeComponent implements OnInit, OnDestroy, InteractiveN
         this.warningSteps = {};
         this.durations = {};
 
-        const alls = GraphNode.generateMatrixOptions(this.node.job.strategy.matrix);
+        const alls = GraphNode.generateMatrixOptions(GraphNode.matrixDefinition(this.node));
         this.keys = alls.map(option => {
             return Array.from(option.keys()).sort().map(key => {
                 return `${key}: ${option.get(key)}`;
             }).join(', ');
         });
         (this.node.runs ?? []).forEach(r => {
-            const key = Object.keys(r.matrix).sort().map(key => {
-                return `${key}: ${r.matrix[key]}`;
-            }).join(', ');
+            const key = matrixKey(r.matrix);
             this.dates[key] = {
                 queued: new Date(r.queued),
                 scheduled: r.scheduled ? new Date(r.scheduled) : null,
@@ -146,9 +144,7 @@ export class GraphMatrixNodeComponent implements OnInit, OnDestroy, InteractiveN
     async refreshDelay() {
         const now = new Date();
         (this.node.runs ?? []).forEach(r => {
-            const key = Object.keys(r.matrix).sort().map(key => {
-                return `${key}: ${r.matrix[key]}`;
-            }).join(', ');
+            const key = matrixKey(r.matrix);
             switch (r.status) {
                 case V2WorkflowRunJobStatus.Waiting:
                 case V2WorkflowRunJobStatus.Scheduling:
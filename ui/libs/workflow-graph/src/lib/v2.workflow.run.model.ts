@@ -170,7 +170,7 @@ export class V2WorkflowRunJob {
     username: string;
     region: string;
     model_type: string;
-    matrix: { [key: string]: string };
+    matrix: { [key: string]: any };
     gate_inputs: { [key: string]: any };
     retry: number;
 
@@ -255,7 +255,27 @@ export class ActionStep {
 }
 
 export class V2JobStrategy {
-    matrix: { [key: string]: Array<string> };
+    // Values of the matrix, or an expression returning the whole matrix
+    matrix: { [key: string]: Array<any> | string } | string;
+}
+
+/** Short label of a matrix value: the value itself, the name of an object if it has one, its JSON otherwise. */
+export function matrixValueLabel(v: any): string {
+    if (v === null || v === undefined) {
+        return '';
+    }
+    if (typeof v === 'string') {
+        return v;
+    }
+    if (typeof v === 'object' && !Array.isArray(v) && typeof v.name === 'string' && v.name !== '') {
+        return v.name;
+    }
+    return JSON.stringify(v);
+}
+
+/** Readable name of a matrix permutation: `os: linux, service: api`. */
+export function matrixKey(matrix: { [key: string]: any }, separator: string = ': ', join: string = ', '): string {
+    return Object.keys(matrix ?? {}).sort().map(k => `${k}${separator}${matrixValueLabel(matrix[k])}`).join(join);
 }
 
 export class StepStatus {
@@ -274,6 +294,26 @@ export class WorkflowRunInfo {
     message: string;
 }
 
+export class V2WorkflowRunJobAnnotation {
+    file: string;
+    line: number;
+    level: string;
+    title: string;
+    message: string;
+}
+
+export class V2WorkflowRunJobSummary {
+    id: string;
+    workflow_run_id: string;
+    workflow_run_job_id: string;
+    job_id: string;
+    step_name: string;
+    run_attempt: number;
+    summary: string;
+    annotations: Array<V2WorkflowRunJobAnnotation>;
+    created: string;
+}
+
 export class WorkflowRunResult {
     id: string;
     /** The run job that created the result, which is what puts it on the timeline of that job. */
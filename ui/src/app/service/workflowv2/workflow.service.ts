@@ -1,7 +1,7 @@
 import { inject, Injectable } from "@angular/core";
 import { HttpClient, HttpHeaders, HttpParams } from "@angular/common/http";
 import { Observable } from "rxjs";
-import { V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunTriggerJobsRequest, V2WorkflowRunManualRequest, V2WorkflowRunManualResponse, WorkflowRunInfo, WorkflowRunResult } from "../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
+import { V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunTriggerJobsRequest, V2WorkflowRunManualRequest, V2WorkflowRunManualResponse, V2WorkflowRunJobSummary, WorkflowRunInfo, WorkflowRunResult } from "../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
 import { CDNLogLink, CDNLogLinks } from "app/model/cdn.model";
 
 /**
@@ -58,6 +58,14 @@ export class V2WorkflowRunService {
         return this._http.get<Array<WorkflowRunInfo>>(`/v2/project/${projKey}/run/${workflowRunID}/infos`);
     }
 
+    getSummaries(projKey: string, workflowRunID: string, attempt: number = null): Observable<Array<V2WorkflowRunJobSummary>> {
+        let params = new HttpParams();
+        if (attempt) {
+            params = params.append('attempt', attempt);
+        }
+        return this._http.get<Array<V2WorkflowRunJobSummary>>(`/v2/project/${projKey}/run/${workflowRunID}/summary`, { params });
+    }
+
     getRunJobInfos(r: V2WorkflowRun, jobRunID: string): Observable<Array<WorkflowRunInfo>> {
         return this._http.get<Array<WorkflowRunInfo>>(`/v2/project/${r.project_key}/run/${r.id}/job/${jobRunID}/infos`);
     }
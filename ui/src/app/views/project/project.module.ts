@@ -50,6 +50,7 @@ import { RunJobComponent } from '../projectv2/run/run-job.component';
 import { RunResultComponent } from '../projectv2/run/run-result.component';
 import { RunResultsComponent } from '../projectv2/run/run-results.component';
 import { RunSourcesComponent } from '../projectv2/run/run-sources.component';
+import { RunSummaryComponent } from '../projectv2/run/run-summary.component';
 import { RunTestComponent } from '../projectv2/run/run-test.component';
 import { RunTestsComponent } from '../projectv2/run/run-tests.component';
 import { RunTimelineComponent } from '../projectv2/run/run-timeline.component';
@@ -104,6 +105,7 @@ import { TimelineModule } from '../../../../libs/timeline/src/public-api';
         RunResultComponent,
         RunResultsComponent,
         RunSourcesComponent,
+        RunSummaryComponent,
         RunTestComponent,
         RunTestsComponent,
         RunTimelineComponent,
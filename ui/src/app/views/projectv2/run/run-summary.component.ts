@@ -0,0 +1,14 @@
+import { ChangeDetectionStrategy, Component, EventEmitter, Input, Output } from "@angular/core";
+import { V2WorkflowRunJobSummary } from "../../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
+
+@Component({
+	standalone: false,
+	selector: 'app-run-summary',
+	templateUrl: './run-summary.html',
+	styleUrls: ['./run-summary.scss'],
+	changeDetection: ChangeDetectionStrategy.OnPush
+})
+export class RunSummaryComponent {
+	@Input() summaries: Array<V2WorkflowRunJobSummary>;
+	@Output() onSelectJob = new EventEmitter<string>();
+}
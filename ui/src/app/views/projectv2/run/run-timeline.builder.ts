@@ -1,5 +1,5 @@
 import { TimelineData, TimelineDetail, TimelineLane, TimelineMarker, TimelineSection, TimelineSegment } from "../../../../../libs/timeline/src/public-api";
-import { V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunJobStatus, WorkflowRunResult, WorkflowRunResultType, WorkflowRunInfo } from "../../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
+import { matrixKey, V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunJobStatus, WorkflowRunResult, WorkflowRunResultType, WorkflowRunInfo } from "../../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
 
 /** What a lane or a marker of the timeline stands for, so that activating it opens the right panel. */
 export interface RunTimelineTarget {
@@ -33,8 +33,7 @@ function toMs(value: string): number {
 
 /** `os=linux, go=1.22` — what tells two run jobs of the same matrixed job apart. */
 function variant(job: V2WorkflowRunJob): string {
-    const keys = Object.keys(job.matrix ?? {}).sort();
-    return keys.length === 0 ? '' : keys.map(k => `${k}=${job.matrix[k]}`).join(', ');
+    return matrixKey(job.matrix, '=');
 }
 
 function jobLabel(job: V2WorkflowRunJob): string {
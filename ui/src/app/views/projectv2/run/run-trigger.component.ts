@@ -1,7 +1,7 @@
 import { ChangeDetectionStrategy, ChangeDetectorRef, Component, inject, Input, OnInit } from "@angular/core";
 import { FormBuilder, FormControl, FormGroup } from "@angular/forms";
 import { AutoUnsubscribe } from "app/shared/decorator/autoUnsubscribe";
-import { areAllJobVariantsSelected, V2Job, V2JobGate, V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunTriggerJobsRequest } from "../../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
+import { areAllJobVariantsSelected, matrixKey, V2Job, V2JobGate, V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunTriggerJobsRequest } from "../../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
 import { NzDrawerRef } from "ng-zorro-antd/drawer";
 import { NzMessageService } from "ng-zorro-antd/message";
 import { ErrorUtils } from "app/shared/error.utils";
@@ -60,7 +60,7 @@ export class RunTriggerComponent implements OnInit {
             const runJob = this.runJobs.find(j => j.id === jobRunID);
             const isPartialMatrixSelection = runJob.matrix && !areAllJobVariantsSelected(runJob.job_id, this.jobRunIDs, this.runJobs);
             if (isPartialMatrixSelection) {
-                const matrixLabel = Object.entries(runJob.matrix).map(([k, v]) => `${k}:${v}`).join(', ');
+                const matrixLabel = matrixKey(runJob.matrix, ':');
                 this.allJobLabels.push(`${runJob.job_id} (${matrixLabel})`);
                 continue;
             }
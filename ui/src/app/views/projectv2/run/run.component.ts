@@ -11,7 +11,7 @@ import { concatMap, map, switchMap } from "rxjs/operators";
 import { ActivatedRoute, Router } from "@angular/router";
 import { NzMessageService } from "ng-zorro-antd/message";
 import { NavigationState } from "app/store/navigation.state";
-import { V2JobGate, V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunJobStatus, V2WorkflowRunJobStatusIsFailed, V2WorkflowRunStatus, V2WorkflowRunStatusIsTerminated, WorkflowRunInfo, WorkflowRunResult, WorkflowRunResultType, areAllJobVariantsSelected, groupRunJobSelectionsByJobId } from "../../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
+import { V2JobGate, V2WorkflowRun, V2WorkflowRunJob, V2WorkflowRunJobStatus, V2WorkflowRunJobStatusIsFailed, V2WorkflowRunJobSummary, V2WorkflowRunStatus, V2WorkflowRunStatusIsTerminated, WorkflowRunInfo, WorkflowRunResult, WorkflowRunResultType, areAllJobVariantsSelected, groupRunJobSelectionsByJobId } from "../../../../../libs/workflow-graph/src/lib/v2.workflow.run.model";
 import { RunTriggerComponent } from "./run-trigger.component";
 import { RouterService } from "app/service/services.module";
 import { ErrorUtils } from "app/shared/error.utils";
@@ -82,6 +82,7 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
     workflowGraph: any;
     selectedRunAttempt: number;
     results: Array<WorkflowRunResult>;
+    summaries: Array<V2WorkflowRunJobSummary>;
     tests: Tests;
     projectKey: string;
     workflowRunIsTerminated: boolean = false;
@@ -230,6 +231,9 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
             title: 'Tests',
             key: 'tests',
             template: this.tabTestsTemplate
+        }, <Tab>{
+            title: 'Summary',
+            key: 'summary'
         }];
         this._cd.markForCheck();
     }
@@ -447,7 +451,8 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
         if (a.job_id !== b.job_id) {
             return false;
         }
-        const variant = (matrix: { [key: string]: string }) => Object.keys(matrix ?? {}).sort().map(k => `${k}=${matrix[k]}`).join(',');
+        // Structured values are compared whole, not by their label
+        const variant = (matrix: { [key: string]: any }) => Object.keys(matrix ?? {}).sort().map(k => `${k}=${JSON.stringify(matrix[k])}`).join(',');
         return variant(a.matrix) === variant(b.matrix);
     }
 
@@ -540,11 +545,12 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
         // carries: they are read at the same time as the run rather than one after the other, so the
         // whole view is drawn once, complete, after a single round trip.
         const sequence = this.eventSequence;
-        const [run, jobs, results, infos] = await Promise.all([
+        const [run, jobs, results, infos, summaries] = await Promise.all([
             this.fetchRun(workflowRunID),
             this.fetchJobs(workflowRunID, runAttempt),
             this.fetchResults(workflowRunID, runAttempt),
-            this.fetchRunInfos(workflowRunID)
+            this.fetchRunInfos(workflowRunID),
+            this.fetchSummaries(workflowRunID, runAttempt)
         ]);
 
         // Another run was asked for while this one was being read: it is the one the user is waiting
@@ -579,6 +585,7 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
         this.applyJobs(jobs ?? []);
         this.applyResults(results ?? []);
         this.applyRunInfos(infos ?? []);
+        this.summaries = summaries ?? [];
 
         await this.refreshPanel();
         this._cd.markForCheck();
@@ -674,6 +681,15 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
         }
     }
 
+    private async fetchSummaries(workflowRunID: string, attempt: number): Promise<Array<V2WorkflowRunJobSummary>> {
+        try {
+            return await lastValueFrom(this._workflowService.getSummaries(this.projectKey, workflowRunID, attempt));
+        } catch (e) {
+            this._messageService.error(`Unable to get summaries: ${ErrorUtils.print(e)}`, { nzDuration: 2000 });
+            return null;
+        }
+    }
+
     private applyRun(run: V2WorkflowRun): void {
         this.workflowRun = run;
         this.workflowRunIsTerminated = V2WorkflowRunStatusIsTerminated(run.status);
@@ -718,10 +734,11 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
         const sequence = this.eventSequence;
         const runID = this.workflowRun.id;
 
-        const [jobs, results, infos] = await Promise.all([
+        const [jobs, results, infos, summaries] = await Promise.all([
             this.fetchJobs(runID, this.selectedRunAttempt),
             this.fetchResults(runID, this.selectedRunAttempt),
-            this.fetchRunInfos(runID)
+            this.fetchRunInfos(runID),
+            this.fetchSummaries(runID, this.selectedRunAttempt)
         ]);
 
         if (this.isStale(runID)) {
@@ -731,6 +748,7 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
         this.applyJobs(jobs);
         this.applyResults(results);
         this.applyRunInfos(infos);
+        this.summaries = summaries ?? this.summaries;
 
         await this.refreshPanel();
 
@@ -763,11 +781,12 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
 
         const sequence = this.eventSequence;
         const runID = this.workflowRun.id;
-        const [run, jobs, results, infos] = await Promise.all([
+        const [run, jobs, results, infos, summaries] = await Promise.all([
             this.fetchRun(runID),
             this.fetchJobs(runID, this.selectedRunAttempt),
             this.fetchResults(runID, this.selectedRunAttempt),
-            this.fetchRunInfos(runID)
+            this.fetchRunInfos(runID),
+            this.fetchSummaries(runID, this.selectedRunAttempt)
         ]);
 
         if (this.isStale(runID)) {
@@ -785,6 +804,7 @@ export class ProjectV2RunComponent implements AfterViewInit, OnDestroy {
         this.applyJobs(jobs);
         this.applyResults(results);
         this.applyRunInfos(infos);
+        this.summaries = summaries ?? this.summaries;
 
         await this.refreshPanel();
 